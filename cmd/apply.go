@@ -0,0 +1,175 @@
+/*
+* Copyright © 2025 Carlos Mendez <carlos@hadaelectronics.com> | https://cjairm.me/
+ */
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/cjairm/devgita/internal/apps/fonts"
+	"github.com/cjairm/devgita/internal/commands"
+	"github.com/cjairm/devgita/internal/config"
+	"github.com/cjairm/devgita/internal/manifest"
+	"github.com/cjairm/devgita/internal/tooling/aitools"
+	"github.com/cjairm/devgita/internal/tooling/databases"
+	"github.com/cjairm/devgita/internal/tooling/desktop"
+	"github.com/cjairm/devgita/internal/tooling/languages"
+	"github.com/cjairm/devgita/internal/tooling/terminal"
+	"github.com/cjairm/devgita/pkg/constants"
+	"github.com/cjairm/devgita/pkg/logger"
+	"github.com/cjairm/devgita/pkg/utils"
+	"github.com/spf13/cobra"
+)
+
+var applyFile string
+
+var applyCmd = &cobra.Command{
+	Use:   "apply",
+	Short: "Set up this machine from a devgita.yaml manifest",
+	Long: `Installs and configures everything declared in a devgita.yaml manifest,
+without any interactive prompts.
+
+The manifest is validated up front against the app registry and the
+language, database, and font catalogs; nothing is installed if any entry is
+unknown. Re-running apply is safe: installed apps, languages, databases, and
+fonts are detected and skipped.
+
+Manifest keys (all optional):
+  categories   Full install categories: terminal, desktop, ai-tools
+  apps         Individual registry apps (e.g. neovim, alacritty)
+  skip         Categories or apps to exclude (same as dg install --skip)
+  languages    name[@version] specs (e.g. go, node@20, php)
+  databases    Database names (e.g. postgresql, redis)
+  fonts        Nerd Font package names (e.g. font-hack-nerd-font)
+  theme        Theme name (default)
+
+Example devgita.yaml:
+  categories: [terminal, ai-tools]
+  apps: [alacritty]
+  skip: [lazydocker]
+  languages: [go, node@20]
+  databases: [postgresql]
+  fonts: [font-hack-nerd-font]
+  theme: default
+
+Examples:
+  dg apply                    # uses ./devgita.yaml
+  dg apply -f ~/dotfiles/devgita.yaml
+`,
+	Args: cobra.NoArgs,
+	RunE: runApply,
+}
+
+func init() {
+	rootCmd.AddCommand(applyCmd)
+
+	applyCmd.Flags().
+		StringVarP(&applyFile, "file", "f", manifest.DefaultFile, "Path to the manifest file")
+}
+
+func runApply(cmd *cobra.Command, args []string) error {
+	m, err := manifest.Load(applyFile)
+	if err != nil {
+		return err
+	}
+	cfg, err := planApply(m)
+	if err != nil {
+		return err
+	}
+
+	logger.L().Debugw("apply plan", "manifest", m, "cfg", cfg)
+
+	utils.PrintBold(constants.Devgita)
+	utils.Print(fmt.Sprintf("=> Applying %s...", applyFile), "")
+	utils.Print("===============================================", "")
+
+	osCmd := commands.NewCommand()
+
+	utils.PrintInfo("Validating version...")
+	if err := osCmd.ValidateOSVersion(); err != nil {
+		return err
+	}
+
+	utils.PrintInfo("Installing package manager...")
+	if err := osCmd.MaybeInstallPackageManager(); err != nil {
+		return err
+	}
+
+	installDevgita()
+
+	if cfg != nil {
+		if cfg.runTerminal {
+			t := terminal.New()
+			t.NonInteractive = true
+			t.InstallAndConfigure(cfg.terminalAppFilter, cfg.terminalSkipFilter)
+		}
+		if cfg.runDesktop {
+			d := desktop.New()
+			d.NonInteractive = true
+			d.InstallAndConfigure(cfg.desktopAppFilter, cfg.desktopSkipFilter)
+		}
+		if cfg.runAITools {
+			aitools.New().InstallAndConfigure(cfg.aiToolsAppFilter, cfg.aiToolsSkipFilter)
+		}
+	}
+
+	if len(m.Languages) > 0 {
+		languages.New().InstallLanguages(m.LanguageConfigs())
+	}
+	if len(m.Databases) > 0 {
+		databases.New().InstallDatabases(m.DatabaseConfigs())
+	}
+
+	if len(m.Fonts) > 0 {
+		f := fonts.New()
+		for _, font := range m.Fonts {
+			utils.PrintInfo(fmt.Sprintf("Installing font %s (if not previously installed)...", font))
+			if err := f.SoftInstallFont(font); err != nil {
+				utils.PrintWarning(fmt.Sprintf("Font %s failed to install: %v", font, err))
+				logger.L().Warnw("Font installation failed, continuing", "font", font, "error", err)
+			}
+		}
+	}
+
+	if err := applyTheme(m.Theme); err != nil {
+		return err
+	}
+
+	utils.PrintSuccess(fmt.Sprintf("Applied %s", applyFile))
+	return nil
+}
+
+// planApply resolves the manifest's categories, apps, and skips through the
+// same planner dg install uses. It returns nil when the manifest names no
+// categories or apps: an empty --only means "everything" to dg install, but a
+// manifest that lists only languages must not install every terminal tool.
+func planApply(m *manifest.Manifest) (*installConfig, error) {
+	targets := m.Targets()
+	if len(targets) == 0 {
+		return nil, nil
+	}
+	return parseInstallFlags(targets, m.Skip)
+}
+
+// applyTheme records the manifest theme as the current theme. A no-op when
+// the manifest sets none or the theme is already current.
+func applyTheme(theme string) error {
+	if theme == "" {
+		return nil
+	}
+	gc := &config.GlobalConfig{}
+	if err := gc.Create(); err != nil {
+		return fmt.Errorf("failed to create global config: %w", err)
+	}
+	if err := gc.Load(); err != nil {
+		return fmt.Errorf("failed to load global config: %w", err)
+	}
+	if gc.CurrentTheme == theme {
+		return nil
+	}
+	gc.CurrentTheme = theme
+	if err := gc.Save(); err != nil {
+		return fmt.Errorf("failed to save global config: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,56 @@
+package cmd
+
+import (
+	"testing"
+
+	"github.com/cjairm/devgita/internal/config"
+	"github.com/cjairm/devgita/internal/manifest"
+	"github.com/cjairm/devgita/internal/testutil"
+)
+
+func TestPlanApply_NoTargetsRunsNoCoordinators(t *testing.T) {
+	m := &manifest.Manifest{Languages: []string{"go"}}
+	cfg, err := planApply(m)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if cfg != nil {
+		t.Errorf("expected nil plan for a manifest without categories or apps, got %+v", cfg)
+	}
+}
+
+func TestPlanApply_CategoriesAndApps(t *testing.T) {
+	m := &manifest.Manifest{
+		Categories: []string{"terminal"},
+		Apps:       []string{"alacritty"},
+		Skip:       []string{"lazygit"},
+	}
+	cfg, err := planApply(m)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	// Languages and databases come from their own manifest keys, never the planner.
+	assertRunsOnly(t, cfg, "terminal", "desktop")
+	if !cfg.desktopAppFilter["alacritty"] {
+		t.Errorf("expected desktop filter to contain alacritty, got %v", cfg.desktopAppFilter)
+	}
+	if !cfg.terminalSkipFilter["lazygit"] {
+		t.Errorf("expected terminal skip filter to contain lazygit, got %v", cfg.terminalSkipFilter)
+	}
+}
+
+func TestApplyTheme_PersistsCurrentTheme(t *testing.T) {
+	tc := testutil.SetupCompleteTest(t)
+	defer tc.Cleanup()
+
+	if err := applyTheme("default"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	gc := &config.GlobalConfig{}
+	if err := gc.Load(); err != nil {
+		t.Fatalf("failed to load config: %v", err)
+	}
+	if gc.CurrentTheme != "default" {
+		t.Errorf("expected current_theme 'default', got %q", gc.CurrentTheme)
+	}
+}
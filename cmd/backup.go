@@ -0,0 +1,220 @@
+/*
+* Copyright © 2025 Carlos Mendez <carlos@hadaelectronics.com> | https://cjairm.me/
+ */
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"text/tabwriter"
+	"time"
+
+	"github.com/cjairm/devgita/internal/backup"
+	"github.com/cjairm/devgita/internal/config"
+	"github.com/cjairm/devgita/pkg/utils"
+	"github.com/spf13/cobra"
+)
+
+var backupPruneKeep int
+
+// newBackupManager builds the snapshot manager; overridden in tests.
+var newBackupManager = func() *backup.Manager {
+	version, _, _ := resolveVersionInfo()
+	return backup.New(version)
+}
+
+// reloadRestoredConfig applies a restored global_config.yaml: shell features
+// are reconciled with what's installed, as every configure step does, and
+// devgita.zsh is regenerated from the result. A snapshot without a global
+// config leaves nothing to reload. Overridden in tests.
+var reloadRestoredConfig = func() error {
+	gc := &config.GlobalConfig{}
+	if err := gc.Load(); err != nil {
+		if os.IsNotExist(err) {
+			return nil
+		}
+		return fmt.Errorf("failed to load global config: %w", err)
+	}
+	gc.ReconcileShellFeatures()
+	if err := gc.RegenerateShellConfig(); err != nil {
+		return fmt.Errorf("failed to generate shell config: %w", err)
+	}
+	if err := gc.Save(); err != nil {
+		return fmt.Errorf("failed to save global config: %w", err)
+	}
+	return nil
+}
+
+var backupCmd = &cobra.Command{
+	Use:   "backup [name]",
+	Short: "Snapshot every file devgita manages",
+	Long: `Creates a snapshot of every file devgita manages: the app configs under
+~/.config (git, neovim, alacritty, aerospace, fastfetch, i3), ~/.tmux.conf,
+the devgita-rendered parts of ~/.claude and ~/.config/opencode, devgita.zsh,
+and global_config.yaml.
+
+Snapshots are gzipped tarballs stored under the devgita data directory, each
+with a manifest of SHA-256 hashes that dg restore verifies before writing.
+The name defaults to the current timestamp; "list" and "prune" are
+subcommands, not names.
+
+Examples:
+  dg backup                       # snapshot named after the current time
+  dg backup before-nvim-rewrite   # named snapshot
+  dg backup list                  # show snapshots, newest first
+  dg backup prune --keep 3        # delete all but the 3 newest snapshots
+  dg restore before-nvim-rewrite  # put the files back
+`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: runBackup,
+}
+
+var backupListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List snapshots, newest first",
+	Args:  cobra.NoArgs,
+	RunE:  runBackupList,
+}
+
+var backupPruneCmd = &cobra.Command{
+	Use:   "prune",
+	Short: "Delete all but the newest snapshots",
+	Args:  cobra.NoArgs,
+	RunE:  runBackupPrune,
+}
+
+var restoreCmd = &cobra.Command{
+	Use:   "restore <name>",
+	Short: "Restore a snapshot created by dg backup",
+	Long: `Restores every file in a snapshot created by dg backup, then reloads
+global_config.yaml and regenerates devgita.zsh from it.
+
+The archive is verified against its manifest hashes before anything on disk
+changes. Each snapshotted path is replaced wholesale, so files added since the
+snapshot are removed; the current state is snapshotted first as
+pre-restore-<timestamp> so a restore can itself be undone.
+
+Examples:
+  dg backup list                  # find the snapshot name
+  dg restore 20260101-120000
+`,
+	Args: cobra.ExactArgs(1),
+	RunE: runRestore,
+}
+
+func init() {
+	rootCmd.AddCommand(backupCmd)
+	rootCmd.AddCommand(restoreCmd)
+	backupCmd.AddCommand(backupListCmd)
+	backupCmd.AddCommand(backupPruneCmd)
+
+	backupPruneCmd.Flags().
+		IntVar(&backupPruneKeep, "keep", 5, "Number of newest snapshots to keep")
+}
+
+func runBackup(cmd *cobra.Command, args []string) error {
+	name := ""
+	if len(args) == 1 {
+		name = args[0]
+	}
+	snap, err := newBackupManager().Create(name)
+	if err != nil {
+		return err
+	}
+	utils.PrintSuccess(fmt.Sprintf(
+		"Backed up %d files to %s (%s)",
+		countFiles(snap.Manifest), snap.Path, formatBytes(snap.Size),
+	))
+	return nil
+}
+
+func runBackupList(cmd *cobra.Command, args []string) error {
+	snapshots, err := newBackupManager().List()
+	if err != nil {
+		return err
+	}
+	out := cmd.OutOrStdout()
+	if len(snapshots) == 0 {
+		fmt.Fprintln(out, "No backups yet. Create one with `dg backup`.")
+		return nil
+	}
+	w := tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "NAME\tCREATED\tFILES\tSIZE\tDEVGITA")
+	for _, s := range snapshots {
+		fmt.Fprintf(w, "%s\t%s\t%d\t%s\t%s\n",
+			s.Name,
+			s.Manifest.CreatedAt.Local().Format("2006-01-02 15:04"),
+			countFiles(s.Manifest),
+			formatBytes(s.Size),
+			s.Manifest.DevgitaVersion,
+		)
+	}
+	return w.Flush()
+}
+
+func runBackupPrune(cmd *cobra.Command, args []string) error {
+	removed, err := newBackupManager().Prune(backupPruneKeep)
+	for _, s := range removed {
+		utils.PrintInfo("Removed backup " + s.Name)
+	}
+	if err != nil {
+		return err
+	}
+	if len(removed) == 0 {
+		utils.PrintInfo(fmt.Sprintf("Nothing to prune (keeping %d newest)", backupPruneKeep))
+	}
+	return nil
+}
+
+func runRestore(cmd *cobra.Command, args []string) error {
+	m := newBackupManager()
+
+	// Fail on an unknown or corrupt name before taking the safety snapshot.
+	if _, err := m.Open(args[0]); err != nil {
+		return err
+	}
+	safety, err := m.Create("pre-restore-" + backup.DefaultName(time.Now()))
+	if err != nil {
+		utils.PrintWarning(fmt.Sprintf("Could not snapshot current state before restoring: %v", err))
+	} else {
+		utils.PrintInfo("Current state saved as " + safety.Name)
+	}
+
+	snap, err := m.Restore(args[0])
+	if err != nil {
+		return err
+	}
+
+	if err := reloadRestoredConfig(); err != nil {
+		return fmt.Errorf("restored files, but failed to reload them: %w", err)
+	}
+	utils.PrintSuccess(fmt.Sprintf(
+		"Restored %d files from %s (taken %s)",
+		countFiles(snap.Manifest), snap.Name,
+		snap.Manifest.CreatedAt.Local().Format("2006-01-02 15:04"),
+	))
+	utils.PrintInfo("Run `source ~/.zshrc` to apply shell changes.")
+	return nil
+}
+
+// countFiles counts the regular files and symlinks in a manifest.
+func countFiles(m backup.Manifest) int {
+	n := 0
+	for _, f := range m.Files {
+		if !f.Mode.IsDir() {
+			n++
+		}
+	}
+	return n
+}
+
+func formatBytes(n int64) string {
+	switch {
+	case n >= 1<<20:
+		return fmt.Sprintf("%.1f MB", float64(n)/(1<<20))
+	case n >= 1<<10:
+		return fmt.Sprintf("%.1f KB", float64(n)/(1<<10))
+	default:
+		return fmt.Sprintf("%d B", n)
+	}
+}
@@ -0,0 +1,104 @@
+package cmd
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/cjairm/devgita/internal/backup"
+)
+
+// setupBackupManager points dg backup/restore at a temp home holding one
+// managed file and returns that file's path. The post-restore config reload
+// is stubbed out; *reloads counts its calls.
+func setupBackupManager(t *testing.T) (string, *int) {
+	t.Helper()
+	home := t.TempDir()
+	managed := filepath.Join(home, ".tmux.conf")
+	if err := os.WriteFile(managed, []byte("original\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	orig := newBackupManager
+	newBackupManager = func() *backup.Manager {
+		m := backup.New("test")
+		m.Dir = filepath.Join(home, "backups")
+		m.Home = home
+		m.Targets = []backup.Target{{App: "tmux", Path: managed}}
+		return m
+	}
+	origReload := reloadRestoredConfig
+	reloads := 0
+	reloadRestoredConfig = func() error {
+		reloads++
+		return nil
+	}
+	t.Cleanup(func() {
+		newBackupManager = orig
+		reloadRestoredConfig = origReload
+	})
+	return managed, &reloads
+}
+
+func TestBackupRestore_RoundTrip(t *testing.T) {
+	managed, reloads := setupBackupManager(t)
+
+	if err := runBackup(backupCmd, []string{"snap"}); err != nil {
+		t.Fatalf("backup: %v", err)
+	}
+	if err := os.WriteFile(managed, []byte("edited\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if err := runRestore(restoreCmd, []string{"snap"}); err != nil {
+		t.Fatalf("restore: %v", err)
+	}
+	got, _ := os.ReadFile(managed)
+	if string(got) != "original\n" {
+		t.Errorf("expected restored content, got %q", got)
+	}
+	if *reloads != 1 {
+		t.Errorf("expected the restored config to be reloaded once, got %d", *reloads)
+	}
+
+	var out bytes.Buffer
+	backupListCmd.SetOut(&out)
+	defer backupListCmd.SetOut(nil)
+	if err := runBackupList(backupListCmd, nil); err != nil {
+		t.Fatalf("list: %v", err)
+	}
+	if !strings.Contains(out.String(), "snap") || !strings.Contains(out.String(), "pre-restore-") {
+		t.Errorf("expected the snapshot and the pre-restore safety snapshot, got:\n%s", out.String())
+	}
+}
+
+func TestRestore_UnknownNameTakesNoSafetySnapshot(t *testing.T) {
+	_, reloads := setupBackupManager(t)
+
+	if err := runRestore(restoreCmd, []string{"nope"}); err == nil {
+		t.Fatal("expected an error for an unknown backup")
+	}
+	list, err := newBackupManager().List()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(list) != 0 {
+		t.Errorf("expected no safety snapshot for a failed lookup, got %v", list)
+	}
+	if *reloads != 0 {
+		t.Errorf("expected no reload after a failed restore, got %d", *reloads)
+	}
+}
+
+func TestBackupSubcommandsAreReservedNames(t *testing.T) {
+	setupBackupManager(t)
+	for _, sub := range backupCmd.Commands() {
+		if err := backup.ValidateName(sub.Name()); err == nil {
+			t.Errorf("expected dg backup %s to be rejected as a snapshot name", sub.Name())
+		}
+	}
+	if err := runRestore(restoreCmd, []string{"list"}); err == nil ||
+		!strings.Contains(err.Error(), "reserved") {
+		t.Errorf("expected dg restore list to be rejected as reserved, got %v", err)
+	}
+}
@@ -0,0 +1,125 @@
+/*
+* Copyright © 2025 Carlos Mendez <carlos@hadaelectronics.com> | https://cjairm.me/
+ */
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"text/tabwriter"
+	"time"
+
+	"github.com/cjairm/devgita/internal/commands"
+	"github.com/cjairm/devgita/internal/config"
+	"github.com/cjairm/devgita/internal/updates"
+	"github.com/spf13/cobra"
+)
+
+var (
+	checkUpdatesJSON    bool
+	checkUpdatesTimeout time.Duration
+)
+
+// newUpdateChecker is swapped in tests for a checker backed by fake sources.
+var newUpdateChecker = func() *updates.Checker {
+	return updates.New(commands.NewBaseCommand().IsMac())
+}
+
+var checkUpdatesCmd = &cobra.Command{
+	Use:   "check-updates",
+	Short: "Show tracked items with newer versions available",
+	Long: `Check every item in global_config.yaml for a newer version and report the
+installed version next to the latest one.
+
+Each item is checked against the source that installed it: Homebrew
+(brew outdated), apt (apt list --upgradable, which also covers PPAs), mise
+for languages (mise outdated), or GitHub releases for binaries devgita
+downloads directly (lazygit, lazydocker, rtk, neovim on Debian/Ubuntu). All
+sources are queried at once; a source that fails or does not answer within
+--timeout marks its items "unknown" without holding up the rest. Fonts,
+themes, and script or git-clone installs have no update source.
+
+Nothing is upgraded; this command only reads.
+
+Examples:
+  dg check-updates                 # Table of current vs latest versions
+  dg check-updates --json          # Machine-readable output for dashboards
+  dg check-updates --timeout 10s   # Give up on slow sources sooner`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		gc := &config.GlobalConfig{}
+		if err := gc.Load(); err != nil {
+			return fmt.Errorf("failed to load global config: %w", err)
+		}
+
+		checker := newUpdateChecker()
+		checker.Timeout = checkUpdatesTimeout
+		results := checker.Check(cmd.Context(), gc)
+
+		if checkUpdatesJSON {
+			return writeUpdatesJSON(cmd.OutOrStdout(), results)
+		}
+		writeUpdatesTable(cmd.OutOrStdout(), results)
+		return nil
+	},
+}
+
+func writeUpdatesJSON(w io.Writer, results []updates.Result) error {
+	if results == nil {
+		results = []updates.Result{}
+	}
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	if err := enc.Encode(results); err != nil {
+		return fmt.Errorf("failed to encode results: %w", err)
+	}
+	return nil
+}
+
+func writeUpdatesTable(w io.Writer, results []updates.Result) {
+	if len(results) == 0 {
+		fmt.Fprintln(w, "Nothing tracked yet. Run `dg install` to get started.")
+		return
+	}
+	outdated := 0
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "NAME\tCATEGORY\tSOURCE\tCURRENT\tLATEST\tSTATUS")
+	for _, r := range results {
+		status := string(r.Status)
+		if r.Error != "" {
+			status += " (" + r.Error + ")"
+		}
+		if r.Status == updates.StatusOutdated {
+			outdated++
+		}
+		fmt.Fprintf(tw, "%s\t%s\t%s\t%s\t%s\t%s\n",
+			r.Name,
+			r.Category,
+			orDash(r.Source),
+			orDash(r.Current),
+			orDash(r.Latest),
+			status,
+		)
+	}
+	_ = tw.Flush()
+	fmt.Fprintln(w)
+	fmt.Fprintf(w, "%d update(s) available\n", outdated)
+}
+
+func init() {
+	rootCmd.AddCommand(checkUpdatesCmd)
+
+	checkUpdatesCmd.Flags().BoolVar(
+		&checkUpdatesJSON,
+		"json",
+		false,
+		"Print results as JSON",
+	)
+	checkUpdatesCmd.Flags().DurationVar(
+		&checkUpdatesTimeout,
+		"timeout",
+		updates.DefaultTimeout,
+		"Give up on sources that have not answered after this long",
+	)
+}
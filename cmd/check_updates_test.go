@@ -0,0 +1,93 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/cjairm/devgita/internal/config"
+	"github.com/cjairm/devgita/internal/updates"
+	"github.com/cjairm/devgita/pkg/paths"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type fakeUpdateSource struct {
+	name  string
+	found map[string]updates.Versions
+}
+
+func (f fakeUpdateSource) Name() string            { return f.name }
+func (f fakeUpdateSource) ListsOnlyOutdated() bool { return true }
+func (f fakeUpdateSource) Outdated(context.Context, []string) (map[string]updates.Versions, error) {
+	return f.found, nil
+}
+
+func setupCheckUpdates(t *testing.T) *bytes.Buffer {
+	t.Helper()
+	origRoot := paths.Paths.Config.Root
+	paths.Paths.Config.Root = t.TempDir()
+	origChecker := newUpdateChecker
+	newUpdateChecker = func() *updates.Checker {
+		return &updates.Checker{Sources: map[string]updates.Source{
+			updates.SourceBrew: fakeUpdateSource{
+				name:  updates.SourceBrew,
+				found: map[string]updates.Versions{"git": {Installed: "2.44.0", Latest: "2.45.0"}},
+			},
+		}}
+	}
+	t.Cleanup(func() {
+		paths.Paths.Config.Root = origRoot
+		newUpdateChecker = origChecker
+		checkUpdatesJSON = false
+	})
+
+	gc := &config.GlobalConfig{}
+	require.NoError(t, gc.Create())
+	require.NoError(t, gc.Load())
+	gc.Installed.Packages = config.TrackedItems{
+		{Name: "git", Version: "2.44.0", Method: config.MethodBrew},
+		{Name: "tmux", Version: "3.4", Method: config.MethodBrew},
+	}
+	require.NoError(t, gc.Save())
+
+	var out bytes.Buffer
+	checkUpdatesCmd.SetOut(&out)
+	checkUpdatesCmd.SetContext(context.Background())
+	t.Cleanup(func() { checkUpdatesCmd.SetOut(nil) })
+	return &out
+}
+
+func TestCheckUpdates_Table(t *testing.T) {
+	out := setupCheckUpdates(t)
+
+	require.NoError(t, checkUpdatesCmd.RunE(checkUpdatesCmd, nil))
+
+	assert.Regexp(t, `git\s+packages\s+brew\s+2\.44\.0\s+2\.45\.0\s+outdated`, out.String())
+	assert.Regexp(t, `tmux\s+packages\s+brew\s+3\.4\s+3\.4\s+up-to-date`, out.String())
+	assert.Contains(t, out.String(), "1 update(s) available")
+}
+
+func TestCheckUpdates_JSON(t *testing.T) {
+	out := setupCheckUpdates(t)
+	checkUpdatesJSON = true
+
+	require.NoError(t, checkUpdatesCmd.RunE(checkUpdatesCmd, nil))
+
+	var results []updates.Result
+	require.NoError(t, json.Unmarshal(out.Bytes(), &results))
+	require.Len(t, results, 2)
+	assert.Equal(t, updates.Result{
+		Name: "git", Category: "packages", Source: "brew",
+		Current: "2.44.0", Latest: "2.45.0", Status: updates.StatusOutdated,
+	}, results[0])
+}
+
+func TestWriteUpdatesJSON_EmptyIsArray(t *testing.T) {
+	var out bytes.Buffer
+
+	require.NoError(t, writeUpdatesJSON(&out, nil))
+
+	assert.Equal(t, "[]\n", out.String())
+}
@@ -6,19 +6,29 @@ package cmd
 import (
 	"errors"
 	"fmt"
+	"path/filepath"
 	"slices"
 	"strings"
+	"time"
 
 	"github.com/cjairm/devgita/internal/apps"
 	"github.com/cjairm/devgita/internal/apps/devgita"
 	"github.com/cjairm/devgita/internal/apps/registry"
+	"github.com/cjairm/devgita/internal/backup"
+	"github.com/cjairm/devgita/internal/commands"
+	"github.com/cjairm/devgita/internal/configdiff"
+	"github.com/cjairm/devgita/pkg/dryrun"
+	"github.com/cjairm/devgita/pkg/files"
+	"github.com/cjairm/devgita/pkg/paths"
 	"github.com/cjairm/devgita/pkg/utils"
 	"github.com/spf13/cobra"
 )
 
 var (
-	configureForce bool
-	configureOnly  []string
+	configureForce  bool
+	configureOnly   []string
+	configureDryRun bool
+	configureDiff   bool
 )
 
 // getAppFn is the registry lookup; overridden in tests.
@@ -38,19 +48,32 @@ var configureCmd = &cobra.Command{
 	Long: `Apply configuration files for a named app without reinstalling.
 
 By default (soft mode), configuration is only applied if files do not already exist.
-Use --force to overwrite existing configuration files.
+Use --force to overwrite existing configuration files. Any existing file that
+--force replaces with different content (or deletes) is first copied to a
+timestamped folder under the devgita data dir, and the copies are listed.
 
 The AI coders (claude, opencode) expose discrete, separately-refreshable parts
-via --only (must be combined with --force): the shared config subtrees
+via --only (combined with --force, or with --diff to preview): the shared config subtrees
 (skills, commands, agents) overwrite only those folders, leaving settings,
 themes, and other config you may have edited untouched; the rtk part runs
 "rtk init" to wire rtk's command-rewriting hook into that AI coder — the
 explicit opt-in required by ADR-0004, recorded so the hook survives future
 --force re-renders of claude's settings.json.
 
+Use --dry-run to list the files that would be created or overwritten, the
+commands that would run, and the global_config.yaml changes, without
+touching anything.
+
+Use --diff to compare what --force would write against the files on disk and
+print a unified diff. It exits non-zero when the two differ, so it can gate
+scripts. Combine it with --only to diff just those parts.
+
 Examples:
   dg configure git                              # Apply git config if not already present
   dg configure neovim --force                   # Overwrite existing neovim config
+  dg configure neovim --force --dry-run         # Show which files would be overwritten
+  dg configure neovim --diff                    # Show local edits --force would discard
+  dg configure claude --diff --only=skills      # Diff only the skills folder
   dg configure claude --force --only=skills     # Refresh only the skills folder
   dg configure opencode --force --only=skills,commands
   dg configure claude --force --only=rtk        # Opt into rtk's hook for Claude Code
@@ -66,12 +89,50 @@ func init() {
 	configureCmd.Flags().
 		BoolVar(&configureForce, "force", false, "Overwrite existing configuration files")
 	configureCmd.Flags().
-		StringSliceVar(&configureOnly, "only", nil, "Refresh only these app-defined config parts (claude/opencode: skills,commands,agents,rtk); requires --force")
+		StringSliceVar(&configureOnly, "only", nil, "Refresh only these app-defined config parts (claude/opencode: skills,commands,agents,rtk); requires --force or --diff")
+	configureCmd.Flags().
+		BoolVar(&configureDryRun, "dry-run", false, "Show the files and config changes without writing them")
+	configureCmd.Flags().
+		BoolVar(&configureDiff, "diff", false, "Show a unified diff between the rendered config and disk; exits non-zero on drift")
+}
+
+// newOverwriteBackupDir returns a fresh, timestamped directory for the files
+// one `configure --force` run replaces; overridden in tests.
+var newOverwriteBackupDir = func() string {
+	return filepath.Join(
+		paths.Paths.App.Root,
+		backup.DirName,
+		"overwritten",
+		time.Now().Format("20060102-150405.000"),
+	)
+}
+
+// reportOverwriteBackups ends the overwrite-backup session and lists what
+// was saved and where.
+func reportOverwriteBackups() {
+	saved, err := files.EndOverwriteBackups()
+	if err != nil {
+		utils.PrintWarning(fmt.Sprintf("Failed to finalize config backups: %v", err))
+		return
+	}
+	if len(saved) == 0 {
+		return
+	}
+	utils.PrintInfo(fmt.Sprintf("Backed up %d file(s) that were overwritten or removed:", len(saved)))
+	for _, f := range saved {
+		utils.Print(fmt.Sprintf("  %s -> %s", f.Path, f.Backup), "")
+	}
 }
 
 func runConfigure(cmd *cobra.Command, args []string) error {
 	appName := args[0]
 
+	if configureDiff && (configureForce || configureDryRun) {
+		return fmt.Errorf("--diff cannot be combined with --force or --dry-run")
+	}
+
+	defer beginDryRun(configureDryRun)()
+
 	// Re-extract embedded configs so templates always match the running binary.
 	// Without this, a newer binary may configure apps using stale templates
 	// left on disk by an older version.
@@ -79,11 +140,30 @@ func runConfigure(cmd *cobra.Command, args []string) error {
 		return err
 	}
 
+	// --diff only compares rendered files with disk: the app is built with
+	// recording executors so commands its configure would run are planned
+	// into a throwaway plan rather than run.
+	if configureDiff {
+		defer commands.RecordTo(dryrun.NewPlan())()
+	}
+
 	app, err := getAppFn(appName)
 	if err != nil {
 		return err
 	}
 
+	if configureDiff {
+		return runConfigureDiff(cmd, app, appName)
+	}
+
+	// --force overwrites whatever is on disk, so keep a copy of every file it
+	// changes. Started after the embedded refresh above so devgita's own
+	// templates are never backed up.
+	if configureForce {
+		files.BeginOverwriteBackups(newOverwriteBackupDir(), paths.Paths.Home.Root)
+		defer reportOverwriteBackups()
+	}
+
 	// --only refreshes just the named shared subtrees. It's overwrite-only (so
 	// it never silently no-ops in soft mode) and limited to apps that expose
 	// separately-refreshable parts (the AI coders).
@@ -91,18 +171,9 @@ func runConfigure(cmd *cobra.Command, args []string) error {
 		if !configureForce {
 			return fmt.Errorf("--only requires --force (it overwrites the named config folders)")
 		}
-		sc, ok := app.(apps.SelectiveConfigurer)
-		if !ok {
-			return fmt.Errorf("--only is not supported for %s", appName)
-		}
-		allowed := sc.ConfigurableParts()
-		for _, part := range configureOnly {
-			if !slices.Contains(allowed, part) {
-				return fmt.Errorf(
-					"unknown --only value %q for %s (valid: %s)",
-					part, appName, strings.Join(allowed, ", "),
-				)
-			}
+		sc, err := selectiveConfigurer(app, appName)
+		if err != nil {
+			return err
 		}
 		if err := sc.ForceConfigureParts(configureOnly); err != nil {
 			return err
@@ -130,3 +201,58 @@ func runConfigure(cmd *cobra.Command, args []string) error {
 	utils.PrintSuccess("configured " + appName)
 	return nil
 }
+
+// selectiveConfigurer returns app as a SelectiveConfigurer after checking
+// every --only value against the parts it exposes.
+func selectiveConfigurer(app apps.App, appName string) (apps.SelectiveConfigurer, error) {
+	sc, ok := app.(apps.SelectiveConfigurer)
+	if !ok {
+		return nil, fmt.Errorf("--only is not supported for %s", appName)
+	}
+	allowed := sc.ConfigurableParts()
+	for _, part := range configureOnly {
+		if !slices.Contains(allowed, part) {
+			return nil, fmt.Errorf(
+				"unknown --only value %q for %s (valid: %s)",
+				part, appName, strings.Join(allowed, ", "),
+			)
+		}
+	}
+	return sc, nil
+}
+
+// runConfigureDiff renders what `configure --force` (or --force --only)
+// would write, prints it as a unified diff against disk, and returns an
+// error when anything differs.
+func runConfigureDiff(cmd *cobra.Command, app apps.App, appName string) error {
+	render := app.ForceConfigure
+	if len(configureOnly) > 0 {
+		sc, err := selectiveConfigurer(app, appName)
+		if err != nil {
+			return err
+		}
+		render = func() error { return sc.ForceConfigureParts(configureOnly) }
+	}
+
+	diffs, err := configdiff.Compute(render)
+	if errors.Is(err, apps.ErrConfigureNotSupported) {
+		utils.PrintInfo("configure not supported for " + appName)
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	if len(diffs) == 0 {
+		utils.PrintSuccess("no drift for " + appName)
+		return nil
+	}
+
+	out := cmd.OutOrStdout()
+	for _, d := range diffs {
+		fmt.Fprint(out, d.Unified)
+	}
+	return fmt.Errorf(
+		"%s: %d file(s) differ from the rendered config; run `dg configure %s --force` to reset them",
+		appName, len(diffs), appName,
+	)
+}
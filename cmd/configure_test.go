@@ -1,11 +1,18 @@
 package cmd
 
 import (
+	"bytes"
 	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
 	"testing"
 
 	"github.com/cjairm/devgita/internal/apps"
+	"github.com/cjairm/devgita/internal/commands"
 	"github.com/cjairm/devgita/internal/testutil"
+	"github.com/cjairm/devgita/pkg/dryrun"
+	"github.com/cjairm/devgita/pkg/files"
 )
 
 func init() { testutil.InitLogger() }
@@ -213,3 +220,204 @@ func TestConfigure_NotSupported(t *testing.T) {
 		t.Fatalf("ErrConfigureNotSupported should exit zero, got: %v", err)
 	}
 }
+
+// writingConfigureApp writes its config through pkg/files, like real apps do.
+type writingConfigureApp struct {
+	mockConfigureApp
+	target string
+}
+
+func (m *writingConfigureApp) ForceConfigure() error {
+	return files.WriteFileAtomic(m.target, []byte("set -g mouse on\n"), files.FilePermission)
+}
+
+func TestConfigure_DryRunPrintsPlanWithoutWriting(t *testing.T) {
+	target := filepath.Join(t.TempDir(), "tmux.conf")
+	restore := setupConfigureCmd(t, &writingConfigureApp{target: target})
+	defer restore()
+
+	var out bytes.Buffer
+	origOut := dryRunOut
+	dryRunOut = &out
+	configureForce, configureDryRun = true, true
+	defer func() {
+		dryRunOut = origOut
+		configureForce, configureDryRun = false, false
+	}()
+
+	if err := runConfigure(configureCmd, []string{"tmux"}); err != nil {
+		t.Fatalf("expected no error, got: %v", err)
+	}
+	if _, err := os.Stat(target); !os.IsNotExist(err) {
+		t.Errorf("expected %s not to be written under --dry-run", target)
+	}
+	if !strings.Contains(out.String(), "create  "+target) {
+		t.Errorf("expected plan to list %s, got:\n%s", target, out.String())
+	}
+	if dryrun.Active() {
+		t.Error("expected dry-run mode to be switched off after the command")
+	}
+}
+
+// execConfigureApp runs a command from ForceConfigure through the executor
+// it was built with, as the real apps do.
+type execConfigureApp struct {
+	mockConfigureApp
+	base   commands.BaseCommandExecutor
+	marker string
+}
+
+func (m *execConfigureApp) ForceConfigure() error {
+	_, _, err := m.base.ExecCommand(commands.CommandParams{Command: "touch", Args: []string{m.marker}})
+	return err
+}
+
+func TestConfigure_DryRunRecordsCommandsOfAppsBuiltForIt(t *testing.T) {
+	marker := filepath.Join(t.TempDir(), "configured")
+	restore := setupConfigureCmd(t, nil)
+	defer restore()
+	getAppFn = func(string) (apps.App, error) {
+		return &execConfigureApp{base: commands.NewBaseCommand(), marker: marker}, nil
+	}
+
+	var out bytes.Buffer
+	origOut := dryRunOut
+	dryRunOut = &out
+	configureForce, configureDryRun = true, true
+	defer func() {
+		dryRunOut = origOut
+		configureForce, configureDryRun = false, false
+	}()
+
+	if err := runConfigure(configureCmd, []string{"tmux"}); err != nil {
+		t.Fatalf("expected no error, got: %v", err)
+	}
+	if _, err := os.Stat(marker); !os.IsNotExist(err) {
+		t.Errorf("expected the configure command not to run under --dry-run")
+	}
+	if !strings.Contains(out.String(), "run  touch "+marker) {
+		t.Errorf("expected plan to list the command, got:\n%s", out.String())
+	}
+	if _, ok := commands.NewBaseCommand().(*commands.BaseCommand); !ok {
+		t.Error("expected executors to run for real again after the command")
+	}
+}
+
+func TestConfigure_ForceBacksUpChangedFiles(t *testing.T) {
+	target := filepath.Join(t.TempDir(), "tmux.conf")
+	if err := os.WriteFile(target, []byte("# my tweaks\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	restore := setupConfigureCmd(t, &writingConfigureApp{target: target})
+	defer restore()
+
+	backupDir := filepath.Join(t.TempDir(), "overwritten")
+	origDir := newOverwriteBackupDir
+	newOverwriteBackupDir = func() string { return backupDir }
+	configureForce = true
+	defer func() {
+		newOverwriteBackupDir = origDir
+		configureForce = false
+	}()
+
+	if err := runConfigure(configureCmd, []string{"tmux"}); err != nil {
+		t.Fatalf("expected no error, got: %v", err)
+	}
+
+	// The target lies outside $HOME here, so its backup mirrors the absolute path.
+	saved := filepath.Join(backupDir, strings.TrimPrefix(target, string(filepath.Separator)))
+	got, err := os.ReadFile(saved)
+	if err != nil {
+		t.Fatalf("expected previous content saved at %s: %v", saved, err)
+	}
+	if string(got) != "# my tweaks\n" {
+		t.Errorf("expected backup to hold the user's edits, got %q", got)
+	}
+}
+
+func TestConfigure_DiffReportsDrift(t *testing.T) {
+	target := filepath.Join(t.TempDir(), "tmux.conf")
+	if err := os.WriteFile(target, []byte("# my tweaks\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	restore := setupConfigureCmd(t, &writingConfigureApp{target: target})
+	defer restore()
+
+	var out bytes.Buffer
+	configureCmd.SetOut(&out)
+	configureDiff = true
+	defer func() {
+		configureCmd.SetOut(nil)
+		configureDiff = false
+	}()
+
+	err := runConfigure(configureCmd, []string{"tmux"})
+	if err == nil || !strings.Contains(err.Error(), "1 file(s) differ") {
+		t.Fatalf("expected a drift error, got: %v", err)
+	}
+	for _, want := range []string{"-# my tweaks", "+set -g mouse on", "a" + target} {
+		if !strings.Contains(out.String(), want) {
+			t.Errorf("expected diff to contain %q, got:\n%s", want, out.String())
+		}
+	}
+	if got, _ := os.ReadFile(target); string(got) != "# my tweaks\n" {
+		t.Errorf("expected --diff to leave the file untouched, got %q", got)
+	}
+	if dryrun.Active() {
+		t.Error("expected dry-run mode to be switched off after --diff")
+	}
+}
+
+func TestConfigure_DiffNoDrift(t *testing.T) {
+	target := filepath.Join(t.TempDir(), "tmux.conf")
+	if err := os.WriteFile(target, []byte("set -g mouse on\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	restore := setupConfigureCmd(t, &writingConfigureApp{target: target})
+	defer restore()
+
+	var out bytes.Buffer
+	configureCmd.SetOut(&out)
+	configureDiff = true
+	defer func() {
+		configureCmd.SetOut(nil)
+		configureDiff = false
+	}()
+
+	if err := runConfigure(configureCmd, []string{"tmux"}); err != nil {
+		t.Fatalf("expected no error when disk matches, got: %v", err)
+	}
+	if out.Len() != 0 {
+		t.Errorf("expected no diff output, got:\n%s", out.String())
+	}
+}
+
+func TestConfigure_DiffOnlyUsesParts(t *testing.T) {
+	mock := &mockSelectiveApp{}
+	restore := setupConfigureCmd(t, mock)
+	defer restore()
+
+	configureDiff = true
+	configureOnly = []string{"skills"}
+	defer func() { configureDiff = false; configureOnly = nil }()
+
+	if err := runConfigure(configureCmd, []string{"claude"}); err != nil {
+		t.Fatalf("expected no error, got: %v", err)
+	}
+	if !mock.partsCalled || mock.forceCalled {
+		t.Errorf("expected --diff --only to render only the parts (parts=%v force=%v)",
+			mock.partsCalled, mock.forceCalled)
+	}
+}
+
+func TestConfigure_DiffRejectsForce(t *testing.T) {
+	restore := setupConfigureCmd(t, &mockConfigureApp{})
+	defer restore()
+
+	configureDiff, configureForce = true, true
+	defer func() { configureDiff, configureForce = false, false }()
+
+	if err := runConfigure(configureCmd, []string{"tmux"}); err == nil {
+		t.Fatal("expected --diff with --force to be rejected")
+	}
+}
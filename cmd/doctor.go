@@ -0,0 +1,156 @@
+/*
+* Copyright © 2025 Carlos Mendez <carlos@hadaelectronics.com> | https://cjairm.me/
+ */
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"text/tabwriter"
+
+	"github.com/cjairm/devgita/internal/apps"
+	"github.com/cjairm/devgita/internal/commands"
+	"github.com/cjairm/devgita/internal/config"
+	"github.com/cjairm/devgita/internal/embedded"
+	"github.com/cjairm/devgita/internal/inventory"
+	"github.com/cjairm/devgita/pkg/constants"
+	"github.com/spf13/cobra"
+)
+
+var doctorFix bool
+
+// newDoctor builds the doctor the command runs; overridden in tests.
+var newDoctor = func() *inventory.Doctor {
+	return &inventory.Doctor{
+		Base:            commands.NewBaseCommand(),
+		EmbeddedConfigs: embedded.DefaultFS,
+	}
+}
+
+var doctorCmd = &cobra.Command{
+	Use:   "doctor",
+	Short: "Check that the devgita environment works end-to-end",
+	Long: `Verifies the environment devgita set up, end-to-end, and reports each check
+as OK, WARN, or FAIL with a hint on how to fix it:
+
+  - devgita.zsh exists and is sourced from your shell config
+  - ~/.zshenv sources the PATH self-repair script (zsh only)
+  - the cc / oc aliases resolve in an interactive shell
+  - ~/.tmux.conf loads without errors (in a throwaway tmux server)
+  - neovim's :checkhealth reports no errors
+  - tracked fonts are visible to fc-list
+  - the configs extracted on disk match the ones in this binary
+
+Checks for tools global_config.yaml does not track are skipped. With --fix,
+each failing check that has an automatic repair runs that app's soft
+configure (devgita's also re-extracts the embedded configs), then all checks
+run again. Exits non-zero while any check fails.
+
+Examples:
+  dg doctor          # report only
+  dg doctor --fix    # repair what can be repaired, then re-check`,
+	Args: cobra.NoArgs,
+	RunE: runDoctor,
+}
+
+func init() {
+	rootCmd.AddCommand(doctorCmd)
+
+	doctorCmd.Flags().
+		BoolVar(&doctorFix, "fix", false, "Run the soft configure of each app behind a failing check, then re-check")
+}
+
+func runDoctor(cmd *cobra.Command, _ []string) error {
+	out := cmd.OutOrStdout()
+	checks, err := runDoctorChecks()
+	if err != nil {
+		return err
+	}
+	writeDoctorChecks(out, checks)
+
+	if doctorFix {
+		fixable := doctorFixApps(checks)
+		if len(fixable) == 0 {
+			fmt.Fprintln(out, "\nNothing to fix automatically.")
+		} else {
+			fmt.Fprintln(out)
+			for _, name := range fixable {
+				if err := fixDoctorApp(name); err != nil {
+					fmt.Fprintf(out, "fix %s: %v\n", name, err)
+					continue
+				}
+				fmt.Fprintf(out, "fix %s: done\n", name)
+			}
+			if checks, err = runDoctorChecks(); err != nil {
+				return err
+			}
+			fmt.Fprintln(out, "\nAfter fixes:")
+			writeDoctorChecks(out, checks)
+		}
+	}
+
+	failed := 0
+	for _, c := range checks {
+		if c.Status == inventory.CheckFail {
+			failed++
+		}
+	}
+	if failed > 0 {
+		return fmt.Errorf("%d check(s) failed", failed)
+	}
+	return nil
+}
+
+func runDoctorChecks() ([]inventory.Check, error) {
+	gc := &config.GlobalConfig{}
+	if err := gc.Load(); err != nil {
+		return nil, fmt.Errorf("failed to load global config (run `dg install` first): %w", err)
+	}
+	return newDoctor().Run(gc), nil
+}
+
+// doctorFixApps returns the apps whose SoftConfigure repairs a failing or
+// warning check, each once, in check order.
+func doctorFixApps(checks []inventory.Check) []string {
+	seen := map[string]bool{}
+	var names []string
+	for _, c := range checks {
+		if c.Status == inventory.CheckOK || c.FixApp == "" || seen[c.FixApp] {
+			continue
+		}
+		seen[c.FixApp] = true
+		names = append(names, c.FixApp)
+	}
+	return names
+}
+
+func fixDoctorApp(name string) error {
+	// devgita's soft configure leaves the extracted configs alone, so stale
+	// or missing templates are re-extracted first, as `dg configure` does.
+	if name == constants.DevgitaApp {
+		if err := refreshEmbeddedConfigs(); err != nil {
+			return fmt.Errorf("failed to refresh embedded configs: %w", err)
+		}
+	}
+	app, err := getAppFn(name)
+	if err != nil {
+		return err
+	}
+	if err := app.SoftConfigure(); err != nil && !errors.Is(err, apps.ErrConfigureNotSupported) {
+		return err
+	}
+	return nil
+}
+
+func writeDoctorChecks(w io.Writer, checks []inventory.Check) {
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "STATUS\tCHECK\tDETAIL")
+	for _, c := range checks {
+		fmt.Fprintf(tw, "%s\t%s\t%s\n", c.Status, c.Name, c.Detail)
+		if c.Hint != "" {
+			fmt.Fprintf(tw, "\t\t→ %s\n", c.Hint)
+		}
+	}
+	_ = tw.Flush()
+}
@@ -0,0 +1,85 @@
+package cmd
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/cjairm/devgita/internal/apps"
+	"github.com/cjairm/devgita/internal/inventory"
+	"github.com/cjairm/devgita/internal/testutil"
+	"github.com/cjairm/devgita/pkg/constants"
+	"github.com/cjairm/devgita/pkg/paths"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fixingConfigureApp is a mockConfigureApp whose SoftConfigure runs fix.
+type fixingConfigureApp struct {
+	mockConfigureApp
+	fix func()
+}
+
+func (m *fixingConfigureApp) SoftConfigure() error {
+	m.fix()
+	return m.mockConfigureApp.SoftConfigure()
+}
+
+func setupDoctor(t *testing.T, app apps.App) (*bytes.Buffer, *[]string) {
+	t.Helper()
+	tc := testutil.SetupCompleteTest(t)
+	t.Cleanup(tc.Cleanup)
+
+	origShell := paths.Files.ShellConfig
+	paths.Files.ShellConfig = filepath.Join(t.TempDir(), ".bashrc")
+	origDoctor, origApp, origRefresh := newDoctor, getAppFn, refreshEmbeddedConfigs
+	var fixed []string
+	newDoctor = func() *inventory.Doctor {
+		return &inventory.Doctor{Base: testutil.NewMockApp().Base}
+	}
+	getAppFn = func(name string) (apps.App, error) {
+		fixed = append(fixed, name)
+		return app, nil
+	}
+	refreshEmbeddedConfigs = func() error { return nil }
+	t.Cleanup(func() {
+		paths.Files.ShellConfig = origShell
+		newDoctor, getAppFn, refreshEmbeddedConfigs = origDoctor, origApp, origRefresh
+		doctorFix = false
+		doctorCmd.SetOut(nil)
+	})
+
+	var out bytes.Buffer
+	doctorCmd.SetOut(&out)
+	return &out, &fixed
+}
+
+func TestDoctor_ReportsFailureWithHint(t *testing.T) {
+	out, fixed := setupDoctor(t, &mockConfigureApp{})
+
+	err := runDoctor(doctorCmd, nil)
+
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "1 check(s) failed")
+	assert.Regexp(t, `FAIL\s+shell integration`, out.String())
+	assert.Contains(t, out.String(), "→ run `dg configure devgita`")
+	assert.Empty(t, *fixed, "without --fix nothing is repaired")
+}
+
+func TestDoctor_FixRunsSoftConfigureThenRechecks(t *testing.T) {
+	app := &fixingConfigureApp{fix: func() {
+		zsh := filepath.Join(paths.Paths.App.Root, "devgita.zsh")
+		_ = os.WriteFile(zsh, []byte("# generated\n"), 0644)
+		_ = os.WriteFile(paths.Files.ShellConfig, []byte(`source "`+zsh+`"`+"\n"), 0644)
+	}}
+	out, fixed := setupDoctor(t, app)
+	doctorFix = true
+
+	require.NoError(t, runDoctor(doctorCmd, nil))
+
+	assert.Equal(t, []string{constants.DevgitaApp}, *fixed)
+	assert.True(t, app.softCalled)
+	assert.Contains(t, out.String(), "fix devgita: done")
+	assert.Regexp(t, `After fixes:\n.*\nOK\s+shell integration`, out.String())
+}
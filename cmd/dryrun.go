@@ -0,0 +1,35 @@
+/*
+ * Copyright © 2025 Carlos Mendez <carlos@hadaelectronics.com> | https://cjairm.me/
+ */
+package cmd
+
+import (
+	"io"
+	"os"
+
+	"github.com/cjairm/devgita/internal/commands"
+	"github.com/cjairm/devgita/pkg/dryrun"
+	"github.com/cjairm/devgita/pkg/utils"
+)
+
+// dryRunOut is where the dry-run plan is printed; overridden in tests.
+var dryRunOut io.Writer = os.Stdout
+
+// beginDryRun starts a dry-run plan when enabled: executors built from here
+// on record commands into it, and file writes are recorded instead of made.
+// It returns a func that prints the plan and turns both back off. Callers
+// defer the returned func so the plan is printed even when a step fails, and
+// must build their coordinators after calling it.
+func beginDryRun(enabled bool) func() {
+	if !enabled {
+		return func() {}
+	}
+	plan := dryrun.Enable()
+	restore := commands.RecordTo(plan)
+	utils.PrintWarning("Dry run: commands and file writes are recorded, not performed")
+	return func() {
+		plan.Report(dryRunOut)
+		restore()
+		dryrun.Disable()
+	}
+}
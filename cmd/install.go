@@ -22,8 +22,9 @@ import (
 )
 
 var (
-	only []string
-	skip []string
+	only          []string
+	skip          []string
+	installDryRun bool
 )
 
 // knownCategories are the install categories accepted by --only/--skip.
@@ -90,11 +91,14 @@ Supported platforms:
 Flags:
   --only <...>     Only install specific categories or apps (e.g., terminal, neovim)
   --skip <...>     Skip specific categories or apps (e.g., databases, git)
+  --dry-run        Print the package-manager commands, file writes, and
+                   global_config.yaml changes instead of performing them
 
 Per-app targeting (registry apps only):
   dg install --only neovim            # install only neovim
   dg install --skip git               # install everything except git
   dg install --only terminal --skip lazygit  # full terminal minus lazygit
+  dg install --only neovim --dry-run  # show what installing neovim would do
 `,
 	RunE: run,
 }
@@ -106,6 +110,8 @@ func init() {
 		StringSliceVar(&only, "only", []string{}, "Only install specific categories or apps (comma-separated or repeatable)")
 	installCmd.Flags().
 		StringSliceVar(&skip, "skip", []string{}, "Skip specific categories or apps (comma-separated or repeatable)")
+	installCmd.Flags().
+		BoolVar(&installDryRun, "dry-run", false, "Show what would be installed, run, and written without changing anything")
 }
 
 func run(cmd *cobra.Command, args []string) error {
@@ -116,6 +122,8 @@ func run(cmd *cobra.Command, args []string) error {
 
 	logger.L().Debugw("install config", "cfg", cfg, "verbose", verbose)
 
+	defer beginDryRun(installDryRun)()
+
 	utils.PrintBold(constants.Devgita)
 	utils.Print("=> Begin installation (or abort with ctrl+c)...", "")
 	utils.Print("===============================================", "")
@@ -8,6 +8,7 @@ import (
 	"fmt"
 	"strings"
 	"text/tabwriter"
+	"time"
 
 	"github.com/cjairm/devgita/internal/config"
 	tuiinventory "github.com/cjairm/devgita/internal/tui/inventory"
@@ -19,8 +20,8 @@ import (
 type categoryDef struct {
 	Key              string
 	Label            string
-	Installed        func(*config.InstalledConfig) []string
-	AlreadyInstalled func(*config.AlreadyInstalledConfig) []string
+	Installed        func(*config.InstalledConfig) config.TrackedItems
+	AlreadyInstalled func(*config.AlreadyInstalledConfig) config.TrackedItems
 }
 
 // categoryDefs is iterated in this fixed order for stable, testable output.
@@ -28,44 +29,44 @@ var categoryDefs = []categoryDef{
 	{
 		Key:              "packages",
 		Label:            "Packages",
-		Installed:        func(c *config.InstalledConfig) []string { return c.Packages },
-		AlreadyInstalled: func(c *config.AlreadyInstalledConfig) []string { return c.Packages },
+		Installed:        func(c *config.InstalledConfig) config.TrackedItems { return c.Packages },
+		AlreadyInstalled: func(c *config.AlreadyInstalledConfig) config.TrackedItems { return c.Packages },
 	},
 	{
 		Key:              "desktop_apps",
 		Label:            "Desktop Apps",
-		Installed:        func(c *config.InstalledConfig) []string { return c.DesktopApps },
-		AlreadyInstalled: func(c *config.AlreadyInstalledConfig) []string { return c.DesktopApps },
+		Installed:        func(c *config.InstalledConfig) config.TrackedItems { return c.DesktopApps },
+		AlreadyInstalled: func(c *config.AlreadyInstalledConfig) config.TrackedItems { return c.DesktopApps },
 	},
 	{
 		Key:              "fonts",
 		Label:            "Fonts",
-		Installed:        func(c *config.InstalledConfig) []string { return c.Fonts },
-		AlreadyInstalled: func(c *config.AlreadyInstalledConfig) []string { return c.Fonts },
+		Installed:        func(c *config.InstalledConfig) config.TrackedItems { return c.Fonts },
+		AlreadyInstalled: func(c *config.AlreadyInstalledConfig) config.TrackedItems { return c.Fonts },
 	},
 	{
 		Key:              "themes",
 		Label:            "Themes",
-		Installed:        func(c *config.InstalledConfig) []string { return c.Themes },
-		AlreadyInstalled: func(c *config.AlreadyInstalledConfig) []string { return c.Themes },
+		Installed:        func(c *config.InstalledConfig) config.TrackedItems { return c.Themes },
+		AlreadyInstalled: func(c *config.AlreadyInstalledConfig) config.TrackedItems { return c.Themes },
 	},
 	{
 		Key:              "terminal_tools",
 		Label:            "Terminal Tools",
-		Installed:        func(c *config.InstalledConfig) []string { return c.TerminalTools },
-		AlreadyInstalled: func(c *config.AlreadyInstalledConfig) []string { return c.TerminalTools },
+		Installed:        func(c *config.InstalledConfig) config.TrackedItems { return c.TerminalTools },
+		AlreadyInstalled: func(c *config.AlreadyInstalledConfig) config.TrackedItems { return c.TerminalTools },
 	},
 	{
 		Key:              "dev_languages",
 		Label:            "Dev Languages",
-		Installed:        func(c *config.InstalledConfig) []string { return c.DevLanguages },
-		AlreadyInstalled: func(c *config.AlreadyInstalledConfig) []string { return c.DevLanguages },
+		Installed:        func(c *config.InstalledConfig) config.TrackedItems { return c.DevLanguages },
+		AlreadyInstalled: func(c *config.AlreadyInstalledConfig) config.TrackedItems { return c.DevLanguages },
 	},
 	{
 		Key:              "databases",
 		Label:            "Databases",
-		Installed:        func(c *config.InstalledConfig) []string { return c.Databases },
-		AlreadyInstalled: func(c *config.AlreadyInstalledConfig) []string { return c.Databases },
+		Installed:        func(c *config.InstalledConfig) config.TrackedItems { return c.Databases },
+		AlreadyInstalled: func(c *config.AlreadyInstalledConfig) config.TrackedItems { return c.Databases },
 	},
 }
 
@@ -92,7 +93,7 @@ func isValidCategory(category string) bool {
 func writeCategoryTables(
 	buf *bytes.Buffer,
 	heading string,
-	items func(categoryDef) []string,
+	items func(categoryDef) config.TrackedItems,
 	category string,
 ) bool {
 	wrote := false
@@ -100,8 +101,8 @@ func writeCategoryTables(
 		if category != "" && def.Key != category {
 			continue
 		}
-		names := items(def)
-		if len(names) == 0 {
+		tracked := items(def)
+		if len(tracked) == 0 {
 			continue
 		}
 		if !wrote && heading != "" {
@@ -110,9 +111,15 @@ func writeCategoryTables(
 		}
 		fmt.Fprintf(buf, "%s:\n", def.Label)
 		w := tabwriter.NewWriter(buf, 0, 0, 2, ' ', 0)
-		fmt.Fprintln(w, "NAME")
-		for _, name := range names {
-			fmt.Fprintf(w, "%s\n", name)
+		fmt.Fprintln(w, "NAME\tVERSION\tMETHOD\tINSTALLED\tDEVGITA")
+		for _, item := range tracked {
+			fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\n",
+				item.Name,
+				orDash(item.Version),
+				orDash(string(item.Method)),
+				formatTrackedAt(item.InstalledAt),
+				orDash(item.DevgitaVersion),
+			)
 		}
 		_ = w.Flush()
 		fmt.Fprintln(buf)
@@ -121,6 +128,22 @@ func writeCategoryTables(
 	return wrote
 }
 
+// orDash fills empty table cells, so details that were never recorded (items
+// tracked before schema version 2) read as unknown rather than misaligned.
+func orDash(s string) string {
+	if s == "" {
+		return "-"
+	}
+	return s
+}
+
+func formatTrackedAt(t time.Time) string {
+	if t.IsZero() {
+		return "-"
+	}
+	return t.Local().Format("2006-01-02")
+}
+
 // formatInstalled renders gc.Installed and gc.AlreadyInstalled as tables
 // grouped by category. category == "" means show all categories; otherwise
 // it must be one of the valid yaml key names, or an error is returned.
@@ -133,13 +156,13 @@ func formatInstalled(gc *config.GlobalConfig, category string) (string, error) {
 	}
 
 	var buf bytes.Buffer
-	installedWrote := writeCategoryTables(&buf, "", func(d categoryDef) []string {
+	installedWrote := writeCategoryTables(&buf, "", func(d categoryDef) config.TrackedItems {
 		return d.Installed(&gc.Installed)
 	}, category)
 	alreadyWrote := writeCategoryTables(
 		&buf,
 		"Already on this machine (not installed by Devgita):",
-		func(d categoryDef) []string { return d.AlreadyInstalled(&gc.AlreadyInstalled) },
+		func(d categoryDef) config.TrackedItems { return d.AlreadyInstalled(&gc.AlreadyInstalled) },
 		category,
 	)
 
@@ -166,6 +189,10 @@ with a live OK/MISSING/UNKNOWN status per item. Piped output, CI, or --plain
 fall back to the plain-text table (reads ~/.config/devgita/global_config.yaml
 directly, with no live status check).
 
+Both views show the version, install method, install date, and devgita
+version recorded for each item; "-" marks details tracked before devgita
+recorded them.
+
 Examples:
   dg list                          # Interactive dashboard in a terminal
   dg list --plain                  # Force the plain-text table
@@ -3,6 +3,7 @@ package cmd
 import (
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/cjairm/devgita/internal/config"
 	"github.com/cjairm/devgita/internal/testutil"
@@ -24,7 +25,7 @@ func TestFormatInstalled_EmptyConfig(t *testing.T) {
 
 func TestFormatInstalled_SingleCategory(t *testing.T) {
 	gc := &config.GlobalConfig{}
-	gc.Installed.Fonts = []string{"JetBrainsMono"}
+	gc.Installed.Fonts = config.NewTrackedItems("JetBrainsMono")
 
 	out, err := formatInstalled(gc, "")
 
@@ -36,9 +37,9 @@ func TestFormatInstalled_SingleCategory(t *testing.T) {
 
 func TestFormatInstalled_MultipleCategories(t *testing.T) {
 	gc := &config.GlobalConfig{}
-	gc.Installed.Packages = []string{"git", "tmux"}
-	gc.Installed.TerminalTools = []string{"neovim"}
-	gc.AlreadyInstalled.Databases = []string{"postgres"}
+	gc.Installed.Packages = config.NewTrackedItems("git", "tmux")
+	gc.Installed.TerminalTools = config.NewTrackedItems("neovim")
+	gc.AlreadyInstalled.Databases = config.NewTrackedItems("postgres")
 
 	out, err := formatInstalled(gc, "")
 
@@ -68,9 +69,9 @@ func TestFormatInstalled_MultipleCategories(t *testing.T) {
 
 func TestFormatInstalled_CategoryFilter(t *testing.T) {
 	gc := &config.GlobalConfig{}
-	gc.Installed.Fonts = []string{"JetBrainsMono"}
-	gc.Installed.Packages = []string{"git"}
-	gc.AlreadyInstalled.Fonts = []string{"Menlo"}
+	gc.Installed.Fonts = config.NewTrackedItems("JetBrainsMono")
+	gc.Installed.Packages = config.NewTrackedItems("git")
+	gc.AlreadyInstalled.Fonts = config.NewTrackedItems("Menlo")
 
 	out, err := formatInstalled(gc, "fonts")
 
@@ -123,3 +124,22 @@ func TestListCmd_LoadFailure(t *testing.T) {
 
 	require.Error(t, err)
 }
+
+func TestFormatInstalled_ShowsInstallRecord(t *testing.T) {
+	gc := &config.GlobalConfig{}
+	gc.Installed.Packages = config.TrackedItems{{
+		Name:           "lazygit",
+		Version:        "0.44.1",
+		Method:         config.MethodGitHubBinary,
+		InstalledAt:    time.Date(2026, 3, 1, 12, 0, 0, 0, time.Local),
+		DevgitaVersion: "v1.2.0",
+	}}
+	gc.AlreadyInstalled.Packages = config.NewTrackedItems("curl")
+
+	out, err := formatInstalled(gc, "")
+
+	require.NoError(t, err)
+	assert.Contains(t, out, "NAME     VERSION  METHOD         INSTALLED   DEVGITA")
+	assert.Regexp(t, `lazygit\s+0\.44\.1\s+github-binary\s+2026-03-01\s+v1\.2\.0`, out)
+	assert.Regexp(t, `curl\s+-\s+-\s+-\s+-`, out)
+}
@@ -6,6 +6,7 @@ package cmd
 import (
 	"fmt"
 
+	"github.com/cjairm/devgita/internal/config"
 	"github.com/cjairm/devgita/pkg/logger"
 	"github.com/cjairm/devgita/pkg/utils"
 	"github.com/spf13/cobra"
@@ -37,9 +38,10 @@ Available Commands:
   configure      Apply configuration files for a named app (e.g., dg configure git)
   re-configure   Re-apply configuration even if already present
   uninstall      Remove previously installed apps or assets (fonts/themes) safely
-  update         Update selected apps (e.g., --neovim, --aerospace)
+  update         Upgrade apps devgita installed (e.g., neovim, terminal)
   list           View all items installed via Devgita
   check-updates  See if any managed apps have updates
+  doctor         Check shell wiring, configs, and tools end-to-end (--fix)
   backup         Create a backup of your current Devgita-managed environment
   restore        Restore a previous backup configuration
   change         Change font or theme (--theme=..., --font=...)
@@ -50,7 +52,7 @@ Examples:
   dg uninstall --font=my-font --app=aerospace
   dg re-configure --app=neovim
   dg change --theme=tokyonight --font=JetBrainsMono
-  dg backup --output=~/dg_backup.json
+  dg backup before-upgrade
 `,
 }
 
@@ -67,6 +69,15 @@ func init() {
 	rootCmd.PersistentPreRunE = func(cmd *cobra.Command, args []string) error {
 		// Init logger here using the global verbose flag
 		logger.Init(verbose)
+
+		config.DevgitaVersion, _, _ = resolveVersionInfo()
+		// Upgrade an older global_config.yaml in place before anything reads
+		// it, except when the command promises to change nothing.
+		if dryRun, _ := cmd.Flags().GetBool("dry-run"); !dryRun {
+			if _, err := config.MigrateFile(); err != nil {
+				logger.L().Warnw("Failed to migrate global config", "error", err)
+			}
+		}
 		return nil
 	}
 
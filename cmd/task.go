@@ -33,8 +33,8 @@ type taskRunner interface {
 	ReinstallLibrary(name string) error
 	DeleteBranch(target string) error
 	ReviewScope(bodies bool) (string, error)
-	BranchDiff(file string) (string, error)
-	ReviewPackage(base, head, file string) (string, error)
+	BranchDiff(file string, maxTokens, page int) (string, error)
+	ReviewPackage(base, head, file string, maxTokens, page int) (string, error)
 	WorktreeStart(name, base string) (string, error)
 	WorktreeFinish(name string, merge, discard, force bool) (string, error)
 	Release(version, messageFile string, push bool) (string, error)
@@ -46,7 +46,7 @@ var newTaskManager = func() taskRunner { return task.New() }
 var taskCmd = &cobra.Command{
 	Use:     "task",
 	Aliases: []string{"t"},
-	Short:   "Developer utilities (git, npm, GitHub PRs) callable by agents and humans",
+	Short:   "Developer utilities (git, npm, GitHub/GitLab PRs) callable by agents and humans",
 	Long: `Developer utility commands callable by agents (Claude Code, CI, any
 non-interactive process) and humans (via the dge() shell wrapper or directly).
 
@@ -56,13 +56,15 @@ Six families:
   - worktree lifecycle: worktree-start, worktree-finish
   - release:     release
   - npm deps:    reinstall-libraries, reinstall-library
-  - GitHub PRs:  review-threads, resolve/unresolve/reply-thread, submit-review,
+  - PRs:         review-threads, resolve/unresolve/reply-thread, submit-review,
                  create-pr, update-pr-description, approve-pr, request-changes-pr,
-                 comment-pr, merge-pr, pr-view, pr-checks, current-pr, current-repo
+                 comment-pr, merge-pr, pr-view, pr-checks, pr-watch, current-pr,
+                 current-repo
 
 review-scope and PR data commands return compact, LLM-oriented output
-(review-scope/branch-diff/review-package parse git plumbing; PR commands run
-gh + jq). Run "dg task <subcommand> --help" for flags and examples.`,
+(review-scope/branch-diff/review-package parse git plumbing; PR commands call
+the GitHub API or run gh/glab). Run "dg task <subcommand> --help" for flags
+and examples.`,
 	Example: `  dg task review-threads --state unresolved
   dg task pr-view
   dg task refresh-branch
@@ -148,6 +150,13 @@ var taskBranchDiffFileFlag string
 // taskReviewPackageFileFlag is review-package's --file flag.
 var taskReviewPackageFileFlag string
 
+// taskDiffMaxTokensFlag and taskDiffPageFlag are the --max-tokens and --page
+// flags shared by branch-diff and review-package.
+var (
+	taskDiffMaxTokensFlag int
+	taskDiffPageFlag      int
+)
+
 // taskReviewScopeBodiesFlag is review-scope's --bodies flag.
 var taskReviewScopeBodiesFlag bool
 
@@ -158,7 +167,10 @@ var taskReviewScopeCmd = &cobra.Command{
 compact orientation report: ahead/behind counts, commit lines (short SHA, ISO
 date, subject), and a per-file stat table. Lockfile-style noise
 (package-lock.json, go.sum, *.min.js, ...) is excluded from the table and
-noted separately with its own stat counts, never silently dropped.
+noted separately with its own stat counts, never silently dropped. A repo adds
+its own patterns under "exclude:" in .devgita/review.yaml, and files
+.gitattributes marks linguist-generated or -diff are excluded too; each note
+names the rule that excluded the file.
 
 --bodies appends each commit's body as indented lines beneath its subject.
 
@@ -179,19 +191,28 @@ var taskBranchDiffCmd = &cobra.Command{
 	Use:   "branch-diff",
 	Short: "Show the merge-base diff against the default branch, noise excluded (for agents)",
 	Long: `Diff the current branch against its merge-base with the default branch.
-Lockfile-style noise (package-lock.json, go.sum, *.min.js, ...) is excluded by
-default and noted separately with its own stat counts.
+Lockfile-style noise (package-lock.json, go.sum, *.min.js, ...), the repo's
+.devgita/review.yaml patterns, and files .gitattributes marks
+linguist-generated or -diff are excluded, and noted separately with their own
+stat counts and the rule that excluded them.
 
 --file bypasses exclusions and returns just that file's diff, including an
 otherwise-excluded file.
 
+--max-tokens N splits a large diff into pages of about N tokens (estimated
+as bytes/4) at file and hunk boundaries, headers and notes included. Page 1
+leads with a contents table of every page and file with its size; --page K
+prints page K.
+
 Does not fetch: run "dg task review-scope" first in the same review session,
 since re-fetching per file pull could shift the comparison base mid-review.`,
 	Example: `  dg task branch-diff
-  dg task branch-diff --file internal/tooling/task/scope.go`,
+  dg task branch-diff --file internal/tooling/task/scope.go
+  dg task branch-diff --max-tokens 20000 --page 2`,
 	Args: cobra.NoArgs,
 	RunE: func(cmd *cobra.Command, args []string) error {
-		out, err := newTaskManager().BranchDiff(taskBranchDiffFileFlag)
+		out, err := newTaskManager().
+			BranchDiff(taskBranchDiffFileFlag, taskDiffMaxTokensFlag, taskDiffPageFlag)
 		return emitPRResult(cmd, out, err)
 	},
 }
@@ -204,22 +225,31 @@ the base..head range, the commit list (short SHA, date, subject), a per-file
 stat table, and the full -U10-context diff of the included files, fenced as
 ` + "```diff" + `.
 
-Lockfile-style noise (package-lock.json, go.sum, *.min.js, ...) is excluded
-from the stat table and diff, and noted separately with its own stat counts —
-never silently dropped.
+Lockfile-style noise (package-lock.json, go.sum, *.min.js, ...), the repo's
+.devgita/review.yaml patterns, and files .gitattributes marks
+linguist-generated or -diff are excluded from the stat table and diff, and
+noted separately with their own stat counts and rule — never silently dropped.
 
 Unlike review-scope/branch-diff, base and head are not tied to the current
 branch's default-branch merge-base: this is for reviewing an arbitrary
 historical range or a PR that isn't checked out.
 
 --file bypasses exclusions and returns just that file's -U10 diff, including
-an otherwise-excluded file.`,
+an otherwise-excluded file.
+
+--max-tokens N splits a large diff into pages of about N tokens (estimated
+as bytes/4) at file and hunk boundaries. Page 1 carries the commit list, stat
+table, and a contents table of every page and file with its size, all counted
+against its budget; --page K prints page K's diff.`,
 	Example: `  dg task review-package main feature-branch
   dg task review-package v1.2.0 v1.3.0
-  dg task review-package main feature-branch --file internal/tooling/task/reviewpackage.go`,
+  dg task review-package main feature-branch --file internal/tooling/task/reviewpackage.go
+  dg task review-package main feature-branch --max-tokens 20000 --page 2`,
 	Args: cobra.ExactArgs(2),
 	RunE: func(cmd *cobra.Command, args []string) error {
-		out, err := newTaskManager().ReviewPackage(args[0], args[1], taskReviewPackageFileFlag)
+		out, err := newTaskManager().ReviewPackage(
+			args[0], args[1], taskReviewPackageFileFlag, taskDiffMaxTokensFlag, taskDiffPageFlag,
+		)
 		return emitPRResult(cmd, out, err)
 	},
 }
@@ -245,7 +275,13 @@ it and vice versa.
 --base sets the branch's starting point explicitly (any ref: a branch, tag, or
 SHA). Without --base, the new branch is based on the repo's freshly-fetched
 default branch (or an existing local/remote branch of the same name, if one
-already exists).`,
+already exists).
+
+The repo's bootstrap hooks then run in the new worktree: .devgita/worktree.yaml
+(or ~/.config/devgita/worktree.yaml when the repo has none) lists files to copy
+or symlink in from the main checkout and setup commands to run. Setup output
+streams here and is logged under ~/.local/share/devgita/worktree-logs/. A
+bootstrap failure is printed as a warning; the worktree is still created.`,
 	Example: `  dg task worktree-start add-retry-logic
   dg task worktree-start hotfix-123 --base origin/release-2.0`,
 	Args: cobra.ExactArgs(1),
@@ -343,6 +379,15 @@ func init() {
 		StringVar(&taskBranchDiffFileFlag, "file", "", "Diff only this file, bypassing exclusions")
 	taskReviewPackageCmd.Flags().
 		StringVar(&taskReviewPackageFileFlag, "file", "", "Diff only this file, bypassing exclusions")
+	for _, c := range []*cobra.Command{taskBranchDiffCmd, taskReviewPackageCmd} {
+		c.Flags().IntVar(
+			&taskDiffMaxTokensFlag,
+			"max-tokens",
+			0,
+			"Split the diff into pages of about this many tokens (0: no paging)",
+		)
+		c.Flags().IntVar(&taskDiffPageFlag, "page", 0, "Page to print with --max-tokens (default: 1)")
+	}
 	taskReviewScopeCmd.Flags().
 		BoolVar(&taskReviewScopeBodiesFlag, "bodies", false, "Append each commit's body beneath its subject")
 
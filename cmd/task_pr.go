@@ -7,8 +7,10 @@ import (
 	"fmt"
 	"os"
 	"strings"
+	"time"
 
 	"github.com/cjairm/devgita/internal/tooling/task"
+	"github.com/cjairm/devgita/pkg/utils"
 	"github.com/spf13/cobra"
 )
 
@@ -29,6 +31,7 @@ type prRunner interface {
 	MergePR(prNumber, method string) (string, error)
 	PRView(prNumber string) (string, error)
 	PRChecks(prNumber string) (string, error)
+	WatchChecks(prNumber string, timeout time.Duration) (string, task.ChecksOutcome, error)
 	CurrentPR() (string, error)
 	CurrentRepo() (string, error)
 }
@@ -48,6 +51,7 @@ var (
 	prMethodFlag   string
 	prEventFlag    string
 	prCommentsFile string
+	prTimeoutFlag  time.Duration
 )
 
 // resolveBody returns the body text to use, preferring --body-file over --body.
@@ -307,6 +311,51 @@ var taskPRChecksCmd = &cobra.Command{
 	},
 }
 
+// Exit statuses of dg task pr-watch beyond 0 (every check passed) and 1
+// (the watch itself errored, as for any dg command). 8 follows gh pr checks
+// for checks still pending; a failed check gets 2 rather than gh's 1, so a
+// red CI can't be mistaken for a broken watch.
+const (
+	prWatchExitFailed   = 2
+	prWatchExitTimedOut = 8
+)
+
+var taskPRWatchCmd = &cobra.Command{
+	Use:   "pr-watch",
+	Short: "Wait for a pull request's CI checks to finish, then show them",
+	Long: `Poll a pull request's CI checks, backing off from 10s to a minute between
+polls, until none is pending, then print a one-line verdict followed by the
+same report as pr-checks, log digests of failing checks included. If new
+commits are pushed while watching, the watch restarts on the new head and the
+report notes it.
+
+--timeout bounds the wait (default 30m; 0 waits indefinitely).
+--pr targets a PR number; omit it to use the current branch's PR.
+
+Exit status: 0 when every check passed (or there are none), 1 when the watch
+itself errored (auth, network, no PR for the branch), 2 when a check failed or
+was cancelled, 8 when the timeout ran out with checks still pending.`,
+	Example: `  dg task pr-watch                       # current branch's PR
+  dg task pr-watch --pr 42 --timeout 1h`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		out, outcome, err := newPRTasks().WatchChecks(prFlag, prTimeoutFlag)
+		if err := emitPRResult(cmd, out, err); err != nil {
+			return err
+		}
+		// The report is already printed; the exit status carries the verdict.
+		switch outcome {
+		case task.ChecksFailed:
+			cmd.SilenceErrors = true
+			return &utils.ExitError{Code: prWatchExitFailed, Message: "checks failed"}
+		case task.ChecksTimedOut:
+			cmd.SilenceErrors = true
+			return &utils.ExitError{Code: prWatchExitTimedOut, Message: "timed out waiting for checks"}
+		}
+		return nil
+	},
+}
+
 var taskCurrentPRCmd = &cobra.Command{
 	Use:   "current-pr",
 	Short: "Print the PR number for the current branch",
@@ -342,6 +391,7 @@ func init() {
 	taskCmd.AddCommand(taskMergePRCmd)
 	taskCmd.AddCommand(taskPRViewCmd)
 	taskCmd.AddCommand(taskPRChecksCmd)
+	taskCmd.AddCommand(taskPRWatchCmd)
 	taskCmd.AddCommand(taskCurrentPRCmd)
 	taskCmd.AddCommand(taskCurrentRepoCmd)
 
@@ -403,4 +453,8 @@ func init() {
 
 	taskPRViewCmd.Flags().StringVar(&prFlag, "pr", "", "PR number (default: current branch)")
 	taskPRChecksCmd.Flags().StringVar(&prFlag, "pr", "", "PR number (default: current branch)")
+
+	taskPRWatchCmd.Flags().StringVar(&prFlag, "pr", "", "PR number (default: current branch)")
+	taskPRWatchCmd.Flags().
+		DurationVar(&prTimeoutFlag, "timeout", 30*time.Minute, "Give up after this long (0: no limit)")
 }
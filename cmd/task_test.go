@@ -1,10 +1,15 @@
 package cmd
 
 import (
+	"errors"
 	"fmt"
 	"os"
 	"strings"
 	"testing"
+	"time"
+
+	"github.com/cjairm/devgita/internal/tooling/task"
+	"github.com/cjairm/devgita/pkg/utils"
 )
 
 // mockTaskRunner records calls to each task method.
@@ -33,6 +38,7 @@ type mockTaskRunner struct {
 	reviewScopeErr    error
 
 	branchDiffArg    string
+	branchDiffPaging [2]int // maxTokens, page
 	branchDiffCalled bool
 	branchDiffRet    string
 	branchDiffErr    error
@@ -40,6 +46,7 @@ type mockTaskRunner struct {
 	reviewPackageBaseArg string
 	reviewPackageHeadArg string
 	reviewPackageFileArg string
+	reviewPackagePaging  [2]int // maxTokens, page
 	reviewPackageCalled  bool
 	reviewPackageRet     string
 	reviewPackageErr     error
@@ -100,17 +107,19 @@ func (m *mockTaskRunner) ReviewScope(bodies bool) (string, error) {
 	return m.reviewScopeRet, m.reviewScopeErr
 }
 
-func (m *mockTaskRunner) BranchDiff(file string) (string, error) {
+func (m *mockTaskRunner) BranchDiff(file string, maxTokens, page int) (string, error) {
 	m.branchDiffCalled = true
 	m.branchDiffArg = file
+	m.branchDiffPaging = [2]int{maxTokens, page}
 	return m.branchDiffRet, m.branchDiffErr
 }
 
-func (m *mockTaskRunner) ReviewPackage(base, head, file string) (string, error) {
+func (m *mockTaskRunner) ReviewPackage(base, head, file string, maxTokens, page int) (string, error) {
 	m.reviewPackageCalled = true
 	m.reviewPackageBaseArg = base
 	m.reviewPackageHeadArg = head
 	m.reviewPackageFileArg = file
+	m.reviewPackagePaging = [2]int{maxTokens, page}
 	return m.reviewPackageRet, m.reviewPackageErr
 }
 
@@ -403,6 +412,23 @@ func TestTask_BranchDiff(t *testing.T) {
 		}
 	})
 
+	t.Run("passes --max-tokens and --page", func(t *testing.T) {
+		mock := &mockTaskRunner{branchDiffRet: "showing page 2 of 3"}
+		restore := setupTaskMock(t, mock)
+		defer restore()
+		taskBranchDiffFileFlag = ""
+		taskDiffMaxTokensFlag, taskDiffPageFlag = 4000, 2
+		defer func() { taskDiffMaxTokensFlag, taskDiffPageFlag = 0, 0 }()
+
+		err := taskBranchDiffCmd.RunE(taskBranchDiffCmd, []string{})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if mock.branchDiffPaging != [2]int{4000, 2} {
+			t.Errorf("expected max-tokens 4000 and page 2, got %v", mock.branchDiffPaging)
+		}
+	})
+
 	t.Run("propagates error", func(t *testing.T) {
 		mock := &mockTaskRunner{branchDiffErr: fmt.Errorf("diff failed")}
 		restore := setupTaskMock(t, mock)
@@ -457,6 +483,23 @@ func TestTask_ReviewPackage(t *testing.T) {
 		}
 	})
 
+	t.Run("passes --max-tokens and --page", func(t *testing.T) {
+		mock := &mockTaskRunner{reviewPackageRet: "range: main..feat"}
+		restore := setupTaskMock(t, mock)
+		defer restore()
+		taskReviewPackageFileFlag = ""
+		taskDiffMaxTokensFlag, taskDiffPageFlag = 4000, 2
+		defer func() { taskDiffMaxTokensFlag, taskDiffPageFlag = 0, 0 }()
+
+		err := taskReviewPackageCmd.RunE(taskReviewPackageCmd, []string{"main", "feat"})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if mock.reviewPackagePaging != [2]int{4000, 2} {
+			t.Errorf("expected max-tokens 4000 and page 2, got %v", mock.reviewPackagePaging)
+		}
+	})
+
 	t.Run("propagates error", func(t *testing.T) {
 		mock := &mockTaskRunner{reviewPackageErr: fmt.Errorf("unrecognized ref")}
 		restore := setupTaskMock(t, mock)
@@ -673,6 +716,7 @@ type mockPRRunner struct {
 	lastArg map[string]string
 	ret     string
 	err     error
+	outcome task.ChecksOutcome
 }
 
 func newMockPRRunner() *mockPRRunner {
@@ -744,6 +788,10 @@ func (m *mockPRRunner) PRView(pr string) (string, error)   { return m.record("PR
 func (m *mockPRRunner) PRChecks(pr string) (string, error) { return m.record("PRChecks", "pr", pr) }
 func (m *mockPRRunner) CurrentPR() (string, error)         { return m.record("CurrentPR") }
 func (m *mockPRRunner) CurrentRepo() (string, error)       { return m.record("CurrentRepo") }
+func (m *mockPRRunner) WatchChecks(pr string, timeout time.Duration) (string, task.ChecksOutcome, error) {
+	out, err := m.record("WatchChecks", "pr", pr, "timeout", timeout.String())
+	return out, m.outcome, err
+}
 
 func setupPRMock(t *testing.T, mock prRunner) func() {
 	t.Helper()
@@ -882,6 +930,54 @@ func TestPRTask_Dispatch(t *testing.T) {
 	})
 }
 
+func TestPRTask_Watch(t *testing.T) {
+	cases := []struct {
+		name     string
+		outcome  task.ChecksOutcome
+		wantCode int // 0: no error
+	}{
+		{"passed exits cleanly", task.ChecksPassed, 0},
+		{"failed exits 2", task.ChecksFailed, 2},
+		{"timed out exits 8", task.ChecksTimedOut, 8},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			mock := newMockPRRunner()
+			mock.outcome = c.outcome
+			defer setupPRMock(t, mock)()
+			prFlag, prTimeoutFlag = "42", time.Hour
+			defer func() { prFlag, prTimeoutFlag = "", 30*time.Minute }()
+
+			err := taskPRWatchCmd.RunE(taskPRWatchCmd, nil)
+			if mock.lastArg["pr"] != "42" || mock.lastArg["timeout"] != "1h0m0s" {
+				t.Fatalf("unexpected args: %v", mock.lastArg)
+			}
+			var exitErr *utils.ExitError
+			switch {
+			case c.wantCode == 0 && err != nil:
+				t.Fatalf("unexpected error: %v", err)
+			case c.wantCode != 0 && !errors.As(err, &exitErr):
+				t.Fatalf("expected an ExitError, got %v", err)
+			case c.wantCode != 0 && exitErr.Code != c.wantCode:
+				t.Fatalf("expected exit %d, got %d", c.wantCode, exitErr.Code)
+			}
+		})
+	}
+
+	t.Run("watch errors are not exit codes", func(t *testing.T) {
+		mock := newMockPRRunner()
+		mock.err = fmt.Errorf("boom")
+		mock.outcome = task.ChecksFailed
+		defer setupPRMock(t, mock)()
+
+		err := taskPRWatchCmd.RunE(taskPRWatchCmd, nil)
+		var exitErr *utils.ExitError
+		if err == nil || errors.As(err, &exitErr) {
+			t.Fatalf("expected the plain error (exit 1), got %v", err)
+		}
+	})
+}
+
 func TestPRTask_BodyFile(t *testing.T) {
 	t.Run("create-pr reads markdown body from file verbatim", func(t *testing.T) {
 		mock := newMockPRRunner()
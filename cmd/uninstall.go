@@ -15,6 +15,8 @@ import (
 	"github.com/spf13/cobra"
 )
 
+var uninstallDryRun bool
+
 // uninstallGetAppFn is the registry lookup for uninstall; overridden in tests.
 var uninstallGetAppFn = func(name string) (apps.App, error) {
 	return registry.GetApp(name)
@@ -29,6 +31,7 @@ Only removes apps that devgita originally installed. Pre-existing apps are skipp
 Examples:
   dg uninstall git           # uninstall a single app
   dg uninstall terminal      # uninstall all terminal apps devgita installed
+  dg uninstall tmux --dry-run  # show the commands and removals without running them
 `,
 	Args: cobra.ExactArgs(1),
 	RunE: runUninstall,
@@ -36,6 +39,9 @@ Examples:
 
 func init() {
 	rootCmd.AddCommand(uninstallCmd)
+
+	uninstallCmd.Flags().
+		BoolVar(&uninstallDryRun, "dry-run", false, "Show what would be removed without removing anything")
 }
 
 func runUninstall(_ *cobra.Command, args []string) error {
@@ -69,6 +75,8 @@ func runUninstall(_ *cobra.Command, args []string) error {
 		targets = []string{target}
 	}
 
+	defer beginDryRun(uninstallDryRun)()
+
 	gc := &config.GlobalConfig{}
 	if err := gc.Load(); err != nil {
 		return fmt.Errorf("failed to load global config: %w", err)
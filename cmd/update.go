@@ -0,0 +1,214 @@
+/*
+* Copyright © 2025 Carlos Mendez <carlos@hadaelectronics.com> | https://cjairm.me/
+ */
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/cjairm/devgita/internal/apps"
+	"github.com/cjairm/devgita/internal/apps/registry"
+	"github.com/cjairm/devgita/internal/commands"
+	"github.com/cjairm/devgita/internal/config"
+	"github.com/cjairm/devgita/pkg/logger"
+	"github.com/cjairm/devgita/pkg/utils"
+	"github.com/spf13/cobra"
+)
+
+var updateDryRun bool
+
+// updateGetAppFn is the registry lookup for update; overridden in tests.
+var updateGetAppFn = func(name string) (apps.App, error) {
+	return registry.GetApp(name)
+}
+
+// newUpdateCommand returns the platform command used to refresh the package
+// index before upgrading; overridden in tests.
+var newUpdateCommand = commands.NewCommand
+
+var updateCmd = &cobra.Command{
+	Use:   "update [app|category]",
+	Short: "Upgrade apps installed by devgita",
+	Long: `Upgrades apps devgita installed, using the same method that installed them:
+brew upgrade on macOS; apt --only-upgrade (including PPAs), a fresh
+checksum-verified GitHub release download, or a re-run of the install
+script on Debian/Ubuntu. After each upgrade the app's configuration is
+re-applied the soft way (existing files are left alone) and the new version
+is recorded in global_config.yaml.
+
+With no argument every registered app is considered. Pre-existing apps
+(not installed by devgita) are skipped, as are apps that have no upgrade
+path. Failed upgrades are recorded under failed_installations, and cleared
+again by the next successful one. Run ` + "`dg check-updates`" + ` first to see what
+is outdated.
+
+Examples:
+  dg update                  # upgrade everything devgita installed
+  dg update neovim           # upgrade a single app
+  dg update terminal         # upgrade all terminal apps devgita installed
+  dg update git --dry-run    # show the commands without running them
+`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: runUpdate,
+}
+
+func init() {
+	rootCmd.AddCommand(updateCmd)
+
+	updateCmd.Flags().
+		BoolVar(&updateDryRun, "dry-run", false, "Show what would be upgraded without changing anything")
+}
+
+func runUpdate(_ *cobra.Command, args []string) error {
+	targets, err := resolveUpdateTargets(args)
+	if err != nil {
+		return err
+	}
+
+	defer beginDryRun(updateDryRun)()
+
+	gc := &config.GlobalConfig{}
+	if err := gc.Load(); err != nil {
+		return fmt.Errorf("failed to load global config: %w", err)
+	}
+
+	var pending []string
+	for _, name := range targets {
+		if gc.IsInstalledByDevgita(name, registry.Meta[name].ItemType) {
+			pending = append(pending, name)
+			continue
+		}
+		logger.L().Infow("skipping: not installed by devgita", "app", name)
+		if len(args) > 0 {
+			utils.PrintInfo(fmt.Sprintf("skipping %s: not installed by devgita", name))
+		}
+	}
+	if len(pending) == 0 {
+		utils.PrintInfo("Nothing to update")
+		return nil
+	}
+
+	if err := newUpdateCommand().RefreshPackageIndex(); err != nil {
+		// Upgrades still work against a stale index; they just may not find
+		// the newest release.
+		utils.PrintWarning(fmt.Sprintf("could not refresh the package index: %v", err))
+	}
+
+	var failedApps []string
+	for _, name := range pending {
+		if !updateApp(name) {
+			failedApps = append(failedApps, name)
+		}
+	}
+
+	if len(failedApps) > 0 {
+		return fmt.Errorf("update failed for: %s", strings.Join(failedApps, ", "))
+	}
+	return nil
+}
+
+// resolveUpdateTargets expands the optional app/category argument into app
+// names, validated the same way dg uninstall validates its target.
+func resolveUpdateTargets(args []string) ([]string, error) {
+	if len(args) == 0 {
+		var all []string
+		for _, category := range registry.KnownCategories() {
+			all = append(all, registry.AppsByCoordinator(category)...)
+		}
+		return all, nil
+	}
+
+	target := args[0]
+	if target == "languages" || target == "databases" {
+		return nil, fmt.Errorf(
+			"dg update %s is not yet supported — manage runtimes via mise",
+			target,
+		)
+	}
+	if target == "devgita" {
+		return nil, fmt.Errorf("devgita cannot update itself — install the new release instead")
+	}
+	if registry.IsKnownCategory(target) {
+		return registry.AppsByCoordinator(target), nil
+	}
+	if registry.IsKnownApp(target) {
+		return []string{target}, nil
+	}
+	return nil, fmt.Errorf(
+		"unknown target %q\n\nValid categories: %s\nValid apps: see `dg install --help`",
+		target,
+		strings.Join(registry.KnownCategories(), ", "),
+	)
+}
+
+// updateApp upgrades one app, re-applies its soft configuration, and records
+// the outcome in global_config.yaml. It reports whether the app ended up
+// updated (or had nothing to update).
+func updateApp(name string) bool {
+	app, err := updateGetAppFn(name)
+	if err != nil {
+		logger.L().Errorw("failed to get app", "app", name, "error", err)
+		return false
+	}
+
+	if err := app.Update(); err != nil {
+		if errors.Is(err, apps.ErrUpdateNotSupported) {
+			utils.PrintInfo(fmt.Sprintf("skipping %s: %v", name, err))
+			return true
+		}
+		logger.L().Errorw("update failed", "app", name, "error", err)
+		utils.PrintError(fmt.Sprintf("failed to update %s: %v", name, err))
+		recordUpdateOutcome(name, err)
+		return false
+	}
+	recordUpdateOutcome(name, nil)
+
+	// Upgrades can leave new defaults behind; SoftConfigure fills in what is
+	// missing without touching files the user has edited.
+	if err := app.SoftConfigure(); err != nil && !errors.Is(err, apps.ErrConfigureNotSupported) {
+		logger.L().Errorw("re-applying configuration failed", "app", name, "error", err)
+		utils.PrintError(fmt.Sprintf("updated %s but failed to re-apply its configuration: %v", name, err))
+		return false
+	}
+
+	utils.PrintSuccess(fmt.Sprintf("updated %s%s", name, updatedVersionSuffix(name)))
+	return true
+}
+
+// recordUpdateOutcome adds a failed upgrade to failed_installations, or clears
+// an earlier failure once the app upgrades cleanly. It reloads the config
+// because Update itself rewrites the install record.
+func recordUpdateOutcome(name string, updateErr error) {
+	gc := &config.GlobalConfig{}
+	if err := gc.Load(); err != nil {
+		logger.L().Warnw("could not load global config to record update", "app", name, "error", err)
+		return
+	}
+	if updateErr != nil {
+		gc.AddToFailed(
+			name,
+			registry.Meta[name].Coordinator,
+			updateErr.Error(),
+			gc.FailedAttempts(name)+1,
+		)
+	} else if !gc.RemoveFromFailed(name) {
+		return
+	}
+	if err := gc.Save(); err != nil {
+		logger.L().Warnw("could not save update outcome", "app", name, "error", err)
+	}
+}
+
+func updatedVersionSuffix(name string) string {
+	gc := &config.GlobalConfig{}
+	if err := gc.Load(); err != nil {
+		return ""
+	}
+	item, ok := gc.InstalledItem(name, registry.Meta[name].ItemType)
+	if !ok || item.Version == "" {
+		return ""
+	}
+	return " to " + item.Version
+}
@@ -0,0 +1,161 @@
+package cmd
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/cjairm/devgita/internal/apps"
+	"github.com/cjairm/devgita/internal/commands"
+	"github.com/cjairm/devgita/internal/config"
+	"github.com/cjairm/devgita/internal/testutil"
+	"github.com/cjairm/devgita/pkg/constants"
+)
+
+// mockUpdateApp records Update/SoftConfigure calls and returns a preset error.
+type mockUpdateApp struct {
+	mockUninstallApp
+	updateCalled    bool
+	configureCalled bool
+	updateErr       error
+}
+
+func (m *mockUpdateApp) Update() error {
+	m.updateCalled = true
+	return m.updateErr
+}
+
+func (m *mockUpdateApp) SoftConfigure() error {
+	m.configureCalled = true
+	return nil
+}
+
+// setupUpdateSeams overrides updateGetAppFn and newUpdateCommand and returns
+// the mock command so tests can check the index refresh.
+func setupUpdateSeams(t *testing.T, mock apps.App) *commands.MockCommand {
+	t.Helper()
+	origGetApp, origCommand := updateGetAppFn, newUpdateCommand
+	mockCmd := commands.NewMockCommand()
+	updateGetAppFn = func(name string) (apps.App, error) { return mock, nil }
+	newUpdateCommand = func() commands.Command { return mockCmd }
+	t.Cleanup(func() {
+		updateGetAppFn = origGetApp
+		newUpdateCommand = origCommand
+	})
+	return mockCmd
+}
+
+func loadUpdateConfig(t *testing.T) *config.GlobalConfig {
+	t.Helper()
+	gc := &config.GlobalConfig{}
+	if err := gc.Load(); err != nil {
+		t.Fatalf("failed to load config: %v", err)
+	}
+	return gc
+}
+
+func TestUpdate_LanguagesBlocked(t *testing.T) {
+	err := runUpdate(updateCmd, []string{"languages"})
+	if err == nil || !strings.Contains(err.Error(), "not yet supported") {
+		t.Errorf("expected 'not yet supported' error, got: %v", err)
+	}
+}
+
+func TestUpdate_UnknownTarget(t *testing.T) {
+	err := runUpdate(updateCmd, []string{"notanapp"})
+	if err == nil || !strings.Contains(err.Error(), "unknown target") {
+		t.Errorf("expected 'unknown target' error, got: %v", err)
+	}
+}
+
+func TestUpdate_SkipsAppNotInstalledByDevgita(t *testing.T) {
+	tc := testutil.SetupCompleteTest(t)
+	defer tc.Cleanup()
+
+	mock := &mockUpdateApp{mockUninstallApp: mockUninstallApp{name: constants.Fastfetch}}
+	mockCmd := setupUpdateSeams(t, mock)
+
+	if err := runUpdate(updateCmd, []string{constants.Fastfetch}); err != nil {
+		t.Fatalf("expected no error, got: %v", err)
+	}
+	if mock.updateCalled {
+		t.Error("expected Update NOT to be called for an untracked app")
+	}
+	if mockCmd.IndexRefreshed {
+		t.Error("expected no index refresh when nothing is pending")
+	}
+}
+
+func TestUpdate_SuccessClearsFailedEntry(t *testing.T) {
+	tc := testutil.SetupCompleteTest(t)
+	defer tc.Cleanup()
+
+	configWithPackages(t, tc.ConfigPath, []string{constants.Fastfetch})
+	gc := loadUpdateConfig(t)
+	gc.AddToFailed(constants.Fastfetch, "terminal", "boom", 1)
+	if err := gc.Save(); err != nil {
+		t.Fatalf("failed to save config: %v", err)
+	}
+
+	mock := &mockUpdateApp{mockUninstallApp: mockUninstallApp{name: constants.Fastfetch}}
+	mockCmd := setupUpdateSeams(t, mock)
+
+	if err := runUpdate(updateCmd, []string{constants.Fastfetch}); err != nil {
+		t.Fatalf("expected no error, got: %v", err)
+	}
+	if !mockCmd.IndexRefreshed {
+		t.Error("expected the package index to be refreshed")
+	}
+	if !mock.updateCalled || !mock.configureCalled {
+		t.Error("expected Update and SoftConfigure to be called")
+	}
+	if n := loadUpdateConfig(t).FailedAttempts(constants.Fastfetch); n != 0 {
+		t.Errorf("expected failed entry to be cleared, got %d attempts", n)
+	}
+}
+
+func TestUpdate_FailureIsRecorded(t *testing.T) {
+	tc := testutil.SetupCompleteTest(t)
+	defer tc.Cleanup()
+
+	configWithPackages(t, tc.ConfigPath, []string{constants.Fastfetch})
+
+	mock := &mockUpdateApp{
+		mockUninstallApp: mockUninstallApp{name: constants.Fastfetch},
+		updateErr:        errors.New("upgrade exploded"),
+	}
+	setupUpdateSeams(t, mock)
+
+	for attempt := 1; attempt <= 2; attempt++ {
+		err := runUpdate(updateCmd, []string{constants.Fastfetch})
+		if err == nil || !strings.Contains(err.Error(), constants.Fastfetch) {
+			t.Fatalf("expected error naming %s, got: %v", constants.Fastfetch, err)
+		}
+		if n := loadUpdateConfig(t).FailedAttempts(constants.Fastfetch); n != attempt {
+			t.Errorf("attempt %d: expected AttemptCount %d, got %d", attempt, attempt, n)
+		}
+	}
+	if mock.configureCalled {
+		t.Error("expected SoftConfigure NOT to run after a failed upgrade")
+	}
+}
+
+func TestUpdate_NotSupportedIsSkipped(t *testing.T) {
+	tc := testutil.SetupCompleteTest(t)
+	defer tc.Cleanup()
+
+	configWithPackages(t, tc.ConfigPath, []string{constants.Fastfetch})
+
+	mock := &mockUpdateApp{
+		mockUninstallApp: mockUninstallApp{name: constants.Fastfetch},
+		updateErr:        apps.ErrUpdateNotSupported,
+	}
+	setupUpdateSeams(t, mock)
+
+	if err := runUpdate(updateCmd, []string{constants.Fastfetch}); err != nil {
+		t.Fatalf("expected unsupported update to be skipped, got: %v", err)
+	}
+	if n := loadUpdateConfig(t).FailedAttempts(constants.Fastfetch); n != 0 {
+		t.Errorf("expected no failed entry, got %d attempts", n)
+	}
+}
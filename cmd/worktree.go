@@ -6,6 +6,7 @@ package cmd
 import (
 	"fmt"
 	"os"
+	"strings"
 	"text/tabwriter"
 
 	"github.com/cjairm/devgita/internal/config"
@@ -33,15 +34,18 @@ Examples:
   dg worktree create feature-login --ai claude    # Create with Claude Code
   dg worktree create feature-login --layout nvim  # Create with the nvim-only layout
   dg wt c feature-login                           # Same, using short form
+  dg wt new fix-auth -p "fix the redirect"        # Start the AI coder on a task
+  dg wt new -p "fix the redirect"                 # Same, naming it fix-redirect
   dg wt new fix-auth --repo ~/code/api            # Create for another repo (window opens in its session)
   dg wt l                                         # List all worktrees
   dg wt rm                                        # Remove worktree (fzf selection)
   dg wt repair feature-login                      # Repair missing window
-  dg wt prune                                     # Remove all worktrees`,
+  dg wt prune                                     # Remove all worktrees
+  dg wt allow                                     # Trust this repo's worktree.yaml setup commands`,
 }
 
 var worktreeCreateCmd = &cobra.Command{
-	Use:     "create <name>",
+	Use:     "create [name]",
 	Aliases: []string{"c", "new"},
 	Short:   "Create a new worktree with tmux window",
 	Long: `Create a new git worktree with an associated tmux window (aliases: c, new).
@@ -52,6 +56,19 @@ This command:
   3. Creates a new tmux window named wt-<repo>-<name> in the current session
   4. Launches the selected AI coder in the window
 
+Without <name>, one is generated: a slug of --prompt's leading words when a
+prompt is given ("fix the login redirect" -> fix-login-redirect), otherwise
+worktree.name_template from global_config.yaml (default
+{user}/{date}-{adjective}-{noun}). A -2, -3, ... suffix is added until the
+name matches no local or origin branch and no existing worktree.
+
+The repo's bootstrap hooks run before the window's first pane launches:
+.devgita/worktree.yaml (or ~/.config/devgita/worktree.yaml when the repo has
+none) lists files to copy or symlink in from the main checkout (.env, local
+config) and setup commands (npm ci, go generate ./...) that the first pane
+runs, streaming their output, before starting the AI coder. Bootstrap
+problems are reported as warnings and never abort the create.
+
 If a branch named <name> already exists locally, create adopts it into the
 worktree instead of failing. If that branch is currently checked out in the
 main clone, the source checkout is moved to the repo's default branch first
@@ -71,20 +88,36 @@ Window layout selection precedence (--layout and --ai are mutually exclusive):
   5. worktree.default_ai in global_config.yaml, derived into a single-pane layout
   6. Default: opencode, single-pane
 
+With --prompt, the AI coder in the layout's first pane starts with that task
+instead of an empty session (claude takes it as its first message, opencode
+via its --prompt flag). The prompt is flattened to one line and shell-quoted
+before it is typed into the pane. The layout's first pane must launch an AI
+coder; the prompt is recorded with the worktree (see dg wt list --details).
+
 Valid AI coders: opencode (oc), claude (cc, claudecode)
-Valid layouts: opencode, claude, claude-nvim, nvim
+Valid layouts: opencode, claude, claude-nvim, nvim, plus any declared under
+worktree.layouts in global_config.yaml
 
 After creation, switch to the window with:
   <prefix> + [window number] or <prefix> + w to see all windows`,
-	Args: cobra.ExactArgs(1),
+	Args: cobra.MaximumNArgs(1),
 	RunE: func(cmd *cobra.Command, args []string) error {
-		name := args[0]
 		layout, err := resolveWorktreeLayout(createLayoutFlag, createAIFlag)
 		if err != nil {
 			return err
 		}
+		layout, err = layout.WithPrompt(createPromptFlag)
+		if err != nil {
+			return err
+		}
 
 		wm := worktree.New()
+		var name string
+		if len(args) == 1 {
+			name = args[0]
+		} else if name, err = generateWorktreeName(wm); err != nil {
+			return err
+		}
 		var repoRoot string
 		if repoFlag != "" {
 			if err := wm.CreateAt(repoFlag, name, layout, forceFlag); err != nil {
@@ -120,7 +153,11 @@ Shows worktrees from all repos in ~/.local/share/devgita/worktrees/ along with:
   - Repo name
   - Branch name
   - Associated tmux window name
-  - Whether the window is currently active`,
+  - Whether the window is currently active
+
+With --details, also what each worktree was created with: layout, AI coder,
+base commit, creation time, originating repo, and task prompt ("-" for
+worktrees created before devgita recorded these).`,
 	Args: cobra.NoArgs,
 	RunE: func(cmd *cobra.Command, args []string) error {
 		wm := worktree.New()
@@ -136,14 +173,22 @@ Shows worktrees from all repos in ~/.local/share/devgita/worktrees/ along with:
 		}
 
 		w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
-		_, _ = fmt.Fprintln(w, "REPO\tWORKTREE\tBRANCH\tWINDOW\tSTATUS")
+		header := "REPO\tWORKTREE\tBRANCH\tWINDOW\tSTATUS"
+		if listDetailsFlag {
+			header += "\tLAYOUT\tAI\tBASE\tCREATED\tORIGIN\tPROMPT"
+		}
+		_, _ = fmt.Fprintln(w, header)
 		for _, s := range statuses {
 			status := "No window"
 			if s.WindowActive {
 				status = "Active"
 			}
-			_, _ = fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\n",
+			row := fmt.Sprintf("%s\t%s\t%s\t%s\t%s",
 				s.Repo, s.Name, s.Branch, s.TmuxWindow, status)
+			if listDetailsFlag {
+				row += "\t" + strings.Join(worktreeDetailColumns(s.Meta), "\t")
+			}
+			_, _ = fmt.Fprintln(w, row)
 		}
 		return w.Flush()
 	},
@@ -209,30 +254,36 @@ This command:
   2. Creates a new tmux window if missing
   3. Launches the selected AI coder in the window
 
-Window layout selection follows the same precedence as create (--layout and
---ai are mutually exclusive):
+Window layout selection (--layout and --ai are mutually exclusive):
   1. --layout flag (explicit layout name)
   2. --ai flag, derived into a single-pane layout
-  3. DEVGITA_WORKTREE_AI environment variable, derived into a single-pane layout
-  4. worktree.default_layout in global_config.yaml
-  5. worktree.default_ai in global_config.yaml, derived into a single-pane layout
-  6. Default: opencode, single-pane
+  3. The layout the worktree was created with, as recorded at create time
+  4. With nothing recorded (a worktree created by an older devgita):
+     worktree.default_layout, then worktree.default_ai, then opencode
 
 Valid AI coders: opencode (oc), claude (cc, claudecode)
-Valid layouts: opencode, claude, claude-nvim, nvim
+Valid layouts: opencode, claude, claude-nvim, nvim, plus any declared under
+worktree.layouts in global_config.yaml
 
-Note: repair does not remember the layout a worktree was created with. If the
-window is missing, it is rebuilt from scratch using the precedence above,
-same as create. If the window already exists (e.g. only a pane inside it was
+If the window is missing, it is rebuilt from scratch using the precedence
+above. If the window already exists (e.g. only a pane inside it was
 closed), repair only relaunches the AI coder in the existing window — it does
 not add or recreate missing panes, since there is no way to tell whether the
 surviving panes already match the requested layout.`,
 	Args: cobra.ExactArgs(1),
 	RunE: func(cmd *cobra.Command, args []string) error {
 		name := args[0]
-		layout, err := resolveWorktreeLayout(repairLayoutFlag, repairAIFlag)
-		if err != nil {
-			return err
+		// With neither flag, worktree.Layout{} lets Repair rebuild the
+		// recorded layout. DEVGITA_WORKTREE_AI is deliberately not consulted
+		// here: a session-wide default must not override what this
+		// worktree was created with.
+		var layout worktree.Layout
+		if repairLayoutFlag != "" || repairAIFlag != "" {
+			var err error
+			layout, err = resolveWorktreeLayout(repairLayoutFlag, repairAIFlag)
+			if err != nil {
+				return err
+			}
 		}
 
 		wm := worktree.New()
@@ -276,6 +327,46 @@ Example:
 	},
 }
 
+var worktreeAllowCmd = &cobra.Command{
+	Use:   "allow",
+	Short: "Trust the repo's .devgita/worktree.yaml setup commands",
+	Long: `Trust the repo's .devgita/worktree.yaml so its setup commands run on create.
+
+A repo's bootstrap file comes with whatever was cloned, so its setup commands
+are skipped (with a warning) until you review the file and allow it. The
+trust is recorded in global_config.yaml against the file's exact contents:
+any later change to the file has to be allowed again. The global
+~/.config/devgita/worktree.yaml is always trusted.
+
+Examples:
+  dg wt allow                   # Trust the repo containing the current directory
+  dg wt allow --repo ~/code/api # Trust another repo's file`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		wm := worktree.New()
+		var repoRoot string
+		var err error
+		if allowRepoFlag != "" {
+			repoRoot, err = wm.Git.GetRepoRootIn(paths.ExpandHome(allowRepoFlag))
+		} else {
+			repoRoot, err = wm.Git.GetRepoRoot()
+		}
+		if err != nil {
+			return fmt.Errorf("failed to find the repository: %w", err)
+		}
+
+		cfg, err := worktree.AllowRepoSetup(repoRoot)
+		if err != nil {
+			return err
+		}
+		utils.PrintSuccess(fmt.Sprintf("Trusted bootstrap setup for %s", repoRoot))
+		for _, command := range cfg.Setup {
+			utils.PrintInfo("  " + command)
+		}
+		return nil
+	},
+}
+
 // createAIFlag/createLayoutFlag and repairAIFlag/repairLayoutFlag are
 // deliberately command-local rather than shared: a single shared var bound
 // to both commands' flags (the old aiFlag) works today only because cobra
@@ -286,10 +377,13 @@ Example:
 var (
 	createAIFlag     string
 	createLayoutFlag string
+	createPromptFlag string
 	repairAIFlag     string
 	repairLayoutFlag string
 	forceFlag        bool
 	repoFlag         string
+	listDetailsFlag  bool
+	allowRepoFlag    string
 )
 
 func init() {
@@ -299,12 +393,15 @@ func init() {
 	worktreeCmd.AddCommand(worktreeRemoveCmd)
 	worktreeCmd.AddCommand(worktreeRepairCmd)
 	worktreeCmd.AddCommand(worktreePruneCmd)
+	worktreeCmd.AddCommand(worktreeAllowCmd)
 
 	worktreeCreateCmd.Flags().
 		StringVarP(&createAIFlag, "ai", "a", "", "AI coder to launch (opencode, oc, claude, cc, claudecode)")
 	worktreeCreateCmd.Flags().
-		StringVarP(&createLayoutFlag, "layout", "l", "", "Window layout to build (opencode, claude, claude-nvim, nvim)")
+		StringVarP(&createLayoutFlag, "layout", "l", "", "Window layout to build (opencode, claude, claude-nvim, nvim, or a worktree.layouts name)")
 	worktreeCreateCmd.MarkFlagsMutuallyExclusive("ai", "layout")
+	worktreeCreateCmd.Flags().
+		StringVarP(&createPromptFlag, "prompt", "p", "", "Initial task for the AI coder in the first pane to start working on")
 	worktreeCreateCmd.Flags().
 		BoolVarP(&forceFlag, "force", "f", false, "Skip hook compatibility check")
 	worktreeCreateCmd.Flags().
@@ -319,14 +416,56 @@ func init() {
 	worktreeRepairCmd.Flags().
 		StringVarP(&repairAIFlag, "ai", "a", "", "AI coder to launch (opencode, oc, claude, cc, claudecode)")
 	worktreeRepairCmd.Flags().
-		StringVarP(&repairLayoutFlag, "layout", "l", "", "Window layout to build (opencode, claude, claude-nvim, nvim)")
+		StringVarP(&repairLayoutFlag, "layout", "l", "", "Window layout to build (opencode, claude, claude-nvim, nvim, or a worktree.layouts name)")
 	worktreeRepairCmd.MarkFlagsMutuallyExclusive("ai", "layout")
+	worktreeListCmd.Flags().
+		BoolVarP(&listDetailsFlag, "details", "d", false, "Also show what each worktree was created with")
 	worktreeRemoveCmd.Flags().
 		BoolVarP(&forceFlag, "force", "f", false, "Force removal even if worktree has uncommitted changes")
+	worktreeAllowCmd.Flags().
+		StringVarP(&allowRepoFlag, "repo", "r", "", "Path to the repository (defaults to the repo containing the current directory)")
 }
 
 var globalConfig config.GlobalConfig
 
+// maxPromptColumn caps the PROMPT column of `dg wt list --details` so one
+// long task prompt doesn't push every other row off screen.
+const maxPromptColumn = 40
+
+// worktreeDetailColumns renders a worktree's recorded metadata as the
+// --details columns, "-" standing in for anything not recorded.
+func worktreeDetailColumns(meta *worktree.Metadata) []string {
+	if meta == nil {
+		return []string{"-", "-", "-", "-", "-", "-"}
+	}
+	orDash := func(v string) string {
+		if v == "" {
+			return "-"
+		}
+		return v
+	}
+	base := meta.BaseRef
+	if len(base) > 7 {
+		base = base[:7]
+	}
+	created := "-"
+	if !meta.CreatedAt.IsZero() {
+		created = meta.CreatedAt.Local().Format("2006-01-02 15:04")
+	}
+	prompt := strings.Join(strings.Fields(meta.Prompt), " ")
+	if len([]rune(prompt)) > maxPromptColumn {
+		prompt = string([]rune(prompt)[:maxPromptColumn-1]) + "…"
+	}
+	return []string{
+		orDash(meta.Layout),
+		orDash(meta.AICoder),
+		orDash(base),
+		created,
+		orDash(meta.Repo),
+		orDash(prompt),
+	}
+}
+
 // resolveWorktreeLayout is the single load+resolve sequence create's and
 // repair's RunE both need - they differ only in which flag vars they pass,
 // so this is the one place that sequence is written.
@@ -335,6 +474,27 @@ func resolveWorktreeLayout(layoutFlag, aiFlag string) (worktree.Layout, error) {
 	return worktree.ResolveLayout(layoutFlag, resolveWorktreeAIFlag(aiFlag), &globalConfig)
 }
 
+// generateWorktreeName names a create that wasn't given one, for the repo
+// --repo points at (or the one containing the current directory). It must
+// run after resolveWorktreeLayout, which loads globalConfig.
+func generateWorktreeName(wm *worktree.WorktreeManager) (string, error) {
+	var repoRoot string
+	var err error
+	if repoFlag != "" {
+		if repoRoot, err = wm.Git.GetRepoRootIn(paths.ExpandHome(repoFlag)); err != nil {
+			return "", fmt.Errorf("no git repository at %s: %w", repoFlag, err)
+		}
+	} else if repoRoot, err = wm.Git.GetRepoRoot(); err != nil {
+		return "", fmt.Errorf("not in a git repository: %w", err)
+	}
+	name, err := wm.GenerateName(repoRoot, createPromptFlag, &globalConfig)
+	if err != nil {
+		return "", err
+	}
+	utils.PrintInfo(fmt.Sprintf("Generated worktree name: %s", name))
+	return name, nil
+}
+
 // loadWorktreeGlobalConfig loads global_config.yaml into the package-level
 // globalConfig so ResolveLayout can see worktree.default_ai/default_layout
 // from the CLI path (the dg ws dashboard loads its own gc elsewhere and never
@@ -12,7 +12,6 @@ package aerospace
 
 import (
 	"fmt"
-	"os"
 	"path/filepath"
 
 	"github.com/cjairm/devgita/internal/apps"
@@ -60,7 +59,7 @@ func (a *Aerospace) Uninstall() error {
 	if err := a.Cmd.UninstallDesktopApp("nikitabobko/tap/aerospace"); err != nil {
 		return fmt.Errorf("failed to uninstall aerospace: %w", err)
 	}
-	_ = os.RemoveAll(paths.Paths.Config.Aerospace)
+	_ = files.RemoveAll(paths.Paths.Config.Aerospace)
 	gc.RemoveFromInstalled(constants.Aerospace, "desktop_app")
 	return gc.Save()
 }
@@ -101,5 +100,5 @@ func (a *Aerospace) ExecuteCommand(args ...string) error {
 }
 
 func (a *Aerospace) Update() error {
-	return fmt.Errorf("%w for aerospace", apps.ErrUpdateNotSupported)
+	return a.Cmd.UpgradeDesktopApp("nikitabobko/tap/aerospace", "AeroSpace")
 }
@@ -1,7 +1,6 @@
 package aerospace
 
 import (
-	"errors"
 	"os"
 	"path/filepath"
 	"testing"
@@ -107,12 +106,11 @@ func TestUpdate(t *testing.T) {
 	mockApp := testutil.NewMockApp()
 	app := &Aerospace{Cmd: mockApp.Cmd, Base: mockApp.Base}
 
-	err := app.Update()
-	if err == nil {
-		t.Fatal("expected Update to return error")
+	if err := app.Update(); err != nil {
+		t.Fatalf("Update() error: %v", err)
 	}
-	if !errors.Is(err, apps.ErrUpdateNotSupported) {
-		t.Errorf("expected ErrUpdateNotSupported, got: %v", err)
+	if mockApp.Cmd.UpgradedDesktopApp != "nikitabobko/tap/aerospace" {
+		t.Errorf("expected %q to be upgraded, got %q", "nikitabobko/tap/aerospace", mockApp.Cmd.UpgradedDesktopApp)
 	}
 
 	testutil.VerifyNoRealCommands(t, mockApp.Base)
@@ -6,7 +6,6 @@ package alacritty
 
 import (
 	"fmt"
-	"os"
 	"path/filepath"
 
 	"github.com/cjairm/devgita/internal/apps"
@@ -110,7 +109,7 @@ func (a *Alacritty) Uninstall() error {
 	if err := a.Cmd.UninstallDesktopApp(constants.Alacritty); err != nil {
 		return fmt.Errorf("failed to uninstall alacritty: %w", err)
 	}
-	_ = os.RemoveAll(paths.Paths.Config.Alacritty)
+	_ = files.RemoveAll(paths.Paths.Config.Alacritty)
 	gc.RemoveFromInstalled(constants.Alacritty, "desktop_app")
 	return gc.Save()
 }
@@ -121,5 +120,5 @@ func (a *Alacritty) ExecuteCommand(args ...string) error {
 }
 
 func (a *Alacritty) Update() error {
-	return fmt.Errorf("%w for alacritty", apps.ErrUpdateNotSupported)
+	return a.Cmd.UpgradeDesktopApp(constants.Alacritty)
 }
@@ -1,7 +1,6 @@
 package alacritty
 
 import (
-	"errors"
 	"os"
 	"path/filepath"
 	"strings"
@@ -108,12 +107,11 @@ func TestUpdate(t *testing.T) {
 	mockApp := testutil.NewMockApp()
 	app := &Alacritty{Cmd: mockApp.Cmd, Base: mockApp.Base}
 
-	err := app.Update()
-	if err == nil {
-		t.Fatal("expected Update to return error")
+	if err := app.Update(); err != nil {
+		t.Fatalf("Update() error: %v", err)
 	}
-	if !errors.Is(err, apps.ErrUpdateNotSupported) {
-		t.Errorf("expected ErrUpdateNotSupported, got: %v", err)
+	if mockApp.Cmd.UpgradedDesktopApp != constants.Alacritty {
+		t.Errorf("expected %q to be upgraded, got %q", constants.Alacritty, mockApp.Cmd.UpgradedDesktopApp)
 	}
 
 	testutil.VerifyNoRealCommands(t, mockApp.Base)
@@ -2,8 +2,10 @@ package baseapp
 
 import (
 	"errors"
+	"fmt"
 
 	"github.com/cjairm/devgita/internal/apps"
+	"github.com/cjairm/devgita/internal/config"
 )
 
 // Reinstall implements the "force reinstall" flow correctly:
@@ -16,3 +18,22 @@ func Reinstall(install, uninstall func() error) error {
 	}
 	return install()
 }
+
+// RecordInstall saves the method and version an app was just installed with
+// to global_config.yaml, for installs that bypass the package manager's
+// MaybeInstall bookkeeping (e.g. GitHub release binaries). The app's own
+// configure step still tracks the item; this only adds the details.
+func RecordInstall(itemName, itemType string, method config.InstallMethod, version string) error {
+	gc := &config.GlobalConfig{}
+	if err := gc.Create(); err != nil {
+		return fmt.Errorf("failed to create global config: %w", err)
+	}
+	if err := gc.Load(); err != nil {
+		return fmt.Errorf("failed to load global config: %w", err)
+	}
+	gc.RecordInstall(itemName, itemType, method, version)
+	if err := gc.Save(); err != nil {
+		return fmt.Errorf("failed to save global config: %w", err)
+	}
+	return nil
+}
@@ -2,7 +2,6 @@ package baseapp
 
 import (
 	"fmt"
-	"os"
 	"path/filepath"
 
 	"github.com/cjairm/devgita/pkg/files"
@@ -29,10 +28,10 @@ func SyncSharedParts(destRoot string, parts []string) error {
 	for _, part := range parts {
 		src := filepath.Join(paths.Paths.App.Configs.Shared, part)
 		dst := filepath.Join(destRoot, part)
-		if err := os.RemoveAll(dst); err != nil {
+		if err := files.RemoveAll(dst); err != nil {
 			return fmt.Errorf("failed to clear %s: %w", part, err)
 		}
-		if err := os.MkdirAll(dst, 0o755); err != nil {
+		if err := files.MkdirAll(dst, 0o755); err != nil {
 			return fmt.Errorf("failed to create %s dir: %w", part, err)
 		}
 		if err := files.CopyDir(src, dst); err != nil {
@@ -78,5 +78,5 @@ func (b *Brave) ExecuteCommand(args ...string) error {
 }
 
 func (b *Brave) Update() error {
-	return fmt.Errorf("%w for brave", apps.ErrUpdateNotSupported)
+	return b.Cmd.UpgradeDesktopApp(fmt.Sprintf("%s-browser", constants.Brave), constants.Brave)
 }
@@ -171,12 +171,11 @@ func TestUpdate(t *testing.T) {
 	mockApp := testutil.NewMockApp()
 	brave := &Brave{Cmd: mockApp.Cmd}
 
-	err := brave.Update()
-	if err == nil {
-		t.Fatal("Expected Update() to return error")
+	if err := brave.Update(); err != nil {
+		t.Fatalf("Update() error: %v", err)
 	}
-	if !errors.Is(err, apps.ErrUpdateNotSupported) {
-		t.Errorf("expected ErrUpdateNotSupported, got: %v", err)
+	if mockApp.Cmd.UpgradedDesktopApp != "brave-browser" {
+		t.Errorf("expected %q to be upgraded, got %q", "brave-browser", mockApp.Cmd.UpgradedDesktopApp)
 	}
 
 	testutil.VerifyNoRealCommands(t, mockApp.Base)
@@ -2,7 +2,6 @@ package claude
 
 import (
 	"fmt"
-	"os"
 	"os/exec"
 	"path/filepath"
 	"slices"
@@ -15,6 +14,7 @@ import (
 	"github.com/cjairm/devgita/internal/config"
 	"github.com/cjairm/devgita/pkg/constants"
 	"github.com/cjairm/devgita/pkg/files"
+	"github.com/cjairm/devgita/pkg/logger"
 	"github.com/cjairm/devgita/pkg/paths"
 )
 
@@ -74,7 +74,7 @@ func (c *Claude) Uninstall() error {
 	}); err != nil {
 		return fmt.Errorf("failed to uninstall claude: %w", err)
 	}
-	_ = os.RemoveAll(paths.Paths.Config.Claude)
+	_ = files.RemoveAll(paths.Paths.Config.Claude)
 	gc.DisableShellFeature(constants.Claude)
 	if err := gc.RegenerateShellConfig(); err != nil {
 		return fmt.Errorf("failed to regenerate shell config: %w", err)
@@ -84,7 +84,7 @@ func (c *Claude) Uninstall() error {
 }
 
 func (c *Claude) ForceConfigure() error {
-	if err := os.MkdirAll(paths.Paths.Config.Claude, 0o755); err != nil {
+	if err := files.MkdirAll(paths.Paths.Config.Claude, 0o755); err != nil {
 		return err
 	}
 
@@ -114,7 +114,7 @@ func (c *Claude) ForceConfigure() error {
 		); err != nil {
 			return fmt.Errorf("failed to copy claude %s: %w", script, err)
 		}
-		if err := os.Chmod(dst, 0o755); err != nil {
+		if err := files.Chmod(dst, 0o755); err != nil {
 			return fmt.Errorf("failed to chmod %s: %w", script, err)
 		}
 	}
@@ -182,7 +182,7 @@ func (c *Claude) ConfigurableParts() []string {
 // agents) are overwritten from the embedded configs; the rtk part opts into
 // rtk's hook via enableRtkHook. This is the `--force --only=...` path.
 func (c *Claude) ForceConfigureParts(parts []string) error {
-	if err := os.MkdirAll(paths.Paths.Config.Claude, 0o755); err != nil {
+	if err := files.MkdirAll(paths.Paths.Config.Claude, 0o755); err != nil {
 		return err
 	}
 	shared := make([]string, 0, len(parts))
@@ -262,9 +262,15 @@ func (c *Claude) ExecuteCommand(args ...string) error {
 	return nil
 }
 
+// Update re-runs the official install script, which replaces the binary
+// with the latest release. The script does not report a version, so only the
+// method and timestamp are refreshed.
 func (c *Claude) Update() error {
-	return fmt.Errorf(
-		"%w — re-run: curl -fsSL https://claude.ai/install.sh | bash",
-		apps.ErrUpdateNotSupported,
-	)
+	if err := c.Install(); err != nil {
+		return err
+	}
+	if err := baseapp.RecordInstall(constants.Claude, "package", config.MethodScript, ""); err != nil {
+		logger.L().Warnw("Failed to record claude update", "error", err)
+	}
+	return nil
 }
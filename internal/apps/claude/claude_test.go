@@ -2,7 +2,6 @@ package claude
 
 import (
 	"encoding/json"
-	"errors"
 	"fmt"
 	"os"
 	"path/filepath"
@@ -299,18 +298,28 @@ func TestUninstall(t *testing.T) {
 }
 
 func TestUpdate(t *testing.T) {
+	tc := testutil.SetupCompleteTest(t)
+	defer tc.Cleanup()
+
 	mockApp := testutil.NewMockApp()
 	app := &Claude{Cmd: mockApp.Cmd, Base: mockApp.Base}
 
-	err := app.Update()
-	if err == nil {
-		t.Fatal("Expected Update to return error")
-	}
-	if !errors.Is(err, apps.ErrUpdateNotSupported) {
-		t.Errorf("expected ErrUpdateNotSupported, got: %v", err)
+	if err := app.Update(); err != nil {
+		t.Fatalf("Update error: %v", err)
 	}
 
-	testutil.VerifyNoRealCommands(t, mockApp.Base)
+	last := mockApp.Base.GetLastExecCommandCall()
+	if last == nil || last.Command != "sh" {
+		t.Fatalf("expected the install script to be re-run, got %+v", last)
+	}
+	gc := &config.GlobalConfig{}
+	if err := gc.Load(); err != nil {
+		t.Fatalf("Load: %v", err)
+	}
+	item, ok := gc.Installed.Packages.Find(constants.Claude)
+	if !ok || item.Method != config.MethodScript {
+		t.Errorf("expected claude recorded as a script install, got %+v (found=%v)", item, ok)
+	}
 }
 
 func TestForceConfigure(t *testing.T) {
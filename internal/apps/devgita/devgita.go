@@ -2,7 +2,6 @@ package devgita
 
 import (
 	"fmt"
-	"os"
 	"path/filepath"
 
 	"github.com/cjairm/devgita/internal/apps"
@@ -11,6 +10,7 @@ import (
 	"github.com/cjairm/devgita/internal/config"
 	"github.com/cjairm/devgita/internal/embedded"
 	"github.com/cjairm/devgita/pkg/constants"
+	"github.com/cjairm/devgita/pkg/dryrun"
 	"github.com/cjairm/devgita/pkg/files"
 	"github.com/cjairm/devgita/pkg/logger"
 	"github.com/cjairm/devgita/pkg/paths"
@@ -60,6 +60,12 @@ func (dg *Devgita) setupZshenv() error {
 	return dg.Base.MaybeSetupInFile(line, scriptPath, paths.Files.ZshEnv)
 }
 
+// setupShellSource makes the user's shell config source devgita.zsh.
+func (dg *Devgita) setupShellSource() error {
+	line := fmt.Sprintf(`source "%s"`, getZshConfigPath())
+	return dg.Base.MaybeSetup(line, getZshConfigPath())
+}
+
 func New() *Devgita {
 	return &Devgita{
 		Base:            commands.NewBaseCommand(),
@@ -71,9 +77,16 @@ func (dg *Devgita) Install() error {
 	// Create configs directory inside app root
 	configsDir := filepath.Join(paths.Paths.App.Root, "configs")
 
+	// Extraction happens outside pkg/files, so a dry run records it here and
+	// leaves the current templates in place for later steps to read.
+	if dryrun.Active() {
+		dryrun.RecordFileWrite(configsDir+string(filepath.Separator), files.DirAlreadyExist(configsDir))
+		return nil
+	}
+
 	// Clean up existing configs directory if it exists
 	if files.DirAlreadyExist(configsDir) {
-		if err := os.RemoveAll(configsDir); err != nil {
+		if err := files.RemoveAll(configsDir); err != nil {
 			return fmt.Errorf("failed to remove existing configs directory: %w", err)
 		}
 	}
@@ -107,7 +120,7 @@ func (dg *Devgita) Uninstall() error {
 	configsDir := filepath.Join(paths.Paths.App.Root, "configs")
 	if files.DirAlreadyExist(configsDir) {
 		logger.L().Debugw("Removing extracted configs directory", "path", configsDir)
-		if err := os.RemoveAll(configsDir); err != nil {
+		if err := files.RemoveAll(configsDir); err != nil {
 			return fmt.Errorf("failed to remove configs directory: %w", err)
 		}
 	} else {
@@ -118,7 +131,7 @@ func (dg *Devgita) Uninstall() error {
 	if files.DirAlreadyExist(paths.Paths.App.Root) {
 		if files.IsDirEmpty(paths.Paths.App.Root) {
 			logger.L().Debugw("App directory is empty, removing", "path", paths.Paths.App.Root)
-			if err := os.Remove(paths.Paths.App.Root); err != nil {
+			if err := files.Remove(paths.Paths.App.Root); err != nil {
 				return fmt.Errorf("failed to remove empty app directory: %w", err)
 			}
 		}
@@ -127,7 +140,7 @@ func (dg *Devgita) Uninstall() error {
 	// Remove global config file
 	if files.FileAlreadyExist(getGlobalConfigPath()) {
 		logger.L().Debugw("Removing global config file", "path", getGlobalConfigPath())
-		if err := os.Remove(getGlobalConfigPath()); err != nil {
+		if err := files.Remove(getGlobalConfigPath()); err != nil {
 			return fmt.Errorf("failed to remove global config file: %w", err)
 		}
 	} else {
@@ -137,7 +150,7 @@ func (dg *Devgita) Uninstall() error {
 	// Remove zsh config file
 	if files.FileAlreadyExist(getZshConfigPath()) {
 		logger.L().Debugw("Removing zsh config file", "path", getZshConfigPath())
-		if err := os.Remove(getZshConfigPath()); err != nil {
+		if err := files.Remove(getZshConfigPath()); err != nil {
 			return fmt.Errorf("failed to remove zsh config file: %w", err)
 		}
 	} else {
@@ -147,7 +160,7 @@ func (dg *Devgita) Uninstall() error {
 	// Remove config directory if empty
 	if files.DirAlreadyExist(getConfigDirPath()) && files.IsDirEmpty(getConfigDirPath()) {
 		logger.L().Debugw("Config directory is empty, removing", "path", getConfigDirPath())
-		if err := os.Remove(getConfigDirPath()); err != nil {
+		if err := files.Remove(getConfigDirPath()); err != nil {
 			return fmt.Errorf("failed to remove empty config directory: %w", err)
 		}
 	}
@@ -182,8 +195,7 @@ func (dg *Devgita) ForceConfigure() error {
 	if err := gc.RegenerateShellConfig(); err != nil {
 		return fmt.Errorf("failed to create global config file: %w", err)
 	}
-	devgitaConfigLine := fmt.Sprintf(`source "%s"`, getZshConfigPath())
-	if err := dg.Base.MaybeSetup(devgitaConfigLine, getZshConfigPath()); err != nil {
+	if err := dg.setupShellSource(); err != nil {
 		return err
 	}
 	if err := dg.setupZshenv(); err != nil {
@@ -216,7 +228,9 @@ func (dg *Devgita) SoftConfigure() error {
 			return fmt.Errorf("failed to enable extended capabilities: %w", err)
 		}
 	}
-	return nil
+	// Also idempotent: restores the source line if the user's shell config
+	// lost it while devgita.zsh itself survived.
+	return dg.setupShellSource()
 }
 
 func (dg *Devgita) ExecuteCommand(_ ...string) error {
@@ -86,5 +86,5 @@ func (d *Docker) ExecuteCommand(args ...string) error {
 }
 
 func (d *Docker) Update() error {
-	return fmt.Errorf("%w for docker", apps.ErrUpdateNotSupported)
+	return d.Cmd.UpgradeDesktopApp(constants.Docker)
 }
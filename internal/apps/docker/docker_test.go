@@ -162,12 +162,11 @@ func TestUpdate(t *testing.T) {
 	mockApp := testutil.NewMockApp()
 	app := &Docker{Cmd: mockApp.Cmd}
 
-	err := app.Update()
-	if err == nil {
-		t.Fatal("expected Update to return error")
+	if err := app.Update(); err != nil {
+		t.Fatalf("Update() error: %v", err)
 	}
-	if !errors.Is(err, apps.ErrUpdateNotSupported) {
-		t.Errorf("expected ErrUpdateNotSupported, got: %v", err)
+	if mockApp.Cmd.UpgradedDesktopApp != constants.Docker {
+		t.Errorf("expected %q to be upgraded, got %q", constants.Docker, mockApp.Cmd.UpgradedDesktopApp)
 	}
 
 	testutil.VerifyNoRealCommands(t, mockApp.Base)
@@ -9,7 +9,6 @@ package fastfetch
 
 import (
 	"fmt"
-	"os"
 	"path/filepath"
 
 	"github.com/cjairm/devgita/internal/apps"
@@ -81,7 +80,7 @@ func (f *Fastfetch) Uninstall() error {
 	if err := f.Cmd.UninstallPackage(constants.Fastfetch); err != nil {
 		return fmt.Errorf("failed to uninstall fastfetch: %w", err)
 	}
-	_ = os.RemoveAll(paths.Paths.Config.Fastfetch)
+	_ = files.RemoveAll(paths.Paths.Config.Fastfetch)
 	gc.RemoveFromInstalled(constants.Fastfetch, "package")
 	return gc.Save()
 }
@@ -99,5 +98,5 @@ func (f *Fastfetch) ExecuteCommand(args ...string) error {
 }
 
 func (f *Fastfetch) Update() error {
-	return fmt.Errorf("%w for fastfetch", apps.ErrUpdateNotSupported)
+	return f.Cmd.UpgradePackage(constants.Fastfetch)
 }
@@ -1,7 +1,6 @@
 package fastfetch
 
 import (
-	"errors"
 	"os"
 	"path/filepath"
 	"testing"
@@ -117,12 +116,11 @@ func TestUpdate(t *testing.T) {
 	mockApp := testutil.NewMockApp()
 	app := &Fastfetch{Cmd: mockApp.Cmd, Base: mockApp.Base}
 
-	err := app.Update()
-	if err == nil {
-		t.Fatal("expected Update to return error")
+	if err := app.Update(); err != nil {
+		t.Fatalf("Update() error: %v", err)
 	}
-	if !errors.Is(err, apps.ErrUpdateNotSupported) {
-		t.Errorf("expected ErrUpdateNotSupported, got: %v", err)
+	if mockApp.Cmd.UpgradedPkg != constants.Fastfetch {
+		t.Errorf("expected %q to be upgraded, got %q", constants.Fastfetch, mockApp.Cmd.UpgradedPkg)
 	}
 
 	testutil.VerifyNoRealCommands(t, mockApp.Base)
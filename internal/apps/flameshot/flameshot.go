@@ -75,5 +75,5 @@ func (f *Flameshot) ExecuteCommand(args ...string) error {
 }
 
 func (f *Flameshot) Update() error {
-	return fmt.Errorf("%w for flameshot", apps.ErrUpdateNotSupported)
+	return f.Cmd.UpgradeDesktopApp(constants.Flameshot)
 }
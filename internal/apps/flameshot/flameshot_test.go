@@ -166,12 +166,11 @@ func TestUpdate(t *testing.T) {
 	mockApp := testutil.NewMockApp()
 	flameshot := &Flameshot{Cmd: mockApp.Cmd}
 
-	err := flameshot.Update()
-	if err == nil {
-		t.Fatal("Expected Update() to return error")
+	if err := flameshot.Update(); err != nil {
+		t.Fatalf("Update() error: %v", err)
 	}
-	if !errors.Is(err, apps.ErrUpdateNotSupported) {
-		t.Errorf("expected ErrUpdateNotSupported, got: %v", err)
+	if mockApp.Cmd.UpgradedDesktopApp != constants.Flameshot {
+		t.Errorf("expected %q to be upgraded, got %q", constants.Flameshot, mockApp.Cmd.UpgradedDesktopApp)
 	}
 
 	testutil.VerifyNoRealCommands(t, mockApp.Base)
@@ -78,5 +78,5 @@ func (g *Gimp) ExecuteCommand(args ...string) error {
 }
 
 func (g *Gimp) Update() error {
-	return fmt.Errorf("%w for gimp", apps.ErrUpdateNotSupported)
+	return g.Cmd.UpgradeDesktopApp(constants.Gimp)
 }
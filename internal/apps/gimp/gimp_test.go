@@ -166,12 +166,11 @@ func TestUpdate(t *testing.T) {
 	mockApp := testutil.NewMockApp()
 	gimp := &Gimp{Cmd: mockApp.Cmd}
 
-	err := gimp.Update()
-	if err == nil {
-		t.Fatal("Expected Update() to return error")
+	if err := gimp.Update(); err != nil {
+		t.Fatalf("Update() error: %v", err)
 	}
-	if !errors.Is(err, apps.ErrUpdateNotSupported) {
-		t.Errorf("expected ErrUpdateNotSupported, got: %v", err)
+	if mockApp.Cmd.UpgradedDesktopApp != constants.Gimp {
+		t.Errorf("expected %q to be upgraded, got %q", constants.Gimp, mockApp.Cmd.UpgradedDesktopApp)
 	}
 
 	testutil.VerifyNoRealCommands(t, mockApp.Base)
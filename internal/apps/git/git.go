@@ -86,7 +86,7 @@ func (g *Git) Uninstall() error {
 	if err := g.Cmd.UninstallPackage(constants.Git); err != nil {
 		return fmt.Errorf("failed to uninstall git: %w", err)
 	}
-	_ = os.RemoveAll(paths.Paths.Config.Git)
+	_ = files.RemoveAll(paths.Paths.Config.Git)
 	gc.RemoveFromInstalled(constants.Git, "package")
 	return gc.Save()
 }
@@ -214,7 +214,7 @@ func (g *Git) Restore(branch, files string) error {
 }
 
 func (g *Git) Update() error {
-	return fmt.Errorf("%w for git", apps.ErrUpdateNotSupported)
+	return g.Cmd.UpgradePackage(constants.Git)
 }
 
 // ListBranches returns all local branch names, stripping the current-branch
@@ -310,6 +310,19 @@ func (g *Git) CurrentBranchIn(dir string) (string, error) {
 	return strings.TrimSpace(stdout), nil
 }
 
+// HeadIn returns the full commit SHA HEAD points at in the repository or
+// worktree at dir (mirrors `git -C dir rev-parse HEAD`).
+func (g *Git) HeadIn(dir string) (string, error) {
+	stdout, _, err := g.Base.ExecCommand(cmd.CommandParams{
+		Command: constants.Git,
+		Args:    dirArgs(dir, "rev-parse", "HEAD"),
+	})
+	if err != nil {
+		return "", fmt.Errorf("failed to resolve HEAD: %w", err)
+	}
+	return strings.TrimSpace(stdout), nil
+}
+
 // ShortHead returns HEAD's short commit SHA (mirrors `git rev-parse --short HEAD`).
 func (g *Git) ShortHead() (string, error) {
 	stdout, _, err := g.Base.ExecCommand(cmd.CommandParams{
@@ -646,6 +659,47 @@ func (g *Git) IsWorktreeDirty(path string) (bool, error) {
 	return strings.TrimSpace(stdout) != "", nil
 }
 
+// MergeConflictsIn reports the files that would conflict if branch were
+// merged into base, without touching any checkout or the index: `git
+// merge-tree --write-tree` does the merge entirely in the object store
+// (git 2.38+). It exits 1 on conflicts with the result tree's OID on the
+// first line and, with --name-only, one conflicted path per line after it;
+// any other failure (unknown ref, older git) has no tree line and is
+// returned as an error.
+func (g *Git) MergeConflictsIn(dir, base, branch string) ([]string, error) {
+	execCommand := cmd.CommandParams{
+		Command: constants.Git,
+		Args: dirArgs(
+			dir, "merge-tree", "--write-tree", "--name-only", "--no-messages", base, branch,
+		),
+	}
+	stdout, stderr, err := g.Base.ExecCommand(execCommand)
+	if err == nil {
+		return nil, nil
+	}
+	lines := strings.Split(strings.TrimSpace(stdout), "\n")
+	if len(lines) < 2 || !isObjectID(lines[0]) {
+		if stderr != "" {
+			return nil, fmt.Errorf("git: %s", strings.TrimSpace(stderr))
+		}
+		return nil, fmt.Errorf("failed to check %s for conflicts with %s: %w", branch, base, err)
+	}
+	return lines[1:], nil
+}
+
+// isObjectID reports whether s is a full SHA-1 or SHA-256 object name.
+func isObjectID(s string) bool {
+	if len(s) != 40 && len(s) != 64 {
+		return false
+	}
+	for _, r := range s {
+		if !strings.ContainsRune("0123456789abcdef", r) {
+			return false
+		}
+	}
+	return true
+}
+
 // PruneWorktrees removes stale worktree entries
 func (g *Git) PruneWorktrees() error {
 	return g.ExecuteCommand("worktree", "prune")
@@ -1,7 +1,6 @@
 package git
 
 import (
-	"errors"
 	"fmt"
 	"os"
 	"path/filepath"
@@ -129,12 +128,11 @@ func TestUpdate(t *testing.T) {
 	mockApp := testutil.NewMockApp()
 	app := &Git{Cmd: mockApp.Cmd, Base: mockApp.Base}
 
-	err := app.Update()
-	if err == nil {
-		t.Fatal("expected Update to return error")
+	if err := app.Update(); err != nil {
+		t.Fatalf("Update() error: %v", err)
 	}
-	if !errors.Is(err, apps.ErrUpdateNotSupported) {
-		t.Errorf("expected ErrUpdateNotSupported, got: %v", err)
+	if mockApp.Cmd.UpgradedPkg != constants.Git {
+		t.Errorf("expected %q to be upgraded, got %q", constants.Git, mockApp.Cmd.UpgradedPkg)
 	}
 
 	testutil.VerifyNoRealCommands(t, mockApp.Base)
@@ -1435,6 +1433,53 @@ func TestIsWorktreeDirty(t *testing.T) {
 	})
 }
 
+func TestMergeConflictsIn(t *testing.T) {
+	mockApp := testutil.NewMockApp()
+	app := &Git{Cmd: mockApp.Cmd, Base: mockApp.Base}
+	tree := strings.Repeat("a7", 20)
+
+	t.Run("clean merge", func(t *testing.T) {
+		mockApp.Base.ResetExecCommand()
+		mockApp.Base.SetExecCommandResult(tree+"\n", "", nil)
+
+		conflicts, err := app.MergeConflictsIn("/repo", "main", "feat")
+		if err != nil || len(conflicts) != 0 {
+			t.Fatalf("expected no conflicts, got %v, %v", conflicts, err)
+		}
+		want := "-C /repo merge-tree --write-tree --name-only --no-messages main feat"
+		if got := strings.Join(mockApp.Base.GetLastExecCommandCall().Args, " "); got != want {
+			t.Errorf("expected %q, got %q", want, got)
+		}
+	})
+
+	t.Run("conflicts", func(t *testing.T) {
+		mockApp.Base.ResetExecCommand()
+		mockApp.Base.SetExecCommandResult(
+			tree+"\nsrc/app.go\nREADME.md\n", "", fmt.Errorf("exit status 1"),
+		)
+
+		conflicts, err := app.MergeConflictsIn("/repo", "main", "feat")
+		if err != nil {
+			t.Fatalf("MergeConflictsIn failed: %v", err)
+		}
+		if strings.Join(conflicts, ",") != "src/app.go,README.md" {
+			t.Errorf("unexpected conflicts: %v", conflicts)
+		}
+	})
+
+	t.Run("git error", func(t *testing.T) {
+		mockApp.Base.ResetExecCommand()
+		mockApp.Base.SetExecCommandResult(
+			"", "fatal: unknown option `write-tree'\n", fmt.Errorf("exit status 129"),
+		)
+
+		if _, err := app.MergeConflictsIn("/repo", "main", "feat"); err == nil ||
+			!strings.Contains(err.Error(), "write-tree") {
+			t.Errorf("expected git's error to be surfaced, got %v", err)
+		}
+	})
+}
+
 func TestCheckHookCompatibility(t *testing.T) {
 	t.Run("no hooks directory returns no warnings", func(t *testing.T) {
 		mockApp := testutil.NewMockApp()
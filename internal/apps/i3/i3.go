@@ -2,7 +2,6 @@ package i3
 
 import (
 	"fmt"
-	"os"
 	"path/filepath"
 
 	"github.com/cjairm/devgita/internal/apps"
@@ -48,7 +47,7 @@ func (i *I3) Uninstall() error {
 	if err := i.Cmd.UninstallPackage(constants.I3); err != nil {
 		return fmt.Errorf("failed to uninstall i3: %w", err)
 	}
-	_ = os.RemoveAll(paths.Paths.Config.I3)
+	_ = files.RemoveAll(paths.Paths.Config.I3)
 	gc.RemoveFromInstalled(constants.I3, "package")
 	return gc.Save()
 }
@@ -87,5 +86,5 @@ func (i *I3) ExecuteCommand(args ...string) error {
 }
 
 func (i *I3) Update() error {
-	return fmt.Errorf("%w for i3 — use system package manager", apps.ErrUpdateNotSupported)
+	return i.Cmd.UpgradePackage(constants.I3)
 }
@@ -1,7 +1,6 @@
 package i3
 
 import (
-	"errors"
 	"os"
 	"path/filepath"
 	"testing"
@@ -279,12 +278,11 @@ func TestUpdate(t *testing.T) {
 	mockApp := testutil.NewMockApp()
 	i := &I3{Cmd: mockApp.Cmd}
 
-	err := i.Update()
-	if err == nil {
-		t.Fatal("Expected Update to return error")
+	if err := i.Update(); err != nil {
+		t.Fatalf("Update() error: %v", err)
 	}
-	if !errors.Is(err, apps.ErrUpdateNotSupported) {
-		t.Errorf("expected ErrUpdateNotSupported, got: %v", err)
+	if mockApp.Cmd.UpgradedPkg != constants.I3 {
+		t.Errorf("expected %q to be upgraded, got %q", constants.I3, mockApp.Cmd.UpgradedPkg)
 	}
 
 	testutil.VerifyNoRealCommands(t, mockApp.Base)
@@ -116,6 +116,11 @@ func (ld *LazyDocker) installDebianLazydocker() error {
 	}
 
 	logger.L().Infow("lazydocker installed successfully for Debian", "version", version)
+	if err := baseapp.RecordInstall(
+		constants.LazyDocker, "package", config.MethodGitHubBinary, version,
+	); err != nil {
+		logger.L().Warnw("Failed to record lazydocker install details", "error", err)
+	}
 	return nil
 }
 
@@ -194,6 +199,11 @@ func (ld *LazyDocker) ExecuteCommand(args ...string) error {
 	return nil
 }
 
+// Update upgrades through brew on macOS and re-runs the verified GitHub
+// release install on Debian, the same split as Install.
 func (ld *LazyDocker) Update() error {
-	return fmt.Errorf("%w for lazydocker", apps.ErrUpdateNotSupported)
+	if ld.Base.IsMac() {
+		return ld.Cmd.UpgradePackage(packageName, constants.LazyDocker)
+	}
+	return ld.installDebianLazydocker()
 }
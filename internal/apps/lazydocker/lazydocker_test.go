@@ -2,7 +2,6 @@ package lazydocker
 
 import (
 	"context"
-	"errors"
 	"fmt"
 	"os"
 	"strings"
@@ -240,12 +239,13 @@ func TestUpdate(t *testing.T) {
 	mockApp := testutil.NewMockApp()
 	app := &LazyDocker{Cmd: mockApp.Cmd, Base: mockApp.Base}
 
-	err := app.Update()
-	if err == nil {
-		t.Fatal("expected Update to return error")
+	mockApp.Base.IsMacResult = true
+
+	if err := app.Update(); err != nil {
+		t.Fatalf("Update() error: %v", err)
 	}
-	if !errors.Is(err, apps.ErrUpdateNotSupported) {
-		t.Errorf("expected ErrUpdateNotSupported, got: %v", err)
+	if mockApp.Cmd.UpgradedPkg != packageName {
+		t.Errorf("expected %q to be upgraded, got %q", packageName, mockApp.Cmd.UpgradedPkg)
 	}
 
 	testutil.VerifyNoRealCommands(t, mockApp.Base)
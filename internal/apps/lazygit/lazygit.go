@@ -112,6 +112,11 @@ func (lg *LazyGit) installDebianLazygit() error {
 	}
 
 	logger.L().Infow("lazygit installed successfully for Debian", "version", version)
+	if err := baseapp.RecordInstall(
+		constants.LazyGit, "package", config.MethodGitHubBinary, version,
+	); err != nil {
+		logger.L().Warnw("Failed to record lazygit install details", "error", err)
+	}
 	return nil
 }
 
@@ -190,6 +195,12 @@ func (lg *LazyGit) ExecuteCommand(args ...string) error {
 	return nil
 }
 
+// Update upgrades through brew on macOS. On Debian it re-runs the GitHub
+// release install, which fetches the latest version, verifies its checksum,
+// and records the new version.
 func (lg *LazyGit) Update() error {
-	return fmt.Errorf("%w for lazygit", apps.ErrUpdateNotSupported)
+	if lg.Base.IsMac() {
+		return lg.Cmd.UpgradePackage(constants.LazyGit)
+	}
+	return lg.installDebianLazygit()
 }
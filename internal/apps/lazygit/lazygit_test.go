@@ -2,7 +2,6 @@ package lazygit
 
 import (
 	"context"
-	"errors"
 	"fmt"
 	"os"
 	"strings"
@@ -227,12 +226,13 @@ func TestUpdate(t *testing.T) {
 	mockApp := testutil.NewMockApp()
 	app := &LazyGit{Cmd: mockApp.Cmd, Base: mockApp.Base}
 
-	err := app.Update()
-	if err == nil {
-		t.Fatal("expected Update to return error")
+	mockApp.Base.IsMacResult = true
+
+	if err := app.Update(); err != nil {
+		t.Fatalf("Update() error: %v", err)
 	}
-	if !errors.Is(err, apps.ErrUpdateNotSupported) {
-		t.Errorf("expected ErrUpdateNotSupported, got: %v", err)
+	if mockApp.Cmd.UpgradedPkg != constants.LazyGit {
+		t.Errorf("expected %q to be upgraded, got %q", constants.LazyGit, mockApp.Cmd.UpgradedPkg)
 	}
 
 	testutil.VerifyNoRealCommands(t, mockApp.Base)
@@ -108,7 +108,7 @@ func (m *Mise) ExecuteCommand(args ...string) error {
 }
 
 func (m *Mise) Update() error {
-	return fmt.Errorf("%w for mise", apps.ErrUpdateNotSupported)
+	return m.Cmd.UpgradePackage(constants.Mise)
 }
 
 func (m *Mise) UseGlobal(language, version string) error {
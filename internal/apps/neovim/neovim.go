@@ -158,6 +158,11 @@ func (n *Neovim) installDebianNeovim() error {
 	}
 
 	logger.L().Infow("Neovim installed successfully for Debian", "version", version, "arch", arch)
+	if err := baseapp.RecordInstall(
+		constants.Neovim, "package", config.MethodGitHubBinary, version,
+	); err != nil {
+		logger.L().Warnw("Failed to record neovim install details", "error", err)
+	}
 	return nil
 }
 
@@ -225,13 +230,13 @@ func (n *Neovim) Uninstall() error {
 			}
 		}
 	}
-	_ = os.RemoveAll(paths.Paths.Config.Nvim)
+	_ = files.RemoveAll(paths.Paths.Config.Nvim)
 	for _, dir := range []string{
 		paths.GetDataDir(constants.Nvim),
 		paths.GetStateDir(constants.Nvim),
 		paths.GetCacheDir(constants.Nvim),
 	} {
-		_ = os.RemoveAll(dir)
+		_ = files.RemoveAll(dir)
 	}
 	gc.DisableShellFeature(constants.Neovim)
 	if err := gc.RegenerateShellConfig(); err != nil {
@@ -250,12 +255,22 @@ func (n *Neovim) ExecuteCommand(args ...string) error {
 	return nil
 }
 
+// Update upgrades through brew on macOS. Debian installs are pinned to
+// constants.SupportedVersion.Neovim, so there's nothing to upgrade to:
+// moving past the pin means bumping it in a devgita release.
 func (n *Neovim) Update() error {
-	return fmt.Errorf("%w for neovim", apps.ErrUpdateNotSupported)
+	if n.Base.IsMac() {
+		return n.Cmd.UpgradePackage(constants.Neovim)
+	}
+	return fmt.Errorf(
+		"%w: neovim is pinned to %s on Debian/Ubuntu and is not upgradable",
+		apps.ErrUpdateNotSupported, constants.SupportedVersion.Neovim.Number,
+	)
 }
 
 func (n *Neovim) checkVersion() error {
 	baseCmd := getBaseCmd("--version")
+	baseCmd.ReadOnly = true
 	stdout, stderr, err := n.Base.ExecCommand(baseCmd)
 	if err != nil {
 		return fmt.Errorf("failed to check neovim version: %w, stderr: %s", err, stderr)
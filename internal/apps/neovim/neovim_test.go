@@ -216,17 +216,33 @@ func TestUpdate(t *testing.T) {
 	mockApp := testutil.NewMockApp()
 	app := &Neovim{Cmd: mockApp.Cmd, Base: mockApp.Base}
 
-	err := app.Update()
-	if err == nil {
-		t.Fatal("expected Update to return error")
+	mockApp.Base.IsMacResult = true
+
+	if err := app.Update(); err != nil {
+		t.Fatalf("Update() error: %v", err)
 	}
-	if !errors.Is(err, apps.ErrUpdateNotSupported) {
-		t.Errorf("expected ErrUpdateNotSupported, got: %v", err)
+	if mockApp.Cmd.UpgradedPkg != constants.Neovim {
+		t.Errorf("expected %q to be upgraded, got %q", constants.Neovim, mockApp.Cmd.UpgradedPkg)
 	}
 
 	testutil.VerifyNoRealCommands(t, mockApp.Base)
 }
 
+func TestUpdateDebianIsPinned(t *testing.T) {
+	mockApp := testutil.NewMockApp()
+	app := &Neovim{Cmd: mockApp.Cmd, Base: mockApp.Base}
+
+	mockApp.Base.IsMacResult = false
+
+	err := app.Update()
+	if !errors.Is(err, apps.ErrUpdateNotSupported) || !strings.Contains(err.Error(), "pinned") {
+		t.Fatalf("expected a pinned, not-upgradable error, got %v", err)
+	}
+	if mockApp.Base.GetExecCommandCallCount() != 0 {
+		t.Errorf("expected no commands, got %d", mockApp.Base.GetExecCommandCallCount())
+	}
+}
+
 func TestForceConfigure(t *testing.T) {
 	tc := testutil.SetupCompleteTest(t)
 	defer tc.Cleanup()
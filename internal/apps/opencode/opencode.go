@@ -11,7 +11,6 @@ package opencode
 
 import (
 	"fmt"
-	"os"
 	"path/filepath"
 	"slices"
 	"testing"
@@ -70,7 +69,7 @@ func (o *OpenCode) Uninstall() error {
 	if err := o.Cmd.UninstallPackage(constants.OpenCode); err != nil {
 		return fmt.Errorf("failed to uninstall opencode: %w", err)
 	}
-	_ = os.RemoveAll(paths.Paths.Config.OpenCode)
+	_ = files.RemoveAll(paths.Paths.Config.OpenCode)
 	gc.DisableShellFeature(constants.OpenCode)
 	if err := gc.RegenerateShellConfig(); err != nil {
 		return fmt.Errorf("failed to regenerate shell config: %w", err)
@@ -80,12 +79,12 @@ func (o *OpenCode) Uninstall() error {
 }
 
 func (o *OpenCode) ForceConfigure() error {
-	if err := os.RemoveAll(paths.Paths.Config.OpenCode); err != nil {
+	if err := files.RemoveAll(paths.Paths.Config.OpenCode); err != nil {
 		return err
 	}
 	// Directory permissions should be 0755 not 0644. Directories need execute
 	// permission to be entered.
-	if err := os.MkdirAll(paths.Paths.Config.OpenCode, 0o755); err != nil {
+	if err := files.MkdirAll(paths.Paths.Config.OpenCode, 0o755); err != nil {
 		return err
 	}
 	gc := &config.GlobalConfig{}
@@ -106,7 +105,7 @@ func (o *OpenCode) ForceConfigure() error {
 	)
 	if theme == DEFAULT_THEME_NAME {
 		themesDir := filepath.Join(paths.Paths.Config.OpenCode, "themes")
-		if err := os.MkdirAll(themesDir, 0o755); err != nil {
+		if err := files.MkdirAll(themesDir, 0o755); err != nil {
 			return fmt.Errorf("failed to create themes directory: %w", err)
 		}
 		if err := files.CopyFile(
@@ -205,7 +204,7 @@ func (o *OpenCode) ConfigurableParts() []string {
 // opencode.json or themes, so a hand-edited config survives. This is the
 // `--force --only=...` path.
 func (o *OpenCode) ForceConfigureParts(parts []string) error {
-	if err := os.MkdirAll(paths.Paths.Config.OpenCode, 0o755); err != nil {
+	if err := files.MkdirAll(paths.Paths.Config.OpenCode, 0o755); err != nil {
 		return err
 	}
 	shared := make([]string, 0, len(parts))
@@ -258,5 +257,5 @@ func (o *OpenCode) ExecuteCommand(args ...string) error {
 }
 
 func (o *OpenCode) Update() error {
-	return fmt.Errorf("%w for opencode", apps.ErrUpdateNotSupported)
+	return o.Cmd.UpgradePackage(constants.OpenCode)
 }
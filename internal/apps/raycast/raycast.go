@@ -79,5 +79,5 @@ func (r *Raycast) ExecuteCommand(args ...string) error {
 }
 
 func (r *Raycast) Update() error {
-	return fmt.Errorf("%w for raycast", apps.ErrUpdateNotSupported)
+	return r.Cmd.UpgradeDesktopApp(constants.Raycast)
 }
@@ -178,12 +178,11 @@ func TestUpdate(t *testing.T) {
 	mockApp := testutil.NewMockApp()
 	r := &Raycast{Cmd: mockApp.Cmd}
 
-	err := r.Update()
-	if err == nil {
-		t.Fatal("Update() should return error")
+	if err := r.Update(); err != nil {
+		t.Fatalf("Update() error: %v", err)
 	}
-	if !errors.Is(err, apps.ErrUpdateNotSupported) {
-		t.Errorf("expected ErrUpdateNotSupported, got: %v", err)
+	if mockApp.Cmd.UpgradedDesktopApp != constants.Raycast {
+		t.Errorf("expected %q to be upgraded, got %q", constants.Raycast, mockApp.Cmd.UpgradedDesktopApp)
 	}
 
 	testutil.VerifyNoRealCommands(t, mockApp.Base)
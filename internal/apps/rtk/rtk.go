@@ -119,6 +119,11 @@ func (r *Rtk) installDebianRtk() error {
 	}
 
 	logger.L().Infow("rtk installed successfully for Debian", "version", version)
+	if err := baseapp.RecordInstall(
+		constants.Rtk, "package", config.MethodGitHubBinary, version,
+	); err != nil {
+		logger.L().Warnw("Failed to record rtk install details", "error", err)
+	}
 	return nil
 }
 
@@ -225,6 +230,11 @@ func (r *Rtk) initAgent(args ...string) error {
 	return nil
 }
 
+// Update upgrades through brew on macOS and re-runs the verified GitHub
+// release install on Debian, the same split as Install.
 func (r *Rtk) Update() error {
-	return fmt.Errorf("%w for rtk", apps.ErrUpdateNotSupported)
+	if r.Base.IsMac() {
+		return r.Cmd.UpgradePackage(constants.Rtk)
+	}
+	return r.installDebianRtk()
 }
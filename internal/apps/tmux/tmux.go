@@ -110,7 +110,7 @@ func (t *Tmux) Uninstall() error {
 	if err := t.Cmd.UninstallPackage(constants.Tmux); err != nil {
 		return fmt.Errorf("failed to uninstall tmux: %w", err)
 	}
-	_ = os.Remove(filepath.Join(paths.Paths.Home.Root, configFileName))
+	_ = files.Remove(filepath.Join(paths.Paths.Home.Root, configFileName))
 	gc.DisableShellFeature(constants.Tmux)
 	if err := gc.RegenerateShellConfig(); err != nil {
 		return fmt.Errorf("failed to regenerate shell config: %w", err)
@@ -131,7 +131,7 @@ func (t *Tmux) ExecuteCommand(args ...string) error {
 }
 
 func (t *Tmux) Update() error {
-	return fmt.Errorf("%w for tmux", apps.ErrUpdateNotSupported)
+	return t.Cmd.UpgradePackage(constants.Tmux)
 }
 
 // CreateSession creates a new detached tmux session in the given directory
@@ -343,17 +343,32 @@ func (t *Tmux) CreateWindow(name, workdir string) error {
 // model's language), not tmux's own flag letters: "vertical" means panes
 // side by side (tmux's -h), "horizontal" means panes stacked (tmux's -v).
 func (t *Tmux) SplitWindow(window, workdir, direction string) error {
+	return t.SplitWindowSized(window, workdir, direction, 0)
+}
+
+// SplitWindowSized is SplitWindow with the new pane taking percent of the
+// split pane (tmux's -l N%). percent 0 keeps tmux's even split.
+func (t *Tmux) SplitWindowSized(window, workdir, direction string, percent int) error {
+	var flag string
 	switch direction {
 	case "vertical":
-		return t.ExecuteCommand("split-window", "-h", "-t", window, "-c", workdir)
+		flag = "-h"
 	case "horizontal":
-		return t.ExecuteCommand("split-window", "-v", "-t", window, "-c", workdir)
+		flag = "-v"
 	default:
 		return fmt.Errorf(
 			"unknown split direction %q (want \"vertical\" or \"horizontal\")",
 			direction,
 		)
 	}
+	if percent < 0 || percent > 99 {
+		return fmt.Errorf("split size %d%% out of range (want 1-99)", percent)
+	}
+	args := []string{"split-window", flag, "-t", window, "-c", workdir}
+	if percent > 0 {
+		args = append(args, "-l", fmt.Sprintf("%d%%", percent))
+	}
+	return t.ExecuteCommand(args...)
 }
 
 // ActivePaneID returns the tmux pane_id (e.g. "%12") of window's currently
@@ -433,6 +448,91 @@ func (t *Tmux) SendKeysToWindow(window, keys string) error {
 	return t.ExecuteCommand("send-keys", "-t", window, keys, "Enter")
 }
 
+// SendLiteralKeys types text into target's active pane verbatim, then
+// presses Enter. See SendText.
+func (t *Tmux) SendLiteralKeys(target, text string) error {
+	return t.SendText(target, text, true)
+}
+
+// SendText types text into target (a window, "session:window", or pane id)
+// verbatim and, when enter is set, presses Enter after it. -l keeps tmux from
+// reading text as a key name (a bare "Enter" or "C-c"), "--" from reading a
+// leading "-" as an option, and a trailing semicolon is escaped because tmux
+// ends a command at any argument ending in one, -l or not. Empty text with
+// enter just presses Enter.
+func (t *Tmux) SendText(target, text string, enter bool) error {
+	if strings.HasSuffix(text, ";") {
+		text = strings.TrimSuffix(text, ";") + `\;`
+	}
+	if text != "" {
+		if err := t.ExecuteCommand("send-keys", "-t", target, "-l", "--", text); err != nil {
+			return err
+		}
+	}
+	if !enter {
+		return nil
+	}
+	return t.ExecuteCommand("send-keys", "-t", target, "Enter")
+}
+
+// PaneInfo describes one pane of a window, as listed by WindowPanes.
+type PaneInfo struct {
+	ID      string // pane_id, e.g. "%12"; see ActivePaneID for why ids beat indexes
+	Command string // pane_current_command: the pane's foreground process
+	Title   string // pane_title, which full-screen programs (AI coders included) often set
+	Dead    bool   // the pane's process exited and remain-on-exit kept the pane open
+}
+
+// WindowPanes lists target's panes in pane-index order, so the first entry
+// is the window's original pane: splits are always numbered after the pane
+// they split. target takes the same forms as ActivePaneID's window.
+func (t *Tmux) WindowPanes(target string) ([]PaneInfo, error) {
+	execCommand := cmd.CommandParams{
+		Command: constants.Tmux,
+		Args: []string{
+			"list-panes", "-t", target,
+			"-F", "#{pane_id}\t#{pane_dead}\t#{pane_current_command}\t#{pane_title}",
+		},
+	}
+	stdout, _, err := t.Base.ExecCommand(execCommand)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list panes of %s: %w", target, err)
+	}
+	var panes []PaneInfo
+	scanner := bufio.NewScanner(strings.NewReader(stdout))
+	for scanner.Scan() {
+		parts := strings.SplitN(scanner.Text(), "\t", 4)
+		if len(parts) != 4 {
+			continue
+		}
+		panes = append(panes, PaneInfo{
+			ID:      parts[0],
+			Dead:    parts[1] == "1",
+			Command: parts[2],
+			Title:   parts[3],
+		})
+	}
+	return panes, nil
+}
+
+// CapturePane returns target pane's visible screen plus up to history lines
+// of scrollback above it, wrapped lines joined back together (-J) so a
+// resize doesn't change what was captured. With escapes the text keeps the
+// pane's colors and attributes as ANSI SGR sequences (-e) for display;
+// without, it's plain text.
+func (t *Tmux) CapturePane(target string, history int, escapes bool) (string, error) {
+	args := []string{"capture-pane", "-p", "-J", "-t", target, "-S", fmt.Sprintf("-%d", history)}
+	if escapes {
+		args = append(args, "-e")
+	}
+	execCommand := cmd.CommandParams{Command: constants.Tmux, Args: args}
+	stdout, _, err := t.Base.ExecCommand(execCommand)
+	if err != nil {
+		return "", fmt.Errorf("failed to capture pane %s: %w", target, err)
+	}
+	return stdout, nil
+}
+
 // SelectWindow switches focus to a specific window by name
 func (t *Tmux) SelectWindow(name string) error {
 	return t.ExecuteCommand("select-window", "-t", name)
@@ -203,12 +203,11 @@ func TestUpdate(t *testing.T) {
 	mockApp := testutil.NewMockApp()
 	app := &tmux.Tmux{Cmd: mockApp.Cmd, Base: mockApp.Base}
 
-	err := app.Update()
-	if err == nil {
-		t.Fatal("expected Update to return error")
+	if err := app.Update(); err != nil {
+		t.Fatalf("Update() error: %v", err)
 	}
-	if !errors.Is(err, apps.ErrUpdateNotSupported) {
-		t.Errorf("expected ErrUpdateNotSupported, got: %v", err)
+	if mockApp.Cmd.UpgradedPkg != constants.Tmux {
+		t.Errorf("expected %q to be upgraded, got %q", constants.Tmux, mockApp.Cmd.UpgradedPkg)
 	}
 
 	testutil.VerifyNoRealCommands(t, mockApp.Base)
@@ -1298,6 +1297,48 @@ func TestSplitWindow(t *testing.T) {
 	})
 }
 
+func TestSplitWindowSized(t *testing.T) {
+	t.Run("percent adds -l", func(t *testing.T) {
+		mockApp := testutil.NewMockApp()
+		mockApp.Base.SetExecCommandResult("", "", nil)
+		app := &tmux.Tmux{Cmd: mockApp.Cmd, Base: mockApp.Base}
+
+		if err := app.SplitWindowSized("wt-feature", "/tmp/repo", "horizontal", 30); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		last := mockApp.Base.GetLastExecCommandCall()
+		if last == nil {
+			t.Fatal("no ExecCommand call recorded")
+		}
+		expectedArgs := []string{
+			"split-window", "-v", "-t", "wt-feature", "-c", "/tmp/repo", "-l", "30%",
+		}
+		if len(last.Args) != len(expectedArgs) {
+			t.Fatalf("Expected %d args, got %d: %v", len(expectedArgs), len(last.Args), last.Args)
+		}
+		for i, arg := range expectedArgs {
+			if last.Args[i] != arg {
+				t.Errorf("Expected arg[%d] to be %q, got %q", i, arg, last.Args[i])
+			}
+		}
+	})
+
+	t.Run("out of range percent returns error without executing a command", func(t *testing.T) {
+		mockApp := testutil.NewMockApp()
+		app := &tmux.Tmux{Cmd: mockApp.Cmd, Base: mockApp.Base}
+
+		if err := app.SplitWindowSized("wt-feature", "/tmp/repo", "vertical", 100); err == nil {
+			t.Fatal("expected error for a 100% split")
+		}
+		if mockApp.Base.GetExecCommandCallCount() != 0 {
+			t.Errorf(
+				"expected no ExecCommand calls, got %d",
+				mockApp.Base.GetExecCommandCallCount(),
+			)
+		}
+	})
+}
+
 func TestCreateWindowInSession(t *testing.T) {
 	mockApp := testutil.NewMockApp()
 	mockApp.Base.SetExecCommandResult("", "", nil)
@@ -1566,3 +1607,129 @@ func TestSelectPane(t *testing.T) {
 		t.Errorf("expected target '%%67' in args %v", last.Args)
 	}
 }
+
+func TestSendLiteralKeys(t *testing.T) {
+	mockApp := testutil.NewMockApp()
+	mockApp.Base.SetExecCommandResult("", "", nil)
+	app := &tmux.Tmux{Cmd: mockApp.Cmd, Base: mockApp.Base}
+
+	if err := app.SendLiteralKeys("sess:wt-feature", "run the tests;"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	calls := mockApp.Base.ExecCommandCalls
+	if len(calls) != 2 {
+		t.Fatalf("expected 2 send-keys calls, got %d", len(calls))
+	}
+	expected := [][]string{
+		{"send-keys", "-t", "sess:wt-feature", "-l", "--", `run the tests\;`},
+		{"send-keys", "-t", "sess:wt-feature", "Enter"},
+	}
+	for i, want := range expected {
+		got := calls[i].Args
+		if strings.Join(got, "\x00") != strings.Join(want, "\x00") {
+			t.Errorf("call %d: expected %q, got %q", i, want, got)
+		}
+	}
+}
+
+func TestWindowPanes(t *testing.T) {
+	mockApp := testutil.NewMockApp()
+	mockApp.Base.SetExecCommandResult(
+		"%3\t0\tclaude\t✳ Fix login\n%4\t1\tzsh\thost\nmalformed\n", "", nil,
+	)
+	app := &tmux.Tmux{Cmd: mockApp.Cmd, Base: mockApp.Base}
+
+	panes, err := app.WindowPanes("sess:wt-feature")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want := []tmux.PaneInfo{
+		{ID: "%3", Command: "claude", Title: "✳ Fix login"},
+		{ID: "%4", Command: "zsh", Title: "host", Dead: true},
+	}
+	if len(panes) != len(want) {
+		t.Fatalf("expected %d panes, got %+v", len(want), panes)
+	}
+	for i := range want {
+		if panes[i] != want[i] {
+			t.Errorf("pane %d: expected %+v, got %+v", i, want[i], panes[i])
+		}
+	}
+	args := mockApp.Base.GetLastExecCommandCall().Args
+	if args[0] != "list-panes" || args[2] != "sess:wt-feature" {
+		t.Errorf("unexpected args: %v", args)
+	}
+
+	mockApp.Base.SetExecCommandResult("", "can't find window", errors.New("exit status 1"))
+	if _, err := app.WindowPanes("sess:gone"); err == nil {
+		t.Error("expected an error for a missing window")
+	}
+}
+
+func TestCapturePane(t *testing.T) {
+	mockApp := testutil.NewMockApp()
+	mockApp.Base.SetExecCommandResult("line one\nline two\n", "", nil)
+	app := &tmux.Tmux{Cmd: mockApp.Cmd, Base: mockApp.Base}
+
+	out, err := app.CapturePane("%3", 40, false)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if out != "line one\nline two\n" {
+		t.Errorf("unexpected capture: %q", out)
+	}
+	want := []string{"capture-pane", "-p", "-J", "-t", "%3", "-S", "-40"}
+	got := mockApp.Base.GetLastExecCommandCall().Args
+	if strings.Join(got, " ") != strings.Join(want, " ") {
+		t.Errorf("expected %q, got %q", want, got)
+	}
+
+	if _, err := app.CapturePane("%3", 0, true); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want = []string{"capture-pane", "-p", "-J", "-t", "%3", "-S", "-0", "-e"}
+	got = mockApp.Base.GetLastExecCommandCall().Args
+	if strings.Join(got, " ") != strings.Join(want, " ") {
+		t.Errorf("expected %q, got %q", want, got)
+	}
+}
+
+func TestSendText(t *testing.T) {
+	tests := []struct {
+		name  string
+		text  string
+		enter bool
+		want  [][]string
+	}{
+		{
+			name: "without enter",
+			text: "-v keep going",
+			want: [][]string{{"send-keys", "-t", "%3", "-l", "--", "-v keep going"}},
+		},
+		{
+			name:  "enter only",
+			enter: true,
+			want:  [][]string{{"send-keys", "-t", "%3", "Enter"}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockApp := testutil.NewMockApp()
+			mockApp.Base.SetExecCommandResult("", "", nil)
+			app := &tmux.Tmux{Cmd: mockApp.Cmd, Base: mockApp.Base}
+
+			if err := app.SendText("%3", tt.text, tt.enter); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			calls := mockApp.Base.ExecCommandCalls
+			if len(calls) != len(tt.want) {
+				t.Fatalf("expected %d calls, got %d", len(tt.want), len(calls))
+			}
+			for i, want := range tt.want {
+				if got := calls[i].Args; strings.Join(got, "\x00") != strings.Join(want, "\x00") {
+					t.Errorf("call %d: expected %q, got %q", i, want, got)
+				}
+			}
+		})
+	}
+}
@@ -76,5 +76,5 @@ func (u *Ulauncher) ExecuteCommand(args ...string) error {
 }
 
 func (u *Ulauncher) Update() error {
-	return fmt.Errorf("%w for ulauncher — use system package manager", apps.ErrUpdateNotSupported)
+	return u.Cmd.UpgradeDesktopApp(constants.Ulauncher)
 }
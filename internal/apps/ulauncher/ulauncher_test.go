@@ -161,12 +161,11 @@ func TestUpdate(t *testing.T) {
 	mockApp := testutil.NewMockApp()
 	u := &Ulauncher{Cmd: mockApp.Cmd}
 
-	err := u.Update()
-	if err == nil {
-		t.Fatal("Expected Update to return error")
+	if err := u.Update(); err != nil {
+		t.Fatalf("Update() error: %v", err)
 	}
-	if !errors.Is(err, apps.ErrUpdateNotSupported) {
-		t.Errorf("expected ErrUpdateNotSupported, got: %v", err)
+	if mockApp.Cmd.UpgradedDesktopApp != constants.Ulauncher {
+		t.Errorf("expected %q to be upgraded, got %q", constants.Ulauncher, mockApp.Cmd.UpgradedDesktopApp)
 	}
 
 	testutil.VerifyNoRealCommands(t, mockApp.Base)
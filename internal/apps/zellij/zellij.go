@@ -0,0 +1,389 @@
+// -------------------------
+// Zellij is the alternative terminal multiplexer `dg wt`/`dg ws` can drive
+// instead of tmux (worktree.multiplexer: zellij in global_config.yaml).
+// - Zellij documentation: https://zellij.dev/documentation/
+// - CLI actions: https://zellij.dev/documentation/cli-actions
+// - Layouts (KDL): https://zellij.dev/documentation/layouts
+//
+// Zellij mirrors the subset of *tmux.Tmux's methods (and its value types)
+// that worktree.Multiplexer is made of, mapping tmux windows to Zellij tabs.
+// Its CLI can only address the focused pane of a tab, not a pane by id, so
+// the pane-level calls used while building a window work on the tab's
+// focused pane (see ActivePaneID), and multi-pane windows are built in one
+// step from a KDL layout (CreateWindowFromLayout) rather than split by split.
+// Reading or typing into an existing window's agent pane would mean stealing
+// the user's tab focus to reach it, so WindowPanes and CapturePane report
+// ErrPaneAccessUnsupported instead. Installing Zellij is
+// not managed by devgita; it must already be on PATH (0.40 or newer, for
+// `attach --create-background`).
+// -------------------------
+
+package zellij
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/cjairm/devgita/internal/apps/tmux"
+	cmd "github.com/cjairm/devgita/internal/commands"
+	"github.com/cjairm/devgita/pkg/constants"
+)
+
+// ErrPaneAccessUnsupported is returned by the calls that would have to
+// address a pane other than the one the user has focused: Zellij's CLI
+// can only reach a pane by focusing its tab first.
+var ErrPaneAccessUnsupported = errors.New(
+	"zellij can't read or address a pane without focusing its tab",
+)
+
+// sessionEnv is set by Zellij inside every pane to the owning session's name.
+const sessionEnv = "ZELLIJ_SESSION_NAME"
+
+// tabTemplate wraps a layout's panes in the tab and status bars Zellij's
+// default layout shows, which a custom layout would otherwise drop.
+const tabTemplate = `    default_tab_template {
+        pane size=1 borderless=true {
+            plugin location="zellij:tab-bar"
+        }
+        children
+        pane size=2 borderless=true {
+            plugin location="zellij:status-bar"
+        }
+    }
+`
+
+type Zellij struct {
+	Base cmd.BaseCommandExecutor
+}
+
+func New() *Zellij {
+	return &Zellij{Base: cmd.NewBaseCommand()}
+}
+
+// run executes zellij with args, against session (via the global --session
+// flag) when it's non-empty and the current session otherwise.
+func (z *Zellij) run(session string, args ...string) (string, error) {
+	if session != "" {
+		args = append([]string{"--session", session}, args...)
+	}
+	execCommand := cmd.CommandParams{Command: constants.Zellij, Args: args}
+	stdout, stderr, err := z.Base.ExecCommand(execCommand)
+	if err != nil {
+		if msg := strings.TrimSpace(stderr); msg != "" {
+			return stdout, fmt.Errorf("zellij: %s", msg)
+		}
+		return stdout, fmt.Errorf("failed to execute zellij command: %w", err)
+	}
+	return stdout, nil
+}
+
+// action runs `zellij action <args>` against session.
+func (z *Zellij) action(session string, args ...string) error {
+	_, err := z.run(session, append([]string{"action"}, args...)...)
+	return err
+}
+
+// CreateSession creates a detached (background) session rooted at workdir.
+func (z *Zellij) CreateSession(name, workdir string) error {
+	_, err := z.run(
+		"", "attach", "--create-background", name, "options", "--default-cwd", workdir,
+	)
+	return err
+}
+
+// CreateSessionWithWindow creates a detached session whose only tab is named
+// windowName.
+func (z *Zellij) CreateSessionWithWindow(session, windowName, workdir string) error {
+	if err := z.CreateSession(session, workdir); err != nil {
+		return err
+	}
+	return z.action(session, "rename-tab", windowName)
+}
+
+// CreateWindowInSession opens a new tab named name in session.
+func (z *Zellij) CreateWindowInSession(session, name, workdir string) error {
+	return z.action(session, "new-tab", "--name", name, "--cwd", workdir)
+}
+
+// CreateWindow opens a new tab in the current session.
+func (z *Zellij) CreateWindow(name, workdir string) error {
+	return z.action("", "new-tab", "--name", name, "--cwd", workdir)
+}
+
+// CreateWindowFromLayout opens a tab named window whose panes come from
+// layout, the pane nodes of a KDL `layout { ... }` (placed under the usual
+// tab and status bars). session "" means the current session; a session
+// that doesn't exist yet is created around the layout. The layout goes in a
+// private temp file (0600, unique name, so neither another user nor another
+// window's layout can take its place) that is removed once the zellij
+// client, which parses it before talking to the server, has returned.
+func (z *Zellij) CreateWindowFromLayout(session, window, workdir, layout string) error {
+	f, err := os.CreateTemp("", "devgita-zellij-*.kdl")
+	if err != nil {
+		return fmt.Errorf("failed to write zellij layout: %w", err)
+	}
+	file := f.Name()
+	defer os.Remove(file)
+	_, err = f.WriteString("layout {\n" + tabTemplate + layout + "}\n")
+	if err = errors.Join(err, f.Close()); err != nil {
+		return fmt.Errorf("failed to write zellij layout: %w", err)
+	}
+
+	if session != "" && !z.HasSession(session) {
+		if _, err := z.run(
+			"", "attach", "--create-background", session,
+			"options", "--default-layout", file, "--default-cwd", workdir,
+		); err != nil {
+			return err
+		}
+		return z.action(session, "rename-tab", window)
+	}
+	return z.action(session, "new-tab", "--layout", file, "--name", window, "--cwd", workdir)
+}
+
+// ListSessions returns the live sessions; exited sessions Zellij keeps
+// around for resurrection are skipped, and "no sessions" is (nil, nil).
+// Zellij doesn't report attached clients per session, so Attached marks
+// only the session this process runs in.
+func (z *Zellij) ListSessions() ([]tmux.SessionInfo, error) {
+	stdout, err := z.run("", "list-sessions", "--no-formatting")
+	if err != nil {
+		if strings.Contains(stdout+err.Error(), "No active zellij sessions") {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to list zellij sessions: %w", err)
+	}
+	current, _ := z.CurrentSession()
+	var sessions []tmux.SessionInfo
+	scanner := bufio.NewScanner(strings.NewReader(stdout))
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) == 0 || strings.Contains(scanner.Text(), "EXITED") {
+			continue
+		}
+		sessions = append(sessions, tmux.SessionInfo{
+			Name:     fields[0],
+			Attached: fields[0] == current,
+		})
+	}
+	return sessions, nil
+}
+
+// HasSession reports whether a live session named name exists.
+func (z *Zellij) HasSession(name string) bool {
+	sessions, err := z.ListSessions()
+	if err != nil {
+		return false
+	}
+	for _, s := range sessions {
+		if s.Name == name {
+			return true
+		}
+	}
+	return false
+}
+
+// CurrentSession returns the session this process runs in, from the
+// environment Zellij gives every pane.
+func (z *Zellij) CurrentSession() (string, bool) {
+	name := os.Getenv(sessionEnv)
+	return name, name != ""
+}
+
+// SwitchToSession succeeds only for the current session: Zellij's CLI can't
+// move an attached client to another session.
+func (z *Zellij) SwitchToSession(name string) error {
+	if current, ok := z.CurrentSession(); ok && current == name {
+		return nil
+	}
+	return fmt.Errorf(
+		"zellij can't switch this client to session %q; run `zellij attach %s`", name, name,
+	)
+}
+
+// KillSession terminates a session.
+func (z *Zellij) KillSession(name string) error {
+	_, err := z.run("", "kill-session", name)
+	return err
+}
+
+// SessionWindows returns every (session, tab) pair across live sessions,
+// one query-tab-names per session. Returns nil when nothing is reachable.
+func (z *Zellij) SessionWindows() []tmux.SessionWindow {
+	sessions, err := z.ListSessions()
+	if err != nil {
+		return nil
+	}
+	var pairs []tmux.SessionWindow
+	for _, s := range sessions {
+		stdout, err := z.run(s.Name, "action", "query-tab-names")
+		if err != nil {
+			continue
+		}
+		for tab := range strings.SplitSeq(strings.TrimSpace(stdout), "\n") {
+			if tab != "" {
+				pairs = append(pairs, tmux.SessionWindow{Session: s.Name, Window: tab})
+			}
+		}
+	}
+	return pairs
+}
+
+// WindowSession returns the session holding a tab named name.
+func (z *Zellij) WindowSession(name string) (string, bool) {
+	for _, sw := range z.SessionWindows() {
+		if sw.Window == name {
+			return sw.Session, true
+		}
+	}
+	return "", false
+}
+
+// FindWindowsBySuffix returns the names of all tabs ending in suffix.
+func (z *Zellij) FindWindowsBySuffix(suffix string) []string {
+	var matches []string
+	for _, sw := range z.SessionWindows() {
+		if strings.HasSuffix(sw.Window, suffix) {
+			matches = append(matches, sw.Window)
+		}
+	}
+	return matches
+}
+
+// resolve splits a "session:tab" target, or finds the session of a bare tab
+// name.
+func (z *Zellij) resolve(target string) (session, tab string, err error) {
+	if session, tab, ok := strings.Cut(target, ":"); ok {
+		return session, tab, nil
+	}
+	session, ok := z.WindowSession(target)
+	if !ok {
+		return "", "", fmt.Errorf("no zellij tab named %q", target)
+	}
+	return session, target, nil
+}
+
+// focusTab resolves target and makes its tab the focused one in its
+// session, which every pane-level action below acts on.
+func (z *Zellij) focusTab(target string) (session string, err error) {
+	session, tab, err := z.resolve(target)
+	if err != nil {
+		return "", err
+	}
+	return session, z.action(session, "go-to-tab-name", tab)
+}
+
+// SwitchToWindow focuses the tab, provided session is the current one (see
+// SwitchToSession).
+func (z *Zellij) SwitchToWindow(session, name string) error {
+	if err := z.SwitchToSession(session); err != nil {
+		return err
+	}
+	return z.action(session, "go-to-tab-name", name)
+}
+
+// KillWindow closes the tab named name, in whichever session holds it.
+func (z *Zellij) KillWindow(name string) error {
+	session, err := z.focusTab(name)
+	if err != nil {
+		return err
+	}
+	return z.action(session, "close-tab")
+}
+
+// SendKeysToWindow types keys into the focused pane of window's tab and
+// presses Enter.
+func (z *Zellij) SendKeysToWindow(window, keys string) error {
+	return z.SendText(window, keys, true)
+}
+
+// SendKeysToWindowInSession is SendKeysToWindow for a tab in session.
+func (z *Zellij) SendKeysToWindowInSession(session, window, keys string) error {
+	return z.SendText(session+":"+window, keys, true)
+}
+
+// SendLiteralKeys types text into target and presses Enter. See SendText.
+func (z *Zellij) SendLiteralKeys(target, text string) error {
+	return z.SendText(target, text, true)
+}
+
+// SendText types text verbatim into the focused pane of target's tab ("tab"
+// or "session:tab") and, when enter is set, presses Enter (byte 13) after
+// it. write-chars never interprets key names, so nothing needs escaping.
+func (z *Zellij) SendText(target, text string, enter bool) error {
+	session, err := z.focusTab(target)
+	if err != nil {
+		return err
+	}
+	if text != "" {
+		if err := z.action(session, "write-chars", "--", text); err != nil {
+			return err
+		}
+	}
+	if !enter {
+		return nil
+	}
+	return z.action(session, "write", "13")
+}
+
+// ActivePaneID returns target itself: Zellij's CLI has no pane ids, so the
+// closest stable handle to "the active pane" is the tab, whose focused pane
+// every pane-level call acts on.
+func (z *Zellij) ActivePaneID(window string) (string, error) {
+	session, tab, err := z.resolve(window)
+	if err != nil {
+		return "", err
+	}
+	return session + ":" + tab, nil
+}
+
+// SelectPane focuses the tab of a handle returned by ActivePaneID.
+func (z *Zellij) SelectPane(paneID string) error {
+	_, err := z.focusTab(paneID)
+	return err
+}
+
+// SplitWindowSized opens a new pane in window's tab, beside ("vertical") or
+// below ("horizontal") the focused one. Zellij's new-pane takes no size, so
+// percent is only validated; KDL layouts (CreateWindowFromLayout) are the way
+// to get sized panes.
+func (z *Zellij) SplitWindowSized(window, workdir, direction string, percent int) error {
+	var flag string
+	switch direction {
+	case "vertical":
+		flag = "right"
+	case "horizontal":
+		flag = "down"
+	default:
+		return fmt.Errorf(
+			"unknown split direction %q (want \"vertical\" or \"horizontal\")",
+			direction,
+		)
+	}
+	if percent < 0 || percent > 99 {
+		return fmt.Errorf("split size %d%% out of range (want 1-99)", percent)
+	}
+	session, err := z.focusTab(window)
+	if err != nil {
+		return err
+	}
+	return z.action(session, "new-pane", "--direction", flag, "--cwd", workdir)
+}
+
+// WindowPanes returns ErrPaneAccessUnsupported: Zellij's CLI can't list a
+// tab's panes, and the focused pane it can reach isn't necessarily the
+// first one (the agent pane worktree layouts launch).
+func (z *Zellij) WindowPanes(target string) ([]tmux.PaneInfo, error) {
+	return nil, ErrPaneAccessUnsupported
+}
+
+// CapturePane returns ErrPaneAccessUnsupported: dump-screen only reads the
+// focused pane of the focused tab, and focusing target's tab to reach it
+// would pull the user away from whatever they're looking at every time the
+// dashboard samples.
+func (z *Zellij) CapturePane(target string, history int, escapes bool) (string, error) {
+	return "", ErrPaneAccessUnsupported
+}
@@ -0,0 +1,216 @@
+package zellij_test
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/cjairm/devgita/internal/apps/zellij"
+	"github.com/cjairm/devgita/internal/commands"
+	"github.com/cjairm/devgita/internal/testutil"
+	"github.com/cjairm/devgita/pkg/constants"
+)
+
+func init() {
+	testutil.InitLogger()
+}
+
+func newMockZellij() (*zellij.Zellij, *commands.MockBaseCommand) {
+	mockBase := commands.NewMockBaseCommand()
+	return &zellij.Zellij{Base: mockBase}, mockBase
+}
+
+func callArgs(mockBase *commands.MockBaseCommand) []string {
+	var calls []string
+	for _, c := range mockBase.ExecCommandCalls {
+		if c.Command != constants.Zellij {
+			calls = append(calls, "unexpected command "+c.Command)
+		}
+		calls = append(calls, strings.Join(c.Args, " "))
+	}
+	return calls
+}
+
+func TestListSessionsSkipsExited(t *testing.T) {
+	t.Setenv("ZELLIJ_SESSION_NAME", "devgita")
+	z, mockBase := newMockZellij()
+	mockBase.SetExecCommandResult(
+		"devgita [Created 2h ago]\nold [Created 1d ago] (EXITED - attach to resurrect)\nmisc [Created 5m ago]\n",
+		"", nil,
+	)
+
+	sessions, err := z.ListSessions()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(sessions) != 2 || sessions[0].Name != "devgita" || sessions[1].Name != "misc" {
+		t.Fatalf("expected devgita and misc, got %+v", sessions)
+	}
+	if !sessions[0].Attached || sessions[1].Attached {
+		t.Errorf("expected only the current session attached, got %+v", sessions)
+	}
+	if got := callArgs(mockBase); got[0] != "list-sessions --no-formatting" {
+		t.Errorf("unexpected call %q", got[0])
+	}
+}
+
+func TestListSessionsNoServer(t *testing.T) {
+	z, mockBase := newMockZellij()
+	mockBase.SetExecCommandResult("", "No active zellij sessions found.", errors.New("exit status 1"))
+
+	sessions, err := z.ListSessions()
+	if err != nil || sessions != nil {
+		t.Errorf("expected (nil, nil), got (%v, %v)", sessions, err)
+	}
+}
+
+func TestCreateWindowInSession(t *testing.T) {
+	z, mockBase := newMockZellij()
+
+	if err := z.CreateWindowInSession("devgita", "wt-devgita-feat", "/tmp/wt"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want := "--session devgita action new-tab --name wt-devgita-feat --cwd /tmp/wt"
+	if got := callArgs(mockBase); len(got) != 1 || got[0] != want {
+		t.Errorf("expected %q, got %v", want, got)
+	}
+}
+
+func TestSendTextTargetsFocusedPaneOfTab(t *testing.T) {
+	z, mockBase := newMockZellij()
+
+	if err := z.SendText("devgita:wt-devgita-feat", "-fix it", true); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want := []string{
+		"--session devgita action go-to-tab-name wt-devgita-feat",
+		"--session devgita action write-chars -- -fix it",
+		"--session devgita action write 13",
+	}
+	if got := callArgs(mockBase); strings.Join(got, "\n") != strings.Join(want, "\n") {
+		t.Errorf("unexpected calls:\n%s", strings.Join(got, "\n"))
+	}
+}
+
+func TestPaneReadsAreUnsupportedWithoutStealingFocus(t *testing.T) {
+	z, mockBase := newMockZellij()
+
+	if _, err := z.WindowPanes("devgita:wt-devgita-feat"); !errors.Is(err, zellij.ErrPaneAccessUnsupported) {
+		t.Errorf("expected WindowPanes to be unsupported, got %v", err)
+	}
+	if _, err := z.CapturePane("devgita:wt-devgita-feat", 0, false); !errors.Is(err, zellij.ErrPaneAccessUnsupported) {
+		t.Errorf("expected CapturePane to be unsupported, got %v", err)
+	}
+	if got := callArgs(mockBase); len(got) != 0 {
+		t.Errorf("expected no zellij calls (no tab focus change), got %v", got)
+	}
+}
+
+func TestSwitchToSessionOnlyCurrent(t *testing.T) {
+	t.Setenv("ZELLIJ_SESSION_NAME", "devgita")
+	z, _ := newMockZellij()
+
+	if err := z.SwitchToSession("devgita"); err != nil {
+		t.Errorf("expected switching to the current session to succeed: %v", err)
+	}
+	if err := z.SwitchToSession("misc"); err == nil {
+		t.Error("expected switching to another session to fail")
+	}
+}
+
+// layoutRecorder reads each --layout/--default-layout file while the zellij
+// call that names it runs, since the file is gone once the call returns.
+type layoutRecorder struct {
+	*commands.MockBaseCommand
+	layouts map[string]string
+}
+
+func (r *layoutRecorder) ExecCommand(c commands.CommandParams) (string, string, error) {
+	for i, arg := range c.Args {
+		if (arg == "--layout" || arg == "--default-layout") && i+1 < len(c.Args) {
+			data, _ := os.ReadFile(c.Args[i+1])
+			r.layouts[c.Args[i+1]] = string(data)
+		}
+	}
+	return r.MockBaseCommand.ExecCommand(c)
+}
+
+func newLayoutZellij() (*zellij.Zellij, *layoutRecorder) {
+	rec := &layoutRecorder{MockBaseCommand: commands.NewMockBaseCommand(), layouts: map[string]string{}}
+	return &zellij.Zellij{Base: rec}, rec
+}
+
+// layoutFile returns the single layout file rec saw and its contents.
+func layoutFile(t *testing.T, rec *layoutRecorder) (string, string) {
+	t.Helper()
+	if len(rec.layouts) != 1 {
+		t.Fatalf("expected one layout file, got %v", rec.layouts)
+	}
+	for file, content := range rec.layouts {
+		return file, content
+	}
+	return "", ""
+}
+
+func TestCreateWindowFromLayout(t *testing.T) {
+	tmp := t.TempDir()
+	t.Setenv("TMPDIR", tmp)
+	body := "    pane\n"
+
+	t.Run("new session", func(t *testing.T) {
+		z, rec := newLayoutZellij()
+		rec.SetExecCommandResults(
+			commands.ExecCommandResult("", "No active zellij sessions found.", errors.New("exit status 1")),
+			commands.ExecCommandResult("", "", nil),
+		)
+
+		if err := z.CreateWindowFromLayout("devgita", "wt-devgita-feat", "/tmp/wt", body); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		file, content := layoutFile(t, rec)
+		got := callArgs(rec.MockBaseCommand)
+		want := "attach --create-background devgita options --default-layout " +
+			file + " --default-cwd /tmp/wt"
+		if len(got) < 2 || got[1] != want {
+			t.Errorf("expected %q, got %v", want, got)
+		}
+		if filepath.Dir(file) != tmp {
+			t.Errorf("expected a private temp file under %s, got %s", tmp, file)
+		}
+		if !strings.HasPrefix(content, "layout {\n") ||
+			!strings.HasSuffix(content, body+"}\n") ||
+			!strings.Contains(content, "zellij:tab-bar") {
+			t.Errorf("unexpected layout file:\n%s", content)
+		}
+		if _, err := os.Stat(file); !os.IsNotExist(err) {
+			t.Errorf("expected the layout file removed after zellij loaded it, got %v", err)
+		}
+	})
+
+	t.Run("current session", func(t *testing.T) {
+		z, rec := newLayoutZellij()
+
+		if err := z.CreateWindowFromLayout("", "wt-devgita-feat", "/tmp/wt", body); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		file, _ := layoutFile(t, rec)
+		want := "action new-tab --layout " + file + " --name wt-devgita-feat --cwd /tmp/wt"
+		if got := callArgs(rec.MockBaseCommand); len(got) != 1 || got[0] != want {
+			t.Errorf("expected %q, got %v", want, got)
+		}
+		if info, err := os.Stat(file); err == nil {
+			t.Errorf("expected the layout file removed, found %v", info.Mode())
+		}
+	})
+
+	t.Run("windows with the same name get separate files", func(t *testing.T) {
+		z, rec := newLayoutZellij()
+		_ = z.CreateWindowFromLayout("", "wt-devgita-feat", "/tmp/wt", body)
+		_ = z.CreateWindowFromLayout("", "wt-devgita-feat", "/tmp/wt", body)
+		if len(rec.layouts) != 2 {
+			t.Errorf("expected two distinct layout files, got %v", rec.layouts)
+		}
+	})
+}
@@ -0,0 +1,763 @@
+// Package backup snapshots every file devgita manages into a gzipped tarball
+// and restores it later.
+//
+// A snapshot holds the configured app directories under XDG_CONFIG_HOME, the
+// devgita-owned parts of ~/.claude and ~/.config/opencode, ~/.tmux.conf,
+// devgita.zsh, and global_config.yaml. Its first entry is manifest.json, which
+// lists every archived file with its SHA-256; Restore verifies the archive
+// against the manifest before touching anything on disk.
+//
+// Paths inside an archive are stored relative to the home directory, so a
+// snapshot taken on one machine restores into the right places on another.
+package backup
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/cjairm/devgita/pkg/constants"
+	"github.com/cjairm/devgita/pkg/logger"
+	"github.com/cjairm/devgita/pkg/paths"
+)
+
+const (
+	// DirName is the folder under the devgita data dir that holds snapshots.
+	DirName = "backups"
+	// manifestName is the archive entry holding the Manifest; always first.
+	manifestName = "manifest.json"
+	// filesPrefix is the archive directory holding the snapshotted files.
+	filesPrefix = "files/"
+	// archiveExt is appended to a snapshot name to form its file name.
+	archiveExt = ".tar.gz"
+	// manifestVersion is bumped when the Manifest layout changes.
+	manifestVersion = 1
+)
+
+// namePattern restricts snapshot names to something safe as a file name.
+var namePattern = regexp.MustCompile(`^[A-Za-z0-9][A-Za-z0-9._-]*$`)
+
+// ReservedNames are dg backup's subcommands: `dg backup list` runs the
+// subcommand, so a snapshot by that name could never be created, and
+// `dg restore list` would name one that can't exist.
+var ReservedNames = []string{"list", "prune"}
+
+// ErrNotFound is returned when a named snapshot does not exist.
+var ErrNotFound = errors.New("backup not found")
+
+// Target is one file or directory devgita manages.
+type Target struct {
+	App  string // owning app, for display
+	Path string // absolute path
+}
+
+// FileEntry describes one archived file, directory, or symlink.
+type FileEntry struct {
+	Path   string      `json:"path"` // slash-separated, relative to the home directory
+	Mode   fs.FileMode `json:"mode"`
+	Size   int64       `json:"size,omitempty"`
+	SHA256 string      `json:"sha256,omitempty"` // regular files only
+	Link   string      `json:"link,omitempty"`   // symlinks only
+}
+
+// Manifest is stored as the first entry of every snapshot.
+type Manifest struct {
+	Version        int       `json:"version"`
+	Name           string    `json:"name"`
+	CreatedAt      time.Time `json:"created_at"`
+	DevgitaVersion string    `json:"devgita_version"`
+	// Roots are the targets that existed when the snapshot was taken. Restore
+	// replaces each root wholesale, so files added after the snapshot go away.
+	Roots []string    `json:"roots"`
+	Files []FileEntry `json:"files"`
+}
+
+// Snapshot is a snapshot archive on disk plus its manifest.
+type Snapshot struct {
+	Name     string
+	Path     string
+	Size     int64
+	Manifest Manifest
+}
+
+// Manager creates, lists, restores, and prunes snapshots.
+type Manager struct {
+	// Dir holds the snapshot archives.
+	Dir string
+	// Home is the directory archive paths are relative to.
+	Home string
+	// Targets are the paths captured by Create.
+	Targets []Target
+	// DevgitaVersion is recorded in each new manifest.
+	DevgitaVersion string
+
+	now func() time.Time
+}
+
+// New returns a Manager for the current user's managed files.
+func New(devgitaVersion string) *Manager {
+	return &Manager{
+		Dir:            filepath.Join(paths.Paths.App.Root, DirName),
+		Home:           paths.Paths.Home.Root,
+		Targets:        ManagedTargets(),
+		DevgitaVersion: devgitaVersion,
+		now:            time.Now,
+	}
+}
+
+// ManagedTargets lists every path devgita writes when configuring apps. For
+// the AI coders only the parts devgita renders are included: ~/.claude also
+// holds Claude Code's own history and project state, which is not ours to
+// snapshot or overwrite.
+func ManagedTargets() []Target {
+	targets := []Target{
+		{
+			App:  constants.DevgitaApp,
+			Path: filepath.Join(paths.Paths.Config.Root, constants.App.Name, constants.App.File.GlobalConfig),
+		},
+		{App: constants.DevgitaApp, Path: filepath.Join(paths.Paths.App.Root, constants.App.Name+".zsh")},
+		{App: constants.Aerospace, Path: paths.Paths.Config.Aerospace},
+		{App: constants.Alacritty, Path: paths.Paths.Config.Alacritty},
+		{App: constants.Fastfetch, Path: paths.Paths.Config.Fastfetch},
+		{App: constants.Git, Path: paths.Paths.Config.Git},
+		{App: constants.I3, Path: paths.Paths.Config.I3},
+		{App: constants.Neovim, Path: paths.Paths.Config.Nvim},
+		{App: constants.Tmux, Path: filepath.Join(paths.Paths.Home.Root, ".tmux.conf")},
+	}
+	aiParts := map[string][]string{
+		constants.Claude: {
+			"settings.json", "statusline.sh", "format.sh", "task-redirect.sh",
+			"themes", "skills", "commands", "agents",
+		},
+		constants.OpenCode: {
+			constants.OpenCode + ".json", "themes", "plugin",
+			"skills", "commands", "agents",
+		},
+	}
+	aiRoots := map[string]string{
+		constants.Claude:   paths.Paths.Config.Claude,
+		constants.OpenCode: paths.Paths.Config.OpenCode,
+	}
+	for _, app := range []string{constants.Claude, constants.OpenCode} {
+		for _, part := range aiParts[app] {
+			targets = append(targets, Target{App: app, Path: filepath.Join(aiRoots[app], part)})
+		}
+	}
+	return targets
+}
+
+// ValidateName reports whether name can be used as a snapshot name.
+func ValidateName(name string) error {
+	if !namePattern.MatchString(name) {
+		return fmt.Errorf(
+			"invalid backup name %q (use letters, digits, '.', '_' or '-', starting with a letter or digit)",
+			name,
+		)
+	}
+	if slices.Contains(ReservedNames, name) {
+		return fmt.Errorf("invalid backup name %q (reserved for dg backup %s)", name, name)
+	}
+	return nil
+}
+
+// DefaultName is the timestamp name used when `dg backup` is given none.
+func DefaultName(t time.Time) string {
+	return t.Format("20060102-150405")
+}
+
+func (m *Manager) archivePath(name string) string {
+	return filepath.Join(m.Dir, name+archiveExt)
+}
+
+// Create snapshots every existing target into <Dir>/<name>.tar.gz. An empty
+// name defaults to the current timestamp. Existing snapshots are never
+// overwritten.
+func (m *Manager) Create(name string) (*Snapshot, error) {
+	now := m.now()
+	if name == "" {
+		name = DefaultName(now)
+	}
+	if err := ValidateName(name); err != nil {
+		return nil, err
+	}
+	dest := m.archivePath(name)
+	if _, err := os.Lstat(dest); err == nil {
+		return nil, fmt.Errorf("backup %q already exists", name)
+	}
+
+	manifest := Manifest{
+		Version:        manifestVersion,
+		Name:           name,
+		CreatedAt:      now.UTC(),
+		DevgitaVersion: m.DevgitaVersion,
+	}
+	for _, t := range m.Targets {
+		if _, err := os.Lstat(t.Path); err != nil {
+			continue
+		}
+		rel, err := m.relToHome(t.Path)
+		if err != nil {
+			return nil, err
+		}
+		manifest.Roots = append(manifest.Roots, rel)
+		entries, err := m.collect(t.Path)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read %s: %w", t.Path, err)
+		}
+		manifest.Files = append(manifest.Files, entries...)
+	}
+	if len(manifest.Roots) == 0 {
+		return nil, fmt.Errorf("nothing to back up: no devgita-managed files found")
+	}
+
+	if err := os.MkdirAll(m.Dir, 0o755); err != nil {
+		return nil, fmt.Errorf("failed to create backup directory: %w", err)
+	}
+	tmp, err := os.CreateTemp(m.Dir, "."+name+"-*.tmp")
+	if err != nil {
+		return nil, fmt.Errorf("failed to create backup file: %w", err)
+	}
+	tmpName := tmp.Name()
+	defer func() { _ = os.Remove(tmpName) }()
+
+	if err := m.writeArchive(tmp, &manifest); err != nil {
+		_ = tmp.Close()
+		return nil, err
+	}
+	if err := tmp.Close(); err != nil {
+		return nil, fmt.Errorf("failed to close backup file: %w", err)
+	}
+	if err := os.Rename(tmpName, dest); err != nil {
+		return nil, fmt.Errorf("failed to finalize backup: %w", err)
+	}
+	info, err := os.Stat(dest)
+	if err != nil {
+		return nil, err
+	}
+	logger.L().Infow("Backup created", "name", name, "path", dest, "files", len(manifest.Files))
+	return &Snapshot{Name: name, Path: dest, Size: info.Size(), Manifest: manifest}, nil
+}
+
+// collect walks root and returns an entry per file, directory, and symlink,
+// hashing regular files. Other file types (sockets, devices) are skipped.
+func (m *Manager) collect(root string) ([]FileEntry, error) {
+	var entries []FileEntry
+	err := filepath.WalkDir(root, func(p string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		info, err := os.Lstat(p)
+		if err != nil {
+			return err
+		}
+		rel, err := m.relToHome(p)
+		if err != nil {
+			return err
+		}
+		entry := FileEntry{Path: rel, Mode: info.Mode()}
+		switch {
+		case info.Mode().IsRegular():
+			sum, err := hashFile(p)
+			if err != nil {
+				return err
+			}
+			entry.Size = info.Size()
+			entry.SHA256 = sum
+		case info.Mode()&fs.ModeSymlink != 0:
+			link, err := os.Readlink(p)
+			if err != nil {
+				return err
+			}
+			entry.Link = link
+		case info.IsDir():
+		default:
+			return nil
+		}
+		entries = append(entries, entry)
+		return nil
+	})
+	return entries, err
+}
+
+// writeArchive writes the manifest followed by every file it lists.
+func (m *Manager) writeArchive(w io.Writer, manifest *Manifest) error {
+	gz := gzip.NewWriter(w)
+	tw := tar.NewWriter(gz)
+
+	data, err := json.MarshalIndent(manifest, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode manifest: %w", err)
+	}
+	if err := tw.WriteHeader(&tar.Header{
+		Name:    manifestName,
+		Mode:    0o644,
+		Size:    int64(len(data)),
+		ModTime: manifest.CreatedAt,
+	}); err != nil {
+		return err
+	}
+	if _, err := tw.Write(data); err != nil {
+		return err
+	}
+
+	for _, e := range manifest.Files {
+		hdr := &tar.Header{
+			Name:    filesPrefix + e.Path,
+			Mode:    int64(e.Mode.Perm()),
+			ModTime: manifest.CreatedAt,
+		}
+		switch {
+		case e.Mode.IsDir():
+			hdr.Typeflag = tar.TypeDir
+			hdr.Name += "/"
+		case e.Mode&fs.ModeSymlink != 0:
+			hdr.Typeflag = tar.TypeSymlink
+			hdr.Linkname = e.Link
+		default:
+			hdr.Typeflag = tar.TypeReg
+			hdr.Size = e.Size
+		}
+		if err := tw.WriteHeader(hdr); err != nil {
+			return fmt.Errorf("failed to write %s: %w", e.Path, err)
+		}
+		if hdr.Typeflag != tar.TypeReg {
+			continue
+		}
+		if err := copyFileInto(tw, filepath.Join(m.Home, filepath.FromSlash(e.Path)), e.Size); err != nil {
+			return fmt.Errorf("failed to archive %s: %w", e.Path, err)
+		}
+	}
+
+	if err := tw.Close(); err != nil {
+		return err
+	}
+	return gz.Close()
+}
+
+// copyFileInto streams exactly size bytes of the file at p into w, so a file
+// that grows between hashing and archiving cannot corrupt the tar stream.
+func copyFileInto(w io.Writer, p string, size int64) error {
+	f, err := os.Open(p)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = f.Close() }()
+	_, err = io.CopyN(w, f, size)
+	return err
+}
+
+// List returns every snapshot, newest first.
+func (m *Manager) List() ([]Snapshot, error) {
+	entries, err := os.ReadDir(m.Dir)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to read backup directory: %w", err)
+	}
+	var snapshots []Snapshot
+	for _, e := range entries {
+		name, ok := strings.CutSuffix(e.Name(), archiveExt)
+		if !ok || e.IsDir() || strings.HasPrefix(e.Name(), ".") {
+			continue
+		}
+		snap, err := m.Open(name)
+		if err != nil {
+			logger.L().Warnw("Skipping unreadable backup", "name", name, "error", err)
+			continue
+		}
+		snapshots = append(snapshots, *snap)
+	}
+	sort.SliceStable(snapshots, func(i, j int) bool {
+		return snapshots[i].Manifest.CreatedAt.After(snapshots[j].Manifest.CreatedAt)
+	})
+	return snapshots, nil
+}
+
+// Open reads a snapshot's manifest without extracting anything.
+func (m *Manager) Open(name string) (*Snapshot, error) {
+	if err := ValidateName(name); err != nil {
+		return nil, err
+	}
+	p := m.archivePath(name)
+	info, err := os.Stat(p)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, fmt.Errorf("%w: %s", ErrNotFound, name)
+		}
+		return nil, err
+	}
+	f, err := os.Open(p)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = f.Close() }()
+	gz, err := gzip.NewReader(f)
+	if err != nil {
+		return nil, fmt.Errorf("%s is not a devgita backup: %w", p, err)
+	}
+	tr := tar.NewReader(gz)
+	hdr, err := tr.Next()
+	if err != nil || hdr.Name != manifestName {
+		return nil, fmt.Errorf("%s is not a devgita backup: missing %s", p, manifestName)
+	}
+	var manifest Manifest
+	if err := json.NewDecoder(tr).Decode(&manifest); err != nil {
+		return nil, fmt.Errorf("invalid manifest in %s: %w", p, err)
+	}
+	return &Snapshot{Name: name, Path: p, Size: info.Size(), Manifest: manifest}, nil
+}
+
+// Restore puts a snapshot's files back. The archive is first extracted to a
+// staging directory and every file is checked against the manifest hashes,
+// and each root's replacement is copied next to it; only then are the roots
+// swapped in by rename, so a corrupt archive or a failed copy changes
+// nothing.
+func (m *Manager) Restore(name string) (*Snapshot, error) {
+	if err := ValidateName(name); err != nil {
+		return nil, err
+	}
+	snap, err := m.Open(name)
+	if err != nil {
+		return nil, err
+	}
+	if snap.Manifest.Version > manifestVersion {
+		return nil, fmt.Errorf(
+			"backup %q was written by a newer devgita (manifest version %d)",
+			name, snap.Manifest.Version,
+		)
+	}
+
+	if err := m.checkRoots(snap.Manifest.Roots); err != nil {
+		return nil, fmt.Errorf("backup %q has an invalid manifest: %w", name, err)
+	}
+
+	staging, err := os.MkdirTemp(m.Dir, ".restore-"+name+"-")
+	if err != nil {
+		return nil, fmt.Errorf("failed to create staging directory: %w", err)
+	}
+	defer func() { _ = os.RemoveAll(staging) }()
+
+	if err := extract(snap.Path, staging); err != nil {
+		return nil, err
+	}
+	if err := verify(staging, snap.Manifest.Files); err != nil {
+		return nil, fmt.Errorf("backup %q failed verification: %w", name, err)
+	}
+
+	swaps := make([]rootSwap, 0, len(snap.Manifest.Roots))
+	defer func() {
+		for _, s := range swaps {
+			_ = os.RemoveAll(s.holder)
+		}
+	}()
+	for _, root := range snap.Manifest.Roots {
+		s, err := m.prepareRoot(staging, root)
+		if err != nil {
+			return nil, err
+		}
+		swaps = append(swaps, s)
+	}
+	for i := range swaps {
+		if err := swaps[i].swap(); err != nil {
+			for j := i - 1; j >= 0; j-- {
+				swaps[j].rollback()
+			}
+			return nil, err
+		}
+	}
+	logger.L().Infow("Backup restored", "name", name, "roots", snap.Manifest.Roots)
+	return snap, nil
+}
+
+// checkRoots rejects manifest roots Restore must not replace. Roots come
+// from inside the archive, so a hand-edited or corrupted manifest could
+// otherwise name "", ".", or a path outside the home directory and have
+// Restore clear it; only paths Create itself would snapshot are accepted.
+func (m *Manager) checkRoots(roots []string) error {
+	allowed := map[string]bool{}
+	for _, t := range m.Targets {
+		if rel, err := m.relToHome(t.Path); err == nil {
+			allowed[rel] = true
+		}
+	}
+	for _, root := range roots {
+		if root == "" || root == "." || !isLocalPath(root) {
+			return fmt.Errorf("unsafe root %q", root)
+		}
+		if !allowed[root] {
+			return fmt.Errorf("root %q is not a devgita-managed path", root)
+		}
+	}
+	return nil
+}
+
+// rootSwap is one root's pending replacement. holder is a temp directory
+// beside dst (so renames stay on one filesystem) holding the staged copy
+// as "new" and, once swapped, the previous contents as "old".
+type rootSwap struct {
+	dst    string
+	holder string
+	hadOld bool
+}
+
+// prepareRoot copies root's staged tree into a holder directory next to
+// its destination, leaving the destination itself untouched.
+func (m *Manager) prepareRoot(staging, root string) (rootSwap, error) {
+	src := filepath.Join(staging, filepath.FromSlash(root))
+	dst := filepath.Join(m.Home, filepath.FromSlash(root))
+	if err := os.MkdirAll(filepath.Dir(dst), 0o755); err != nil {
+		return rootSwap{}, fmt.Errorf("failed to create %s: %w", filepath.Dir(dst), err)
+	}
+	holder, err := os.MkdirTemp(filepath.Dir(dst), ".devgita-restore-")
+	if err != nil {
+		return rootSwap{}, fmt.Errorf("failed to stage %s: %w", dst, err)
+	}
+	s := rootSwap{dst: dst, holder: holder}
+	if err := copyTree(src, filepath.Join(holder, "new")); err != nil {
+		_ = os.RemoveAll(holder)
+		return rootSwap{}, fmt.Errorf("failed to stage %s: %w", dst, err)
+	}
+	return s, nil
+}
+
+// swap moves the current destination aside and the staged copy into place.
+func (s *rootSwap) swap() error {
+	if err := os.Rename(s.dst, filepath.Join(s.holder, "old")); err == nil {
+		s.hadOld = true
+	} else if !errors.Is(err, fs.ErrNotExist) {
+		return fmt.Errorf("failed to replace %s: %w", s.dst, err)
+	}
+	if err := os.Rename(filepath.Join(s.holder, "new"), s.dst); err != nil {
+		s.rollback()
+		return fmt.Errorf("failed to restore %s: %w", s.dst, err)
+	}
+	return nil
+}
+
+// rollback undoes swap, best-effort: the restored copy is dropped and the
+// previous contents, if any, are moved back.
+func (s *rootSwap) rollback() {
+	if _, err := os.Lstat(filepath.Join(s.holder, "new")); err != nil {
+		_ = os.RemoveAll(s.dst)
+	}
+	if s.hadOld {
+		_ = os.Rename(filepath.Join(s.holder, "old"), s.dst)
+		s.hadOld = false
+	}
+}
+
+// extract unpacks the files/ subtree of the archive at archive into dir.
+// Every write goes through an os.Root on dir, so an entry can't reach
+// outside it — not by its name, and not through a symlink an earlier entry
+// planted (files/x -> / followed by files/x/etc/foo).
+func extract(archive, dir string) error {
+	f, err := os.Open(archive)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = f.Close() }()
+	root, err := os.OpenRoot(dir)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = root.Close() }()
+	gz, err := gzip.NewReader(f)
+	if err != nil {
+		return err
+	}
+	tr := tar.NewReader(gz)
+	for {
+		hdr, err := tr.Next()
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+		if err != nil {
+			return fmt.Errorf("failed to read backup: %w", err)
+		}
+		rel, ok := strings.CutPrefix(hdr.Name, filesPrefix)
+		if !ok {
+			continue
+		}
+		rel = strings.TrimSuffix(rel, "/")
+		if rel == "" || !isLocalPath(rel) {
+			return fmt.Errorf("backup contains unsafe path %q", hdr.Name)
+		}
+		target := filepath.FromSlash(rel)
+		if err := root.MkdirAll(filepath.Dir(target), 0o755); err != nil {
+			return fmt.Errorf("backup contains unsafe path %q: %w", hdr.Name, err)
+		}
+		mode := fs.FileMode(hdr.Mode).Perm()
+		switch hdr.Typeflag {
+		case tar.TypeDir:
+			if err := root.MkdirAll(target, mode|0o700); err != nil {
+				return fmt.Errorf("backup contains unsafe path %q: %w", hdr.Name, err)
+			}
+		case tar.TypeSymlink:
+			if err := root.Symlink(hdr.Linkname, target); err != nil {
+				return err
+			}
+		case tar.TypeReg:
+			out, err := root.OpenFile(target, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, mode)
+			if err != nil {
+				return fmt.Errorf("backup contains unsafe path %q: %w", hdr.Name, err)
+			}
+			if _, err := io.Copy(out, tr); err != nil {
+				_ = out.Close()
+				return err
+			}
+			if err := out.Close(); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+// isLocalPath rejects absolute paths and ".." components in archive entries.
+func isLocalPath(rel string) bool {
+	return !path.IsAbs(rel) && path.Clean(rel) == rel && !strings.HasPrefix(rel, "../") && rel != ".."
+}
+
+// verify checks every manifest entry against the staged files, reading
+// through an os.Root on dir so a staged symlink can't point the check at a
+// file outside it.
+func verify(dir string, entries []FileEntry) error {
+	root, err := os.OpenRoot(dir)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = root.Close() }()
+	var errs []error
+	for _, e := range entries {
+		p := filepath.FromSlash(e.Path)
+		info, err := root.Lstat(p)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("%s: missing from archive", e.Path))
+			continue
+		}
+		switch {
+		case e.Mode.IsRegular():
+			sum, err := hashRootFile(root, p)
+			if err != nil {
+				errs = append(errs, fmt.Errorf("%s: %w", e.Path, err))
+			} else if sum != e.SHA256 {
+				errs = append(errs, fmt.Errorf("%s: checksum mismatch", e.Path))
+			}
+		case e.Mode&fs.ModeSymlink != 0:
+			if info.Mode()&fs.ModeSymlink == 0 {
+				errs = append(errs, fmt.Errorf("%s: expected a symlink", e.Path))
+			}
+		case e.Mode.IsDir():
+			if !info.IsDir() {
+				errs = append(errs, fmt.Errorf("%s: expected a directory", e.Path))
+			}
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// copyTree copies src (a file, symlink, or directory) to dst, preserving
+// permissions and symlinks.
+func copyTree(src, dst string) error {
+	return filepath.WalkDir(src, func(p string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		rel, err := filepath.Rel(src, p)
+		if err != nil {
+			return err
+		}
+		target := filepath.Join(dst, rel)
+		info, err := os.Lstat(p)
+		if err != nil {
+			return err
+		}
+		switch {
+		case info.IsDir():
+			return os.MkdirAll(target, info.Mode().Perm()|0o700)
+		case info.Mode()&fs.ModeSymlink != 0:
+			link, err := os.Readlink(p)
+			if err != nil {
+				return err
+			}
+			return os.Symlink(link, target)
+		default:
+			data, err := os.ReadFile(p)
+			if err != nil {
+				return err
+			}
+			return os.WriteFile(target, data, info.Mode().Perm())
+		}
+	})
+}
+
+// Prune deletes all but the keep newest snapshots and returns the ones it
+// removed.
+func (m *Manager) Prune(keep int) ([]Snapshot, error) {
+	if keep < 0 {
+		return nil, fmt.Errorf("--keep must be zero or more, got %d", keep)
+	}
+	snapshots, err := m.List()
+	if err != nil {
+		return nil, err
+	}
+	if len(snapshots) <= keep {
+		return nil, nil
+	}
+	var removed []Snapshot
+	for _, s := range snapshots[keep:] {
+		if err := os.Remove(s.Path); err != nil {
+			return removed, fmt.Errorf("failed to remove backup %s: %w", s.Name, err)
+		}
+		removed = append(removed, s)
+	}
+	return removed, nil
+}
+
+func (m *Manager) relToHome(p string) (string, error) {
+	rel, err := filepath.Rel(m.Home, p)
+	if err != nil || rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+		return "", fmt.Errorf("%s is outside the home directory %s", p, m.Home)
+	}
+	return filepath.ToSlash(rel), nil
+}
+
+func hashFile(p string) (string, error) {
+	f, err := os.Open(p)
+	if err != nil {
+		return "", err
+	}
+	return hashAndClose(f)
+}
+
+// hashRootFile is hashFile for a path inside root.
+func hashRootFile(root *os.Root, p string) (string, error) {
+	f, err := root.Open(p)
+	if err != nil {
+		return "", err
+	}
+	return hashAndClose(f)
+}
+
+func hashAndClose(f *os.File) (string, error) {
+	defer func() { _ = f.Close() }()
+	h := sha256.New()
+	if _, err := io.Copy(h, f); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
@@ -0,0 +1,424 @@
+package backup
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"encoding/json"
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/cjairm/devgita/internal/testutil"
+	"github.com/cjairm/devgita/pkg/paths"
+)
+
+func init() { testutil.InitLogger() }
+
+// newTestManager returns a Manager rooted in a temp home with two targets: a
+// config directory and a single file.
+func newTestManager(t *testing.T) (*Manager, string) {
+	t.Helper()
+	home := t.TempDir()
+	writeFile(t, filepath.Join(home, ".config", "nvim", "init.lua"), "vim.o.number = true\n")
+	writeFile(t, filepath.Join(home, ".config", "nvim", "lua", "plugins.lua"), "return {}\n")
+	writeFile(t, filepath.Join(home, ".tmux.conf"), "set -g mouse on\n")
+
+	clock := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+	m := &Manager{
+		Dir:  filepath.Join(home, ".local", "share", "devgita", DirName),
+		Home: home,
+		Targets: []Target{
+			{App: "neovim", Path: filepath.Join(home, ".config", "nvim")},
+			{App: "tmux", Path: filepath.Join(home, ".tmux.conf")},
+			{App: "alacritty", Path: filepath.Join(home, ".config", "alacritty")}, // absent
+		},
+		DevgitaVersion: "v1.2.3",
+		now: func() time.Time {
+			clock = clock.Add(time.Minute)
+			return clock
+		},
+	}
+	return m, home
+}
+
+func writeFile(t *testing.T, p, content string) {
+	t.Helper()
+	if err := os.MkdirAll(filepath.Dir(p), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(p, []byte(content), 0o644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func readFile(t *testing.T, p string) string {
+	t.Helper()
+	data, err := os.ReadFile(p)
+	if err != nil {
+		t.Fatalf("read %s: %v", p, err)
+	}
+	return string(data)
+}
+
+func TestCreate_WritesManifestWithHashes(t *testing.T) {
+	m, _ := newTestManager(t)
+
+	snap, err := m.Create("")
+	if err != nil {
+		t.Fatalf("Create: %v", err)
+	}
+	if snap.Name != "20260102-030505" {
+		t.Errorf("expected timestamp name, got %q", snap.Name)
+	}
+	if _, err := os.Stat(snap.Path); err != nil {
+		t.Fatalf("expected archive at %s: %v", snap.Path, err)
+	}
+
+	wantRoots := []string{".config/nvim", ".tmux.conf"}
+	if strings.Join(snap.Manifest.Roots, ",") != strings.Join(wantRoots, ",") {
+		t.Errorf("expected roots %v (absent targets skipped), got %v", wantRoots, snap.Manifest.Roots)
+	}
+	if snap.Manifest.DevgitaVersion != "v1.2.3" {
+		t.Errorf("expected devgita version recorded, got %q", snap.Manifest.DevgitaVersion)
+	}
+
+	hashed := 0
+	for _, f := range snap.Manifest.Files {
+		if f.Mode.IsRegular() {
+			if len(f.SHA256) != 64 {
+				t.Errorf("%s: expected a sha256 hash, got %q", f.Path, f.SHA256)
+			}
+			hashed++
+		}
+	}
+	if hashed != 3 {
+		t.Errorf("expected 3 hashed files, got %d", hashed)
+	}
+
+	reopened, err := m.Open(snap.Name)
+	if err != nil {
+		t.Fatalf("Open: %v", err)
+	}
+	if len(reopened.Manifest.Files) != len(snap.Manifest.Files) {
+		t.Errorf("manifest did not round-trip through the archive")
+	}
+}
+
+func TestCreate_RejectsBadOrDuplicateNames(t *testing.T) {
+	m, _ := newTestManager(t)
+
+	for _, name := range []string{"../escape", ".hidden", "has space", "a/b", "list", "prune"} {
+		if _, err := m.Create(name); err == nil {
+			t.Errorf("expected %q to be rejected", name)
+		}
+	}
+	if _, err := m.Create("mine"); err != nil {
+		t.Fatalf("Create: %v", err)
+	}
+	if _, err := m.Create("mine"); err == nil || !strings.Contains(err.Error(), "already exists") {
+		t.Errorf("expected duplicate name to be rejected, got %v", err)
+	}
+}
+
+func TestCreate_NothingToBackUp(t *testing.T) {
+	m := &Manager{
+		Dir:     t.TempDir(),
+		Home:    t.TempDir(),
+		Targets: []Target{{App: "git", Path: "/nonexistent/devgita/git"}},
+		now:     time.Now,
+	}
+	if _, err := m.Create("x"); err == nil {
+		t.Fatal("expected an error when no targets exist")
+	}
+}
+
+func TestRestore_PutsFilesBackAndDropsNewOnes(t *testing.T) {
+	m, home := newTestManager(t)
+	if _, err := m.Create("before"); err != nil {
+		t.Fatalf("Create: %v", err)
+	}
+
+	initLua := filepath.Join(home, ".config", "nvim", "init.lua")
+	writeFile(t, initLua, "-- rewritten\n")
+	writeFile(t, filepath.Join(home, ".config", "nvim", "added.lua"), "new\n")
+	if err := os.Remove(filepath.Join(home, ".tmux.conf")); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := m.Restore("before"); err != nil {
+		t.Fatalf("Restore: %v", err)
+	}
+
+	if got := readFile(t, initLua); got != "vim.o.number = true\n" {
+		t.Errorf("init.lua not restored, got %q", got)
+	}
+	if got := readFile(t, filepath.Join(home, ".tmux.conf")); got != "set -g mouse on\n" {
+		t.Errorf(".tmux.conf not restored, got %q", got)
+	}
+	if _, err := os.Stat(filepath.Join(home, ".config", "nvim", "added.lua")); !os.IsNotExist(err) {
+		t.Error("expected files added after the snapshot to be removed")
+	}
+	if got := readFile(t, filepath.Join(home, ".config", "nvim", "lua", "plugins.lua")); got != "return {}\n" {
+		t.Errorf("nested file not restored, got %q", got)
+	}
+}
+
+func TestRestore_PreservesSymlinks(t *testing.T) {
+	m, home := newTestManager(t)
+	link := filepath.Join(home, ".config", "nvim", "current.lua")
+	if err := os.Symlink("init.lua", link); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := m.Create("links"); err != nil {
+		t.Fatalf("Create: %v", err)
+	}
+	if err := os.Remove(link); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := m.Restore("links"); err != nil {
+		t.Fatalf("Restore: %v", err)
+	}
+	target, err := os.Readlink(link)
+	if err != nil || target != "init.lua" {
+		t.Errorf("expected symlink to init.lua, got %q (%v)", target, err)
+	}
+}
+
+func TestRestore_UnknownName(t *testing.T) {
+	m, _ := newTestManager(t)
+	if _, err := m.Restore("missing"); !errors.Is(err, ErrNotFound) {
+		t.Errorf("expected ErrNotFound, got %v", err)
+	}
+}
+
+func TestRestore_CorruptArchiveChangesNothing(t *testing.T) {
+	m, home := newTestManager(t)
+	snap, err := m.Create("orig")
+	if err != nil {
+		t.Fatalf("Create: %v", err)
+	}
+	tamperArchive(t, snap.Path, ".tmux.conf", "set -g mouse off\n")
+
+	writeFile(t, filepath.Join(home, ".tmux.conf"), "current\n")
+	_, err = m.Restore("orig")
+	if err == nil || !strings.Contains(err.Error(), "checksum mismatch") {
+		t.Fatalf("expected checksum mismatch, got %v", err)
+	}
+	if got := readFile(t, filepath.Join(home, ".tmux.conf")); got != "current\n" {
+		t.Errorf("expected on-disk file untouched after failed verification, got %q", got)
+	}
+}
+
+// tamperArchive rewrites the archive at p, replacing the content of one file
+// entry while leaving the manifest (and its hash) as it was.
+func tamperArchive(t *testing.T, p, rel, content string) {
+	t.Helper()
+	in, err := os.Open(p)
+	if err != nil {
+		t.Fatal(err)
+	}
+	gz, err := gzip.NewReader(in)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tr := tar.NewReader(gz)
+
+	outPath := p + ".new"
+	out, err := os.Create(outPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	gw := gzip.NewWriter(out)
+	tw := tar.NewWriter(gw)
+	for {
+		hdr, err := tr.Next()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			t.Fatal(err)
+		}
+		body, err := io.ReadAll(tr)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if hdr.Name == filesPrefix+rel {
+			body = []byte(content)
+			hdr.Size = int64(len(body))
+		}
+		if err := tw.WriteHeader(hdr); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := tw.Write(body); err != nil {
+			t.Fatal(err)
+		}
+	}
+	_ = tw.Close()
+	_ = gw.Close()
+	_ = out.Close()
+	_ = in.Close()
+	if err := os.Rename(outPath, p); err != nil {
+		t.Fatal(err)
+	}
+}
+
+// rawEntry is one hand-built files/ entry for writeRawArchive.
+type rawEntry struct {
+	name, link, body string
+	typeflag         byte
+}
+
+// writeRawArchive writes a snapshot named name whose manifest and entries
+// are exactly as given, bypassing Create, to model hand-edited or hostile
+// archives.
+func writeRawArchive(t *testing.T, m *Manager, name string, manifest Manifest, entries []rawEntry) {
+	t.Helper()
+	if err := os.MkdirAll(m.Dir, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	out, err := os.Create(m.archivePath(name))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer func() { _ = out.Close() }()
+	gw := gzip.NewWriter(out)
+	tw := tar.NewWriter(gw)
+	manifest.Version, manifest.Name = manifestVersion, name
+	data, err := json.Marshal(manifest)
+	if err != nil {
+		t.Fatal(err)
+	}
+	entries = append([]rawEntry{{name: manifestName, body: string(data), typeflag: tar.TypeReg}}, entries...)
+	for _, e := range entries {
+		hdr := &tar.Header{Name: e.name, Typeflag: e.typeflag, Linkname: e.link, Mode: 0o755, Size: int64(len(e.body))}
+		if e.typeflag != tar.TypeReg {
+			hdr.Size = 0
+		}
+		if err := tw.WriteHeader(hdr); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := tw.Write([]byte(e.body)); err != nil && e.typeflag == tar.TypeReg {
+			t.Fatal(err)
+		}
+	}
+	if err := tw.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if err := gw.Close(); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestRestore_RejectsUnsafeOrUnmanagedRoots(t *testing.T) {
+	for _, root := range []string{"", ".", "../outside", "/etc", ".ssh"} {
+		m, home := newTestManager(t)
+		writeRawArchive(t, m, "evil", Manifest{Roots: []string{root}}, nil)
+
+		if _, err := m.Restore("evil"); err == nil || !strings.Contains(err.Error(), "invalid manifest") {
+			t.Errorf("root %q: expected an invalid-manifest error, got %v", root, err)
+		}
+		if got := readFile(t, filepath.Join(home, ".tmux.conf")); got != "set -g mouse on\n" {
+			t.Errorf("root %q: expected home untouched, got %q", root, got)
+		}
+	}
+}
+
+func TestRestore_RefusesSymlinkEscape(t *testing.T) {
+	m, home := newTestManager(t)
+	outside := t.TempDir()
+	writeRawArchive(t, m, "evil", Manifest{Roots: []string{".config/nvim"}}, []rawEntry{
+		{name: filesPrefix + ".config/nvim", link: outside, typeflag: tar.TypeSymlink},
+		{name: filesPrefix + ".config/nvim/pwned", body: "gotcha\n", typeflag: tar.TypeReg},
+	})
+
+	if _, err := m.Restore("evil"); err == nil {
+		t.Fatal("expected extraction through a symlink to fail")
+	}
+	if _, err := os.Lstat(filepath.Join(outside, "pwned")); !errors.Is(err, os.ErrNotExist) {
+		t.Errorf("expected nothing written outside staging, got %v", err)
+	}
+	if got := readFile(t, filepath.Join(home, ".config", "nvim", "init.lua")); got != "vim.o.number = true\n" {
+		t.Errorf("expected nvim config untouched, got %q", got)
+	}
+}
+
+func TestRestore_FailedRootLeavesEarlierRootsUntouched(t *testing.T) {
+	m, home := newTestManager(t)
+	// .tmux.conf is a root but has no staged copy, so preparing it fails
+	// after .config/nvim's replacement has already been staged.
+	writeRawArchive(t, m, "partial", Manifest{Roots: []string{".config/nvim", ".tmux.conf"}}, []rawEntry{
+		{name: filesPrefix + ".config/nvim/", typeflag: tar.TypeDir},
+		{name: filesPrefix + ".config/nvim/init.lua", body: "restored\n", typeflag: tar.TypeReg},
+	})
+
+	if _, err := m.Restore("partial"); err == nil {
+		t.Fatal("expected restore to fail")
+	}
+	if got := readFile(t, filepath.Join(home, ".config", "nvim", "init.lua")); got != "vim.o.number = true\n" {
+		t.Errorf("expected the earlier root untouched, got %q", got)
+	}
+	if _, err := os.Stat(filepath.Join(home, ".config", "nvim", "lua", "plugins.lua")); err != nil {
+		t.Errorf("expected the earlier root's other files kept: %v", err)
+	}
+	leftovers, _ := filepath.Glob(filepath.Join(home, ".config", ".devgita-restore-*"))
+	if len(leftovers) != 0 {
+		t.Errorf("expected staged copies cleaned up, found %v", leftovers)
+	}
+}
+
+func TestListAndPrune(t *testing.T) {
+	m, _ := newTestManager(t)
+	for _, name := range []string{"one", "two", "three"} {
+		if _, err := m.Create(name); err != nil {
+			t.Fatalf("Create %s: %v", name, err)
+		}
+	}
+
+	list, err := m.List()
+	if err != nil {
+		t.Fatalf("List: %v", err)
+	}
+	var names []string
+	for _, s := range list {
+		names = append(names, s.Name)
+	}
+	if strings.Join(names, ",") != "three,two,one" {
+		t.Errorf("expected newest first, got %v", names)
+	}
+
+	removed, err := m.Prune(1)
+	if err != nil {
+		t.Fatalf("Prune: %v", err)
+	}
+	if len(removed) != 2 || removed[0].Name != "two" || removed[1].Name != "one" {
+		t.Errorf("expected two and one pruned, got %v", removed)
+	}
+	list, _ = m.List()
+	if len(list) != 1 || list[0].Name != "three" {
+		t.Errorf("expected only the newest snapshot left, got %v", list)
+	}
+
+	if _, err := m.Prune(-1); err == nil {
+		t.Error("expected negative keep to be rejected")
+	}
+}
+
+func TestList_NoBackupDir(t *testing.T) {
+	m := &Manager{Dir: filepath.Join(t.TempDir(), "missing")}
+	list, err := m.List()
+	if err != nil || len(list) != 0 {
+		t.Errorf("expected empty list and no error, got %v, %v", list, err)
+	}
+}
+
+func TestManagedTargets_OnlyDevgitaPartsOfAICoders(t *testing.T) {
+	for _, target := range ManagedTargets() {
+		if target.Path == paths.Paths.Config.Claude || target.Path == paths.Paths.Config.OpenCode {
+			t.Errorf("%s: whole AI coder directory must not be a target", target.Path)
+		}
+	}
+}
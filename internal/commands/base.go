@@ -84,12 +84,22 @@ type CommandParams struct {
 	// can run a tool that has no directory flag (e.g. gh) against a specific
 	// repo or worktree. Empty preserves the process's current directory.
 	Dir string
+	// ReadOnly marks a command that only inspects state (a version or OS
+	// probe). A RecordingBaseCommand still runs it under --dry-run, so the
+	// plan reflects what is actually present; everything else is recorded
+	// instead of executed. Any command whose output or exit status decides
+	// what happens next must set it.
+	ReadOnly bool
 }
 
-func NewBaseCommand() *BaseCommand {
-	return &BaseCommand{
-		Platform: NewPlatform(),
+// NewBaseCommand returns the executor for the current platform, or a
+// RecordingBaseCommand wrapping it while a dry-run plan is set (see RecordTo).
+func NewBaseCommand() BaseCommandExecutor {
+	base := &BaseCommand{Platform: NewPlatform()}
+	if plan := currentRecordPlan(); plan != nil {
+		return NewRecordingBaseCommand(base, plan)
 	}
+	return base
 }
 
 func NewBaseCommandCustom(p CustomizablePlatform) *BaseCommand {
@@ -344,6 +354,8 @@ func (b *BaseCommand) MaybeInstall(
 ) error {
 	var isInstalled bool
 	var err error
+	// pkgToInstall is the name the item is tracked under; version and
+	// install-method probes ask the package manager about itemName.
 	pkgToInstall := itemName
 	if len(alias) > 0 {
 		pkgToInstall = alias[0]
@@ -381,6 +393,7 @@ func (b *BaseCommand) MaybeInstall(
 		logger.L().
 			Debugw("Item is already installed, marking as such in global config", "item", pkgToInstall, "type", itemType)
 		globalConfig.AddToAlreadyInstalled(pkgToInstall, itemType)
+		globalConfig.SetTrackedVersion(pkgToInstall, itemType, installedVersion(b.IsMac(), itemName, itemType))
 		globalConfig.Save()
 		return nil
 	}
@@ -393,7 +406,12 @@ func (b *BaseCommand) MaybeInstall(
 	}
 
 	if installErr == nil {
-		globalConfig.AddToInstalled(pkgToInstall, itemType)
+		globalConfig.RecordInstall(
+			pkgToInstall,
+			itemType,
+			b.installMethod(itemName, itemType, installURLFunc != nil),
+			installedVersion(b.IsMac(), itemName, itemType),
+		)
 		if err := globalConfig.Save(); err != nil {
 			logger.L().Errorw("Failed to update global config after installation", "error", err)
 		}
@@ -405,11 +423,100 @@ func (b *BaseCommand) MaybeInstall(
 	return installErr
 }
 
+// trackedName is the name an item is recorded under in global_config.yaml:
+// alias[0] when given, as MaybeInstall records it, else pkg itself.
+func trackedName(pkg string, alias []string) string {
+	if len(alias) > 0 {
+		return alias[0]
+	}
+	return pkg
+}
+
+// recordUpgrade refreshes tracked's install record after an in-place upgrade
+// of pkg (the package manager's name for it, which the version is probed
+// with). Items devgita installed get a new record (method, version,
+// timestamp); pre-existing items only get their version updated, and
+// untracked ones are left alone. As with MaybeInstall, a failure to record
+// never fails the upgrade itself.
+func recordUpgrade(base BaseCommandExecutor, pkg, tracked, itemType string, method config.InstallMethod) {
+	gc := &config.GlobalConfig{}
+	if err := gc.Load(); err != nil {
+		logger.L().Warnw("Could not load global config to record upgrade", "item", tracked, "error", err)
+		return
+	}
+	version := installedVersion(base.IsMac(), pkg, itemType)
+	switch {
+	case gc.IsInstalledByDevgita(tracked, itemType):
+		gc.RecordInstall(tracked, itemType, method, version)
+	case version == "" || !gc.SetTrackedVersion(tracked, itemType, version):
+		return
+	}
+	if err := gc.Save(); err != nil {
+		logger.L().Errorw("Failed to update global config after upgrade", "item", tracked, "error", err)
+	}
+}
+
+// installMethod names how MaybeInstall's install funcs put pkg on this
+// platform, for the install record in global_config.yaml.
+func (b *BaseCommand) installMethod(pkg, itemType string, fromURL bool) config.InstallMethod {
+	switch {
+	case b.IsMac():
+		return config.MethodBrew
+	case fromURL:
+		return config.MethodDownload
+	case itemType == "package":
+		return (&DebianCommand{}).getInstallationStrategy(pkg).Method()
+	default:
+		return config.MethodApt
+	}
+}
+
+// installedVersion asks the platform package manager which version of pkg is
+// installed. It returns "" whenever it cannot tell (fonts, script installs,
+// a failed probe): the version is informational and never fails an install.
+func installedVersion(isMac bool, pkg, itemType string) string {
+	var name string
+	var args []string
+	switch {
+	case isMac && (itemType == "desktop_app" || itemType == "font"):
+		name, args = "brew", []string{"list", "--cask", "--versions", pkg}
+	case isMac:
+		name, args = "brew", []string{"list", "--versions", pkg}
+	case itemType == "font":
+		return ""
+	default:
+		name, args = "dpkg-query", []string{"-W", "-f=${Version}", constants.GetDebianPackageName(pkg)}
+	}
+	if _, err := LookPathFn(name); err != nil {
+		return ""
+	}
+	out, err := CommandFn(name, args...).Output()
+	if err != nil {
+		return ""
+	}
+	fields := strings.Fields(string(out))
+	// brew prints "<name> <version> [<older versions>...]"; dpkg-query just the version.
+	if name == "brew" {
+		fields = fields[min(1, len(fields)):]
+	}
+	if len(fields) == 0 {
+		return ""
+	}
+	return fields[0]
+}
+
 func (b *BaseCommand) InstallFontFromURL(url, fontFileName string, runCache bool) error {
+	return installFontFromURL(b, url, fontFileName, runCache)
+}
+
+// installFontFromURL downloads a font with curl, moves it into the user's
+// font directory and optionally refreshes the font cache, running each step
+// through base so a RecordingBaseCommand records them instead.
+func installFontFromURL(base BaseCommandExecutor, url, fontFileName string, runCache bool) error {
 	tmpPath := fmt.Sprintf("/tmp/%s.ttf", fontFileName)
 
 	// 1. Download font
-	if _, _, err := b.ExecCommand(CommandParams{
+	if _, _, err := base.ExecCommand(CommandParams{
 		PreExecMsg: fmt.Sprintf("Downloading %s...", fontFileName),
 		Command:    "curl",
 		Args:       []string{"-o", tmpPath, url},
@@ -418,7 +525,7 @@ func (b *BaseCommand) InstallFontFromURL(url, fontFileName string, runCache bool
 	}
 
 	// 2. Move font
-	if _, _, err := b.ExecCommand(CommandParams{
+	if _, _, err := base.ExecCommand(CommandParams{
 		PreExecMsg: "Installing font...",
 		Command:    "mv",
 		Args:       []string{tmpPath, filepath.Join(paths.Paths.User.Fonts, fontFileName+".ttf")},
@@ -428,7 +535,7 @@ func (b *BaseCommand) InstallFontFromURL(url, fontFileName string, runCache bool
 
 	// 3. Update font cache if needed
 	if runCache {
-		if _, _, err := b.ExecCommand(CommandParams{
+		if _, _, err := base.ExecCommand(CommandParams{
 			PreExecMsg: "Refreshing font cache...",
 			Command:    "fc-cache",
 			Args:       []string{"-fv"},
@@ -12,6 +12,7 @@ import (
 	"github.com/cjairm/devgita/internal/config"
 	"github.com/cjairm/devgita/internal/testutil"
 	"github.com/cjairm/devgita/pkg/constants"
+	"github.com/cjairm/devgita/pkg/dryrun"
 	"github.com/cjairm/devgita/pkg/logger"
 	"github.com/cjairm/devgita/pkg/paths"
 	"gopkg.in/yaml.v3"
@@ -348,10 +349,10 @@ func TestMaybeInstall_ItemNotInstalled_InstallsSuccessfully(t *testing.T) {
 	// Create test config
 	testConfig := &config.GlobalConfig{
 		Installed: config.InstalledConfig{
-			Packages: []string{},
+			Packages: config.NewTrackedItems(),
 		},
 		AlreadyInstalled: config.AlreadyInstalledConfig{
-			Packages: []string{},
+			Packages: config.NewTrackedItems(),
 		},
 	}
 
@@ -385,19 +386,59 @@ func TestMaybeInstall_ItemNotInstalled_InstallsSuccessfully(t *testing.T) {
 	var updatedConfig config.GlobalConfig
 	updatedConfig.Load()
 	if len(updatedConfig.Installed.Packages) != 1 ||
-		updatedConfig.Installed.Packages[0] != "test-package" {
+		updatedConfig.Installed.Packages[0].Name != "test-package" {
 		t.Errorf("Expected 'test-package' to be added to installed config")
 	}
+	if got := updatedConfig.Installed.Packages[0]; got.Method != config.MethodBrew || got.InstalledAt.IsZero() {
+		t.Errorf("Expected the install to be recorded with method brew and a timestamp, got %+v", got)
+	}
+}
+
+func TestMaybeInstall_AliasProbesPackageNameAndRecordsAlias(t *testing.T) {
+	cleanup := setupMaybeInstallTest(t, &config.GlobalConfig{})
+	defer cleanup()
+
+	origLookPath, origCommand := commands.LookPathFn, commands.CommandFn
+	t.Cleanup(func() { commands.LookPathFn, commands.CommandFn = origLookPath, origCommand })
+	var probed []string
+	commands.LookPathFn = func(name string) (string, error) { return name, nil }
+	commands.CommandFn = func(name string, args ...string) *exec.Cmd {
+		probed = append(probed, args[len(args)-1])
+		return exec.Command("echo", "brave-browser 1.70.1")
+	}
+
+	b := commands.NewBaseCommandCustom(FakePlatform{Mac: true})
+	mockInstaller := &MockInstaller{}
+	if err := b.MaybeInstall(
+		"brave-browser",
+		[]string{"brave"},
+		mockInstaller.Check,
+		mockInstaller.Install,
+		nil,
+		"desktop_app",
+	); err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+
+	if len(probed) != 1 || probed[0] != "brave-browser" {
+		t.Errorf("Expected the version probed by the package name brave-browser, got %v", probed)
+	}
+	var updatedConfig config.GlobalConfig
+	updatedConfig.Load()
+	brave, ok := updatedConfig.Installed.DesktopApps.Find("brave")
+	if !ok || brave.Version != "1.70.1" {
+		t.Errorf("Expected the install recorded under brave with version 1.70.1, got %+v", brave)
+	}
 }
 
 func TestMaybeInstall_ItemAlreadyInstalledByDevgita_SkipsInstall(t *testing.T) {
 	// Create test config with item already installed by devgita
 	testConfig := &config.GlobalConfig{
 		Installed: config.InstalledConfig{
-			Packages: []string{"test-package"},
+			Packages: config.NewTrackedItems("test-package"),
 		},
 		AlreadyInstalled: config.AlreadyInstalledConfig{
-			Packages: []string{},
+			Packages: config.NewTrackedItems(),
 		},
 	}
 
@@ -428,10 +469,10 @@ func TestMaybeInstall_ItemPreExisting_TracksAsAlreadyInstalled(t *testing.T) {
 	// Create test config with empty lists
 	testConfig := &config.GlobalConfig{
 		Installed: config.InstalledConfig{
-			Packages: []string{},
+			Packages: config.NewTrackedItems(),
 		},
 		AlreadyInstalled: config.AlreadyInstalledConfig{
-			Packages: []string{},
+			Packages: config.NewTrackedItems(),
 		},
 	}
 
@@ -474,7 +515,7 @@ func TestMaybeInstall_ItemPreExisting_TracksAsAlreadyInstalled(t *testing.T) {
 	var updatedConfig config.GlobalConfig
 	updatedConfig.Load()
 	if len(updatedConfig.AlreadyInstalled.Packages) != 1 ||
-		updatedConfig.AlreadyInstalled.Packages[0] != "pre-existing-package" {
+		updatedConfig.AlreadyInstalled.Packages[0].Name != "pre-existing-package" {
 		t.Errorf("Expected 'pre-existing-package' to be added to already installed config")
 	}
 }
@@ -658,22 +699,22 @@ func TestMaybeInstall_DifferentItemTypes(t *testing.T) {
 			switch tc.itemType {
 			case "font":
 				found = len(updatedConfig.Installed.Fonts) == 1 &&
-					updatedConfig.Installed.Fonts[0] == tc.itemName
+					updatedConfig.Installed.Fonts[0].Name == tc.itemName
 			case "desktop_app":
 				found = len(updatedConfig.Installed.DesktopApps) == 1 &&
-					updatedConfig.Installed.DesktopApps[0] == tc.itemName
+					updatedConfig.Installed.DesktopApps[0].Name == tc.itemName
 			case "terminal_tool":
 				found = len(updatedConfig.Installed.TerminalTools) == 1 &&
-					updatedConfig.Installed.TerminalTools[0] == tc.itemName
+					updatedConfig.Installed.TerminalTools[0].Name == tc.itemName
 			case "theme":
 				found = len(updatedConfig.Installed.Themes) == 1 &&
-					updatedConfig.Installed.Themes[0] == tc.itemName
+					updatedConfig.Installed.Themes[0].Name == tc.itemName
 			case "dev_language":
 				found = len(updatedConfig.Installed.DevLanguages) == 1 &&
-					updatedConfig.Installed.DevLanguages[0] == tc.itemName
+					updatedConfig.Installed.DevLanguages[0].Name == tc.itemName
 			case "database":
 				found = len(updatedConfig.Installed.Databases) == 1 &&
-					updatedConfig.Installed.Databases[0] == tc.itemName
+					updatedConfig.Installed.Databases[0].Name == tc.itemName
 			}
 
 			if !found {
@@ -686,3 +727,72 @@ func TestMaybeInstall_DifferentItemTypes(t *testing.T) {
 		})
 	}
 }
+
+func TestUpgrade_RecordsCommandAndRefreshesInstallRecord(t *testing.T) {
+	testConfig := &config.GlobalConfig{}
+	testConfig.Installed.Packages = config.TrackedItems{
+		{Name: "git", Version: "2.40.0", Method: config.MethodApt},
+	}
+	testConfig.Installed.DesktopApps = config.NewTrackedItems("alacritty", "brave")
+	cleanup := setupMaybeInstallTest(t, testConfig)
+	defer cleanup()
+
+	origLookPath := commands.LookPathFn
+	commands.LookPathFn = func(string) (string, error) { return "", fmt.Errorf("not found") }
+	t.Cleanup(func() { commands.LookPathFn = origLookPath })
+
+	plan := dryrun.NewPlan()
+	debian := &commands.DebianCommand{
+		BaseCommandExecutor: commands.NewRecordingBaseCommand(
+			commands.NewBaseCommandCustom(&FakePlatform{Linux: true}), plan,
+		),
+	}
+	if err := debian.UpgradePackage("git"); err != nil {
+		t.Fatalf("UpgradePackage: %v", err)
+	}
+	mac := &commands.MacOSCommand{
+		BaseCommandExecutor: commands.NewRecordingBaseCommand(
+			commands.NewBaseCommandCustom(&FakePlatform{Mac: true}), plan,
+		),
+	}
+	if err := mac.UpgradeDesktopApp("alacritty"); err != nil {
+		t.Fatalf("UpgradeDesktopApp: %v", err)
+	}
+	if err := mac.UpgradeDesktopApp("brave-browser", "brave"); err != nil {
+		t.Fatalf("UpgradeDesktopApp: %v", err)
+	}
+
+	var targets []string
+	for _, step := range plan.Steps() {
+		if step.Kind == dryrun.KindExec {
+			targets = append(targets, step.Target)
+		}
+	}
+	want := []string{
+		"sudo apt install --only-upgrade -y git",
+		"brew upgrade --cask alacritty",
+		"brew upgrade --cask brave-browser",
+	}
+	if strings.Join(targets, "\n") != strings.Join(want, "\n") {
+		t.Errorf("Expected commands %q, got %q", want, targets)
+	}
+
+	gc := &config.GlobalConfig{}
+	if err := gc.Load(); err != nil {
+		t.Fatalf("Load: %v", err)
+	}
+	git, _ := gc.Installed.Packages.Find("git")
+	if git.Method != config.MethodApt || git.Version != "2.40.0" || git.InstalledAt.IsZero() {
+		t.Errorf("Expected a fresh apt record keeping the unprobed version, got %+v", git)
+	}
+	alacritty, _ := gc.Installed.DesktopApps.Find("alacritty")
+	if alacritty.Method != config.MethodBrew {
+		t.Errorf("Expected alacritty recorded as a brew install, got %+v", alacritty)
+	}
+	if brave, _ := gc.Installed.DesktopApps.Find("brave"); brave.Method != config.MethodBrew {
+		t.Errorf("Expected the upgrade recorded under the tracked name brave, got %+v", brave)
+	}
+	if _, ok := gc.Installed.DesktopApps.Find("brave-browser"); ok {
+		t.Error("Expected no record under the cask name brave-browser")
+	}
+}
@@ -8,6 +8,7 @@ import (
 	"strconv"
 	"strings"
 
+	"github.com/cjairm/devgita/internal/config"
 	"github.com/cjairm/devgita/pkg/apt"
 	"github.com/cjairm/devgita/pkg/constants"
 	"github.com/cjairm/devgita/pkg/logger"
@@ -16,7 +17,7 @@ import (
 )
 
 type DebianCommand struct {
-	BaseCommand
+	BaseCommandExecutor
 }
 
 func (d *DebianCommand) MaybeInstallPackage(packageName string, alias ...string) error {
@@ -248,6 +249,75 @@ func (d *DebianCommand) installWithApt(packageName string) error {
 	return nil
 }
 
+// UpgradePackage upgrades packageName with the strategy that installed it and
+// refreshes its install record, which is kept under alias[0] when given (see
+// MaybeInstall).
+func (d *DebianCommand) UpgradePackage(packageName string, alias ...string) error {
+	strategy := d.getInstallationStrategy(packageName)
+	if err := strategy.Upgrade(packageName); err != nil {
+		return err
+	}
+	recordUpgrade(d, packageName, trackedName(packageName, alias), "package", strategy.Method())
+	return nil
+}
+
+// UpgradeDesktopApp mirrors InstallDesktopApp: apt first, then snap for apps
+// that only ship there. The record is kept under alias[0] when given.
+func (d *DebianCommand) UpgradeDesktopApp(desktopAppName string, alias ...string) error {
+	if err := d.upgradeWithApt(desktopAppName); err != nil {
+		if snapErr := d.refreshWithSnap(desktopAppName); snapErr != nil {
+			return err
+		}
+	}
+	recordUpgrade(d, desktopAppName, trackedName(desktopAppName, alias), "desktop_app", config.MethodApt)
+	return nil
+}
+
+// RefreshPackageIndex runs apt update so upgrades see the newest versions.
+func (d *DebianCommand) RefreshPackageIndex() error {
+	if _, stderr, err := d.ExecCommand(CommandParams{
+		PreExecMsg: "Refreshing apt package index...",
+		Command:    "apt",
+		Args:       []string{"update"},
+		IsSudo:     true,
+	}); err != nil {
+		return fmt.Errorf("apt update failed: %w\nOutput: %s", err, stderr)
+	}
+	return nil
+}
+
+// upgradeWithApt upgrades an installed package without installing it when
+// missing (--only-upgrade), so a stale record never pulls in a new package.
+func (d *DebianCommand) upgradeWithApt(packageName string) error {
+	logger.L().Debug(fmt.Sprintf("executing: apt install --only-upgrade -y %s", packageName))
+	cmd := CommandParams{
+		PreExecMsg: fmt.Sprintf("Upgrading %s...", strings.ToLower(packageName)),
+		IsSudo:     true,
+		Command:    "apt",
+		Args:       []string{"install", "--only-upgrade", "-y", packageName},
+	}
+	if _, _, err := d.ExecCommand(cmd); err != nil {
+		return fmt.Errorf("failed to upgrade %s: %w", packageName, err)
+	}
+	return nil
+}
+
+func (d *DebianCommand) refreshWithSnap(packageName string) error {
+	if _, err := exec.LookPath("snap"); err != nil {
+		return fmt.Errorf("snap not installed: %w", err)
+	}
+	cmd := CommandParams{
+		PreExecMsg: fmt.Sprintf("Upgrading %s via Snap...", strings.ToLower(packageName)),
+		IsSudo:     true,
+		Command:    "snap",
+		Args:       []string{"refresh", packageName},
+	}
+	if _, _, err := d.ExecCommand(cmd); err != nil {
+		return fmt.Errorf("failed to upgrade %s via Snap: %w", packageName, err)
+	}
+	return nil
+}
+
 func (d *DebianCommand) installWithSnap(packageName string) error {
 	// Check if snap is available before attempting installation
 	if _, err := exec.LookPath("snap"); err != nil {
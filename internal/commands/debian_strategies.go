@@ -12,9 +12,12 @@ import (
 	"path/filepath"
 	"strings"
 
+	"github.com/cjairm/devgita/internal/config"
 	"github.com/cjairm/devgita/pkg/apt"
 	"github.com/cjairm/devgita/pkg/constants"
 	"github.com/cjairm/devgita/pkg/downloader"
+	"github.com/cjairm/devgita/pkg/dryrun"
+	"github.com/cjairm/devgita/pkg/files"
 	"github.com/cjairm/devgita/pkg/logger"
 )
 
@@ -36,10 +39,6 @@ func InstallGitHubBinary(
 	checksumsURL string,
 	downloadFn func(ctx context.Context, url, dest string, cfg downloader.RetryConfig) error,
 ) error {
-	if downloadFn == nil {
-		downloadFn = downloader.DownloadFileWithRetry
-	}
-
 	tarPath := filepath.Join("/tmp", binaryName+".tar.gz")
 	checksumsPath := filepath.Join("/tmp", binaryName+"-checksums.txt")
 	extractDir := filepath.Join("/tmp", binaryName+"-extract")
@@ -47,6 +46,46 @@ func InstallGitHubBinary(
 	defer os.Remove(checksumsPath)
 	defer os.RemoveAll(extractDir)
 
+	// Verification reads the downloaded files, so it can't be split into
+	// commands a recording executor could stand in for: a dry run plans the
+	// verified download as one step and records the commands below as usual.
+	if rec, ok := recorderFor(base); ok {
+		rec.Plan.Record(dryrun.KindDownload, archiveURL, "verified against "+checksumsURL)
+	} else if err := downloadVerified(
+		binaryName, archiveURL, checksumsURL, tarPath, checksumsPath, extractDir, downloadFn,
+	); err != nil {
+		return err
+	}
+
+	if _, stderr, err := base.ExecCommand(CommandParams{
+		Command: "tar",
+		Args:    []string{"-xf", tarPath, "-C", extractDir, binaryName},
+	}); err != nil {
+		return fmt.Errorf("failed to extract %s: %w\nOutput: %s", binaryName, err, stderr)
+	}
+
+	binaryPath := filepath.Join(extractDir, binaryName)
+	if _, stderr, err := base.ExecCommand(CommandParams{
+		Command: "install",
+		Args:    []string{"-m", "755", binaryPath, "/usr/local/bin/" + binaryName},
+		IsSudo:  true,
+	}); err != nil {
+		return fmt.Errorf("failed to install %s binary: %w\nOutput: %s", binaryName, err, stderr)
+	}
+
+	return nil
+}
+
+// downloadVerified downloads archiveURL to tarPath and checks it against the
+// release's checksums file, then creates extractDir for the archive.
+func downloadVerified(
+	binaryName, archiveURL, checksumsURL, tarPath, checksumsPath, extractDir string,
+	downloadFn func(ctx context.Context, url, dest string, cfg downloader.RetryConfig) error,
+) error {
+	if downloadFn == nil {
+		downloadFn = downloader.DownloadFileWithRetry
+	}
+
 	ctx := context.Background()
 	if err := downloadFn(ctx, archiveURL, tarPath, downloader.DefaultRetryConfig()); err != nil {
 		return fmt.Errorf("failed to download %s: %w", binaryName, err)
@@ -75,23 +114,6 @@ func InstallGitHubBinary(
 	if err := os.MkdirAll(extractDir, 0o755); err != nil {
 		return fmt.Errorf("failed to create extract directory: %w", err)
 	}
-
-	if _, stderr, err := base.ExecCommand(CommandParams{
-		Command: "tar",
-		Args:    []string{"-xf", tarPath, "-C", extractDir, binaryName},
-	}); err != nil {
-		return fmt.Errorf("failed to extract %s: %w\nOutput: %s", binaryName, err, stderr)
-	}
-
-	binaryPath := filepath.Join(extractDir, binaryName)
-	if _, stderr, err := base.ExecCommand(CommandParams{
-		Command: "install",
-		Args:    []string{"-m", "755", binaryPath, "/usr/local/bin/" + binaryName},
-		IsSudo:  true,
-	}); err != nil {
-		return fmt.Errorf("failed to install %s binary: %w\nOutput: %s", binaryName, err, stderr)
-	}
-
 	return nil
 }
 
@@ -148,6 +170,13 @@ type InstallationStrategy interface {
 
 	// IsInstalled checks if the package is already installed
 	IsInstalled(packageName string) (bool, error)
+
+	// Upgrade brings an installed package up to the newest version the
+	// strategy's source offers
+	Upgrade(packageName string) error
+
+	// Method names the strategy in global_config.yaml's install records
+	Method() config.InstallMethod
 }
 
 // AptStrategy implements installation via apt package manager with package name translation
@@ -155,6 +184,8 @@ type AptStrategy struct {
 	cmd *DebianCommand
 }
 
+func (s *AptStrategy) Method() config.InstallMethod { return config.MethodApt }
+
 // Install installs a package using apt after translating the package name
 func (s *AptStrategy) Install(packageName string) error {
 	// Translate package name using mapping (e.g., gdbm -> libgdbm-dev)
@@ -169,6 +200,11 @@ func (s *AptStrategy) Install(packageName string) error {
 	return s.cmd.installWithApt(debianName)
 }
 
+// Upgrade upgrades the translated package in place
+func (s *AptStrategy) Upgrade(packageName string) error {
+	return s.cmd.upgradeWithApt(constants.GetDebianPackageName(packageName))
+}
+
 // IsInstalled checks if a package is installed using dpkg
 func (s *AptStrategy) IsInstalled(packageName string) (bool, error) {
 	debianName := constants.GetDebianPackageName(packageName)
@@ -181,6 +217,8 @@ type PPAStrategy struct {
 	ppaConfig apt.PPAConfig
 }
 
+func (s *PPAStrategy) Method() config.InstallMethod { return config.MethodPPA }
+
 // Install adds the PPA and then installs the package
 func (s *PPAStrategy) Install(packageName string) error {
 	logger.L().Infow(
@@ -189,9 +227,18 @@ func (s *PPAStrategy) Install(packageName string) error {
 		"ppa", s.ppaConfig.Name,
 	)
 
-	// Add PPA repository
+	// Add PPA repository. Adding one pipes a downloaded key through gpg
+	// rather than running single commands, so a dry run plans its steps.
 	ppaManager := apt.NewPPAManager()
-	if err := ppaManager.AddPPA(s.ppaConfig); err != nil {
+	if rec, ok := recorderFor(s.cmd.BaseCommandExecutor); ok {
+		steps, err := ppaManager.PlanPPA(s.ppaConfig)
+		if err != nil {
+			return fmt.Errorf("failed to add PPA: %w", err)
+		}
+		for _, step := range steps {
+			rec.Plan.Record(step.Kind, step.Target, step.Detail)
+		}
+	} else if err := ppaManager.AddPPA(s.ppaConfig); err != nil {
 		return fmt.Errorf("failed to add PPA: %w", err)
 	}
 
@@ -199,6 +246,11 @@ func (s *PPAStrategy) Install(packageName string) error {
 	return s.cmd.installWithApt(packageName)
 }
 
+// Upgrade upgrades the package from the PPA added at install time
+func (s *PPAStrategy) Upgrade(packageName string) error {
+	return s.cmd.upgradeWithApt(packageName)
+}
+
 // IsInstalled checks if a package is installed
 func (s *PPAStrategy) IsInstalled(packageName string) (bool, error) {
 	return s.cmd.IsPackageInstalled(packageName)
@@ -212,6 +264,8 @@ type LaunchpadPPAStrategy struct {
 	ppaRef string // e.g., "ppa:zhangsongcui3371/fastfetch"
 }
 
+func (s *LaunchpadPPAStrategy) Method() config.InstallMethod { return config.MethodPPA }
+
 // Install adds the Launchpad PPA and installs the package
 func (s *LaunchpadPPAStrategy) Install(packageName string) error {
 	logger.L().Infow(
@@ -252,6 +306,11 @@ func (s *LaunchpadPPAStrategy) Install(packageName string) error {
 	return s.cmd.installWithApt(packageName)
 }
 
+// Upgrade upgrades the package from the PPA added at install time
+func (s *LaunchpadPPAStrategy) Upgrade(packageName string) error {
+	return s.cmd.upgradeWithApt(packageName)
+}
+
 // IsInstalled checks if the package is installed
 func (s *LaunchpadPPAStrategy) IsInstalled(packageName string) (bool, error) {
 	return s.cmd.IsPackageInstalled(packageName)
@@ -263,6 +322,8 @@ type InstallScriptStrategy struct {
 	scriptURL string
 }
 
+func (s *InstallScriptStrategy) Method() config.InstallMethod { return config.MethodScript }
+
 // Install downloads and executes an install script via curl | sh
 func (s *InstallScriptStrategy) Install(packageName string) error {
 	logger.L().Infow(
@@ -271,20 +332,28 @@ func (s *InstallScriptStrategy) Install(packageName string) error {
 		"script_url", s.scriptURL,
 	)
 
-	curlCmd := exec.Command("sh", "-c", fmt.Sprintf("curl -fsSL %s | sh", s.scriptURL))
-	output, err := curlCmd.CombinedOutput()
-	if err != nil {
+	script := fmt.Sprintf("curl -fsSL %s | sh", s.scriptURL)
+	if _, stderr, err := s.cmd.ExecCommand(CommandParams{
+		Command: "sh",
+		Args:    []string{"-c", script},
+	}); err != nil {
 		return fmt.Errorf(
 			"install script failed for %s: %w\nOutput: %s",
 			packageName,
 			err,
-			string(output),
+			stderr,
 		)
 	}
 
 	return nil
 }
 
+// Upgrade re-runs the install script, which replaces the existing binary with
+// the latest release
+func (s *InstallScriptStrategy) Upgrade(packageName string) error {
+	return s.Install(packageName)
+}
+
 // IsInstalled checks if the package binary exists in common PATH locations
 func (s *InstallScriptStrategy) IsInstalled(packageName string) (bool, error) {
 	_, err := exec.LookPath(packageName)
@@ -300,6 +369,8 @@ type NerdFontStrategy struct {
 	archiveURL string // Full GitHub release URL for the tar.xz archive
 }
 
+func (s *NerdFontStrategy) Method() config.InstallMethod { return config.MethodDownload }
+
 // Install downloads a Nerd Font tar.xz archive, extracts fonts to ~/.local/share/fonts/,
 // and runs fc-cache to register them
 func (s *NerdFontStrategy) Install(packageName string) error {
@@ -315,7 +386,7 @@ func (s *NerdFontStrategy) Install(packageName string) error {
 	}
 
 	fontsDir := filepath.Join(homeDir, ".local", "share", "fonts")
-	if err := os.MkdirAll(fontsDir, 0o755); err != nil {
+	if err := files.MkdirAll(fontsDir, 0o755); err != nil {
 		return fmt.Errorf("failed to create fonts directory: %w", err)
 	}
 
@@ -330,21 +401,30 @@ func (s *NerdFontStrategy) Install(packageName string) error {
 	}
 
 	// Extract .tar.xz to fonts directory
-	extractCmd := exec.Command("tar", "-xf", tmpArchive, "-C", fontsDir)
-	if output, err := extractCmd.CombinedOutput(); err != nil {
-		return fmt.Errorf("failed to extract font archive: %w\nOutput: %s", err, string(output))
+	if _, stderr, err := s.cmd.ExecCommand(CommandParams{
+		Command: "tar",
+		Args:    []string{"-xf", tmpArchive, "-C", fontsDir},
+	}); err != nil {
+		return fmt.Errorf("failed to extract font archive: %w\nOutput: %s", err, stderr)
 	}
 
 	// Update font cache
-	cacheCmd := exec.Command("fc-cache", "-fv")
-	if output, err := cacheCmd.CombinedOutput(); err != nil {
-		logger.L().Warnw("fc-cache failed (non-fatal)", "error", err, "output", string(output))
+	if _, stderr, err := s.cmd.ExecCommand(CommandParams{
+		Command: "fc-cache",
+		Args:    []string{"-fv"},
+	}); err != nil {
+		logger.L().Warnw("fc-cache failed (non-fatal)", "error", err, "output", stderr)
 	}
 
 	logger.L().Infow("Nerd Font installed successfully", "package", packageName)
 	return nil
 }
 
+// Upgrade downloads and extracts the archive again over the existing fonts
+func (s *NerdFontStrategy) Upgrade(packageName string) error {
+	return s.Install(packageName)
+}
+
 // IsInstalled checks if the font is present using fc-list
 func (s *NerdFontStrategy) IsInstalled(packageName string) (bool, error) {
 	return s.cmd.IsFontPresent(packageName)
@@ -357,6 +437,8 @@ type GitCloneStrategy struct {
 	installPath string
 }
 
+func (s *GitCloneStrategy) Method() config.InstallMethod { return config.MethodGitClone }
+
 // Install clones a Git repository to the specified path
 func (s *GitCloneStrategy) Install(packageName string) error {
 	logger.L().Infow(
@@ -373,15 +455,35 @@ func (s *GitCloneStrategy) Install(packageName string) error {
 	}
 
 	// Clone repository
-	cmd := exec.Command("git", "clone", "--depth", "1", s.repoURL, s.installPath)
-	output, err := cmd.CombinedOutput()
-	if err != nil {
-		return fmt.Errorf("git clone failed: %w\nOutput: %s", err, string(output))
+	if _, stderr, err := s.cmd.ExecCommand(CommandParams{
+		Command: "git",
+		Args:    []string{"clone", "--depth", "1", s.repoURL, s.installPath},
+	}); err != nil {
+		return fmt.Errorf("git clone failed: %w\nOutput: %s", err, stderr)
 	}
 
 	return nil
 }
 
+// Upgrade fast-forwards the existing clone to the remote's latest commit
+func (s *GitCloneStrategy) Upgrade(packageName string) error {
+	logger.L().Infow(
+		"Upgrading package via Git pull",
+		"package", packageName,
+		"path", s.installPath,
+	)
+	if _, err := os.Stat(s.installPath); err != nil {
+		return fmt.Errorf("%s is not cloned at %s: %w", packageName, s.installPath, err)
+	}
+	if _, stderr, err := s.cmd.ExecCommand(CommandParams{
+		Command: "git",
+		Args:    []string{"-C", s.installPath, "pull", "--ff-only"},
+	}); err != nil {
+		return fmt.Errorf("git pull failed: %w\nOutput: %s", err, stderr)
+	}
+	return nil
+}
+
 // IsInstalled checks if the repository is already cloned
 func (s *GitCloneStrategy) IsInstalled(packageName string) (bool, error) {
 	_, err := os.Stat(s.installPath)
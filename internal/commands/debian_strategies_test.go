@@ -11,6 +11,7 @@ import (
 	"testing"
 
 	"github.com/cjairm/devgita/pkg/downloader"
+	"github.com/cjairm/devgita/pkg/dryrun"
 )
 
 func TestVerifySHA256(t *testing.T) {
@@ -111,3 +112,37 @@ func TestInstallGitHubBinary_RefusesUnverified(t *testing.T) {
 		}
 	})
 }
+
+func TestInstallGitHubBinary_DryRunPlansWithoutDownloading(t *testing.T) {
+	mock := NewMockBaseCommand()
+	plan := dryrun.NewPlan()
+	dl := func(context.Context, string, string, downloader.RetryConfig) error {
+		t.Fatal("expected no download under a recording executor")
+		return nil
+	}
+
+	err := InstallGitHubBinary(
+		NewRecordingBaseCommand(mock, plan), "tool",
+		"https://example.com/releases/tool.tar.gz",
+		"https://example.com/releases/checksums.txt",
+		dl,
+	)
+	if err != nil {
+		t.Fatalf("InstallGitHubBinary: %v", err)
+	}
+	if got := mock.GetExecCommandCallCount(); got != 0 {
+		t.Errorf("expected no commands to reach the real executor, got %d", got)
+	}
+	var targets []string
+	for _, step := range plan.Steps() {
+		targets = append(targets, string(step.Kind)+" "+step.Target)
+	}
+	want := []string{
+		"download https://example.com/releases/tool.tar.gz",
+		"run tar -xf /tmp/tool.tar.gz -C /tmp/tool-extract tool",
+		"run sudo install -m 755 /tmp/tool-extract/tool /usr/local/bin/tool",
+	}
+	if strings.Join(targets, "\n") != strings.Join(want, "\n") {
+		t.Errorf("expected plan %q, got %q", want, targets)
+	}
+}
@@ -19,22 +19,33 @@ type Command interface {
 	UninstallPackage(packageName string) error
 	UninstallDesktopApp(packageName string) error
 
+	// Upgrade
+	UpgradePackage(packageName string, alias ...string) error
+	UpgradeDesktopApp(desktopAppName string, alias ...string) error
+	RefreshPackageIndex() error
+
 	// Utils
 	IsPackageInstalled(packageName string) (bool, error)
 	IsDesktopAppInstalled(desktopAppName string) (bool, error)
 }
 
+// NewCommand returns the current platform's Command, or a RecordingCommand
+// while a dry-run plan is set (see RecordTo).
 func NewCommand() Command {
+	base := NewBaseCommand()
+	if rec, ok := recorderFor(base); ok {
+		return NewRecordingCommand(rec)
+	}
+	return newPlatformCommand(base)
+}
+
+func newPlatformCommand(base BaseCommandExecutor) Command {
 	switch runtime.GOOS {
 	case "darwin":
-		return &MacOSCommand{
-			BaseCommand: *NewBaseCommand(),
-		}
+		return &MacOSCommand{BaseCommandExecutor: base}
 	// TODO: Is it possible to detect the distribution of Linux?
 	case "linux":
-		return &DebianCommand{
-			BaseCommand: *NewBaseCommand(),
-		}
+		return &DebianCommand{BaseCommandExecutor: base}
 	default:
 		panic("unsupported operating system")
 	}
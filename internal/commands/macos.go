@@ -6,6 +6,7 @@ import (
 	"strconv"
 	"strings"
 
+	"github.com/cjairm/devgita/internal/config"
 	"github.com/cjairm/devgita/pkg/constants"
 	"github.com/cjairm/devgita/pkg/logger"
 	"github.com/cjairm/devgita/pkg/paths"
@@ -13,7 +14,7 @@ import (
 )
 
 type MacOSCommand struct {
-	BaseCommand
+	BaseCommandExecutor
 }
 
 func (m *MacOSCommand) MaybeInstallPackage(packageName string, alias ...string) error {
@@ -91,6 +92,49 @@ func (m *MacOSCommand) InstallDesktopApp(packageName string) error {
 	return nil
 }
 
+// UpgradePackage runs brew upgrade and refreshes the install record, which
+// is kept under alias[0] when given (see MaybeInstall).
+func (m *MacOSCommand) UpgradePackage(packageName string, alias ...string) error {
+	if err := m.brewUpgrade(packageName); err != nil {
+		return fmt.Errorf("failed to upgrade package %s: %w", packageName, err)
+	}
+	recordUpgrade(m, packageName, trackedName(packageName, alias), "package", config.MethodBrew)
+	return nil
+}
+
+// UpgradeDesktopApp runs brew upgrade --cask and refreshes the install
+// record, which is kept under alias[0] when given (see MaybeInstall).
+func (m *MacOSCommand) UpgradeDesktopApp(desktopAppName string, alias ...string) error {
+	if err := m.brewUpgrade("--cask", desktopAppName); err != nil {
+		return fmt.Errorf("failed to upgrade desktop app %s: %w", desktopAppName, err)
+	}
+	recordUpgrade(m, desktopAppName, trackedName(desktopAppName, alias), "desktop_app", config.MethodBrew)
+	return nil
+}
+
+// RefreshPackageIndex runs brew update so upgrades see the newest formulae.
+func (m *MacOSCommand) RefreshPackageIndex() error {
+	if _, _, err := m.ExecCommand(CommandParams{
+		PreExecMsg: "Updating Homebrew...",
+		Command:    "brew",
+		Args:       []string{"update"},
+	}); err != nil {
+		return fmt.Errorf("brew update failed: %w", err)
+	}
+	return nil
+}
+
+func (m *MacOSCommand) brewUpgrade(args ...string) error {
+	name := args[len(args)-1]
+	logger.L().Debug(fmt.Sprintf("executing: brew upgrade %s", strings.Join(args, " ")))
+	_, _, err := m.ExecCommand(CommandParams{
+		PreExecMsg: fmt.Sprintf("Upgrading %s...", strings.ToLower(name)),
+		Command:    "brew",
+		Args:       append([]string{"upgrade"}, args...),
+	})
+	return err
+}
+
 func (m *MacOSCommand) IsPackageManagerInstalled() bool {
 	logger.L().Debug("executing: brew --version")
 	err := exec.Command("brew", "--version").Run()
@@ -128,11 +172,12 @@ func (m *MacOSCommand) ValidateOSVersion() error {
 	utils.PrintSecondary("Getting macOS version")
 
 	cmd := CommandParams{
-		Command: "sw_vers",
-		Args:    []string{"-productVersion"},
+		Command:  "sw_vers",
+		Args:     []string{"-productVersion"},
+		ReadOnly: true,
 	}
 
-	version, _, err := m.BaseCommand.ExecCommand(cmd)
+	version, _, err := m.ExecCommand(cmd)
 	if err != nil {
 		err := fmt.Errorf("unable to get macOS version")
 		return err
@@ -12,6 +12,9 @@ type MockCommand struct {
 	MaybeInstalledDesktop string
 	FontURL               string
 	FontName              string
+	UpgradedPkg           string
+	UpgradedDesktopApp    string
+	IndexRefreshed        bool
 
 	// Error fields to simulate various failure scenarios
 	InstallError        error
@@ -20,6 +23,7 @@ type MockCommand struct {
 	DesktopInstallError error
 	FontInstallError    error
 	ValidationError     error
+	UpgradeError        error
 
 	// State tracking
 	PackageManagerInstalled bool
@@ -67,6 +71,21 @@ func (m *MockCommand) UninstallDesktopApp(pkg string) error {
 	return m.UninstallError
 }
 
+func (m *MockCommand) UpgradePackage(pkg string, alias ...string) error {
+	m.UpgradedPkg = pkg
+	return m.UpgradeError
+}
+
+func (m *MockCommand) UpgradeDesktopApp(desktopAppName string, alias ...string) error {
+	m.UpgradedDesktopApp = desktopAppName
+	return m.UpgradeError
+}
+
+func (m *MockCommand) RefreshPackageIndex() error {
+	m.IndexRefreshed = true
+	return nil
+}
+
 func (m *MockCommand) MaybeInstallPackage(pkg string, alias ...string) error {
 	m.MaybeInstalled = pkg
 	m.MaybeInstalledPkgs = append(m.MaybeInstalledPkgs, pkg)
@@ -150,6 +169,9 @@ func (m *MockCommand) Reset() {
 	m.MaybeInstalledDesktop = ""
 	m.FontURL = ""
 	m.FontName = ""
+	m.UpgradedPkg = ""
+	m.UpgradedDesktopApp = ""
+	m.IndexRefreshed = false
 
 	m.InstallError = nil
 	m.UninstallError = nil
@@ -157,6 +179,7 @@ func (m *MockCommand) Reset() {
 	m.DesktopInstallError = nil
 	m.FontInstallError = nil
 	m.ValidationError = nil
+	m.UpgradeError = nil
 
 	m.MaybeInstalledPkgs = []string{}
 	m.MaybeInstallErrors = map[string]error{}
@@ -181,6 +204,8 @@ func (m *MockCommand) SetError(operation string, err error) {
 		m.FontInstallError = err
 	case "validation":
 		m.ValidationError = err
+	case "upgrade":
+		m.UpgradeError = err
 	}
 }
 
@@ -0,0 +1,114 @@
+package commands
+
+import (
+	"strings"
+	"sync"
+
+	"github.com/cjairm/devgita/pkg/dryrun"
+)
+
+var (
+	recordMu   sync.Mutex
+	recordPlan *dryrun.Plan
+)
+
+// RecordTo makes NewBaseCommand and NewCommand hand out recording executors
+// that add to plan instead of touching the machine, until the returned func
+// is called. It is how --dry-run injects them into the real coordinators:
+// every app and coordinator builds its executors through those constructors,
+// so anything constructed in between plans rather than acts.
+func RecordTo(plan *dryrun.Plan) (restore func()) {
+	recordMu.Lock()
+	defer recordMu.Unlock()
+	previous := recordPlan
+	recordPlan = plan
+	return func() {
+		recordMu.Lock()
+		defer recordMu.Unlock()
+		recordPlan = previous
+	}
+}
+
+func currentRecordPlan() *dryrun.Plan {
+	recordMu.Lock()
+	defer recordMu.Unlock()
+	return recordPlan
+}
+
+// RecordingBaseCommand is the BaseCommandExecutor used under --dry-run. It
+// records every command that would change the machine into Plan instead of
+// running it, and hands everything that only inspects state — commands marked
+// CommandParams.ReadOnly, and the package, app and font presence checks — to
+// the embedded executor, so the plan lists only what is actually missing.
+//
+// Recorded commands report success with empty output, as a command that
+// prints nothing would. A caller that branches on a command's output or exit
+// status is probing, and must mark the command ReadOnly.
+type RecordingBaseCommand struct {
+	BaseCommandExecutor
+	Plan *dryrun.Plan
+}
+
+// NewRecordingBaseCommand wraps real, which still answers read-only probes.
+func NewRecordingBaseCommand(real BaseCommandExecutor, plan *dryrun.Plan) *RecordingBaseCommand {
+	return &RecordingBaseCommand{BaseCommandExecutor: real, Plan: plan}
+}
+
+// ExecCommand runs cmd when it is ReadOnly and records it otherwise, with
+// sudo prepended as BaseCommand would run it.
+func (r *RecordingBaseCommand) ExecCommand(cmd CommandParams) (string, string, error) {
+	if cmd.ReadOnly {
+		return r.BaseCommandExecutor.ExecCommand(cmd)
+	}
+	command, args := cmd.Command, cmd.Args
+	if cmd.IsSudo {
+		args = append([]string{command}, args...)
+		command = "sudo"
+	}
+	r.Plan.Record(dryrun.KindExec, formatCommandLine(command, args), "")
+	return "", "", nil
+}
+
+// InstallFontFromURL records the download, move and cache refresh a real
+// font install would run.
+func (r *RecordingBaseCommand) InstallFontFromURL(url, fontFileName string, runCache bool) error {
+	return installFontFromURL(r, url, fontFileName, runCache)
+}
+
+// RecordingCommand is the Command used under --dry-run: the platform's
+// Command (MacOSCommand or DebianCommand) running on a RecordingBaseCommand.
+// Installs, upgrades and removals land in the plan as the exact
+// package-manager invocations they would run, while the presence checks that
+// decide whether they are needed still query the machine.
+type RecordingCommand struct {
+	Command
+	Base *RecordingBaseCommand
+}
+
+// NewRecordingCommand builds the current platform's Command on base.
+func NewRecordingCommand(base *RecordingBaseCommand) *RecordingCommand {
+	return &RecordingCommand{Command: newPlatformCommand(base), Base: base}
+}
+
+// recorderFor returns base as a RecordingBaseCommand when it is one. Steps
+// that cannot be split into ExecCommand calls — a download checked against
+// its checksum before anything runs, a PPA's key and source list — describe
+// themselves to its plan instead of running.
+func recorderFor(base BaseCommandExecutor) (*RecordingBaseCommand, bool) {
+	r, ok := base.(*RecordingBaseCommand)
+	return r, ok
+}
+
+// formatCommandLine renders a command and its arguments as a shell would
+// need them typed, quoting any argument containing whitespace or shell
+// metacharacters, so a dry-run plan can be copied and run by hand.
+func formatCommandLine(command string, args []string) string {
+	parts := make([]string, 0, len(args)+1)
+	for _, a := range append([]string{command}, args...) {
+		if a == "" || strings.ContainsAny(a, " \t\n'\"$`|&;<>()*?[]{}~!#") {
+			a = "'" + strings.ReplaceAll(a, "'", `'\''`) + "'"
+		}
+		parts = append(parts, a)
+	}
+	return strings.Join(parts, " ")
+}
@@ -0,0 +1,107 @@
+package commands_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/cjairm/devgita/internal/commands"
+	"github.com/cjairm/devgita/pkg/dryrun"
+)
+
+func TestRecordingBaseCommand_ExecCommand(t *testing.T) {
+	newRecorder := func() (*commands.RecordingBaseCommand, *dryrun.Plan) {
+		plan := dryrun.NewPlan()
+		return commands.NewRecordingBaseCommand(commands.NewBaseCommandCustom(&FakePlatform{Linux: true}), plan), plan
+	}
+
+	t.Run("records mutating commands instead of running them", func(t *testing.T) {
+		r, plan := newRecorder()
+		marker := filepath.Join(t.TempDir(), "should-not-exist")
+		stdout, _, err := r.ExecCommand(commands.CommandParams{
+			Command: "touch",
+			Args:    []string{marker},
+			IsSudo:  true,
+		})
+		if err != nil || stdout != "" {
+			t.Fatalf("Expected empty success, got stdout %q err %v", stdout, err)
+		}
+		if _, statErr := os.Stat(marker); !os.IsNotExist(statErr) {
+			t.Errorf("Expected command not to run, but %s exists", marker)
+		}
+		steps := plan.Steps()
+		want := "sudo touch " + marker
+		if len(steps) != 1 || steps[0].Kind != dryrun.KindExec || steps[0].Target != want {
+			t.Errorf("Expected one %q step, got %v", want, steps)
+		}
+	})
+
+	t.Run("quotes arguments that need it", func(t *testing.T) {
+		r, plan := newRecorder()
+		_, _, _ = r.ExecCommand(commands.CommandParams{
+			Command: "sh",
+			Args:    []string{"-c", "echo 'hi' > /tmp/x"},
+		})
+		steps := plan.Steps()
+		want := `sh -c 'echo '\''hi'\'' > /tmp/x'`
+		if len(steps) != 1 || steps[0].Target != want {
+			t.Errorf("Expected %q, got %v", want, steps)
+		}
+	})
+
+	t.Run("read-only probes still run", func(t *testing.T) {
+		r, plan := newRecorder()
+		marker := filepath.Join(t.TempDir(), "probed")
+		_, _, err := r.ExecCommand(commands.CommandParams{
+			Command:  "touch",
+			Args:     []string{marker},
+			ReadOnly: true,
+		})
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		if _, statErr := os.Stat(marker); statErr != nil {
+			t.Errorf("Expected read-only command to run, but %s is missing", marker)
+		}
+		if steps := plan.Steps(); len(steps) != 0 {
+			t.Errorf("Expected read-only probe not to be recorded, got %v", steps)
+		}
+	})
+
+	t.Run("font installs record each step", func(t *testing.T) {
+		r, plan := newRecorder()
+		if err := r.InstallFontFromURL("https://example.com/Hack.ttf", "Hack", true); err != nil {
+			t.Fatalf("InstallFontFromURL: %v", err)
+		}
+		steps := plan.Steps()
+		if len(steps) != 3 || steps[0].Target != "curl -o /tmp/Hack.ttf https://example.com/Hack.ttf" ||
+			steps[2].Target != "sudo fc-cache -fv" {
+			t.Errorf("Expected curl, mv and fc-cache steps, got %v", steps)
+		}
+	})
+}
+
+func TestRecordTo(t *testing.T) {
+	plan := dryrun.NewPlan()
+	restore := commands.RecordTo(plan)
+
+	base, ok := commands.NewBaseCommand().(*commands.RecordingBaseCommand)
+	if !ok || base.Plan != plan {
+		t.Fatalf("Expected NewBaseCommand to record into the plan, got %#v", base)
+	}
+	cmd, ok := commands.NewCommand().(*commands.RecordingCommand)
+	if !ok || cmd.Base.Plan != plan {
+		t.Fatalf("Expected NewCommand to record into the plan, got %#v", cmd)
+	}
+	if err := cmd.UninstallPackage("tmux"); err != nil {
+		t.Fatalf("UninstallPackage: %v", err)
+	}
+	if steps := plan.Steps(); len(steps) != 1 || steps[0].Kind != dryrun.KindExec {
+		t.Errorf("Expected the uninstall recorded as one command, got %v", steps)
+	}
+
+	restore()
+	if _, ok := commands.NewBaseCommand().(*commands.BaseCommand); !ok {
+		t.Error("Expected NewBaseCommand to build a real executor once restored")
+	}
+}
@@ -0,0 +1,142 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/cjairm/devgita/pkg/dryrun"
+	"gopkg.in/yaml.v3"
+)
+
+// recordDryRunSave stands in for writing the global config under --dry-run.
+// The new content is kept as an overlay so later Loads in the same run see
+// it, and the step records which key paths were added or removed relative to
+// what was there before (the overlay, or the file on disk). Saves that change
+// nothing are not recorded.
+func recordDryRunSave(path string, data []byte) error {
+	previous, ok := dryrun.Overlay(path)
+	existed := ok
+	if !ok {
+		var err error
+		previous, err = os.ReadFile(path)
+		existed = err == nil
+	}
+	changes, err := describeChanges(previous, data)
+	if err != nil {
+		return fmt.Errorf("failed to compare global config: %w", err)
+	}
+	dryrun.SetOverlay(path, data)
+	if existed && changes == "" {
+		return nil
+	}
+	dryrun.Record(dryrun.KindConfig, path, changes)
+	return nil
+}
+
+// describeChanges returns one line per key path that differs between two
+// YAML documents, prefixed "- " for removed values and "+ " for added ones
+// (enabling tmux yields "- shell.tmux: false" then "+ shell.tmux: true").
+// Lists contribute one line per element so appending to installed.packages
+// shows only the new entry; tracked items collapse to one line naming the
+// item with its version and method.
+func describeChanges(before, after []byte) (string, error) {
+	oldLines, err := flattenYAML(before)
+	if err != nil {
+		return "", err
+	}
+	newLines, err := flattenYAML(after)
+	if err != nil {
+		return "", err
+	}
+	oldSet := make(map[string]bool, len(oldLines))
+	for _, l := range oldLines {
+		oldSet[l] = true
+	}
+	newSet := make(map[string]bool, len(newLines))
+	for _, l := range newLines {
+		newSet[l] = true
+	}
+
+	var out []string
+	for _, l := range oldLines {
+		if !newSet[l] {
+			out = append(out, "- "+l)
+		}
+	}
+	for _, l := range newLines {
+		if !oldSet[l] {
+			out = append(out, "+ "+l)
+		}
+	}
+	// Order by key path so a changed scalar shows its old and new value together.
+	sort.SliceStable(out, func(i, j int) bool { return out[i][2:] < out[j][2:] })
+	return strings.Join(out, "\n"), nil
+}
+
+// flattenYAML renders a YAML document as sorted "dotted.key: value" lines.
+// Empty documents, empty values, and empty lists produce no lines.
+func flattenYAML(data []byte) ([]string, error) {
+	var doc any
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		return nil, err
+	}
+	var lines []string
+	flattenValue("", doc, &lines)
+	sort.Strings(lines)
+	return lines, nil
+}
+
+func flattenValue(prefix string, v any, lines *[]string) {
+	switch val := v.(type) {
+	case nil:
+		return
+	case map[string]any:
+		for k, child := range val {
+			key := k
+			if prefix != "" {
+				key = prefix + "." + k
+			}
+			flattenValue(key, child, lines)
+		}
+	case []any:
+		for i, child := range val {
+			if name, ok := trackedItemSummary(child); ok {
+				*lines = append(*lines, fmt.Sprintf("%s: %s", prefix, name))
+				continue
+			}
+			switch child.(type) {
+			case map[string]any, []any:
+				flattenValue(fmt.Sprintf("%s[%d]", prefix, i), child, lines)
+			default:
+				flattenValue(prefix, child, lines)
+			}
+		}
+	default:
+		if s, ok := val.(string); ok && s == "" {
+			return
+		}
+		*lines = append(*lines, fmt.Sprintf("%s: %v", prefix, val))
+	}
+}
+
+// trackedItemSummary renders a TrackedItem mapping as "name version (method)",
+// leaving out the timestamp and devgita version that every new entry carries.
+func trackedItemSummary(v any) (string, bool) {
+	m, ok := v.(map[string]any)
+	if !ok {
+		return "", false
+	}
+	name, ok := m["name"].(string)
+	if !ok {
+		return "", false
+	}
+	if version, ok := m["version"].(string); ok && version != "" {
+		name += " " + version
+	}
+	if method, ok := m["method"].(string); ok && method != "" {
+		name += " (" + method + ")"
+	}
+	return name, true
+}
@@ -0,0 +1,74 @@
+package config
+
+import (
+	"os"
+	"testing"
+
+	"github.com/cjairm/devgita/pkg/dryrun"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSave_DryRunRecordsMutationsAndLeavesFileUntouched(t *testing.T) {
+	setupIsolatedConfigPaths(t)
+
+	gc := &GlobalConfig{}
+	require.NoError(t, gc.Create())
+	gc.Installed.Packages = NewTrackedItems("git")
+	require.NoError(t, gc.Save())
+	before, err := os.ReadFile(getGlobalConfigFilePath())
+	require.NoError(t, err)
+
+	plan := dryrun.Enable()
+	t.Cleanup(dryrun.Disable)
+
+	gc.AddToInstalled("tmux", "package")
+	gc.EnableShellFeature("tmux")
+	require.NoError(t, gc.Save())
+
+	after, err := os.ReadFile(getGlobalConfigFilePath())
+	require.NoError(t, err)
+	assert.Equal(t, string(before), string(after), "dry-run Save must not write the file")
+
+	steps := plan.Steps()
+	require.Len(t, steps, 1)
+	assert.Equal(t, dryrun.KindConfig, steps[0].Kind)
+	assert.Equal(t, getGlobalConfigFilePath(), steps[0].Target)
+	assert.Equal(t,
+		"+ installed.packages: tmux\n- shell.tmux: false\n+ shell.tmux: true",
+		steps[0].Detail,
+	)
+
+	// A later Load in the same run observes the unsaved change.
+	reloaded := &GlobalConfig{}
+	require.NoError(t, reloaded.Load())
+	assert.Equal(t, []string{"git", "tmux"}, reloaded.Installed.Packages.Names())
+
+	// Saving the same content again records nothing new.
+	require.NoError(t, reloaded.Save())
+	assert.Len(t, plan.Steps(), 1)
+}
+
+func TestCreate_DryRunRecordsNewConfig(t *testing.T) {
+	setupIsolatedConfigPaths(t)
+
+	plan := dryrun.Enable()
+	t.Cleanup(dryrun.Disable)
+
+	gc := &GlobalConfig{}
+	require.NoError(t, gc.Create())
+	require.NoError(t, gc.Create(), "second Create must see the overlay and no-op")
+
+	_, err := os.Stat(getGlobalConfigFilePath())
+	assert.True(t, os.IsNotExist(err), "dry-run Create must not write the file")
+
+	var configSteps int
+	for _, s := range plan.Steps() {
+		if s.Kind == dryrun.KindConfig {
+			configSteps++
+			assert.Equal(t, getGlobalConfigFilePath(), s.Target)
+		}
+	}
+	assert.Equal(t, 1, configSteps)
+	require.NoError(t, gc.Load())
+}
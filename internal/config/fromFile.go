@@ -4,10 +4,10 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
-	"slices"
 	"time"
 
 	"github.com/cjairm/devgita/pkg/constants"
+	"github.com/cjairm/devgita/pkg/dryrun"
 	"github.com/cjairm/devgita/pkg/files"
 	"github.com/cjairm/devgita/pkg/logger"
 	"github.com/cjairm/devgita/pkg/paths"
@@ -16,24 +16,24 @@ import (
 
 // Used to store what this app installed
 type InstalledConfig struct {
-	Packages      []string `yaml:"packages"`
-	DesktopApps   []string `yaml:"desktop_apps"`
-	Fonts         []string `yaml:"fonts"`
-	Themes        []string `yaml:"themes"`
-	TerminalTools []string `yaml:"terminal_tools"`
-	DevLanguages  []string `yaml:"dev_languages"`
-	Databases     []string `yaml:"databases"`
+	Packages      TrackedItems `yaml:"packages"`
+	DesktopApps   TrackedItems `yaml:"desktop_apps"`
+	Fonts         TrackedItems `yaml:"fonts"`
+	Themes        TrackedItems `yaml:"themes"`
+	TerminalTools TrackedItems `yaml:"terminal_tools"`
+	DevLanguages  TrackedItems `yaml:"dev_languages"`
+	Databases     TrackedItems `yaml:"databases"`
 }
 
 // Used to store config that user already had installed before using this app
 type AlreadyInstalledConfig struct {
-	Packages      []string `yaml:"packages"`
-	DesktopApps   []string `yaml:"desktop_apps"`
-	Fonts         []string `yaml:"fonts"`
-	Themes        []string `yaml:"themes"`
-	TerminalTools []string `yaml:"terminal_tools"`
-	DevLanguages  []string `yaml:"dev_languages"`
-	Databases     []string `yaml:"databases"`
+	Packages      TrackedItems `yaml:"packages"`
+	DesktopApps   TrackedItems `yaml:"desktop_apps"`
+	Fonts         TrackedItems `yaml:"fonts"`
+	Themes        TrackedItems `yaml:"themes"`
+	TerminalTools TrackedItems `yaml:"terminal_tools"`
+	DevLanguages  TrackedItems `yaml:"dev_languages"`
+	Databases     TrackedItems `yaml:"databases"`
 }
 
 // ShellFeatures tracks which shell enhancements are enabled
@@ -87,11 +87,32 @@ const maxRecentRepos = 20
 
 // WorktreeConfig stores worktree-specific settings
 type WorktreeConfig struct {
-	DefaultAI     string       `yaml:"default_ai"`               // "opencode" | "claude"; empty = fallback to "opencode"
-	RecentRepos   []RecentRepo `yaml:"recent_repos,omitempty"`   // MRU-ordered; new field, absent in old configs
-	SearchPaths   []string     `yaml:"search_paths,omitempty"`   // dirs to scan for git repos for the worktree picker; empty = scanning disabled (the only off-switch)
-	ScanDepth     int          `yaml:"scan_depth,omitempty"`     // max dir depth for the repo scan; 0 (or unset) = use the default of 4
-	DefaultLayout string       `yaml:"default_layout,omitempty"` // default tmux window layout for `dg ws`'s create; empty = derive a single-pane layout from DefaultAI
+	DefaultAI     string            `yaml:"default_ai"`               // "opencode" | "claude"; empty = fallback to "opencode"
+	RecentRepos   []RecentRepo      `yaml:"recent_repos,omitempty"`   // MRU-ordered; new field, absent in old configs
+	SearchPaths   []string          `yaml:"search_paths,omitempty"`   // dirs to scan for git repos for the worktree picker; empty = scanning disabled (the only off-switch)
+	ScanDepth     int               `yaml:"scan_depth,omitempty"`     // max dir depth for the repo scan; 0 (or unset) = use the default of 4
+	DefaultLayout string            `yaml:"default_layout,omitempty"` // default tmux window layout for `dg ws`'s create; empty = derive a single-pane layout from DefaultAI
+	Layouts       []LayoutConfig    `yaml:"layouts,omitempty"`        // user-defined window layouts, selectable by name alongside the built-ins
+	NameTemplate  string            `yaml:"name_template,omitempty"`  // template for names generated when create gets none and no prompt; empty = worktree.DefaultNameTemplate
+	Multiplexer   string            `yaml:"multiplexer,omitempty"`    // "tmux" | "zellij"; empty = tmux
+	TrustedSetup  map[string]string `yaml:"trusted_setup,omitempty"`  // repo .devgita/worktree.yaml path -> sha256 of the contents trusted to run setup (`dg wt allow`)
+}
+
+// LayoutConfig declares a named tmux window layout in global_config.yaml.
+// worktree.ResolveLayout validates it and turns it into a worktree.Layout.
+type LayoutConfig struct {
+	Name  string             `yaml:"name"`
+	Panes []LayoutPaneConfig `yaml:"panes"`
+	Focus int                `yaml:"focus,omitempty"` // 1-based pane to land on after building; 0 = the first pane
+}
+
+// LayoutPaneConfig is one pane of a LayoutConfig.
+type LayoutPaneConfig struct {
+	Command string            `yaml:"command,omitempty"` // empty = a plain shell
+	Split   string            `yaml:"split,omitempty"`   // "vertical" | "horizontal"; must be empty for the first pane
+	Size    int               `yaml:"size,omitempty"`    // percentage of the split pane the new pane takes; 0 = even split
+	Dir     string            `yaml:"dir,omitempty"`     // subdirectory of the worktree to start in
+	Env     map[string]string `yaml:"env,omitempty"`     // exported in the pane's shell before Command runs
 }
 
 // UpsertRecentRepo records path as the most-recently-used repo: if path is
@@ -148,6 +169,7 @@ func CanonicalRepoPath(path string) string {
 }
 
 type GlobalConfig struct {
+	SchemaVersion       int                    `yaml:"schema_version"` // see CurrentSchemaVersion
 	AppPath             string                 `yaml:"app_path"`
 	ConfigPath          string                 `yaml:"config_path"`
 	AlreadyInstalled    AlreadyInstalledConfig `yaml:"already_installed"`
@@ -170,11 +192,22 @@ func getGlobalConfigFilePath() string {
 }
 
 func (gc *GlobalConfig) Load() error {
+	if data, ok := dryrun.Overlay(getGlobalConfigFilePath()); ok {
+		if err := yaml.Unmarshal(data, gc); err != nil {
+			return err
+		}
+		gc.migrate()
+		return nil
+	}
 	globalConfigFile, err := os.ReadFile(getGlobalConfigFilePath())
 	if err != nil {
 		return err
 	}
-	return yaml.Unmarshal(globalConfigFile, gc)
+	if err := yaml.Unmarshal(globalConfigFile, gc); err != nil {
+		return err
+	}
+	gc.migrate()
+	return nil
 }
 
 func (gc *GlobalConfig) Save() error {
@@ -182,16 +215,22 @@ func (gc *GlobalConfig) Save() error {
 	if err != nil {
 		return err
 	}
+	if dryrun.Active() {
+		return recordDryRunSave(getGlobalConfigFilePath(), data)
+	}
 	return files.WriteFileAtomic(getGlobalConfigFilePath(), data, files.FilePermission)
 }
 
 func (gc *GlobalConfig) Reset() error {
 	logger.L().Debug("Resetting global config")
-	*gc = GlobalConfig{}
+	*gc = GlobalConfig{SchemaVersion: CurrentSchemaVersion}
 	data, err := yaml.Marshal(gc)
 	if err != nil {
 		return err
 	}
+	if dryrun.Active() {
+		return recordDryRunSave(getGlobalConfigFilePath(), data)
+	}
 	return files.WriteFileAtomic(getGlobalConfigFilePath(), data, files.FilePermission)
 }
 
@@ -200,12 +239,15 @@ func (gc *GlobalConfig) Create() error {
 	if paths.FileAlreadyExist(globalConfigFilePath) {
 		return nil
 	}
+	if _, ok := dryrun.Overlay(globalConfigFilePath); ok {
+		return nil
+	}
 	appFolder := filepath.Join(
 		paths.Paths.Config.Root,
 		constants.App.Name,
 	)
 	if !files.DirAlreadyExist(appFolder) {
-		if err := os.MkdirAll(appFolder, files.DirPermission); err != nil {
+		if err := files.MkdirAll(appFolder, files.DirPermission); err != nil {
 			return err
 		}
 	}
@@ -214,7 +256,13 @@ func (gc *GlobalConfig) Create() error {
 	return gc.Reset()
 }
 
-func (gc *GlobalConfig) getSliceByType(configType, itemType string) *[]string {
+// trackedItemTypes lists every itemType the installed/already_installed
+// buckets accept.
+var trackedItemTypes = []string{
+	"package", "desktop_app", "font", "theme", "terminal_tool", "dev_language", "database",
+}
+
+func (gc *GlobalConfig) getSliceByType(configType, itemType string) *TrackedItems {
 	switch configType {
 	case "installed":
 		return gc.getInstalledSlice(itemType)
@@ -224,7 +272,7 @@ func (gc *GlobalConfig) getSliceByType(configType, itemType string) *[]string {
 	return nil
 }
 
-func (gc *GlobalConfig) getInstalledSlice(itemType string) *[]string {
+func (gc *GlobalConfig) getInstalledSlice(itemType string) *TrackedItems {
 	switch itemType {
 	case "package":
 		return &gc.Installed.Packages
@@ -244,7 +292,7 @@ func (gc *GlobalConfig) getInstalledSlice(itemType string) *[]string {
 	return nil
 }
 
-func (gc *GlobalConfig) getAlreadyInstalledSlice(itemType string) *[]string {
+func (gc *GlobalConfig) getAlreadyInstalledSlice(itemType string) *TrackedItems {
 	switch itemType {
 	case "package":
 		return &gc.AlreadyInstalled.Packages
@@ -269,7 +317,7 @@ func (gc *GlobalConfig) IsTracked(itemName, itemType, configType string) bool {
 	if slice == nil {
 		return false
 	}
-	return slices.Contains(*slice, itemName)
+	return slice.Contains(itemName)
 }
 
 func (gc *GlobalConfig) AddToConfig(itemName, itemType, configType string) {
@@ -277,8 +325,8 @@ func (gc *GlobalConfig) AddToConfig(itemName, itemType, configType string) {
 	if slice == nil {
 		return
 	}
-	if !slices.Contains(*slice, itemName) {
-		*slice = append(*slice, itemName)
+	if !slice.Contains(itemName) {
+		*slice = append(*slice, newTrackedItem(itemName))
 	}
 }
 
@@ -295,7 +343,7 @@ func (gc *GlobalConfig) RemoveFromInstalled(itemName, itemType string) {
 	}
 	result := (*slice)[:0]
 	for _, v := range *slice {
-		if v != itemName {
+		if v.Name != itemName {
 			result = append(result, v)
 		}
 	}
@@ -341,6 +389,32 @@ func (gc *GlobalConfig) AddToFailed(packageName, category, errorMessage string,
 	)
 }
 
+// FailedAttempts returns how many times packageName has failed so far, or 0
+// if it is not in the failed list.
+func (gc *GlobalConfig) FailedAttempts(packageName string) int {
+	for _, f := range gc.FailedInstallations {
+		if f.PackageName == packageName {
+			return f.AttemptCount
+		}
+	}
+	return 0
+}
+
+// RemoveFromFailed drops packageName from the failed installations list once
+// a later attempt succeeds. It reports whether an entry was removed.
+func (gc *GlobalConfig) RemoveFromFailed(packageName string) bool {
+	for i, f := range gc.FailedInstallations {
+		if f.PackageName == packageName {
+			gc.FailedInstallations = append(
+				gc.FailedInstallations[:i],
+				gc.FailedInstallations[i+1:]...,
+			)
+			return true
+		}
+	}
+	return false
+}
+
 func (gc *GlobalConfig) IsInstalledByDevgita(itemName, itemType string) bool {
 	return gc.IsTracked(itemName, itemType, "installed")
 }
@@ -45,62 +45,62 @@ func TestRemoveFromInstalled(t *testing.T) {
 		{
 			name: "removes existing package",
 			setup: func(gc *GlobalConfig) {
-				gc.Installed.Packages = []string{"git", "tmux", "neovim"}
+				gc.Installed.Packages = NewTrackedItems("git", "tmux", "neovim")
 			},
 			removeItem:  "tmux",
 			removeType:  "package",
-			checkField:  func(gc *GlobalConfig) []string { return gc.Installed.Packages },
+			checkField:  func(gc *GlobalConfig) []string { return gc.Installed.Packages.Names() },
 			wantRemains: []string{"git", "neovim"},
 		},
 		{
 			name: "no-op when package absent",
 			setup: func(gc *GlobalConfig) {
-				gc.Installed.Packages = []string{"git"}
+				gc.Installed.Packages = NewTrackedItems("git")
 			},
 			removeItem:  "tmux",
 			removeType:  "package",
-			checkField:  func(gc *GlobalConfig) []string { return gc.Installed.Packages },
+			checkField:  func(gc *GlobalConfig) []string { return gc.Installed.Packages.Names() },
 			wantRemains: []string{"git"},
 		},
 		{
 			name: "removes desktop_app",
 			setup: func(gc *GlobalConfig) {
-				gc.Installed.DesktopApps = []string{"brave", "alacritty", "raycast"}
+				gc.Installed.DesktopApps = NewTrackedItems("brave", "alacritty", "raycast")
 			},
 			removeItem:  "brave",
 			removeType:  "desktop_app",
-			checkField:  func(gc *GlobalConfig) []string { return gc.Installed.DesktopApps },
+			checkField:  func(gc *GlobalConfig) []string { return gc.Installed.DesktopApps.Names() },
 			wantRemains: []string{"alacritty", "raycast"},
 		},
 		{
 			name: "does not affect already_installed",
 			setup: func(gc *GlobalConfig) {
-				gc.Installed.Packages = []string{"git"}
-				gc.AlreadyInstalled.Packages = []string{"git"}
+				gc.Installed.Packages = NewTrackedItems("git")
+				gc.AlreadyInstalled.Packages = NewTrackedItems("git")
 			},
 			removeItem:  "git",
 			removeType:  "package",
-			checkField:  func(gc *GlobalConfig) []string { return gc.AlreadyInstalled.Packages },
+			checkField:  func(gc *GlobalConfig) []string { return gc.AlreadyInstalled.Packages.Names() },
 			wantRemains: []string{"git"},
 		},
 		{
 			name: "unknown item type is no-op",
 			setup: func(gc *GlobalConfig) {
-				gc.Installed.Packages = []string{"git"}
+				gc.Installed.Packages = NewTrackedItems("git")
 			},
 			removeItem:  "git",
 			removeType:  "unknown_type",
-			checkField:  func(gc *GlobalConfig) []string { return gc.Installed.Packages },
+			checkField:  func(gc *GlobalConfig) []string { return gc.Installed.Packages.Names() },
 			wantRemains: []string{"git"},
 		},
 		{
 			name: "removes terminal_tool",
 			setup: func(gc *GlobalConfig) {
-				gc.Installed.TerminalTools = []string{"tool1", "tool2"}
+				gc.Installed.TerminalTools = NewTrackedItems("tool1", "tool2")
 			},
 			removeItem:  "tool1",
 			removeType:  "terminal_tool",
-			checkField:  func(gc *GlobalConfig) []string { return gc.Installed.TerminalTools },
+			checkField:  func(gc *GlobalConfig) []string { return gc.Installed.TerminalTools.Names() },
 			wantRemains: []string{"tool2"},
 		},
 	}
@@ -136,7 +136,7 @@ func TestSave_AtomicRoundTripAndNoLeakedTempFile(t *testing.T) {
 		t.Fatalf("Create failed: %v", err)
 	}
 	gc.CurrentFont = "JetBrainsMono"
-	gc.Installed.Packages = []string{"git", "tmux"}
+	gc.Installed.Packages = NewTrackedItems("git", "tmux")
 	gc.Worktree.DefaultAI = "claude"
 
 	if err := gc.Save(); err != nil {
@@ -204,7 +204,7 @@ func TestReset_AtomicRoundTripAndNoLeakedTempFile(t *testing.T) {
 		t.Fatalf("Create failed: %v", err)
 	}
 	gc.CurrentFont = "will-be-wiped"
-	gc.Installed.Packages = []string{"git"}
+	gc.Installed.Packages = NewTrackedItems("git")
 	if err := gc.Save(); err != nil {
 		t.Fatalf("Save failed: %v", err)
 	}
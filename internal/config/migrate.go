@@ -0,0 +1,82 @@
+package config
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/cjairm/devgita/pkg/logger"
+	"gopkg.in/yaml.v3"
+)
+
+// CurrentSchemaVersion is the global_config.yaml layout this binary writes.
+//
+//	1 (or absent): installed/already_installed lists are bare names.
+//	2: each list entry is a TrackedItem mapping (name, version, method,
+//	   installed_at, devgita_version).
+const CurrentSchemaVersion = 2
+
+// migrate upgrades gc, as just loaded, to CurrentSchemaVersion and reports
+// whether anything changed. Version-1 names already decode into TrackedItems
+// (see TrackedItem.UnmarshalYAML), so the upgrade only has to drop the
+// duplicates bare string lists allowed and stamp the new version. Details
+// that were never recorded stay empty rather than being guessed.
+func (gc *GlobalConfig) migrate() bool {
+	if gc.SchemaVersion >= CurrentSchemaVersion {
+		return false
+	}
+	for _, slice := range gc.allTrackedSlices() {
+		*slice = dedupeTracked(*slice)
+	}
+	gc.SchemaVersion = CurrentSchemaVersion
+	return true
+}
+
+// MigrateFile upgrades global_config.yaml on disk to CurrentSchemaVersion
+// and reports whether it rewrote the file. A missing file is not an error
+// (there is nothing to upgrade yet). Load applies the same upgrade in memory,
+// so callers that skip this still see the current layout; running it once at
+// startup just keeps the file itself current.
+func MigrateFile() (bool, error) {
+	path := getGlobalConfigFilePath()
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return false, nil
+	}
+	if err != nil {
+		return false, fmt.Errorf("failed to read global config: %w", err)
+	}
+	gc := &GlobalConfig{}
+	if err := yaml.Unmarshal(data, gc); err != nil {
+		return false, fmt.Errorf("failed to parse global config: %w", err)
+	}
+	from := gc.SchemaVersion
+	if !gc.migrate() {
+		return false, nil
+	}
+	if err := gc.Save(); err != nil {
+		return false, fmt.Errorf("failed to save migrated global config: %w", err)
+	}
+	logger.L().Infow("Migrated global config", "from", from, "to", CurrentSchemaVersion)
+	return true, nil
+}
+
+func (gc *GlobalConfig) allTrackedSlices() []*TrackedItems {
+	var out []*TrackedItems
+	for _, itemType := range trackedItemTypes {
+		out = append(out, gc.getInstalledSlice(itemType), gc.getAlreadyInstalledSlice(itemType))
+	}
+	return out
+}
+
+func dedupeTracked(items TrackedItems) TrackedItems {
+	seen := make(map[string]bool, len(items))
+	out := items[:0]
+	for _, item := range items {
+		if seen[item.Name] {
+			continue
+		}
+		seen[item.Name] = true
+		out = append(out, item)
+	}
+	return out
+}
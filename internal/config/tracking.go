@@ -0,0 +1,203 @@
+package config
+
+import (
+	"fmt"
+	"slices"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+// InstallMethod records how devgita put an item on the machine.
+type InstallMethod string
+
+const (
+	MethodBrew         InstallMethod = "brew"
+	MethodApt          InstallMethod = "apt"
+	MethodPPA          InstallMethod = "ppa"
+	MethodGitHubBinary InstallMethod = "github-binary"
+	MethodScript       InstallMethod = "script"
+	MethodMise         InstallMethod = "mise"
+	MethodGitClone     InstallMethod = "git-clone"
+	MethodDownload     InstallMethod = "download" // fonts fetched straight from a URL
+)
+
+// DevgitaVersion is stamped onto every item recorded in global_config.yaml.
+// cmd sets it from the build info before any subcommand runs.
+var DevgitaVersion = "dev"
+
+// TrackedItem is one entry of an installed/already_installed list. Only Name
+// is guaranteed: items recorded before schema version 2, or tracked by a
+// configure step rather than an install, carry no version or method.
+type TrackedItem struct {
+	Name           string        `yaml:"name"`
+	Version        string        `yaml:"version,omitempty"`
+	Method         InstallMethod `yaml:"method,omitempty"`
+	InstalledAt    time.Time     `yaml:"installed_at,omitempty"`
+	DevgitaVersion string        `yaml:"devgita_version,omitempty"`
+}
+
+// UnmarshalYAML accepts both the current mapping form and the bare-name
+// strings written by schema version 1.
+func (t *TrackedItem) UnmarshalYAML(node *yaml.Node) error {
+	if node.Kind == yaml.ScalarNode {
+		*t = TrackedItem{Name: node.Value}
+		return nil
+	}
+	type plain TrackedItem
+	var p plain
+	if err := node.Decode(&p); err != nil {
+		return err
+	}
+	if p.Name == "" {
+		return fmt.Errorf("line %d: tracked item is missing a name", node.Line)
+	}
+	*t = TrackedItem(p)
+	return nil
+}
+
+// TrackedItems is an ordered list of tracked items with unique names.
+type TrackedItems []TrackedItem
+
+// NewTrackedItems returns a list of bare items, one per name.
+func NewTrackedItems(names ...string) TrackedItems {
+	items := make(TrackedItems, len(names))
+	for i, name := range names {
+		items[i] = TrackedItem{Name: name}
+	}
+	return items
+}
+
+// Names returns the item names in order.
+func (ti TrackedItems) Names() []string {
+	names := make([]string, len(ti))
+	for i, item := range ti {
+		names[i] = item.Name
+	}
+	return names
+}
+
+// Contains reports whether an item named name is tracked.
+func (ti TrackedItems) Contains(name string) bool {
+	return ti.index(name) >= 0
+}
+
+// Find returns the item named name.
+func (ti TrackedItems) Find(name string) (TrackedItem, bool) {
+	if i := ti.index(name); i >= 0 {
+		return ti[i], true
+	}
+	return TrackedItem{}, false
+}
+
+func (ti TrackedItems) index(name string) int {
+	return slices.IndexFunc(ti, func(item TrackedItem) bool { return item.Name == name })
+}
+
+// RecordInstall tracks itemName as installed by devgita with the version and
+// method it was installed with, replacing any details recorded earlier (a
+// reinstall or update). An empty version leaves a previously recorded one in
+// place, since "unknown" is never more accurate than what was known.
+func (gc *GlobalConfig) RecordInstall(
+	itemName, itemType string,
+	method InstallMethod,
+	version string,
+) {
+	slice := gc.getInstalledSlice(itemType)
+	if slice == nil {
+		return
+	}
+	item := newTrackedItem(itemName)
+	item.Method = method
+	item.Version = version
+	if i := slice.index(itemName); i >= 0 {
+		if version == "" {
+			item.Version = (*slice)[i].Version
+		}
+		(*slice)[i] = item
+		return
+	}
+	*slice = append(*slice, item)
+}
+
+// InstalledItem returns the install record devgita keeps for an item it
+// installed, if any.
+func (gc *GlobalConfig) InstalledItem(itemName, itemType string) (TrackedItem, bool) {
+	slice := gc.getInstalledSlice(itemType)
+	if slice == nil {
+		return TrackedItem{}, false
+	}
+	return slice.Find(itemName)
+}
+
+// Tracked returns the items tracked under itemType in one bucket
+// ("installed" or "already_installed").
+func (gc *GlobalConfig) Tracked(itemType, configType string) TrackedItems {
+	if slice := gc.getSliceByType(configType, itemType); slice != nil {
+		return *slice
+	}
+	return nil
+}
+
+// SetTrackedVersion updates the version recorded for an already tracked item
+// in either bucket, leaving everything else as it was. It reports whether
+// the item was found.
+func (gc *GlobalConfig) SetTrackedVersion(itemName, itemType, version string) bool {
+	for _, slice := range []*TrackedItems{
+		gc.getInstalledSlice(itemType),
+		gc.getAlreadyInstalledSlice(itemType),
+	} {
+		if slice == nil {
+			continue
+		}
+		if i := slice.index(itemName); i >= 0 {
+			(*slice)[i].Version = version
+			return true
+		}
+	}
+	return false
+}
+
+// newTrackedItem stamps a freshly tracked item with the current time and
+// devgita version.
+func newTrackedItem(name string) TrackedItem {
+	return TrackedItem{
+		Name:           name,
+		InstalledAt:    time.Now().UTC().Truncate(time.Second),
+		DevgitaVersion: DevgitaVersion,
+	}
+}
+
+// Untrack drops itemName from one bucket ("installed" or "already_installed")
+// without touching the system, for items the user no longer wants devgita to
+// manage. It reports whether the item was tracked there.
+func (gc *GlobalConfig) Untrack(itemName, itemType, configType string) bool {
+	slice := gc.getSliceByType(configType, itemType)
+	if slice == nil {
+		return false
+	}
+	i := slice.index(itemName)
+	if i < 0 {
+		return false
+	}
+	*slice = append((*slice)[:i], (*slice)[i+1:]...)
+	return true
+}
+
+// MarkAlreadyInstalled moves an item from installed to already_installed,
+// keeping its install record, so uninstall and update leave it alone from
+// then on. It reports whether the item was moved.
+func (gc *GlobalConfig) MarkAlreadyInstalled(itemName, itemType string) bool {
+	item, ok := gc.InstalledItem(itemName, itemType)
+	if !ok {
+		return false
+	}
+	gc.Untrack(itemName, itemType, "installed")
+	already := gc.getAlreadyInstalledSlice(itemType)
+	if i := already.index(itemName); i >= 0 {
+		(*already)[i] = item
+		return true
+	}
+	*already = append(*already, item)
+	return true
+}
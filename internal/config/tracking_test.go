@@ -0,0 +1,137 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v3"
+)
+
+// v1Config is a global_config.yaml as written before install records existed.
+const v1Config = `app_path: /home/me/.local/share/devgita
+installed:
+  packages:
+    - git
+    - tmux
+    - git
+already_installed:
+  databases:
+    - postgresql
+`
+
+func writeGlobalConfig(t *testing.T, content string) string {
+	t.Helper()
+	path := getGlobalConfigFilePath()
+	require.NoError(t, os.MkdirAll(filepath.Dir(path), 0o755))
+	require.NoError(t, os.WriteFile(path, []byte(content), 0o644))
+	return path
+}
+
+func TestLoad_DecodesV1ListsAndMigratesInMemory(t *testing.T) {
+	setupIsolatedConfigPaths(t)
+	path := writeGlobalConfig(t, v1Config)
+
+	gc := &GlobalConfig{}
+	require.NoError(t, gc.Load())
+
+	assert.Equal(t, CurrentSchemaVersion, gc.SchemaVersion)
+	assert.Equal(t, []string{"git", "tmux"}, gc.Installed.Packages.Names(), "duplicates dropped")
+	assert.True(t, gc.IsAlreadyInstalled("postgresql", "database"))
+
+	onDisk, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.Equal(t, v1Config, string(onDisk), "Load must not rewrite the file")
+}
+
+func TestMigrateFile_RewritesOnceInPlace(t *testing.T) {
+	setupIsolatedConfigPaths(t)
+	path := writeGlobalConfig(t, v1Config)
+
+	migrated, err := MigrateFile()
+	require.NoError(t, err)
+	assert.True(t, migrated)
+
+	var raw struct {
+		SchemaVersion int `yaml:"schema_version"`
+		Installed     struct {
+			Packages []map[string]any `yaml:"packages"`
+		} `yaml:"installed"`
+	}
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+	require.NoError(t, yaml.Unmarshal(data, &raw))
+	assert.Equal(t, CurrentSchemaVersion, raw.SchemaVersion)
+	require.Len(t, raw.Installed.Packages, 2)
+	assert.Equal(t, "git", raw.Installed.Packages[0]["name"])
+
+	migrated, err = MigrateFile()
+	require.NoError(t, err)
+	assert.False(t, migrated, "an up-to-date file is left alone")
+}
+
+func TestMigrateFile_NoFile(t *testing.T) {
+	setupIsolatedConfigPaths(t)
+
+	migrated, err := MigrateFile()
+	assert.NoError(t, err)
+	assert.False(t, migrated)
+}
+
+func TestRecordInstall(t *testing.T) {
+	orig := DevgitaVersion
+	DevgitaVersion = "v9.9.9"
+	t.Cleanup(func() { DevgitaVersion = orig })
+
+	gc := &GlobalConfig{}
+	gc.RecordInstall("lazygit", "package", MethodGitHubBinary, "0.44.1")
+
+	item, ok := gc.Installed.Packages.Find("lazygit")
+	require.True(t, ok)
+	assert.Equal(t, "0.44.1", item.Version)
+	assert.Equal(t, MethodGitHubBinary, item.Method)
+	assert.Equal(t, "v9.9.9", item.DevgitaVersion)
+	assert.False(t, item.InstalledAt.IsZero())
+
+	// A later record without a version keeps the known one.
+	gc.RecordInstall("lazygit", "package", MethodGitHubBinary, "")
+	item, _ = gc.Installed.Packages.Find("lazygit")
+	assert.Equal(t, "0.44.1", item.Version)
+	assert.Len(t, gc.Installed.Packages, 1)
+
+	// AddToInstalled never clobbers recorded details.
+	gc.AddToInstalled("lazygit", "package")
+	item, _ = gc.Installed.Packages.Find("lazygit")
+	assert.Equal(t, MethodGitHubBinary, item.Method)
+}
+
+func TestMarkAlreadyInstalledKeepsRecord(t *testing.T) {
+	gc := &GlobalConfig{}
+	gc.RecordInstall("tmux", "package", MethodBrew, "3.4")
+
+	require.True(t, gc.MarkAlreadyInstalled("tmux", "package"))
+
+	assert.False(t, gc.IsInstalledByDevgita("tmux", "package"))
+	item, ok := gc.AlreadyInstalled.Packages.Find("tmux")
+	require.True(t, ok)
+	assert.Equal(t, "3.4", item.Version)
+	assert.Equal(t, MethodBrew, item.Method)
+	assert.False(t, gc.MarkAlreadyInstalled("tmux", "package"), "nothing left to move")
+}
+
+func TestUntrack(t *testing.T) {
+	gc := &GlobalConfig{}
+	gc.AddToAlreadyInstalled("curl", "package")
+
+	assert.False(t, gc.Untrack("curl", "package", "installed"))
+	assert.True(t, gc.Untrack("curl", "package", "already_installed"))
+	assert.Empty(t, gc.AlreadyInstalled.Packages)
+}
+
+func TestTrackedItem_RejectsNamelessMapping(t *testing.T) {
+	var items TrackedItems
+	err := yaml.Unmarshal([]byte("- version: 1.0\n"), &items)
+	assert.Error(t, err)
+}
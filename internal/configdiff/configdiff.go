@@ -0,0 +1,179 @@
+// Package configdiff shows how an app's on-disk config has drifted from what
+// devgita would render for it.
+//
+// It runs the app's force-configure inside a dry run (see pkg/dryrun), where
+// every pkg/files writer records the content it would have written instead
+// of writing it, then compares that content with the files on disk. Because
+// it reuses the real configure code path, every registered app is covered
+// without app-specific diff logic.
+package configdiff
+
+import (
+	"bytes"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/cjairm/devgita/pkg/constants"
+	"github.com/cjairm/devgita/pkg/dryrun"
+	"github.com/cjairm/devgita/pkg/paths"
+	"github.com/pmezard/go-difflib/difflib"
+)
+
+// Status describes how a rendered file compares with the one on disk.
+type Status string
+
+const (
+	// StatusModified: the file exists on disk with different content.
+	StatusModified Status = "modified"
+	// StatusMissing: devgita would create the file; it is not on disk.
+	StatusMissing Status = "missing"
+	// StatusExtra: the file is on disk under a tree devgita mirrors, but
+	// configure would delete it.
+	StatusExtra Status = "extra"
+)
+
+// contextLines is the number of unchanged lines shown around each hunk.
+const contextLines = 3
+
+// FileDiff is one drifted file.
+type FileDiff struct {
+	Path    string
+	Status  Status
+	Unified string // unified diff, on-disk ("a/") → rendered ("b/")
+}
+
+// Compute runs configure in dry-run mode and returns every file whose
+// rendered content differs from disk, sorted by path. global_config.yaml is
+// ignored: it is devgita's own state, not app config.
+//
+// Dry-run mode must not already be active; Compute owns it for the call.
+func Compute(configure func() error) ([]FileDiff, error) {
+	if dryrun.Active() {
+		return nil, fmt.Errorf("cannot compute a config diff inside --dry-run")
+	}
+	dryrun.Enable()
+	defer dryrun.Disable()
+
+	if err := configure(); err != nil {
+		return nil, err
+	}
+	rendered := dryrun.Overlays()
+	delete(rendered, globalConfigPath())
+	return diffAgainstDisk(rendered, dryrun.Removed())
+}
+
+// diffAgainstDisk compares rendered files with disk. Files under a removed
+// root that are not rendered again would be deleted by configure, so they
+// are reported as StatusExtra.
+func diffAgainstDisk(rendered map[string][]byte, removed []string) ([]FileDiff, error) {
+	var diffs []FileDiff
+	for path, next := range rendered {
+		current, err := os.ReadFile(path)
+		switch {
+		case os.IsNotExist(err):
+			diffs = append(diffs, FileDiff{
+				Path:    path,
+				Status:  StatusMissing,
+				Unified: unified(path, nil, next),
+			})
+		case err != nil:
+			return nil, fmt.Errorf("failed to read %s: %w", path, err)
+		case !bytes.Equal(current, next):
+			diffs = append(diffs, FileDiff{
+				Path:    path,
+				Status:  StatusModified,
+				Unified: unified(path, current, next),
+			})
+		}
+	}
+
+	seen := make(map[string]bool)
+	for _, root := range removed {
+		err := filepath.WalkDir(root, func(p string, d fs.DirEntry, err error) error {
+			if err != nil {
+				if os.IsNotExist(err) {
+					return nil
+				}
+				return err
+			}
+			if d.IsDir() || seen[p] {
+				return nil
+			}
+			seen[p] = true
+			if _, ok := rendered[p]; ok {
+				return nil
+			}
+			current, err := os.ReadFile(p)
+			if err != nil {
+				return err
+			}
+			diffs = append(diffs, FileDiff{
+				Path:    p,
+				Status:  StatusExtra,
+				Unified: unified(p, current, nil),
+			})
+			return nil
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan %s: %w", root, err)
+		}
+	}
+
+	sort.Slice(diffs, func(i, j int) bool { return diffs[i].Path < diffs[j].Path })
+	return diffs, nil
+}
+
+// unified renders a git-style unified diff from current (on disk) to next
+// (rendered). A nil side is shown as /dev/null.
+func unified(path string, current, next []byte) string {
+	from, to := "a"+path, "b"+path
+	if current == nil {
+		from = "/dev/null"
+	}
+	if next == nil {
+		to = "/dev/null"
+	}
+	if isBinary(current) || isBinary(next) {
+		return fmt.Sprintf("Binary files %s and %s differ\n", from, to)
+	}
+	text, err := difflib.GetUnifiedDiffString(difflib.UnifiedDiff{
+		A:        splitLines(current),
+		B:        splitLines(next),
+		FromFile: from,
+		ToFile:   to,
+		Context:  contextLines,
+	})
+	if err != nil {
+		return fmt.Sprintf("(diff unavailable: %v)\n", err)
+	}
+	return text
+}
+
+// splitLines splits content into lines that each keep their newline, with a
+// marker on a final line that lacks one (as diff(1) does).
+func splitLines(content []byte) []string {
+	if len(content) == 0 {
+		return nil
+	}
+	lines := difflib.SplitLines(string(content))
+	// SplitLines appends "\n" to the last line; undo that for content that
+	// already ended in a newline, and flag the ones that did not.
+	last := len(lines) - 1
+	if strings.HasSuffix(string(content), "\n") {
+		return lines[:last]
+	}
+	lines[last] = strings.TrimSuffix(lines[last], "\n") + "\n\\ No newline at end of file\n"
+	return lines
+}
+
+func isBinary(content []byte) bool {
+	return bytes.IndexByte(content, 0) >= 0
+}
+
+func globalConfigPath() string {
+	return filepath.Join(paths.Paths.Config.Root, constants.App.Name, constants.App.File.GlobalConfig)
+}
@@ -0,0 +1,85 @@
+package configdiff
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/cjairm/devgita/internal/testutil"
+	"github.com/cjairm/devgita/pkg/dryrun"
+	"github.com/cjairm/devgita/pkg/files"
+)
+
+func init() { testutil.InitLogger() }
+
+func writeFile(t *testing.T, p, content string) {
+	t.Helper()
+	if err := os.MkdirAll(filepath.Dir(p), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(p, []byte(content), 0o644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestCompute_ClassifiesDrift(t *testing.T) {
+	src := t.TempDir()
+	writeFile(t, filepath.Join(src, "init.lua"), "vim.o.number = true\n")
+	writeFile(t, filepath.Join(src, "lua", "new.lua"), "return {}\n")
+	writeFile(t, filepath.Join(src, "same.lua"), "-- same\n")
+
+	dst := filepath.Join(t.TempDir(), "nvim")
+	writeFile(t, filepath.Join(dst, "init.lua"), "vim.o.number = false\n")
+	writeFile(t, filepath.Join(dst, "same.lua"), "-- same\n")
+	writeFile(t, filepath.Join(dst, "stale.lua"), "-- old\n")
+
+	// Mirror src onto dst the way the AI coders sync their subtrees.
+	diffs, err := Compute(func() error {
+		if err := files.RemoveAll(dst); err != nil {
+			return err
+		}
+		return files.CopyDir(src, dst)
+	})
+	if err != nil {
+		t.Fatalf("Compute: %v", err)
+	}
+	if dryrun.Active() {
+		t.Fatal("expected Compute to leave dry-run mode off")
+	}
+
+	got := map[string]Status{}
+	for _, d := range diffs {
+		got[strings.TrimPrefix(d.Path, dst+"/")] = d.Status
+	}
+	want := map[string]Status{
+		"init.lua":    StatusModified,
+		"lua/new.lua": StatusMissing,
+		"stale.lua":   StatusExtra,
+	}
+	if len(got) != len(want) {
+		t.Fatalf("expected %v, got %v", want, got)
+	}
+	for path, status := range want {
+		if got[path] != status {
+			t.Errorf("%s: expected %s, got %s", path, status, got[path])
+		}
+	}
+	if _, err := os.Stat(filepath.Join(dst, "stale.lua")); err != nil {
+		t.Error("expected Compute not to touch disk")
+	}
+}
+
+func TestUnified_Format(t *testing.T) {
+	text := unified("/x/conf", []byte("a\nb\n"), []byte("a\nc"))
+	for _, want := range []string{
+		"--- a/x/conf", "+++ b/x/conf", "-b\n", "+c\n", `\ No newline at end of file`,
+	} {
+		if !strings.Contains(text, want) {
+			t.Errorf("expected %q in:\n%s", want, text)
+		}
+	}
+	if got := unified("/x/bin", nil, []byte{0, 1}); !strings.Contains(got, "Binary files /dev/null and b/x/bin differ") {
+		t.Errorf("expected binary notice, got %q", got)
+	}
+}
@@ -1,8 +1,15 @@
 package embedded
 
+import "io/fs"
+
 // ExtractFunc is a function type for extracting embedded configs
 // This allows dependency injection for testing
 type ExtractFunc func(destDir string) error
 
 // DefaultExtractor will be set by main package
 var DefaultExtractor ExtractFunc
+
+// DefaultFS is the configs/ tree embedded in the running binary, rooted so
+// its paths match the extracted copy under the app dir. Set by main package;
+// nil when no binary embedded it (e.g. in tests).
+var DefaultFS fs.FS
@@ -0,0 +1,378 @@
+package inventory
+
+import (
+	"bytes"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	cmdpkg "github.com/cjairm/devgita/internal/commands"
+	"github.com/cjairm/devgita/internal/config"
+	"github.com/cjairm/devgita/pkg/constants"
+	"github.com/cjairm/devgita/pkg/files"
+	"github.com/cjairm/devgita/pkg/paths"
+)
+
+// CheckStatus is the outcome of one `dg doctor` environment check.
+type CheckStatus int
+
+const (
+	// CheckOK: the check ran and everything it looked at is in place.
+	CheckOK CheckStatus = iota
+	// CheckWarn: something is off but devgita still works, or the check
+	// could not run at all.
+	CheckWarn
+	// CheckFail: the environment is broken in a way the user will notice.
+	CheckFail
+)
+
+func (s CheckStatus) String() string {
+	switch s {
+	case CheckOK:
+		return "OK"
+	case CheckWarn:
+		return "WARN"
+	default:
+		return "FAIL"
+	}
+}
+
+// Check is one doctor finding.
+type Check struct {
+	Name   string
+	Status CheckStatus
+	Detail string
+	Hint   string // how to fix it by hand; empty when Status == CheckOK
+	// FixApp names the registry app whose SoftConfigure repairs this finding,
+	// or "" when there is no automatic fix.
+	FixApp string
+}
+
+// doctorTmuxSocket is the throwaway tmux server the config check loads
+// ~/.tmux.conf into, so the user's running server is never touched.
+const doctorTmuxSocket = "devgita-doctor"
+
+// healthLine matches the ERROR / WARNING bullets of a :checkhealth report,
+// with or without the status emoji newer nvim releases print before them.
+var healthLine = regexp.MustCompile(`^\s*-\s+(?:\S+\s+)?(ERROR|WARNING)\b`)
+
+// Doctor runs end-to-end environment checks: the shell wiring devgita sets
+// up, the configs it renders, and the tools those configs drive. Like
+// Collector it is read-only; repairs are left to the caller (see FixApp).
+type Doctor struct {
+	Base cmdpkg.BaseCommandExecutor
+	// ShellCommandExists resolves a command the way an interactive pane
+	// would; defaults to commands.ShellCommandExistsFn.
+	ShellCommandExists func(name string) bool
+	// EmbeddedConfigs is the configs tree in the running binary. When nil the
+	// embedded-config check is skipped.
+	EmbeddedConfigs fs.FS
+}
+
+// Run returns every applicable check in a fixed order. Checks for tools that
+// global_config.yaml does not track are left out rather than reported.
+func (d *Doctor) Run(gc *config.GlobalConfig) []Check {
+	checks := []Check{d.checkShellIntegration()}
+	if isZsh() {
+		checks = append(checks, d.checkZshenv())
+	}
+	checks = append(checks, d.checkAliases(gc)...)
+	if isTracked(gc, constants.Tmux, "package") {
+		checks = append(checks, d.checkTmuxConfig())
+	}
+	if isTracked(gc, constants.Neovim, "package") {
+		checks = append(checks, d.checkNeovimHealth())
+	}
+	checks = append(checks, d.checkFonts(gc)...)
+	if d.EmbeddedConfigs != nil {
+		checks = append(checks, d.checkEmbeddedConfigs())
+	}
+	return checks
+}
+
+func (d *Doctor) shellCommandExists(name string) bool {
+	if d.ShellCommandExists != nil {
+		return d.ShellCommandExists(name)
+	}
+	return cmdpkg.ShellCommandExistsFn(name)
+}
+
+func isZsh() bool {
+	return filepath.Base(paths.Files.ShellConfig) == ".zshrc"
+}
+
+func isTracked(gc *config.GlobalConfig, name, itemType string) bool {
+	return gc.IsInstalledByDevgita(name, itemType) || gc.IsAlreadyInstalled(name, itemType)
+}
+
+func devgitaZshPath() string {
+	return filepath.Join(paths.Paths.App.Root, fmt.Sprintf("%s.zsh", constants.App.Name))
+}
+
+func (d *Doctor) checkShellIntegration() Check {
+	c := Check{Name: "shell integration", FixApp: constants.DevgitaApp}
+	zshPath := devgitaZshPath()
+	if !files.FileAlreadyExist(zshPath) {
+		c.Status = CheckFail
+		c.Detail = fmt.Sprintf("%s is missing", zshPath)
+		c.Hint = "run `dg configure devgita` to regenerate it"
+		return c
+	}
+	if !fileContains(paths.Files.ShellConfig, zshPath) {
+		c.Status = CheckFail
+		c.Detail = fmt.Sprintf("%s does not source %s", paths.Files.ShellConfig, zshPath)
+		c.Hint = "run `dg configure devgita` to add the source line"
+		return c
+	}
+	c.Detail = fmt.Sprintf("%s is sourced from %s", filepath.Base(zshPath), paths.Files.ShellConfig)
+	return c
+}
+
+func (d *Doctor) checkZshenv() Check {
+	c := Check{Name: "PATH self-repair", FixApp: constants.DevgitaApp}
+	script := filepath.Join(paths.Paths.App.Root, "configs", "zsh", "zshenv.zsh")
+	if !fileContains(paths.Files.ZshEnv, script) {
+		c.Status = CheckFail
+		c.Detail = fmt.Sprintf("%s does not source %s", paths.Files.ZshEnv, script)
+		c.Hint = "run `dg configure devgita`; until then tmux panes may start with a broken PATH"
+		return c
+	}
+	if !files.FileAlreadyExist(script) {
+		c.Status = CheckFail
+		c.Detail = fmt.Sprintf("%s is missing", script)
+		c.Hint = "run `dg configure devgita` to re-extract devgita's configs"
+		return c
+	}
+	c.Detail = fmt.Sprintf("%s sources zshenv.zsh", paths.Files.ZshEnv)
+	return c
+}
+
+// checkAliases resolves the AI coder aliases devgita.zsh defines, through an
+// interactive shell, for every coder whose shell feature is enabled.
+func (d *Doctor) checkAliases(gc *config.GlobalConfig) []Check {
+	aliases := []struct {
+		alias, feature string
+	}{
+		{"oc", constants.OpenCode},
+		{"cc", constants.Claude},
+	}
+	var checks []Check
+	for _, a := range aliases {
+		if !gc.IsShellFeatureEnabled(a.feature) {
+			continue
+		}
+		c := Check{Name: fmt.Sprintf("alias %s", a.alias)}
+		if d.shellCommandExists(a.alias) {
+			c.Detail = fmt.Sprintf("%s resolves in an interactive shell", a.alias)
+		} else {
+			c.Status = CheckFail
+			c.Detail = fmt.Sprintf("%s does not resolve in an interactive shell", a.alias)
+			c.Hint = fmt.Sprintf(
+				"check that %s is installed and open a new shell; `dg configure devgita --force` regenerates devgita.zsh",
+				a.feature,
+			)
+		}
+		checks = append(checks, c)
+	}
+	return checks
+}
+
+// checkTmuxConfig loads ~/.tmux.conf into a private tmux server, so syntax
+// errors surface here instead of on the next tmux start.
+func (d *Doctor) checkTmuxConfig() Check {
+	c := Check{Name: "tmux config"}
+	conf := filepath.Join(paths.Paths.Home.Root, ".tmux.conf")
+	if !files.FileAlreadyExist(conf) {
+		c.Status = CheckFail
+		c.Detail = fmt.Sprintf("%s is missing", conf)
+		c.Hint = "run `dg configure tmux`"
+		c.FixApp = constants.Tmux
+		return c
+	}
+	_, stderr, err := d.Base.ExecCommand(cmdpkg.CommandParams{
+		Command: constants.Tmux,
+		Args: []string{
+			"-L", doctorTmuxSocket, "-f", os.DevNull,
+			"new-session", "-d", ";", "source-file", conf,
+		},
+	})
+	// Always tear the throwaway server down, even when source-file failed
+	// and stopped the command chain before it could exit on its own.
+	_, _, _ = d.Base.ExecCommand(cmdpkg.CommandParams{
+		Command: constants.Tmux,
+		Args:    []string{"-L", doctorTmuxSocket, "kill-server"},
+	})
+	if err != nil {
+		c.Status = CheckFail
+		c.Detail = firstLine(stderr, err)
+		c.Hint = "run `dg configure tmux --diff` to see local edits, `--force` to reset them"
+		return c
+	}
+	c.Detail = fmt.Sprintf("%s loads cleanly", conf)
+	return c
+}
+
+// checkNeovimHealth runs :checkhealth headless and counts its ERROR and
+// WARNING bullets. Warnings (usually optional providers) do not fail it.
+func (d *Doctor) checkNeovimHealth() Check {
+	c := Check{Name: "neovim health"}
+	if files.IsDirEmpty(paths.Paths.Config.Nvim) {
+		c.Status = CheckFail
+		c.Detail = fmt.Sprintf("%s is missing or empty", paths.Paths.Config.Nvim)
+		c.Hint = "run `dg configure neovim`"
+		c.FixApp = constants.Neovim
+		return c
+	}
+
+	report, err := os.CreateTemp("", "devgita-checkhealth-*.txt")
+	if err != nil {
+		c.Status = CheckWarn
+		c.Detail = fmt.Sprintf("could not create a report file: %v", err)
+		return c
+	}
+	reportPath := report.Name()
+	_ = report.Close()
+	defer os.Remove(reportPath)
+
+	if _, stderr, err := d.Base.ExecCommand(cmdpkg.CommandParams{
+		Command: constants.Nvim,
+		Args: []string{
+			"--headless", "+checkhealth", fmt.Sprintf("+write! %s", reportPath), "+qa!",
+		},
+	}); err != nil {
+		c.Status = CheckWarn
+		c.Detail = fmt.Sprintf("could not run :checkhealth: %s", firstLine(stderr, err))
+		c.Hint = "run `nvim --version` to confirm neovim starts"
+		return c
+	}
+	data, err := os.ReadFile(reportPath)
+	if err != nil || len(data) == 0 {
+		c.Status = CheckWarn
+		c.Detail = "neovim produced no :checkhealth report"
+		c.Hint = "run :checkhealth inside nvim"
+		return c
+	}
+
+	errCount, warnCount := parseHealthReport(data)
+	c.Detail = fmt.Sprintf("%d error(s), %d warning(s)", errCount, warnCount)
+	if errCount > 0 {
+		c.Status = CheckFail
+		c.Hint = "run :checkhealth inside nvim to see the failing providers"
+	}
+	return c
+}
+
+// parseHealthReport counts the ERROR and WARNING bullets in a :checkhealth
+// report.
+func parseHealthReport(data []byte) (errCount, warnCount int) {
+	for _, line := range bytes.Split(data, []byte{'\n'}) {
+		m := healthLine.FindSubmatch(line)
+		if m == nil {
+			continue
+		}
+		if string(m[1]) == "ERROR" {
+			errCount++
+		} else {
+			warnCount++
+		}
+	}
+	return errCount, warnCount
+}
+
+func (d *Doctor) checkFonts(gc *config.GlobalConfig) []Check {
+	var checks []Check
+	tracked := append(gc.Installed.Fonts.Names(), gc.AlreadyInstalled.Fonts.Names()...)
+	for _, font := range tracked {
+		c := Check{Name: fmt.Sprintf("font %s", font)}
+		ok, err := d.Base.IsFontPresent(font)
+		switch {
+		case err != nil:
+			c.Status = CheckWarn
+			c.Detail = fmt.Sprintf("could not look the font up: %v", err)
+		case !ok:
+			c.Status = CheckFail
+			c.Detail = "not visible to fc-list or the font directories"
+			c.Hint = "run `fc-cache -f`, or reinstall it with `dg install --only desktop`"
+		default:
+			c.Detail = "visible to the system"
+		}
+		checks = append(checks, c)
+	}
+	return checks
+}
+
+// checkEmbeddedConfigs compares the configs tree extracted under the app dir
+// with the one embedded in the running binary. A mismatch means the binary
+// was upgraded without re-extracting, so configure renders stale templates.
+// Extra files on disk are ignored.
+func (d *Doctor) checkEmbeddedConfigs() Check {
+	c := Check{Name: "embedded configs", FixApp: constants.DevgitaApp}
+	configsDir := filepath.Join(paths.Paths.App.Root, "configs")
+
+	var missing, modified []string
+	err := fs.WalkDir(d.EmbeddedConfigs, ".", func(path string, entry fs.DirEntry, err error) error {
+		if err != nil || entry.IsDir() {
+			return err
+		}
+		want, err := fs.ReadFile(d.EmbeddedConfigs, path)
+		if err != nil {
+			return err
+		}
+		got, err := os.ReadFile(filepath.Join(configsDir, filepath.FromSlash(path)))
+		switch {
+		case os.IsNotExist(err):
+			missing = append(missing, path)
+		case err != nil:
+			return err
+		case !bytes.Equal(want, got):
+			modified = append(modified, path)
+		}
+		return nil
+	})
+	if err != nil {
+		c.Status = CheckWarn
+		c.Detail = fmt.Sprintf("could not compare configs: %v", err)
+		c.FixApp = ""
+		return c
+	}
+	if len(missing) == 0 && len(modified) == 0 {
+		c.Detail = fmt.Sprintf("%s matches this binary", configsDir)
+		return c
+	}
+	c.Status = CheckFail
+	c.Detail = fmt.Sprintf(
+		"%d missing, %d modified under %s (e.g. %s)",
+		len(missing), len(modified), configsDir, firstOf(missing, modified),
+	)
+	c.Hint = "run `dg doctor --fix` (or any `dg configure`) to re-extract them"
+	return c
+}
+
+func fileContains(path, needle string) bool {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return false
+	}
+	return strings.Contains(string(data), needle)
+}
+
+// firstLine returns the first line of a command's stderr, falling back to
+// the error itself when stderr is empty.
+func firstLine(stderr string, err error) string {
+	if line, _, _ := strings.Cut(strings.TrimSpace(stderr), "\n"); line != "" {
+		return line
+	}
+	return err.Error()
+}
+
+func firstOf(lists ...[]string) string {
+	for _, l := range lists {
+		if len(l) > 0 {
+			return l[0]
+		}
+	}
+	return ""
+}
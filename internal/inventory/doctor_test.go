@@ -0,0 +1,233 @@
+package inventory
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"testing/fstest"
+
+	"github.com/cjairm/devgita/internal/config"
+	"github.com/cjairm/devgita/internal/testutil"
+	"github.com/cjairm/devgita/pkg/constants"
+	"github.com/cjairm/devgita/pkg/paths"
+)
+
+// setupDoctorPaths points every path the doctor reads at a temp dir and
+// returns that dir.
+func setupDoctorPaths(t *testing.T) string {
+	t.Helper()
+	dir := t.TempDir()
+	origAppRoot, origHome, origNvim := paths.Paths.App.Root, paths.Paths.Home.Root, paths.Paths.Config.Nvim
+	origShell, origZshEnv := paths.Files.ShellConfig, paths.Files.ZshEnv
+	paths.Paths.App.Root = filepath.Join(dir, "app")
+	paths.Paths.Home.Root = dir
+	paths.Paths.Config.Nvim = filepath.Join(dir, "nvim")
+	paths.Files.ShellConfig = filepath.Join(dir, ".zshrc")
+	paths.Files.ZshEnv = filepath.Join(dir, ".zshenv")
+	t.Cleanup(func() {
+		paths.Paths.App.Root, paths.Paths.Home.Root, paths.Paths.Config.Nvim = origAppRoot, origHome, origNvim
+		paths.Files.ShellConfig, paths.Files.ZshEnv = origShell, origZshEnv
+	})
+	if err := os.MkdirAll(paths.Paths.App.Root, 0755); err != nil {
+		t.Fatal(err)
+	}
+	return dir
+}
+
+func writeDoctorFile(t *testing.T, path, content string) {
+	t.Helper()
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func checksByName(checks []Check) map[string]Check {
+	byName := map[string]Check{}
+	for _, c := range checks {
+		byName[c.Name] = c
+	}
+	return byName
+}
+
+func TestDoctor_ShellIntegration(t *testing.T) {
+	setupDoctorPaths(t)
+	d := &Doctor{Base: testutil.NewMockApp().Base}
+
+	c := d.checkShellIntegration()
+	if c.Status != CheckFail || c.FixApp != constants.DevgitaApp {
+		t.Fatalf("missing devgita.zsh: got %+v, want FAIL fixed by devgita", c)
+	}
+
+	zsh := filepath.Join(paths.Paths.App.Root, "devgita.zsh")
+	writeDoctorFile(t, zsh, "# generated\n")
+	if c := d.checkShellIntegration(); c.Status != CheckFail {
+		t.Errorf("unsourced devgita.zsh: got %v, want FAIL", c.Status)
+	}
+
+	writeDoctorFile(t, paths.Files.ShellConfig, `source "`+zsh+`"`+"\n")
+	if c := d.checkShellIntegration(); c.Status != CheckOK {
+		t.Errorf("sourced devgita.zsh: got %v (%s), want OK", c.Status, c.Detail)
+	}
+}
+
+func TestDoctor_ZshenvRequiresLineAndScript(t *testing.T) {
+	setupDoctorPaths(t)
+	d := &Doctor{Base: testutil.NewMockApp().Base}
+	script := filepath.Join(paths.Paths.App.Root, "configs", "zsh", "zshenv.zsh")
+
+	writeDoctorFile(t, paths.Files.ZshEnv, `[ -f "`+script+`" ] && source "`+script+`"`+"\n")
+	if c := d.checkZshenv(); c.Status != CheckFail || !strings.Contains(c.Detail, "missing") {
+		t.Errorf("script missing: got %v (%s), want FAIL naming the missing script", c.Status, c.Detail)
+	}
+
+	writeDoctorFile(t, script, "# repair PATH\n")
+	if c := d.checkZshenv(); c.Status != CheckOK {
+		t.Errorf("wired: got %v (%s), want OK", c.Status, c.Detail)
+	}
+}
+
+func TestDoctor_AliasesOnlyForEnabledCoders(t *testing.T) {
+	setupDoctorPaths(t)
+	var probed []string
+	d := &Doctor{
+		Base: testutil.NewMockApp().Base,
+		ShellCommandExists: func(name string) bool {
+			probed = append(probed, name)
+			return false
+		},
+	}
+	gc := &config.GlobalConfig{}
+	gc.EnableShellFeature(constants.Claude)
+
+	checks := d.checkAliases(gc)
+
+	if len(checks) != 1 || checks[0].Name != "alias cc" || checks[0].Status != CheckFail {
+		t.Fatalf("got %+v, want a single failing cc check", checks)
+	}
+	if len(probed) != 1 || probed[0] != "cc" {
+		t.Errorf("probed %v, want only cc", probed)
+	}
+}
+
+func TestDoctor_TmuxConfigErrorAlwaysKillsServer(t *testing.T) {
+	dir := setupDoctorPaths(t)
+	writeDoctorFile(t, filepath.Join(dir, ".tmux.conf"), "set -g bogus-option on\n")
+	mockApp := testutil.NewMockApp()
+	mockApp.Base.SetExecCommandResult(
+		"",
+		".tmux.conf:1: invalid option: bogus-option\nmore",
+		errors.New("exit status 1"),
+	)
+	d := &Doctor{Base: mockApp.Base}
+
+	c := d.checkTmuxConfig()
+
+	if c.Status != CheckFail || c.Detail != ".tmux.conf:1: invalid option: bogus-option" {
+		t.Errorf("got %v (%q), want FAIL with the first stderr line", c.Status, c.Detail)
+	}
+	last := mockApp.Base.GetLastExecCommandCall()
+	if last == nil || strings.Join(last.Args, " ") != "-L "+doctorTmuxSocket+" kill-server" {
+		t.Errorf("last command = %+v, want the throwaway server killed", last)
+	}
+}
+
+func TestDoctor_MissingTmuxConfigIsFixedByTmux(t *testing.T) {
+	setupDoctorPaths(t)
+	mockApp := testutil.NewMockApp()
+	d := &Doctor{Base: mockApp.Base}
+
+	c := d.checkTmuxConfig()
+
+	if c.Status != CheckFail || c.FixApp != constants.Tmux {
+		t.Errorf("got %+v, want FAIL fixed by tmux", c)
+	}
+	testutil.VerifyNoRealCommands(t, mockApp.Base)
+}
+
+func TestParseHealthReport(t *testing.T) {
+	report := strings.Join([]string{
+		"vim.lsp: require(\"vim.lsp.health\").check()",
+		"- OK Log size: 12 KB",
+		"- WARNING Python 3 provider not found",
+		"- ❌ ERROR Failed to run healthcheck for \"foo\" plugin",
+		"- ⚠️ WARNING No clipboard tool found",
+		"  - ADVICE: install xclip",
+	}, "\n")
+
+	errCount, warnCount := parseHealthReport([]byte(report))
+
+	if errCount != 1 || warnCount != 2 {
+		t.Errorf("got %d error(s), %d warning(s); want 1, 2", errCount, warnCount)
+	}
+}
+
+func TestDoctor_FontsReportMissing(t *testing.T) {
+	setupDoctorPaths(t)
+	mockApp := testutil.NewMockApp()
+	mockApp.Base.IsFontPresentResult = false
+	d := &Doctor{Base: mockApp.Base}
+	gc := &config.GlobalConfig{}
+	gc.Installed.Fonts = config.NewTrackedItems("JetBrainsMono")
+
+	checks := d.checkFonts(gc)
+
+	if len(checks) != 1 || checks[0].Status != CheckFail {
+		t.Fatalf("got %+v, want one failing font check", checks)
+	}
+}
+
+func TestDoctor_EmbeddedConfigsDrift(t *testing.T) {
+	setupDoctorPaths(t)
+	configsDir := filepath.Join(paths.Paths.App.Root, "configs")
+	writeDoctorFile(t, filepath.Join(configsDir, "git", ".gitconfig"), "same\n")
+	writeDoctorFile(t, filepath.Join(configsDir, "tmux", "tmux.conf"), "old\n")
+	writeDoctorFile(t, filepath.Join(configsDir, "extra.txt"), "ignored\n")
+	d := &Doctor{
+		Base: testutil.NewMockApp().Base,
+		EmbeddedConfigs: fstest.MapFS{
+			"git/.gitconfig":  {Data: []byte("same\n")},
+			"tmux/tmux.conf":  {Data: []byte("new\n")},
+			"zsh/zshenv.zsh":  {Data: []byte("# repair\n")},
+			"templates/x.tmp": {Data: []byte("x\n")},
+		},
+	}
+
+	c := d.checkEmbeddedConfigs()
+
+	if c.Status != CheckFail || c.FixApp != constants.DevgitaApp {
+		t.Fatalf("got %+v, want FAIL fixed by devgita", c)
+	}
+	if !strings.Contains(c.Detail, "2 missing, 1 modified") {
+		t.Errorf("detail = %q, want 2 missing and 1 modified", c.Detail)
+	}
+
+	writeDoctorFile(t, filepath.Join(configsDir, "tmux", "tmux.conf"), "new\n")
+	writeDoctorFile(t, filepath.Join(configsDir, "zsh", "zshenv.zsh"), "# repair\n")
+	writeDoctorFile(t, filepath.Join(configsDir, "templates", "x.tmp"), "x\n")
+	if c := d.checkEmbeddedConfigs(); c.Status != CheckOK {
+		t.Errorf("after re-extract: got %v (%s), want OK", c.Status, c.Detail)
+	}
+}
+
+func TestDoctor_RunSkipsUntrackedTools(t *testing.T) {
+	setupDoctorPaths(t)
+	mockApp := testutil.NewMockApp()
+	d := &Doctor{Base: mockApp.Base, ShellCommandExists: func(string) bool { return true }}
+
+	byName := checksByName(d.Run(&config.GlobalConfig{}))
+
+	for _, name := range []string{"tmux config", "neovim health", "embedded configs", "alias cc"} {
+		if _, ok := byName[name]; ok {
+			t.Errorf("%q should be skipped when nothing tracks it", name)
+		}
+	}
+	if _, ok := byName["shell integration"]; !ok {
+		t.Error("shell integration should always be checked")
+	}
+	testutil.VerifyNoRealCommands(t, mockApp.Base)
+}
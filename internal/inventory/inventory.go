@@ -1,6 +1,8 @@
 package inventory
 
 import (
+	"time"
+
 	cmdpkg "github.com/cjairm/devgita/internal/commands"
 	"github.com/cjairm/devgita/internal/config"
 	"github.com/cjairm/devgita/internal/tooling/databases"
@@ -38,24 +40,52 @@ type Item struct {
 	Source   string // "installed" (devgita installed it) or "pre-existing" (found already on the system)
 	State    ItemState
 	Detail   string // populated when State == StateUnknown (the check error's message)
+
+	// Recorded in global_config.yaml when the item was tracked; empty/zero for
+	// items tracked before devgita recorded them (schema version 1).
+	Version        string
+	Method         string // config.InstallMethod, e.g. "brew", "apt", "github-binary"
+	InstalledAt    time.Time
+	DevgitaVersion string
 }
 
-// CategoryInfo pairs a category key with its display label, in the fixed display
-// order used by the `dg list` dashboard.
+// CategoryInfo pairs a category key with its display label and the itemType
+// global_config.yaml tracks it under, in the fixed display order used by the
+// `dg list` dashboard.
 type CategoryInfo struct {
-	Key   string
-	Label string
+	Key      string
+	Label    string
+	ItemType string
 }
 
 // Categories is the canonical 7-category vocabulary and display order.
 var Categories = []CategoryInfo{
-	{Key: "packages", Label: "Packages"},
-	{Key: "desktop_apps", Label: "Desktop Apps"},
-	{Key: "fonts", Label: "Fonts"},
-	{Key: "themes", Label: "Themes"},
-	{Key: "terminal_tools", Label: "Terminal Tools"},
-	{Key: "dev_languages", Label: "Dev Languages"},
-	{Key: "databases", Label: "Databases"},
+	{Key: "packages", Label: "Packages", ItemType: "package"},
+	{Key: "desktop_apps", Label: "Desktop Apps", ItemType: "desktop_app"},
+	{Key: "fonts", Label: "Fonts", ItemType: "font"},
+	{Key: "themes", Label: "Themes", ItemType: "theme"},
+	{Key: "terminal_tools", Label: "Terminal Tools", ItemType: "terminal_tool"},
+	{Key: "dev_languages", Label: "Dev Languages", ItemType: "dev_language"},
+	{Key: "databases", Label: "Databases", ItemType: "database"},
+}
+
+// ItemType returns the global_config.yaml itemType for a category key, or ""
+// for an unknown category.
+func ItemType(category string) string {
+	for _, c := range Categories {
+		if c.Key == category {
+			return c.ItemType
+		}
+	}
+	return ""
+}
+
+// ConfigType returns the global_config.yaml bucket an item's Source maps to.
+func ConfigType(source string) string {
+	if source == "pre-existing" {
+		return "already_installed"
+	}
+	return "installed"
 }
 
 // Collector runs presence checks for every item devgita has tracked, for both
@@ -70,95 +100,87 @@ type Collector struct {
 // (which would shell out for every configured — not just tracked — language and
 // database, and can silently persist newly-detected pre-existing installs).
 func (c *Collector) Collect(gc *config.GlobalConfig) []Item {
-	dl := &languages.DevLanguages{Cmd: c.Cmd, Base: c.Base}
-	db := &databases.Databases{Cmd: c.Cmd, Base: c.Base}
-
 	var items []Item
-	items = append(
-		items,
-		c.collectCategory(
-			"packages",
-			gc.Installed.Packages,
-			gc.AlreadyInstalled.Packages,
-			c.checkPackage,
-		)...)
-	items = append(
-		items,
-		c.collectCategory(
-			"desktop_apps",
-			gc.Installed.DesktopApps,
-			gc.AlreadyInstalled.DesktopApps,
-			c.checkDesktopApp,
-		)...)
-	items = append(
-		items,
-		c.collectCategory("fonts", gc.Installed.Fonts, gc.AlreadyInstalled.Fonts, c.checkFont)...)
-	items = append(
-		items,
-		c.collectCategory(
-			"themes",
-			gc.Installed.Themes,
-			gc.AlreadyInstalled.Themes,
-			checkNotImplemented,
-		)...)
-	items = append(
-		items,
-		c.collectCategory(
-			"terminal_tools",
-			gc.Installed.TerminalTools,
-			gc.AlreadyInstalled.TerminalTools,
-			checkNotImplemented,
-		)...)
-	items = append(
-		items,
-		c.collectCategory(
-			"dev_languages",
-			gc.Installed.DevLanguages,
-			gc.AlreadyInstalled.DevLanguages,
-			checkLanguageFn(dl),
-		)...)
-	items = append(
-		items,
-		c.collectCategory(
-			"databases",
-			gc.Installed.Databases,
-			gc.AlreadyInstalled.Databases,
-			checkDatabaseFn(db),
-		)...)
+	for _, category := range Categories {
+		installed, alreadyInstalled := trackedBuckets(gc, category.ItemType)
+		items = append(
+			items,
+			c.collectCategory(category.Key, installed, alreadyInstalled, c.checkFor(category.Key))...,
+		)
+	}
 	return items
 }
 
+// Recheck re-runs the presence check for one item after its tracking may
+// have changed (a repair from the dashboard). It looks the item up by name in
+// both buckets, installed first, and reports false once it is tracked in
+// neither. Same read-only contract as Collect.
+func (c *Collector) Recheck(gc *config.GlobalConfig, name, category string) (Item, bool) {
+	installed, alreadyInstalled := trackedBuckets(gc, ItemType(category))
+	check := c.checkFor(category)
+	if tracked, ok := installed.Find(name); ok {
+		return newItem(tracked, category, "installed", check), true
+	}
+	if tracked, ok := alreadyInstalled.Find(name); ok {
+		return newItem(tracked, category, "pre-existing", check), true
+	}
+	return Item{}, false
+}
+
+func trackedBuckets(gc *config.GlobalConfig, itemType string) (installed, alreadyInstalled config.TrackedItems) {
+	return gc.Tracked(itemType, "installed"), gc.Tracked(itemType, "already_installed")
+}
+
+// checkFor returns the presence check backing a category.
+func (c *Collector) checkFor(category string) checkFn {
+	switch category {
+	case "packages":
+		return c.checkPackage
+	case "desktop_apps":
+		return c.checkDesktopApp
+	case "fonts":
+		return c.checkFont
+	case "dev_languages":
+		return checkLanguageFn(&languages.DevLanguages{Cmd: c.Cmd, Base: c.Base})
+	case "databases":
+		return checkDatabaseFn(&databases.Databases{Cmd: c.Cmd, Base: c.Base})
+	default:
+		return checkNotImplemented
+	}
+}
+
 type checkFn func(name string) (ItemState, string)
 
 func (c *Collector) collectCategory(
 	category string,
-	installed, alreadyInstalled []string,
+	installed, alreadyInstalled config.TrackedItems,
 	check checkFn,
 ) []Item {
 	var items []Item
-	for _, name := range installed {
-		state, detail := check(name)
-		items = append(
-			items,
-			Item{Name: name, Category: category, Source: "installed", State: state, Detail: detail},
-		)
+	for _, tracked := range installed {
+		items = append(items, newItem(tracked, category, "installed", check))
 	}
-	for _, name := range alreadyInstalled {
-		state, detail := check(name)
-		items = append(
-			items,
-			Item{
-				Name:     name,
-				Category: category,
-				Source:   "pre-existing",
-				State:    state,
-				Detail:   detail,
-			},
-		)
+	for _, tracked := range alreadyInstalled {
+		items = append(items, newItem(tracked, category, "pre-existing", check))
 	}
 	return items
 }
 
+func newItem(tracked config.TrackedItem, category, source string, check checkFn) Item {
+	state, detail := check(tracked.Name)
+	return Item{
+		Name:           tracked.Name,
+		Category:       category,
+		Source:         source,
+		State:          state,
+		Detail:         detail,
+		Version:        tracked.Version,
+		Method:         string(tracked.Method),
+		InstalledAt:    tracked.InstalledAt,
+		DevgitaVersion: tracked.DevgitaVersion,
+	}
+}
+
 func (c *Collector) checkPackage(name string) (ItemState, string) {
 	ok, err := c.Cmd.IsPackageInstalled(name)
 	return stateFromCheck(ok, err)
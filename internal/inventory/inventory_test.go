@@ -4,6 +4,7 @@ import (
 	"errors"
 	"os"
 	"testing"
+	"time"
 
 	"github.com/cjairm/devgita/internal/config"
 	"github.com/cjairm/devgita/internal/testutil"
@@ -19,7 +20,7 @@ func TestCollect_PackageStates_OK_Missing_Unknown(t *testing.T) {
 	}
 
 	gc := &config.GlobalConfig{}
-	gc.Installed.Packages = []string{"git", "tmux", "broken-pkg"}
+	gc.Installed.Packages = config.NewTrackedItems("git", "tmux", "broken-pkg")
 
 	c := &Collector{Cmd: mockApp.Cmd, Base: mockApp.Base}
 	items := c.Collect(gc)
@@ -56,7 +57,7 @@ func TestCollect_AlreadyInstalledSource(t *testing.T) {
 	mockApp.Cmd.PackageInstalledMap = map[string]bool{"curl": true}
 
 	gc := &config.GlobalConfig{}
-	gc.AlreadyInstalled.Packages = []string{"curl"}
+	gc.AlreadyInstalled.Packages = config.NewTrackedItems("curl")
 
 	c := &Collector{Cmd: mockApp.Cmd, Base: mockApp.Base}
 	items := c.Collect(gc)
@@ -73,8 +74,8 @@ func TestCollect_DesktopAppAndFontStates(t *testing.T) {
 	mockApp.Base.IsFontPresentError = errors.New("fc-list: not found")
 
 	gc := &config.GlobalConfig{}
-	gc.Installed.DesktopApps = []string{"docker"}
-	gc.Installed.Fonts = []string{"JetBrainsMono"}
+	gc.Installed.DesktopApps = config.NewTrackedItems("docker")
+	gc.Installed.Fonts = config.NewTrackedItems("JetBrainsMono")
 
 	c := &Collector{Cmd: mockApp.Cmd, Base: mockApp.Base}
 	items := c.Collect(gc)
@@ -101,8 +102,8 @@ func TestCollect_DevLanguageAndDatabaseStates(t *testing.T) {
 	mockApp.Base.SetExecCommandResult("v20.0.0", "", nil) // every version check succeeds
 
 	gc := &config.GlobalConfig{}
-	gc.Installed.DevLanguages = []string{"node@lts"} // matches mise-managed Node config
-	gc.Installed.Databases = []string{"redis"}
+	gc.Installed.DevLanguages = config.NewTrackedItems("node@lts") // matches mise-managed Node config
+	gc.Installed.Databases = config.NewTrackedItems("redis")
 
 	c := &Collector{Cmd: mockApp.Cmd, Base: mockApp.Base}
 	items := c.Collect(gc)
@@ -155,7 +156,7 @@ func TestCollect_DoesNotWriteGlobalConfig(t *testing.T) {
 	if err := gc.Load(); err != nil {
 		t.Fatalf("gc.Load() failed: %v", err)
 	}
-	gc.Installed.Packages = []string{"git"}
+	gc.Installed.Packages = config.NewTrackedItems("git")
 	if err := gc.Save(); err != nil {
 		t.Fatalf("gc.Save() failed: %v", err)
 	}
@@ -178,3 +179,34 @@ func TestCollect_DoesNotWriteGlobalConfig(t *testing.T) {
 		t.Error("Collect must not modify global_config.yaml on disk")
 	}
 }
+
+func TestCollect_CarriesInstallRecord(t *testing.T) {
+	mockApp := testutil.NewMockApp()
+	mockApp.Cmd.PackageInstalledMap = map[string]bool{"lazygit": true, "curl": true}
+
+	installedAt := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	gc := &config.GlobalConfig{}
+	gc.Installed.Packages = config.TrackedItems{{
+		Name:           "lazygit",
+		Version:        "0.44.1",
+		Method:         config.MethodGitHubBinary,
+		InstalledAt:    installedAt,
+		DevgitaVersion: "v1.2.0",
+	}}
+	gc.AlreadyInstalled.Packages = config.NewTrackedItems("curl") // tracked before schema v2
+
+	c := &Collector{Cmd: mockApp.Cmd, Base: mockApp.Base}
+	byName := map[string]Item{}
+	for _, it := range c.Collect(gc) {
+		byName[it.Name] = it
+	}
+
+	lg := byName["lazygit"]
+	if lg.Version != "0.44.1" || lg.Method != "github-binary" ||
+		!lg.InstalledAt.Equal(installedAt) || lg.DevgitaVersion != "v1.2.0" {
+		t.Errorf("lazygit: install record not carried through, got %+v", lg)
+	}
+	if curl := byName["curl"]; curl.Version != "" || curl.Method != "" || !curl.InstalledAt.IsZero() {
+		t.Errorf("curl: expected an empty record for a v1 item, got %+v", curl)
+	}
+}
@@ -0,0 +1,161 @@
+// Package manifest parses and validates the declarative devgita.yaml file
+// consumed by `dg apply`. A manifest names what a machine should have —
+// install categories, individual registry apps, languages (optionally pinned),
+// databases, fonts, and a theme — so the whole setup can run unattended.
+//
+// Validation is strict and exhaustive: every entry is checked against the
+// registry and the language/database/font catalogs, unknown YAML keys are
+// rejected (a typo such as "langauges" must not silently install nothing),
+// and all problems are reported together instead of one per run.
+package manifest
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"slices"
+	"strings"
+
+	"github.com/cjairm/devgita/internal/apps/registry"
+	"github.com/cjairm/devgita/internal/tooling/databases"
+	"github.com/cjairm/devgita/internal/tooling/languages"
+	"github.com/cjairm/devgita/pkg/constants"
+	"gopkg.in/yaml.v3"
+)
+
+// DefaultFile is the manifest name `dg apply` looks for when -f is omitted.
+const DefaultFile = "devgita.yaml"
+
+// knownThemes lists the themes the shipped config templates can render. Only
+// the default palette exists today.
+var knownThemes = []string{"default"}
+
+// Manifest is the parsed form of devgita.yaml.
+//
+//	categories: [terminal, ai-tools]
+//	apps: [alacritty]
+//	skip: [lazydocker]
+//	languages: [go, node@20, php]
+//	databases: [postgresql, redis]
+//	fonts: [font-hack-nerd-font]
+//	theme: default
+type Manifest struct {
+	// Categories are full install categories: terminal, desktop, ai-tools.
+	Categories []string `yaml:"categories"`
+	// Apps are individual registry apps installed on top of Categories.
+	Apps []string `yaml:"apps"`
+	// Skip excludes categories or apps, mirroring `dg install --skip`.
+	Skip []string `yaml:"skip"`
+	// Languages are "name[@version]" specs; the version defaults to the
+	// catalog's ("lts" for node, "latest" otherwise).
+	Languages []string `yaml:"languages"`
+	Databases []string `yaml:"databases"`
+	// Fonts are Nerd Font package names (e.g. "font-hack-nerd-font").
+	Fonts []string `yaml:"fonts"`
+	Theme string   `yaml:"theme"`
+}
+
+// Load reads, parses, and validates the manifest at path.
+func Load(path string) (*Manifest, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read manifest: %w", err)
+	}
+	m, err := Parse(data)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	return m, nil
+}
+
+// Parse decodes and validates manifest YAML. Unknown keys are an error.
+func Parse(data []byte) (*Manifest, error) {
+	m := &Manifest{}
+	dec := yaml.NewDecoder(bytes.NewReader(data))
+	dec.KnownFields(true)
+	if err := dec.Decode(m); err != nil && !errors.Is(err, io.EOF) {
+		return nil, fmt.Errorf("invalid manifest: %w", err)
+	}
+	if err := m.Validate(); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+// Validate checks every entry against the registry and the language,
+// database, and font catalogs, returning all problems joined.
+func (m *Manifest) Validate() error {
+	var errs []error
+	for _, c := range m.Categories {
+		if !registry.IsKnownCategory(c) {
+			errs = append(errs, fmt.Errorf(
+				"categories: unknown category %q (valid: %s; languages and databases have their own keys)",
+				c, strings.Join(registry.KnownCategories(), ", "),
+			))
+		}
+	}
+	for _, a := range m.Apps {
+		if !registry.IsKnownApp(a) {
+			errs = append(errs, fmt.Errorf("apps: unknown app %q", a))
+		}
+	}
+	for _, s := range m.Skip {
+		if !registry.IsKnownCategory(s) && !registry.IsKnownApp(s) {
+			errs = append(errs, fmt.Errorf("skip: unknown category or app %q", s))
+		}
+	}
+	for _, spec := range m.Languages {
+		if _, err := languages.ResolveLanguage(spec); err != nil {
+			errs = append(errs, fmt.Errorf("languages: %w", err))
+		}
+	}
+	for _, name := range m.Databases {
+		if _, err := databases.ResolveDatabase(name); err != nil {
+			errs = append(errs, fmt.Errorf("databases: %w", err))
+		}
+	}
+	for _, font := range m.Fonts {
+		if constants.GetFontConfigByPackageName(font) == nil {
+			errs = append(errs, fmt.Errorf("fonts: unknown font %q", font))
+		}
+	}
+	if m.Theme != "" && !slices.Contains(knownThemes, m.Theme) {
+		errs = append(errs, fmt.Errorf(
+			"theme: unknown theme %q (valid: %s)", m.Theme, strings.Join(knownThemes, ", "),
+		))
+	}
+	return errors.Join(errs...)
+}
+
+// Targets returns the categories and apps to feed the install planner as its
+// --only set. Empty means the manifest requests no coordinator work at all —
+// unlike `dg install`, where no --only means "everything".
+func (m *Manifest) Targets() []string {
+	return append(slices.Clone(m.Categories), m.Apps...)
+}
+
+// LanguageConfigs resolves the manifest's language specs. Call on a
+// validated manifest; unresolvable entries are dropped.
+func (m *Manifest) LanguageConfigs() []languages.LanguageConfig {
+	var cfgs []languages.LanguageConfig
+	for _, spec := range m.Languages {
+		if cfg, err := languages.ResolveLanguage(spec); err == nil {
+			cfgs = append(cfgs, cfg)
+		}
+	}
+	return cfgs
+}
+
+// DatabaseConfigs resolves the manifest's databases. Call on a validated
+// manifest; unresolvable entries are dropped.
+func (m *Manifest) DatabaseConfigs() []databases.DatabaseConfig {
+	var cfgs []databases.DatabaseConfig
+	for _, name := range m.Databases {
+		if cfg, err := databases.ResolveDatabase(name); err == nil {
+			cfgs = append(cfgs, cfg)
+		}
+	}
+	return cfgs
+}
@@ -0,0 +1,103 @@
+package manifest
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestParse_Valid(t *testing.T) {
+	m, err := Parse([]byte(`
+categories: [terminal, ai-tools]
+apps: [alacritty]
+skip: [lazydocker, desktop]
+languages: [go, node@20, php]
+databases: [postgresql, Redis]
+fonts: [font-hack-nerd-font]
+theme: default
+`))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got := m.Targets(); strings.Join(got, ",") != "terminal,ai-tools,alacritty" {
+		t.Errorf("unexpected targets: %v", got)
+	}
+	langs := m.LanguageConfigs()
+	if len(langs) != 3 {
+		t.Fatalf("expected 3 language configs, got %d", len(langs))
+	}
+	if langs[1].Name != "node" || langs[1].Version != "20" {
+		t.Errorf("expected node pinned to 20, got %+v", langs[1])
+	}
+	dbs := m.DatabaseConfigs()
+	if len(dbs) != 2 || dbs[1].Name != "redis" {
+		t.Errorf("expected postgresql and redis, got %+v", dbs)
+	}
+}
+
+func TestParse_Empty(t *testing.T) {
+	m, err := Parse(nil)
+	if err != nil {
+		t.Fatalf("empty manifest should be valid, got: %v", err)
+	}
+	if len(m.Targets()) != 0 {
+		t.Errorf("expected no targets, got %v", m.Targets())
+	}
+}
+
+func TestParse_RejectsUnknownKeys(t *testing.T) {
+	if _, err := Parse([]byte("langauges: [go]\n")); err == nil {
+		t.Fatal("expected error for misspelled key")
+	}
+}
+
+func TestParse_ReportsEveryProblem(t *testing.T) {
+	_, err := Parse([]byte(`
+categories: [languages]
+apps: [devgita, notanapp]
+skip: [bogus]
+languages: [cobol, php@8]
+databases: [cassandra]
+fonts: [comic-sans]
+theme: neon
+`))
+	if err == nil {
+		t.Fatal("expected validation errors")
+	}
+	for _, want := range []string{
+		`unknown category "languages"`,
+		`unknown app "devgita"`,
+		`unknown app "notanapp"`,
+		`unknown category or app "bogus"`,
+		`unknown language "cobol"`,
+		"php is installed by the system package manager",
+		`unknown database "cassandra"`,
+		`unknown font "comic-sans"`,
+		`unknown theme "neon"`,
+	} {
+		if !strings.Contains(err.Error(), want) {
+			t.Errorf("expected error to mention %q, got:\n%v", want, err)
+		}
+	}
+}
+
+func TestLoad(t *testing.T) {
+	path := filepath.Join(t.TempDir(), DefaultFile)
+	if err := os.WriteFile(path, []byte("apps: [neovim]\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	m, err := Load(path)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(m.Apps) != 1 || m.Apps[0] != "neovim" {
+		t.Errorf("expected apps [neovim], got %v", m.Apps)
+	}
+}
+
+func TestLoad_MissingFile(t *testing.T) {
+	if _, err := Load(filepath.Join(t.TempDir(), "missing.yaml")); err == nil {
+		t.Fatal("expected error for missing manifest")
+	}
+}
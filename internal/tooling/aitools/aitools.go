@@ -27,7 +27,7 @@ type namedInstallable struct {
 
 type AITools struct {
 	Cmd  cmd.Command
-	Base cmd.BaseCommand
+	Base cmd.BaseCommandExecutor
 	// appsOverride replaces the default ai-tools app list when non-nil (used in tests).
 	appsOverride []namedInstallable
 }
@@ -35,7 +35,7 @@ type AITools struct {
 func New() *AITools {
 	osCmd := cmd.NewCommand()
 	baseCmd := cmd.NewBaseCommand()
-	return &AITools{Cmd: osCmd, Base: *baseCmd}
+	return &AITools{Cmd: osCmd, Base: baseCmd}
 }
 
 // defaultApps returns the production list of registry apps managed by this coordinator.
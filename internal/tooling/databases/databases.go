@@ -81,8 +81,9 @@ func (d *Databases) ChooseDatabases(ctx context.Context) (context.Context, error
 func (d *Databases) isDatabaseInstalledOnSystem(dbCfg DatabaseConfig) bool {
 	versionCmd, versionArgs := getVersionCommand(dbCfg.Name)
 	_, _, err := d.Base.ExecCommand(cmd.CommandParams{
-		Command: versionCmd,
-		Args:    versionArgs,
+		Command:  versionCmd,
+		Args:     versionArgs,
+		ReadOnly: true,
 	})
 	return err == nil
 }
@@ -129,6 +130,23 @@ func (d *Databases) InstallChosen(ctx context.Context) {
 	}
 }
 
+// InstallDatabases installs the given database configs without prompting,
+// skipping any already tracked by devgita or detected as pre-existing.
+func (d *Databases) InstallDatabases(dbCfgs []DatabaseConfig) {
+	gc := &config.GlobalConfig{}
+	if err := gc.Load(); err != nil {
+		logger.L().Warnw("Failed to load global config", "error", err)
+	}
+	for _, dbCfg := range dbCfgs {
+		if gc.IsInstalledByDevgita(dbCfg.Name, "database") ||
+			gc.IsAlreadyInstalled(dbCfg.Name, "database") {
+			utils.PrintInfo(fmt.Sprintf("%s already installed, skipping", dbCfg.DisplayName))
+			continue
+		}
+		d.installDatabase(dbCfg)
+	}
+}
+
 // installDatabase handles the installation and config tracking for a single database
 func (d *Databases) installDatabase(dbCfg DatabaseConfig) {
 	utils.PrintInfo(fmt.Sprintf("Installing %s (if not previously installed)...",
@@ -159,13 +177,16 @@ func (d *Databases) installNative(dbCfg DatabaseConfig) error {
 	return nil
 }
 
-// trackInstallation adds the database to GlobalConfig
+// trackInstallation adds the database to GlobalConfig, copying the method and
+// version recorded for the package that provided it
 func (d *Databases) trackInstallation(databaseSpec string) error {
 	gc := &config.GlobalConfig{}
 	if err := gc.Load(); err != nil {
 		return fmt.Errorf("failed to load global config: %w", err)
 	}
-	gc.AddToInstalled(databaseSpec, "database")
+	// The package manager install already recorded how the package got here.
+	pkg, _ := gc.Installed.Packages.Find(databaseSpec)
+	gc.RecordInstall(databaseSpec, "database", pkg.Method, pkg.Version)
 	if err := gc.Save(); err != nil {
 		return fmt.Errorf("failed to save global config: %w", err)
 	}
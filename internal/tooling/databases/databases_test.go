@@ -159,8 +159,8 @@ func TestGetInstalledDatabases(t *testing.T) {
 
 	// Create test global config
 	gc := &config.GlobalConfig{}
-	gc.Installed.Databases = []string{"redis", "postgresql"}
-	gc.AlreadyInstalled.Databases = []string{"mysql"}
+	gc.Installed.Databases = config.NewTrackedItems("redis", "postgresql")
+	gc.AlreadyInstalled.Databases = config.NewTrackedItems("mysql")
 
 	installed := d.getInstalledDatabases(gc)
 
@@ -247,6 +247,43 @@ func TestInstallChosen_WithSelections(t *testing.T) {
 	testutil.VerifyNoRealCommands(t, mockApp.Base)
 }
 
+func TestInstallDatabases_SkipsTracked(t *testing.T) {
+	tc := testutil.SetupCompleteTest(t)
+	defer tc.Cleanup()
+
+	gc := &config.GlobalConfig{}
+	if err := gc.Load(); err != nil {
+		t.Fatalf("Failed to load config: %v", err)
+	}
+	gc.AddToInstalled("redis", "database")
+	if err := gc.Save(); err != nil {
+		t.Fatalf("Failed to save config: %v", err)
+	}
+
+	mockApp := testutil.NewMockApp()
+	d := &Databases{Cmd: mockApp.Cmd, Base: mockApp.Base}
+	d.InstallDatabases([]DatabaseConfig{
+		{DisplayName: "Redis", Name: "redis"},
+		{DisplayName: "SQLite", Name: "sqlite"},
+	})
+
+	if len(mockApp.Cmd.MaybeInstalledPkgs) != 1 || mockApp.Cmd.MaybeInstalledPkgs[0] != "sqlite" {
+		t.Errorf("Expected only sqlite to be installed, got %v", mockApp.Cmd.MaybeInstalledPkgs)
+	}
+	testutil.VerifyNoRealCommands(t, mockApp.Base)
+}
+
+func TestResolveDatabase(t *testing.T) {
+	for _, name := range []string{"postgresql", "PostgreSQL", " redis "} {
+		if _, err := ResolveDatabase(name); err != nil {
+			t.Errorf("Expected %q to resolve, got %v", name, err)
+		}
+	}
+	if _, err := ResolveDatabase("cassandra"); err == nil {
+		t.Error("Expected error for unknown database")
+	}
+}
+
 func TestGetVersionCommand(t *testing.T) {
 	tests := []struct {
 		name         string
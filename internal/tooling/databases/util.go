@@ -1,6 +1,7 @@
 package databases
 
 import (
+	"fmt"
 	"strings"
 
 	"github.com/cjairm/devgita/pkg/constants"
@@ -59,6 +60,18 @@ func GetDatabaseConfigs() []DatabaseConfig {
 	}
 }
 
+// ResolveDatabase returns the DatabaseConfig whose Name or DisplayName matches
+// name (case-insensitive).
+func ResolveDatabase(name string) (DatabaseConfig, error) {
+	name = strings.TrimSpace(name)
+	for _, dbCfg := range GetDatabaseConfigs() {
+		if strings.EqualFold(name, dbCfg.Name) || strings.EqualFold(name, dbCfg.DisplayName) {
+			return dbCfg, nil
+		}
+	}
+	return DatabaseConfig{}, fmt.Errorf("unknown database %q", name)
+}
+
 // getVersionCommand returns the command and args to check if a database is installed
 func getVersionCommand(dbName string) (string, []string) {
 	switch dbName {
@@ -33,7 +33,9 @@ type namedInstaller struct {
 
 type Desktop struct {
 	Cmd  cmd.Command
-	Base cmd.BaseCommand
+	Base cmd.BaseCommandExecutor
+	// NonInteractive skips the macOS privacy instructions prompt (used by dg apply).
+	NonInteractive bool
 	// crossPlatformAppsOverride replaces the default cross-platform app list when non-nil (tests).
 	crossPlatformAppsOverride []namedInstaller
 	// launcherOverride replaces the platform-specific launcher (raycast/ulauncher) when non-nil (tests).
@@ -43,7 +45,7 @@ type Desktop struct {
 func New() *Desktop {
 	osCmd := cmd.NewCommand()
 	baseCmd := cmd.NewBaseCommand()
-	return &Desktop{Cmd: osCmd, Base: *baseCmd}
+	return &Desktop{Cmd: osCmd, Base: baseCmd}
 }
 
 func (d *Desktop) getCrossPlatformApps() []namedInstaller {
@@ -80,7 +82,7 @@ func (d *Desktop) InstallAndConfigure(appFilter, skipFilter map[string]bool) err
 	}
 
 	// Platform-specific window managers
-	if d.Base.Platform.IsMac() {
+	if d.Base.IsMac() {
 		if shouldInstallApp(constants.Aerospace, appFilter, skipFilter) {
 			err := d.InstallAerospace()
 			displayMessage(err, constants.Aerospace)
@@ -101,7 +103,7 @@ func (d *Desktop) InstallAndConfigure(appFilter, skipFilter map[string]bool) err
 
 	d.InstallDesktopAppsWithoutConfiguration(appFilter, skipFilter)
 
-	if d.Base.Platform.IsMac() {
+	if d.Base.IsMac() && !d.NonInteractive {
 		d.DisplayPrivacyInstructions()
 	}
 
@@ -127,7 +129,7 @@ func (d *Desktop) InstallDesktopAppsWithoutConfiguration(appFilter, skipFilter m
 				displayMessage(err, entry.name)
 			}
 		}
-	} else if d.Base.Platform.IsMac() {
+	} else if d.Base.IsMac() {
 		if shouldInstallApp(constants.Raycast, appFilter, skipFilter) {
 			r := raycast.New()
 			if err := r.SoftInstall(); err != nil {
@@ -36,7 +36,7 @@ func buildCrossPlatformOverride(names ...string) ([]namedInstaller, map[string]*
 func newTestDesktop(crossPlatformEntries []namedInstaller, launcherName string) (*Desktop, *mockSoftInstaller) {
 	launcherMock := &mockSoftInstaller{}
 	return &Desktop{
-		Base:                      *cmd.NewBaseCommand(),
+		Base:                      cmd.NewBaseCommand(),
 		crossPlatformAppsOverride: crossPlatformEntries,
 		launcherOverride:          &namedInstaller{name: launcherName, app: launcherMock},
 	}, launcherMock
@@ -91,8 +91,9 @@ func (dl *DevLanguages) ChooseLanguages(ctx context.Context) (context.Context, e
 func (dl *DevLanguages) isLanguageInstalledOnSystem(langCfg LanguageConfig) bool {
 	versionCmd, versionArgs := getVersionCommand(langCfg.Name)
 	_, _, err := dl.Base.ExecCommand(cmd.CommandParams{
-		Command: versionCmd,
-		Args:    versionArgs,
+		Command:  versionCmd,
+		Args:     versionArgs,
+		ReadOnly: true,
 	})
 	return err == nil
 }
@@ -140,6 +141,25 @@ func (dl *DevLanguages) InstallChosen(ctx context.Context) {
 	}
 }
 
+// InstallLanguages installs the given language configs without prompting.
+// Specs already tracked (by devgita or as pre-existing) are skipped, so
+// re-running a manifest through `dg apply` is a no-op for them.
+func (dl *DevLanguages) InstallLanguages(langCfgs []LanguageConfig) {
+	gc := &config.GlobalConfig{}
+	if err := gc.Load(); err != nil {
+		logger.L().Warnw("Failed to load global config", "error", err)
+	}
+	for _, langCfg := range langCfgs {
+		langSpec := formatSpec(langCfg.Name, langCfg.Version, langCfg.UseMise)
+		if gc.IsInstalledByDevgita(langSpec, "dev_language") ||
+			gc.IsAlreadyInstalled(langSpec, "dev_language") {
+			utils.PrintInfo(fmt.Sprintf("%s already installed, skipping", langSpec))
+			continue
+		}
+		dl.installLanguage(langCfg)
+	}
+}
+
 // installLanguage handles the installation and config tracking for a single language
 func (dl *DevLanguages) installLanguage(langCfg LanguageConfig) {
 	utils.PrintInfo(fmt.Sprintf("Installing %s (if not previously installed)...",
@@ -161,7 +181,7 @@ func (dl *DevLanguages) installLanguage(langCfg LanguageConfig) {
 	}
 	// Track successful installation in GlobalConfig
 	langSpec := formatSpec(langCfg.Name, langCfg.Version, langCfg.UseMise)
-	if err := dl.trackInstallation(langSpec); err != nil {
+	if err := dl.trackInstallation(langSpec, langCfg); err != nil {
 		logger.L().Warnw("Failed to track language installation",
 			"language", langSpec,
 			"error", err)
@@ -195,13 +215,20 @@ func (dl *DevLanguages) installNative(langCfg LanguageConfig) error {
 	return nil
 }
 
-// trackInstallation adds the language to GlobalConfig
-func (dl *DevLanguages) trackInstallation(languageSpec string) error {
+// trackInstallation adds the language to GlobalConfig, recording the version
+// mise was asked for (e.g. "lts") or, for native installs, the method and
+// version already recorded for the package that provided it.
+func (dl *DevLanguages) trackInstallation(languageSpec string, langCfg LanguageConfig) error {
 	gc := &config.GlobalConfig{}
 	if err := gc.Load(); err != nil {
 		return fmt.Errorf("failed to load global config: %w", err)
 	}
-	gc.AddToInstalled(languageSpec, "dev_language")
+	if langCfg.UseMise {
+		gc.RecordInstall(languageSpec, "dev_language", config.MethodMise, langCfg.Version)
+	} else {
+		pkg, _ := gc.Installed.Packages.Find(langCfg.Name)
+		gc.RecordInstall(languageSpec, "dev_language", pkg.Method, pkg.Version)
+	}
 	if err := gc.Save(); err != nil {
 		return fmt.Errorf("failed to save global config: %w", err)
 	}
@@ -179,8 +179,8 @@ func TestGetInstalledLanguages(t *testing.T) {
 
 	// Create test global config
 	gc := &config.GlobalConfig{}
-	gc.Installed.DevLanguages = []string{"node@lts", "python@latest"}
-	gc.AlreadyInstalled.DevLanguages = []string{"php"}
+	gc.Installed.DevLanguages = config.NewTrackedItems("node@lts", "python@latest")
+	gc.AlreadyInstalled.DevLanguages = config.NewTrackedItems("php")
 
 	installed := dl.getInstalledLanguages(gc)
 
@@ -212,7 +212,7 @@ func TestTrackInstallation(t *testing.T) {
 	dl := New()
 
 	// Track installation
-	err := dl.trackInstallation("node@lts")
+	err := dl.trackInstallation("node@lts", LanguageConfig{Name: "node", Version: "lts", UseMise: true})
 	if err != nil {
 		t.Fatalf("trackInstallation failed: %v", err)
 	}
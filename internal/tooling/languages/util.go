@@ -74,6 +74,34 @@ func GetLanguageConfigs() []LanguageConfig {
 	}
 }
 
+// ResolveLanguage maps a "name[@version]" spec (e.g. "node", "node@20") to its
+// LanguageConfig, matching Name or DisplayName case-insensitively. A version
+// overrides the config default; native languages have nothing to pin, so a
+// versioned spec for them is rejected.
+func ResolveLanguage(spec string) (LanguageConfig, error) {
+	name, version, hasVersion := strings.Cut(strings.TrimSpace(spec), "@")
+	for _, langCfg := range GetLanguageConfigs() {
+		if !strings.EqualFold(name, langCfg.Name) && !strings.EqualFold(name, langCfg.DisplayName) {
+			continue
+		}
+		if !hasVersion {
+			return langCfg, nil
+		}
+		if !langCfg.UseMise {
+			return LanguageConfig{}, fmt.Errorf(
+				"%s is installed by the system package manager and cannot be pinned to a version",
+				langCfg.Name,
+			)
+		}
+		if version == "" {
+			return LanguageConfig{}, fmt.Errorf("empty version in language spec %q", spec)
+		}
+		langCfg.Version = version
+		return langCfg, nil
+	}
+	return LanguageConfig{}, fmt.Errorf("unknown language %q", name)
+}
+
 // getVersionCommand returns the command and args to check if a language is installed
 func getVersionCommand(langName string) (string, []string) {
 	switch langName {
@@ -367,3 +367,43 @@ func TestGetVersionCommand_Unknown(t *testing.T) {
 		t.Errorf("Expected args ['--version'], got %v", args)
 	}
 }
+
+func TestResolveLanguage_DefaultVersion(t *testing.T) {
+	cfg, err := ResolveLanguage("node")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if cfg.Name != constants.Node || cfg.Version != "lts" || !cfg.UseMise {
+		t.Errorf("Expected node@lts via mise, got %+v", cfg)
+	}
+}
+
+func TestResolveLanguage_PinnedVersion(t *testing.T) {
+	cfg, err := ResolveLanguage("Python@3.12")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if cfg.Name != constants.Python || cfg.Version != "3.12" {
+		t.Errorf("Expected python@3.12, got %+v", cfg)
+	}
+	if got := formatSpec(cfg.Name, cfg.Version, cfg.UseMise); got != "python@3.12" {
+		t.Errorf("Expected spec 'python@3.12', got '%s'", got)
+	}
+}
+
+func TestResolveLanguage_NativeRejectsVersion(t *testing.T) {
+	if _, err := ResolveLanguage("php@8.3"); err == nil {
+		t.Error("Expected error pinning a native language")
+	}
+	if _, err := ResolveLanguage("php"); err != nil {
+		t.Errorf("Expected unversioned php to resolve, got %v", err)
+	}
+}
+
+func TestResolveLanguage_Invalid(t *testing.T) {
+	for _, spec := range []string{"cobol", "go@", ""} {
+		if _, err := ResolveLanguage(spec); err == nil {
+			t.Errorf("Expected error for spec %q", spec)
+		}
+	}
+}
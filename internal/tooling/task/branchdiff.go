@@ -44,10 +44,14 @@ func BranchDiffAt(g *git_app.Git, dir string) (BranchDiffResult, error) {
 	}
 	base := strings.TrimSpace(baseOut)
 	rangeLabel := defaultBranch + "..worktree"
+	ex, err := loadExclusions(g, dir)
+	if err != nil {
+		return BranchDiffResult{}, fmt.Errorf("branch-diff: %w", err)
+	}
 
 	args := append(
 		[]string{"-C", dir, "diff", "--color=always", base, "--", "."},
-		exclusionPathspecs()...,
+		ex.pathspecs()...,
 	)
 	diff, err := g.RunCapture(args...)
 	if err != nil {
@@ -62,7 +66,10 @@ func BranchDiffAt(g *git_app.Git, dir string) (BranchDiffResult, error) {
 	if err != nil {
 		return BranchDiffResult{}, fmt.Errorf("branch-diff: %w", err)
 	}
-	included, excluded := partitionExcluded(changes)
+	included, excluded, err := ex.partition(changes)
+	if err != nil {
+		return BranchDiffResult{}, fmt.Errorf("branch-diff: %w", err)
+	}
 
 	shortBase := base
 	if len(shortBase) > 7 {
@@ -110,8 +117,9 @@ func untrackedFiles(g *git_app.Git, dir string) []string {
 }
 
 // BranchDiff returns the merge-base diff against the default branch, with
-// lockfile-style noise excluded by default (see exclusions.go) and called
-// out in a trailing notes line so nothing is silently hidden.
+// lockfile-style noise, the repo's own review.yaml patterns, and files
+// .gitattributes marks generated or -diff excluded (see exclusions.go) and
+// called out in a trailing notes line so nothing is silently hidden.
 //
 // It does not fetch: review-scope is the orient-and-fetch step, and
 // branch-diff is follow-up retrieval within the same review session.
@@ -120,7 +128,14 @@ func untrackedFiles(g *git_app.Git, dir string) []string {
 //
 // file, when non-empty, bypasses exclusions and returns only that file's
 // diff — an explicit request wins over the default noise filter.
-func (tm *TaskManager) BranchDiff(file string) (string, error) {
+//
+// maxTokens, when non-zero, splits the diff into pages of about that many
+// tokens at file and hunk boundaries and returns page (0 = the first),
+// headed by navigation — see pageDiff.
+func (tm *TaskManager) BranchDiff(file string, maxTokens, page int) (string, error) {
+	if err := validateDiffPaging(maxTokens, page); err != nil {
+		return "", fmt.Errorf("branch-diff: %w", err)
+	}
 	defaultBranch := tm.Git.DefaultBranch()
 	base, err := tm.mergeBase(defaultBranch)
 	if err != nil {
@@ -129,15 +144,15 @@ func (tm *TaskManager) BranchDiff(file string) (string, error) {
 	rangeSpec := base + "...HEAD"
 
 	if file != "" {
-		return tm.branchDiffFile(rangeSpec, file)
+		return tm.branchDiffFile(rangeSpec, file, maxTokens, page)
 	}
-	return tm.branchDiffAll(rangeSpec)
+	return tm.branchDiffAll(rangeSpec, maxTokens, page)
 }
 
 // branchDiffFile returns file's diff over rangeSpec, without exclusions.
 // file is passed as its own argv element (never shell-interpolated), so it
 // needs no escaping.
-func (tm *TaskManager) branchDiffFile(rangeSpec, file string) (string, error) {
+func (tm *TaskManager) branchDiffFile(rangeSpec, file string, maxTokens, page int) (string, error) {
 	diff, err := tm.Git.RunCapture("diff", rangeSpec, "--", file)
 	if err != nil {
 		return "", fmt.Errorf("branch-diff: %w", err)
@@ -145,14 +160,24 @@ func (tm *TaskManager) branchDiffFile(rangeSpec, file string) (string, error) {
 	if strings.TrimSpace(diff) == "" {
 		return fmt.Sprintf("No changes for %s in %s.", file, rangeSpec), nil
 	}
-	return diff, nil
+	out, err := pageDiff(diff, maxTokens, page, "dg task branch-diff --file "+file, plainPage)
+	if err != nil {
+		return "", fmt.Errorf("branch-diff: %w", err)
+	}
+	return out, nil
 }
 
-// branchDiffAll returns the full diff over rangeSpec with the default
-// exclusion patterns applied in a single `git diff` invocation, plus a
-// trailing note for every excluded file that actually changed.
-func (tm *TaskManager) branchDiffAll(rangeSpec string) (string, error) {
-	args := append([]string{"diff", rangeSpec, "--", "."}, exclusionPathspecs()...)
+// branchDiffAll returns the full diff over rangeSpec with the repo's
+// exclusions applied in a single `git diff` invocation, plus a trailing
+// note for every excluded file that actually changed. When paged, the
+// notes ride along on every page: they're short, and they explain files
+// missing from the contents table.
+func (tm *TaskManager) branchDiffAll(rangeSpec string, maxTokens, page int) (string, error) {
+	ex, err := loadExclusions(tm.Git, "")
+	if err != nil {
+		return "", fmt.Errorf("branch-diff: %w", err)
+	}
+	args := append([]string{"diff", rangeSpec, "--", "."}, ex.pathspecs()...)
 	diff, err := tm.Git.RunCapture(args...)
 	if err != nil {
 		return "", fmt.Errorf("branch-diff: %w", err)
@@ -166,9 +191,20 @@ func (tm *TaskManager) branchDiffAll(rangeSpec string) (string, error) {
 	if err != nil {
 		return "", fmt.Errorf("branch-diff: %w", err)
 	}
-	_, excluded := partitionExcluded(changes)
+	_, excluded, err := ex.partition(changes)
+	if err != nil {
+		return "", fmt.Errorf("branch-diff: %w", err)
+	}
 
-	return formatBranchDiff(rangeSpec, diff, excluded), nil
+	out, err := pageDiff(diff, maxTokens, page, "dg task branch-diff",
+		func(header, diff string, _ int) string {
+			return withPageHeader(header, formatBranchDiff(rangeSpec, diff, excluded))
+		},
+	)
+	if err != nil {
+		return "", fmt.Errorf("branch-diff: %w", err)
+	}
+	return out, nil
 }
 
 // formatBranchDiff renders the diff payload plus an exclusion-notes line.
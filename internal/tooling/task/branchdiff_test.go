@@ -70,6 +70,7 @@ func TestBranchDiff(t *testing.T) {
 				nil,
 			), // symbolic-ref (default branch)
 			commands.ExecCommandResult("abc123\n", "", nil), // merge-base
+			commands.ExecCommandResult("/repo\n", "", nil),  // rev-parse --show-toplevel
 			commands.ExecCommandResult(
 				"diff --git a/x b/x\n+hi\n",
 				"",
@@ -80,9 +81,14 @@ func TestBranchDiff(t *testing.T) {
 				"",
 				nil,
 			), // numstat (unfiltered)
+			commands.ExecCommandResult(
+				"x\x00linguist-generated\x00unspecified\x00x\x00diff\x00unspecified\x00",
+				"",
+				nil,
+			), // check-attr
 		)
 
-		out, err := tm.BranchDiff("")
+		out, err := tm.BranchDiff("", 0, 0)
 		if err != nil {
 			t.Fatalf("unexpected error: %v", err)
 		}
@@ -91,14 +97,14 @@ func TestBranchDiff(t *testing.T) {
 		}
 		if !strings.Contains(
 			out,
-			"excluded (see `dg task branch-diff --file <path>` to inspect): go.sum (+40/-12)",
+			"excluded (see `dg task branch-diff --file <path>` to inspect): go.sum (+40/-12; default)",
 		) {
 			t.Fatalf("expected exclusion note, got: %q", out)
 		}
 
 		// The filtered diff call must be a single invocation carrying "--", ".",
 		// and the exclusion pathspecs — not one diff per pattern.
-		diffCall := gitBase.ExecCommandCalls[2]
+		diffCall := gitBase.ExecCommandCalls[3]
 		joined := strings.Join(diffCall.Args, " ")
 		if !strings.Contains(joined, "abc123...HEAD -- .") {
 			t.Fatalf("expected range and pathspec base, got: %v", diffCall.Args)
@@ -113,11 +119,12 @@ func TestBranchDiff(t *testing.T) {
 		gitBase.SetExecCommandResults(
 			commands.ExecCommandResult("origin/main\n", "", nil),
 			commands.ExecCommandResult("abc123\n", "", nil),
+			commands.ExecCommandResult("/repo\n", "", nil),
 			commands.ExecCommandResult("", "", nil),                 // filtered diff is empty
 			commands.ExecCommandResult("40\t12\tgo.sum\n", "", nil), // numstat shows only go.sum
 		)
 
-		out, err := tm.BranchDiff("")
+		out, err := tm.BranchDiff("", 0, 0)
 		if err != nil {
 			t.Fatalf("unexpected error: %v", err)
 		}
@@ -127,7 +134,7 @@ func TestBranchDiff(t *testing.T) {
 		) {
 			t.Fatalf("expected all-excluded sentinel, got: %q", out)
 		}
-		if !strings.Contains(out, "go.sum (+40/-12)") {
+		if !strings.Contains(out, "go.sum (+40/-12; default)") {
 			t.Fatalf("expected exclusion note, got: %q", out)
 		}
 	})
@@ -141,7 +148,7 @@ func TestBranchDiff(t *testing.T) {
 			commands.ExecCommandResult("", "", nil),
 		)
 
-		out, err := tm.BranchDiff("")
+		out, err := tm.BranchDiff("", 0, 0)
 		if err != nil {
 			t.Fatalf("unexpected error: %v", err)
 		}
@@ -158,7 +165,7 @@ func TestBranchDiff(t *testing.T) {
 			commands.ExecCommandResult("diff --git a/go.sum b/go.sum\n+entry\n", "", nil),
 		)
 
-		out, err := tm.BranchDiff("go.sum")
+		out, err := tm.BranchDiff("go.sum", 0, 0)
 		if err != nil {
 			t.Fatalf("unexpected error: %v", err)
 		}
@@ -180,7 +187,7 @@ func TestBranchDiff(t *testing.T) {
 			commands.ExecCommandResult("", "", nil),
 		)
 
-		out, err := tm.BranchDiff("unrelated.go")
+		out, err := tm.BranchDiff("unrelated.go", 0, 0)
 		if err != nil {
 			t.Fatalf("unexpected error: %v", err)
 		}
@@ -198,7 +205,7 @@ func TestBranchDiff(t *testing.T) {
 			commands.ExecCommandResult("", "", nil),
 		)
 
-		if _, err := tm.BranchDiff(""); err != nil {
+		if _, err := tm.BranchDiff("", 0, 0); err != nil {
 			t.Fatalf("unexpected error: %v", err)
 		}
 		for _, call := range gitBase.ExecCommandCalls {
@@ -207,6 +214,52 @@ func TestBranchDiff(t *testing.T) {
 			}
 		}
 	})
+
+	t.Run("--max-tokens pages the filtered diff and keeps the notes", func(t *testing.T) {
+		tm, gitBase, _ := newTaskSetup()
+		gitBase.SetExecCommandResults(
+			commands.ExecCommandResult("origin/main\n", "", nil),
+			commands.ExecCommandResult("abc123\n", "", nil),
+			commands.ExecCommandResult("/repo\n", "", nil),
+			commands.ExecCommandResult(pagingFixture, "", nil),
+			commands.ExecCommandResult(
+				"1\t1\tsmall.go\n2\t2\tbig.go\n-\t-\tlogo.png\n40\t12\tgo.sum\n",
+				"",
+				nil,
+			),
+			commands.ExecCommandResult("", "", nil), // check-attr
+		)
+
+		out, err := tm.BranchDiff("", 100, 3)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		want := "showing page 3 of 4; next: `dg task branch-diff --max-tokens 100 --page 4`\n\n" +
+			"diff --git a/big.go b/big.go\n"
+		if !strings.HasPrefix(out, want) {
+			t.Fatalf("expected the page position then big.go, got:\n%s", out)
+		}
+		if strings.Contains(out, "small.go") || strings.Contains(out, "logo.png") {
+			t.Fatalf("expected only page 3's file, got:\n%s", out)
+		}
+		if estimateTokens(out) > 100 {
+			t.Fatalf("expected the page, notes included, within budget, got ~%d", estimateTokens(out))
+		}
+		if !strings.HasSuffix(out, "go.sum (+40/-12; default)") {
+			t.Fatalf("expected the exclusion note on every page, got:\n%s", out)
+		}
+	})
+
+	t.Run("--page without --max-tokens fails before any git call", func(t *testing.T) {
+		tm, gitBase, _ := newTaskSetup()
+
+		if _, err := tm.BranchDiff("", 0, 2); err == nil {
+			t.Fatal("expected an error")
+		}
+		if got := gitBase.GetExecCommandCallCount(); got != 0 {
+			t.Fatalf("expected no git calls, got %d", got)
+		}
+	})
 }
 
 func TestBranchDiffAt(t *testing.T) {
@@ -219,8 +272,10 @@ func TestBranchDiffAt(t *testing.T) {
 				nil,
 			), // symbolic-ref (default branch)
 			commands.ExecCommandResult("abc123\n", "", nil),                        // merge-base
+			commands.ExecCommandResult("/tmp/wt\n", "", nil),                       // rev-parse --show-toplevel
 			commands.ExecCommandResult("diff --git a/x b/x\n+hi\n", "", nil),       // diff
 			commands.ExecCommandResult("5\t2\tmain.go\n40\t12\tgo.sum\n", "", nil), // numstat
+			commands.ExecCommandResult("", "", nil),                                // check-attr
 			commands.ExecCommandResult(
 				"?? notes.txt\n",
 				"",
@@ -236,7 +291,7 @@ func TestBranchDiffAt(t *testing.T) {
 		if !strings.Contains(res.Content, "diff --git a/x b/x") {
 			t.Errorf("expected diff content, got: %q", res.Content)
 		}
-		if !strings.Contains(res.Content, "go.sum (+40/-12)") {
+		if !strings.Contains(res.Content, "go.sum (+40/-12; default)") {
 			t.Errorf("expected exclusion note for go.sum, got: %q", res.Content)
 		}
 		if !strings.Contains(res.Content, "Untracked files:\n  notes.txt") {
@@ -270,7 +325,7 @@ func TestBranchDiffAt(t *testing.T) {
 
 		// The diff call must target the bare merge-base (working tree diff,
 		// committed + uncommitted), keep colors, and carry the exclusions.
-		diffCall := gitBase.ExecCommandCalls[2]
+		diffCall := gitBase.ExecCommandCalls[3]
 		joined := strings.Join(diffCall.Args, " ")
 		if !strings.Contains(joined, "diff --color=always abc123 -- .") {
 			t.Errorf("expected colored working-tree diff against merge-base, got %v", diffCall.Args)
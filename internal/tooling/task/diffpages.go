@@ -0,0 +1,299 @@
+package task
+
+import (
+	"fmt"
+	"strings"
+)
+
+// approxBytesPerToken is the ratio diff paging estimates token counts
+// with. Real tokenizers vary by model and content, so this is deliberately
+// a plain, deterministic stand-in — ~4 bytes per token is the usual rule of
+// thumb for code and English — and the same diff always pages the same way,
+// so a later --page K lands on exactly what page 1's contents promised.
+const approxBytesPerToken = 4
+
+// estimateTokens returns s's estimated token count, rounded up so any
+// non-empty text costs at least one token.
+func estimateTokens(s string) int {
+	return (len(s) + approxBytesPerToken - 1) / approxBytesPerToken
+}
+
+// diffFile is one file's section of a unified diff: the header (the
+// `diff --git` line through the line before the first hunk) and its hunks,
+// each kept with its trailing newlines so joining them gives back the
+// section byte for byte.
+type diffFile struct {
+	Path   string
+	Header string
+	Hunks  []string
+}
+
+// diffChunk is one unit of a paged diff: a whole file section or, for a
+// file too large for one page, a run of its hunks under a repeat of the
+// file's header, so every chunk still reads as a standalone diff. Part and
+// Parts are 1-based; Parts is 1 for a file that wasn't split.
+type diffChunk struct {
+	Path   string
+	Part   int
+	Parts  int
+	Text   string
+	Tokens int
+}
+
+// diffPage is one page of a paged diff; Tokens counts its chunks only,
+// not the frame printed around them.
+type diffPage struct {
+	Chunks []diffChunk
+	Tokens int
+}
+
+// pageRenderer renders one page of a paged diff the way the caller prints
+// it: header is the page's navigation ("" when the diff isn't paged), diff
+// its slice of the diff, and page its 1-based number (0 when unpaged).
+// Whatever it adds around diff — a preamble, exclusion notes, fences — is
+// counted against the page's budget along with the header.
+type pageRenderer func(header, diff string, page int) string
+
+// plainPage renders a page as just its header above its diff.
+func plainPage(header, diff string, _ int) string {
+	return withPageHeader(header, diff)
+}
+
+// validateDiffPaging checks --max-tokens/--page before any git work, so a
+// bad flag fails fast instead of after the diff has been fetched.
+func validateDiffPaging(maxTokens, page int) error {
+	switch {
+	case maxTokens < 0:
+		return fmt.Errorf("--max-tokens must be positive, got %d", maxTokens)
+	case page < 0:
+		return fmt.Errorf("--page must be positive, got %d", page)
+	case page > 0 && maxTokens == 0:
+		return fmt.Errorf("--page requires --max-tokens")
+	}
+	return nil
+}
+
+// splitDiffFiles splits unified diff output at its `diff --git` lines, and
+// each file's section at its `@@` hunk lines. Anything before the first
+// `diff --git` line (there is normally nothing) becomes a pathless section
+// of its own, so no text is dropped.
+func splitDiffFiles(diff string) []diffFile {
+	var files []diffFile
+	var cur *diffFile
+	for _, line := range strings.SplitAfter(diff, "\n") {
+		if line == "" {
+			continue
+		}
+		switch {
+		case strings.HasPrefix(line, "diff --git ") || cur == nil:
+			files = append(files, diffFile{Header: line})
+			cur = &files[len(files)-1]
+		case strings.HasPrefix(line, "@@"):
+			cur.Hunks = append(cur.Hunks, line)
+		case len(cur.Hunks) == 0:
+			cur.Header += line
+		default:
+			cur.Hunks[len(cur.Hunks)-1] += line
+		}
+	}
+	for i := range files {
+		files[i].Path = diffFilePath(files[i].Header)
+	}
+	return files
+}
+
+// diffFilePath names a file section by its header: the post-image path
+// from `+++ b/`, the pre-image path from `--- a/` for a deletion, and
+// otherwise (binary files and pure renames have no ---/+++ lines) the
+// `b/` side of the `diff --git` line — or, under a diff.noprefix-style
+// config with no a/ b/ to split on, that line's two paths as printed.
+func diffFilePath(header string) string {
+	var fromGitLine, fromOld string
+	for _, line := range strings.Split(header, "\n") {
+		switch {
+		case strings.HasPrefix(line, "+++ b/"):
+			return strings.TrimPrefix(line, "+++ b/")
+		case strings.HasPrefix(line, "--- a/"):
+			fromOld = strings.TrimPrefix(line, "--- a/")
+		case strings.HasPrefix(line, "diff --git "):
+			fromGitLine = strings.TrimPrefix(line, "diff --git ")
+			if i := strings.LastIndex(fromGitLine, " b/"); i >= 0 {
+				fromGitLine = fromGitLine[i+len(" b/"):]
+			}
+		}
+	}
+	if fromOld != "" {
+		return fromOld
+	}
+	return fromGitLine
+}
+
+// chunkDiffFile turns one file section into chunks of at most maxTokens:
+// the whole section when it fits, otherwise runs of consecutive hunks, each
+// under its own copy of the header. A single hunk over budget still gets a
+// chunk of its own — hunks are the smallest unit that reads on its own.
+func chunkDiffFile(f diffFile, maxTokens int) []diffChunk {
+	whole := f.Header + strings.Join(f.Hunks, "")
+	if estimateTokens(whole) <= maxTokens || len(f.Hunks) <= 1 {
+		return []diffChunk{{Path: f.Path, Part: 1, Parts: 1, Text: whole, Tokens: estimateTokens(whole)}}
+	}
+
+	var texts []string
+	text := f.Header
+	for _, hunk := range f.Hunks {
+		if text != f.Header && estimateTokens(text+hunk) > maxTokens {
+			texts = append(texts, text)
+			text = f.Header
+		}
+		text += hunk
+	}
+	texts = append(texts, text)
+
+	chunks := make([]diffChunk, len(texts))
+	for i, t := range texts {
+		chunks[i] = diffChunk{Path: f.Path, Part: i + 1, Parts: len(texts), Text: t, Tokens: estimateTokens(t)}
+	}
+	return chunks
+}
+
+// paginateDiff packs files' chunks, in diff order, into pages: page 1 has
+// maxTokens-firstFrame tokens of room for chunks and every later page
+// maxTokens-frame, the frames being what the caller prints around each
+// page's diff. A chunk that doesn't fit on the current page starts the next
+// one, so when page 1's frame leaves no room for the first chunk, page 1
+// holds the frame alone. Only a single oversized hunk makes a page exceed
+// the budget.
+func paginateDiff(files []diffFile, maxTokens, firstFrame, frame int) []diffPage {
+	var pages []diffPage
+	room := maxTokens - firstFrame
+	for _, f := range files {
+		for _, c := range chunkDiffFile(f, max(maxTokens-frame, 1)) {
+			switch {
+			case len(pages) == 0:
+				pages = append(pages, diffPage{})
+				if firstFrame > 0 && c.Tokens > room {
+					pages = append(pages, diffPage{})
+				}
+			case pages[len(pages)-1].Tokens+c.Tokens > room:
+				pages = append(pages, diffPage{})
+			}
+			if len(pages) > 1 {
+				room = maxTokens - frame
+			}
+			p := &pages[len(pages)-1]
+			p.Chunks = append(p.Chunks, c)
+			p.Tokens += c.Tokens
+		}
+	}
+	return pages
+}
+
+// frameTokens estimates what render prints around a page's diff, header
+// included. It measures bytes rather than tokens so that a page's size,
+// estimated as a whole, never exceeds its frame plus its chunks' sizes.
+func frameTokens(render pageRenderer, header string, page int) int {
+	const probe = "x\n"
+	n := max(len(render(header, probe, page))-len(probe), 0)
+	return (n + approxBytesPerToken - 1) / approxBytesPerToken
+}
+
+// maxDiffPages bounds how many pages files can take at any budget: one
+// per hunk (or per hunkless file) plus page 1 holding only its frame.
+func maxDiffPages(files []diffFile) int {
+	n := 1
+	for _, f := range files {
+		n += max(len(f.Hunks), 1)
+	}
+	return n
+}
+
+// pageDiff renders page (0 = 1) of diff paged at maxTokens through render.
+// The budget covers the whole rendered page, not just its diff: page 1's
+// frame — the contents table and whatever preamble render prints there — is
+// taken out of page 1's room, and every later page reserves room for its
+// position line. With maxTokens 0, or a diff that fits in a single page,
+// the diff is rendered whole with no header, so a budget only costs output
+// when it bites. command is the invocation the "next page" hint extends
+// with --page.
+func pageDiff(diff string, maxTokens, page int, command string, render pageRenderer) (string, error) {
+	if maxTokens == 0 {
+		return render("", diff, 0), nil
+	}
+	page = max(page, 1)
+	command = fmt.Sprintf("%s --max-tokens %d", command, maxTokens)
+	files := splitDiffFiles(diff)
+
+	// Later pages carry only a position line, sized here for the largest
+	// page number any packing could reach. Page 1's frame depends on the
+	// contents table, which depends on the packing, so it's grown until
+	// the packing stops changing the table's size.
+	n := maxDiffPages(files)
+	frame := frameTokens(render, formatDiffPagePosition(n, n+1, command), n)
+	firstFrame := frameTokens(render, "", 0)
+	pages := paginateDiff(files, maxTokens, firstFrame, frame)
+	for len(pages) > 1 {
+		t := frameTokens(render, formatDiffPageHeader(pages, 1, command), 1)
+		if t <= firstFrame {
+			break
+		}
+		firstFrame = t
+		pages = paginateDiff(files, maxTokens, firstFrame, frame)
+	}
+
+	if page > max(len(pages), 1) {
+		return "", fmt.Errorf(
+			"--page %d is out of range: the diff has %d page(s) at --max-tokens %d",
+			page, max(len(pages), 1), maxTokens,
+		)
+	}
+	if len(pages) <= 1 {
+		return render("", diff, 0), nil
+	}
+
+	var text strings.Builder
+	for _, c := range pages[page-1].Chunks {
+		text.WriteString(c.Text)
+	}
+	return render(formatDiffPageHeader(pages, page, command), text.String(), page), nil
+}
+
+// formatDiffPageHeader renders a paged diff's navigation, with no leading
+// or trailing newline: on page 1 a contents table (each page's estimated
+// size, then each file on it with its own size and, when split, which part
+// it is) and on every page a position line with the command for the next.
+func formatDiffPageHeader(pages []diffPage, page int, command string) string {
+	var b strings.Builder
+	if page == 1 {
+		fmt.Fprintf(&b, "diff pages (%d, ~tokens):\n", len(pages))
+		for i, p := range pages {
+			fmt.Fprintf(&b, "page %d  ~%d\n", i+1, p.Tokens)
+			for _, c := range p.Chunks {
+				part := ""
+				if c.Parts > 1 {
+					part = fmt.Sprintf(" [%d/%d]", c.Part, c.Parts)
+				}
+				fmt.Fprintf(&b, "  %s%s  ~%d\n", c.Path, part, c.Tokens)
+			}
+		}
+	}
+	b.WriteString(formatDiffPagePosition(page, len(pages), command))
+	return b.String()
+}
+
+// formatDiffPagePosition is a paged diff's position line: which page this
+// is and, unless it's the last, the command for the next.
+func formatDiffPagePosition(page, pages int, command string) string {
+	if page == pages {
+		return fmt.Sprintf("showing page %d of %d (last)", page, pages)
+	}
+	return fmt.Sprintf("showing page %d of %d; next: `%s --page %d`", page, pages, command, page+1)
+}
+
+// withPageHeader puts a paged diff's header above its body, separated by a
+// blank line; an empty header (an unpaged diff) leaves body untouched.
+func withPageHeader(header, body string) string {
+	if header == "" {
+		return body
+	}
+	return header + "\n\n" + body
+}
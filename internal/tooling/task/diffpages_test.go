@@ -0,0 +1,286 @@
+package task
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+)
+
+// pagingFixture is a three-file diff: a small modification, a file with two
+// hunks, and a binary file (no ---/+++ or @@ lines).
+var pagingFixture = strings.Join([]string{
+	"diff --git a/small.go b/small.go",
+	"index 1111111..2222222 100644",
+	"--- a/small.go",
+	"+++ b/small.go",
+	"@@ -1,3 +1,3 @@",
+	" package x",
+	"-var a = 1",
+	"+var a = 2",
+	"diff --git a/big.go b/big.go",
+	"index 3333333..4444444 100644",
+	"--- a/big.go",
+	"+++ b/big.go",
+	"@@ -10,4 +10,4 @@ func one() {",
+	" \tx := 1",
+	"-\ty := 2",
+	"+\ty := 3",
+	" \treturn x + y",
+	"@@ -40,4 +40,4 @@ func two() {",
+	" \tx := 1",
+	"-\ty := 4",
+	"+\ty := 5",
+	" \treturn x + y",
+	"diff --git a/logo.png b/logo.png",
+	"index 5555555..6666666 100644",
+	"Binary files a/logo.png and b/logo.png differ",
+	"",
+}, "\n")
+
+func TestEstimateTokens(t *testing.T) {
+	cases := map[string]int{"": 0, "a": 1, "abcd": 1, "abcde": 2, strings.Repeat("x", 400): 100}
+	for s, want := range cases {
+		if got := estimateTokens(s); got != want {
+			t.Errorf("estimateTokens(%d bytes) = %d, want %d", len(s), got, want)
+		}
+	}
+}
+
+func TestSplitDiffFiles(t *testing.T) {
+	files := splitDiffFiles(pagingFixture)
+	if len(files) != 3 {
+		t.Fatalf("expected 3 files, got %d", len(files))
+	}
+	var paths []string
+	var rejoined strings.Builder
+	for _, f := range files {
+		paths = append(paths, f.Path)
+		rejoined.WriteString(f.Header + strings.Join(f.Hunks, ""))
+	}
+	if strings.Join(paths, " ") != "small.go big.go logo.png" {
+		t.Errorf("unexpected paths %v", paths)
+	}
+	if len(files[1].Hunks) != 2 || !strings.HasPrefix(files[1].Hunks[1], "@@ -40,4") {
+		t.Errorf("expected big.go split into its two hunks, got %q", files[1].Hunks)
+	}
+	if rejoined.String() != pagingFixture {
+		t.Error("expected the sections to rejoin into the original diff byte for byte")
+	}
+}
+
+func TestDiffFilePath(t *testing.T) {
+	cases := []struct{ name, header, want string }{
+		{
+			"deleted file",
+			"diff --git a/old.go b/old.go\ndeleted file mode 100644\n--- a/old.go\n+++ /dev/null\n",
+			"old.go",
+		},
+		{
+			"pure rename",
+			"diff --git a/a.go b/b.go\nsimilarity index 100%\nrename from a.go\nrename to b.go\n",
+			"b.go",
+		},
+		{"no prefix", "diff --git x.go x.go\n--- x.go\n+++ x.go\n", "x.go x.go"},
+	}
+	for _, c := range cases {
+		if got := diffFilePath(c.header); got != c.want {
+			t.Errorf("%s: got %q, want %q", c.name, got, c.want)
+		}
+	}
+}
+
+func TestPaginateDiff(t *testing.T) {
+	t.Run("packs whole files while they fit", func(t *testing.T) {
+		pages := paginateDiff(splitDiffFiles(pagingFixture), 100, 0, 0)
+		if len(pages) != 2 || len(pages[0].Chunks) != 2 || len(pages[1].Chunks) != 1 {
+			t.Fatalf("expected small.go and big.go on page 1, logo.png on page 2, got %+v", pages)
+		}
+		if pages[0].Tokens != 36+58 || pages[1].Tokens != 28 {
+			t.Errorf("expected page sizes ~94 and ~28, got ~%d and ~%d", pages[0].Tokens, pages[1].Tokens)
+		}
+	})
+
+	t.Run("splits an oversized file at hunk boundaries", func(t *testing.T) {
+		pages := paginateDiff(splitDiffFiles(pagingFixture), 50, 0, 0)
+		var got []string
+		for i, p := range pages {
+			if p.Tokens > 50 {
+				t.Errorf("page %d is over budget: ~%d", i+1, p.Tokens)
+			}
+			for _, c := range p.Chunks {
+				got = append(got, fmt.Sprintf("%s/%d/%d", c.Path, c.Part, c.Parts))
+			}
+		}
+		want := "small.go/1/1 big.go/1/2 big.go/2/2 logo.png/1/1"
+		if strings.Join(got, " ") != want {
+			t.Fatalf("expected chunks %s, got %v", want, got)
+		}
+		for _, p := range pages {
+			for _, c := range p.Chunks {
+				if c.Path == "big.go" && !strings.HasPrefix(c.Text, "diff --git a/big.go b/big.go\n") {
+					t.Errorf("expected each big.go part to repeat its header, got %q", c.Text)
+				}
+			}
+		}
+	})
+
+	t.Run("a single hunk over budget gets a page of its own", func(t *testing.T) {
+		pages := paginateDiff(splitDiffFiles(pagingFixture), 10, 0, 0)
+		if len(pages) != 4 {
+			t.Fatalf("expected one page per chunk, got %d", len(pages))
+		}
+		if pages[0].Tokens <= 10 {
+			t.Errorf("expected the first page to exceed the tiny budget, got ~%d", pages[0].Tokens)
+		}
+	})
+
+	t.Run("empty diff has no pages", func(t *testing.T) {
+		if pages := paginateDiff(splitDiffFiles(""), 100, 0, 0); len(pages) != 0 {
+			t.Fatalf("expected no pages, got %+v", pages)
+		}
+	})
+}
+
+// withPreamble renders pages like plainPage, with preamble above the first
+// page (and above an unpaged diff), the way review-package prints its
+// commit list and stat table.
+func withPreamble(preamble string) pageRenderer {
+	return func(header, diff string, page int) string {
+		if page > 1 {
+			return plainPage(header, diff, page)
+		}
+		return preamble + plainPage(header, diff, page)
+	}
+}
+
+func TestPageDiff(t *testing.T) {
+	t.Run("no budget renders the diff whole", func(t *testing.T) {
+		out, err := pageDiff(pagingFixture, 0, 0, "dg task branch-diff", plainPage)
+		if err != nil || out != pagingFixture {
+			t.Fatalf("expected the diff unchanged, got %q, %v", out, err)
+		}
+	})
+
+	t.Run("a diff that fits one page is not paged", func(t *testing.T) {
+		out, err := pageDiff(pagingFixture, 1000, 1, "dg task branch-diff", plainPage)
+		if err != nil || out != pagingFixture {
+			t.Fatalf("expected the diff unchanged, got %q, %v", out, err)
+		}
+	})
+
+	t.Run("page 1 leads with the contents table", func(t *testing.T) {
+		out, err := pageDiff(pagingFixture, 100, 0, "dg task branch-diff", plainPage)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		want := "diff pages (3, ~tokens):\n" +
+			"page 1  ~36\n" +
+			"  small.go  ~36\n" +
+			"page 2  ~58\n" +
+			"  big.go  ~58\n" +
+			"page 3  ~28\n" +
+			"  logo.png  ~28\n" +
+			"showing page 1 of 3; next: `dg task branch-diff --max-tokens 100 --page 2`\n\n" +
+			"diff --git a/small.go b/small.go\n"
+		if !strings.HasPrefix(out, want) {
+			t.Fatalf("unexpected page 1:\n%s\n---want prefix---\n%s", out, want)
+		}
+		if strings.Contains(out, "big.go b/big.go") {
+			t.Errorf("expected page 1 to hold only small.go, got %q", out)
+		}
+	})
+
+	t.Run("later pages carry only their position", func(t *testing.T) {
+		out, err := pageDiff(pagingFixture, 100, 2, "dg task branch-diff", plainPage)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		want := "showing page 2 of 3; next: `dg task branch-diff --max-tokens 100 --page 3`\n\n" +
+			"diff --git a/big.go b/big.go\n"
+		if !strings.HasPrefix(out, want) || !strings.Contains(out, "@@ -40,4") || strings.Contains(out, "small.go") {
+			t.Fatalf("expected only big.go under the position line, got:\n%s", out)
+		}
+
+		out, err = pageDiff(pagingFixture, 100, 3, "dg task branch-diff", plainPage)
+		if err != nil || !strings.HasPrefix(out, "showing page 3 of 3 (last)\n\n") {
+			t.Fatalf("expected the last page's position line, got %q, %v", out, err)
+		}
+	})
+
+	t.Run("a preamble comes out of page 1's budget", func(t *testing.T) {
+		preamble := strings.Repeat("p", 120) + "\n"
+		out, err := pageDiff(pagingFixture, 100, 1, "dg task review-package a b", withPreamble(preamble))
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if !strings.HasPrefix(out, preamble+"diff pages (") || strings.Contains(out, "diff --git") {
+			t.Fatalf("expected page 1 to be the preamble and contents alone, got:\n%s", out)
+		}
+		out, err = pageDiff(pagingFixture, 100, 2, "dg task review-package a b", withPreamble(preamble))
+		if err != nil || !strings.Contains(out, "diff --git a/small.go") || strings.Contains(out, preamble) {
+			t.Fatalf("expected page 2 to start the diff without the preamble, got %q, %v", out, err)
+		}
+	})
+
+	t.Run("a preamble that alone overflows page 1 still pages the diff", func(t *testing.T) {
+		preamble := strings.Repeat("p", 500) + "\n"
+		out, err := pageDiff(pagingFixture, 100, 1, "dg task review-package a b", withPreamble(preamble))
+		if err != nil || strings.Contains(out, "diff --git") || !strings.Contains(out, "diff pages (4,") {
+			t.Fatalf("expected page 1 to hold no diff, got %q, %v", out, err)
+		}
+	})
+
+	t.Run("out-of-range page errors", func(t *testing.T) {
+		_, err := pageDiff(pagingFixture, 100, 4, "dg task branch-diff", plainPage)
+		if err == nil || !strings.Contains(err.Error(), "3 page(s)") {
+			t.Fatalf("expected an out-of-range error naming the page count, got %v", err)
+		}
+	})
+}
+
+func TestPageDiffPagesFitTheBudget(t *testing.T) {
+	renderers := map[string]pageRenderer{
+		"plain":          plainPage,
+		"short preamble": withPreamble(strings.Repeat("p", 80) + "\n"),
+		"long preamble":  withPreamble(strings.Repeat("p", 200) + "\n"),
+	}
+	for name, render := range renderers {
+		for maxTokens := 20; maxTokens <= 200; maxTokens += 5 {
+			for page := 1; ; page++ {
+				out, err := pageDiff(pagingFixture, maxTokens, page, "dg task branch-diff", render)
+				if err != nil {
+					break
+				}
+				if estimateTokens(out) <= maxTokens {
+					continue
+				}
+				// Only a page holding a single hunk (or hunkless file) too big
+				// for any page may run over; a page 1 that can't fit even its
+				// contents table at this budget is the other unavoidable case.
+				body := out[strings.Index(out, "\n\n")+2:]
+				oversized := strings.Count(body, "diff --git") == 1 && strings.Count(body, "\n@@ ") <= 1 &&
+					!strings.Contains(out, "diff pages (")
+				tableOnly := page == 1 && !strings.Contains(out, "diff --git")
+				if !oversized && !tableOnly {
+					t.Errorf("%s, --max-tokens %d, page %d: ~%d tokens:\n%s",
+						name, maxTokens, page, estimateTokens(out), out)
+				}
+			}
+		}
+	}
+}
+
+func TestValidateDiffPaging(t *testing.T) {
+	if err := validateDiffPaging(0, 0); err != nil {
+		t.Errorf("expected no paging to be valid, got %v", err)
+	}
+	if err := validateDiffPaging(4000, 2); err != nil {
+		t.Errorf("expected a budget and page to be valid, got %v", err)
+	}
+	if err := validateDiffPaging(0, 2); err == nil || !strings.Contains(err.Error(), "--max-tokens") {
+		t.Errorf("expected --page without --max-tokens to error, got %v", err)
+	}
+	if err := validateDiffPaging(-1, 0); err == nil {
+		t.Error("expected a negative budget to error")
+	}
+}
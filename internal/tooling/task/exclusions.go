@@ -1,15 +1,27 @@
 package task
 
-import "path/filepath"
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+
+	git_app "github.com/cjairm/devgita/internal/apps/git"
+)
 
 // defaultExclusionPatterns are lockfiles and generated/minified assets that
 // are unreviewable diff noise. Matched against a file's basename so they
 // exclude at any depth (e.g. packages/app/package-lock.json in a monorepo).
 // Deliberately non-exhaustive — Pipfile.lock, mix.lock, Podfile.lock,
-// packages.lock.json, etc. are absent by design; the --file bypass on
-// BranchDiff and raw `git diff` permissions cover the gaps. Shared by
+// packages.lock.json, etc. are absent by design; a repo adds its own in
+// .devgita/review.yaml (see loadExclusions), and the --file bypass on
+// BranchDiff and raw `git diff` permissions cover the rest. Shared by
 // ReviewScope (partitions an already-fetched file list) and BranchDiff
-// (excludes at the git-diff level via exclusionPathspecs).
+// (excludes at the git-diff level via exclusions.pathspecs).
 var defaultExclusionPatterns = []string{
 	"package-lock.json",
 	"yarn.lock",
@@ -25,24 +37,205 @@ var defaultExclusionPatterns = []string{
 	"*.min.css",
 }
 
-// isExcludedPath reports whether path matches one of the default exclusion
-// patterns, checked against the basename so nested paths are covered too.
-func isExcludedPath(path string) bool {
-	base := filepath.Base(path)
-	for _, pattern := range defaultExclusionPatterns {
-		if ok, _ := filepath.Match(pattern, base); ok {
-			return true
+// reviewConfigFileName is the per-repo review config's path under the repo
+// root.
+const reviewConfigFileName = ".devgita/review.yaml"
+
+// reviewConfig is the schema of a repo's .devgita/review.yaml. Exclude
+// patterns are added to defaultExclusionPatterns: one without a slash
+// matches a basename at any depth, like the defaults; one with a slash is a
+// glob relative to the repo root, where ** spans directories (e.g.
+// "api/gen/**").
+type reviewConfig struct {
+	Exclude []string `yaml:"exclude,omitempty"`
+}
+
+// Attribute-based exclusion rules, as named in exclusion notes. A file is
+// excluded when .gitattributes marks it linguist-generated (GitHub's own
+// "generated code" marker, which also collapses it in PR diffs) or -diff
+// (git won't render a textual diff for it; the binary macro sets this too).
+const (
+	generatedAttrRule = "linguist-generated"
+	noDiffAttrRule    = "-diff"
+)
+
+// exclusionPattern is one glob and the rule text an exclusion note shows
+// for files it matches.
+type exclusionPattern struct {
+	Glob string
+	Rule string
+}
+
+// exclusions is the exclusion rule set for one repository: the default
+// patterns, the repo's .devgita/review.yaml patterns, and its
+// .gitattributes (read by git per path, so nested .gitattributes files
+// count too).
+type exclusions struct {
+	git      *git_app.Git
+	root     string
+	patterns []exclusionPattern
+}
+
+// loadExclusions returns the exclusion rules for the repository at dir
+// ("" = current directory). A missing review.yaml just means the defaults;
+// one that exists but can't be read or parsed is an error.
+func loadExclusions(g *git_app.Git, dir string) (*exclusions, error) {
+	root, err := g.GetRepoRootIn(dir)
+	if err != nil {
+		return nil, err
+	}
+	ex := &exclusions{git: g, root: root}
+	for _, p := range defaultExclusionPatterns {
+		ex.patterns = append(ex.patterns, exclusionPattern{Glob: p, Rule: "default"})
+	}
+
+	configPath := filepath.Join(root, reviewConfigFileName)
+	data, err := os.ReadFile(configPath)
+	if errors.Is(err, os.ErrNotExist) {
+		return ex, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", configPath, err)
+	}
+	var cfg reviewConfig
+	if err := yaml.Unmarshal(data, &cfg); err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", configPath, err)
+	}
+	for _, p := range cfg.Exclude {
+		p = strings.TrimPrefix(strings.TrimSpace(p), "/")
+		if _, err := path.Match(p, ""); p == "" || err != nil {
+			return nil, fmt.Errorf("invalid exclude pattern %q in %s", p, configPath)
+		}
+		ex.patterns = append(ex.patterns, exclusionPattern{Glob: p, Rule: "review.yaml " + p})
+	}
+	return ex, nil
+}
+
+// patternRule returns the rule of the first pattern p matches, if any.
+func (ex *exclusions) patternRule(p string) (string, bool) {
+	for _, pattern := range ex.patterns {
+		if matchExclusionGlob(pattern.Glob, p) {
+			return pattern.Rule, true
+		}
+	}
+	return "", false
+}
+
+// matchExclusionGlob matches a repo-relative path against one exclusion
+// pattern: a slash-free pattern against the basename, otherwise segment by
+// segment against the whole path, where a ** segment matches any number of
+// directories — the same semantics git gives the pattern as a glob pathspec.
+func matchExclusionGlob(pattern, p string) bool {
+	if !strings.Contains(pattern, "/") {
+		ok, _ := path.Match(pattern, path.Base(p))
+		return ok
+	}
+	return matchSegments(strings.Split(pattern, "/"), strings.Split(p, "/"))
+}
+
+// matchSegments is matchExclusionGlob's recursive path matcher.
+func matchSegments(pattern, segments []string) bool {
+	if len(pattern) == 0 {
+		return len(segments) == 0
+	}
+	if pattern[0] == "**" {
+		for i := 0; i <= len(segments); i++ {
+			if matchSegments(pattern[1:], segments[i:]) {
+				return true
+			}
 		}
+		return false
+	}
+	if len(segments) == 0 {
+		return false
 	}
-	return false
+	if ok, _ := path.Match(pattern[0], segments[0]); !ok {
+		return false
+	}
+	return matchSegments(pattern[1:], segments[1:])
 }
 
-// exclusionPathspecs renders defaultExclusionPatterns as git pathspecs that
-// exclude matches at any depth, for use as extra `git diff` arguments.
-func exclusionPathspecs() []string {
-	pathspecs := make([]string, len(defaultExclusionPatterns))
-	for i, p := range defaultExclusionPatterns {
-		pathspecs[i] = ":(exclude,glob)**/" + p
+// attributeRules asks git which of paths .gitattributes marks
+// linguist-generated or -diff, returning each such path's rule. paths are
+// repo-relative, so check-attr runs from the repo root.
+func (ex *exclusions) attributeRules(paths []string) (map[string]string, error) {
+	rules := map[string]string{}
+	if len(paths) == 0 {
+		return rules, nil
+	}
+	args := []string{"check-attr", "-z", "linguist-generated", "diff", "--"}
+	if ex.root != "" {
+		args = append([]string{"-C", ex.root}, args...)
+	}
+	out, err := ex.git.RunCapture(append(args, paths...)...)
+	if err != nil {
+		return nil, err
+	}
+	// -z output is NUL-terminated <path> <attribute> <value> triples.
+	fields := strings.Split(out, "\x00")
+	for i := 0; i+2 < len(fields); i += 3 {
+		p, attr, value := fields[i], fields[i+1], fields[i+2]
+		switch {
+		case attr == "linguist-generated" && (value == "set" || value == "true"):
+			rules[p] = generatedAttrRule
+		case attr == "diff" && value == "unset":
+			if _, ok := rules[p]; !ok {
+				rules[p] = noDiffAttrRule
+			}
+		}
+	}
+	return rules, nil
+}
+
+// partition splits changes into reviewable and excluded, preserving each
+// list's relative order and recording on every excluded change the rule
+// that excluded it. Patterns are checked first; only the remaining paths
+// cost a check-attr call.
+func (ex *exclusions) partition(changes []fileChange) (reviewable, excluded []fileChange, err error) {
+	rules := make([]string, len(changes))
+	var unmatched []string
+	for i, c := range changes {
+		if rule, ok := ex.patternRule(c.Path); ok {
+			rules[i] = rule
+		} else {
+			unmatched = append(unmatched, c.Path)
+		}
+	}
+	attrRules, err := ex.attributeRules(unmatched)
+	if err != nil {
+		return nil, nil, err
+	}
+	for i, c := range changes {
+		if rules[i] == "" {
+			rules[i] = attrRules[c.Path]
+		}
+		if rules[i] == "" {
+			reviewable = append(reviewable, c)
+			continue
+		}
+		c.ExcludedBy = rules[i]
+		excluded = append(excluded, c)
+	}
+	return reviewable, excluded, nil
+}
+
+// pathspecs renders the rule set as git pathspecs for use as extra `git
+// diff` arguments: slash-free patterns exclude matches at any depth,
+// slash patterns are anchored at the repo root (top magic, so they hold
+// from a subdirectory too), and attr magic excludes what partition's
+// check-attr would.
+func (ex *exclusions) pathspecs() []string {
+	pathspecs := make([]string, 0, len(ex.patterns)+3)
+	for _, p := range ex.patterns {
+		if strings.Contains(p.Glob, "/") {
+			pathspecs = append(pathspecs, ":(exclude,glob,top)"+p.Glob)
+		} else {
+			pathspecs = append(pathspecs, ":(exclude,glob)**/"+p.Glob)
+		}
 	}
-	return pathspecs
+	return append(pathspecs,
+		":(exclude,attr:linguist-generated)",
+		":(exclude,attr:linguist-generated=true)",
+		":(exclude,attr:-diff)",
+	)
 }
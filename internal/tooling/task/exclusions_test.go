@@ -0,0 +1,153 @@
+package task
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// writeReviewConfig writes a .devgita/review.yaml under a fresh repo root.
+func writeReviewConfig(t *testing.T, content string) string {
+	t.Helper()
+	root := t.TempDir()
+	if err := os.MkdirAll(filepath.Join(root, ".devgita"), 0o755); err != nil {
+		t.Fatalf("mkdir: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(root, reviewConfigFileName), []byte(content), 0o644); err != nil {
+		t.Fatalf("write review.yaml: %v", err)
+	}
+	return root
+}
+
+func TestLoadExclusions(t *testing.T) {
+	t.Run("defaults only without a review.yaml", func(t *testing.T) {
+		tm, gitBase, _ := newTaskSetup()
+		gitBase.SetExecCommandResult(t.TempDir()+"\n", "", nil)
+
+		ex, err := loadExclusions(tm.Git, "")
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if len(ex.patterns) != len(defaultExclusionPatterns) {
+			t.Fatalf("expected only the defaults, got %+v", ex.patterns)
+		}
+		if args := strings.Join(gitBase.GetLastExecCommandCall().Args, " "); args != "rev-parse --show-toplevel" {
+			t.Fatalf("expected the repo root lookup, got %q", args)
+		}
+	})
+
+	t.Run("merges review.yaml patterns after the defaults", func(t *testing.T) {
+		root := writeReviewConfig(t, "exclude:\n  - \"*.pb.go\"\n  - /api/gen/**\n")
+		tm, gitBase, _ := newTaskSetup()
+		gitBase.SetExecCommandResult(root+"\n", "", nil)
+
+		ex, err := loadExclusions(tm.Git, "/tmp/wt")
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		extra := ex.patterns[len(defaultExclusionPatterns):]
+		if len(extra) != 2 || extra[0] != (exclusionPattern{"*.pb.go", "review.yaml *.pb.go"}) ||
+			extra[1] != (exclusionPattern{"api/gen/**", "review.yaml api/gen/**"}) {
+			t.Fatalf("unexpected repo patterns: %+v", extra)
+		}
+		if args := gitBase.GetLastExecCommandCall().Args; args[0] != "-C" || args[1] != "/tmp/wt" {
+			t.Fatalf("expected the root lookup to target dir, got %v", args)
+		}
+	})
+
+	t.Run("malformed review.yaml errors", func(t *testing.T) {
+		root := writeReviewConfig(t, "exclude: [unclosed\n")
+		tm, gitBase, _ := newTaskSetup()
+		gitBase.SetExecCommandResult(root+"\n", "", nil)
+
+		if _, err := loadExclusions(tm.Git, ""); err == nil || !strings.Contains(err.Error(), "review.yaml") {
+			t.Fatalf("expected a parse error naming the file, got %v", err)
+		}
+	})
+
+	t.Run("invalid pattern errors", func(t *testing.T) {
+		root := writeReviewConfig(t, "exclude:\n  - \"[\"\n")
+		tm, gitBase, _ := newTaskSetup()
+		gitBase.SetExecCommandResult(root+"\n", "", nil)
+
+		if _, err := loadExclusions(tm.Git, ""); err == nil || !strings.Contains(err.Error(), "invalid exclude pattern") {
+			t.Fatalf("expected an invalid pattern error, got %v", err)
+		}
+	})
+}
+
+func TestMatchExclusionGlob(t *testing.T) {
+	cases := []struct {
+		pattern, path string
+		want          bool
+	}{
+		{"go.sum", "go.sum", true},
+		{"go.sum", "tools/go.sum", true},
+		{"*.pb.go", "api/v1/user.pb.go", true},
+		{"*.pb.go", "api/v1/user.go", false},
+		{"api/gen/**", "api/gen/client.go", true},
+		{"api/gen/**", "api/gen/v1/client.go", true},
+		{"api/gen/**", "web/api/gen/client.go", false},
+		{"**/testdata/*.golden", "testdata/a.golden", true},
+		{"**/testdata/*.golden", "pkg/x/testdata/a.golden", true},
+		{"docs/*.md", "docs/guides/a.md", false},
+	}
+	for _, c := range cases {
+		if got := matchExclusionGlob(c.pattern, c.path); got != c.want {
+			t.Errorf("matchExclusionGlob(%q, %q) = %v, want %v", c.pattern, c.path, got, c.want)
+		}
+	}
+}
+
+func TestExclusionAttributeRules(t *testing.T) {
+	tm, gitBase, _ := newTaskSetup()
+	gitBase.SetExecCommandResult(
+		"gen/x.go\x00linguist-generated\x00set\x00gen/x.go\x00diff\x00unspecified\x00"+
+			"y.pb.go\x00linguist-generated\x00true\x00y.pb.go\x00diff\x00unset\x00"+
+			"z.snap\x00linguist-generated\x00unspecified\x00z.snap\x00diff\x00unset\x00"+
+			"keep.go\x00linguist-generated\x00false\x00keep.go\x00diff\x00unspecified\x00",
+		"",
+		nil,
+	)
+	ex := &exclusions{git: tm.Git, root: "/repo"}
+
+	rules, err := ex.attributeRules([]string{"gen/x.go", "y.pb.go", "z.snap", "keep.go"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want := map[string]string{
+		"gen/x.go": generatedAttrRule,
+		"y.pb.go":  generatedAttrRule, // linguist-generated wins over -diff
+		"z.snap":   noDiffAttrRule,
+	}
+	if len(rules) != len(want) {
+		t.Fatalf("expected %v, got %v", want, rules)
+	}
+	for p, rule := range want {
+		if rules[p] != rule {
+			t.Errorf("%s: expected %q, got %q", p, rule, rules[p])
+		}
+	}
+	args := strings.Join(gitBase.GetLastExecCommandCall().Args, " ")
+	if args != "-C /repo check-attr -z linguist-generated diff -- gen/x.go y.pb.go z.snap keep.go" {
+		t.Errorf("unexpected check-attr call %q", args)
+	}
+}
+
+func TestExclusionPathspecs(t *testing.T) {
+	ex := &exclusions{patterns: []exclusionPattern{
+		{Glob: "go.sum", Rule: "default"},
+		{Glob: "api/gen/**", Rule: "review.yaml api/gen/**"},
+	}}
+	want := []string{
+		":(exclude,glob)**/go.sum",
+		":(exclude,glob,top)api/gen/**",
+		":(exclude,attr:linguist-generated)",
+		":(exclude,attr:linguist-generated=true)",
+		":(exclude,attr:-diff)",
+	}
+	if got := ex.pathspecs(); strings.Join(got, " ") != strings.Join(want, " ") {
+		t.Fatalf("expected %v, got %v", want, got)
+	}
+}
@@ -0,0 +1,83 @@
+package task
+
+import (
+	"net/url"
+	"os"
+	"strings"
+
+	git_app "github.com/cjairm/devgita/internal/apps/git"
+	"github.com/cjairm/devgita/internal/tooling/terminal/dev_tools/githubcli"
+	"github.com/cjairm/devgita/internal/tooling/terminal/dev_tools/gitlabcli"
+)
+
+// Forge is the code host behind the PR subcommands: GitHub through gh
+// (githubcli.GithubCli) or GitLab through glab (gitlabcli.GitlabCli), which
+// says "merge request" where GitHub says "pull request". Fetches return
+// GitHub-shaped JSON whichever forge answers them, so PRManager's
+// formatters (pr_format.go), and the markdown agents are prompted with,
+// don't change.
+type Forge interface {
+	CurrentRepo() (string, error)
+	CurrentPRNumber() (string, error)
+	FetchReviewThreads(owner, repo, prNumber string) (string, error)
+	FetchPRDiscussion(owner, repo, prNumber string) (string, error)
+	ResolveReviewThread(threadID string) (string, error)
+	UnresolveReviewThread(threadID string) (string, error)
+	ReplyToReviewThread(threadID, body string) (string, error)
+	CreateReview(owner, repo, prNumber, payloadJSON string) (string, error)
+	CreatePR(title, body, base string) (string, error)
+	UpdatePRDescription(prNumber, body string) error
+	ApprovePR(prNumber, body string) error
+	RequestChangesPR(prNumber, body string) error
+	RequestReviewPR(prNumber string, reviewers []string) error
+	CommentPR(prNumber, body string) error
+	MergePR(prNumber, method string) error
+	PRView(prNumber string) (string, error)
+	PRChecks(prNumber string) (string, error)
+	PRHeadSHA(prNumber string) (string, error)
+	RunFailedJobLog(jobID string) (string, error)
+}
+
+// DetectForge picks the forge for the current repository from its origin
+// remote: GitLab when the host is gitlab.com, mentions "gitlab", or is glab's
+// configured self-hosted instance ($GITLAB_HOST); GitHub otherwise, including
+// when there is no origin.
+func DetectForge(g *git_app.Git) Forge {
+	remote, err := g.RunCapture("remote", "get-url", "origin")
+	if err == nil && isGitLabRemote(strings.TrimSpace(remote), os.Getenv("GITLAB_HOST")) {
+		return gitlabcli.New()
+	}
+	return githubcli.New()
+}
+
+// isGitLabRemote reports whether a remote URL points at GitLab; gitlabHost
+// is a self-hosted instance's host (or URL) as glab takes it.
+func isGitLabRemote(remote, gitlabHost string) bool {
+	host := remoteHost(remote)
+	if host == "" {
+		return false
+	}
+	if strings.Contains(host, "gitlab") {
+		return true
+	}
+	return gitlabHost != "" && host == remoteHost(gitlabHost)
+}
+
+// remoteHost extracts the lower-cased host from a remote URL in any of
+// git's forms: scheme://[user@]host[:port]/path, scp-like [user@]host:path,
+// or a bare host.
+func remoteHost(remote string) string {
+	if strings.Contains(remote, "://") {
+		u, err := url.Parse(remote)
+		if err != nil {
+			return ""
+		}
+		return strings.ToLower(u.Hostname())
+	}
+	host, _, _ := strings.Cut(remote, ":")
+	if _, after, ok := strings.Cut(host, "@"); ok {
+		host = after
+	}
+	host, _, _ = strings.Cut(host, "/")
+	return strings.ToLower(host)
+}
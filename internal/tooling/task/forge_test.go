@@ -0,0 +1,88 @@
+package task
+
+import (
+	"testing"
+
+	git_app "github.com/cjairm/devgita/internal/apps/git"
+	"github.com/cjairm/devgita/internal/commands"
+	"github.com/cjairm/devgita/internal/tooling/terminal/dev_tools/githubcli"
+	"github.com/cjairm/devgita/internal/tooling/terminal/dev_tools/gitlabcli"
+)
+
+func TestIsGitLabRemote(t *testing.T) {
+	cases := []struct {
+		remote     string
+		gitlabHost string
+		want       bool
+	}{
+		{"git@github.com:octocat/hello.git", "", false},
+		{"https://github.com/octocat/hello.git", "", false},
+		{"git@gitlab.com:group/sub/app.git", "", true},
+		{"https://gitlab.example.com/group/app.git", "", true},
+		{"ssh://git@gitlab.example.com:2222/group/app.git", "", true},
+		{"git@git.corp.io:team/app.git", "", false},
+		{"git@git.corp.io:team/app.git", "git.corp.io", true},
+		{"https://git.corp.io/team/app.git", "https://git.corp.io", true},
+		{"", "", false},
+	}
+	for _, c := range cases {
+		if got := isGitLabRemote(c.remote, c.gitlabHost); got != c.want {
+			t.Errorf("isGitLabRemote(%q, %q) = %v, want %v", c.remote, c.gitlabHost, got, c.want)
+		}
+	}
+}
+
+func TestDetectForge(t *testing.T) {
+	t.Setenv("GITLAB_HOST", "")
+	detect := func(remote string, err error) Forge {
+		base := commands.NewMockBaseCommand()
+		base.SetExecCommandResult(remote, "", err)
+		return DetectForge(&git_app.Git{Cmd: commands.NewMockCommand(), Base: base})
+	}
+	if _, ok := detect("git@gitlab.com:group/app.git\n", nil).(*gitlabcli.GitlabCli); !ok {
+		t.Error("expected a gitlab.com origin to pick GitLab")
+	}
+	if _, ok := detect("git@github.com:octocat/hello.git\n", nil).(*githubcli.GithubCli); !ok {
+		t.Error("expected a github.com origin to pick GitHub")
+	}
+	if _, ok := detect("", commandsErr()).(*githubcli.GithubCli); !ok {
+		t.Error("expected no origin to fall back to GitHub")
+	}
+}
+
+// TestGitLabReviewThreadsRenderLikeGitHub runs GitLab discussions through
+// the GitHub formatters to prove agents get the same markdown layout from
+// either forge.
+func TestGitLabReviewThreadsRenderLikeGitHub(t *testing.T) {
+	glabBase := commands.NewMockBaseCommand()
+	glabBase.SetExecCommandResults(
+		commands.ExecCommandResult(`{"path_with_namespace":"group/app"}`, "", nil),
+		commands.ExecCommandResult(`[
+			{"id":"d1","notes":[
+				{"id":101,"body":"rename this","created_at":"2026-10-01T10:00:00Z",
+				 "author":{"username":"alice"},"resolved":false,
+				 "position":{"new_path":"main.go","new_line":12}},
+				{"id":102,"body":"done","created_at":"2026-10-01T11:00:00Z",
+				 "author":{"username":"bob"}}]},
+			{"id":"d2","individual_note":true,"notes":[
+				{"id":103,"body":"added 1 commit","system":true,"author":{"username":"bob"}}]},
+			{"id":"d3","individual_note":true,"notes":[
+				{"id":104,"body":"looks good overall","created_at":"2026-10-02T09:00:00Z",
+				 "author":{"username":"carol"}}]}
+		]`, "", nil),
+	)
+	pm := &PRManager{Forge: &gitlabcli.GitlabCli{Base: glabBase}}
+
+	out, err := pm.ReviewThreads("7", "unresolved")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want := "## main.go:12 (thread 7:d1)\n\n" +
+		"**alice** (101, 2026-10-01T10:00:00Z): rename this\n\n" +
+		"**bob** (102, 2026-10-01T11:00:00Z): done\n\n---\n\n" +
+		"## Conversation\n\n" +
+		"**carol** (2026-10-02T09:00:00Z): looks good overall"
+	if out != want {
+		t.Errorf("unexpected output:\n%s\nwant:\n%s", out, want)
+	}
+}
@@ -2,28 +2,34 @@ package task
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
 	"strings"
+	"time"
 
-	"github.com/cjairm/devgita/internal/tooling/terminal/dev_tools/githubcli"
-	"github.com/cjairm/devgita/internal/tooling/terminal/dev_tools/jq"
+	git_app "github.com/cjairm/devgita/internal/apps/git"
+	"github.com/cjairm/devgita/internal/tooling/terminal/dev_tools/gitlabcli"
 )
 
-// PRManager wires the githubcli primitives to the jq formatters so the dg task
-// PR subcommands return compact, agent-friendly output. gh fetches/acts; jq
-// renders. Methods return the text to print (markdown, a URL, or a one-line
-// confirmation) plus an error.
+// PRManager wires the forge primitives to the formatters in pr_format.go so
+// the dg task PR subcommands return compact, agent-friendly output. The
+// forge (GitHub or GitLab) fetches/acts; the formatters render. Methods
+// return the text to print (markdown, a URL, or a one-line confirmation)
+// plus an error.
 type PRManager struct {
-	Gh *githubcli.GithubCli
-	Jq *jq.Jq
+	Forge Forge
+
+	now   func() time.Time    // time.Now when nil; stubbed in tests
+	sleep func(time.Duration) // time.Sleep when nil; stubbed in tests
 }
 
-// NewPR creates a PRManager with real executors.
+// NewPR creates a PRManager with real executors, on the forge the current
+// repository's origin remote points at (see DetectForge).
 func NewPR() *PRManager {
-	return &PRManager{Gh: githubcli.New(), Jq: jq.New()}
+	return &PRManager{Forge: DetectForge(git_app.New())}
 }
 
-// resolvedPtrForState maps a --state value to the *bool the jq filter expects:
+// resolvedPtrForState maps a --state value to the *bool formatReviewThreads expects:
 // "resolved" → &true, "unresolved"/"" → &false, "all" → nil.
 func resolvedPtrForState(state string) (*bool, error) {
 	t, f := true, false
@@ -42,7 +48,7 @@ func resolvedPtrForState(state string) (*bool, error) {
 // resolveOwnerRepoPR fills in owner/name from the current repo and, when
 // prNumber is empty, the PR number for the current branch.
 func (p *PRManager) resolveOwnerRepoPR(prNumber string) (owner, name, pr string, err error) {
-	repo, err := p.Gh.CurrentRepo()
+	repo, err := p.Forge.CurrentRepo()
 	if err != nil {
 		return "", "", "", err
 	}
@@ -53,7 +59,7 @@ func (p *PRManager) resolveOwnerRepoPR(prNumber string) (owner, name, pr string,
 
 	pr = prNumber
 	if pr == "" {
-		pr, err = p.Gh.CurrentPRNumber()
+		pr, err = p.Forge.CurrentPRNumber()
 		if err != nil {
 			return "", "", "", err
 		}
@@ -80,20 +86,20 @@ func (p *PRManager) ReviewThreads(prNumber, state string) (string, error) {
 		return "", err
 	}
 
-	rawThreads, err := p.Gh.FetchReviewThreads(owner, name, pr)
+	rawThreads, err := p.Forge.FetchReviewThreads(owner, name, pr)
 	if err != nil {
 		return "", err
 	}
-	threads, err := p.Jq.FormatReviewThreads(rawThreads, resolved)
+	threads, err := formatReviewThreads(rawThreads, resolved)
 	if err != nil {
 		return "", err
 	}
 
-	rawDiscussion, err := p.Gh.FetchPRDiscussion(owner, name, pr)
+	rawDiscussion, err := p.Forge.FetchPRDiscussion(owner, name, pr)
 	if err != nil {
 		return "", err
 	}
-	discussion, err := p.Jq.FormatPRDiscussion(rawDiscussion)
+	discussion, err := formatPRDiscussion(rawDiscussion)
 	if err != nil {
 		return "", err
 	}
@@ -113,7 +119,7 @@ func (p *PRManager) ReviewThreads(prNumber, state string) (string, error) {
 
 // ResolveThread marks a review thread resolved.
 func (p *PRManager) ResolveThread(threadID string) (string, error) {
-	if _, err := p.Gh.ResolveReviewThread(threadID); err != nil {
+	if _, err := p.Forge.ResolveReviewThread(threadID); err != nil {
 		return "", err
 	}
 	return fmt.Sprintf("Resolved thread %s", threadID), nil
@@ -121,7 +127,7 @@ func (p *PRManager) ResolveThread(threadID string) (string, error) {
 
 // UnresolveThread reopens a resolved review thread.
 func (p *PRManager) UnresolveThread(threadID string) (string, error) {
-	if _, err := p.Gh.UnresolveReviewThread(threadID); err != nil {
+	if _, err := p.Forge.UnresolveReviewThread(threadID); err != nil {
 		return "", err
 	}
 	return fmt.Sprintf("Reopened thread %s", threadID), nil
@@ -129,7 +135,7 @@ func (p *PRManager) UnresolveThread(threadID string) (string, error) {
 
 // ReplyThread posts a reply on a review thread.
 func (p *PRManager) ReplyThread(threadID, body string) (string, error) {
-	if _, err := p.Gh.ReplyToReviewThread(threadID, body); err != nil {
+	if _, err := p.Forge.ReplyToReviewThread(threadID, body); err != nil {
 		return "", err
 	}
 	return fmt.Sprintf("Replied to thread %s", threadID), nil
@@ -194,7 +200,7 @@ func (p *PRManager) SubmitReview(prNumber, verdict, body, commentsJSON string) (
 	if err != nil {
 		return "", err
 	}
-	// Build (and validate) the payload before any gh call, so a malformed
+	// Build (and validate) the payload before any forge call, so a malformed
 	// request fails fast without touching the network.
 	payload, err := buildReviewPayload(event, body, commentsJSON)
 	if err != nil {
@@ -204,7 +210,13 @@ func (p *PRManager) SubmitReview(prNumber, verdict, body, commentsJSON string) (
 	if err != nil {
 		return "", err
 	}
-	if _, err := p.Gh.CreateReview(owner, name, pr, payload); err != nil {
+	_, err = p.Forge.CreateReview(owner, name, pr, payload)
+	if errors.Is(err, gitlabcli.ErrNoRequestChanges) {
+		return fmt.Sprintf(
+			"Submitted comment review on %s (%s)", prLabel(prNumber), noRequestChangesNote,
+		), nil
+	}
+	if err != nil {
 		return "", err
 	}
 	return fmt.Sprintf("Submitted %s review on %s", verdictLabel(event), prLabel(prNumber)), nil
@@ -224,12 +236,12 @@ func verdictLabel(event string) string {
 
 // CreatePR opens a PR and returns its URL.
 func (p *PRManager) CreatePR(title, body, base string) (string, error) {
-	return p.Gh.CreatePR(title, body, base)
+	return p.Forge.CreatePR(title, body, base)
 }
 
 // UpdatePRDescription replaces a PR's body.
 func (p *PRManager) UpdatePRDescription(prNumber, body string) (string, error) {
-	if err := p.Gh.UpdatePRDescription(prNumber, body); err != nil {
+	if err := p.Forge.UpdatePRDescription(prNumber, body); err != nil {
 		return "", err
 	}
 	return "Updated PR description for " + prLabel(prNumber), nil
@@ -237,15 +249,24 @@ func (p *PRManager) UpdatePRDescription(prNumber, body string) (string, error) {
 
 // ApprovePR approves a PR.
 func (p *PRManager) ApprovePR(prNumber, body string) (string, error) {
-	if err := p.Gh.ApprovePR(prNumber, body); err != nil {
+	if err := p.Forge.ApprovePR(prNumber, body); err != nil {
 		return "", err
 	}
 	return "Approved " + prLabel(prNumber), nil
 }
 
-// RequestChangesPR requests changes on a PR.
+// noRequestChangesNote qualifies a request-changes that a forge without
+// that verdict (GitLab) could only post as a comment.
+const noRequestChangesNote = "GitLab has no request-changes verdict"
+
+// RequestChangesPR requests changes on a PR. On GitLab only the feedback is
+// posted, and the message says so rather than claim a blocking verdict.
 func (p *PRManager) RequestChangesPR(prNumber, body string) (string, error) {
-	if err := p.Gh.RequestChangesPR(prNumber, body); err != nil {
+	err := p.Forge.RequestChangesPR(prNumber, body)
+	if errors.Is(err, gitlabcli.ErrNoRequestChanges) {
+		return fmt.Sprintf("Commented on %s (%s)", prLabel(prNumber), noRequestChangesNote), nil
+	}
+	if err != nil {
 		return "", err
 	}
 	return "Requested changes on " + prLabel(prNumber), nil
@@ -254,7 +275,7 @@ func (p *PRManager) RequestChangesPR(prNumber, body string) (string, error) {
 // RequestReviewPR re-requests review from the given reviewers by adding them
 // back to the PR's requested-reviewers list.
 func (p *PRManager) RequestReviewPR(prNumber string, reviewers []string) (string, error) {
-	if err := p.Gh.RequestReviewPR(prNumber, reviewers); err != nil {
+	if err := p.Forge.RequestReviewPR(prNumber, reviewers); err != nil {
 		return "", err
 	}
 	return fmt.Sprintf(
@@ -266,7 +287,7 @@ func (p *PRManager) RequestReviewPR(prNumber string, reviewers []string) (string
 
 // CommentPR posts a top-level comment on a PR.
 func (p *PRManager) CommentPR(prNumber, body string) (string, error) {
-	if err := p.Gh.CommentPR(prNumber, body); err != nil {
+	if err := p.Forge.CommentPR(prNumber, body); err != nil {
 		return "", err
 	}
 	return "Commented on " + prLabel(prNumber), nil
@@ -274,7 +295,7 @@ func (p *PRManager) CommentPR(prNumber, body string) (string, error) {
 
 // MergePR merges a PR with the given strategy.
 func (p *PRManager) MergePR(prNumber, method string) (string, error) {
-	if err := p.Gh.MergePR(prNumber, method); err != nil {
+	if err := p.Forge.MergePR(prNumber, method); err != nil {
 		return "", err
 	}
 	return "Merged " + prLabel(prNumber), nil
@@ -282,11 +303,11 @@ func (p *PRManager) MergePR(prNumber, method string) (string, error) {
 
 // PRView returns a compact summary of a PR's metadata.
 func (p *PRManager) PRView(prNumber string) (string, error) {
-	raw, err := p.Gh.PRView(prNumber)
+	raw, err := p.Forge.PRView(prNumber)
 	if err != nil {
 		return "", err
 	}
-	return p.Jq.FormatPRView(raw)
+	return formatPRView(raw)
 }
 
 // failingCheckLogMaxLines bounds how many deduplicated log lines are kept
@@ -308,17 +329,16 @@ const failingCheckLogMaxLines = 60
 // totalDigestLineBudget bounds the combined digest size across every
 // failing check in one PRChecks call, so a PR with many failing checks
 // can't produce an unbounded response. Once the budget is spent, remaining
-// failing checks get a one-line note instead of a fetched digest — no gh
+// failing checks get a one-line note instead of a fetched digest — no forge
 // call is made for them. 240 = 4 checks' worth of failingCheckLogMaxLines,
 // a reasonable ceiling for "several checks failing at once" without being
 // so large a genuinely bad PR (dozens of failing checks) blows the budget.
 const totalDigestLineBudget = 240
 
-// prCheck mirrors the fields githubcli.PRChecks requests (see its doc
-// comment): name/state/link/workflow feed jq.FormatPRChecks's one-line
-// rendering, and bucket is gh's own authoritative pass/fail/pending/
-// skipping/cancel categorization of state, used here to decide which
-// checks get a log digest appended.
+// prCheck mirrors the objects Forge.PRChecks returns: name/state/link feed
+// formatCheckLine's one-line rendering, and bucket is the forge's own
+// pass/fail/pending/skipping/cancel categorization of state, used here to
+// decide which checks get a log digest appended.
 type prCheck struct {
 	Name     string `json:"name"`
 	State    string `json:"state"`
@@ -332,61 +352,56 @@ func isFailingCheck(c prCheck) bool {
 	return strings.EqualFold(strings.TrimSpace(c.Bucket), "fail")
 }
 
-// PRChecks returns a compact, one-line-per-check CI status for a PR.
-// Passing/pending checks keep exactly today's one-line format (jq's
-// prChecksFilter, unchanged — this is the sentinel-adjacent contract
-// task-design.md calls out). Failing checks get extra indented lines
-// appended underneath: a bounded, deduplicated tail of the failing job's
-// log (see digestLogTail), or an honest "log unavailable" note when no
-// digest could be produced.
+// PRChecks returns a compact, one-line-per-check CI status for a PR
+// (see formatCheckLine), or "No checks." when none are configured. Failing
+// checks get extra indented lines appended underneath: a bounded,
+// deduplicated tail of the failing job's log (see digestLogTail), or an
+// honest "log unavailable" note when no digest could be produced.
 //
-// This breaks the pure "gh fetches JSON → jq formats it" split the other
-// PRManager methods use, because producing a digest needs a SEPARATE gh
-// call per failing check (RunFailedJobLog) — genuine orchestration with
-// per-item side effects, not just reshaping one JSON payload. jq still owns
-// the base one-line-per-check rendering; only the digest lines are Go-side,
-// per task-design.md's "line-oriented text processing is a pure Go
-// function's job" guidance.
+// Unlike the other PRManager methods this is more than reshaping one
+// payload: a digest needs a SEPARATE forge call per failing check
+// (RunFailedJobLog), so the rendering loop interleaves those fetches.
 func (p *PRManager) PRChecks(prNumber string) (string, error) {
-	raw, err := p.Gh.PRChecks(prNumber)
+	checks, err := p.fetchChecks(prNumber)
 	if err != nil {
 		return "", err
 	}
-	formatted, err := p.Jq.FormatPRChecks(raw)
+	return p.renderChecks(checks), nil
+}
+
+// fetchChecks reads and decodes a PR's checks from the forge.
+func (p *PRManager) fetchChecks(prNumber string) ([]prCheck, error) {
+	raw, err := p.Forge.PRChecks(prNumber)
 	if err != nil {
-		return "", err
+		return nil, err
 	}
-	if strings.TrimSpace(formatted) == "" || formatted == "No checks." {
-		return formatted, nil
-	}
-
 	var checks []prCheck
 	if err := json.Unmarshal([]byte(raw), &checks); err != nil {
-		// The base formatting already succeeded via jq; don't fail the whole
-		// command over a payload shape only the digest enrichment can't parse.
-		return formatted, nil
+		return nil, fmt.Errorf("unexpected pr checks response: %w", err)
 	}
+	return checks, nil
+}
 
-	lines := strings.Split(strings.TrimRight(formatted, "\n"), "\n")
-	if len(lines) != len(checks) {
-		// jq's filter emits exactly one line per element of the same array,
-		// in order, with no filtering — this should never happen. If it
-		// somehow does, don't guess which line belongs to which check.
-		return formatted, nil
+// renderChecks is PRChecks' rendering: one line per check, with a log
+// digest under each failing one. WatchChecks reuses it for its final
+// report.
+func (p *PRManager) renderChecks(checks []prCheck) string {
+	if len(checks) == 0 {
+		return "No checks."
 	}
 
 	budget := totalDigestLineBudget
-	out := make([]string, 0, len(lines))
-	for i, line := range lines {
-		out = append(out, line)
-		if !isFailingCheck(checks[i]) {
+	out := make([]string, 0, len(checks))
+	for _, c := range checks {
+		out = append(out, formatCheckLine(c))
+		if !isFailingCheck(c) {
 			continue
 		}
-		digestLines, spent := p.failingCheckDigest(checks[i], budget)
+		digestLines, spent := p.failingCheckDigest(c, budget)
 		budget -= spent
 		out = append(out, digestLines...)
 	}
-	return strings.Join(out, "\n"), nil
+	return strings.Join(out, "\n")
 }
 
 // failingCheckDigestIndent prefixes every digest line appended under a
@@ -406,12 +421,12 @@ func (p *PRManager) failingCheckDigest(check prCheck, budget int) ([]string, int
 		}, 0
 	}
 
-	jobID, ok := parseActionsJobID(check.Link)
+	jobID, ok := parseCheckJobID(check.Link)
 	if !ok {
 		return []string{failingCheckDigestIndent + "log unavailable: external check"}, 0
 	}
 
-	rawLog, err := p.Gh.RunFailedJobLog(jobID)
+	rawLog, err := p.Forge.RunFailedJobLog(jobID)
 	if err != nil {
 		return []string{failingCheckDigestIndent + "log unavailable: " + err.Error()}, 0
 	}
@@ -436,7 +451,7 @@ func (p *PRManager) failingCheckDigest(check prCheck, budget int) ([]string, int
 
 // CurrentPR returns the PR number for the current branch.
 func (p *PRManager) CurrentPR() (string, error) {
-	n, err := p.Gh.CurrentPRNumber()
+	n, err := p.Forge.CurrentPRNumber()
 	if err != nil {
 		return "", err
 	}
@@ -448,7 +463,7 @@ func (p *PRManager) CurrentPR() (string, error) {
 
 // CurrentRepo returns the current repository as "owner/name".
 func (p *PRManager) CurrentRepo() (string, error) {
-	return p.Gh.CurrentRepo()
+	return p.Forge.CurrentRepo()
 }
 
 // prLabel describes the PR target for confirmation messages.
@@ -28,6 +28,23 @@ func parseActionsJobID(link string) (string, bool) {
 	return m[1], true
 }
 
+// gitlabJobLinkRE matches a GitLab CI job's web URL, the link
+// gitlabcli.PRChecks gives each check: https://<host>/<group path>/-/jobs/<id>.
+var gitlabJobLinkRE = regexp.MustCompile(`^https?://[^/]+/.+/-/jobs/(\d+)/?(?:#.*)?$`)
+
+// parseCheckJobID extracts the job id from a check link on either forge:
+// a GitHub Actions job or a GitLab CI job. Anything else is ("", false).
+func parseCheckJobID(link string) (string, bool) {
+	if id, ok := parseActionsJobID(link); ok {
+		return id, true
+	}
+	m := gitlabJobLinkRE.FindStringSubmatch(strings.TrimSpace(link))
+	if m == nil {
+		return "", false
+	}
+	return m[1], true
+}
+
 // logLineTimestampRE strips the leading RFC3339-ish timestamp gh prints on
 // every log line (e.g. "2026-07-22T18:32:18.0019976Z ").
 var logLineTimestampRE = regexp.MustCompile(
@@ -78,6 +78,26 @@ func TestParseActionsJobID(t *testing.T) {
 	}
 }
 
+func TestParseCheckJobID(t *testing.T) {
+	cases := []struct {
+		link   string
+		wantID string
+		wantOK bool
+	}{
+		{"https://github.com/octocat/hello/actions/runs/123456/job/789", "789", true},
+		{"https://gitlab.example.com/group/sub/app/-/jobs/4242", "4242", true},
+		{"https://gitlab.com/group/app/-/pipelines/99", "", false},
+		{"https://ci.example.com/build/42", "", false},
+	}
+	for _, c := range cases {
+		gotID, gotOK := parseCheckJobID(c.link)
+		if gotID != c.wantID || gotOK != c.wantOK {
+			t.Errorf("parseCheckJobID(%q) = (%q, %v), want (%q, %v)",
+				c.link, gotID, gotOK, c.wantID, c.wantOK)
+		}
+	}
+}
+
 func TestDigestLogTail(t *testing.T) {
 	t.Run("short log under the bound passes through unchanged (minus noise)", func(t *testing.T) {
 		raw := "job\tstep\t2026-07-22T18:32:18.0000000Z line one\n" +
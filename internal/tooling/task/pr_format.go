@@ -0,0 +1,233 @@
+package task
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Pure formatters for the PR subcommands: each decodes the GitHub-shaped
+// JSON a Forge returns into typed structs and renders compact markdown
+// intended to be fed to an LLM. Missing fields render as fallbacks ("?",
+// "unknown", "none") rather than errors.
+
+type prActor struct {
+	Login string `json:"login"`
+}
+
+type prThreadComment struct {
+	ID        string   `json:"id"`
+	Author    *prActor `json:"author"`
+	Body      string   `json:"body"`
+	CreatedAt string   `json:"createdAt"`
+}
+
+type prReviewThread struct {
+	ID           string   `json:"id"`
+	IsResolved   bool     `json:"isResolved"`
+	IsOutdated   bool     `json:"isOutdated"`
+	ResolvedBy   *prActor `json:"resolvedBy"`
+	Path         string   `json:"path"`
+	Line         *int     `json:"line"`
+	OriginalLine *int     `json:"originalLine"`
+	Comments     struct {
+		Nodes []prThreadComment `json:"nodes"`
+	} `json:"comments"`
+	FirstComment struct {
+		Nodes []struct {
+			DiffHunk *string `json:"diffHunk"`
+		} `json:"nodes"`
+	} `json:"firstComment"`
+}
+
+type prReview struct {
+	Author      *prActor `json:"author"`
+	Body        string   `json:"body"`
+	State       string   `json:"state"`
+	SubmittedAt string   `json:"submittedAt"`
+}
+
+type prConversationComment struct {
+	Author    *prActor `json:"author"`
+	Body      string   `json:"body"`
+	CreatedAt string   `json:"createdAt"`
+}
+
+// prGraphQLResponse is the data.repository.pullRequest envelope both
+// FetchReviewThreads and FetchPRDiscussion return; each fills its own
+// fields.
+type prGraphQLResponse struct {
+	Data struct {
+		Repository struct {
+			PullRequest struct {
+				ReviewThreads struct {
+					Nodes []prReviewThread `json:"nodes"`
+				} `json:"reviewThreads"`
+				Reviews struct {
+					Nodes []prReview `json:"nodes"`
+				} `json:"reviews"`
+				Comments struct {
+					Nodes []prConversationComment `json:"nodes"`
+				} `json:"comments"`
+			} `json:"pullRequest"`
+		} `json:"repository"`
+	} `json:"data"`
+}
+
+// prSummary mirrors the fields Forge.PRView returns.
+type prSummary struct {
+	Number         int    `json:"number"`
+	Title          string `json:"title"`
+	State          string `json:"state"`
+	Mergeable      string `json:"mergeable"`
+	ReviewDecision string `json:"reviewDecision"`
+	HeadRefName    string `json:"headRefName"`
+	BaseRefName    string `json:"baseRefName"`
+}
+
+func orDefault(s, fallback string) string {
+	if s == "" {
+		return fallback
+	}
+	return s
+}
+
+func authorLogin(a *prActor) string {
+	if a == nil {
+		return "unknown"
+	}
+	return orDefault(a.Login, "unknown")
+}
+
+// formatReviewThreads renders review threads as one block per thread,
+// headed by "file:line (thread <id>)" — with " (outdated)" appended when
+// GitHub's isOutdated flag says the code the thread was anchored to has
+// changed since, and " — resolved by <login>" appended when the thread is
+// resolved — an optional diff hunk for context, then one line per comment
+// as "**author** (<id>, <createdAt>): body". Blocks end in "---".
+//
+// The layout is deliberately terse — no "## Location" / "### Comments"
+// scaffold — so it reads clearly while spending few tokens. Thread and
+// comment ids are kept because follow-up tasks (resolve, reply) act on them.
+// Comment createdAt is rendered raw (the ISO-8601 string GitHub returns) so a
+// caller (e.g. the /review-pr dedup rule) can tell whether code changed
+// since a given reply.
+//
+// resolved filters by resolution status: &true for resolved threads only,
+// &false for unresolved only, nil for all. Returns "" when no thread matches.
+func formatReviewThreads(raw string, resolved *bool) (string, error) {
+	var resp prGraphQLResponse
+	if err := json.Unmarshal([]byte(raw), &resp); err != nil {
+		return "", fmt.Errorf("unexpected review threads response: %w", err)
+	}
+	var blocks []string
+	for _, t := range resp.Data.Repository.PullRequest.ReviewThreads.Nodes {
+		if resolved != nil && t.IsResolved != *resolved {
+			continue
+		}
+		line := "?"
+		if t.Line != nil {
+			line = strconv.Itoa(*t.Line)
+		} else if t.OriginalLine != nil {
+			line = strconv.Itoa(*t.OriginalLine)
+		}
+
+		var b strings.Builder
+		fmt.Fprintf(&b, "## %s:%s (thread %s)", t.Path, line, t.ID)
+		if t.IsOutdated {
+			b.WriteString(" (outdated)")
+		}
+		if t.ResolvedBy != nil && t.ResolvedBy.Login != "" {
+			b.WriteString(" — resolved by " + t.ResolvedBy.Login)
+		}
+		b.WriteString("\n\n")
+		if n := t.FirstComment.Nodes; len(n) > 0 && n[0].DiffHunk != nil {
+			b.WriteString("```diff\n" + *n[0].DiffHunk + "\n```\n\n")
+		}
+		comments := make([]string, len(t.Comments.Nodes))
+		for i, c := range t.Comments.Nodes {
+			comments[i] = fmt.Sprintf(
+				"**%s** (%s, %s): %s",
+				authorLogin(c.Author), c.ID, orDefault(c.CreatedAt, "?"), c.Body,
+			)
+		}
+		b.WriteString(strings.Join(comments, "\n\n"))
+		b.WriteString("\n\n---")
+		blocks = append(blocks, b.String())
+	}
+	return strings.Join(blocks, "\n\n"), nil
+}
+
+// formatPRDiscussion renders a pull request's review summaries and
+// top-level conversation, matching the terse style of formatReviewThreads:
+// no scaffolding beyond two "##" section headers.
+//
+//   - "## Review summaries": one entry per review that has a non-blank
+//     body — "**<login>** [<STATE>] (<submittedAt>): <body>". Reviews that
+//     carry only a state or only inline comments are skipped. The whole
+//     section, header included, is omitted when no review qualifies.
+//   - "## Conversation": one entry per top-level PR comment —
+//     "**<login>** (<createdAt>): <body>". Omitted when there are none.
+//
+// submittedAt/createdAt are rendered raw so a caller (e.g. the /review-pr
+// dedup rule) can tell whether code changed since a given review or
+// comment. Returns "" when there is nothing to render, so the caller
+// (PRManager.ReviewThreads) can decide what message to show.
+func formatPRDiscussion(raw string) (string, error) {
+	var resp prGraphQLResponse
+	if err := json.Unmarshal([]byte(raw), &resp); err != nil {
+		return "", fmt.Errorf("unexpected pr discussion response: %w", err)
+	}
+	pr := resp.Data.Repository.PullRequest
+
+	var sections []string
+	var reviews []string
+	for _, r := range pr.Reviews.Nodes {
+		if strings.TrimSpace(r.Body) == "" {
+			continue
+		}
+		reviews = append(reviews, fmt.Sprintf(
+			"**%s** [%s] (%s): %s",
+			authorLogin(r.Author), orDefault(r.State, "?"), orDefault(r.SubmittedAt, "?"), r.Body,
+		))
+	}
+	if len(reviews) > 0 {
+		sections = append(sections, "## Review summaries\n\n"+strings.Join(reviews, "\n\n"))
+	}
+	if len(pr.Comments.Nodes) > 0 {
+		comments := make([]string, len(pr.Comments.Nodes))
+		for i, c := range pr.Comments.Nodes {
+			comments[i] = fmt.Sprintf(
+				"**%s** (%s): %s", authorLogin(c.Author), orDefault(c.CreatedAt, "?"), c.Body,
+			)
+		}
+		sections = append(sections, "## Conversation\n\n"+strings.Join(comments, "\n\n"))
+	}
+	return strings.Join(sections, "\n\n"), nil
+}
+
+// formatPRView renders a PR's metadata (see prSummary) as a compact
+// three-line summary.
+func formatPRView(raw string) (string, error) {
+	var pr prSummary
+	if err := json.Unmarshal([]byte(raw), &pr); err != nil {
+		return "", fmt.Errorf("unexpected pr view response: %w", err)
+	}
+	return fmt.Sprintf(
+		"PR #%d: %s\nstate: %s  mergeable: %s  review: %s\nbranch: %s -> %s",
+		pr.Number, pr.Title,
+		pr.State, orDefault(pr.Mergeable, "?"), orDefault(pr.ReviewDecision, "none"),
+		orDefault(pr.HeadRefName, "?"), orDefault(pr.BaseRefName, "?"),
+	), nil
+}
+
+// formatCheckLine renders one check as "<state><TAB><name>", plus two
+// spaces and the link when there is one.
+func formatCheckLine(c prCheck) string {
+	line := c.State + "\t" + c.Name
+	if c.Link != "" {
+		line += "  " + c.Link
+	}
+	return line
+}
@@ -0,0 +1,136 @@
+package task
+
+import (
+	"strings"
+	"testing"
+)
+
+func boolPtr(b bool) *bool { return &b }
+
+func TestFormatReviewThreads(t *testing.T) {
+	const payload = `{"data":{"repository":{"pullRequest":{"reviewThreads":{"nodes":[
+		{"id":"T1","isResolved":false,"isOutdated":true,"path":"a.go","line":null,"originalLine":7,
+		 "comments":{"nodes":[
+			{"id":"C1","author":{"login":"alice"},"body":"rename this","createdAt":"2026-01-01T00:00:00Z"},
+			{"id":"C2","author":null,"body":"done"}]},
+		 "firstComment":{"nodes":[{"diffHunk":"@@ -1 +1 @@\n-a\n+b"}]}},
+		{"id":"T2","isResolved":true,"resolvedBy":{"login":"bob"},"path":"b.go","line":3,
+		 "comments":{"nodes":[{"id":"C3","author":{"login":"bob"},"body":"ok","createdAt":"2026-01-02T00:00:00Z"}]},
+		 "firstComment":{"nodes":[{"diffHunk":null}]}}
+	]}}}}}`
+	unresolved := "## a.go:7 (thread T1) (outdated)\n\n" +
+		"```diff\n@@ -1 +1 @@\n-a\n+b\n```\n\n" +
+		"**alice** (C1, 2026-01-01T00:00:00Z): rename this\n\n" +
+		"**unknown** (C2, ?): done\n\n---"
+	resolved := "## b.go:3 (thread T2) — resolved by bob\n\n" +
+		"**bob** (C3, 2026-01-02T00:00:00Z): ok\n\n---"
+
+	cases := []struct {
+		name     string
+		resolved *bool
+		want     string
+	}{
+		{"unresolved only", boolPtr(false), unresolved},
+		{"resolved only", boolPtr(true), resolved},
+		{"all", nil, unresolved + "\n\n" + resolved},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			out, err := formatReviewThreads(payload, c.resolved)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if out != c.want {
+				t.Fatalf("unexpected output:\n%s\nwant:\n%s", out, c.want)
+			}
+		})
+	}
+
+	t.Run("missing path degrades to empty instead of erroring", func(t *testing.T) {
+		out, err := formatReviewThreads(`{"data":{"repository":{}}}`, nil)
+		if err != nil {
+			t.Fatalf("expected no error for a missing path, got: %v", err)
+		}
+		if out != "" {
+			t.Fatalf("expected empty output, got %q", out)
+		}
+	})
+
+	t.Run("malformed JSON errors", func(t *testing.T) {
+		if _, err := formatReviewThreads("not json", nil); err == nil {
+			t.Fatal("expected error")
+		}
+	})
+}
+
+func TestFormatPRDiscussion(t *testing.T) {
+	t.Run("both sections rendered, blank review bodies skipped", func(t *testing.T) {
+		payload := `{"data":{"repository":{"pullRequest":{
+			"reviews":{"nodes":[
+				{"author":{"login":"alice"},"body":"Looks good","state":"APPROVED","submittedAt":"2026-01-02T10:00:00Z"},
+				{"author":{"login":"bob"},"body":"  \n","state":"COMMENTED"},
+				{"author":{"login":"carol"},"body":"Needs tests"}]},
+			"comments":{"nodes":[
+				{"author":{"login":"dave"},"body":"Thanks for the PR","createdAt":"2026-01-03T09:00:00Z"}]}}}}}`
+
+		out, err := formatPRDiscussion(payload)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		want := "## Review summaries\n\n" +
+			"**alice** [APPROVED] (2026-01-02T10:00:00Z): Looks good\n\n" +
+			"**carol** [?] (?): Needs tests\n\n" +
+			"## Conversation\n\n**dave** (2026-01-03T09:00:00Z): Thanks for the PR"
+		if out != want {
+			t.Fatalf("unexpected output:\n%s\nwant:\n%s", out, want)
+		}
+	})
+
+	t.Run("only conversation", func(t *testing.T) {
+		payload := `{"data":{"repository":{"pullRequest":{
+			"reviews":{"nodes":[{"author":{"login":"bob"},"body":"","state":"APPROVED"}]},
+			"comments":{"nodes":[{"author":{"login":"dave"},"body":"hi"}]}}}}}`
+
+		out, err := formatPRDiscussion(payload)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if out != "## Conversation\n\n**dave** (?): hi" {
+			t.Fatalf("unexpected output: %q", out)
+		}
+	})
+
+	t.Run("nothing to render yields empty output", func(t *testing.T) {
+		out, err := formatPRDiscussion(`{"data":{"repository":{"pullRequest":{}}}}`)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if out != "" {
+			t.Fatalf("expected empty output, got %q", out)
+		}
+	})
+}
+
+func TestFormatPRView(t *testing.T) {
+	out, err := formatPRView(`{"number":42,"title":"Add login","state":"OPEN","headRefName":"feat/login"}`)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want := "PR #42: Add login\nstate: OPEN  mergeable: ?  review: none\nbranch: feat/login -> ?"
+	if out != want {
+		t.Fatalf("unexpected output:\n%s\nwant:\n%s", out, want)
+	}
+	if _, err := formatPRView("not json"); err == nil || !strings.Contains(err.Error(), "pr view") {
+		t.Fatalf("expected a pr view parse error, got: %v", err)
+	}
+}
+
+func TestFormatCheckLine(t *testing.T) {
+	if got := formatCheckLine(prCheck{Name: "build", State: "SUCCESS"}); got != "SUCCESS\tbuild" {
+		t.Errorf("unexpected line without link: %q", got)
+	}
+	got := formatCheckLine(prCheck{Name: "test", State: "FAILURE", Link: "https://ci/1"})
+	if got != "FAILURE\ttest  https://ci/1" {
+		t.Errorf("unexpected line with link: %q", got)
+	}
+}
@@ -6,24 +6,165 @@ import (
 	"strings"
 	"testing"
 
-	gitcli "github.com/cjairm/devgita/internal/tooling/terminal/dev_tools/githubcli"
-	"github.com/cjairm/devgita/internal/tooling/terminal/dev_tools/jq"
-
-	"github.com/cjairm/devgita/internal/commands"
+	"github.com/cjairm/devgita/internal/tooling/terminal/dev_tools/gitlabcli"
 )
 
-// newPRSetup builds a PRManager whose gh and jq calls go through separate mock
-// bases so each side can be scripted independently.
-func newPRSetup() (pm *PRManager, ghBase, jqBase *commands.MockBaseCommand) {
-	ghBase = commands.NewMockBaseCommand()
-	jqBase = commands.NewMockBaseCommand()
-	pm = &PRManager{
-		Gh: &gitcli.GithubCli{Cmd: commands.NewMockCommand(), Base: ghBase},
-		Jq: &jq.Jq{Cmd: commands.NewMockCommand(), Base: jqBase},
+// fakeForge is an in-memory Forge: each fetch returns its canned field and
+// every call is recorded as "Method arg1 arg2 ..." in calls, so tests can
+// assert both the rendered output and which forge calls were made.
+type fakeForge struct {
+	repo          string
+	prNumber      string
+	prNumberErr   error
+	threads       string
+	threadsErr    error
+	discussion    string
+	discussionErr error
+	view          string
+	checks        string
+	checksSeq     []string // successive PRChecks results, when set; the last repeats
+	heads         []string // successive PRHeadSHA results; the last repeats
+	jobLog        string
+	jobLogErr     error
+	verdictErr    error // returned by RequestChangesPR and CreateReview
+	calls         []string
+}
+
+func (f *fakeForge) record(method string, args ...string) {
+	f.calls = append(f.calls, strings.TrimSpace(method+" "+strings.Join(args, " ")))
+}
+
+func (f *fakeForge) CurrentRepo() (string, error) {
+	f.record("CurrentRepo")
+	return f.repo, nil
+}
+
+func (f *fakeForge) CurrentPRNumber() (string, error) {
+	f.record("CurrentPRNumber")
+	return f.prNumber, f.prNumberErr
+}
+
+func (f *fakeForge) FetchReviewThreads(owner, repo, prNumber string) (string, error) {
+	f.record("FetchReviewThreads", owner, repo, prNumber)
+	return f.threads, f.threadsErr
+}
+
+func (f *fakeForge) FetchPRDiscussion(owner, repo, prNumber string) (string, error) {
+	f.record("FetchPRDiscussion", owner, repo, prNumber)
+	return f.discussion, f.discussionErr
+}
+
+func (f *fakeForge) ResolveReviewThread(threadID string) (string, error) {
+	f.record("ResolveReviewThread", threadID)
+	return "{}", nil
+}
+
+func (f *fakeForge) UnresolveReviewThread(threadID string) (string, error) {
+	f.record("UnresolveReviewThread", threadID)
+	return "{}", nil
+}
+
+func (f *fakeForge) ReplyToReviewThread(threadID, body string) (string, error) {
+	f.record("ReplyToReviewThread", threadID, body)
+	return "{}", nil
+}
+
+func (f *fakeForge) CreateReview(owner, repo, prNumber, payloadJSON string) (string, error) {
+	f.record("CreateReview", owner, repo, prNumber, payloadJSON)
+	if f.verdictErr != nil {
+		return "", f.verdictErr
+	}
+	return `{"id":1}`, nil
+}
+
+func (f *fakeForge) CreatePR(title, body, base string) (string, error) {
+	f.record("CreatePR", title, body, base)
+	return "https://github.com/octocat/hello/pull/1", nil
+}
+
+func (f *fakeForge) UpdatePRDescription(prNumber, body string) error {
+	f.record("UpdatePRDescription", prNumber, body)
+	return nil
+}
+
+func (f *fakeForge) ApprovePR(prNumber, body string) error {
+	f.record("ApprovePR", prNumber, body)
+	return nil
+}
+
+func (f *fakeForge) RequestChangesPR(prNumber, body string) error {
+	f.record("RequestChangesPR", prNumber, body)
+	return f.verdictErr
+}
+
+func (f *fakeForge) RequestReviewPR(prNumber string, reviewers []string) error {
+	f.record("RequestReviewPR", append([]string{prNumber}, reviewers...)...)
+	return nil
+}
+
+func (f *fakeForge) CommentPR(prNumber, body string) error {
+	f.record("CommentPR", prNumber, body)
+	return nil
+}
+
+func (f *fakeForge) MergePR(prNumber, method string) error {
+	f.record("MergePR", prNumber, method)
+	return nil
+}
+
+func (f *fakeForge) PRView(prNumber string) (string, error) {
+	f.record("PRView", prNumber)
+	return f.view, nil
+}
+
+func (f *fakeForge) PRChecks(prNumber string) (string, error) {
+	f.record("PRChecks", prNumber)
+	if len(f.checksSeq) > 0 {
+		return nextScripted(&f.checksSeq), nil
+	}
+	return f.checks, nil
+}
+
+func (f *fakeForge) PRHeadSHA(prNumber string) (string, error) {
+	f.record("PRHeadSHA", prNumber)
+	if len(f.heads) > 0 {
+		return nextScripted(&f.heads), nil
+	}
+	return "abc1234", nil
+}
+
+// nextScripted pops the next scripted result, repeating the last one.
+func nextScripted(seq *[]string) string {
+	next := (*seq)[0]
+	if len(*seq) > 1 {
+		*seq = (*seq)[1:]
 	}
-	return
+	return next
 }
 
+func (f *fakeForge) RunFailedJobLog(jobID string) (string, error) {
+	f.record("RunFailedJobLog", jobID)
+	return f.jobLog, f.jobLogErr
+}
+
+// newPRSetup builds a PRManager over a fakeForge for the octocat/hello repo.
+func newPRSetup() (*PRManager, *fakeForge) {
+	forge := &fakeForge{repo: "octocat/hello"}
+	return &PRManager{Forge: forge}, forge
+}
+
+const (
+	oneThreadJSON = `{"data":{"repository":{"pullRequest":{"reviewThreads":{"nodes":[
+		{"id":"T1","isResolved":false,"path":"a.go","line":1,
+		 "comments":{"nodes":[{"id":"C1","author":{"login":"alice"},"body":"nit","createdAt":"2026-01-01T00:00:00Z"}]}}
+	]}}}}}`
+	noThreadsJSON      = `{"data":{"repository":{"pullRequest":{"reviewThreads":{"nodes":[]}}}}}`
+	oneCommentJSON     = `{"data":{"repository":{"pullRequest":{"reviews":{"nodes":[]},"comments":{"nodes":[{"author":{"login":"dave"},"body":"hi","createdAt":"2026-01-03T09:00:00Z"}]}}}}}`
+	noDiscussionJSON   = `{"data":{"repository":{"pullRequest":{"reviews":{"nodes":[]},"comments":{"nodes":[]}}}}}`
+	oneThreadRendered  = "## a.go:1 (thread T1)\n\n**alice** (C1, 2026-01-01T00:00:00Z): nit\n\n---"
+	oneCommentRendered = "## Conversation\n\n**dave** (2026-01-03T09:00:00Z): hi"
+)
+
 func TestResolvedPtrForState(t *testing.T) {
 	cases := []struct {
 		state   string
@@ -64,101 +205,58 @@ func TestResolvedPtrForState(t *testing.T) {
 
 func TestReviewThreads(t *testing.T) {
 	t.Run("explicit pr renders threads and discussion combined", func(t *testing.T) {
-		pm, ghBase, jqBase := newPRSetup()
-		// gh: CurrentRepo, FetchReviewThreads, FetchPRDiscussion (pr given, so no CurrentPRNumber)
-		ghBase.SetExecCommandResults(
-			commands.ExecCommandResult("octocat/hello", "", nil),
-			commands.ExecCommandResult(`{"data":{}}`, "", nil),
-			commands.ExecCommandResult(`{"data":{}}`, "", nil),
-		)
-		// jq: FormatReviewThreads, then FormatPRDiscussion
-		jqBase.SetExecCommandResults(
-			commands.ExecCommandResult("## a.go:1 (thread T1)", "", nil),
-			commands.ExecCommandResult("## Conversation\n\n**dave**: hi", "", nil),
-		)
+		pm, forge := newPRSetup()
+		forge.threads, forge.discussion = oneThreadJSON, oneCommentJSON
 
 		out, err := pm.ReviewThreads("42", "all")
 		if err != nil {
 			t.Fatalf("unexpected error: %v", err)
 		}
-		want := "## a.go:1 (thread T1)\n\n## Conversation\n\n**dave**: hi"
-		if out != want {
-			t.Fatalf("unexpected output: %q", out)
+		if want := oneThreadRendered + "\n\n" + oneCommentRendered; out != want {
+			t.Fatalf("unexpected output:\n%s\nwant:\n%s", out, want)
 		}
 
-		// Both gh fetches must carry the resolved owner/name/pr
-		if ghBase.GetExecCommandCallCount() != 3 {
-			t.Fatalf("expected 3 gh calls (CurrentRepo, threads, discussion), got %d",
-				ghBase.GetExecCommandCallCount())
+		// Both fetches must carry the resolved owner/name/pr, and an explicit
+		// pr skips CurrentPRNumber.
+		want := []string{
+			"CurrentRepo",
+			"FetchReviewThreads octocat hello 42",
+			"FetchPRDiscussion octocat hello 42",
 		}
-		for _, call := range ghBase.ExecCommandCalls[1:] {
-			joined := strings.Join(call.Args, " ")
-			if !strings.Contains(joined, "owner=octocat") ||
-				!strings.Contains(joined, "name=hello") ||
-				!strings.Contains(joined, "pr=42") {
-				t.Fatalf("fetch missing resolved vars: %v", call.Args)
-			}
-		}
-		// The discussion fetch must NOT be paginated (distinct from the threads fetch).
-		discussionCall := ghBase.ExecCommandCalls[2]
-		if strings.Contains(strings.Join(discussionCall.Args, " "), "--paginate") {
-			t.Fatalf("discussion fetch must not paginate, got: %v", discussionCall.Args)
+		if strings.Join(forge.calls, "|") != strings.Join(want, "|") {
+			t.Fatalf("unexpected forge calls: %v", forge.calls)
 		}
 	})
 
 	t.Run("only threads non-empty", func(t *testing.T) {
-		pm, ghBase, jqBase := newPRSetup()
-		ghBase.SetExecCommandResults(
-			commands.ExecCommandResult("octocat/hello", "", nil),
-			commands.ExecCommandResult(`{"data":{}}`, "", nil),
-			commands.ExecCommandResult(`{"data":{}}`, "", nil),
-		)
-		jqBase.SetExecCommandResults(
-			commands.ExecCommandResult("## a.go:1 (thread T1)", "", nil),
-			commands.ExecCommandResult("", "", nil),
-		)
+		pm, forge := newPRSetup()
+		forge.threads, forge.discussion = oneThreadJSON, noDiscussionJSON
 
 		out, err := pm.ReviewThreads("42", "all")
 		if err != nil {
 			t.Fatalf("unexpected error: %v", err)
 		}
-		if out != "## a.go:1 (thread T1)" {
+		if out != oneThreadRendered {
 			t.Fatalf("unexpected output: %q", out)
 		}
 	})
 
 	t.Run("only discussion non-empty", func(t *testing.T) {
-		pm, ghBase, jqBase := newPRSetup()
-		ghBase.SetExecCommandResults(
-			commands.ExecCommandResult("octocat/hello", "", nil),
-			commands.ExecCommandResult(`{"data":{}}`, "", nil),
-			commands.ExecCommandResult(`{"data":{}}`, "", nil),
-		)
-		jqBase.SetExecCommandResults(
-			commands.ExecCommandResult("", "", nil),
-			commands.ExecCommandResult("## Conversation\n\n**dave**: hi", "", nil),
-		)
+		pm, forge := newPRSetup()
+		forge.threads, forge.discussion = noThreadsJSON, oneCommentJSON
 
 		out, err := pm.ReviewThreads("42", "all")
 		if err != nil {
 			t.Fatalf("unexpected error: %v", err)
 		}
-		if out != "## Conversation\n\n**dave**: hi" {
+		if out != oneCommentRendered {
 			t.Fatalf("unexpected output: %q", out)
 		}
 	})
 
 	t.Run("both empty yields flat friendly message", func(t *testing.T) {
-		pm, ghBase, jqBase := newPRSetup()
-		ghBase.SetExecCommandResults(
-			commands.ExecCommandResult("octocat/hello", "", nil),
-			commands.ExecCommandResult(`{"data":{}}`, "", nil),
-			commands.ExecCommandResult(`{"data":{}}`, "", nil),
-		)
-		jqBase.SetExecCommandResults(
-			commands.ExecCommandResult("", "", nil),
-			commands.ExecCommandResult("", "", nil),
-		)
+		pm, forge := newPRSetup()
+		forge.threads, forge.discussion = noThreadsJSON, noDiscussionJSON
 
 		out, err := pm.ReviewThreads("42", "")
 		if err != nil {
@@ -170,44 +268,35 @@ func TestReviewThreads(t *testing.T) {
 	})
 
 	t.Run("discussion shown regardless of --state", func(t *testing.T) {
-		pm, ghBase, jqBase := newPRSetup()
-		ghBase.SetExecCommandResults(
-			commands.ExecCommandResult("octocat/hello", "", nil),
-			commands.ExecCommandResult(`{"data":{}}`, "", nil),
-			commands.ExecCommandResult(`{"data":{}}`, "", nil),
-		)
-		// FormatReviewThreads returns empty (no threads match "resolved"), but
-		// discussion is still rendered.
-		jqBase.SetExecCommandResults(
-			commands.ExecCommandResult("", "", nil),
-			commands.ExecCommandResult("## Conversation\n\n**dave**: hi", "", nil),
-		)
+		pm, forge := newPRSetup()
+		// The only thread is unresolved, so --state resolved filters it out,
+		// but the discussion is still rendered.
+		forge.threads, forge.discussion = oneThreadJSON, oneCommentJSON
 
 		out, err := pm.ReviewThreads("42", "resolved")
 		if err != nil {
 			t.Fatalf("unexpected error: %v", err)
 		}
-		if out != "## Conversation\n\n**dave**: hi" {
+		if out != oneCommentRendered {
 			t.Fatalf("unexpected output: %q", out)
 		}
-		// FormatReviewThreads must have been called with resolved=true, proving
-		// --state still filters only the threads path.
-		threadsCall := jqBase.ExecCommandCalls[0]
-		if !strings.Contains(strings.Join(threadsCall.Args, " "), "resolved true") {
-			t.Fatalf(
-				"expected resolved=true passed to threads formatter, got: %v",
-				threadsCall.Args,
-			)
+	})
+
+	t.Run("current branch pr is resolved when none is given", func(t *testing.T) {
+		pm, forge := newPRSetup()
+		forge.prNumber = "7"
+		forge.threads, forge.discussion = noThreadsJSON, noDiscussionJSON
+
+		if _, err := pm.ReviewThreads("", "unresolved"); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if forge.calls[1] != "CurrentPRNumber" || forge.calls[2] != "FetchReviewThreads octocat hello 7" {
+			t.Fatalf("unexpected forge calls: %v", forge.calls)
 		}
 	})
 
 	t.Run("no pr for branch errors", func(t *testing.T) {
-		pm, ghBase, _ := newPRSetup()
-		// CurrentRepo ok, CurrentPRNumber returns empty (no PR)
-		ghBase.SetExecCommandResults(
-			commands.ExecCommandResult("octocat/hello", "", nil),
-			commands.ExecCommandResult("", "", nil),
-		)
+		pm, _ := newPRSetup()
 
 		_, err := pm.ReviewThreads("", "unresolved")
 		if err == nil {
@@ -218,67 +307,67 @@ func TestReviewThreads(t *testing.T) {
 		}
 	})
 
-	t.Run("bad state errors before any gh call", func(t *testing.T) {
-		pm, ghBase, _ := newPRSetup()
+	t.Run("bad state errors before any forge call", func(t *testing.T) {
+		pm, forge := newPRSetup()
 
 		if _, err := pm.ReviewThreads("42", "bogus"); err == nil {
 			t.Fatal("expected error for bad state")
 		}
-		if ghBase.GetExecCommandCallCount() != 0 {
-			t.Fatal("expected no gh call when state is invalid")
+		if len(forge.calls) != 0 {
+			t.Fatalf("expected no forge call when state is invalid, got %v", forge.calls)
 		}
 	})
 
 	t.Run("error from threads fetch propagates before discussion fetch", func(t *testing.T) {
-		pm, ghBase, _ := newPRSetup()
-		ghBase.SetExecCommandResults(
-			commands.ExecCommandResult("octocat/hello", "", nil),
-			commands.ExecCommandResult("", "boom", fmt.Errorf("exit 1")),
-		)
+		pm, forge := newPRSetup()
+		forge.threadsErr = fmt.Errorf("boom")
 
 		if _, err := pm.ReviewThreads("42", "all"); err == nil {
 			t.Fatal("expected error when threads fetch fails")
 		}
-		if ghBase.GetExecCommandCallCount() != 2 {
-			t.Fatalf("expected no discussion fetch after threads fetch failure, got %d calls",
-				ghBase.GetExecCommandCallCount())
+		if len(forge.calls) != 2 {
+			t.Fatalf("expected no discussion fetch after threads fetch failure, got %v",
+				forge.calls)
 		}
 	})
 
 	t.Run("error from discussion fetch propagates", func(t *testing.T) {
-		pm, ghBase, jqBase := newPRSetup()
-		ghBase.SetExecCommandResults(
-			commands.ExecCommandResult("octocat/hello", "", nil),
-			commands.ExecCommandResult(`{"data":{}}`, "", nil),
-			commands.ExecCommandResult("", "boom", fmt.Errorf("exit 1")),
-		)
-		jqBase.SetExecCommandResult("## a.go:1 (thread T1)", "", nil)
+		pm, forge := newPRSetup()
+		forge.threads = oneThreadJSON
+		forge.discussionErr = fmt.Errorf("boom")
 
 		if _, err := pm.ReviewThreads("42", "all"); err == nil {
 			t.Fatal("expected error when discussion fetch fails")
 		}
 	})
+
+	t.Run("malformed fetch response errors", func(t *testing.T) {
+		pm, forge := newPRSetup()
+		forge.threads = "not json"
+
+		if _, err := pm.ReviewThreads("42", "all"); err == nil {
+			t.Fatal("expected error for a malformed threads response")
+		}
+	})
 }
 
 func TestPRViewAndChecks(t *testing.T) {
-	t.Run("pr-view formats via jq", func(t *testing.T) {
-		pm, ghBase, jqBase := newPRSetup()
-		ghBase.SetExecCommandResult(`{"number":42}`, "", nil)
-		jqBase.SetExecCommandResult("PR #42: Title", "", nil)
+	t.Run("pr-view renders a summary", func(t *testing.T) {
+		pm, forge := newPRSetup()
+		forge.view = `{"number":42,"title":"Title","state":"OPEN"}`
 
 		out, err := pm.PRView("42")
 		if err != nil {
 			t.Fatalf("unexpected error: %v", err)
 		}
-		if out != "PR #42: Title" {
+		if !strings.HasPrefix(out, "PR #42: Title\nstate: OPEN") {
 			t.Fatalf("unexpected output: %q", out)
 		}
 	})
 
-	t.Run("pr-checks formats via jq", func(t *testing.T) {
-		pm, ghBase, jqBase := newPRSetup()
-		ghBase.SetExecCommandResult(`[{"name":"build","state":"SUCCESS"}]`, "", nil)
-		jqBase.SetExecCommandResult("SUCCESS\tbuild", "", nil)
+	t.Run("pr-checks renders one line per check", func(t *testing.T) {
+		pm, forge := newPRSetup()
+		forge.checks = `[{"name":"build","state":"SUCCESS"}]`
 
 		out, err := pm.PRChecks("")
 		if err != nil {
@@ -288,19 +377,26 @@ func TestPRViewAndChecks(t *testing.T) {
 			t.Fatalf("unexpected output: %q", out)
 		}
 	})
+
+	t.Run("pr-checks rejects a malformed response", func(t *testing.T) {
+		pm, forge := newPRSetup()
+		forge.checks = "not json"
+
+		if _, err := pm.PRChecks(""); err == nil {
+			t.Fatal("expected error for a malformed checks response")
+		}
+	})
 }
 
 // TestPRChecksDigest covers the failing-check log digest enrichment: the
-// passing/pending one-line format must stay byte-identical to today's (the
+// passing/pending one-line format must stay byte-identical (the
 // sentinel-adjacent contract task-design.md calls out), and failing checks
 // get extra indented lines appended — a fetched log digest, or an honest
 // "log unavailable" note when no digest could be produced.
 func TestPRChecksDigest(t *testing.T) {
-	t.Run("all passing: output unchanged, no extra gh calls", func(t *testing.T) {
-		pm, ghBase, jqBase := newPRSetup()
-		raw := `[{"name":"build","state":"SUCCESS","bucket":"pass","link":""}]`
-		ghBase.SetExecCommandResult(raw, "", nil)
-		jqBase.SetExecCommandResult("SUCCESS\tbuild", "", nil)
+	t.Run("all passing: output unchanged, no log fetch", func(t *testing.T) {
+		pm, forge := newPRSetup()
+		forge.checks = `[{"name":"build","state":"SUCCESS","bucket":"pass","link":""}]`
 
 		out, err := pm.PRChecks("42")
 		if err != nil {
@@ -309,29 +405,20 @@ func TestPRChecksDigest(t *testing.T) {
 		if out != "SUCCESS\tbuild" {
 			t.Fatalf("unexpected output: %q", out)
 		}
-		if ghBase.GetExecCommandCallCount() != 1 {
-			t.Fatalf("expected exactly 1 gh call (PRChecks only), got %d",
-				ghBase.GetExecCommandCallCount())
+		if len(forge.calls) != 1 {
+			t.Fatalf("expected exactly 1 forge call (PRChecks only), got %v", forge.calls)
 		}
 	})
 
 	t.Run("failing check with a parseable Actions link gets a digest appended", func(t *testing.T) {
-		pm, ghBase, jqBase := newPRSetup()
-		raw := `[
+		pm, forge := newPRSetup()
+		forge.checks = `[
 			{"name":"build","state":"SUCCESS","bucket":"pass","link":""},
 			{"name":"test","state":"FAILURE","bucket":"fail","link":"https://github.com/octocat/hello/actions/runs/111/job/222"}
 		]`
-		formatted := "SUCCESS\tbuild\n" +
-			"FAILURE\ttest  https://github.com/octocat/hello/actions/runs/111/job/222"
-		logContent := "job\tstep\t2026-07-22T18:32:18.0000000Z compile error: undefined foo\n" +
+		forge.jobLog = "job\tstep\t2026-07-22T18:32:18.0000000Z compile error: undefined foo\n" +
 			"job\tstep\t2026-07-22T18:32:19.0000000Z exit status 1\n"
 
-		ghBase.SetExecCommandResults(
-			commands.ExecCommandResult(raw, "", nil),
-			commands.ExecCommandResult(logContent, "", nil),
-		)
-		jqBase.SetExecCommandResult(formatted, "", nil)
-
 		out, err := pm.PRChecks("42")
 		if err != nil {
 			t.Fatalf("unexpected error: %v", err)
@@ -345,24 +432,15 @@ func TestPRChecksDigest(t *testing.T) {
 		if out != strings.Join(wantLines, "\n") {
 			t.Fatalf("unexpected output:\n%s", out)
 		}
-		if ghBase.GetExecCommandCallCount() != 2 {
-			t.Fatalf("expected 2 gh calls (PRChecks + RunFailedJobLog), got %d",
-				ghBase.GetExecCommandCallCount())
-		}
-		// The second gh call must target the parsed job id.
-		logCall := ghBase.ExecCommandCalls[1]
-		if !strings.Contains(strings.Join(logCall.Args, " "), "222") {
-			t.Fatalf("expected job id 222 in log-fetch args, got: %v", logCall.Args)
+		// The log fetch must target the parsed job id.
+		if len(forge.calls) != 2 || forge.calls[1] != "RunFailedJobLog 222" {
+			t.Fatalf("expected PRChecks then RunFailedJobLog 222, got %v", forge.calls)
 		}
 	})
 
 	t.Run("failing check with a non-Actions link falls back cleanly, no crash", func(t *testing.T) {
-		pm, ghBase, jqBase := newPRSetup()
-		raw := `[{"name":"external","state":"FAILURE","bucket":"fail","link":"https://ci.example.com/build/1"}]`
-		formatted := "FAILURE\texternal  https://ci.example.com/build/1"
-
-		ghBase.SetExecCommandResult(raw, "", nil)
-		jqBase.SetExecCommandResult(formatted, "", nil)
+		pm, forge := newPRSetup()
+		forge.checks = `[{"name":"external","state":"FAILURE","bucket":"fail","link":"https://ci.example.com/build/1"}]`
 
 		out, err := pm.PRChecks("42")
 		if err != nil {
@@ -374,30 +452,21 @@ func TestPRChecksDigest(t *testing.T) {
 			t.Fatalf("unexpected output:\n%s", out)
 		}
 		// No RunFailedJobLog call should be attempted for an unparseable link.
-		if ghBase.GetExecCommandCallCount() != 1 {
-			t.Fatalf("expected exactly 1 gh call, got %d", ghBase.GetExecCommandCallCount())
+		if len(forge.calls) != 1 {
+			t.Fatalf("expected exactly 1 forge call, got %v", forge.calls)
 		}
 	})
 
 	t.Run(
 		"mixed pass, pending, and fail: only the failing check gets a digest",
 		func(t *testing.T) {
-			pm, ghBase, jqBase := newPRSetup()
-			raw := `[
+			pm, forge := newPRSetup()
+			forge.checks = `[
 			{"name":"build","state":"SUCCESS","bucket":"pass","link":""},
 			{"name":"deploy","state":"PENDING","bucket":"pending","link":""},
 			{"name":"test","state":"FAILURE","bucket":"fail","link":"https://github.com/octocat/hello/actions/runs/1/job/2"}
 		]`
-			formatted := "SUCCESS\tbuild\n" +
-				"PENDING\tdeploy\n" +
-				"FAILURE\ttest  https://github.com/octocat/hello/actions/runs/1/job/2"
-			logContent := "job\tstep\t2026-07-22T18:32:18.0000000Z it broke\n"
-
-			ghBase.SetExecCommandResults(
-				commands.ExecCommandResult(raw, "", nil),
-				commands.ExecCommandResult(logContent, "", nil),
-			)
-			jqBase.SetExecCommandResult(formatted, "", nil)
+			forge.jobLog = "job\tstep\t2026-07-22T18:32:18.0000000Z it broke\n"
 
 			out, err := pm.PRChecks("42")
 			if err != nil {
@@ -418,21 +487,15 @@ func TestPRChecksDigest(t *testing.T) {
 	t.Run(
 		"log fetch error falls back to a note instead of failing the whole call",
 		func(t *testing.T) {
-			pm, ghBase, jqBase := newPRSetup()
-			raw := `[{"name":"test","state":"FAILURE","bucket":"fail","link":"https://github.com/o/r/actions/runs/1/job/2"}]`
-			formatted := "FAILURE\ttest  https://github.com/o/r/actions/runs/1/job/2"
-
-			ghBase.SetExecCommandResults(
-				commands.ExecCommandResult(raw, "", nil),
-				commands.ExecCommandResult("", "boom", fmt.Errorf("exit 1")),
-			)
-			jqBase.SetExecCommandResult(formatted, "", nil)
+			pm, forge := newPRSetup()
+			forge.checks = `[{"name":"test","state":"FAILURE","bucket":"fail","link":"https://github.com/o/r/actions/runs/1/job/2"}]`
+			forge.jobLogErr = fmt.Errorf("boom")
 
 			out, err := pm.PRChecks("42")
 			if err != nil {
 				t.Fatalf("unexpected error: %v", err)
 			}
-			if !strings.Contains(out, "log unavailable:") {
+			if !strings.Contains(out, "log unavailable: boom") {
 				t.Fatalf("expected a log-unavailable note, got: %q", out)
 			}
 		},
@@ -441,15 +504,8 @@ func TestPRChecksDigest(t *testing.T) {
 	t.Run(
 		"empty but error-free log fetch (permission-gated) falls back to a note",
 		func(t *testing.T) {
-			pm, ghBase, jqBase := newPRSetup()
-			raw := `[{"name":"test","state":"FAILURE","bucket":"fail","link":"https://github.com/o/r/actions/runs/1/job/2"}]`
-			formatted := "FAILURE\ttest  https://github.com/o/r/actions/runs/1/job/2"
-
-			ghBase.SetExecCommandResults(
-				commands.ExecCommandResult(raw, "", nil),
-				commands.ExecCommandResult("", "", nil),
-			)
-			jqBase.SetExecCommandResult(formatted, "", nil)
+			pm, forge := newPRSetup()
+			forge.checks = `[{"name":"test","state":"FAILURE","bucket":"fail","link":"https://github.com/o/r/actions/runs/1/job/2"}]`
 
 			out, err := pm.PRChecks("42")
 			if err != nil {
@@ -463,10 +519,9 @@ func TestPRChecksDigest(t *testing.T) {
 		},
 	)
 
-	t.Run("no checks sentinel passes through untouched", func(t *testing.T) {
-		pm, ghBase, jqBase := newPRSetup()
-		ghBase.SetExecCommandResult("[]", "", nil)
-		jqBase.SetExecCommandResult("No checks.", "", nil)
+	t.Run("no checks renders a short note", func(t *testing.T) {
+		pm, forge := newPRSetup()
+		forge.checks = "[]"
 
 		out, err := pm.PRChecks("42")
 		if err != nil {
@@ -475,8 +530,8 @@ func TestPRChecksDigest(t *testing.T) {
 		if out != "No checks." {
 			t.Fatalf("unexpected output: %q", out)
 		}
-		if ghBase.GetExecCommandCallCount() != 1 {
-			t.Fatalf("expected exactly 1 gh call, got %d", ghBase.GetExecCommandCallCount())
+		if len(forge.calls) != 1 {
+			t.Fatalf("expected exactly 1 forge call, got %v", forge.calls)
 		}
 	})
 }
@@ -484,12 +539,13 @@ func TestPRChecksDigest(t *testing.T) {
 // TestFailingCheckDigestBudget exercises the per-check/total digest size
 // bounding directly against the unexported orchestration helper.
 func TestFailingCheckDigestBudget(t *testing.T) {
-	t.Run("budget already spent: no gh call, one-line note", func(t *testing.T) {
-		pm, ghBase, _ := newPRSetup()
-		check := prCheck{
-			Name: "test", State: "FAILURE", Bucket: "fail",
-			Link: "https://github.com/o/r/actions/runs/1/job/2",
-		}
+	check := prCheck{
+		Name: "test", State: "FAILURE", Bucket: "fail",
+		Link: "https://github.com/o/r/actions/runs/1/job/2",
+	}
+
+	t.Run("budget already spent: no log fetch, one-line note", func(t *testing.T) {
+		pm, forge := newPRSetup()
 
 		lines, spent := pm.failingCheckDigest(check, 0)
 		if spent != 0 {
@@ -498,22 +554,18 @@ func TestFailingCheckDigestBudget(t *testing.T) {
 		if len(lines) != 1 || !strings.Contains(lines[0], "total digest size bound reached") {
 			t.Fatalf("unexpected lines: %v", lines)
 		}
-		if ghBase.GetExecCommandCallCount() != 0 {
-			t.Fatal("expected no gh call once budget is exhausted")
+		if len(forge.calls) != 0 {
+			t.Fatalf("expected no forge call once budget is exhausted, got %v", forge.calls)
 		}
 	})
 
 	t.Run("budget smaller than per-check max caps the digest", func(t *testing.T) {
-		pm, ghBase, _ := newPRSetup()
-		check := prCheck{
-			Name: "test", State: "FAILURE", Bucket: "fail",
-			Link: "https://github.com/o/r/actions/runs/1/job/2",
-		}
+		pm, forge := newPRSetup()
 		var b strings.Builder
 		for i := 0; i < 50; i++ {
 			b.WriteString("job\tstep\t2026-07-22T18:32:18.0000000Z log line\n")
 		}
-		ghBase.SetExecCommandResult(b.String(), "", nil)
+		forge.jobLog = b.String()
 
 		lines, spent := pm.failingCheckDigest(check, 3)
 		if spent > 3 {
@@ -527,63 +579,61 @@ func TestFailingCheckDigestBudget(t *testing.T) {
 }
 
 func TestPRConfirmations(t *testing.T) {
-	t.Run("resolve thread", func(t *testing.T) {
-		pm, ghBase, _ := newPRSetup()
-		ghBase.SetExecCommandResult(`{"data":{}}`, "", nil)
-
-		out, err := pm.ResolveThread("PRRT_x")
-		if err != nil {
-			t.Fatalf("unexpected error: %v", err)
-		}
-		if out != "Resolved thread PRRT_x" {
-			t.Fatalf("unexpected confirmation: %q", out)
-		}
-	})
-
-	t.Run("approve with explicit pr", func(t *testing.T) {
-		pm, ghBase, _ := newPRSetup()
-		ghBase.SetExecCommandResult("", "", nil)
-
-		out, err := pm.ApprovePR("7", "LGTM")
-		if err != nil {
-			t.Fatalf("unexpected error: %v", err)
-		}
-		if out != "Approved PR #7" {
-			t.Fatalf("unexpected confirmation: %q", out)
-		}
-	})
-
-	t.Run("request review with explicit pr", func(t *testing.T) {
-		pm, ghBase, _ := newPRSetup()
-		ghBase.SetExecCommandResult("", "", nil)
-
-		out, err := pm.RequestReviewPR("7", []string{"octocat", "hubot"})
-		if err != nil {
-			t.Fatalf("unexpected error: %v", err)
-		}
-		if out != "Requested review from octocat, hubot on PR #7" {
-			t.Fatalf("unexpected confirmation: %q", out)
-		}
-	})
-
-	t.Run("merge current branch pr", func(t *testing.T) {
-		pm, ghBase, _ := newPRSetup()
-		ghBase.SetExecCommandResult("", "", nil)
+	cases := []struct {
+		name     string
+		run      func(pm *PRManager) (string, error)
+		want     string
+		wantCall string
+	}{
+		{
+			name:     "resolve thread",
+			run:      func(pm *PRManager) (string, error) { return pm.ResolveThread("PRRT_x") },
+			want:     "Resolved thread PRRT_x",
+			wantCall: "ResolveReviewThread PRRT_x",
+		},
+		{
+			name:     "approve with explicit pr",
+			run:      func(pm *PRManager) (string, error) { return pm.ApprovePR("7", "LGTM") },
+			want:     "Approved PR #7",
+			wantCall: "ApprovePR 7 LGTM",
+		},
+		{
+			name: "request review with explicit pr",
+			run: func(pm *PRManager) (string, error) {
+				return pm.RequestReviewPR("7", []string{"octocat", "hubot"})
+			},
+			want:     "Requested review from octocat, hubot on PR #7",
+			wantCall: "RequestReviewPR 7 octocat hubot",
+		},
+		{
+			name:     "merge current branch pr",
+			run:      func(pm *PRManager) (string, error) { return pm.MergePR("", "squash") },
+			want:     "Merged the current branch's PR",
+			wantCall: "MergePR  squash",
+		},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			pm, forge := newPRSetup()
 
-		out, err := pm.MergePR("", "squash")
-		if err != nil {
-			t.Fatalf("unexpected error: %v", err)
-		}
-		if out != "Merged the current branch's PR" {
-			t.Fatalf("unexpected confirmation: %q", out)
-		}
-	})
+			out, err := c.run(pm)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if out != c.want {
+				t.Fatalf("unexpected confirmation: %q", out)
+			}
+			if len(forge.calls) != 1 || forge.calls[0] != c.wantCall {
+				t.Fatalf("expected forge call %q, got %v", c.wantCall, forge.calls)
+			}
+		})
+	}
 }
 
 func TestCurrentPR(t *testing.T) {
 	t.Run("returns number", func(t *testing.T) {
-		pm, ghBase, _ := newPRSetup()
-		ghBase.SetExecCommandResult("42", "", nil)
+		pm, forge := newPRSetup()
+		forge.prNumber = "42"
 
 		out, err := pm.CurrentPR()
 		if err != nil {
@@ -595,8 +645,7 @@ func TestCurrentPR(t *testing.T) {
 	})
 
 	t.Run("no pr friendly message", func(t *testing.T) {
-		pm, ghBase, _ := newPRSetup()
-		ghBase.SetExecCommandResult("", `no pull requests found for branch "feat"`, commandsErr())
+		pm, _ := newPRSetup()
 
 		out, err := pm.CurrentPR()
 		if err != nil {
@@ -606,6 +655,15 @@ func TestCurrentPR(t *testing.T) {
 			t.Fatalf("unexpected message: %q", out)
 		}
 	})
+
+	t.Run("lookup failure propagates", func(t *testing.T) {
+		pm, forge := newPRSetup()
+		forge.prNumberErr = commandsErr()
+
+		if _, err := pm.CurrentPR(); err == nil {
+			t.Fatal("expected the lookup error to propagate")
+		}
+	})
 }
 
 func TestReviewEventForVerdict(t *testing.T) {
@@ -672,13 +730,36 @@ func TestBuildReviewPayload(t *testing.T) {
 	})
 }
 
+func TestRequestChangesPR(t *testing.T) {
+	t.Run("reports the verdict", func(t *testing.T) {
+		pm, _ := newPRSetup()
+		out, err := pm.RequestChangesPR("42", "Please fix")
+		if err != nil || out != "Requested changes on PR #42" {
+			t.Fatalf("unexpected result %q, %v", out, err)
+		}
+	})
+
+	t.Run("a forge without the verdict reports a comment", func(t *testing.T) {
+		pm, forge := newPRSetup()
+		forge.verdictErr = gitlabcli.ErrNoRequestChanges
+		out, err := pm.RequestChangesPR("42", "Please fix")
+		if err != nil || out != "Commented on PR #42 (GitLab has no request-changes verdict)" {
+			t.Fatalf("unexpected result %q, %v", out, err)
+		}
+	})
+
+	t.Run("other forge errors surface", func(t *testing.T) {
+		pm, forge := newPRSetup()
+		forge.verdictErr = fmt.Errorf("boom")
+		if _, err := pm.RequestChangesPR("42", "Please fix"); err == nil {
+			t.Fatal("expected the forge error")
+		}
+	})
+}
+
 func TestSubmitReview(t *testing.T) {
-	t.Run("posts review to the resolved endpoint with inline comments", func(t *testing.T) {
-		pm, ghBase, _ := newPRSetup()
-		ghBase.SetExecCommandResults(
-			commands.ExecCommandResult("octocat/hello", "", nil), // CurrentRepo
-			commands.ExecCommandResult(`{"id":1}`, "", nil),      // CreateReview
-		)
+	t.Run("posts review for the resolved repo with inline comments", func(t *testing.T) {
+		pm, forge := newPRSetup()
 
 		out, err := pm.SubmitReview(
 			"42",
@@ -693,38 +774,47 @@ func TestSubmitReview(t *testing.T) {
 			t.Fatalf("unexpected confirmation: %q", out)
 		}
 
-		call := ghBase.ExecCommandCalls[len(ghBase.ExecCommandCalls)-1]
-		joined := strings.Join(call.Args, " ")
-		if !strings.Contains(joined, "/repos/octocat/hello/pulls/42/reviews") {
-			t.Fatalf("missing reviews endpoint: %v", call.Args)
+		last := forge.calls[len(forge.calls)-1]
+		if !strings.HasPrefix(last, "CreateReview octocat hello 42 ") {
+			t.Fatalf("expected a review on octocat/hello#42, got %q", last)
+		}
+		if !strings.Contains(last, `"event":"REQUEST_CHANGES"`) ||
+			!strings.Contains(last, `"path":"a.go"`) {
+			t.Fatalf("expected the built payload, got %q", last)
 		}
-		if !strings.Contains(joined, "--input") {
-			t.Fatalf("expected payload passed via --input: %v", call.Args)
+	})
+
+	t.Run("a forge without request-changes reports a comment review", func(t *testing.T) {
+		pm, forge := newPRSetup()
+		forge.verdictErr = gitlabcli.ErrNoRequestChanges
+		out, err := pm.SubmitReview("42", "request-changes", "Please fix", "")
+		if err != nil || out != "Submitted comment review on PR #42 (GitLab has no request-changes verdict)" {
+			t.Fatalf("unexpected result %q, %v", out, err)
 		}
 	})
 
-	t.Run("invalid verdict errors before any gh call", func(t *testing.T) {
-		pm, ghBase, _ := newPRSetup()
+	t.Run("invalid verdict errors before any forge call", func(t *testing.T) {
+		pm, forge := newPRSetup()
 		if _, err := pm.SubmitReview("42", "bogus", "x", ""); err == nil {
 			t.Fatal("expected error for invalid verdict")
 		}
-		if ghBase.GetExecCommandCallCount() != 0 {
-			t.Fatal("expected no gh call when the verdict is invalid")
+		if len(forge.calls) != 0 {
+			t.Fatalf("expected no forge call when the verdict is invalid, got %v", forge.calls)
 		}
 	})
 
-	t.Run("malformed comments error before any gh call", func(t *testing.T) {
-		pm, ghBase, _ := newPRSetup()
+	t.Run("malformed comments error before any forge call", func(t *testing.T) {
+		pm, forge := newPRSetup()
 		if _, err := pm.SubmitReview("42", "comment", "body", "{bad"); err == nil {
 			t.Fatal("expected error for malformed comments")
 		}
-		if ghBase.GetExecCommandCallCount() != 0 {
-			t.Fatal("expected no gh call when comments are malformed")
+		if len(forge.calls) != 0 {
+			t.Fatalf("expected no forge call when comments are malformed, got %v", forge.calls)
 		}
 	})
 }
 
-// commandsErr returns a non-nil error for simulating gh's non-zero exit.
+// commandsErr returns a non-nil error for simulating a command's non-zero exit.
 func commandsErr() error { return &execErr{} }
 
 type execErr struct{}
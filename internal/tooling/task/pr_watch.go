@@ -0,0 +1,144 @@
+package task
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+)
+
+// Poll intervals for WatchChecks. The first re-poll comes soon, since a
+// quick lint job may already be done, and later ones back off toward a
+// minute: most CI takes minutes, and GitHub's check reads are ETag-cached
+// so an unchanged poll costs no quota anyway. The interval resets when new
+// commits are pushed.
+const (
+	watchInitialInterval = 10 * time.Second
+	watchMaxInterval     = time.Minute
+)
+
+// watchNoChecksGrace is how long WatchChecks keeps polling a head commit
+// that has no checks at all before concluding the PR has none configured:
+// right after a push the forge takes a few seconds to register them.
+const watchNoChecksGrace = 2 * time.Minute
+
+// ChecksOutcome is how a PR's checks stood when WatchChecks returned.
+type ChecksOutcome int
+
+const (
+	// ChecksPassed means every check settled without a failure or a
+	// cancellation (or the PR has no checks).
+	ChecksPassed ChecksOutcome = iota
+	// ChecksFailed means every check settled and at least one failed or
+	// was cancelled.
+	ChecksFailed
+	// ChecksTimedOut means the timeout ran out with checks still pending.
+	ChecksTimedOut
+)
+
+// WatchChecks polls a PR's checks, backing off between polls, until none
+// is pending or timeout elapses (timeout <= 0 waits indefinitely). It
+// returns a one-line verdict followed by the same report PRChecks prints,
+// failing-check log digests included, plus the outcome. When new commits
+// are pushed mid-watch the watch restarts on the new head (with a note in
+// the report), since the old head's results no longer describe the PR.
+// The outcome is meaningless when err is non-nil.
+func (p *PRManager) WatchChecks(prNumber string, timeout time.Duration) (string, ChecksOutcome, error) {
+	now, sleep := p.now, p.sleep
+	if now == nil {
+		now = time.Now
+	}
+	if sleep == nil {
+		sleep = time.Sleep
+	}
+	start := now()
+	var deadline time.Time
+	if timeout > 0 {
+		deadline = start.Add(timeout)
+	}
+
+	head, err := p.Forge.PRHeadSHA(prNumber)
+	if err != nil {
+		return "", ChecksFailed, err
+	}
+	var notes []string
+	watchStart := start
+	interval := watchInitialInterval
+	for {
+		checks, err := p.fetchChecks(prNumber)
+		if err != nil {
+			return "", ChecksFailed, err
+		}
+		// Read the head after the checks: if it moved, these checks may
+		// belong to either commit, so start over on the new one.
+		latest, err := p.Forge.PRHeadSHA(prNumber)
+		if err != nil {
+			return "", ChecksFailed, err
+		}
+		if latest != head {
+			notes = append(notes, fmt.Sprintf(
+				"New commits pushed (%s -> %s); restarted the watch.",
+				shortSHA(head), shortSHA(latest),
+			))
+			head, watchStart, interval = latest, now(), watchInitialInterval
+			continue
+		}
+
+		pending, failed := countChecks(checks)
+		settled := pending == 0 && (len(checks) > 0 || now().Sub(watchStart) >= watchNoChecksGrace)
+		if settled {
+			outcome, verdict := ChecksPassed, "All checks passed."
+			switch {
+			case len(checks) == 0:
+				verdict = "No checks reported."
+			case failed > 0:
+				outcome = ChecksFailed
+				verdict = fmt.Sprintf("%d of %d checks failed.", failed, len(checks))
+			}
+			return watchReport(notes, verdict, p.renderChecks(checks)), outcome, nil
+		}
+
+		wait := interval
+		if !deadline.IsZero() {
+			left := deadline.Sub(now())
+			if left <= 0 {
+				verdict := fmt.Sprintf(
+					"Timed out after %s: %d of %d checks still pending.",
+					timeout, pending, len(checks),
+				)
+				return watchReport(notes, verdict, p.renderChecks(checks)), ChecksTimedOut, nil
+			}
+			wait = min(wait, left)
+		}
+		sleep(wait)
+		interval = min(interval*3/2, watchMaxInterval)
+	}
+}
+
+// countChecks tallies the checks still pending and those that settled
+// badly. Unlike isFailingCheck, a cancelled check counts as failed here:
+// it has no log worth a digest, but it didn't pass either.
+func countChecks(checks []prCheck) (pending, failed int) {
+	for _, c := range checks {
+		switch strings.ToLower(strings.TrimSpace(c.Bucket)) {
+		case "pending":
+			pending++
+		case "fail", "cancel":
+			failed++
+		}
+	}
+	return pending, failed
+}
+
+// watchReport joins WatchChecks' restart notes, verdict, and check report.
+func watchReport(notes []string, verdict, checks string) string {
+	return strings.Join(slices.Concat(notes, []string{verdict, "", checks}), "\n")
+}
+
+// shortSHA abbreviates a commit hash for messages.
+func shortSHA(sha string) string {
+	if len(sha) > 7 {
+		return sha[:7]
+	}
+	return sha
+}
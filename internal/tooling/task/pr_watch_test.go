@@ -0,0 +1,176 @@
+package task
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+const (
+	pendingChecksJSON = `[
+		{"name":"build","state":"SUCCESS","bucket":"pass"},
+		{"name":"test","state":"IN_PROGRESS","bucket":"pending"}
+	]`
+	passedChecksJSON = `[
+		{"name":"build","state":"SUCCESS","bucket":"pass"},
+		{"name":"test","state":"SUCCESS","bucket":"pass"}
+	]`
+)
+
+// newWatchSetup builds a PRManager over a fakeForge with a fake clock that
+// advances by whatever WatchChecks sleeps, which it records.
+func newWatchSetup() (*PRManager, *fakeForge, *[]time.Duration) {
+	pm, forge := newPRSetup()
+	clock := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	var slept []time.Duration
+	pm.now = func() time.Time { return clock }
+	pm.sleep = func(d time.Duration) {
+		slept = append(slept, d)
+		clock = clock.Add(d)
+	}
+	return pm, forge, &slept
+}
+
+func TestWatchChecks(t *testing.T) {
+	t.Run("polls until every check settles", func(t *testing.T) {
+		pm, forge, slept := newWatchSetup()
+		forge.checksSeq = []string{pendingChecksJSON, pendingChecksJSON, passedChecksJSON}
+
+		out, outcome, err := pm.WatchChecks("42", 30*time.Minute)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if outcome != ChecksPassed {
+			t.Errorf("expected ChecksPassed, got %v", outcome)
+		}
+		if out != "All checks passed.\n\nSUCCESS\tbuild\nSUCCESS\ttest" {
+			t.Fatalf("unexpected output: %q", out)
+		}
+		want := []time.Duration{10 * time.Second, 15 * time.Second}
+		if len(*slept) != len(want) || (*slept)[0] != want[0] || (*slept)[1] != want[1] {
+			t.Errorf("expected backoff %v, got %v", want, *slept)
+		}
+	})
+
+	t.Run("failing checks get the pr-checks digest", func(t *testing.T) {
+		pm, forge, _ := newWatchSetup()
+		forge.checks = `[
+			{"name":"build","state":"SUCCESS","bucket":"pass"},
+			{"name":"test","state":"FAILURE","bucket":"fail","link":"https://github.com/octocat/hello/actions/runs/111/job/222"}
+		]`
+		forge.jobLog = "job\tstep\t2026-07-22T18:32:19.0000000Z exit status 1\n"
+
+		out, outcome, err := pm.WatchChecks("42", 30*time.Minute)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if outcome != ChecksFailed {
+			t.Errorf("expected ChecksFailed, got %v", outcome)
+		}
+		if !strings.HasPrefix(out, "1 of 2 checks failed.\n\n") ||
+			!strings.HasSuffix(out, "\n    exit status 1") {
+			t.Fatalf("unexpected output:\n%s", out)
+		}
+	})
+
+	t.Run("times out with checks still pending", func(t *testing.T) {
+		pm, forge, slept := newWatchSetup()
+		forge.checks = pendingChecksJSON
+
+		out, outcome, err := pm.WatchChecks("42", 25*time.Second)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if outcome != ChecksTimedOut {
+			t.Errorf("expected ChecksTimedOut, got %v", outcome)
+		}
+		if !strings.HasPrefix(out, "Timed out after 25s: 1 of 2 checks still pending.\n\n") {
+			t.Fatalf("unexpected output:\n%s", out)
+		}
+		// The second wait is cut short to land on the deadline.
+		if len(*slept) != 2 || (*slept)[1] != 15*time.Second {
+			t.Errorf("expected waits of 10s and 15s, got %v", *slept)
+		}
+	})
+
+	t.Run("restarts when new commits are pushed", func(t *testing.T) {
+		pm, forge, slept := newWatchSetup()
+		forge.heads = []string{"aaaaaaaaaa", "aaaaaaaaaa", "bbbbbbbbbb"}
+		forge.checksSeq = []string{pendingChecksJSON, passedChecksJSON, pendingChecksJSON, passedChecksJSON}
+
+		out, outcome, err := pm.WatchChecks("42", 30*time.Minute)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if outcome != ChecksPassed {
+			t.Errorf("expected ChecksPassed, got %v", outcome)
+		}
+		if !strings.HasPrefix(out, "New commits pushed (aaaaaaa -> bbbbbbb); restarted the watch.\n"+
+			"All checks passed.\n\n") {
+			t.Fatalf("unexpected output:\n%s", out)
+		}
+		// The old head's passing checks are discarded and the backoff resets.
+		want := []time.Duration{10 * time.Second, 10 * time.Second}
+		if len(*slept) != len(want) || (*slept)[1] != want[1] {
+			t.Errorf("expected waits %v, got %v", want, *slept)
+		}
+	})
+
+	t.Run("no checks settles after the grace period", func(t *testing.T) {
+		pm, forge, slept := newWatchSetup()
+		forge.checks = "[]"
+
+		out, outcome, err := pm.WatchChecks("42", 0)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if outcome != ChecksPassed || out != "No checks reported.\n\nNo checks." {
+			t.Fatalf("unexpected result %v: %q", outcome, out)
+		}
+		var total time.Duration
+		for _, d := range *slept {
+			total += d
+		}
+		if total < watchNoChecksGrace {
+			t.Errorf("expected to wait out the %s grace period, waited %s", watchNoChecksGrace, total)
+		}
+	})
+
+	t.Run("a stale check is settled, not pending", func(t *testing.T) {
+		pm, forge, slept := newWatchSetup()
+		forge.checks = `[
+			{"name":"build","state":"SUCCESS","bucket":"pass"},
+			{"name":"old","state":"STALE","bucket":"skipping"}
+		]`
+
+		out, outcome, err := pm.WatchChecks("42", 30*time.Minute)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if outcome != ChecksPassed || len(*slept) != 0 || !strings.HasPrefix(out, "All checks passed.") {
+			t.Fatalf("expected an immediate pass, got %v after %v:\n%s", outcome, *slept, out)
+		}
+	})
+
+	t.Run("forge errors surface", func(t *testing.T) {
+		pm, forge, _ := newWatchSetup()
+		forge.checks = "not json"
+
+		if _, _, err := pm.WatchChecks("42", time.Minute); err == nil {
+			t.Fatal("expected error for a malformed checks response")
+		}
+	})
+}
+
+func TestCountChecks(t *testing.T) {
+	pending, failed := countChecks([]prCheck{
+		{Bucket: "pass"},
+		{Bucket: "pending"},
+		{Bucket: "fail"},
+		{Bucket: "cancel"},
+		{Bucket: "skipping"},
+	})
+	if pending != 1 || failed != 2 {
+		t.Errorf("expected 1 pending and 2 failed (cancel counts), got %d and %d", pending, failed)
+	}
+}
@@ -25,7 +25,12 @@ type reviewPackageData struct {
 	Included []fileChange
 	Excluded []fileChange
 
-	Diff string
+	// Diff is the diff, or one page of it with PageHeader as its
+	// navigation. Past page 1 the commit list and stat table, already read
+	// on page 1, are left out.
+	Diff       string
+	PageHeader string
+	Page       int
 }
 
 // ReviewPackage verifies base and head both resolve to real commits, then
@@ -38,7 +43,13 @@ type reviewPackageData struct {
 //
 // file, when non-empty, bypasses the range/stat gathering and returns only
 // that file's -U10 diff — the same escape hatch BranchDiff offers via --file.
-func (tm *TaskManager) ReviewPackage(base, head, file string) (string, error) {
+//
+// maxTokens and page page the diff the way BranchDiff does (see pageDiff);
+// page 1's budget also covers the commit list and stat table.
+func (tm *TaskManager) ReviewPackage(base, head, file string, maxTokens, page int) (string, error) {
+	if err := validateDiffPaging(maxTokens, page); err != nil {
+		return "", fmt.Errorf("review-package: %w", err)
+	}
 	if err := tm.verifyRef(base); err != nil {
 		return "", fmt.Errorf("review-package: %w", err)
 	}
@@ -49,9 +60,9 @@ func (tm *TaskManager) ReviewPackage(base, head, file string) (string, error) {
 	rangeSpec := base + ".." + head
 
 	if file != "" {
-		return tm.reviewPackageFile(rangeSpec, base, head, file)
+		return tm.reviewPackageFile(rangeSpec, base, head, file, maxTokens, page)
 	}
-	return tm.reviewPackageAll(rangeSpec, base, head)
+	return tm.reviewPackageAll(rangeSpec, base, head, maxTokens, page)
 }
 
 // verifyRef confirms ref resolves to a real commit before any other work
@@ -69,7 +80,7 @@ func (tm *TaskManager) verifyRef(ref string) error {
 // rangeSpec, then the -U10 diff of the included (non-excluded) files only —
 // skipped entirely when nothing is included, so a range that touches only
 // lockfiles doesn't pay for a diff call whose output is discarded.
-func (tm *TaskManager) reviewPackageAll(rangeSpec, base, head string) (string, error) {
+func (tm *TaskManager) reviewPackageAll(rangeSpec, base, head string, maxTokens, page int) (string, error) {
 	commitsOut, err := tm.Git.RunCapture("log", "--format=%h%x09%as%x09%s", "--reverse", rangeSpec)
 	if err != nil {
 		return "", fmt.Errorf("review-package: %w", err)
@@ -83,31 +94,49 @@ func (tm *TaskManager) reviewPackageAll(rangeSpec, base, head string) (string, e
 	if err != nil {
 		return "", fmt.Errorf("review-package: %w", err)
 	}
-	included, excluded := partitionExcluded(files)
+	ex, err := loadExclusions(tm.Git, "")
+	if err != nil {
+		return "", fmt.Errorf("review-package: %w", err)
+	}
+	included, excluded, err := ex.partition(files)
+	if err != nil {
+		return "", fmt.Errorf("review-package: %w", err)
+	}
 
 	var diff string
 	if len(included) > 0 {
-		args := append([]string{"diff", "-U10", rangeSpec, "--", "."}, exclusionPathspecs()...)
+		args := append([]string{"diff", "-U10", rangeSpec, "--", "."}, ex.pathspecs()...)
 		diff, err = tm.Git.RunCapture(args...)
 		if err != nil {
 			return "", fmt.Errorf("review-package: %w", err)
 		}
 	}
-
-	return formatReviewPackage(reviewPackageData{
-		Base:     base,
-		Head:     head,
-		Commits:  commits,
-		Included: included,
-		Excluded: excluded,
-		Diff:     diff,
-	}), nil
+	command := fmt.Sprintf("dg task review-package %s %s", base, head)
+	out, err := pageDiff(diff, maxTokens, page, command, func(header, diff string, page int) string {
+		return formatReviewPackage(reviewPackageData{
+			Base:       base,
+			Head:       head,
+			Commits:    commits,
+			Included:   included,
+			Excluded:   excluded,
+			Diff:       diff,
+			PageHeader: header,
+			Page:       page,
+		})
+	})
+	if err != nil {
+		return "", fmt.Errorf("review-package: %w", err)
+	}
+	return out, nil
 }
 
 // reviewPackageFile returns file's -U10 diff over rangeSpec, without
 // exclusions — file is passed as its own argv element (never
 // shell-interpolated), so it needs no escaping.
-func (tm *TaskManager) reviewPackageFile(rangeSpec, base, head, file string) (string, error) {
+func (tm *TaskManager) reviewPackageFile(
+	rangeSpec, base, head, file string,
+	maxTokens, page int,
+) (string, error) {
 	diff, err := tm.Git.RunCapture("diff", "-U10", rangeSpec, "--", file)
 	if err != nil {
 		return "", fmt.Errorf("review-package: %w", err)
@@ -115,7 +144,12 @@ func (tm *TaskManager) reviewPackageFile(rangeSpec, base, head, file string) (st
 	if strings.TrimSpace(diff) == "" {
 		return fmt.Sprintf("No changes for %s in %s..%s.", file, base, head), nil
 	}
-	return diff, nil
+	command := fmt.Sprintf("dg task review-package %s %s --file %s", base, head, file)
+	out, err := pageDiff(diff, maxTokens, page, command, plainPage)
+	if err != nil {
+		return "", fmt.Errorf("review-package: %w", err)
+	}
+	return out, nil
 }
 
 // parseReviewPackageCommitLog parses `git log --format=%h%x09%as%x09%s`
@@ -154,6 +188,12 @@ func parseReviewPackageCommitLog(raw string) ([]reviewPackageCommit, error) {
 func formatReviewPackage(d reviewPackageData) string {
 	var b strings.Builder
 	fmt.Fprintf(&b, "range: %s..%s\n", d.Base, d.Head)
+	if d.Page > 1 {
+		b.WriteString(d.PageHeader)
+		b.WriteString("\n")
+		writeFencedDiff(&b, d.Diff)
+		return strings.TrimRight(b.String(), "\n")
+	}
 
 	b.WriteString("commits:\n")
 	if len(d.Commits) == 0 {
@@ -178,14 +218,26 @@ func formatReviewPackage(d reviewPackageData) string {
 		b.WriteString("\n")
 	}
 
-	if strings.TrimSpace(d.Diff) != "" {
-		b.WriteString("\n```diff\n")
-		b.WriteString(d.Diff)
-		if !strings.HasSuffix(d.Diff, "\n") {
-			b.WriteString("\n")
-		}
-		b.WriteString("```")
+	if d.PageHeader != "" {
+		b.WriteString("\n")
+		b.WriteString(d.PageHeader)
+		b.WriteString("\n")
 	}
+	writeFencedDiff(&b, d.Diff)
 
 	return strings.TrimRight(b.String(), "\n")
 }
+
+// writeFencedDiff writes diff as a ` ```diff ` block after a blank line, or
+// nothing when diff is empty.
+func writeFencedDiff(b *strings.Builder, diff string) {
+	if strings.TrimSpace(diff) == "" {
+		return
+	}
+	b.WriteString("\n```diff\n")
+	b.WriteString(diff)
+	if !strings.HasSuffix(diff, "\n") {
+		b.WriteString("\n")
+	}
+	b.WriteString("```")
+}
@@ -174,10 +174,12 @@ func TestReviewPackage(t *testing.T) {
 				"M\tinternal/tooling/task/task.go\n"+
 					"M\tgo.sum\n", "", nil,
 			), // name-status
+			commands.ExecCommandResult("/repo\n", "", nil),                   // rev-parse --show-toplevel
+			commands.ExecCommandResult("", "", nil),                          // check-attr
 			commands.ExecCommandResult("diff --git a/x b/x\n+hi\n", "", nil), // diff -U10
 		)
 
-		out, err := tm.ReviewPackage("main", "feat", "")
+		out, err := tm.ReviewPackage("main", "feat", "", 0, 0)
 		if err != nil {
 			t.Fatalf("unexpected error: %v", err)
 		}
@@ -189,7 +191,7 @@ func TestReviewPackage(t *testing.T) {
 			"files (1):",
 			"M  internal/tooling/task/task.go  +120/-30",
 			"total: +120/-30",
-			"excluded (see `dg task review-package main feat --file <path>` to inspect): go.sum (+40/-12)",
+			"excluded (see `dg task review-package main feat --file <path>` to inspect): go.sum (+40/-12; default)",
 			"```diff",
 			"diff --git a/x b/x",
 			"```",
@@ -219,6 +221,64 @@ func TestReviewPackage(t *testing.T) {
 		}
 	})
 
+	t.Run("--max-tokens past page 1 drops the commit list and stat table", func(t *testing.T) {
+		tm, gitBase, _ := newTaskSetup()
+		gitBase.SetExecCommandResults(
+			commands.ExecCommandResult("abc1234\n", "", nil),
+			commands.ExecCommandResult("def5678\n", "", nil),
+			commands.ExecCommandResult("abc1234\t2026-07-20\tfeat: add thing\n", "", nil),
+			commands.ExecCommandResult("1\t1\tsmall.go\n2\t2\tbig.go\n-\t-\tlogo.png\n", "", nil),
+			commands.ExecCommandResult("M\tsmall.go\nM\tbig.go\nM\tlogo.png\n", "", nil),
+			commands.ExecCommandResult("/repo\n", "", nil),
+			commands.ExecCommandResult("", "", nil), // check-attr
+			commands.ExecCommandResult(pagingFixture, "", nil),
+		)
+
+		out, err := tm.ReviewPackage("main", "feat", "", 100, 4)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		want := "range: main..feat\n" +
+			"showing page 4 of 4 (last)\n\n" +
+			"```diff\n" +
+			"diff --git a/logo.png b/logo.png\n" +
+			"index 5555555..6666666 100644\n" +
+			"Binary files a/logo.png and b/logo.png differ\n" +
+			"```"
+		if out != want {
+			t.Fatalf("unexpected output:\n%s\n---want---\n%s", out, want)
+		}
+	})
+
+	t.Run("--max-tokens page 1 counts the commit list and stat table", func(t *testing.T) {
+		tm, gitBase, _ := newTaskSetup()
+		gitBase.SetExecCommandResults(
+			commands.ExecCommandResult("abc1234\n", "", nil),
+			commands.ExecCommandResult("def5678\n", "", nil),
+			commands.ExecCommandResult("abc1234\t2026-07-20\tfeat: add thing\n", "", nil),
+			commands.ExecCommandResult("1\t1\tsmall.go\n2\t2\tbig.go\n-\t-\tlogo.png\n", "", nil),
+			commands.ExecCommandResult("M\tsmall.go\nM\tbig.go\nM\tlogo.png\n", "", nil),
+			commands.ExecCommandResult("/repo\n", "", nil),
+			commands.ExecCommandResult("", "", nil), // check-attr
+			commands.ExecCommandResult(pagingFixture, "", nil),
+		)
+
+		out, err := tm.ReviewPackage("main", "feat", "", 100, 0)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		want := "total: +3/-3\n\n" +
+			"diff pages (4, ~tokens):\n" +
+			"page 1  ~0\n"
+		if !strings.Contains(out, want) ||
+			!strings.HasSuffix(out, "next: `dg task review-package main feat --max-tokens 100 --page 2`") {
+			t.Fatalf("expected the stat table, then the contents table, with the diff left to page 2, got:\n%s", out)
+		}
+		if estimateTokens(out) > 100 {
+			t.Fatalf("expected page 1 within budget, got ~%d", estimateTokens(out))
+		}
+	})
+
 	t.Run("unrecognized base ref fails fast with an actionable error", func(t *testing.T) {
 		tm, gitBase, _ := newTaskSetup()
 		gitBase.SetExecCommandResults(
@@ -227,7 +287,7 @@ func TestReviewPackage(t *testing.T) {
 			),
 		)
 
-		_, err := tm.ReviewPackage("bogus", "feat", "")
+		_, err := tm.ReviewPackage("bogus", "feat", "", 0, 0)
 		if err == nil {
 			t.Fatal("expected error")
 		}
@@ -250,7 +310,7 @@ func TestReviewPackage(t *testing.T) {
 				),
 			)
 
-			_, err := tm.ReviewPackage("main", "bogus", "")
+			_, err := tm.ReviewPackage("main", "bogus", "", 0, 0)
 			if err == nil {
 				t.Fatal("expected error")
 			}
@@ -271,20 +331,22 @@ func TestReviewPackage(t *testing.T) {
 			commands.ExecCommandResult("abc1234\t2026-07-20\tchore: bump lockfile\n", "", nil),
 			commands.ExecCommandResult("40\t12\tgo.sum\n", "", nil),
 			commands.ExecCommandResult("M\tgo.sum\n", "", nil),
+			commands.ExecCommandResult("/repo\n", "", nil),
 		)
 
-		out, err := tm.ReviewPackage("main", "feat", "")
+		out, err := tm.ReviewPackage("main", "feat", "", 0, 0)
 		if err != nil {
 			t.Fatalf("unexpected error: %v", err)
 		}
 		if !strings.Contains(out, "No file changes in range.") {
 			t.Fatalf("expected no-file-changes sentinel, got: %q", out)
 		}
-		if !strings.Contains(out, "go.sum (+40/-12)") {
+		if !strings.Contains(out, "go.sum (+40/-12; default)") {
 			t.Fatalf("expected exclusion note, got: %q", out)
 		}
-		if got := gitBase.GetExecCommandCallCount(); got != 5 {
-			t.Fatalf("expected exactly 5 calls (no diff call made), got %d", got)
+		// go.sum matches a pattern, so there's no check-attr call either.
+		if got := gitBase.GetExecCommandCallCount(); got != 6 {
+			t.Fatalf("expected exactly 6 calls (no check-attr or diff call made), got %d", got)
 		}
 	})
 
@@ -298,7 +360,7 @@ func TestReviewPackage(t *testing.T) {
 			commands.ExecCommandResult("", "", nil),
 		)
 
-		out, err := tm.ReviewPackage("HEAD", "HEAD", "")
+		out, err := tm.ReviewPackage("HEAD", "HEAD", "", 0, 0)
 		if err != nil {
 			t.Fatalf("unexpected error: %v", err)
 		}
@@ -320,7 +382,7 @@ func TestReviewPackage(t *testing.T) {
 			commands.ExecCommandResult("diff --git a/go.sum b/go.sum\n+entry\n", "", nil),
 		)
 
-		out, err := tm.ReviewPackage("main", "feat", "go.sum")
+		out, err := tm.ReviewPackage("main", "feat", "go.sum", 0, 0)
 		if err != nil {
 			t.Fatalf("unexpected error: %v", err)
 		}
@@ -347,7 +409,7 @@ func TestReviewPackage(t *testing.T) {
 			commands.ExecCommandResult("", "", nil),
 		)
 
-		out, err := tm.ReviewPackage("main", "feat", "unrelated.go")
+		out, err := tm.ReviewPackage("main", "feat", "unrelated.go", 0, 0)
 		if err != nil {
 			t.Fatalf("unexpected error: %v", err)
 		}
@@ -364,7 +426,7 @@ func TestReviewPackage(t *testing.T) {
 			),
 		)
 
-		_, err := tm.ReviewPackage("bogus", "feat", "some/file.go")
+		_, err := tm.ReviewPackage("bogus", "feat", "some/file.go", 0, 0)
 		if err == nil {
 			t.Fatal("expected error")
 		}
@@ -37,12 +37,15 @@ type commit struct {
 // fileChange is one file's row from a merged --numstat / --name-status pair.
 // Status is left empty until merged with --name-status output. Binary files
 // report Added/Removed as 0 (numstat uses "-" for both, per its format).
+// ExcludedBy names the exclusion rule that pulled the file out of review
+// (see exclusions.partition), and is empty for reviewable files.
 type fileChange struct {
-	Path    string
-	Status  string
-	Added   int
-	Removed int
-	Binary  bool
+	Path       string
+	Status     string
+	Added      int
+	Removed    int
+	Binary     bool
+	ExcludedBy string
 }
 
 // scopeData is the orchestration result handed to formatReviewScope.
@@ -113,7 +116,14 @@ func (tm *TaskManager) ReviewScope(bodies bool) (string, error) {
 	if err != nil {
 		return "", fmt.Errorf("review-scope: %w", err)
 	}
-	reviewable, excluded := partitionExcluded(files)
+	ex, err := loadExclusions(tm.Git, "")
+	if err != nil {
+		return "", fmt.Errorf("review-scope: %w", err)
+	}
+	reviewable, excluded, err := ex.partition(files)
+	if err != nil {
+		return "", fmt.Errorf("review-scope: %w", err)
+	}
 
 	return formatReviewScope(scopeData{
 		CurrentBranch: currentBranch,
@@ -296,19 +306,6 @@ func parseNameStatus(raw string) (map[string]string, error) {
 	return statuses, nil
 }
 
-// partitionExcluded splits changes into reviewable and excluded (default
-// lockfile/generated-asset patterns), preserving each list's relative order.
-func partitionExcluded(changes []fileChange) (reviewable, excluded []fileChange) {
-	for _, c := range changes {
-		if isExcludedPath(c.Path) {
-			excluded = append(excluded, c)
-		} else {
-			reviewable = append(reviewable, c)
-		}
-	}
-	return reviewable, excluded
-}
-
 // formatFileStats renders one row per file (status, path, and either
 // "binary" or "+added/-removed") followed by a running "total: +X/-Y" line,
 // with no leading or trailing newline. Shared by formatReviewScope and
@@ -336,7 +333,8 @@ func formatFileStats(files []fileChange) string {
 
 // formatExclusionNotes renders the "excluded (see `<hint>` to inspect): ..."
 // line listing every excluded file (binary files noted as such, others with
-// their +added/-removed counts), with no leading or trailing newline. Returns
+// their +added/-removed counts, then the rule that excluded it when known),
+// with no leading or trailing newline. Returns
 // "" when excluded is empty so callers can test the result instead of the
 // input slice. hint is the follow-up command to print inside the backticks —
 // it differs per call site (review-package needs its own base/head range;
@@ -348,11 +346,14 @@ func formatExclusionNotes(excluded []fileChange, hint string) string {
 	}
 	notes := make([]string, len(excluded))
 	for i, f := range excluded {
+		detail := fmt.Sprintf("+%d/-%d", f.Added, f.Removed)
 		if f.Binary {
-			notes[i] = fmt.Sprintf("%s (binary)", f.Path)
-		} else {
-			notes[i] = fmt.Sprintf("%s (+%d/-%d)", f.Path, f.Added, f.Removed)
+			detail = "binary"
+		}
+		if f.ExcludedBy != "" {
+			detail += "; " + f.ExcludedBy
 		}
+		notes[i] = fmt.Sprintf("%s (%s)", f.Path, detail)
 	}
 	return fmt.Sprintf("excluded (see `%s` to inspect): %s", hint, strings.Join(notes, ", "))
 }
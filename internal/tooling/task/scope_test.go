@@ -181,17 +181,36 @@ func TestParseCommitLog(t *testing.T) {
 }
 
 func TestPartitionExcluded(t *testing.T) {
+	tm, gitBase, _ := newTaskSetup()
+	gitBase.SetExecCommandResult(
+		"internal/tooling/task/task.go\x00linguist-generated\x00unspecified\x00"+
+			"internal/tooling/task/task.go\x00diff\x00unspecified\x00",
+		"",
+		nil,
+	)
+	ex := &exclusions{git: tm.Git, patterns: []exclusionPattern{
+		{Glob: "go.sum", Rule: "default"},
+		{Glob: "package-lock.json", Rule: "default"},
+	}}
 	changes := []fileChange{
 		{Path: "internal/tooling/task/task.go", Status: "M", Added: 120, Removed: 30},
 		{Path: "go.sum", Status: "M", Added: 40, Removed: 12},
 		{Path: "packages/app/package-lock.json", Status: "M", Added: 5, Removed: 1},
 	}
-	reviewable, excluded := partitionExcluded(changes)
+	reviewable, excluded, err := ex.partition(changes)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
 	if len(reviewable) != 1 || reviewable[0].Path != "internal/tooling/task/task.go" {
 		t.Fatalf("unexpected reviewable: %+v", reviewable)
 	}
-	if len(excluded) != 2 {
-		t.Fatalf("expected 2 excluded, got %+v", excluded)
+	if len(excluded) != 2 || excluded[0].ExcludedBy != "default" {
+		t.Fatalf("expected 2 excluded by default, got %+v", excluded)
+	}
+	// Only the path no pattern matched is left for check-attr.
+	if got := gitBase.GetLastExecCommandCall().Args; got[len(got)-1] != "internal/tooling/task/task.go" ||
+		gitBase.GetExecCommandCallCount() != 1 {
+		t.Fatalf("expected one check-attr call for task.go, got %v", gitBase.ExecCommandCalls)
 	}
 }
 
@@ -251,6 +270,20 @@ func TestFormatExclusionNotes(t *testing.T) {
 			}
 		},
 	)
+
+	t.Run("names the rule that excluded each file", func(t *testing.T) {
+		got := formatExclusionNotes([]fileChange{
+			{Path: "go.sum", Added: 40, Removed: 12, ExcludedBy: "default"},
+			{Path: "api/v1.pb.go", Added: 300, Removed: 10, ExcludedBy: "review.yaml *.pb.go"},
+			{Path: "testdata/golden.bin", Binary: true, ExcludedBy: "-diff"},
+		}, "dg task branch-diff --file <path>")
+		want := "excluded (see `dg task branch-diff --file <path>` to inspect): " +
+			"go.sum (+40/-12; default), api/v1.pb.go (+300/-10; review.yaml *.pb.go), " +
+			"testdata/golden.bin (binary; -diff)"
+		if got != want {
+			t.Fatalf("unexpected output:\n%s\n---want---\n%s", got, want)
+		}
+	})
 }
 
 func TestFormatReviewScope(t *testing.T) {
@@ -424,6 +457,8 @@ func TestReviewScope(t *testing.T) {
 					"A\tinternal/tooling/task/scope.go\n"+
 					"M\tgo.sum\n", "", nil,
 			), // diff --name-status --no-renames
+			commands.ExecCommandResult("/repo\n", "", nil), // rev-parse --show-toplevel
+			commands.ExecCommandResult("", "", nil),        // check-attr (nothing marked)
 		)
 
 		out, err := tm.ReviewScope(false)
@@ -439,7 +474,7 @@ func TestReviewScope(t *testing.T) {
 			"M  internal/tooling/task/task.go  +120/-30",
 			"A  internal/tooling/task/scope.go  +200/-0",
 			"total: +320/-30",
-			"excluded (see `dg task branch-diff --file <path>` to inspect): go.sum (+40/-12)",
+			"excluded (see `dg task branch-diff --file <path>` to inspect): go.sum (+40/-12; default)",
 		}
 		for _, want := range wantLines {
 			if !strings.Contains(out, want) {
@@ -1,11 +1,13 @@
 package task
 
 import (
+	"errors"
 	"fmt"
 	"os"
 	"path/filepath"
 	"strings"
 
+	git_app "github.com/cjairm/devgita/internal/apps/git"
 	"github.com/cjairm/devgita/internal/config"
 	"github.com/cjairm/devgita/internal/tooling/worktree"
 )
@@ -34,6 +36,13 @@ func taskWorktreePath(repoSlug, name string) string {
 // repo's freshly-fetched default branch (reusing Git.CreateWorktreeIn's
 // local/remote-branch-reuse logic verbatim); when base is given explicitly,
 // the branch is created fresh from exactly that ref.
+//
+// Once the worktree exists, the repo's bootstrap hooks run in it (see
+// worktree.PrepareWorktree): files are copied/symlinked in and setup
+// commands run with their output streamed and logged under
+// worktree.BootstrapLogPath. A bootstrap failure is appended to the result as
+// a warning line rather than failing the command - the worktree is usable,
+// just not fully set up.
 func (tm *TaskManager) WorktreeStart(name, base string) (string, error) {
 	if strings.TrimSpace(name) == "" {
 		return "", fmt.Errorf("worktree-start: name is required")
@@ -91,7 +100,16 @@ func (tm *TaskManager) WorktreeStart(name, base string) (string, error) {
 		}
 	}
 
-	return fmt.Sprintf("Created worktree %s (branch %s, base %s)", wtPath, name, ref), nil
+	out := fmt.Sprintf("Created worktree %s (branch %s, base %s)", wtPath, name, ref)
+	setup, problems := worktree.PrepareWorktree(repoRoot, wtPath)
+	problems = append(
+		problems,
+		worktree.RunSetup(tm.Base, setup, wtPath, worktree.BootstrapLogPath(repoSlug, name))...,
+	)
+	for _, problem := range problems {
+		out += "\nwarning: bootstrap: " + problem.Error()
+	}
+	return out, nil
 }
 
 // WorktreeFinish tears down a worktree via exactly one of merge or discard.
@@ -262,6 +280,97 @@ func (tm *TaskManager) worktreeFinishMerge(wtPath, branch string) (string, error
 	return fmt.Sprintf("Merged %s into %s; removed worktree %s", branch, defaultBranch, wtPath), nil
 }
 
+// ErrMergeConflicts is returned by MergeWorktreeAt when the pre-merge check
+// finds conflicting files; nothing has been merged.
+var ErrMergeConflicts = errors.New("merge would conflict")
+
+// MergeCheck is what MergeCheckAt found out about merging a worktree's branch
+// into the default branch from the main checkout.
+type MergeCheck struct {
+	Branch       string
+	Base         string // default branch the worktree merges into
+	MainWorktree string
+	Conflicts    []string // files `git merge-tree` reports as conflicting
+}
+
+// MergeCheckAt is the pre-check behind `dg ws`'s merge actions: it refuses
+// what a merge from the TUI can't safely do (a detached or dirty worktree, a
+// dirty main checkout or one not on the default branch), then asks `git
+// merge-tree` which files would conflict. Nothing is written to either
+// checkout, so a conflicting branch never leaves a half-merged repo behind.
+func MergeCheckAt(g *git_app.Git, wtPath string) (MergeCheck, error) {
+	branch, err := g.CurrentBranchIn(wtPath)
+	if err != nil {
+		return MergeCheck{}, fmt.Errorf("failed to check %s's branch: %w", wtPath, err)
+	}
+	if branch == "" {
+		return MergeCheck{}, fmt.Errorf("%s is on a detached HEAD; nothing to merge", wtPath)
+	}
+	check := MergeCheck{Branch: branch, Base: g.DefaultBranchIn(wtPath)}
+
+	check.MainWorktree, err = g.GetMainWorktree(wtPath)
+	if err != nil {
+		return check, fmt.Errorf("failed to resolve main worktree: %w", err)
+	}
+	mainBranch, err := g.CurrentBranchIn(check.MainWorktree)
+	if err != nil {
+		return check, fmt.Errorf("failed to check main checkout's branch: %w", err)
+	}
+	if mainBranch != check.Base {
+		return check, fmt.Errorf(
+			"main checkout %s is on %q, not %q; check out %q there first",
+			check.MainWorktree, mainBranch, check.Base, check.Base,
+		)
+	}
+
+	for _, dir := range []string{wtPath, check.MainWorktree} {
+		dirty, err := g.IsWorktreeDirty(dir)
+		if err != nil {
+			return check, err
+		}
+		if dirty {
+			return check, fmt.Errorf("%s has uncommitted changes; commit or stash them first", dir)
+		}
+	}
+
+	check.Conflicts, err = g.MergeConflictsIn(check.MainWorktree, check.Base, branch)
+	return check, err
+}
+
+// MergeWorktreeAt merges the worktree's branch into the default branch from
+// the main checkout - as a regular merge (fast-forwarding when it can), or
+// as a single squashed commit carrying git's "Squashed commit of the
+// following" message. It runs MergeCheckAt first and stops with
+// ErrMergeConflicts when it finds conflicts, so the merge itself only ever
+// runs on a branch known to merge cleanly. Unlike worktree-finish --merge it
+// leaves the worktree in place: `dg ws` tears down the worktree, window,
+// session and branch afterwards via RemoveWithSessionInRepo.
+func MergeWorktreeAt(g *git_app.Git, wtPath string, squash bool) (MergeCheck, error) {
+	check, err := MergeCheckAt(g, wtPath)
+	if err != nil {
+		return check, err
+	}
+	if len(check.Conflicts) > 0 {
+		return check, ErrMergeConflicts
+	}
+
+	steps := [][]string{{"merge", "--no-edit", check.Branch}}
+	if squash {
+		steps = [][]string{{"merge", "--squash", check.Branch}, {"commit", "--no-edit"}}
+	}
+	for _, args := range steps {
+		if err := g.ExecuteCommandAt(check.MainWorktree, args...); err != nil {
+			// reset --merge backs out a merge or squash that got partway, so
+			// a failure never leaves the main checkout mid-merge.
+			_ = g.ExecuteCommandAt(check.MainWorktree, "reset", "--merge")
+			return check, fmt.Errorf(
+				"merge of %s into %s failed: %w", check.Branch, check.Base, err,
+			)
+		}
+	}
+	return check, nil
+}
+
 // resolveWorktreeTarget implements worktree-finish's deterministic target
 // selection: an explicit name wins; otherwise cwd resolving inside a linked
 // worktree wins; otherwise it errors listing the worktrees it found. It never
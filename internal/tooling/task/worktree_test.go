@@ -1,6 +1,7 @@
 package task
 
 import (
+	"errors"
 	"fmt"
 	"os"
 	"path/filepath"
@@ -144,6 +145,55 @@ func TestWorktreeStart(t *testing.T) {
 		}
 	})
 
+	t.Run("runs the repo's bootstrap hooks and reports failures as warnings", func(t *testing.T) {
+		cleanupPaths := testutil.SetupIsolatedPaths(t)
+		defer cleanupPaths()
+		tm, gitBase, shellBase := newTaskSetup()
+		repoRoot := t.TempDir()
+		repoSlug := filepath.Base(repoRoot)
+		t.Cleanup(func() {
+			_ = os.RemoveAll(filepath.Join(worktree.GetWorktreeBasePath(), repoSlug))
+		})
+		config := "copy:\n  - .env\nsetup:\n  - npm ci\n"
+		if err := os.MkdirAll(filepath.Join(repoRoot, ".devgita"), 0o755); err != nil {
+			t.Fatalf("setup: %v", err)
+		}
+		configPath := filepath.Join(repoRoot, ".devgita", "worktree.yaml")
+		if err := os.WriteFile(configPath, []byte(config), 0o644); err != nil {
+			t.Fatalf("setup: %v", err)
+		}
+		if err := os.WriteFile(filepath.Join(repoRoot, ".env"), []byte("SECRET=1\n"), 0o644); err != nil {
+			t.Fatalf("setup: %v", err)
+		}
+		if _, err := worktree.AllowRepoSetup(repoRoot); err != nil {
+			t.Fatalf("setup: %v", err)
+		}
+
+		gitBase.SetExecCommandResults(
+			commands.ExecCommandResult("", "", nil),            // status --porcelain (clean)
+			commands.ExecCommandResult(repoRoot+"\n", "", nil), // rev-parse --show-toplevel
+			commands.ExecCommandResult("", "", nil),            // fetch origin, worktree add
+		)
+		shellBase.SetExecCommandResult("", "npm ERR!", fmt.Errorf("exit status 1"))
+
+		out, err := tm.WorktreeStart("feat", "origin/main")
+		if err != nil {
+			t.Fatalf("a failing setup command must not fail worktree-start: %v", err)
+		}
+
+		wtPath := filepath.Join(worktree.GetWorktreeBasePath(), repoSlug, "feat")
+		if _, err := os.Stat(filepath.Join(wtPath, ".env")); err != nil {
+			t.Errorf("expected .env to be copied into the worktree: %v", err)
+		}
+		last := shellBase.GetLastExecCommandCall()
+		if last == nil || last.Command != "sh" || last.Dir != wtPath {
+			t.Fatalf("expected npm ci to run in the worktree, got %+v", last)
+		}
+		if !strings.Contains(out, "warning: bootstrap: setup command \"npm ci\" failed") {
+			t.Errorf("expected the setup failure as a warning, got %q", out)
+		}
+	})
+
 	t.Run("errors when the worktree path already exists", func(t *testing.T) {
 		tm, gitBase, _ := newTaskSetup()
 		repoSlug := uniqueRepoSlug(t)
@@ -877,3 +927,160 @@ func TestWorktreeFinish_Merge(t *testing.T) {
 		},
 	)
 }
+
+func TestMergeCheckAt(t *testing.T) {
+	tree := strings.Repeat("ab", 20)
+
+	t.Run("reports the files merge-tree finds conflicting", func(t *testing.T) {
+		tm, gitBase, _ := newTaskSetup()
+		gitBase.SetExecCommandResults(
+			commands.ExecCommandResult("feat\n", "", nil),        // branch --show-current at /wt/feat
+			commands.ExecCommandResult("origin/main\n", "", nil), // DefaultBranchIn
+			commands.ExecCommandResult(
+				"worktree /main\nHEAD def456\nbranch refs/heads/main\n\n", "", nil,
+			), // GetMainWorktree
+			commands.ExecCommandResult("main\n", "", nil), // branch --show-current at /main
+			commands.ExecCommandResult("", "", nil),       // status --porcelain at /wt/feat
+			commands.ExecCommandResult("", "", nil),       // status --porcelain at /main
+			commands.ExecCommandResult(
+				tree+"\nsrc/app.go\n", "", fmt.Errorf("exit 1"),
+			), // merge-tree
+		)
+
+		check, err := MergeCheckAt(tm.Git, "/wt/feat")
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if check.Branch != "feat" || check.Base != "main" || check.MainWorktree != "/main" {
+			t.Errorf("unexpected check: %+v", check)
+		}
+		if len(check.Conflicts) != 1 || check.Conflicts[0] != "src/app.go" {
+			t.Errorf("expected src/app.go to conflict, got %v", check.Conflicts)
+		}
+		assertCmd(t, gitBase.ExecCommandCalls[6], "git",
+			"-C", "/main", "merge-tree", "--write-tree", "--name-only", "--no-messages",
+			"main", "feat")
+	})
+
+	t.Run("refuses a dirty worktree before checking", func(t *testing.T) {
+		tm, gitBase, _ := newTaskSetup()
+		gitBase.SetExecCommandResults(
+			commands.ExecCommandResult("feat\n", "", nil),
+			commands.ExecCommandResult("origin/main\n", "", nil),
+			commands.ExecCommandResult(
+				"worktree /main\nHEAD def456\nbranch refs/heads/main\n\n", "", nil,
+			),
+			commands.ExecCommandResult("main\n", "", nil),
+			commands.ExecCommandResult(" M src/app.go\n", "", nil), // status at /wt/feat
+		)
+
+		_, err := MergeCheckAt(tm.Git, "/wt/feat")
+		if err == nil || !strings.Contains(err.Error(), "/wt/feat has uncommitted changes") {
+			t.Fatalf("expected the dirty worktree to be refused, got %v", err)
+		}
+		if len(gitBase.ExecCommandCalls) != 5 {
+			t.Errorf("expected merge-tree not to run, got %d calls", len(gitBase.ExecCommandCalls))
+		}
+	})
+}
+
+func TestMergeWorktreeAt(t *testing.T) {
+	tree := strings.Repeat("ab", 20)
+
+	t.Run("merge", func(t *testing.T) {
+		tm, gitBase, _ := newTaskSetup()
+		gitBase.SetExecCommandResults(
+			commands.ExecCommandResult("feat\n", "", nil),
+			commands.ExecCommandResult("origin/main\n", "", nil),
+			commands.ExecCommandResult(
+				"worktree /main\nHEAD def456\nbranch refs/heads/main\n\n", "", nil,
+			),
+			commands.ExecCommandResult("main\n", "", nil),
+			commands.ExecCommandResult("", "", nil),
+			commands.ExecCommandResult("", "", nil),
+			commands.ExecCommandResult(tree+"\n", "", nil), // merge-tree: clean
+			commands.ExecCommandResult("", "", nil),        // merge
+		)
+
+		if _, err := MergeWorktreeAt(tm.Git, "/wt/feat", false); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		calls := gitBase.ExecCommandCalls
+		if len(calls) != 8 {
+			t.Fatalf("expected 8 git calls, got %d: %+v", len(calls), calls)
+		}
+		assertCmd(t, calls[7], "git", "-C", "/main", "merge", "--no-edit", "feat")
+	})
+
+	t.Run("squash", func(t *testing.T) {
+		tm, gitBase, _ := newTaskSetup()
+		gitBase.SetExecCommandResults(
+			commands.ExecCommandResult("feat\n", "", nil),
+			commands.ExecCommandResult("origin/main\n", "", nil),
+			commands.ExecCommandResult(
+				"worktree /main\nHEAD def456\nbranch refs/heads/main\n\n", "", nil,
+			),
+			commands.ExecCommandResult("main\n", "", nil),
+			commands.ExecCommandResult("", "", nil),
+			commands.ExecCommandResult("", "", nil),
+			commands.ExecCommandResult(tree+"\n", "", nil), // merge-tree: clean
+			commands.ExecCommandResult("", "", nil),        // merge --squash
+			commands.ExecCommandResult("", "", nil),        // commit
+		)
+
+		if _, err := MergeWorktreeAt(tm.Git, "/wt/feat", true); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		calls := gitBase.ExecCommandCalls
+		assertCmd(t, calls[7], "git", "-C", "/main", "merge", "--squash", "feat")
+		assertCmd(t, calls[8], "git", "-C", "/main", "commit", "--no-edit")
+	})
+
+	t.Run("conflicts stop before merging", func(t *testing.T) {
+		tm, gitBase, _ := newTaskSetup()
+		gitBase.SetExecCommandResults(
+			commands.ExecCommandResult("feat\n", "", nil),
+			commands.ExecCommandResult("origin/main\n", "", nil),
+			commands.ExecCommandResult(
+				"worktree /main\nHEAD def456\nbranch refs/heads/main\n\n", "", nil,
+			),
+			commands.ExecCommandResult("main\n", "", nil),
+			commands.ExecCommandResult("", "", nil),
+			commands.ExecCommandResult("", "", nil),
+			commands.ExecCommandResult(tree+"\nsrc/app.go\n", "", fmt.Errorf("exit 1")),
+		)
+
+		check, err := MergeWorktreeAt(tm.Git, "/wt/feat", false)
+		if !errors.Is(err, ErrMergeConflicts) || len(check.Conflicts) != 1 {
+			t.Fatalf("expected ErrMergeConflicts with the file, got %v, %+v", err, check)
+		}
+		if len(gitBase.ExecCommandCalls) != 7 {
+			t.Errorf("expected no merge to run, got %d calls", len(gitBase.ExecCommandCalls))
+		}
+	})
+
+	t.Run("a failed squash commit is backed out", func(t *testing.T) {
+		tm, gitBase, _ := newTaskSetup()
+		gitBase.SetExecCommandResults(
+			commands.ExecCommandResult("feat\n", "", nil),
+			commands.ExecCommandResult("origin/main\n", "", nil),
+			commands.ExecCommandResult(
+				"worktree /main\nHEAD def456\nbranch refs/heads/main\n\n", "", nil,
+			),
+			commands.ExecCommandResult("main\n", "", nil),
+			commands.ExecCommandResult("", "", nil),
+			commands.ExecCommandResult("", "", nil),
+			commands.ExecCommandResult(tree+"\n", "", nil),                        // merge-tree: clean
+			commands.ExecCommandResult("", "", nil),                               // merge --squash
+			commands.ExecCommandResult("", "hook rejected", fmt.Errorf("exit 1")), // commit
+			commands.ExecCommandResult("", "", nil),                               // reset --merge
+		)
+
+		_, err := MergeWorktreeAt(tm.Git, "/wt/feat", true)
+		if err == nil || !strings.Contains(err.Error(), "hook rejected") {
+			t.Fatalf("expected the commit failure, got %v", err)
+		}
+		calls := gitBase.ExecCommandCalls
+		assertCmd(t, calls[len(calls)-1], "git", "-C", "/main", "reset", "--merge")
+	})
+}
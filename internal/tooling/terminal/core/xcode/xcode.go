@@ -104,9 +104,10 @@ func (x *XcodeCommandLineTools) Update() error {
 
 func (x *XcodeCommandLineTools) isInstalled() (bool, error) {
 	stdout, _, err := x.Base.ExecCommand(cmd.CommandParams{
-		Command: "xcode-select",
-		Args:    []string{"-p"},
-		IsSudo:  false,
+		Command:  "xcode-select",
+		Args:     []string{"-p"},
+		IsSudo:   false,
+		ReadOnly: true,
 	})
 	if err != nil {
 		return false, fmt.Errorf("error running xcode-select: %w", err)
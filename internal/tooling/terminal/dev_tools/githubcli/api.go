@@ -0,0 +1,439 @@
+package githubcli
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/cjairm/devgita/pkg/constants"
+	"github.com/cjairm/devgita/pkg/paths"
+)
+
+// defaultMaxRateLimitWait is how long a request may sleep through a rate
+// limit before giving up: long enough to ride out a secondary-limit
+// Retry-After, short enough that an agent's command timeout isn't hit.
+const defaultMaxRateLimitWait = 30 * time.Second
+
+// Client is an in-process client for GitHub's REST and GraphQL APIs. It
+// replaces `gh api` for the calls PR tasks make on every invocation:
+//
+//   - Auth is a bearer token, resolved once on first use (see
+//     GithubCli.tokenFor: $GH_TOKEN, $GITHUB_TOKEN, then `gh auth token`).
+//   - GET responses are cached on disk keyed by URL and revalidated with
+//     If-None-Match; GitHub answers an unchanged resource with a 304 that
+//     doesn't count against the rate limit, which is what makes polling CI
+//     status cheap.
+//   - Rate limits are tracked per resource (core, graphql, …) from the
+//     X-RateLimit-* headers. A limited request waits out the reset once when
+//     it is within MaxRateLimitWait; otherwise it fails with a RateLimitError
+//     saying how long to wait.
+type Client struct {
+	// RESTURL and GraphQLURL are the API endpoints, e.g.
+	// https://api.github.com and https://api.github.com/graphql.
+	RESTURL    string
+	GraphQLURL string
+	HTTP       *http.Client
+	// Token returns the API token; it is called at most once.
+	Token func() (string, error)
+	// CacheDir holds the ETag response cache; "" disables caching.
+	CacheDir string
+	// MaxRateLimitWait bounds how long a rate-limited request sleeps
+	// before retrying (once); longer waits fail immediately.
+	MaxRateLimitWait time.Duration
+
+	sleep func(time.Duration) // time.Sleep when nil; stubbed in tests
+
+	tokenOnce sync.Once
+	token     string
+	tokenErr  error
+
+	mu     sync.Mutex
+	quotas map[string]RateLimit
+}
+
+// NewClient returns a Client for host ("github.com" or a GitHub Enterprise
+// Server hostname).
+func NewClient(host string, token func() (string, error)) *Client {
+	c := &Client{
+		HTTP:             &http.Client{Timeout: 30 * time.Second},
+		Token:            token,
+		CacheDir:         paths.GetCacheDir(constants.App.Name, "github"),
+		MaxRateLimitWait: defaultMaxRateLimitWait,
+	}
+	if host == "" || host == "github.com" {
+		c.RESTURL, c.GraphQLURL = "https://api.github.com", "https://api.github.com/graphql"
+	} else {
+		c.RESTURL, c.GraphQLURL = "https://"+host+"/api/v3", "https://"+host+"/api/graphql"
+	}
+	return c
+}
+
+// RateLimit is one API resource's quota as of its last response.
+type RateLimit struct {
+	Limit     int
+	Remaining int
+	Reset     time.Time
+}
+
+// Quota returns the last seen quota for a rate-limit resource ("core" for
+// REST, "graphql"), and whether any response has reported it yet.
+func (c *Client) Quota(resource string) (RateLimit, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	q, ok := c.quotas[resource]
+	return q, ok
+}
+
+// RateLimitError is returned when a request is rate limited for longer than
+// MaxRateLimitWait (or is still limited after waiting once).
+type RateLimitError struct {
+	Wait time.Duration
+}
+
+func (e *RateLimitError) Error() string {
+	return fmt.Sprintf(
+		"github: API rate limit exceeded; retry in %s",
+		e.Wait.Round(time.Second),
+	)
+}
+
+// APIError is a non-2xx response other than a rate limit.
+type APIError struct {
+	StatusCode int
+	Message    string
+}
+
+func (e *APIError) Error() string {
+	if e.StatusCode == http.StatusUnauthorized {
+		return "github: authentication failed (set GH_TOKEN or run gh auth login)"
+	}
+	return fmt.Sprintf("github: %s (HTTP %d)", e.Message, e.StatusCode)
+}
+
+func (c *Client) authToken() (string, error) {
+	c.tokenOnce.Do(func() {
+		if c.Token == nil {
+			c.tokenErr = errors.New("github: no token source configured")
+			return
+		}
+		c.token, c.tokenErr = c.Token()
+	})
+	return c.token, c.tokenErr
+}
+
+// do sends one request and returns the response with its body read. A
+// rate-limited response is waited out and retried once when the wait fits
+// within MaxRateLimitWait; any other status is left for the caller.
+func (c *Client) do(method, url string, body []byte, header http.Header) (*http.Response, []byte, error) {
+	token, err := c.authToken()
+	if err != nil {
+		return nil, nil, err
+	}
+	for attempt := 0; ; attempt++ {
+		var reader io.Reader
+		if body != nil {
+			reader = bytes.NewReader(body)
+		}
+		req, err := http.NewRequest(method, url, reader)
+		if err != nil {
+			return nil, nil, fmt.Errorf("github: %w", err)
+		}
+		req.Header.Set("Authorization", "Bearer "+token)
+		req.Header.Set("Accept", "application/vnd.github+json")
+		req.Header.Set("X-GitHub-Api-Version", "2022-11-28")
+		req.Header.Set("User-Agent", constants.App.Name)
+		if body != nil {
+			req.Header.Set("Content-Type", "application/json")
+		}
+		for k, v := range header {
+			req.Header[k] = v
+		}
+
+		resp, err := c.HTTP.Do(req)
+		if err != nil {
+			return nil, nil, fmt.Errorf("github: %w", err)
+		}
+		data, err := io.ReadAll(resp.Body)
+		_ = resp.Body.Close()
+		if err != nil {
+			return nil, nil, fmt.Errorf("github: failed to read response: %w", err)
+		}
+		c.recordQuota(resp.Header)
+
+		wait, limited := rateLimitWait(resp, data, time.Now())
+		if !limited {
+			return resp, data, nil
+		}
+		if attempt > 0 || wait > c.MaxRateLimitWait {
+			return nil, nil, &RateLimitError{Wait: wait}
+		}
+		if c.sleep != nil {
+			c.sleep(wait)
+		} else {
+			time.Sleep(wait)
+		}
+	}
+}
+
+// recordQuota stores the X-RateLimit-* headers under their resource.
+func (c *Client) recordQuota(h http.Header) {
+	remaining, err := strconv.Atoi(h.Get("X-RateLimit-Remaining"))
+	if err != nil {
+		return
+	}
+	limit, _ := strconv.Atoi(h.Get("X-RateLimit-Limit"))
+	reset, _ := strconv.ParseInt(h.Get("X-RateLimit-Reset"), 10, 64)
+	resource := h.Get("X-RateLimit-Resource")
+	if resource == "" {
+		resource = "core"
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.quotas == nil {
+		c.quotas = map[string]RateLimit{}
+	}
+	c.quotas[resource] = RateLimit{Limit: limit, Remaining: remaining, Reset: time.Unix(reset, 0)}
+}
+
+// graphQLRateLimitedRE matches the error type GraphQL reports a spent
+// quota with. User content can't match it: quotes inside JSON strings are
+// escaped.
+var graphQLRateLimitedRE = regexp.MustCompile(`"type":\s*"RATE_LIMITED"`)
+
+// rateLimitWait reports whether resp is a rate limit and how long to wait:
+// Retry-After when given (secondary limits), the quota reset when the
+// primary quota is spent, a minute otherwise, as GitHub's docs advise. A
+// GraphQL limit arrives as a 200 whose errors carry type RATE_LIMITED.
+func rateLimitWait(resp *http.Response, body []byte, now time.Time) (time.Duration, bool) {
+	switch resp.StatusCode {
+	case http.StatusForbidden, http.StatusTooManyRequests:
+	case http.StatusOK:
+		if !graphQLRateLimitedRE.Match(body) {
+			return 0, false
+		}
+	default:
+		return 0, false
+	}
+	if secs, err := strconv.Atoi(resp.Header.Get("Retry-After")); err == nil {
+		return time.Duration(secs) * time.Second, true
+	}
+	if resp.Header.Get("X-RateLimit-Remaining") == "0" {
+		if reset, err := strconv.ParseInt(resp.Header.Get("X-RateLimit-Reset"), 10, 64); err == nil {
+			return max(time.Unix(reset, 0).Sub(now), 0), true
+		}
+	}
+	if resp.StatusCode == http.StatusForbidden &&
+		!bytes.Contains(bytes.ToLower(body), []byte("rate limit")) {
+		return 0, false // a plain permission error
+	}
+	return time.Minute, true
+}
+
+// apiError turns a non-2xx response into an *APIError, using the message
+// GitHub puts in the body when there is one.
+func apiError(resp *http.Response, body []byte) error {
+	if resp.StatusCode >= 200 && resp.StatusCode < 300 {
+		return nil
+	}
+	var payload struct {
+		Message string `json:"message"`
+	}
+	msg := http.StatusText(resp.StatusCode)
+	if json.Unmarshal(body, &payload) == nil && payload.Message != "" {
+		msg = payload.Message
+	}
+	return &APIError{StatusCode: resp.StatusCode, Message: msg}
+}
+
+// cacheEntry is one cached GET response. Link is kept so a page served
+// from the cache still points at the next one.
+type cacheEntry struct {
+	ETag string `json:"etag"`
+	Link string `json:"link,omitempty"`
+	Body string `json:"body"`
+}
+
+func (c *Client) cachePath(url string) string {
+	sum := sha256.Sum256([]byte(url))
+	return filepath.Join(c.CacheDir, hex.EncodeToString(sum[:16])+".json")
+}
+
+// loadCache returns the cached entry for url, or nil. The cache is
+// best-effort: any read or decode problem is a miss.
+func (c *Client) loadCache(url string) *cacheEntry {
+	if c.CacheDir == "" {
+		return nil
+	}
+	data, err := os.ReadFile(c.cachePath(url))
+	if err != nil {
+		return nil
+	}
+	var e cacheEntry
+	if json.Unmarshal(data, &e) != nil || e.ETag == "" {
+		return nil
+	}
+	return &e
+}
+
+// storeCache writes entry for url through a temp file and rename, so a
+// concurrent reader never sees a partial entry. Failures are ignored.
+func (c *Client) storeCache(url string, entry cacheEntry) {
+	if c.CacheDir == "" {
+		return
+	}
+	data, err := json.Marshal(entry)
+	if err != nil {
+		return
+	}
+	if err := os.MkdirAll(c.CacheDir, 0o700); err != nil {
+		return
+	}
+	tmp, err := os.CreateTemp(c.CacheDir, "entry-*.tmp")
+	if err != nil {
+		return
+	}
+	tmpName := tmp.Name()
+	_, werr := tmp.Write(data)
+	cerr := tmp.Close()
+	if werr != nil || cerr != nil || os.Rename(tmpName, c.cachePath(url)) != nil {
+		_ = os.Remove(tmpName)
+	}
+}
+
+// linkNextRE extracts the rel="next" URL from a Link header.
+var linkNextRE = regexp.MustCompile(`<([^>]+)>;\s*rel="next"`)
+
+func nextLink(link string) string {
+	if m := linkNextRE.FindStringSubmatch(link); m != nil {
+		return m[1]
+	}
+	return ""
+}
+
+// get fetches one REST resource (path relative to RESTURL, or an absolute
+// URL) through the ETag cache, returning the body and the next page's URL.
+func (c *Client) get(path string) ([]byte, string, error) {
+	url := path
+	if !strings.HasPrefix(url, "http://") && !strings.HasPrefix(url, "https://") {
+		url = c.RESTURL + path
+	}
+	cached := c.loadCache(url)
+	header := http.Header{}
+	if cached != nil {
+		header.Set("If-None-Match", cached.ETag)
+	}
+	resp, data, err := c.do(http.MethodGet, url, nil, header)
+	if err != nil {
+		return nil, "", err
+	}
+	if resp.StatusCode == http.StatusNotModified && cached != nil {
+		return []byte(cached.Body), nextLink(cached.Link), nil
+	}
+	if err := apiError(resp, data); err != nil {
+		return nil, "", err
+	}
+	if etag := resp.Header.Get("ETag"); etag != "" {
+		c.storeCache(url, cacheEntry{ETag: etag, Link: resp.Header.Get("Link"), Body: string(data)})
+	}
+	return data, nextLink(resp.Header.Get("Link")), nil
+}
+
+// getJSON decodes one REST resource into out.
+func (c *Client) getJSON(path string, out any) error {
+	data, _, err := c.get(path)
+	if err != nil {
+		return err
+	}
+	if err := json.Unmarshal(data, out); err != nil {
+		return fmt.Errorf("github: unexpected response from %s: %w", path, err)
+	}
+	return nil
+}
+
+// getPages calls each with every page of a paginated REST resource,
+// following the Link header.
+func (c *Client) getPages(path string, each func(page []byte) error) error {
+	for next := path; next != ""; {
+		data, link, err := c.get(next)
+		if err != nil {
+			return err
+		}
+		if err := each(data); err != nil {
+			return err
+		}
+		next = link
+	}
+	return nil
+}
+
+// send makes an uncached REST request with a JSON payload (nil for none)
+// and returns the response body.
+func (c *Client) send(method, path string, payload any) ([]byte, error) {
+	var body []byte
+	if payload != nil {
+		var err error
+		if body, err = json.Marshal(payload); err != nil {
+			return nil, fmt.Errorf("github: failed to encode request: %w", err)
+		}
+	}
+	resp, data, err := c.do(method, c.RESTURL+path, body, nil)
+	if err != nil {
+		return nil, err
+	}
+	if err := apiError(resp, data); err != nil {
+		return nil, err
+	}
+	return data, nil
+}
+
+// graphQL runs a GraphQL query or mutation and decodes its data into out
+// (which may be a *json.RawMessage). GraphQL reports most failures as a 200
+// with an errors array; those are returned as one error.
+func (c *Client) graphQL(query string, variables map[string]any, out any) error {
+	body, err := json.Marshal(map[string]any{"query": query, "variables": variables})
+	if err != nil {
+		return fmt.Errorf("github: failed to encode query: %w", err)
+	}
+	resp, data, err := c.do(http.MethodPost, c.GraphQLURL, body, nil)
+	if err != nil {
+		return err
+	}
+	if err := apiError(resp, data); err != nil {
+		return err
+	}
+	var payload struct {
+		Data   json.RawMessage `json:"data"`
+		Errors []struct {
+			Message string `json:"message"`
+		} `json:"errors"`
+	}
+	if err := json.Unmarshal(data, &payload); err != nil {
+		return fmt.Errorf("github: unexpected GraphQL response: %w", err)
+	}
+	if len(payload.Errors) > 0 {
+		msgs := make([]string, len(payload.Errors))
+		for i, e := range payload.Errors {
+			msgs[i] = e.Message
+		}
+		return fmt.Errorf("github: %s", strings.Join(msgs, "; "))
+	}
+	if len(payload.Data) == 0 {
+		payload.Data = json.RawMessage("null")
+	}
+	if err := json.Unmarshal(payload.Data, out); err != nil {
+		return fmt.Errorf("github: unexpected GraphQL data: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,268 @@
+package githubcli
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// newTestClient returns a Client pointed at an httptest server running
+// handler, with a throwaway cache dir and a sleep that records its waits
+// instead of sleeping.
+func newTestClient(t *testing.T, handler http.HandlerFunc) (*Client, *[]time.Duration) {
+	t.Helper()
+	srv := httptest.NewServer(handler)
+	t.Cleanup(srv.Close)
+	var slept []time.Duration
+	return &Client{
+		RESTURL:          srv.URL,
+		GraphQLURL:       srv.URL + "/graphql",
+		HTTP:             srv.Client(),
+		Token:            func() (string, error) { return "test-token", nil },
+		CacheDir:         t.TempDir(),
+		MaxRateLimitWait: defaultMaxRateLimitWait,
+		sleep:            func(d time.Duration) { slept = append(slept, d) },
+	}, &slept
+}
+
+func TestNewClient(t *testing.T) {
+	c := NewClient("github.com", nil)
+	if c.RESTURL != "https://api.github.com" || c.GraphQLURL != "https://api.github.com/graphql" {
+		t.Errorf("unexpected github.com endpoints: %s, %s", c.RESTURL, c.GraphQLURL)
+	}
+	c = NewClient("ghe.corp.io", nil)
+	if c.RESTURL != "https://ghe.corp.io/api/v3" || c.GraphQLURL != "https://ghe.corp.io/api/graphql" {
+		t.Errorf("unexpected enterprise endpoints: %s, %s", c.RESTURL, c.GraphQLURL)
+	}
+}
+
+func TestClientSendsAuthHeaders(t *testing.T) {
+	var tokenCalls int
+	c, _ := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if got := r.Header.Get("Authorization"); got != "Bearer test-token" {
+			t.Errorf("unexpected Authorization header %q", got)
+		}
+		if got := r.Header.Get("X-GitHub-Api-Version"); got == "" {
+			t.Error("expected an API version header")
+		}
+		_, _ = w.Write([]byte(`{}`))
+	})
+	c.Token = func() (string, error) {
+		tokenCalls++
+		return "test-token", nil
+	}
+
+	for range 2 {
+		if _, _, err := c.get("/user"); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+	if tokenCalls != 1 {
+		t.Errorf("expected the token to be resolved once, got %d", tokenCalls)
+	}
+
+	noToken, _ := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		t.Error("expected no request without a token")
+	})
+	noToken.Token = nil
+	if _, _, err := noToken.get("/user"); err == nil {
+		t.Error("expected an error without a token source")
+	}
+}
+
+func TestClientETagCache(t *testing.T) {
+	var hits, revalidated atomic.Int32
+	c, _ := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		hits.Add(1)
+		if r.Header.Get("If-None-Match") == `"v1"` {
+			revalidated.Add(1)
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+		w.Header().Set("ETag", `"v1"`)
+		w.Header().Set("Link", `<`+"http://"+r.Host+`/items?page=2>; rel="next"`)
+		_, _ = w.Write([]byte(`[1,2]`))
+	})
+
+	body, next, err := c.get("/items")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	cachedBody, cachedNext, err := c.get("/items")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if hits.Load() != 2 || revalidated.Load() != 1 {
+		t.Fatalf("expected one fetch and one revalidation, got %d hits, %d revalidated",
+			hits.Load(), revalidated.Load())
+	}
+	if string(cachedBody) != string(body) || string(body) != "[1,2]" {
+		t.Errorf("expected the cached body on a 304, got %q", cachedBody)
+	}
+	if cachedNext != next || !strings.HasSuffix(next, "/items?page=2") {
+		t.Errorf("expected the cached next link %q, got %q", next, cachedNext)
+	}
+}
+
+func TestClientPagination(t *testing.T) {
+	c, _ := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		page, _ := strconv.Atoi(r.URL.Query().Get("page"))
+		if page < 3 {
+			w.Header().Set("Link", fmt.Sprintf(
+				`<http://%s/items?page=%d>; rel="next", <http://%s/items?page=3>; rel="last"`,
+				r.Host, max(page, 1)+1, r.Host,
+			))
+		}
+		_, _ = fmt.Fprintf(w, "[%d]", max(page, 1))
+	})
+
+	var pages []string
+	err := c.getPages("/items", func(page []byte) error {
+		pages = append(pages, string(page))
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if strings.Join(pages, ",") != "[1],[2],[3]" {
+		t.Errorf("expected three pages in order, got %v", pages)
+	}
+}
+
+func TestClientRateLimits(t *testing.T) {
+	t.Run("short secondary limit is waited out and retried", func(t *testing.T) {
+		var calls atomic.Int32
+		c, slept := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+			if calls.Add(1) == 1 {
+				w.Header().Set("Retry-After", "5")
+				w.WriteHeader(http.StatusForbidden)
+				_, _ = w.Write([]byte(`{"message":"You have exceeded a secondary rate limit"}`))
+				return
+			}
+			w.Header().Set("X-RateLimit-Limit", "5000")
+			w.Header().Set("X-RateLimit-Remaining", "4999")
+			w.Header().Set("X-RateLimit-Reset", "1800000000")
+			_, _ = w.Write([]byte(`{}`))
+		})
+
+		if _, _, err := c.get("/user"); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if len(*slept) != 1 || (*slept)[0] != 5*time.Second {
+			t.Fatalf("expected one 5s wait, got %v", *slept)
+		}
+		q, ok := c.Quota("core")
+		if !ok || q.Limit != 5000 || q.Remaining != 4999 || q.Reset.Unix() != 1800000000 {
+			t.Errorf("unexpected recorded quota: %+v (ok=%v)", q, ok)
+		}
+	})
+
+	t.Run("spent primary quota fails with the wait", func(t *testing.T) {
+		reset := time.Now().Add(20 * time.Minute)
+		c, slept := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("X-RateLimit-Remaining", "0")
+			w.Header().Set("X-RateLimit-Reset", strconv.FormatInt(reset.Unix(), 10))
+			w.WriteHeader(http.StatusForbidden)
+			_, _ = w.Write([]byte(`{"message":"API rate limit exceeded"}`))
+		})
+
+		_, _, err := c.get("/user")
+		var rl *RateLimitError
+		if !errors.As(err, &rl) {
+			t.Fatalf("expected a RateLimitError, got %v", err)
+		}
+		if rl.Wait < 19*time.Minute || rl.Wait > 20*time.Minute {
+			t.Errorf("expected a wait until the reset, got %s", rl.Wait)
+		}
+		if len(*slept) != 0 {
+			t.Errorf("expected no sleep past MaxRateLimitWait, got %v", *slept)
+		}
+	})
+
+	t.Run("graphql RATE_LIMITED error is a rate limit", func(t *testing.T) {
+		c, slept := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Retry-After", "1")
+			_, _ = w.Write([]byte(`{"errors":[{"type":"RATE_LIMITED","message":"limit"}]}`))
+		})
+
+		var out any
+		err := c.graphQL("query { viewer { login } }", nil, &out)
+		var rl *RateLimitError
+		if !errors.As(err, &rl) {
+			t.Fatalf("expected a RateLimitError after one retry, got %v", err)
+		}
+		if len(*slept) != 1 {
+			t.Errorf("expected exactly one wait before giving up, got %v", *slept)
+		}
+	})
+
+	t.Run("plain 403 is a permission error", func(t *testing.T) {
+		c, _ := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusForbidden)
+			_, _ = w.Write([]byte(`{"message":"Resource not accessible by integration"}`))
+		})
+
+		_, _, err := c.get("/repos/o/r/pulls")
+		var apiErr *APIError
+		if !errors.As(err, &apiErr) || apiErr.StatusCode != http.StatusForbidden {
+			t.Fatalf("expected a 403 APIError, got %v", err)
+		}
+		if !strings.Contains(err.Error(), "Resource not accessible") {
+			t.Errorf("expected GitHub's message in the error, got %v", err)
+		}
+	})
+}
+
+func TestClientGraphQL(t *testing.T) {
+	t.Run("decodes data", func(t *testing.T) {
+		c, _ := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+			if r.URL.Path != "/graphql" || r.Method != http.MethodPost {
+				t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+			}
+			_, _ = w.Write([]byte(`{"data":{"viewer":{"login":"octocat"}}}`))
+		})
+
+		var out struct {
+			Viewer struct {
+				Login string `json:"login"`
+			} `json:"viewer"`
+		}
+		if err := c.graphQL("query { viewer { login } }", nil, &out); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if out.Viewer.Login != "octocat" {
+			t.Errorf("unexpected data: %+v", out)
+		}
+	})
+
+	t.Run("joins errors", func(t *testing.T) {
+		c, _ := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+			_, _ = w.Write([]byte(`{"data":null,"errors":[{"message":"bad field"},{"message":"bad arg"}]}`))
+		})
+
+		var out any
+		err := c.graphQL("query { nope }", nil, &out)
+		if err == nil || err.Error() != "github: bad field; bad arg" {
+			t.Fatalf("expected joined GraphQL errors, got %v", err)
+		}
+	})
+
+	t.Run("unauthorized explains how to log in", func(t *testing.T) {
+		c, _ := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusUnauthorized)
+			_, _ = w.Write([]byte(`{"message":"Bad credentials"}`))
+		})
+
+		var out any
+		err := c.graphQL("query { viewer { login } }", nil, &out)
+		if err == nil || !strings.Contains(err.Error(), "GH_TOKEN") {
+			t.Fatalf("expected an auth hint, got %v", err)
+		}
+	})
+}
@@ -27,23 +27,95 @@ package githubcli
 import (
 	"fmt"
 	"os"
-	"sort"
 	"strings"
+	"sync"
 	"time"
 
+	git_app "github.com/cjairm/devgita/internal/apps/git"
 	cmd "github.com/cjairm/devgita/internal/commands"
 	"github.com/cjairm/devgita/pkg/constants"
 )
 
+// GithubCli installs gh and drives pull requests. Reads and review-thread
+// mutations go through the in-process API client (see pulls.go), so they
+// cost no subprocess; PR porcelain (create, edit, review, comment, merge)
+// and job logs still run gh.
 type GithubCli struct {
 	Cmd  cmd.Command
 	Base cmd.BaseCommandExecutor
+	Git  *git_app.Git
+	// API returns the API client for a GitHub host; the host comes from
+	// the checkout's remote (see client).
+	API func(host string) *Client
+
+	hostOnce     sync.Once
+	checkoutHost string
 }
 
 func New() *GithubCli {
 	osCmd := cmd.NewCommand()
 	baseCmd := cmd.NewBaseCommand()
-	return &GithubCli{Cmd: osCmd, Base: baseCmd}
+	g := &GithubCli{Cmd: osCmd, Base: baseCmd, Git: git_app.New()}
+	var mu sync.Mutex
+	clients := map[string]*Client{}
+	g.API = func(host string) *Client {
+		mu.Lock()
+		defer mu.Unlock()
+		if clients[host] == nil {
+			clients[host] = NewClient(host, g.tokenFor(host))
+		}
+		return clients[host]
+	}
+	return g
+}
+
+// client returns the API client for a repository on host, as read from a
+// git remote. Like gh, $GH_HOST overrides the remote's host; with neither,
+// the host is github.com.
+func (g *GithubCli) client(host string) *Client {
+	if env := strings.TrimSpace(os.Getenv("GH_HOST")); env != "" {
+		host = env
+	}
+	if host == "" {
+		host = "github.com"
+	}
+	return g.API(host)
+}
+
+// checkoutClient returns the API client for the current checkout's host,
+// for calls that name no repository (review thread ids) or get one without
+// its host. The host is read from the remotes once per GithubCli; without
+// a GitHub remote it is github.com.
+func (g *GithubCli) checkoutClient() *Client {
+	g.hostOnce.Do(func() {
+		if strings.TrimSpace(os.Getenv("GH_HOST")) == "" {
+			g.checkoutHost, _, _, _ = g.baseRepo()
+		}
+	})
+	return g.client(g.checkoutHost)
+}
+
+// tokenFor resolves an API token for host the way gh does: $GH_TOKEN or
+// $GITHUB_TOKEN for github.com, $GH_ENTERPRISE_TOKEN or
+// $GITHUB_ENTERPRISE_TOKEN for other hosts, and otherwise the token gh
+// stored at login (one `gh auth token` call per process).
+func (g *GithubCli) tokenFor(host string) func() (string, error) {
+	return func() (string, error) {
+		envs := []string{"GH_TOKEN", "GITHUB_TOKEN"}
+		if host != "github.com" {
+			envs = []string{"GH_ENTERPRISE_TOKEN", "GITHUB_ENTERPRISE_TOKEN"}
+		}
+		for _, env := range envs {
+			if token := strings.TrimSpace(os.Getenv(env)); token != "" {
+				return token, nil
+			}
+		}
+		out, err := g.RunWithOutput("auth", "token", "--hostname", host)
+		if token := strings.TrimSpace(out); err == nil && token != "" {
+			return token, nil
+		}
+		return "", fmt.Errorf("no GitHub token for %s: set GH_TOKEN or run gh auth login", host)
+	}
 }
 
 func (g *GithubCli) Install() error {
@@ -114,198 +186,6 @@ func (g *GithubCli) RunWithOutput(args ...string) (string, error) {
 	return stdout, nil
 }
 
-// GraphQL runs `gh api graphql` with the given query and variables, returning raw JSON stdout.
-// stringVars are passed as -f key=value; intVars are passed as -F key=value.
-func (g *GithubCli) GraphQL(query string, stringVars, intVars map[string]string) (string, error) {
-	args := []string{"api", "graphql", "-f", "query=" + query}
-	// Sort keys so the assembled args are deterministic run-to-run — stabilizes
-	// tests and produces consistent output for agent pipelines.
-	for _, k := range sortedKeys(stringVars) {
-		args = append(args, "-f", k+"="+stringVars[k])
-	}
-	for _, k := range sortedKeys(intVars) {
-		args = append(args, "-F", k+"="+intVars[k])
-	}
-	return g.RunWithOutput(args...)
-}
-
-func sortedKeys(m map[string]string) []string {
-	keys := make([]string, 0, len(m))
-	for k := range m {
-		keys = append(keys, k)
-	}
-	sort.Strings(keys)
-	return keys
-}
-
-// reviewThreadsQuery selects every field jq.FormatReviewThreads needs: per
-// thread id/isResolved/resolvedBy.login/path/line/originalLine, per comment
-// id/author/body/createdAt, and the diff hunk via a dedicated firstComment
-// alias so it isn't refetched for every comment. It paginates threads via
-// $endCursor + pageInfo (driven by `gh api graphql --paginate`).
-//
-// resolvedBy is null for unresolved threads and lets the renderer show who
-// resolved a thread. Comment createdAt lets the renderer show when feedback
-// was posted, which the /review-pr dedup rule needs to decide whether code
-// changed since a reply.
-//
-// Note: comments(first: 100) is NOT paginated — a thread with >100 comments
-// truncates. That is far rarer than >100 threads and deferred for now.
-const reviewThreadsQuery = `query($owner: String!, $name: String!, $pr: Int!, $endCursor: String) {
-  repository(owner: $owner, name: $name) {
-    pullRequest(number: $pr) {
-      reviewThreads(first: 100, after: $endCursor) {
-        nodes {
-          id
-          isResolved
-          isOutdated
-          resolvedBy { login }
-          path
-          line
-          originalLine
-          comments(first: 100) {
-            nodes {
-              id
-              author { login }
-              body
-              createdAt
-            }
-          }
-          firstComment: comments(first: 1) {
-            nodes { diffHunk }
-          }
-        }
-        pageInfo {
-          hasNextPage
-          endCursor
-        }
-      }
-    }
-  }
-}`
-
-// prDiscussionQuery selects a pull request's review summary bodies (the
-// top-level body of a submitted review — Approve/Request-changes/Comment),
-// each with submittedAt, and its top-level conversation comments (`gh pr
-// comment`), each with createdAt. Both are feedback surfaces distinct from
-// inline review threads (reviewThreadsQuery above), which are anchored to a
-// diff line; these are not.
-//
-// submittedAt/createdAt let the renderer show when feedback was posted, which
-// the /review-pr dedup rule needs to decide whether code changed since a reply.
-//
-// This is a deliberately SEPARATE, non-paginated query. reviewThreadsQuery is
-// driven by `gh api graphql --paginate` over its reviewThreads connection,
-// which re-runs the whole query once per page — adding reviews/comments as
-// sibling fields there would refetch and re-render them on every thread page,
-// duplicating output.
-//
-// Note: reviews(first: 100) and comments(first: 100) are NOT paginated — a PR
-// with >100 reviews or >100 conversation comments truncates. That mirrors the
-// deferred-truncation stance already taken on reviewThreadsQuery's per-thread
-// comments(first: 100).
-const prDiscussionQuery = `query($owner: String!, $name: String!, $pr: Int!) {
-  repository(owner: $owner, name: $name) {
-    pullRequest(number: $pr) {
-      reviews(first: 100) {
-        nodes { author { login } body state submittedAt }
-      }
-      comments(first: 100) {
-        nodes { author { login } body createdAt }
-      }
-    }
-  }
-}`
-
-// resolveReviewThreadMutation marks a single review thread as resolved.
-const resolveReviewThreadMutation = `mutation($threadId: ID!) {
-  resolveReviewThread(input: {threadId: $threadId}) {
-    thread { id isResolved }
-  }
-}`
-
-// FetchReviewThreads returns the raw GraphQL JSON for a pull request's review
-// threads — all of them, across pages, resolved and unresolved. With
-// --paginate, gh emits one JSON document per page; jq.FormatReviewThreads
-// applies its filter to each document, so the rendered output covers every page.
-func (g *GithubCli) FetchReviewThreads(owner, repo, prNumber string) (string, error) {
-	if owner == "" || repo == "" || prNumber == "" {
-		return "", fmt.Errorf("fetch review threads requires owner, repo, and pr number")
-	}
-	return g.RunWithOutput(
-		"api", "graphql", "--paginate",
-		"-f", "query="+reviewThreadsQuery,
-		"-f", "owner="+owner,
-		"-f", "name="+repo,
-		"-F", "pr="+prNumber,
-	)
-}
-
-// FetchPRDiscussion returns the raw GraphQL JSON for a pull request's review
-// summary bodies and top-level conversation comments (see prDiscussionQuery).
-// Unlike FetchReviewThreads, this call is NOT paginated.
-func (g *GithubCli) FetchPRDiscussion(owner, repo, prNumber string) (string, error) {
-	if owner == "" || repo == "" || prNumber == "" {
-		return "", fmt.Errorf("fetch pr discussion requires owner, repo, and pr number")
-	}
-	return g.RunWithOutput(
-		"api", "graphql",
-		"-f", "query="+prDiscussionQuery,
-		"-f", "owner="+owner,
-		"-f", "name="+repo,
-		"-F", "pr="+prNumber,
-	)
-}
-
-// ResolveReviewThread marks one PR review thread as resolved and returns the
-// mutation's JSON response.
-func (g *GithubCli) ResolveReviewThread(threadID string) (string, error) {
-	if threadID == "" {
-		return "", fmt.Errorf("resolve review thread requires a thread id")
-	}
-	return g.GraphQL(
-		resolveReviewThreadMutation,
-		map[string]string{"threadId": threadID},
-		nil,
-	)
-}
-
-// CreateReview posts a single pull-request review via the REST reviews
-// endpoint, optionally carrying inline comments. payloadJSON is the full
-// request body ({body, event, comments[]}) already assembled by the caller — it
-// is sent with --input (written to a temp file) because gh's -f/-F flags cannot
-// express the nested comments array. Returns the API's JSON response.
-func (g *GithubCli) CreateReview(owner, repo, prNumber, payloadJSON string) (string, error) {
-	if owner == "" || repo == "" || prNumber == "" {
-		return "", fmt.Errorf("create review requires owner, repo, and pr number")
-	}
-	if strings.TrimSpace(payloadJSON) == "" {
-		return "", fmt.Errorf("create review requires a payload")
-	}
-
-	tmp, err := os.CreateTemp("", "devgita-review-*.json")
-	if err != nil {
-		return "", fmt.Errorf("create review: failed to create temp file: %w", err)
-	}
-	tmpName := tmp.Name()
-	defer func() { _ = os.Remove(tmpName) }()
-
-	if _, err := tmp.WriteString(payloadJSON); err != nil {
-		_ = tmp.Close()
-		return "", fmt.Errorf("create review: failed to write temp file: %w", err)
-	}
-	if err := tmp.Close(); err != nil {
-		return "", fmt.Errorf("create review: failed to close temp file: %w", err)
-	}
-
-	endpoint := fmt.Sprintf("/repos/%s/%s/pulls/%s/reviews", owner, repo, prNumber)
-	return g.RunWithOutput(
-		"api", "--method", "POST",
-		"-H", "Accept: application/vnd.github+json",
-		endpoint, "--input", tmpName,
-	)
-}
-
 // CreatePR opens a pull request from the current branch and returns the created
 // PR URL. base is optional (defaults to the repo's default branch).
 func (g *GithubCli) CreatePR(title, body, base string) (string, error) {
@@ -374,69 +254,6 @@ func (g *GithubCli) RequestReviewPR(prNumber string, reviewers []string) error {
 	return g.ExecuteCommand(args...)
 }
 
-// replyReviewThreadMutation posts a reply comment onto an existing review thread.
-const replyReviewThreadMutation = `mutation($threadId: ID!, $body: String!) {
-  addPullRequestReviewThreadReply(input: {pullRequestReviewThreadId: $threadId, body: $body}) {
-    comment { id url }
-  }
-}`
-
-// unresolveReviewThreadMutation reopens a previously resolved review thread.
-const unresolveReviewThreadMutation = `mutation($threadId: ID!) {
-  unresolveReviewThread(input: {threadId: $threadId}) {
-    thread { id isResolved }
-  }
-}`
-
-// defaultPRViewFields is a compact, agent-oriented field set kept deliberately
-// small for token economy. Callers needing more (e.g. body, statusCheckRollup)
-// pass their own fields.
-var defaultPRViewFields = []string{
-	"number", "title", "state", "mergeable", "reviewDecision", "headRefName", "baseRefName",
-}
-
-// ReplyToReviewThread posts a reply onto a review thread and returns the
-// mutation's JSON response. Pair this with ResolveReviewThread to respond
-// before closing a conversation.
-func (g *GithubCli) ReplyToReviewThread(threadID, body string) (string, error) {
-	if threadID == "" || body == "" {
-		return "", fmt.Errorf("reply requires a thread id and body")
-	}
-	return g.GraphQL(
-		replyReviewThreadMutation,
-		map[string]string{"threadId": threadID, "body": body},
-		nil,
-	)
-}
-
-// UnresolveReviewThread reopens a resolved review thread and returns the
-// mutation's JSON response.
-func (g *GithubCli) UnresolveReviewThread(threadID string) (string, error) {
-	if threadID == "" {
-		return "", fmt.Errorf("unresolve review thread requires a thread id")
-	}
-	return g.GraphQL(
-		unresolveReviewThreadMutation,
-		map[string]string{"threadId": threadID},
-		nil,
-	)
-}
-
-// PRView returns a pull request's metadata as JSON. prNumber may be empty
-// (current branch). When no fields are given, defaultPRViewFields is used to
-// keep the payload small; pass explicit fields to fetch more.
-func (g *GithubCli) PRView(prNumber string, fields ...string) (string, error) {
-	if len(fields) == 0 {
-		fields = defaultPRViewFields
-	}
-	args := []string{"pr", "view"}
-	if prNumber != "" {
-		args = append(args, prNumber)
-	}
-	args = append(args, "--json", strings.Join(fields, ","))
-	return g.RunWithOutput(args...)
-}
-
 // PRTitleAt returns the title of the pull request for the current branch of the
 // repository checked out at dir. It is best-effort: it returns "" (never an
 // error) when gh is absent, unauthenticated, times out, or the branch has no
@@ -456,54 +273,11 @@ func (g *GithubCli) PRTitleAt(dir string, timeout time.Duration) string {
 	return strings.TrimSpace(stdout)
 }
 
-// PRChecks returns the CI check status for a pull request as JSON, for stable
-// machine parsing. prNumber may be empty (current branch).
-//
-// `gh pr checks` exits non-zero when any check is failing or pending, but still
-// prints valid JSON — exactly when a caller most wants the data. So this reads
-// stdout directly: a non-zero exit with JSON present is returned as success, a
-// "no checks" message returns an empty array, and only a genuinely empty
-// failure surfaces as an error.
-//
-// `bucket` is requested alongside the fields jq.FormatPRChecks projects
-// (name, state, link, workflow) because it is gh's own authoritative
-// pass/fail/pending/skipping/cancel categorization of `state` (see `gh pr
-// checks --help` and task.isFailingCheck) — cheaper and more future-proof
-// than re-deriving "is this failing" from state's larger enum. jq's filter
-// only reads name/state/link, so this extra field never changes the rendered
-// per-check line.
-func (g *GithubCli) PRChecks(prNumber string) (string, error) {
-	args := []string{"pr", "checks"}
-	if prNumber != "" {
-		args = append(args, prNumber)
-	}
-	args = append(args, "--json", "name,state,link,workflow,bucket")
-
-	stdout, stderr, err := g.Base.ExecCommand(cmd.CommandParams{
-		Command: constants.GithubCli,
-		Args:    args,
-	})
-	stdout = strings.TrimSpace(stdout)
-	if err != nil {
-		if stdout != "" {
-			return stdout, nil // failing/pending checks: JSON is still valid
-		}
-		if strings.Contains(strings.ToLower(stderr), "no checks") {
-			return "[]", nil // PR has no checks configured
-		}
-		if stderr != "" {
-			return "", fmt.Errorf("gh: %s", stderr)
-		}
-		return "", fmt.Errorf("failed to get pr checks: %w", err)
-	}
-	return stdout, nil
-}
-
 // RunFailedJobLog returns the log for a single job's failed steps
 // ("gh run view --job <jobID> --log-failed"), for embedding under a failing
 // check's digest (see task.PRManager.PRChecks). It operates on the current
-// repo (no owner/repo argument), matching PRChecks/PRView's convention of
-// relying on gh's cwd-based repo resolution.
+// repo (no owner/repo argument), relying on gh's cwd-based repo resolution
+// like the other gh subcommands here.
 //
 // Verified empirically against several public repos with real failing runs
 // (junegunn/fzf, BurntSushi/ripgrep): gh's log-download API returns an
@@ -555,42 +329,3 @@ func (g *GithubCli) MergePR(prNumber, method string) error {
 	args = append(args, flag)
 	return g.ExecuteCommand(args...)
 }
-
-// CurrentPRNumber returns the PR number for the current branch, or "" with a
-// nil error when the branch has no associated pull request. Any other failure
-// (auth, network, not a repo) is returned as an error.
-//
-// A PR number is a GitHub concept, not a git one, so this must go through gh —
-// git cannot resolve a branch to its PR. gh handles the head-ref matching,
-// forks, and remote selection.
-func (g *GithubCli) CurrentPRNumber() (string, error) {
-	stdout, stderr, err := g.Base.ExecCommand(cmd.CommandParams{
-		Command: constants.GithubCli,
-		Args:    []string{"pr", "view", "--json", "number", "--jq", ".number"},
-	})
-	if err != nil {
-		// gh exits non-zero when the branch has no PR; treat that as "no PR"
-		// (empty result) so callers can branch on it instead of error-handling.
-		if strings.Contains(strings.ToLower(stderr), "no pull request") {
-			return "", nil
-		}
-		if stderr != "" {
-			return "", fmt.Errorf("gh: %s", stderr)
-		}
-		return "", fmt.Errorf("failed to resolve current pr number: %w", err)
-	}
-	return strings.TrimSpace(stdout), nil
-}
-
-// CurrentRepo returns the current repository as "owner/name". It delegates
-// repo resolution to gh (which reads the git remotes) rather than parsing
-// remote URLs by hand, so SSH/HTTPS/Enterprise URL forms are all handled.
-func (g *GithubCli) CurrentRepo() (string, error) {
-	out, err := g.RunWithOutput(
-		"repo", "view", "--json", "owner,name", "--jq", `.owner.login + "/" + .name`,
-	)
-	if err != nil {
-		return "", err
-	}
-	return strings.TrimSpace(out), nil
-}
@@ -22,6 +22,30 @@ func TestNew(t *testing.T) {
 	}
 }
 
+func TestTokenFor(t *testing.T) {
+	t.Setenv("GH_TOKEN", "")
+	t.Setenv("GITHUB_TOKEN", "from-github-token")
+	t.Setenv("GH_ENTERPRISE_TOKEN", "from-enterprise")
+	app := &GithubCli{Cmd: commands.NewMockCommand(), Base: commands.NewMockBaseCommand()}
+
+	if token, err := app.tokenFor("github.com")(); err != nil || token != "from-github-token" {
+		t.Errorf("expected GITHUB_TOKEN for github.com, got %q, %v", token, err)
+	}
+	if token, err := app.tokenFor("ghe.corp.io")(); err != nil || token != "from-enterprise" {
+		t.Errorf("expected GH_ENTERPRISE_TOKEN for an enterprise host, got %q, %v", token, err)
+	}
+
+	t.Setenv("GITHUB_TOKEN", "")
+	mockBase := app.Base.(*commands.MockBaseCommand)
+	mockBase.SetExecCommandResult("gho_stored\n", "", nil)
+	if token, err := app.tokenFor("github.com")(); err != nil || token != "gho_stored" {
+		t.Errorf("expected gh's stored token, got %q, %v", token, err)
+	}
+	if call := mockBase.GetLastExecCommandCall(); strings.Join(call.Args, " ") != "auth token --hostname github.com" {
+		t.Errorf("unexpected gh call: %v", call.Args)
+	}
+}
+
 func TestInstall(t *testing.T) {
 	mc := commands.NewMockCommand()
 	app := &GithubCli{Cmd: mc}
@@ -228,60 +252,6 @@ func TestRunWithOutput(t *testing.T) {
 	})
 }
 
-func TestGraphQL(t *testing.T) {
-	mc := commands.NewMockCommand()
-	mockBase := commands.NewMockBaseCommand()
-	app := &GithubCli{Cmd: mc, Base: mockBase}
-
-	t.Run("assembles correct gh args", func(t *testing.T) {
-		mockBase.SetExecCommandResult(`{"data":{}}`, "", nil)
-
-		out, err := app.GraphQL(
-			"query { viewer { login } }",
-			map[string]string{"owner": "octocat"},
-			map[string]string{"pr": "42"},
-		)
-		if err != nil {
-			t.Fatalf("unexpected error: %v", err)
-		}
-		if out != `{"data":{}}` {
-			t.Fatalf("expected json output, got %q", out)
-		}
-
-		call := mockBase.GetLastExecCommandCall()
-		if call == nil {
-			t.Fatal("no ExecCommand call recorded")
-		}
-		if call.Command != "gh" {
-			t.Fatalf("expected 'gh', got %q", call.Command)
-		}
-
-		argsJoined := strings.Join(call.Args, " ")
-		if !strings.Contains(argsJoined, "api graphql") {
-			t.Fatalf("expected 'api graphql' in args, got: %v", call.Args)
-		}
-		if !strings.Contains(argsJoined, "query=query { viewer { login } }") {
-			t.Fatalf("expected query arg in args, got: %v", call.Args)
-		}
-		if !strings.Contains(argsJoined, "-f owner=octocat") {
-			t.Fatalf("expected string var -f owner=octocat in args, got: %v", call.Args)
-		}
-		if !strings.Contains(argsJoined, "-F pr=42") {
-			t.Fatalf("expected int var -F pr=42 in args, got: %v", call.Args)
-		}
-	})
-
-	t.Run("returns error from gh", func(t *testing.T) {
-		mockBase.ResetExecCommand()
-		mockBase.SetExecCommandResult("", "unauthorized", fmt.Errorf("exit 1"))
-
-		_, err := app.GraphQL("query { viewer { login } }", nil, nil)
-		if err == nil {
-			t.Fatal("expected error")
-		}
-	})
-}
-
 // argSeq reports whether wantSeq appears as a contiguous subsequence of args.
 func argSeq(args []string, wantSeq ...string) bool {
 	for i := 0; i+len(wantSeq) <= len(args); i++ {
@@ -299,180 +269,6 @@ func argSeq(args []string, wantSeq ...string) bool {
 	return false
 }
 
-func TestFetchReviewThreads(t *testing.T) {
-	t.Run("assembles graphql query with vars", func(t *testing.T) {
-		mockBase := commands.NewMockBaseCommand()
-		app := &GithubCli{Cmd: commands.NewMockCommand(), Base: mockBase}
-		mockBase.SetExecCommandResult(`{"data":{}}`, "", nil)
-
-		out, err := app.FetchReviewThreads("octocat", "hello", "42")
-		if err != nil {
-			t.Fatalf("unexpected error: %v", err)
-		}
-		if out != `{"data":{}}` {
-			t.Fatalf("unexpected output: %q", out)
-		}
-
-		call := mockBase.GetLastExecCommandCall()
-		if call.Command != "gh" {
-			t.Fatalf("expected gh, got %q", call.Command)
-		}
-		if !argSeq(call.Args, "api", "graphql", "--paginate") {
-			t.Fatalf("expected 'api graphql --paginate' in args, got %v", call.Args)
-		}
-		joined := strings.Join(call.Args, " ")
-		if !strings.Contains(joined, "reviewThreads") {
-			t.Fatalf("expected reviewThreads query, got %v", call.Args)
-		}
-		if !strings.Contains(joined, "diffHunk") {
-			t.Fatalf("expected diffHunk field in query, got %v", call.Args)
-		}
-		if !strings.Contains(joined, "firstComment") {
-			t.Fatalf("expected firstComment alias in query, got %v", call.Args)
-		}
-		if !strings.Contains(joined, "pageInfo") {
-			t.Fatalf("expected pageInfo for pagination, got %v", call.Args)
-		}
-		if !strings.Contains(joined, "resolvedBy") {
-			t.Fatalf("expected resolvedBy field on reviewThread in query, got %v", call.Args)
-		}
-		if !strings.Contains(joined, "createdAt") {
-			t.Fatalf("expected createdAt field on comments in query, got %v", call.Args)
-		}
-		if !argSeq(call.Args, "-f", "owner=octocat") {
-			t.Fatalf("expected -f owner=octocat, got %v", call.Args)
-		}
-		if !argSeq(call.Args, "-f", "name=hello") {
-			t.Fatalf("expected -f name=hello, got %v", call.Args)
-		}
-		if !argSeq(call.Args, "-F", "pr=42") {
-			t.Fatalf("expected -F pr=42, got %v", call.Args)
-		}
-	})
-
-	t.Run("validates required args", func(t *testing.T) {
-		mockBase := commands.NewMockBaseCommand()
-		app := &GithubCli{Cmd: commands.NewMockCommand(), Base: mockBase}
-
-		if _, err := app.FetchReviewThreads("", "hello", "42"); err == nil {
-			t.Fatal("expected error for empty owner")
-		}
-		if mockBase.GetExecCommandCallCount() != 0 {
-			t.Fatal("expected no gh call when validation fails")
-		}
-	})
-}
-
-func TestFetchPRDiscussion(t *testing.T) {
-	t.Run("assembles graphql query with vars, no pagination", func(t *testing.T) {
-		mockBase := commands.NewMockBaseCommand()
-		app := &GithubCli{Cmd: commands.NewMockCommand(), Base: mockBase}
-		mockBase.SetExecCommandResult(`{"data":{}}`, "", nil)
-
-		out, err := app.FetchPRDiscussion("octocat", "hello", "42")
-		if err != nil {
-			t.Fatalf("unexpected error: %v", err)
-		}
-		if out != `{"data":{}}` {
-			t.Fatalf("unexpected output: %q", out)
-		}
-
-		call := mockBase.GetLastExecCommandCall()
-		if call.Command != "gh" {
-			t.Fatalf("expected gh, got %q", call.Command)
-		}
-		if !argSeq(call.Args, "api", "graphql") {
-			t.Fatalf("expected 'api graphql' in args, got %v", call.Args)
-		}
-		if argSeq(call.Args, "--paginate") {
-			t.Fatalf("expected no --paginate flag, got %v", call.Args)
-		}
-		joined := strings.Join(call.Args, " ")
-		if !strings.Contains(joined, "reviews") {
-			t.Fatalf("expected reviews field in query, got %v", call.Args)
-		}
-		if !strings.Contains(joined, "comments") {
-			t.Fatalf("expected comments field in query, got %v", call.Args)
-		}
-		if !strings.Contains(joined, "submittedAt") {
-			t.Fatalf("expected submittedAt field on reviews in query, got %v", call.Args)
-		}
-		if !strings.Contains(joined, "createdAt") {
-			t.Fatalf("expected createdAt field on comments in query, got %v", call.Args)
-		}
-		if !argSeq(call.Args, "-f", "owner=octocat") {
-			t.Fatalf("expected -f owner=octocat, got %v", call.Args)
-		}
-		if !argSeq(call.Args, "-f", "name=hello") {
-			t.Fatalf("expected -f name=hello, got %v", call.Args)
-		}
-		if !argSeq(call.Args, "-F", "pr=42") {
-			t.Fatalf("expected -F pr=42, got %v", call.Args)
-		}
-	})
-
-	t.Run("validates required args", func(t *testing.T) {
-		mockBase := commands.NewMockBaseCommand()
-		app := &GithubCli{Cmd: commands.NewMockCommand(), Base: mockBase}
-
-		if _, err := app.FetchPRDiscussion("", "hello", "42"); err == nil {
-			t.Fatal("expected error for empty owner")
-		}
-		if _, err := app.FetchPRDiscussion("octocat", "", "42"); err == nil {
-			t.Fatal("expected error for empty repo")
-		}
-		if _, err := app.FetchPRDiscussion("octocat", "hello", ""); err == nil {
-			t.Fatal("expected error for empty pr number")
-		}
-		if mockBase.GetExecCommandCallCount() != 0 {
-			t.Fatal("expected no gh call when validation fails")
-		}
-	})
-
-	t.Run("wraps error from gh", func(t *testing.T) {
-		mockBase := commands.NewMockBaseCommand()
-		app := &GithubCli{Cmd: commands.NewMockCommand(), Base: mockBase}
-		mockBase.SetExecCommandResult("", "unauthorized", fmt.Errorf("exit 1"))
-
-		if _, err := app.FetchPRDiscussion("octocat", "hello", "42"); err == nil {
-			t.Fatal("expected error")
-		}
-	})
-}
-
-func TestResolveReviewThread(t *testing.T) {
-	t.Run("assembles resolve mutation", func(t *testing.T) {
-		mockBase := commands.NewMockBaseCommand()
-		app := &GithubCli{Cmd: commands.NewMockCommand(), Base: mockBase}
-		mockBase.SetExecCommandResult(`{"data":{"resolveReviewThread":{}}}`, "", nil)
-
-		if _, err := app.ResolveReviewThread("PRRT_abc"); err != nil {
-			t.Fatalf("unexpected error: %v", err)
-		}
-
-		call := mockBase.GetLastExecCommandCall()
-		joined := strings.Join(call.Args, " ")
-		if !strings.Contains(joined, "resolveReviewThread") {
-			t.Fatalf("expected resolveReviewThread mutation, got %v", call.Args)
-		}
-		if !argSeq(call.Args, "-f", "threadId=PRRT_abc") {
-			t.Fatalf("expected -f threadId=PRRT_abc, got %v", call.Args)
-		}
-	})
-
-	t.Run("requires thread id", func(t *testing.T) {
-		mockBase := commands.NewMockBaseCommand()
-		app := &GithubCli{Cmd: commands.NewMockCommand(), Base: mockBase}
-
-		if _, err := app.ResolveReviewThread(""); err == nil {
-			t.Fatal("expected error for empty thread id")
-		}
-		if mockBase.GetExecCommandCallCount() != 0 {
-			t.Fatal("expected no gh call when validation fails")
-		}
-	})
-}
-
 func TestCreatePR(t *testing.T) {
 	t.Run("assembles create args and returns url", func(t *testing.T) {
 		mockBase := commands.NewMockBaseCommand()
@@ -631,189 +427,6 @@ func TestRequestChangesPR(t *testing.T) {
 	})
 }
 
-func TestReplyToReviewThread(t *testing.T) {
-	t.Run("assembles reply mutation", func(t *testing.T) {
-		mockBase := commands.NewMockBaseCommand()
-		app := &GithubCli{Cmd: commands.NewMockCommand(), Base: mockBase}
-		mockBase.SetExecCommandResult(`{"data":{"addPullRequestReviewThreadReply":{}}}`, "", nil)
-
-		if _, err := app.ReplyToReviewThread("PRRT_abc", "Fixed in abc123"); err != nil {
-			t.Fatalf("unexpected error: %v", err)
-		}
-		call := mockBase.GetLastExecCommandCall()
-		joined := strings.Join(call.Args, " ")
-		if !strings.Contains(joined, "addPullRequestReviewThreadReply") {
-			t.Fatalf("expected reply mutation, got %v", call.Args)
-		}
-		if !argSeq(call.Args, "-f", "threadId=PRRT_abc") {
-			t.Fatalf("expected -f threadId, got %v", call.Args)
-		}
-		if !argSeq(call.Args, "-f", "body=Fixed in abc123") {
-			t.Fatalf("expected -f body, got %v", call.Args)
-		}
-	})
-
-	t.Run("requires thread id and body", func(t *testing.T) {
-		mockBase := commands.NewMockBaseCommand()
-		app := &GithubCli{Cmd: commands.NewMockCommand(), Base: mockBase}
-
-		if _, err := app.ReplyToReviewThread("PRRT_abc", ""); err == nil {
-			t.Fatal("expected error for empty body")
-		}
-		if _, err := app.ReplyToReviewThread("", "hi"); err == nil {
-			t.Fatal("expected error for empty thread id")
-		}
-		if mockBase.GetExecCommandCallCount() != 0 {
-			t.Fatal("expected no gh call when validation fails")
-		}
-	})
-}
-
-func TestUnresolveReviewThread(t *testing.T) {
-	t.Run("assembles unresolve mutation", func(t *testing.T) {
-		mockBase := commands.NewMockBaseCommand()
-		app := &GithubCli{Cmd: commands.NewMockCommand(), Base: mockBase}
-		mockBase.SetExecCommandResult(`{"data":{}}`, "", nil)
-
-		if _, err := app.UnresolveReviewThread("PRRT_abc"); err != nil {
-			t.Fatalf("unexpected error: %v", err)
-		}
-		call := mockBase.GetLastExecCommandCall()
-		if !strings.Contains(strings.Join(call.Args, " "), "unresolveReviewThread") {
-			t.Fatalf("expected unresolveReviewThread mutation, got %v", call.Args)
-		}
-		if !argSeq(call.Args, "-f", "threadId=PRRT_abc") {
-			t.Fatalf("expected -f threadId, got %v", call.Args)
-		}
-	})
-
-	t.Run("requires thread id", func(t *testing.T) {
-		mockBase := commands.NewMockBaseCommand()
-		app := &GithubCli{Cmd: commands.NewMockCommand(), Base: mockBase}
-
-		if _, err := app.UnresolveReviewThread(""); err == nil {
-			t.Fatal("expected error for empty thread id")
-		}
-		if mockBase.GetExecCommandCallCount() != 0 {
-			t.Fatal("expected no gh call when validation fails")
-		}
-	})
-}
-
-func TestPRView(t *testing.T) {
-	t.Run("default fields with pr number", func(t *testing.T) {
-		mockBase := commands.NewMockBaseCommand()
-		app := &GithubCli{Cmd: commands.NewMockCommand(), Base: mockBase}
-		mockBase.SetExecCommandResult(`{"number":7}`, "", nil)
-
-		out, err := app.PRView("7")
-		if err != nil {
-			t.Fatalf("unexpected error: %v", err)
-		}
-		if out != `{"number":7}` {
-			t.Fatalf("unexpected output: %q", out)
-		}
-		call := mockBase.GetLastExecCommandCall()
-		if !argSeq(call.Args, "pr", "view", "7") {
-			t.Fatalf("expected 'pr view 7', got %v", call.Args)
-		}
-		joined := strings.Join(call.Args, " ")
-		if !strings.Contains(joined, "--json") {
-			t.Fatalf("expected --json, got %v", call.Args)
-		}
-		if !strings.Contains(joined, "reviewDecision") {
-			t.Fatalf("expected default fields in --json, got %v", call.Args)
-		}
-	})
-
-	t.Run("custom fields override defaults", func(t *testing.T) {
-		mockBase := commands.NewMockBaseCommand()
-		app := &GithubCli{Cmd: commands.NewMockCommand(), Base: mockBase}
-		mockBase.SetExecCommandResult("{}", "", nil)
-
-		if _, err := app.PRView("", "body", "title"); err != nil {
-			t.Fatalf("unexpected error: %v", err)
-		}
-		call := mockBase.GetLastExecCommandCall()
-		if !argSeq(call.Args, "--json", "body,title") {
-			t.Fatalf("expected --json body,title, got %v", call.Args)
-		}
-		// No pr number → "view" should be immediately followed by "--json"
-		if !argSeq(call.Args, "pr", "view", "--json") {
-			t.Fatalf("expected no pr number, got %v", call.Args)
-		}
-	})
-}
-
-func TestPRChecks(t *testing.T) {
-	t.Run("success returns json", func(t *testing.T) {
-		mockBase := commands.NewMockBaseCommand()
-		app := &GithubCli{Cmd: commands.NewMockCommand(), Base: mockBase}
-		mockBase.SetExecCommandResult(`[{"name":"build","state":"SUCCESS"}]`, "", nil)
-
-		out, err := app.PRChecks("7")
-		if err != nil {
-			t.Fatalf("unexpected error: %v", err)
-		}
-		if out != `[{"name":"build","state":"SUCCESS"}]` {
-			t.Fatalf("unexpected output: %q", out)
-		}
-		call := mockBase.GetLastExecCommandCall()
-		if !argSeq(call.Args, "pr", "checks", "7") {
-			t.Fatalf("expected 'pr checks 7', got %v", call.Args)
-		}
-		if !argSeq(call.Args, "--json", "name,state,link,workflow,bucket") {
-			t.Fatalf("expected --json fields, got %v", call.Args)
-		}
-	})
-
-	t.Run("failing checks (non-zero exit) still return json", func(t *testing.T) {
-		mockBase := commands.NewMockBaseCommand()
-		app := &GithubCli{Cmd: commands.NewMockCommand(), Base: mockBase}
-		mockBase.SetExecCommandResult(
-			`[{"name":"test","state":"FAILURE"}]`,
-			"1 failing",
-			fmt.Errorf("exit 1"),
-		)
-
-		out, err := app.PRChecks("7")
-		if err != nil {
-			t.Fatalf("expected JSON returned despite non-zero exit, got error: %v", err)
-		}
-		if out != `[{"name":"test","state":"FAILURE"}]` {
-			t.Fatalf("unexpected output: %q", out)
-		}
-	})
-
-	t.Run("no checks returns empty array", func(t *testing.T) {
-		mockBase := commands.NewMockBaseCommand()
-		app := &GithubCli{Cmd: commands.NewMockCommand(), Base: mockBase}
-		mockBase.SetExecCommandResult(
-			"",
-			"no checks reported on the 'feat' branch",
-			fmt.Errorf("exit 1"),
-		)
-
-		out, err := app.PRChecks("")
-		if err != nil {
-			t.Fatalf("expected no error for 'no checks', got: %v", err)
-		}
-		if out != "[]" {
-			t.Fatalf("expected empty array, got %q", out)
-		}
-	})
-
-	t.Run("genuine failure surfaces error", func(t *testing.T) {
-		mockBase := commands.NewMockBaseCommand()
-		app := &GithubCli{Cmd: commands.NewMockCommand(), Base: mockBase}
-		mockBase.SetExecCommandResult("", "HTTP 500", fmt.Errorf("exit 1"))
-
-		if _, err := app.PRChecks("7"); err == nil {
-			t.Fatal("expected error for empty failure")
-		}
-	})
-}
-
 func TestCommentPR(t *testing.T) {
 	t.Run("posts comment", func(t *testing.T) {
 		mockBase := commands.NewMockBaseCommand()
@@ -931,87 +544,3 @@ func TestMergePR(t *testing.T) {
 		}
 	})
 }
-
-func TestCurrentPRNumber(t *testing.T) {
-	t.Run("returns number", func(t *testing.T) {
-		mockBase := commands.NewMockBaseCommand()
-		app := &GithubCli{Cmd: commands.NewMockCommand(), Base: mockBase}
-		mockBase.SetExecCommandResult("42", "", nil)
-
-		n, err := app.CurrentPRNumber()
-		if err != nil {
-			t.Fatalf("unexpected error: %v", err)
-		}
-		if n != "42" {
-			t.Fatalf("expected 42, got %q", n)
-		}
-		call := mockBase.GetLastExecCommandCall()
-		if !argSeq(call.Args, "pr", "view", "--json", "number") {
-			t.Fatalf("expected 'pr view --json number', got %v", call.Args)
-		}
-		if !argSeq(call.Args, "--jq", ".number") {
-			t.Fatalf("expected '--jq .number', got %v", call.Args)
-		}
-	})
-
-	t.Run("no pr returns empty without error", func(t *testing.T) {
-		mockBase := commands.NewMockBaseCommand()
-		app := &GithubCli{Cmd: commands.NewMockCommand(), Base: mockBase}
-		mockBase.SetExecCommandResult(
-			"",
-			`no pull requests found for branch "feat"`,
-			fmt.Errorf("exit 1"),
-		)
-
-		n, err := app.CurrentPRNumber()
-		if err != nil {
-			t.Fatalf("expected nil error for no PR, got: %v", err)
-		}
-		if n != "" {
-			t.Fatalf("expected empty number, got %q", n)
-		}
-	})
-
-	t.Run("real error propagates", func(t *testing.T) {
-		mockBase := commands.NewMockBaseCommand()
-		app := &GithubCli{Cmd: commands.NewMockCommand(), Base: mockBase}
-		mockBase.SetExecCommandResult("", "HTTP 500: server error", fmt.Errorf("exit 1"))
-
-		if _, err := app.CurrentPRNumber(); err == nil {
-			t.Fatal("expected error for non-'no PR' failure")
-		}
-	})
-}
-
-func TestCurrentRepo(t *testing.T) {
-	t.Run("returns owner/name", func(t *testing.T) {
-		mockBase := commands.NewMockBaseCommand()
-		app := &GithubCli{Cmd: commands.NewMockCommand(), Base: mockBase}
-		mockBase.SetExecCommandResult("octocat/hello", "", nil)
-
-		r, err := app.CurrentRepo()
-		if err != nil {
-			t.Fatalf("unexpected error: %v", err)
-		}
-		if r != "octocat/hello" {
-			t.Fatalf("expected octocat/hello, got %q", r)
-		}
-		call := mockBase.GetLastExecCommandCall()
-		if !argSeq(call.Args, "repo", "view", "--json", "owner,name") {
-			t.Fatalf("expected 'repo view --json owner,name', got %v", call.Args)
-		}
-		if !argSeq(call.Args, "--jq", `.owner.login + "/" + .name`) {
-			t.Fatalf("expected --jq owner/name expr, got %v", call.Args)
-		}
-	})
-
-	t.Run("error propagates", func(t *testing.T) {
-		mockBase := commands.NewMockBaseCommand()
-		app := &GithubCli{Cmd: commands.NewMockCommand(), Base: mockBase}
-		mockBase.SetExecCommandResult("", "not a git repository", fmt.Errorf("exit 1"))
-
-		if _, err := app.CurrentRepo(); err == nil {
-			t.Fatal("expected error when not in a repo")
-		}
-	})
-}
@@ -0,0 +1,737 @@
+package githubcli
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// Pull request reads and review-thread mutations, through the in-process
+// API client (see api.go). Fetches return the JSON `gh` used to print for
+// the same call (GraphQL's data envelope, gh pr view/checks --json shapes),
+// which is the shape task.PRManager's formatters read from either forge.
+
+// reviewThreadsQuery selects every field task's review-thread formatter
+// needs: per thread id/isResolved/resolvedBy.login/path/line/originalLine,
+// per comment id/author/body/createdAt, and the diff hunk via a dedicated
+// firstComment alias so it isn't refetched for every comment. Threads are
+// paginated via $endCursor + pageInfo, and so are a thread's comments: a
+// thread with more than 100 comments is completed by threadCommentsQuery.
+//
+// resolvedBy is null for unresolved threads and lets the renderer show who
+// resolved a thread. Comment createdAt lets the renderer show when feedback
+// was posted, which the /review-pr dedup rule needs to decide whether code
+// changed since a reply.
+const reviewThreadsQuery = `query($owner: String!, $name: String!, $pr: Int!, $endCursor: String) {
+  repository(owner: $owner, name: $name) {
+    pullRequest(number: $pr) {
+      reviewThreads(first: 100, after: $endCursor) {
+        nodes {
+          id
+          isResolved
+          isOutdated
+          resolvedBy { login }
+          path
+          line
+          originalLine
+          comments(first: 100) {
+            nodes {
+              id
+              author { login }
+              body
+              createdAt
+            }
+            pageInfo {
+              hasNextPage
+              endCursor
+            }
+          }
+          firstComment: comments(first: 1) {
+            nodes { diffHunk }
+          }
+        }
+        pageInfo {
+          hasNextPage
+          endCursor
+        }
+      }
+    }
+  }
+}`
+
+// threadCommentsQuery fetches a review thread's comments past the first
+// page reviewThreadsQuery returned.
+const threadCommentsQuery = `query($id: ID!, $endCursor: String) {
+  node(id: $id) {
+    ... on PullRequestReviewThread {
+      comments(first: 100, after: $endCursor) {
+        nodes {
+          id
+          author { login }
+          body
+          createdAt
+        }
+        pageInfo {
+          hasNextPage
+          endCursor
+        }
+      }
+    }
+  }
+}`
+
+// prDiscussionQuery selects a pull request's review summary bodies (the
+// top-level body of a submitted review — Approve/Request-changes/Comment),
+// each with submittedAt, and its top-level conversation comments (`gh pr
+// comment`), each with createdAt. Both are feedback surfaces distinct from
+// inline review threads (reviewThreadsQuery above), which are anchored to a
+// diff line; these are not.
+//
+// submittedAt/createdAt let the renderer show when feedback was posted, which
+// the /review-pr dedup rule needs to decide whether code changed since a reply.
+//
+// This is a deliberately SEPARATE query. reviewThreadsQuery is re-run once
+// per thread page; adding reviews/comments as sibling fields there would
+// refetch them on every page.
+//
+// Note: reviews(first: 100) and comments(first: 100) are NOT paginated — a PR
+// with >100 reviews or >100 conversation comments truncates.
+const prDiscussionQuery = `query($owner: String!, $name: String!, $pr: Int!) {
+  repository(owner: $owner, name: $name) {
+    pullRequest(number: $pr) {
+      reviews(first: 100) {
+        nodes { author { login } body state submittedAt }
+      }
+      comments(first: 100) {
+        nodes { author { login } body createdAt }
+      }
+    }
+  }
+}`
+
+// prViewQuery selects the compact, agent-oriented field set PRView returns,
+// deliberately small for token economy.
+const prViewQuery = `query($owner: String!, $name: String!, $pr: Int!) {
+  repository(owner: $owner, name: $name) {
+    pullRequest(number: $pr) {
+      number title state mergeable reviewDecision headRefName baseRefName
+    }
+  }
+}`
+
+// resolveReviewThreadMutation marks a single review thread as resolved.
+const resolveReviewThreadMutation = `mutation($threadId: ID!) {
+  resolveReviewThread(input: {threadId: $threadId}) {
+    thread { id isResolved }
+  }
+}`
+
+// replyReviewThreadMutation posts a reply comment onto an existing review thread.
+const replyReviewThreadMutation = `mutation($threadId: ID!, $body: String!) {
+  addPullRequestReviewThreadReply(input: {pullRequestReviewThreadId: $threadId, body: $body}) {
+    comment { id url }
+  }
+}`
+
+// unresolveReviewThreadMutation reopens a previously resolved review thread.
+const unresolveReviewThreadMutation = `mutation($threadId: ID!) {
+  unresolveReviewThread(input: {threadId: $threadId}) {
+    thread { id isResolved }
+  }
+}`
+
+type actor struct {
+	Login string `json:"login"`
+}
+
+type pageInfo struct {
+	HasNextPage bool   `json:"hasNextPage"`
+	EndCursor   string `json:"endCursor"`
+}
+
+type connection[T any] struct {
+	Nodes    []T       `json:"nodes"`
+	PageInfo *pageInfo `json:"pageInfo,omitempty"`
+}
+
+type threadComment struct {
+	ID        string `json:"id"`
+	Author    *actor `json:"author"`
+	Body      string `json:"body"`
+	CreatedAt string `json:"createdAt"`
+}
+
+type diffHunk struct {
+	DiffHunk string `json:"diffHunk"`
+}
+
+type reviewThread struct {
+	ID           string                    `json:"id"`
+	IsResolved   bool                      `json:"isResolved"`
+	IsOutdated   bool                      `json:"isOutdated"`
+	ResolvedBy   *actor                    `json:"resolvedBy"`
+	Path         string                    `json:"path"`
+	Line         *int                      `json:"line"`
+	OriginalLine *int                      `json:"originalLine"`
+	Comments     connection[threadComment] `json:"comments"`
+	FirstComment connection[diffHunk]      `json:"firstComment"`
+}
+
+// pullRequestJSON wraps pr in the data.repository.pullRequest envelope of a
+// GraphQL response.
+func pullRequestJSON(pr any) (string, error) {
+	out, err := json.Marshal(map[string]any{
+		"data": map[string]any{"repository": map[string]any{"pullRequest": pr}},
+	})
+	if err != nil {
+		return "", fmt.Errorf("failed to encode response: %w", err)
+	}
+	return string(out), nil
+}
+
+func parsePRNumber(prNumber string) (int, error) {
+	pr, err := strconv.Atoi(strings.TrimPrefix(strings.TrimSpace(prNumber), "#"))
+	if err != nil || pr <= 0 {
+		return 0, fmt.Errorf("invalid pr number %q", prNumber)
+	}
+	return pr, nil
+}
+
+// GraphQL runs a query or mutation and returns the response's data in its
+// {"data": ...} envelope, as `gh api graphql` printed it.
+func (g *GithubCli) GraphQL(query string, variables map[string]any) (string, error) {
+	var data json.RawMessage
+	if err := g.checkoutClient().graphQL(query, variables, &data); err != nil {
+		return "", err
+	}
+	return `{"data":` + string(data) + `}`, nil
+}
+
+// FetchReviewThreads returns a pull request's review threads — all of them,
+// resolved and unresolved, with all of each thread's comments — as one
+// GraphQL reviewThreads document.
+func (g *GithubCli) FetchReviewThreads(owner, repo, prNumber string) (string, error) {
+	if owner == "" || repo == "" || prNumber == "" {
+		return "", fmt.Errorf("fetch review threads requires owner, repo, and pr number")
+	}
+	pr, err := parsePRNumber(prNumber)
+	if err != nil {
+		return "", err
+	}
+
+	api := g.checkoutClient()
+	var threads []reviewThread
+	var cursor any // null on the first page
+	for {
+		var data struct {
+			Repository struct {
+				PullRequest struct {
+					ReviewThreads connection[reviewThread] `json:"reviewThreads"`
+				} `json:"pullRequest"`
+			} `json:"repository"`
+		}
+		vars := map[string]any{"owner": owner, "name": repo, "pr": pr, "endCursor": cursor}
+		if err := api.graphQL(reviewThreadsQuery, vars, &data); err != nil {
+			return "", err
+		}
+		page := data.Repository.PullRequest.ReviewThreads
+		threads = append(threads, page.Nodes...)
+		if page.PageInfo == nil || !page.PageInfo.HasNextPage {
+			break
+		}
+		cursor = page.PageInfo.EndCursor
+	}
+	for i := range threads {
+		if err := g.fetchRemainingComments(api, &threads[i]); err != nil {
+			return "", err
+		}
+	}
+	return pullRequestJSON(map[string]any{
+		"reviewThreads": connection[reviewThread]{Nodes: threads},
+	})
+}
+
+// fetchRemainingComments appends the comments past a thread's first page.
+func (g *GithubCli) fetchRemainingComments(api *Client, t *reviewThread) error {
+	for t.Comments.PageInfo != nil && t.Comments.PageInfo.HasNextPage {
+		var data struct {
+			Node struct {
+				Comments connection[threadComment] `json:"comments"`
+			} `json:"node"`
+		}
+		vars := map[string]any{"id": t.ID, "endCursor": t.Comments.PageInfo.EndCursor}
+		if err := api.graphQL(threadCommentsQuery, vars, &data); err != nil {
+			return fmt.Errorf("fetch comments of thread %s: %w", t.ID, err)
+		}
+		t.Comments.Nodes = append(t.Comments.Nodes, data.Node.Comments.Nodes...)
+		t.Comments.PageInfo = data.Node.Comments.PageInfo
+	}
+	t.Comments.PageInfo = nil
+	return nil
+}
+
+// FetchPRDiscussion returns the GraphQL JSON for a pull request's review
+// summary bodies and top-level conversation comments (see prDiscussionQuery).
+func (g *GithubCli) FetchPRDiscussion(owner, repo, prNumber string) (string, error) {
+	if owner == "" || repo == "" || prNumber == "" {
+		return "", fmt.Errorf("fetch pr discussion requires owner, repo, and pr number")
+	}
+	pr, err := parsePRNumber(prNumber)
+	if err != nil {
+		return "", err
+	}
+	return g.GraphQL(prDiscussionQuery, map[string]any{"owner": owner, "name": repo, "pr": pr})
+}
+
+// ResolveReviewThread marks one PR review thread as resolved and returns the
+// mutation's JSON response.
+func (g *GithubCli) ResolveReviewThread(threadID string) (string, error) {
+	if threadID == "" {
+		return "", fmt.Errorf("resolve review thread requires a thread id")
+	}
+	return g.GraphQL(resolveReviewThreadMutation, map[string]any{"threadId": threadID})
+}
+
+// UnresolveReviewThread reopens a resolved review thread and returns the
+// mutation's JSON response.
+func (g *GithubCli) UnresolveReviewThread(threadID string) (string, error) {
+	if threadID == "" {
+		return "", fmt.Errorf("unresolve review thread requires a thread id")
+	}
+	return g.GraphQL(unresolveReviewThreadMutation, map[string]any{"threadId": threadID})
+}
+
+// ReplyToReviewThread posts a reply onto a review thread and returns the
+// mutation's JSON response. Pair this with ResolveReviewThread to respond
+// before closing a conversation.
+func (g *GithubCli) ReplyToReviewThread(threadID, body string) (string, error) {
+	if threadID == "" || body == "" {
+		return "", fmt.Errorf("reply requires a thread id and body")
+	}
+	return g.GraphQL(
+		replyReviewThreadMutation,
+		map[string]any{"threadId": threadID, "body": body},
+	)
+}
+
+// CreateReview posts a single pull-request review via the REST reviews
+// endpoint, optionally carrying inline comments. payloadJSON is the full
+// request body ({body, event, comments[]}) already assembled by the caller.
+// Returns the API's JSON response.
+func (g *GithubCli) CreateReview(owner, repo, prNumber, payloadJSON string) (string, error) {
+	if owner == "" || repo == "" || prNumber == "" {
+		return "", fmt.Errorf("create review requires owner, repo, and pr number")
+	}
+	if strings.TrimSpace(payloadJSON) == "" {
+		return "", fmt.Errorf("create review requires a payload")
+	}
+	if !json.Valid([]byte(payloadJSON)) {
+		return "", fmt.Errorf("create review: payload is not valid JSON")
+	}
+	pr, err := parsePRNumber(prNumber)
+	if err != nil {
+		return "", err
+	}
+	out, err := g.checkoutClient().send(
+		http.MethodPost,
+		fmt.Sprintf("%s/pulls/%d/reviews", repoPath(owner, repo), pr),
+		json.RawMessage(payloadJSON),
+	)
+	if err != nil {
+		return "", err
+	}
+	return string(out), nil
+}
+
+// PRView returns a pull request's metadata as JSON: number, title, state,
+// mergeable, reviewDecision, headRefName, baseRefName (prViewQuery).
+// prNumber may be empty (current branch).
+func (g *GithubCli) PRView(prNumber string) (string, error) {
+	api, owner, name, pr, err := g.resolvePR(prNumber)
+	if err != nil {
+		return "", err
+	}
+	var data struct {
+		Repository struct {
+			PullRequest json.RawMessage `json:"pullRequest"`
+		} `json:"repository"`
+	}
+	vars := map[string]any{"owner": owner, "name": name, "pr": pr}
+	if err := api.graphQL(prViewQuery, vars, &data); err != nil {
+		return "", err
+	}
+	if len(data.Repository.PullRequest) == 0 || string(data.Repository.PullRequest) == "null" {
+		return "", fmt.Errorf("pull request #%d not found in %s/%s", pr, owner, name)
+	}
+	return string(data.Repository.PullRequest), nil
+}
+
+// check is one CI check in the shape of gh pr checks --json
+// name,state,link,workflow,bucket.
+type check struct {
+	Name     string `json:"name"`
+	State    string `json:"state"`
+	Link     string `json:"link"`
+	Workflow string `json:"workflow"`
+	Bucket   string `json:"bucket"`
+}
+
+// PRChecks returns the CI checks on a pull request's head commit as JSON
+// (see check). prNumber may be empty (current branch).
+//
+// Checks come from the REST API — the head commit's check runs, its
+// workflow runs (for each check's workflow name), and its combined commit
+// status — rather than GraphQL, because REST GETs go through the ETag
+// cache: re-reading unchanged checks is a 304 that costs no quota.
+//
+// state is a check run's conclusion once completed and its status before
+// (both upper-cased, as gh prints them), or a commit status's state.
+// bucket is gh's pass/fail/pending/skipping/cancel categorization of state
+// (see checkBucket and task.isFailingCheck), except that a completed check
+// run is never pending (see checkRunBucket).
+func (g *GithubCli) PRChecks(prNumber string) (string, error) {
+	api, owner, name, pr, err := g.resolvePR(prNumber)
+	if err != nil {
+		return "", err
+	}
+	repo := repoPath(owner, name)
+	sha, err := g.headSHA(api, repo, pr)
+	if err != nil {
+		return "", err
+	}
+
+	type checkRun struct {
+		Name       string `json:"name"`
+		Status     string `json:"status"`
+		Conclusion string `json:"conclusion"`
+		DetailsURL string `json:"details_url"`
+		HTMLURL    string `json:"html_url"`
+		CheckSuite struct {
+			ID int64 `json:"id"`
+		} `json:"check_suite"`
+	}
+	var runs []checkRun
+	err = api.getPages(
+		fmt.Sprintf("%s/commits/%s/check-runs?per_page=100", repo, sha),
+		func(page []byte) error {
+			var p struct {
+				CheckRuns []checkRun `json:"check_runs"`
+			}
+			if err := json.Unmarshal(page, &p); err != nil {
+				return fmt.Errorf("github: unexpected check runs response: %w", err)
+			}
+			runs = append(runs, p.CheckRuns...)
+			return nil
+		},
+	)
+	if err != nil {
+		return "", err
+	}
+
+	checks := []check{}
+	workflows := g.workflowNames(api, repo, sha, len(runs) > 0)
+	for _, r := range runs {
+		state := checkRunState(r.Status, r.Conclusion)
+		link := r.DetailsURL
+		if link == "" {
+			link = r.HTMLURL
+		}
+		checks = append(checks, check{
+			Name:     r.Name,
+			State:    state,
+			Link:     link,
+			Workflow: workflows[r.CheckSuite.ID],
+			Bucket:   checkRunBucket(r.Status, state),
+		})
+	}
+
+	err = api.getPages(
+		fmt.Sprintf("%s/commits/%s/status?per_page=100", repo, sha),
+		func(page []byte) error {
+			var p struct {
+				Statuses []struct {
+					Context   string `json:"context"`
+					State     string `json:"state"`
+					TargetURL string `json:"target_url"`
+				} `json:"statuses"`
+			}
+			if err := json.Unmarshal(page, &p); err != nil {
+				return fmt.Errorf("github: unexpected commit status response: %w", err)
+			}
+			for _, s := range p.Statuses {
+				state := strings.ToUpper(s.State)
+				checks = append(checks, check{
+					Name:   s.Context,
+					State:  state,
+					Link:   s.TargetURL,
+					Bucket: checkBucket(state),
+				})
+			}
+			return nil
+		},
+	)
+	if err != nil {
+		return "", err
+	}
+
+	out, err := json.Marshal(checks)
+	if err != nil {
+		return "", fmt.Errorf("failed to encode response: %w", err)
+	}
+	return string(out), nil
+}
+
+// PRHeadSHA returns the commit a pull request's head branch points at.
+// prNumber may be empty (current branch).
+func (g *GithubCli) PRHeadSHA(prNumber string) (string, error) {
+	api, owner, name, pr, err := g.resolvePR(prNumber)
+	if err != nil {
+		return "", err
+	}
+	return g.headSHA(api, repoPath(owner, name), pr)
+}
+
+// headSHA reads a pull request's head commit over REST, so polling it is an
+// ETag revalidation until someone pushes.
+func (g *GithubCli) headSHA(api *Client, repo string, pr int) (string, error) {
+	var pull struct {
+		Head struct {
+			SHA string `json:"sha"`
+		} `json:"head"`
+	}
+	if err := api.getJSON(fmt.Sprintf("%s/pulls/%d", repo, pr), &pull); err != nil {
+		return "", err
+	}
+	return pull.Head.SHA, nil
+}
+
+// workflowNames maps check suite ids to their Actions workflow's name for
+// the workflow runs on sha. It is best-effort: the name is informational,
+// so a failure (Actions disabled, no access) leaves workflows blank rather
+// than failing the checks.
+func (g *GithubCli) workflowNames(api *Client, repo, sha string, needed bool) map[int64]string {
+	names := map[int64]string{}
+	if !needed {
+		return names
+	}
+	_ = api.getPages(
+		fmt.Sprintf("%s/actions/runs?head_sha=%s&per_page=100", repo, sha),
+		func(page []byte) error {
+			var p struct {
+				WorkflowRuns []struct {
+					Name         string `json:"name"`
+					CheckSuiteID int64  `json:"check_suite_id"`
+				} `json:"workflow_runs"`
+			}
+			if err := json.Unmarshal(page, &p); err != nil {
+				return err
+			}
+			for _, r := range p.WorkflowRuns {
+				names[r.CheckSuiteID] = r.Name
+			}
+			return nil
+		},
+	)
+	return names
+}
+
+// checkRunState is a check run's state as gh reports it: the conclusion
+// once completed, the status (QUEUED, IN_PROGRESS, …) before that.
+func checkRunState(status, conclusion string) string {
+	if status == "completed" && conclusion != "" {
+		return strings.ToUpper(conclusion)
+	}
+	return strings.ToUpper(status)
+}
+
+// checkRunBucket is a check run's bucket. A completed run is settled
+// whatever its conclusion, so one checkBucket doesn't sort — STALE, or no
+// conclusion at all — is skipping rather than pending, which would keep
+// pr-watch polling a check that will never change.
+func checkRunBucket(status, state string) string {
+	bucket := checkBucket(state)
+	if bucket == "pending" && status == "completed" {
+		return "skipping"
+	}
+	return bucket
+}
+
+// checkBucket sorts a check state into gh pr checks' buckets.
+func checkBucket(state string) string {
+	switch state {
+	case "SUCCESS":
+		return "pass"
+	case "SKIPPED", "NEUTRAL":
+		return "skipping"
+	case "CANCELLED":
+		return "cancel"
+	case "FAILURE", "ERROR", "TIMED_OUT", "ACTION_REQUIRED", "STARTUP_FAILURE":
+		return "fail"
+	default:
+		return "pending"
+	}
+}
+
+// --- Repository and pull request resolution ---
+
+// baseRemotes is the order gh picks the repository PRs live in from a
+// checkout's remotes: a fork's upstream wins over the fork itself.
+var baseRemotes = []string{"upstream", "github", "origin"}
+
+func repoPath(owner, name string) string {
+	return "/repos/" + url.PathEscape(owner) + "/" + url.PathEscape(name)
+}
+
+// parseRepoURL extracts host, owner and name from a GitHub remote URL in
+// any of git's forms: scheme://[user@]host[:port]/owner/name[.git] or
+// scp-like [user@]host:owner/name[.git]. The host drops any user and port
+// and, as gh normalizes it, any github.com subdomain (ssh.github.com).
+func parseRepoURL(remote string) (host, owner, name string, ok bool) {
+	path := strings.TrimSpace(remote)
+	if _, rest, found := strings.Cut(path, "://"); found {
+		if host, path, found = strings.Cut(rest, "/"); !found {
+			return "", "", "", false
+		}
+		if i := strings.LastIndex(host, ":"); i >= 0 {
+			host = host[:i]
+		}
+	} else if host, path, found = strings.Cut(path, ":"); !found {
+		return "", "", "", false
+	}
+	if i := strings.LastIndex(host, "@"); i >= 0 {
+		host = host[i+1:]
+	}
+	host = strings.ToLower(host)
+	if strings.HasSuffix(host, ".github.com") {
+		host = "github.com"
+	}
+	path = strings.TrimSuffix(strings.TrimSuffix(path, "/"), ".git")
+	parts := strings.Split(path, "/")
+	if host == "" || len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+		return "", "", "", false
+	}
+	return host, parts[0], parts[1], true
+}
+
+// remoteRepo returns the host, owner and name a git remote points at.
+func (g *GithubCli) remoteRepo(remote string) (host, owner, name string, err error) {
+	out, err := g.Git.RunCapture("remote", "get-url", remote)
+	if err != nil {
+		return "", "", "", err
+	}
+	host, owner, name, ok := parseRepoURL(out)
+	if !ok {
+		return "", "", "", fmt.Errorf(
+			"remote %q (%s) is not a GitHub repository URL", remote, strings.TrimSpace(out),
+		)
+	}
+	return host, owner, name, nil
+}
+
+// baseRepo returns the host and repository the current checkout's PRs
+// live in.
+func (g *GithubCli) baseRepo() (host, owner, name string, err error) {
+	out, err := g.Git.RunCapture("remote")
+	if err != nil {
+		return "", "", "", err
+	}
+	remotes := strings.Fields(out)
+	if len(remotes) == 0 {
+		return "", "", "", fmt.Errorf("no git remote found")
+	}
+	pick := remotes[0]
+	for _, r := range baseRemotes {
+		if slices.Contains(remotes, r) {
+			pick = r
+			break
+		}
+	}
+	return g.remoteRepo(pick)
+}
+
+// resolvePR fills in the base repository, the API client for its host and,
+// when prNumber is empty, the current branch's PR.
+func (g *GithubCli) resolvePR(prNumber string) (api *Client, owner, name string, pr int, err error) {
+	host, owner, name, err := g.baseRepo()
+	if err != nil {
+		return nil, "", "", 0, err
+	}
+	if prNumber == "" {
+		if prNumber, err = g.CurrentPRNumber(); err != nil {
+			return nil, "", "", 0, err
+		}
+		if prNumber == "" {
+			return nil, "", "", 0, fmt.Errorf("no pull request found for the current branch; pass --pr")
+		}
+	}
+	pr, err = parsePRNumber(prNumber)
+	return g.client(host), owner, name, pr, err
+}
+
+// CurrentPRNumber returns the PR number for the current branch, or "" with a
+// nil error when the branch has no associated pull request (or HEAD is
+// detached). Any other failure (auth, network, not a repo) is returned as an
+// error.
+//
+// The PR is looked up in the base repository (see baseRemotes) by head
+// <owner>:<branch>, where owner is that of the remote the branch tracks —
+// origin when it tracks none — so a fork's branch finds its PR upstream.
+// Like gh, an open PR wins over closed ones, then the most recent.
+func (g *GithubCli) CurrentPRNumber() (string, error) {
+	host, owner, name, err := g.baseRepo()
+	if err != nil {
+		return "", err
+	}
+	branch, err := g.Git.CurrentBranch()
+	if err != nil {
+		return "", err
+	}
+	if branch == "" {
+		return "", nil
+	}
+	headOwner := owner
+	headRemote := "origin"
+	if remote, err := g.Git.RunCapture("config", "branch."+branch+".remote"); err == nil &&
+		strings.TrimSpace(remote) != "" && strings.TrimSpace(remote) != "." {
+		headRemote = strings.TrimSpace(remote)
+	}
+	if _, o, _, err := g.remoteRepo(headRemote); err == nil {
+		headOwner = o
+	}
+
+	query := url.Values{
+		"head":     {headOwner + ":" + branch},
+		"state":    {"all"},
+		"per_page": {"100"},
+	}
+	var pulls []struct {
+		Number int    `json:"number"`
+		State  string `json:"state"`
+	}
+	if err := g.client(host).getJSON(repoPath(owner, name)+"/pulls?"+query.Encode(), &pulls); err != nil {
+		return "", err
+	}
+	for _, p := range pulls {
+		if p.State == "open" {
+			return strconv.Itoa(p.Number), nil
+		}
+	}
+	if len(pulls) > 0 {
+		return strconv.Itoa(pulls[0].Number), nil
+	}
+	return "", nil
+}
+
+// CurrentRepo returns the repository the current checkout's PRs live in as
+// "owner/name", read from its git remotes (see baseRemotes).
+func (g *GithubCli) CurrentRepo() (string, error) {
+	_, owner, name, err := g.baseRepo()
+	if err != nil {
+		return "", err
+	}
+	return owner + "/" + name, nil
+}
@@ -0,0 +1,716 @@
+package githubcli
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	git_app "github.com/cjairm/devgita/internal/apps/git"
+	"github.com/cjairm/devgita/internal/commands"
+)
+
+// newTestGithub returns a GithubCli whose API client talks to an httptest
+// server running handler and whose git calls go through the returned mock.
+func newTestGithub(t *testing.T, handler http.HandlerFunc) (*GithubCli, *commands.MockBaseCommand) {
+	t.Helper()
+	api, _ := newTestClient(t, handler)
+	gitBase := commands.NewMockBaseCommand()
+	return &GithubCli{
+		Cmd:  commands.NewMockCommand(),
+		Base: commands.NewMockBaseCommand(),
+		Git:  &git_app.Git{Cmd: commands.NewMockCommand(), Base: gitBase},
+		API:  func(string) *Client { return api },
+	}, gitBase
+}
+
+// graphQLRequest is a decoded GraphQL request body.
+type graphQLRequest struct {
+	Query     string         `json:"query"`
+	Variables map[string]any `json:"variables"`
+}
+
+func decodeGraphQL(t *testing.T, r *http.Request) graphQLRequest {
+	t.Helper()
+	var req graphQLRequest
+	body, _ := io.ReadAll(r.Body)
+	if err := json.Unmarshal(body, &req); err != nil {
+		t.Fatalf("invalid GraphQL request %q: %v", body, err)
+	}
+	return req
+}
+
+// originRemote scripts git for a checkout whose only remote is origin.
+func originRemote(gitBase *commands.MockBaseCommand) {
+	gitBase.SetExecCommandResults(
+		commands.ExecCommandResult("origin\n", "", nil),
+		commands.ExecCommandResult("git@github.com:octocat/hello.git\n", "", nil),
+	)
+}
+
+func TestParseRepoURL(t *testing.T) {
+	cases := map[string]string{
+		"git@github.com:octocat/hello.git":             "github.com octocat/hello",
+		"https://github.com/octocat/hello":             "github.com octocat/hello",
+		"https://github.com/octocat/hello.git/":        "github.com octocat/hello",
+		"ssh://git@ghe.corp.io:2222/team/app.git":      "ghe.corp.io team/app",
+		"https://x-token@github.com/octocat/hello.git": "github.com octocat/hello",
+		"ssh://git@ssh.github.com:443/octocat/hello":   "github.com octocat/hello",
+		"git@GHE.Corp.io:team/app.git":                 "ghe.corp.io team/app",
+		"https://github.com/octocat":                   "",
+		"/srv/repos/hello.git":                         "",
+	}
+	for remote, want := range cases {
+		host, owner, name, ok := parseRepoURL(remote)
+		got := ""
+		if ok {
+			got = host + " " + owner + "/" + name
+		}
+		if got != want {
+			t.Errorf("parseRepoURL(%q) = %q, want %q", remote, got, want)
+		}
+	}
+}
+
+func TestAPIHostFollowsRemote(t *testing.T) {
+	gheRemote := func(gitBase *commands.MockBaseCommand) {
+		gitBase.SetExecCommandResults(
+			commands.ExecCommandResult("origin\n", "", nil),
+			commands.ExecCommandResult("git@ghe.corp.io:team/app.git\n", "", nil),
+		)
+	}
+	newRecording := func(t *testing.T) (*GithubCli, *commands.MockBaseCommand, *[]string) {
+		app, gitBase := newTestGithub(t, func(w http.ResponseWriter, r *http.Request) {
+			_, _ = w.Write([]byte(`{"data":{"repository":{"pullRequest":{"number":7}}}}`))
+		})
+		api := app.API("")
+		var hosts []string
+		app.API = func(host string) *Client {
+			hosts = append(hosts, host)
+			return api
+		}
+		return app, gitBase, &hosts
+	}
+
+	t.Run("a GitHub Enterprise remote picks its host", func(t *testing.T) {
+		t.Setenv("GH_HOST", "")
+		app, gitBase, hosts := newRecording(t)
+		gheRemote(gitBase)
+
+		if _, err := app.PRView("7"); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if strings.Join(*hosts, " ") != "ghe.corp.io" {
+			t.Errorf("expected the remote's host, got %v", *hosts)
+		}
+	})
+
+	t.Run("thread mutations use the checkout's host", func(t *testing.T) {
+		t.Setenv("GH_HOST", "")
+		app, gitBase, hosts := newRecording(t)
+		gheRemote(gitBase)
+
+		_, _ = app.ResolveReviewThread("T_1")
+		_, _ = app.ResolveReviewThread("T_2")
+		if strings.Join(*hosts, " ") != "ghe.corp.io ghe.corp.io" {
+			t.Errorf("expected the checkout's host for both calls, got %v", *hosts)
+		}
+		if len(gitBase.ExecCommandCalls) != 2 {
+			t.Errorf("expected the remotes read once, got %d git calls", len(gitBase.ExecCommandCalls))
+		}
+	})
+
+	t.Run("GH_HOST overrides the remote", func(t *testing.T) {
+		t.Setenv("GH_HOST", "ghe.other.io")
+		app, gitBase, hosts := newRecording(t)
+		gheRemote(gitBase)
+
+		if _, err := app.PRView("7"); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if strings.Join(*hosts, " ") != "ghe.other.io" {
+			t.Errorf("expected $GH_HOST, got %v", *hosts)
+		}
+	})
+
+	t.Run("New builds an enterprise client with the enterprise token", func(t *testing.T) {
+		t.Setenv("GH_ENTERPRISE_TOKEN", "from-enterprise")
+		c := New().API("ghe.corp.io")
+		if c.RESTURL != "https://ghe.corp.io/api/v3" {
+			t.Errorf("unexpected endpoint %s", c.RESTURL)
+		}
+		if token, err := c.Token(); err != nil || token != "from-enterprise" {
+			t.Errorf("expected the enterprise token, got %q, %v", token, err)
+		}
+	})
+}
+
+func TestGraphQL(t *testing.T) {
+	app, _ := newTestGithub(t, func(w http.ResponseWriter, r *http.Request) {
+		req := decodeGraphQL(t, r)
+		if req.Variables["owner"] != "octocat" || req.Variables["pr"] != float64(42) {
+			t.Errorf("unexpected variables: %v", req.Variables)
+		}
+		_, _ = w.Write([]byte(`{"data":{"viewer":{"login":"octocat"}}}`))
+	})
+
+	out, err := app.GraphQL(
+		"query { viewer { login } }",
+		map[string]any{"owner": "octocat", "pr": 42},
+	)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if out != `{"data":{"viewer":{"login":"octocat"}}}` {
+		t.Fatalf("expected the data envelope, got %q", out)
+	}
+}
+
+func TestFetchReviewThreads(t *testing.T) {
+	t.Run("follows thread and comment pages into one document", func(t *testing.T) {
+		var queries []graphQLRequest
+		app, _ := newTestGithub(t, func(w http.ResponseWriter, r *http.Request) {
+			req := decodeGraphQL(t, r)
+			queries = append(queries, req)
+			switch {
+			case strings.Contains(req.Query, "node(id:"):
+				_, _ = w.Write([]byte(`{"data":{"node":{"comments":{
+					"nodes":[{"id":"C2","body":"second"}],
+					"pageInfo":{"hasNextPage":false,"endCursor":"c2"}}}}}`))
+			case req.Variables["endCursor"] == nil:
+				_, _ = w.Write([]byte(`{"data":{"repository":{"pullRequest":{"reviewThreads":{
+					"nodes":[{"id":"T1","path":"a.go","line":1,
+						"comments":{"nodes":[{"id":"C1","body":"first"}],
+							"pageInfo":{"hasNextPage":true,"endCursor":"c1"}},
+						"firstComment":{"nodes":[{"diffHunk":"@@"}]}}],
+					"pageInfo":{"hasNextPage":true,"endCursor":"t1"}}}}}}`))
+			default:
+				_, _ = w.Write([]byte(`{"data":{"repository":{"pullRequest":{"reviewThreads":{
+					"nodes":[{"id":"T2","isResolved":true,"resolvedBy":{"login":"bob"},"path":"b.go",
+						"comments":{"nodes":[{"id":"C3","body":"third"}],
+							"pageInfo":{"hasNextPage":false,"endCursor":"c3"}}}],
+					"pageInfo":{"hasNextPage":false,"endCursor":"t2"}}}}}}`))
+			}
+		})
+
+		out, err := app.FetchReviewThreads("octocat", "hello", "42")
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if len(queries) != 3 {
+			t.Fatalf("expected 2 thread pages + 1 comment page, got %d queries", len(queries))
+		}
+		first := queries[0]
+		if first.Variables["owner"] != "octocat" || first.Variables["name"] != "hello" ||
+			first.Variables["pr"] != float64(42) {
+			t.Errorf("unexpected variables: %v", first.Variables)
+		}
+		for _, field := range []string{"resolvedBy", "isOutdated", "createdAt", "firstComment", "diffHunk"} {
+			if !strings.Contains(first.Query, field) {
+				t.Errorf("expected %s in the threads query", field)
+			}
+		}
+		if queries[1].Variables["endCursor"] != "t1" {
+			t.Errorf("expected the second thread page after t1, got %v", queries[1].Variables)
+		}
+		if queries[2].Variables["id"] != "T1" || queries[2].Variables["endCursor"] != "c1" {
+			t.Errorf("expected T1's comments after c1, got %v", queries[2].Variables)
+		}
+
+		var doc struct {
+			Data struct {
+				Repository struct {
+					PullRequest struct {
+						ReviewThreads struct {
+							Nodes []reviewThread `json:"nodes"`
+						} `json:"reviewThreads"`
+					} `json:"pullRequest"`
+				} `json:"repository"`
+			} `json:"data"`
+		}
+		if err := json.Unmarshal([]byte(out), &doc); err != nil {
+			t.Fatalf("invalid JSON %q: %v", out, err)
+		}
+		threads := doc.Data.Repository.PullRequest.ReviewThreads.Nodes
+		if len(threads) != 2 || threads[0].ID != "T1" || threads[1].ID != "T2" {
+			t.Fatalf("expected threads T1 and T2, got %s", out)
+		}
+		if n := len(threads[0].Comments.Nodes); n != 2 || threads[0].Comments.Nodes[1].ID != "C2" {
+			t.Errorf("expected T1's comments merged across pages, got %s", out)
+		}
+		if threads[1].ResolvedBy == nil || threads[1].ResolvedBy.Login != "bob" {
+			t.Errorf("expected resolvedBy preserved, got %s", out)
+		}
+	})
+
+	t.Run("validates required args", func(t *testing.T) {
+		app, _ := newTestGithub(t, func(w http.ResponseWriter, r *http.Request) {
+			t.Error("expected no request when validation fails")
+		})
+
+		if _, err := app.FetchReviewThreads("", "hello", "42"); err == nil {
+			t.Fatal("expected error for empty owner")
+		}
+		if _, err := app.FetchReviewThreads("octocat", "hello", "abc"); err == nil {
+			t.Fatal("expected error for a non-numeric pr")
+		}
+	})
+}
+
+func TestFetchPRDiscussion(t *testing.T) {
+	t.Run("returns the discussion document", func(t *testing.T) {
+		payload := `{"data":{"repository":{"pullRequest":{"reviews":{"nodes":[]},"comments":{"nodes":[]}}}}}`
+		app, _ := newTestGithub(t, func(w http.ResponseWriter, r *http.Request) {
+			req := decodeGraphQL(t, r)
+			for _, field := range []string{"reviews", "submittedAt", "comments", "createdAt"} {
+				if !strings.Contains(req.Query, field) {
+					t.Errorf("expected %s in the discussion query", field)
+				}
+			}
+			_, _ = w.Write([]byte(payload))
+		})
+
+		out, err := app.FetchPRDiscussion("octocat", "hello", "42")
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if out != payload {
+			t.Fatalf("unexpected output: %q", out)
+		}
+	})
+
+	t.Run("validates required args", func(t *testing.T) {
+		app, _ := newTestGithub(t, func(w http.ResponseWriter, r *http.Request) {
+			t.Error("expected no request when validation fails")
+		})
+
+		if _, err := app.FetchPRDiscussion("octocat", "", "42"); err == nil {
+			t.Fatal("expected error for empty repo")
+		}
+		if _, err := app.FetchPRDiscussion("octocat", "hello", ""); err == nil {
+			t.Fatal("expected error for empty pr number")
+		}
+	})
+
+	t.Run("surfaces GraphQL errors", func(t *testing.T) {
+		app, _ := newTestGithub(t, func(w http.ResponseWriter, r *http.Request) {
+			_, _ = w.Write([]byte(`{"errors":[{"message":"Could not resolve to a PullRequest"}]}`))
+		})
+
+		_, err := app.FetchPRDiscussion("octocat", "hello", "42")
+		if err == nil || !strings.Contains(err.Error(), "Could not resolve") {
+			t.Fatalf("expected the GraphQL error, got %v", err)
+		}
+	})
+}
+
+func TestReviewThreadMutations(t *testing.T) {
+	cases := []struct {
+		name     string
+		run      func(g *GithubCli) (string, error)
+		mutation string
+		wantVars map[string]any
+	}{
+		{
+			name:     "resolve",
+			run:      func(g *GithubCli) (string, error) { return g.ResolveReviewThread("PRRT_abc") },
+			mutation: "resolveReviewThread(",
+			wantVars: map[string]any{"threadId": "PRRT_abc"},
+		},
+		{
+			name:     "unresolve",
+			run:      func(g *GithubCli) (string, error) { return g.UnresolveReviewThread("PRRT_abc") },
+			mutation: "unresolveReviewThread(",
+			wantVars: map[string]any{"threadId": "PRRT_abc"},
+		},
+		{
+			name: "reply",
+			run: func(g *GithubCli) (string, error) {
+				return g.ReplyToReviewThread("PRRT_abc", "Fixed in abc123")
+			},
+			mutation: "addPullRequestReviewThreadReply",
+			wantVars: map[string]any{"threadId": "PRRT_abc", "body": "Fixed in abc123"},
+		},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			app, _ := newTestGithub(t, func(w http.ResponseWriter, r *http.Request) {
+				req := decodeGraphQL(t, r)
+				if !strings.Contains(req.Query, c.mutation) {
+					t.Errorf("expected %s mutation, got %s", c.mutation, req.Query)
+				}
+				for k, v := range c.wantVars {
+					if req.Variables[k] != v {
+						t.Errorf("%s: expected %v, got %v", k, v, req.Variables[k])
+					}
+				}
+				_, _ = w.Write([]byte(`{"data":{}}`))
+			})
+
+			if _, err := c.run(app); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+		})
+	}
+
+	t.Run("require a thread id and body", func(t *testing.T) {
+		app, _ := newTestGithub(t, func(w http.ResponseWriter, r *http.Request) {
+			t.Error("expected no request when validation fails")
+		})
+
+		if _, err := app.ResolveReviewThread(""); err == nil {
+			t.Error("expected error for an empty thread id")
+		}
+		if _, err := app.UnresolveReviewThread(""); err == nil {
+			t.Error("expected error for an empty thread id")
+		}
+		if _, err := app.ReplyToReviewThread("PRRT_abc", ""); err == nil {
+			t.Error("expected error for an empty body")
+		}
+	})
+}
+
+func TestCreateReview(t *testing.T) {
+	app, _ := newTestGithub(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.URL.Path != "/repos/octocat/hello/pulls/42/reviews" {
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+		body, _ := io.ReadAll(r.Body)
+		if string(body) != `{"event":"APPROVE"}` {
+			t.Errorf("expected the payload verbatim, got %s", body)
+		}
+		_, _ = w.Write([]byte(`{"id":1}`))
+	})
+
+	out, err := app.CreateReview("octocat", "hello", "42", `{"event":"APPROVE"}`)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if out != `{"id":1}` {
+		t.Fatalf("unexpected output: %q", out)
+	}
+	if _, err := app.CreateReview("octocat", "hello", "42", "{bad"); err == nil {
+		t.Fatal("expected error for an invalid payload")
+	}
+}
+
+func TestPRView(t *testing.T) {
+	t.Run("returns the pull request fields", func(t *testing.T) {
+		app, gitBase := newTestGithub(t, func(w http.ResponseWriter, r *http.Request) {
+			req := decodeGraphQL(t, r)
+			if req.Variables["pr"] != float64(7) || req.Variables["owner"] != "octocat" {
+				t.Errorf("unexpected variables: %v", req.Variables)
+			}
+			_, _ = w.Write([]byte(`{"data":{"repository":{"pullRequest":{"number":7,"title":"Add login"}}}}`))
+		})
+		originRemote(gitBase)
+
+		out, err := app.PRView("7")
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if out != `{"number":7,"title":"Add login"}` {
+			t.Fatalf("unexpected output: %q", out)
+		}
+	})
+
+	t.Run("missing pull request errors", func(t *testing.T) {
+		app, gitBase := newTestGithub(t, func(w http.ResponseWriter, r *http.Request) {
+			_, _ = w.Write([]byte(`{"data":{"repository":{"pullRequest":null}}}`))
+		})
+		originRemote(gitBase)
+
+		if _, err := app.PRView("7"); err == nil || !strings.Contains(err.Error(), "not found") {
+			t.Fatalf("expected a not-found error, got %v", err)
+		}
+	})
+}
+
+func TestPRChecks(t *testing.T) {
+	t.Run("maps check runs and statuses to gh's shape", func(t *testing.T) {
+		app, gitBase := newTestGithub(t, func(w http.ResponseWriter, r *http.Request) {
+			switch r.URL.Path {
+			case "/repos/octocat/hello/pulls/7":
+				_, _ = w.Write([]byte(`{"head":{"sha":"abc"}}`))
+			case "/repos/octocat/hello/commits/abc/check-runs":
+				_, _ = w.Write([]byte(`{"check_runs":[
+					{"name":"build","status":"completed","conclusion":"success",
+					 "details_url":"https://github.com/octocat/hello/actions/runs/1/job/2","check_suite":{"id":10}},
+					{"name":"test","status":"completed","conclusion":"failure",
+					 "html_url":"https://github.com/octocat/hello/runs/3","check_suite":{"id":10}},
+					{"name":"lint","status":"in_progress","check_suite":{"id":11}}]}`))
+			case "/repos/octocat/hello/actions/runs":
+				if r.URL.Query().Get("head_sha") != "abc" {
+					t.Errorf("expected workflow runs for abc, got %s", r.URL.RawQuery)
+				}
+				_, _ = w.Write([]byte(`{"workflow_runs":[{"name":"CI","check_suite_id":10}]}`))
+			case "/repos/octocat/hello/commits/abc/status":
+				_, _ = w.Write([]byte(`{"statuses":[
+					{"context":"ci/jenkins","state":"error","target_url":"https://ci.example.com/1"}]}`))
+			default:
+				t.Errorf("unexpected request %s", r.URL)
+				w.WriteHeader(http.StatusNotFound)
+			}
+		})
+		originRemote(gitBase)
+
+		out, err := app.PRChecks("7")
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		var checks []check
+		if err := json.Unmarshal([]byte(out), &checks); err != nil {
+			t.Fatalf("invalid JSON %q: %v", out, err)
+		}
+		want := []check{
+			{"build", "SUCCESS", "https://github.com/octocat/hello/actions/runs/1/job/2", "CI", "pass"},
+			{"test", "FAILURE", "https://github.com/octocat/hello/runs/3", "CI", "fail"},
+			{"lint", "IN_PROGRESS", "", "", "pending"},
+			{"ci/jenkins", "ERROR", "https://ci.example.com/1", "", "fail"},
+		}
+		if len(checks) != len(want) {
+			t.Fatalf("expected %d checks, got %s", len(want), out)
+		}
+		for i := range want {
+			if checks[i] != want[i] {
+				t.Errorf("check %d: expected %+v, got %+v", i, want[i], checks[i])
+			}
+		}
+	})
+
+	t.Run("no checks is an empty array", func(t *testing.T) {
+		app, gitBase := newTestGithub(t, func(w http.ResponseWriter, r *http.Request) {
+			switch r.URL.Path {
+			case "/repos/octocat/hello/pulls/7":
+				_, _ = w.Write([]byte(`{"head":{"sha":"abc"}}`))
+			case "/repos/octocat/hello/commits/abc/check-runs":
+				_, _ = w.Write([]byte(`{"check_runs":[]}`))
+			case "/repos/octocat/hello/commits/abc/status":
+				_, _ = w.Write([]byte(`{"statuses":[]}`))
+			default:
+				t.Errorf("unexpected request %s", r.URL)
+			}
+		})
+		originRemote(gitBase)
+
+		out, err := app.PRChecks("7")
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if out != "[]" {
+			t.Fatalf("expected empty array, got %q", out)
+		}
+	})
+
+	t.Run("API failure surfaces", func(t *testing.T) {
+		app, gitBase := newTestGithub(t, func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = w.Write([]byte(`{"message":"Not Found"}`))
+		})
+		originRemote(gitBase)
+
+		if _, err := app.PRChecks("7"); err == nil || !strings.Contains(err.Error(), "Not Found") {
+			t.Fatalf("expected the API error, got %v", err)
+		}
+	})
+}
+
+func TestPRHeadSHA(t *testing.T) {
+	app, gitBase := newTestGithub(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/repos/octocat/hello/pulls/7" {
+			t.Errorf("unexpected request %s", r.URL)
+		}
+		_, _ = w.Write([]byte(`{"head":{"sha":"abc"}}`))
+	})
+	originRemote(gitBase)
+
+	sha, err := app.PRHeadSHA("7")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if sha != "abc" {
+		t.Fatalf("expected the head sha, got %q", sha)
+	}
+}
+
+func TestCheckBucket(t *testing.T) {
+	cases := map[string]string{
+		"SUCCESS":         "pass",
+		"NEUTRAL":         "skipping",
+		"SKIPPED":         "skipping",
+		"CANCELLED":       "cancel",
+		"FAILURE":         "fail",
+		"TIMED_OUT":       "fail",
+		"ACTION_REQUIRED": "fail",
+		"ERROR":           "fail",
+		"QUEUED":          "pending",
+		"PENDING":         "pending",
+	}
+	for state, want := range cases {
+		if got := checkBucket(state); got != want {
+			t.Errorf("checkBucket(%q) = %q, want %q", state, got, want)
+		}
+	}
+}
+
+func TestCheckRunBucket(t *testing.T) {
+	cases := []struct{ status, conclusion, want string }{
+		{"completed", "success", "pass"},
+		{"completed", "failure", "fail"},
+		{"completed", "stale", "skipping"},
+		{"completed", "", "skipping"},
+		{"in_progress", "", "pending"},
+		{"queued", "", "pending"},
+	}
+	for _, c := range cases {
+		if got := checkRunBucket(c.status, checkRunState(c.status, c.conclusion)); got != c.want {
+			t.Errorf("checkRunBucket(%q, %q) = %q, want %q", c.status, c.conclusion, got, c.want)
+		}
+	}
+}
+
+func TestCurrentPRNumber(t *testing.T) {
+	// pullsHandler answers the head-branch PR lookup with pulls.
+	pullsHandler := func(t *testing.T, wantHead, pulls string) http.HandlerFunc {
+		return func(w http.ResponseWriter, r *http.Request) {
+			if r.URL.Path != "/repos/octocat/hello/pulls" {
+				t.Errorf("unexpected request %s", r.URL)
+			}
+			if got := r.URL.Query().Get("head"); got != wantHead {
+				t.Errorf("expected head %q, got %q", wantHead, got)
+			}
+			_, _ = w.Write([]byte(pulls))
+		}
+	}
+
+	t.Run("prefers the open pull request", func(t *testing.T) {
+		app, gitBase := newTestGithub(t, pullsHandler(t, "octocat:feat",
+			`[{"number":41,"state":"closed"},{"number":42,"state":"open"}]`))
+		gitBase.SetExecCommandResults(
+			commands.ExecCommandResult("origin\n", "", nil),
+			commands.ExecCommandResult("git@github.com:octocat/hello.git\n", "", nil),
+			commands.ExecCommandResult("feat\n", "", nil),
+			commands.ExecCommandResult("", "", fmt.Errorf("exit 1")), // no branch.feat.remote
+			commands.ExecCommandResult("git@github.com:octocat/hello.git\n", "", nil),
+		)
+
+		n, err := app.CurrentPRNumber()
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if n != "42" {
+			t.Fatalf("expected 42, got %q", n)
+		}
+	})
+
+	t.Run("fork branch is looked up upstream by its own owner", func(t *testing.T) {
+		app, gitBase := newTestGithub(t, pullsHandler(t, "me:feat", `[{"number":9,"state":"merged"}]`))
+		gitBase.SetExecCommandResults(
+			commands.ExecCommandResult("origin\nupstream\n", "", nil),
+			commands.ExecCommandResult("https://github.com/octocat/hello.git\n", "", nil),
+			commands.ExecCommandResult("feat\n", "", nil),
+			commands.ExecCommandResult("origin\n", "", nil),
+			commands.ExecCommandResult("git@github.com:me/hello.git\n", "", nil),
+		)
+
+		n, err := app.CurrentPRNumber()
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if n != "9" {
+			t.Fatalf("expected 9, got %q", n)
+		}
+		if call := gitBase.ExecCommandCalls[1]; strings.Join(call.Args, " ") != "remote get-url upstream" {
+			t.Errorf("expected the base repo from upstream, got %v", call.Args)
+		}
+	})
+
+	t.Run("no pr returns empty without error", func(t *testing.T) {
+		app, gitBase := newTestGithub(t, pullsHandler(t, "octocat:feat", `[]`))
+		gitBase.SetExecCommandResults(
+			commands.ExecCommandResult("origin\n", "", nil),
+			commands.ExecCommandResult("git@github.com:octocat/hello.git\n", "", nil),
+			commands.ExecCommandResult("feat\n", "", nil),
+			commands.ExecCommandResult("origin\n", "", nil),
+			commands.ExecCommandResult("git@github.com:octocat/hello.git\n", "", nil),
+		)
+
+		n, err := app.CurrentPRNumber()
+		if err != nil {
+			t.Fatalf("expected nil error for no PR, got: %v", err)
+		}
+		if n != "" {
+			t.Fatalf("expected empty number, got %q", n)
+		}
+	})
+
+	t.Run("detached HEAD has no pr", func(t *testing.T) {
+		app, gitBase := newTestGithub(t, func(w http.ResponseWriter, r *http.Request) {
+			t.Error("expected no API call on a detached HEAD")
+		})
+		gitBase.SetExecCommandResults(
+			commands.ExecCommandResult("origin\n", "", nil),
+			commands.ExecCommandResult("git@github.com:octocat/hello.git\n", "", nil),
+			commands.ExecCommandResult("\n", "", nil),
+		)
+
+		if n, err := app.CurrentPRNumber(); err != nil || n != "" {
+			t.Fatalf("expected no PR and no error, got %q, %v", n, err)
+		}
+	})
+
+	t.Run("API error propagates", func(t *testing.T) {
+		app, gitBase := newTestGithub(t, func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusInternalServerError)
+		})
+		gitBase.SetExecCommandResults(
+			commands.ExecCommandResult("origin\n", "", nil),
+			commands.ExecCommandResult("git@github.com:octocat/hello.git\n", "", nil),
+			commands.ExecCommandResult("feat\n", "", nil),
+			commands.ExecCommandResult("origin\n", "", nil),
+			commands.ExecCommandResult("git@github.com:octocat/hello.git\n", "", nil),
+		)
+
+		if _, err := app.CurrentPRNumber(); err == nil {
+			t.Fatal("expected the API error to propagate")
+		}
+	})
+}
+
+func TestCurrentRepo(t *testing.T) {
+	t.Run("returns owner/name from origin", func(t *testing.T) {
+		app, gitBase := newTestGithub(t, nil)
+		originRemote(gitBase)
+
+		r, err := app.CurrentRepo()
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if r != "octocat/hello" {
+			t.Fatalf("expected octocat/hello, got %q", r)
+		}
+	})
+
+	t.Run("not a GitHub remote errors", func(t *testing.T) {
+		app, gitBase := newTestGithub(t, nil)
+		gitBase.SetExecCommandResults(
+			commands.ExecCommandResult("origin\n", "", nil),
+			commands.ExecCommandResult("/srv/repos/hello.git\n", "", nil),
+		)
+
+		if _, err := app.CurrentRepo(); err == nil {
+			t.Fatal("expected error for a non-GitHub remote")
+		}
+	})
+
+	t.Run("no remote errors", func(t *testing.T) {
+		app, gitBase := newTestGithub(t, nil)
+		gitBase.SetExecCommandResult("", "", nil)
+
+		if _, err := app.CurrentRepo(); err == nil {
+			t.Fatal("expected error when there is no remote")
+		}
+	})
+}
@@ -0,0 +1,776 @@
+// GitLab CLI (glab) as the merge-request backend for `dg task` PR commands
+//
+// glab is GitLab's official command-line tool. devgita drives it (mostly its
+// `glab api` REST passthrough, which handles authentication and self-hosted
+// instances) when a repository's origin remote points at GitLab. glab is not
+// installed by devgita; it must be on PATH and authenticated (`glab auth
+// login --hostname <host>`).
+//
+// Every fetch below returns JSON reshaped into the form githubcli returns for
+// the same call (GitHub GraphQL for review threads and discussion, gh's
+// --json output for view and checks), so task.PRManager's formatters — and
+// the markdown agents read — don't change with the forge.
+//
+// References:
+// - GitLab CLI Documentation: https://gitlab.com/gitlab-org/cli/-/tree/main/docs
+// - Discussions API: https://docs.gitlab.com/api/discussions/
+// - Merge requests API: https://docs.gitlab.com/api/merge_requests/
+
+package gitlabcli
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/url"
+	"os"
+	"regexp"
+	"strconv"
+	"strings"
+
+	cmd "github.com/cjairm/devgita/internal/commands"
+	"github.com/cjairm/devgita/pkg/constants"
+)
+
+// currentProject is glab's placeholder for the project of the current
+// directory's repository, resolved from its git remotes.
+const currentProject = "projects/:id"
+
+type GitlabCli struct {
+	Base cmd.BaseCommandExecutor
+}
+
+func New() *GitlabCli {
+	return &GitlabCli{Base: cmd.NewBaseCommand()}
+}
+
+// RunWithOutput runs a glab command and returns captured stdout.
+func (g *GitlabCli) RunWithOutput(args ...string) (string, error) {
+	execCommand := cmd.CommandParams{
+		IsSudo:  false,
+		Command: constants.GitlabCli,
+		Args:    args,
+	}
+	stdout, stderr, err := g.Base.ExecCommand(execCommand)
+	if err != nil {
+		if msg := strings.TrimSpace(stderr); msg != "" {
+			return stdout, fmt.Errorf("glab: %s", msg)
+		}
+		return stdout, fmt.Errorf("failed to run glab command: %w", err)
+	}
+	return stdout, nil
+}
+
+// ExecuteCommand runs a glab command, discarding its output.
+func (g *GitlabCli) ExecuteCommand(args ...string) error {
+	_, err := g.RunWithOutput(args...)
+	return err
+}
+
+// apiJSON sends payload as a JSON request body to a REST endpoint. glab's
+// -f/-F flags can't express nested objects (an inline comment's position),
+// so the body goes through --input, like githubcli.CreateReview.
+func (g *GitlabCli) apiJSON(method, endpoint string, payload any) (string, error) {
+	data, err := json.Marshal(payload)
+	if err != nil {
+		return "", fmt.Errorf("failed to encode request: %w", err)
+	}
+	tmp, err := os.CreateTemp("", "devgita-glab-*.json")
+	if err != nil {
+		return "", fmt.Errorf("failed to create temp file: %w", err)
+	}
+	tmpName := tmp.Name()
+	defer func() { _ = os.Remove(tmpName) }()
+	if _, err := tmp.Write(data); err != nil {
+		_ = tmp.Close()
+		return "", fmt.Errorf("failed to write temp file: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return "", fmt.Errorf("failed to close temp file: %w", err)
+	}
+	return g.RunWithOutput(
+		"api", "--method", method,
+		"-H", "Content-Type: application/json",
+		endpoint, "--input", tmpName,
+	)
+}
+
+// projectPath is the API path of the project owner/repo; owner may itself
+// contain slashes (nested groups).
+func projectPath(owner, repo string) string {
+	return "projects/" + url.PathEscape(owner+"/"+repo)
+}
+
+// mrArgs appends the merge request to a glab mr subcommand when one was
+// given; glab targets the current branch's merge request otherwise.
+func mrArgs(prNumber string, args ...string) []string {
+	if prNumber == "" {
+		return args
+	}
+	return append(args[:2:2], append([]string{prNumber}, args[2:]...)...)
+}
+
+// --- Repository and merge request resolution ---
+
+// CurrentRepo returns the current project as "group/name"; nested groups
+// stay in the first part ("group/sub/name").
+func (g *GitlabCli) CurrentRepo() (string, error) {
+	out, err := g.RunWithOutput("api", currentProject)
+	if err != nil {
+		return "", err
+	}
+	var project struct {
+		Path string `json:"path_with_namespace"`
+	}
+	if err := json.Unmarshal([]byte(out), &project); err != nil || project.Path == "" {
+		return "", fmt.Errorf("glab: unexpected project response")
+	}
+	return project.Path, nil
+}
+
+// CurrentPRNumber returns the iid of the open merge request whose source is
+// the current branch, or "" with a nil error when there is none.
+func (g *GitlabCli) CurrentPRNumber() (string, error) {
+	out, err := g.RunWithOutput(
+		"api", currentProject+"/merge_requests?state=opened&source_branch=:branch",
+	)
+	if err != nil {
+		return "", fmt.Errorf("failed to resolve current merge request: %w", err)
+	}
+	var mrs []struct {
+		IID int `json:"iid"`
+	}
+	if err := json.Unmarshal([]byte(out), &mrs); err != nil {
+		return "", fmt.Errorf("glab: unexpected merge requests response")
+	}
+	if len(mrs) == 0 {
+		return "", nil
+	}
+	return strconv.Itoa(mrs[0].IID), nil
+}
+
+// resolvePR returns prNumber, or the current branch's merge request.
+func (g *GitlabCli) resolvePR(prNumber string) (string, error) {
+	if prNumber != "" {
+		return prNumber, nil
+	}
+	pr, err := g.CurrentPRNumber()
+	if err != nil {
+		return "", err
+	}
+	if pr == "" {
+		return "", fmt.Errorf("no merge request found for the current branch; pass --pr")
+	}
+	return pr, nil
+}
+
+// --- Review threads and discussion ---
+
+type user struct {
+	Username string `json:"username"`
+}
+
+type note struct {
+	ID         int64  `json:"id"`
+	Body       string `json:"body"`
+	System     bool   `json:"system"`
+	CreatedAt  string `json:"created_at"`
+	Author     user   `json:"author"`
+	Resolved   bool   `json:"resolved"`
+	ResolvedBy *user  `json:"resolved_by"`
+	Position   *struct {
+		NewPath string `json:"new_path"`
+		OldPath string `json:"old_path"`
+		NewLine *int   `json:"new_line"`
+		OldLine *int   `json:"old_line"`
+	} `json:"position"`
+}
+
+type discussion struct {
+	ID    string `json:"id"`
+	Notes []note `json:"notes"`
+}
+
+// fetchDiscussions returns every discussion on a merge request, across
+// pages. glab --paginate prints one JSON array per page, so the output is
+// decoded as a stream.
+func (g *GitlabCli) fetchDiscussions(owner, repo, prNumber string) ([]discussion, error) {
+	out, err := g.RunWithOutput(
+		"api", "--paginate",
+		fmt.Sprintf("%s/merge_requests/%s/discussions?per_page=100", projectPath(owner, repo), prNumber),
+	)
+	if err != nil {
+		return nil, err
+	}
+	var all []discussion
+	dec := json.NewDecoder(strings.NewReader(out))
+	for {
+		var page []discussion
+		if err := dec.Decode(&page); errors.Is(err, io.EOF) {
+			return all, nil
+		} else if err != nil {
+			return nil, fmt.Errorf("glab: unexpected discussions response: %w", err)
+		}
+		all = append(all, page...)
+	}
+}
+
+// encodeThreadID is what a rendered thread is addressed by: GitLab needs the merge
+// request as well as the discussion to resolve or reply, and
+// ResolveReviewThread/ReplyToReviewThread only get the id.
+func encodeThreadID(prNumber, discussionID string) string {
+	return prNumber + ":" + discussionID
+}
+
+// parseThreadID splits an encodeThreadID id back into its merge request and discussion.
+func parseThreadID(id string) (pr, discussionID string, err error) {
+	pr, discussionID, ok := strings.Cut(id, ":")
+	if !ok || pr == "" || discussionID == "" {
+		return "", "", fmt.Errorf("unexpected thread id %q (want <mr>:<discussion>)", id)
+	}
+	return pr, discussionID, nil
+}
+
+type ghLogin struct {
+	Login string `json:"login"`
+}
+
+type ghComment struct {
+	ID        string  `json:"id,omitempty"`
+	Author    ghLogin `json:"author"`
+	Body      string  `json:"body"`
+	CreatedAt string  `json:"createdAt"`
+}
+
+type ghNodes[T any] struct {
+	Nodes []T `json:"nodes"`
+}
+
+type ghThread struct {
+	ID           string             `json:"id"`
+	IsResolved   bool               `json:"isResolved"`
+	IsOutdated   bool               `json:"isOutdated"`
+	ResolvedBy   *ghLogin           `json:"resolvedBy"`
+	Path         string             `json:"path"`
+	Line         *int               `json:"line"`
+	OriginalLine *int               `json:"originalLine"`
+	Comments     ghNodes[ghComment] `json:"comments"`
+	FirstComment ghNodes[any]       `json:"firstComment"` // no diff hunks on GitLab
+}
+
+// pullRequestJSON wraps pr in the data.repository.pullRequest envelope the
+// GitHub GraphQL responses (and the formatters reading them) use.
+func pullRequestJSON(pr any) (string, error) {
+	out, err := json.Marshal(map[string]any{
+		"data": map[string]any{"repository": map[string]any{"pullRequest": pr}},
+	})
+	if err != nil {
+		return "", fmt.Errorf("failed to encode response: %w", err)
+	}
+	return string(out), nil
+}
+
+// FetchReviewThreads returns a merge request's diff discussions as GitHub
+// GraphQL reviewThreads JSON (see githubcli.FetchReviewThreads). GitLab
+// doesn't return the diff hunk with a discussion or flag it outdated, so
+// those render as absent.
+func (g *GitlabCli) FetchReviewThreads(owner, repo, prNumber string) (string, error) {
+	if owner == "" || repo == "" || prNumber == "" {
+		return "", fmt.Errorf("fetch review threads requires owner, repo, and pr number")
+	}
+	discussions, err := g.fetchDiscussions(owner, repo, prNumber)
+	if err != nil {
+		return "", err
+	}
+	threads := []ghThread{}
+	for _, d := range discussions {
+		if len(d.Notes) == 0 || d.Notes[0].Position == nil {
+			continue
+		}
+		first := d.Notes[0]
+		t := ghThread{
+			ID:           encodeThreadID(prNumber, d.ID),
+			IsResolved:   first.Resolved,
+			Path:         first.Position.NewPath,
+			Line:         first.Position.NewLine,
+			OriginalLine: first.Position.OldLine,
+			Comments:     ghNodes[ghComment]{Nodes: []ghComment{}},
+		}
+		if t.Path == "" {
+			t.Path = first.Position.OldPath
+		}
+		if t.Line == nil {
+			t.Line = first.Position.OldLine
+		}
+		if first.ResolvedBy != nil {
+			t.ResolvedBy = &ghLogin{Login: first.ResolvedBy.Username}
+		}
+		for _, n := range d.Notes {
+			if n.System {
+				continue
+			}
+			t.Comments.Nodes = append(t.Comments.Nodes, ghComment{
+				ID:        strconv.FormatInt(n.ID, 10),
+				Author:    ghLogin{Login: n.Author.Username},
+				Body:      n.Body,
+				CreatedAt: n.CreatedAt,
+			})
+		}
+		threads = append(threads, t)
+	}
+	return pullRequestJSON(map[string]any{"reviewThreads": ghNodes[ghThread]{Nodes: threads}})
+}
+
+// FetchPRDiscussion returns a merge request's comments that aren't anchored
+// to the diff (system notes excluded) as GitHub GraphQL discussion JSON (see
+// githubcli.FetchPRDiscussion). GitLab approvals carry no body, so there are
+// never any review summaries.
+func (g *GitlabCli) FetchPRDiscussion(owner, repo, prNumber string) (string, error) {
+	if owner == "" || repo == "" || prNumber == "" {
+		return "", fmt.Errorf("fetch pr discussion requires owner, repo, and pr number")
+	}
+	discussions, err := g.fetchDiscussions(owner, repo, prNumber)
+	if err != nil {
+		return "", err
+	}
+	comments := []ghComment{}
+	for _, d := range discussions {
+		if len(d.Notes) > 0 && d.Notes[0].Position != nil {
+			continue
+		}
+		for _, n := range d.Notes {
+			if n.System {
+				continue
+			}
+			comments = append(comments, ghComment{
+				Author:    ghLogin{Login: n.Author.Username},
+				Body:      n.Body,
+				CreatedAt: n.CreatedAt,
+			})
+		}
+	}
+	return pullRequestJSON(map[string]any{
+		"reviews":  ghNodes[ghComment]{Nodes: []ghComment{}},
+		"comments": ghNodes[ghComment]{Nodes: comments},
+	})
+}
+
+// setThreadResolved resolves or reopens a discussion by threadID.
+func (g *GitlabCli) setThreadResolved(id string, resolved bool) (string, error) {
+	pr, discussionID, err := parseThreadID(id)
+	if err != nil {
+		return "", err
+	}
+	return g.RunWithOutput(
+		"api", "--method", "PUT",
+		fmt.Sprintf("%s/merge_requests/%s/discussions/%s", currentProject, pr, discussionID),
+		"-f", "resolved="+strconv.FormatBool(resolved),
+	)
+}
+
+// ResolveReviewThread marks a discussion resolved.
+func (g *GitlabCli) ResolveReviewThread(threadID string) (string, error) {
+	if threadID == "" {
+		return "", fmt.Errorf("resolve review thread requires a thread id")
+	}
+	return g.setThreadResolved(threadID, true)
+}
+
+// UnresolveReviewThread reopens a resolved discussion.
+func (g *GitlabCli) UnresolveReviewThread(threadID string) (string, error) {
+	if threadID == "" {
+		return "", fmt.Errorf("unresolve review thread requires a thread id")
+	}
+	return g.setThreadResolved(threadID, false)
+}
+
+// ReplyToReviewThread adds a note to a discussion.
+func (g *GitlabCli) ReplyToReviewThread(threadID, body string) (string, error) {
+	if threadID == "" || body == "" {
+		return "", fmt.Errorf("reply requires a thread id and body")
+	}
+	pr, discussionID, err := parseThreadID(threadID)
+	if err != nil {
+		return "", err
+	}
+	return g.RunWithOutput(
+		"api", "--method", "POST",
+		fmt.Sprintf("%s/merge_requests/%s/discussions/%s/notes", currentProject, pr, discussionID),
+		"-f", "body="+body,
+	)
+}
+
+// --- Reviews and merge request actions ---
+
+// ErrNoRequestChanges is returned, once the feedback is posted, by
+// RequestChangesPR and by CreateReview for a REQUEST_CHANGES review:
+// GitLab's API has no request-changes verdict, so nothing blocking was
+// recorded and callers must not report one.
+var ErrNoRequestChanges = errors.New("gitlab has no request-changes verdict")
+
+// reviewPayload is the GitHub reviews-endpoint body task.buildReviewPayload
+// assembles.
+type reviewPayload struct {
+	Body     string `json:"body"`
+	Event    string `json:"event"`
+	Comments []struct {
+		Path string `json:"path"`
+		Line int    `json:"line"`
+		Side string `json:"side"`
+		Body string `json:"body"`
+	} `json:"comments"`
+}
+
+// CreateReview replays a GitHub review payload as GitLab calls: one diff
+// discussion per inline comment (anchored to its last line; GitLab ranges
+// aren't mapped), the body as a note, then an approval for APPROVE. GitLab's
+// API has no request-changes verdict, so REQUEST_CHANGES posts like COMMENT
+// and then returns ErrNoRequestChanges.
+func (g *GitlabCli) CreateReview(owner, repo, prNumber, payloadJSON string) (string, error) {
+	if owner == "" || repo == "" || prNumber == "" {
+		return "", fmt.Errorf("create review requires owner, repo, and pr number")
+	}
+	var review reviewPayload
+	if err := json.Unmarshal([]byte(payloadJSON), &review); err != nil {
+		return "", fmt.Errorf("create review: invalid payload: %w", err)
+	}
+	mrPath := fmt.Sprintf("%s/merge_requests/%s", projectPath(owner, repo), prNumber)
+
+	if len(review.Comments) > 0 {
+		out, err := g.RunWithOutput("api", mrPath)
+		if err != nil {
+			return "", err
+		}
+		var mr struct {
+			DiffRefs map[string]string `json:"diff_refs"`
+		}
+		if err := json.Unmarshal([]byte(out), &mr); err != nil || mr.DiffRefs["head_sha"] == "" {
+			return "", fmt.Errorf("create review: merge request %s has no diff to comment on", prNumber)
+		}
+		for _, c := range review.Comments {
+			position := map[string]any{
+				"position_type": "text",
+				"base_sha":      mr.DiffRefs["base_sha"],
+				"start_sha":     mr.DiffRefs["start_sha"],
+				"head_sha":      mr.DiffRefs["head_sha"],
+				"old_path":      c.Path,
+				"new_path":      c.Path,
+			}
+			if strings.EqualFold(c.Side, "LEFT") {
+				position["old_line"] = c.Line
+			} else {
+				position["new_line"] = c.Line
+			}
+			if _, err := g.apiJSON("POST", mrPath+"/discussions", map[string]any{
+				"body": c.Body, "position": position,
+			}); err != nil {
+				return "", fmt.Errorf("create review: comment on %s:%d: %w", c.Path, c.Line, err)
+			}
+		}
+	}
+	if strings.TrimSpace(review.Body) != "" {
+		if _, err := g.apiJSON("POST", mrPath+"/notes", map[string]string{
+			"body": review.Body,
+		}); err != nil {
+			return "", err
+		}
+	}
+	switch review.Event {
+	case "APPROVE":
+		return g.RunWithOutput("api", "--method", "POST", mrPath+"/approve")
+	case "REQUEST_CHANGES":
+		return "", ErrNoRequestChanges
+	}
+	return "", nil
+}
+
+// urlLineRE matches the merge request URL glab mr create prints last.
+var urlLineRE = regexp.MustCompile(`https?://\S+`)
+
+// CreatePR opens a merge request from the current branch and returns its
+// URL. base is optional (defaults to the project's default branch).
+func (g *GitlabCli) CreatePR(title, body, base string) (string, error) {
+	if title == "" {
+		return "", fmt.Errorf("create pr requires a title")
+	}
+	args := []string{"mr", "create", "--title", title, "--description", body, "--yes"}
+	if base != "" {
+		args = append(args, "--target-branch", base)
+	}
+	out, err := g.RunWithOutput(args...)
+	if err != nil {
+		return "", err
+	}
+	if urls := urlLineRE.FindAllString(out, -1); len(urls) > 0 {
+		return urls[len(urls)-1], nil
+	}
+	return strings.TrimSpace(out), nil
+}
+
+// UpdatePRDescription replaces a merge request's description.
+func (g *GitlabCli) UpdatePRDescription(prNumber, body string) error {
+	return g.ExecuteCommand(mrArgs(prNumber, "mr", "update", "--description", body)...)
+}
+
+// ApprovePR approves a merge request, posting body as a note when given.
+func (g *GitlabCli) ApprovePR(prNumber, body string) error {
+	if err := g.ExecuteCommand(mrArgs(prNumber, "mr", "approve")...); err != nil {
+		return err
+	}
+	if body == "" {
+		return nil
+	}
+	return g.CommentPR(prNumber, body)
+}
+
+// RequestChangesPR posts body as a note and returns ErrNoRequestChanges:
+// GitLab has no request-changes verdict through its API, so the feedback is
+// all that's recorded.
+func (g *GitlabCli) RequestChangesPR(prNumber, body string) error {
+	if body == "" {
+		return fmt.Errorf("request changes requires a body")
+	}
+	if err := g.CommentPR(prNumber, body); err != nil {
+		return err
+	}
+	return ErrNoRequestChanges
+}
+
+// RequestReviewPR adds reviewers to a merge request.
+func (g *GitlabCli) RequestReviewPR(prNumber string, reviewers []string) error {
+	if len(reviewers) == 0 {
+		return fmt.Errorf("request review requires at least one reviewer")
+	}
+	added := make([]string, len(reviewers))
+	for i, r := range reviewers {
+		added[i] = "+" + r
+	}
+	return g.ExecuteCommand(
+		mrArgs(prNumber, "mr", "update", "--reviewer", strings.Join(added, ","))...,
+	)
+}
+
+// CommentPR posts a top-level note on a merge request.
+func (g *GitlabCli) CommentPR(prNumber, body string) error {
+	if body == "" {
+		return fmt.Errorf("comment requires a body")
+	}
+	return g.ExecuteCommand(mrArgs(prNumber, "mr", "note", "--message", body)...)
+}
+
+// MergePR merges a merge request. method is "squash" (default when empty),
+// "merge", or "rebase", as for githubcli.MergePR.
+func (g *GitlabCli) MergePR(prNumber, method string) error {
+	args := mrArgs(prNumber, "mr", "merge", "--yes")
+	switch method {
+	case "", "squash":
+		args = append(args, "--squash")
+	case "merge":
+	case "rebase":
+		args = append(args, "--rebase")
+	default:
+		return fmt.Errorf("unknown merge method %q (use squash, merge, or rebase)", method)
+	}
+	return g.ExecuteCommand(args...)
+}
+
+// PRView returns a merge request's metadata in the shape of
+// githubcli.PRView.
+func (g *GitlabCli) PRView(prNumber string) (string, error) {
+	pr, err := g.resolvePR(prNumber)
+	if err != nil {
+		return "", err
+	}
+	mrPath := currentProject + "/merge_requests/" + pr
+	out, err := g.RunWithOutput("api", mrPath)
+	if err != nil {
+		return "", err
+	}
+	var mr struct {
+		IID                 int    `json:"iid"`
+		Title               string `json:"title"`
+		State               string `json:"state"`
+		MergeStatus         string `json:"merge_status"`
+		DetailedMergeStatus string `json:"detailed_merge_status"`
+		SourceBranch        string `json:"source_branch"`
+		TargetBranch        string `json:"target_branch"`
+	}
+	if err := json.Unmarshal([]byte(out), &mr); err != nil {
+		return "", fmt.Errorf("glab: unexpected merge request response")
+	}
+
+	view := map[string]any{
+		"number":      mr.IID,
+		"title":       mr.Title,
+		"state":       prState(mr.State),
+		"mergeable":   mergeable(mr.MergeStatus, mr.DetailedMergeStatus),
+		"headRefName": mr.SourceBranch,
+		"baseRefName": mr.TargetBranch,
+	}
+	if approvals, err := g.RunWithOutput("api", mrPath+"/approvals"); err == nil {
+		var a struct {
+			Approved bool `json:"approved"`
+		}
+		if json.Unmarshal([]byte(approvals), &a) == nil {
+			view["reviewDecision"] = "REVIEW_REQUIRED"
+			if a.Approved {
+				view["reviewDecision"] = "APPROVED"
+			}
+		}
+	}
+	data, err := json.Marshal(view)
+	if err != nil {
+		return "", fmt.Errorf("failed to encode response: %w", err)
+	}
+	return string(data), nil
+}
+
+// PRHeadSHA returns the commit a merge request's source branch points at.
+func (g *GitlabCli) PRHeadSHA(prNumber string) (string, error) {
+	pr, err := g.resolvePR(prNumber)
+	if err != nil {
+		return "", err
+	}
+	out, err := g.RunWithOutput("api", currentProject+"/merge_requests/"+pr)
+	if err != nil {
+		return "", err
+	}
+	var mr struct {
+		SHA string `json:"sha"`
+	}
+	if err := json.Unmarshal([]byte(out), &mr); err != nil {
+		return "", fmt.Errorf("glab: unexpected merge request response")
+	}
+	return mr.SHA, nil
+}
+
+// prState maps a GitLab merge request state to GitHub's names.
+func prState(state string) string {
+	switch state {
+	case "opened":
+		return "OPEN"
+	case "merged":
+		return "MERGED"
+	case "closed":
+		return "CLOSED"
+	default:
+		return strings.ToUpper(state)
+	}
+}
+
+// mergeable maps GitLab's merge status to GitHub's mergeable values.
+func mergeable(status, detailed string) string {
+	switch {
+	case detailed == "conflict" || status == "cannot_be_merged":
+		return "CONFLICTING"
+	case detailed == "mergeable" || status == "can_be_merged":
+		return "MERGEABLE"
+	default:
+		return "UNKNOWN"
+	}
+}
+
+// --- CI ---
+
+// PRChecks returns the jobs of a merge request's latest pipeline in the
+// shape of gh pr checks --json name,state,link,workflow,bucket: state is the
+// job status upper-cased, workflow its stage, and bucket gh's categories. A
+// failed job that is allowed to fail is "skipping", not "fail", since it
+// doesn't block the merge.
+func (g *GitlabCli) PRChecks(prNumber string) (string, error) {
+	pr, err := g.resolvePR(prNumber)
+	if err != nil {
+		return "", err
+	}
+	out, err := g.RunWithOutput("api", currentProject+"/merge_requests/"+pr+"/pipelines")
+	if err != nil {
+		return "", err
+	}
+	var pipelines []struct {
+		ID int64 `json:"id"`
+	}
+	if err := json.Unmarshal([]byte(out), &pipelines); err != nil {
+		return "", fmt.Errorf("glab: unexpected pipelines response")
+	}
+	if len(pipelines) == 0 {
+		return "[]", nil
+	}
+
+	out, err = g.RunWithOutput("api", "--paginate", fmt.Sprintf(
+		"%s/pipelines/%d/jobs?per_page=100", currentProject, pipelines[0].ID,
+	))
+	if err != nil {
+		return "", err
+	}
+	type job struct {
+		Name         string `json:"name"`
+		Stage        string `json:"stage"`
+		Status       string `json:"status"`
+		AllowFailure bool   `json:"allow_failure"`
+		WebURL       string `json:"web_url"`
+	}
+	checks := []map[string]string{}
+	dec := json.NewDecoder(strings.NewReader(out))
+	for {
+		var page []job
+		if err := dec.Decode(&page); errors.Is(err, io.EOF) {
+			break
+		} else if err != nil {
+			return "", fmt.Errorf("glab: unexpected jobs response: %w", err)
+		}
+		for _, j := range page {
+			checks = append(checks, map[string]string{
+				"name":     j.Name,
+				"state":    strings.ToUpper(j.Status),
+				"bucket":   jobBucket(j.Status, j.AllowFailure),
+				"link":     j.WebURL,
+				"workflow": j.Stage,
+			})
+		}
+	}
+	data, err := json.Marshal(checks)
+	if err != nil {
+		return "", fmt.Errorf("failed to encode response: %w", err)
+	}
+	return string(data), nil
+}
+
+// jobBucket sorts a job status into gh's pass/fail/pending/skipping/cancel.
+func jobBucket(status string, allowFailure bool) string {
+	switch status {
+	case "success":
+		return "pass"
+	case "failed":
+		if allowFailure {
+			return "skipping"
+		}
+		return "fail"
+	case "canceled", "canceling":
+		return "cancel"
+	case "skipped", "manual":
+		return "skipping"
+	default:
+		return "pending"
+	}
+}
+
+// traceNoiseRE matches what GitLab adds to a job trace for its web viewer:
+// ANSI escapes and collapsible-section markers.
+var traceNoiseRE = regexp.MustCompile(
+	`\x1b\[[0-9;]*[A-Za-z]|section_(?:start|end):\d+:[^\r\n]*?\r`,
+)
+
+// RunFailedJobLog returns a job's trace with the viewer markup stripped, for
+// the failing-check digest (see task.PRManager.PRChecks). Unlike gh's
+// --log-failed it's the whole job, which is fine for a tail digest.
+func (g *GitlabCli) RunFailedJobLog(jobID string) (string, error) {
+	if strings.TrimSpace(jobID) == "" {
+		return "", fmt.Errorf("run failed job log requires a job id")
+	}
+	out, err := g.RunWithOutput("api", currentProject+"/jobs/"+jobID+"/trace")
+	if err != nil {
+		return "", err
+	}
+	return traceNoiseRE.ReplaceAllString(out, ""), nil
+}
@@ -0,0 +1,196 @@
+package gitlabcli
+
+import (
+	"encoding/json"
+	"errors"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/cjairm/devgita/internal/commands"
+	"github.com/cjairm/devgita/pkg/logger"
+)
+
+func init() {
+	// Initialize logger for tests
+	logger.Init(false)
+}
+
+func newMockGlab() (*GitlabCli, *commands.MockBaseCommand) {
+	base := commands.NewMockBaseCommand()
+	return &GitlabCli{Base: base}, base
+}
+
+func TestMRCommandArgs(t *testing.T) {
+	g, base := newMockGlab()
+
+	if err := g.MergePR("42", "rebase"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := g.MergePR("", ""); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := g.RequestReviewPR("42", []string{"alice", "bob"}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := g.MergePR("42", "fast"); err == nil {
+		t.Error("expected an unknown merge method to be rejected")
+	}
+
+	want := []string{
+		"mr merge 42 --yes --rebase",
+		"mr merge --yes --squash",
+		"mr update 42 --reviewer +alice,+bob",
+	}
+	if base.GetExecCommandCallCount() != len(want) {
+		t.Fatalf("expected %d glab calls, got %d", len(want), base.GetExecCommandCallCount())
+	}
+	for i, call := range base.ExecCommandCalls {
+		if call.Command != "glab" || strings.Join(call.Args, " ") != want[i] {
+			t.Errorf("call %d: expected glab %s, got %s %v", i, want[i], call.Command, call.Args)
+		}
+	}
+}
+
+func TestResolveReviewThreadUsesEncodedID(t *testing.T) {
+	g, base := newMockGlab()
+
+	if _, err := g.ResolveReviewThread("7:abc123"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	got := strings.Join(base.GetLastExecCommandCall().Args, " ")
+	want := "api --method PUT projects/:id/merge_requests/7/discussions/abc123 -f resolved=true"
+	if got != want {
+		t.Errorf("expected %q, got %q", want, got)
+	}
+	if _, err := g.ResolveReviewThread("abc123"); err == nil {
+		t.Error("expected a thread id without its merge request to be rejected")
+	}
+}
+
+func TestPRChecksMapsJobsToGhShape(t *testing.T) {
+	g, base := newMockGlab()
+	base.SetExecCommandResults(
+		commands.ExecCommandResult(`[{"id":900},{"id":800}]`, "", nil),
+		commands.ExecCommandResult(`[
+			{"name":"lint","stage":"test","status":"success","web_url":"https://gl/g/a/-/jobs/1"},
+			{"name":"unit","stage":"test","status":"failed","web_url":"https://gl/g/a/-/jobs/2"},
+			{"name":"flaky","stage":"test","status":"failed","allow_failure":true,"web_url":"https://gl/g/a/-/jobs/3"}
+		][
+			{"name":"deploy","stage":"deploy","status":"manual","web_url":"https://gl/g/a/-/jobs/4"}
+		]`, "", nil),
+	)
+
+	out, err := g.PRChecks("7")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got := strings.Join(base.ExecCommandCalls[1].Args, " "); !strings.Contains(got, "pipelines/900/jobs") {
+		t.Errorf("expected the latest pipeline's jobs, got %q", got)
+	}
+	var checks []map[string]string
+	if err := json.Unmarshal([]byte(out), &checks); err != nil {
+		t.Fatalf("invalid JSON %q: %v", out, err)
+	}
+	wantBuckets := []string{"pass", "fail", "skipping", "skipping"}
+	if len(checks) != len(wantBuckets) {
+		t.Fatalf("expected %d checks across both pages, got %d", len(wantBuckets), len(checks))
+	}
+	for i, c := range checks {
+		if c["bucket"] != wantBuckets[i] {
+			t.Errorf("check %s: expected bucket %q, got %q", c["name"], wantBuckets[i], c["bucket"])
+		}
+	}
+	if checks[1]["state"] != "FAILED" || checks[1]["link"] != "https://gl/g/a/-/jobs/2" ||
+		checks[1]["workflow"] != "test" {
+		t.Errorf("unexpected check shape: %v", checks[1])
+	}
+}
+
+func TestPRHeadSHA(t *testing.T) {
+	g, base := newMockGlab()
+	base.SetExecCommandResult(`{"iid":7,"sha":"abc123"}`, "", nil)
+
+	sha, err := g.PRHeadSHA("7")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if sha != "abc123" {
+		t.Errorf("expected the source branch head, got %q", sha)
+	}
+	if got := strings.Join(base.GetLastExecCommandCall().Args, " "); got != "api projects/:id/merge_requests/7" {
+		t.Errorf("unexpected glab call %q", got)
+	}
+}
+
+func TestPRViewMapsToGhFields(t *testing.T) {
+	g, base := newMockGlab()
+	base.SetExecCommandResults(
+		commands.ExecCommandResult(`{"iid":7,"title":"Add login","state":"opened",
+			"merge_status":"can_be_merged","source_branch":"feat/login","target_branch":"main"}`, "", nil),
+		commands.ExecCommandResult(`{"approved":true}`, "", nil),
+	)
+
+	out, err := g.PRView("7")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	var view map[string]any
+	if err := json.Unmarshal([]byte(out), &view); err != nil {
+		t.Fatalf("invalid JSON %q: %v", out, err)
+	}
+	want := map[string]any{
+		"number": float64(7), "title": "Add login", "state": "OPEN", "mergeable": "MERGEABLE",
+		"reviewDecision": "APPROVED", "headRefName": "feat/login", "baseRefName": "main",
+	}
+	for k, v := range want {
+		if view[k] != v {
+			t.Errorf("%s: expected %v, got %v", k, v, view[k])
+		}
+	}
+}
+
+func TestCreateReviewReplaysPayload(t *testing.T) {
+	g, base := newMockGlab()
+	base.SetExecCommandResults(
+		commands.ExecCommandResult(`{"diff_refs":{"base_sha":"b","start_sha":"s","head_sha":"h"}}`, "", nil),
+		commands.ExecCommandResult("{}", "", nil),
+	)
+
+	payload := `{"body":"LGTM","event":"APPROVE","comments":[{"path":"main.go","line":12,"body":"nit"}]}`
+	if _, err := g.CreateReview("group", "app", "7", payload); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	mrPath := "projects/group%2Fapp/merge_requests/7"
+	want := []string{mrPath, mrPath + "/discussions", mrPath + "/notes", mrPath + "/approve"}
+	if base.GetExecCommandCallCount() != len(want) {
+		t.Fatalf("expected %d glab calls, got %d", len(want), base.GetExecCommandCallCount())
+	}
+	for i, call := range base.ExecCommandCalls {
+		if !slices.Contains(call.Args, want[i]) {
+			t.Errorf("call %d: expected endpoint %s, got %v", i, want[i], call.Args)
+		}
+	}
+	if _, err := g.CreateReview("group", "app", "7", "not json"); err == nil {
+		t.Error("expected an invalid payload to be rejected")
+	}
+}
+
+func TestRequestChangesRecordsNoVerdict(t *testing.T) {
+	g, base := newMockGlab()
+
+	if err := g.RequestChangesPR("7", "Please fix"); !errors.Is(err, ErrNoRequestChanges) {
+		t.Fatalf("expected ErrNoRequestChanges once the note is posted, got %v", err)
+	}
+	if got := strings.Join(base.GetLastExecCommandCall().Args, " "); got != "mr note 7 --message Please fix" {
+		t.Errorf("expected the feedback posted as a note, got %q", got)
+	}
+
+	payload := `{"body":"Please fix","event":"REQUEST_CHANGES"}`
+	if _, err := g.CreateReview("group", "app", "7", payload); !errors.Is(err, ErrNoRequestChanges) {
+		t.Fatalf("expected ErrNoRequestChanges from a REQUEST_CHANGES review, got %v", err)
+	}
+	if call := base.GetLastExecCommandCall(); !slices.Contains(call.Args, "projects/group%2Fapp/merge_requests/7/notes") {
+		t.Errorf("expected the review body posted as a note, got %v", call.Args)
+	}
+}
@@ -100,7 +100,9 @@ type namedInstallable struct {
 
 type Terminal struct {
 	Cmd  commands.Command
-	Base commands.BaseCommand
+	Base commands.BaseCommandExecutor
+	// NonInteractive skips the GitHub SSH instructions prompt (used by dg apply).
+	NonInteractive bool
 	// appsOverride replaces the default terminal app list when non-nil (used in tests).
 	appsOverride []namedInstallable
 }
@@ -108,7 +110,7 @@ type Terminal struct {
 func New() *Terminal {
 	osCmd := commands.NewCommand()
 	baseCmd := commands.NewBaseCommand()
-	return &Terminal{Cmd: osCmd, Base: *baseCmd}
+	return &Terminal{Cmd: osCmd, Base: baseCmd}
 }
 
 // defaultApps returns the production list of registry apps managed by this coordinator.
@@ -139,8 +141,10 @@ func (t *Terminal) getApps() []namedInstallable {
 func (t *Terminal) InstallAndConfigure(appFilter, skipFilter map[string]bool) {
 	summary := &InstallationSummary{}
 
-	err := t.DisplayGithubInstructions()
-	displayMessage(err, "instructions", true)
+	if !t.NonInteractive {
+		err := t.DisplayGithubInstructions()
+		displayMessage(err, "instructions", true)
+	}
 	t.InstallTerminalApps(summary, appFilter, skipFilter)
 	if len(appFilter) == 0 {
 		t.InstallDevTools(summary)
@@ -227,7 +231,7 @@ func (t *Terminal) InstallDevTools(summary *InstallationSummary) {
 	}
 
 	// Install Debian-only packages (no dedicated app modules)
-	if !t.Base.Platform.IsMac() {
+	if !t.Base.IsMac() {
 		debianOnlyPackages := []string{constants.Plocate, constants.ApacheUtils}
 		for _, pkg := range debianOnlyPackages {
 			if err := t.Cmd.MaybeInstallPackage(pkg); err != nil {
@@ -285,7 +289,7 @@ func (t *Terminal) InstallCoreLibs(summary *InstallationSummary) {
 	for _, lib := range libs {
 		switch lib.name {
 		case constants.Xcode:
-			if t.Base.Platform.IsMac() {
+			if t.Base.IsMac() {
 				if err := lib.app.SoftInstall(); err != nil {
 					displayMessage(err, lib.name)
 					trackResult(summary, lib.name, err)
@@ -314,7 +318,7 @@ func (t *Terminal) InstallCoreLibs(summary *InstallationSummary) {
 func (t *Terminal) DisplayGithubInstructions() error {
 	var sshAddCmd, copyCmd string
 
-	if t.Base.Platform.IsMac() {
+	if t.Base.IsMac() {
 		sshAddCmd = "ssh-add --apple-use-keychain ~/.ssh/id_ed25519"
 		copyCmd = "pbcopy < ~/.ssh/id_ed25519.pub"
 	} else {
@@ -129,7 +129,7 @@ func TestInstallAndConfigure_SkipsDevToolsWhenFilterActive(t *testing.T) {
 	entries, _ := buildOverride(constants.Neovim)
 	term := &Terminal{
 		Cmd:          mockApp.Cmd,
-		Base:         *commands.NewBaseCommand(),
+		Base:         commands.NewBaseCommand(),
 		appsOverride: entries,
 	}
 
@@ -0,0 +1,197 @@
+// Agent activity for the workspace dashboard: whether the AI coder in a
+// worktree's first pane is working, idle (waiting on the user), or has
+// exited back to the shell. tmux can't tell us this directly, so it's
+// inferred from successive samples of the pane: a pane whose screen or title
+// keeps changing is working, one that has sat unchanged for AgentIdleAfter is
+// idle, and one whose foreground process is a shell (or that's dead) has
+// exited.
+
+package worktree
+
+import (
+	"fmt"
+	"hash/fnv"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/cjairm/devgita/internal/apps/tmux"
+)
+
+// AgentIdleAfter is how long a pane must sit unchanged before its agent
+// counts as idle. AI coders animate a spinner or elapsed-time counter while
+// they work, so a quiet screen means they're waiting on the user; the margin
+// covers at least two dashboard samples.
+const AgentIdleAfter = 5 * time.Second
+
+// agentShells are the pane_current_command values that mean the pane is back
+// at its shell prompt, i.e. whatever the layout launched has exited.
+var agentShells = map[string]bool{
+	"bash": true, "dash": true, "fish": true, "ksh": true, "nu": true,
+	"sh": true, "tcsh": true, "zsh": true,
+}
+
+// AgentActivity is what a worktree's agent pane is doing.
+type AgentActivity int
+
+const (
+	AgentUnknown AgentActivity = iota // no window, or not sampled long enough to tell
+	AgentWorking                      // output changed within AgentIdleAfter
+	AgentIdle                         // output unchanged for AgentIdleAfter: waiting for input
+	AgentExited                       // the pane is back at a shell, or dead
+)
+
+// String returns the label the dashboard shows for a.
+func (a AgentActivity) String() string {
+	switch a {
+	case AgentWorking:
+		return "working"
+	case AgentIdle:
+		return "idle"
+	case AgentExited:
+		return "exited"
+	default:
+		return ""
+	}
+}
+
+// NeedsAttention reports whether a is waiting on the user.
+func (a AgentActivity) NeedsAttention() bool {
+	return a == AgentIdle || a == AgentExited
+}
+
+// AgentSample is one look at a worktree's agent pane.
+type AgentSample struct {
+	Command     string // the pane's foreground process
+	Exited      bool
+	Fingerprint uint64 // hash of the pane's title and visible screen
+}
+
+// agentPane returns the first pane of s's tmux window - the pane layouts
+// launch the AI coder in. It's an error for the window or pane to be
+// missing.
+func (w *WorktreeManager) agentPane(s WorktreeStatus) (tmux.PaneInfo, error) {
+	session, ok := w.Mux.WindowSession(s.TmuxWindow)
+	if !ok {
+		return tmux.PaneInfo{}, fmt.Errorf("no tmux window %s", s.TmuxWindow)
+	}
+	panes, err := w.Mux.WindowPanes(session + ":" + s.TmuxWindow)
+	if err != nil {
+		return tmux.PaneInfo{}, err
+	}
+	if len(panes) == 0 {
+		return tmux.PaneInfo{}, fmt.Errorf("tmux window %s has no panes", s.TmuxWindow)
+	}
+	return panes[0], nil
+}
+
+// SampleAgent samples the agent pane of s's tmux window: its foreground
+// process, and a fingerprint of its title and visible screen.
+func (w *WorktreeManager) SampleAgent(s WorktreeStatus) (AgentSample, error) {
+	pane, err := w.agentPane(s)
+	if err != nil {
+		return AgentSample{}, err
+	}
+	sample := AgentSample{Command: pane.Command}
+	if pane.Dead || agentShells[strings.TrimPrefix(filepath.Base(pane.Command), "-")] {
+		sample.Exited = true
+		return sample, nil
+	}
+	screen, err := w.Mux.CapturePane(pane.ID, 0, false)
+	if err != nil {
+		return AgentSample{}, err
+	}
+	h := fnv.New64a()
+	h.Write([]byte(pane.Title + "\n" + screen))
+	sample.Fingerprint = h.Sum64()
+	return sample, nil
+}
+
+// CaptureAgent returns the agent pane of s's tmux window as displayed -
+// colors included - with up to history lines of scrollback above the
+// visible screen, for previewing the agent without attaching.
+func (w *WorktreeManager) CaptureAgent(s WorktreeStatus, history int) (string, error) {
+	pane, err := w.agentPane(s)
+	if err != nil {
+		return "", err
+	}
+	return w.Mux.CapturePane(pane.ID, history, true)
+}
+
+// SendToAgent types message into the agent pane of s's tmux window and, when
+// submit is set, presses Enter after it. The message is flattened to one line
+// first (see cleanPrompt): a newline would submit it early. The pane is
+// targeted by id, so the message reaches the agent even when another pane of
+// the window is active.
+func (w *WorktreeManager) SendToAgent(s WorktreeStatus, message string, submit bool) error {
+	message = cleanPrompt(message)
+	if message == "" && !submit {
+		return nil
+	}
+	pane, err := w.agentPane(s)
+	if err != nil {
+		return err
+	}
+	return w.Mux.SendText(pane.ID, message, submit)
+}
+
+// AgentStatus is an agent's activity as of its latest sample. Since is when
+// its pane last changed (or, for AgentExited, when it exited); before the
+// first change it's when the tracker first saw the pane.
+type AgentStatus struct {
+	Activity AgentActivity
+	Since    time.Time
+}
+
+// AgentTracker turns successive AgentSamples into AgentStatuses. Keys are
+// caller-chosen (the dashboard uses worktree paths). The zero value is not
+// usable; use NewAgentTracker.
+type AgentTracker struct {
+	seen map[string]agentTrack
+}
+
+type agentTrack struct {
+	fingerprint uint64
+	status      AgentStatus
+}
+
+// NewAgentTracker returns an empty tracker.
+func NewAgentTracker() *AgentTracker {
+	return &AgentTracker{seen: map[string]agentTrack{}}
+}
+
+// Observe records key's sample taken at now and returns its status. settled
+// reports the moment a working agent stops: it went idle or exited since the
+// previous sample. The first sample of a key is AgentUnknown (unless it has
+// already exited): one look can't tell a busy screen from a quiet one.
+func (t *AgentTracker) Observe(key string, sample AgentSample, now time.Time) (AgentStatus, bool) {
+	prev, ok := t.seen[key]
+	next := prev
+	switch {
+	case sample.Exited:
+		if !ok || prev.status.Activity != AgentExited {
+			next.status = AgentStatus{Activity: AgentExited, Since: now}
+		}
+	case !ok || prev.status.Activity == AgentExited:
+		next.status = AgentStatus{Activity: AgentUnknown, Since: now}
+	case sample.Fingerprint != prev.fingerprint:
+		next.status = AgentStatus{Activity: AgentWorking, Since: now}
+	case now.Sub(prev.status.Since) >= AgentIdleAfter:
+		next.status.Activity = AgentIdle
+	}
+	next.fingerprint = sample.Fingerprint
+	t.seen[key] = next
+	settled := ok && prev.status.Activity == AgentWorking && next.status.Activity.NeedsAttention()
+	return next.status, settled
+}
+
+// Status returns key's latest status, AgentUnknown when it has none.
+func (t *AgentTracker) Status(key string) AgentStatus {
+	return t.seen[key].status
+}
+
+// Forget drops key, e.g. once its window is gone, so a new window starts
+// from a clean first sample.
+func (t *AgentTracker) Forget(key string) {
+	delete(t.seen, key)
+}
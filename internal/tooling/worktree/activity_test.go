@@ -0,0 +1,149 @@
+package worktree
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/cjairm/devgita/internal/commands"
+)
+
+func TestSampleAgent(t *testing.T) {
+	status := WorktreeStatus{Name: "feat", TmuxWindow: "wt-app-feat"}
+
+	mockTmuxBase := commands.NewMockBaseCommand()
+	mockTmuxBase.SetExecCommandResults(
+		commands.ExecCommandResult("main\twt-app-feat\n", "", nil),
+		commands.ExecCommandResult("%7\t0\tclaude\t✳ Claude\n%8\t0\tzsh\thost\n", "", nil),
+		commands.ExecCommandResult("> thinking… (3s)\n", "", nil),
+	)
+	wm := newLayoutTestWM(commands.NewMockBaseCommand(), mockTmuxBase)
+
+	sample, err := wm.SampleAgent(status)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if sample.Exited || sample.Command != "claude" || sample.Fingerprint == 0 {
+		t.Errorf("expected a live claude sample, got %+v", sample)
+	}
+	if args := mockTmuxBase.GetLastExecCommandCall().Args; !containsArg(args, "%7") {
+		t.Errorf("expected the first pane to be captured, got %v", args)
+	}
+
+	mockTmuxBase.SetExecCommandResults(
+		commands.ExecCommandResult("main\twt-app-feat\n", "", nil),
+		commands.ExecCommandResult("%7\t0\t-zsh\thost\n", "", nil),
+	)
+	if sample, err = wm.SampleAgent(status); err != nil || !sample.Exited {
+		t.Errorf("expected a pane back at its shell to count as exited, got %+v, %v", sample, err)
+	}
+
+	mockTmuxBase.SetExecCommandResult("", "", nil)
+	if _, err := wm.SampleAgent(status); err == nil {
+		t.Error("expected an error when the window is missing")
+	}
+}
+
+func TestAgentTrackerTransitions(t *testing.T) {
+	tracker := NewAgentTracker()
+	start := time.Date(2026, 10, 16, 9, 0, 0, 0, time.UTC)
+	at := func(seconds int) time.Time { return start.Add(time.Duration(seconds) * time.Second) }
+
+	steps := []struct {
+		at      time.Time
+		sample  AgentSample
+		want    AgentActivity
+		settled bool
+	}{
+		{at(0), AgentSample{Fingerprint: 1}, AgentUnknown, false},
+		{at(3), AgentSample{Fingerprint: 2}, AgentWorking, false},
+		{at(6), AgentSample{Fingerprint: 2}, AgentWorking, false},
+		{at(9), AgentSample{Fingerprint: 2}, AgentIdle, true},
+		{at(12), AgentSample{Fingerprint: 2}, AgentIdle, false},
+		{at(15), AgentSample{Fingerprint: 3}, AgentWorking, false},
+		{at(18), AgentSample{Exited: true}, AgentExited, true},
+		{at(21), AgentSample{Exited: true}, AgentExited, false},
+		{at(24), AgentSample{Fingerprint: 4}, AgentUnknown, false},
+	}
+	for i, step := range steps {
+		got, settled := tracker.Observe("/wt/feat", step.sample, step.at)
+		if got.Activity != step.want || settled != step.settled {
+			t.Errorf("step %d: expected %v (settled=%v), got %v (settled=%v)",
+				i, step.want, step.settled, got.Activity, settled)
+		}
+	}
+
+	if since := tracker.Status("/wt/feat").Since; !since.Equal(at(24)) {
+		t.Errorf("expected Since to track the latest change, got %v", since)
+	}
+	tracker.Forget("/wt/feat")
+	if got := tracker.Status("/wt/feat"); got.Activity != AgentUnknown || !got.Since.IsZero() {
+		t.Errorf("expected a forgotten key to have no status, got %+v", got)
+	}
+}
+
+func TestAgentTrackerIdleFromFirstSample(t *testing.T) {
+	tracker := NewAgentTracker()
+	start := time.Now()
+	tracker.Observe("k", AgentSample{Fingerprint: 9}, start)
+	got, settled := tracker.Observe("k", AgentSample{Fingerprint: 9}, start.Add(AgentIdleAfter))
+	if got.Activity != AgentIdle || settled {
+		t.Errorf("expected a never-changing pane to read idle without settling, got %v (settled=%v)",
+			got.Activity, settled)
+	}
+	if !got.Since.Equal(start) {
+		t.Errorf("expected Since to stay at the first sample, got %v", got.Since)
+	}
+}
+
+func TestCaptureAgentKeepsColors(t *testing.T) {
+	mockTmuxBase := commands.NewMockBaseCommand()
+	mockTmuxBase.SetExecCommandResults(
+		commands.ExecCommandResult("main\twt-app-feat\n", "", nil),
+		commands.ExecCommandResult("%7\t0\tclaude\t✳ Claude\n", "", nil),
+		commands.ExecCommandResult("\x1b[32m> ready\x1b[0m\n", "", nil),
+	)
+	wm := newLayoutTestWM(commands.NewMockBaseCommand(), mockTmuxBase)
+
+	got, err := wm.CaptureAgent(WorktreeStatus{TmuxWindow: "wt-app-feat"}, 200)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got != "\x1b[32m> ready\x1b[0m\n" {
+		t.Errorf("unexpected capture: %q", got)
+	}
+	args := mockTmuxBase.GetLastExecCommandCall().Args
+	if !containsArg(args, "%7") || !containsArg(args, "-e") || !containsArg(args, "-200") {
+		t.Errorf("expected a colored capture of the first pane, got %v", args)
+	}
+}
+
+func TestSendToAgentTargetsAgentPane(t *testing.T) {
+	mockTmuxBase := commands.NewMockBaseCommand()
+	mockTmuxBase.SetExecCommandResults(
+		commands.ExecCommandResult("main\twt-app-feat\n", "", nil),
+		commands.ExecCommandResult("%7\t0\tclaude\t✳ Claude\n%8\t0\tnvim\tnvim\n", "", nil),
+		commands.ExecCommandResult("", "", nil),
+	)
+	wm := newLayoutTestWM(commands.NewMockBaseCommand(), mockTmuxBase)
+
+	err := wm.SendToAgent(WorktreeStatus{TmuxWindow: "wt-app-feat"}, "run the\ntests;", false)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	calls := mockTmuxBase.ExecCommandCalls
+	want := `send-keys -t %7 -l -- run the tests\;`
+	if got := strings.Join(calls[len(calls)-1].Args, " "); got != want {
+		t.Errorf("expected %q, got %q", want, got)
+	}
+	if len(calls) != 3 {
+		t.Errorf("expected no Enter without submit, got %d calls", len(calls))
+	}
+
+	mockTmuxBase.ExecCommandCalls = nil
+	if err := wm.SendToAgent(WorktreeStatus{TmuxWindow: "wt-app-feat"}, "  ", false); err != nil ||
+		len(mockTmuxBase.ExecCommandCalls) != 0 {
+		t.Errorf("expected an empty message without submit to do nothing, got %v, %d calls",
+			err, len(mockTmuxBase.ExecCommandCalls))
+	}
+}
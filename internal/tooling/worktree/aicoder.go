@@ -12,6 +12,32 @@ type AICoder interface {
 	Name() string
 	Command() string
 	EnsureInstalled() error
+	// PromptStyle reports how the coder takes an initial task prompt at
+	// launch (see Layout.WithPrompt).
+	PromptStyle() PromptStyle
+}
+
+// PromptMode is the way an initial task prompt reaches a coder.
+type PromptMode int
+
+const (
+	// PromptArg appends the shell-quoted prompt to the launch command,
+	// after PromptStyle.Flag when one is set.
+	PromptArg PromptMode = iota
+	// PromptStdin pipes the prompt into the launch command's stdin.
+	PromptStdin
+	// PromptSendKeys launches the coder as usual, then types the prompt into
+	// its pane followed by Enter - for a coder with no launch-time way to
+	// take one.
+	PromptSendKeys
+)
+
+// PromptStyle describes how a coder takes its initial prompt. Flag only
+// applies to PromptArg: the option preceding the prompt, or "" when the
+// prompt is a positional argument.
+type PromptStyle struct {
+	Mode PromptMode
+	Flag string
 }
 
 // ensureToolInstalled reports whether launchToken resolves in the user's
@@ -58,6 +84,12 @@ func (o *OpenCodeCoder) Name() string { return "opencode" }
 // pane where that alias is defined.
 func (o *OpenCodeCoder) Command() string { return "oc" }
 
+// PromptStyle passes the prompt through opencode's --prompt flag, which
+// starts the TUI with it already submitted.
+func (o *OpenCodeCoder) PromptStyle() PromptStyle {
+	return PromptStyle{Mode: PromptArg, Flag: "--prompt"}
+}
+
 // EnsureInstalled checks the exact launch token (the oc alias), not the raw
 // "opencode" binary, so a pass guarantees the pane launch will resolve too; the
 // error still names "opencode" as the thing to install.
@@ -77,6 +109,13 @@ func (c *ClaudeCoder) Name() string { return "claude" }
 // tmux pane where the alias is defined.
 func (c *ClaudeCoder) Command() string { return "cc" }
 
+// PromptStyle passes the prompt as claude's positional argument, which
+// starts an interactive session with it as the first message (unlike -p,
+// which prints one answer and exits).
+func (c *ClaudeCoder) PromptStyle() PromptStyle {
+	return PromptStyle{Mode: PromptArg}
+}
+
 // EnsureInstalled checks the exact launch token (the cc alias), not the raw
 // "claude" binary, so a pass guarantees the pane launch will resolve too; the
 // error still names "claude" as the thing to install.
@@ -101,3 +140,15 @@ func ResolveAICoder(alias string) (AICoder, error) {
 		)
 	}
 }
+
+// coderFor returns the AICoder command launches (matched on its launch token,
+// so "FOO=1 cc" is claude), or nil when it launches none.
+func coderFor(command string) AICoder {
+	token := launchToken(command)
+	for _, coder := range []AICoder{&OpenCodeCoder{}, &ClaudeCoder{}} {
+		if token == coder.Command() {
+			return coder
+		}
+	}
+	return nil
+}
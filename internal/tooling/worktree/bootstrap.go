@@ -0,0 +1,304 @@
+// Bootstrap hooks for a freshly created worktree: a checkout has only what
+// git tracks, so untracked-but-needed files (.env, local config) and
+// installed/generated state (node_modules, go generate output) are missing.
+// A per-repo .devgita/worktree.yaml - or, when a repo has none, the global
+// <config>/devgita/worktree.yaml - lists files to copy or symlink in from the
+// main worktree and setup commands to run in the new one.
+//
+// A repo's file arrives with whatever was cloned, so its setup commands only
+// run once the user has trusted that exact file (AllowRepoSetup, `dg wt
+// allow`); until then, or after it changes, they're skipped with a warning.
+// The global file is the user's own and always runs.
+//
+// Nothing here is fatal: the worktree already exists by the time bootstrap
+// runs, so every failure is collected and reported to the caller instead of
+// undoing the create.
+
+package worktree
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/cjairm/devgita/internal/commands"
+	"github.com/cjairm/devgita/internal/config"
+	"github.com/cjairm/devgita/pkg/constants"
+	"github.com/cjairm/devgita/pkg/files"
+	"github.com/cjairm/devgita/pkg/logger"
+	"github.com/cjairm/devgita/pkg/paths"
+	"github.com/cjairm/devgita/pkg/utils"
+)
+
+// bootstrapFileName is the bootstrap config's file name, both under a repo's
+// .devgita/ and in devgita's config dir.
+const bootstrapFileName = "worktree.yaml"
+
+// BootstrapConfig is the schema of a worktree.yaml bootstrap file. Copy and
+// Symlink entries are paths or filepath.Match globs relative to the repo
+// root; each match lands at the same relative path in the new worktree
+// unless something (e.g. a tracked file) is already there.
+type BootstrapConfig struct {
+	Copy    []string `yaml:"copy,omitempty"`    // copied, so the worktree can diverge (e.g. .env)
+	Symlink []string `yaml:"symlink,omitempty"` // linked to the main worktree's copy (e.g. a large shared cache)
+	Setup   []string `yaml:"setup,omitempty"`   // shell commands run in order in the new worktree
+}
+
+// repoBootstrapPath is repoRoot's own bootstrap file.
+func repoBootstrapPath(repoRoot string) string {
+	return filepath.Join(repoRoot, ".devgita", bootstrapFileName)
+}
+
+// globalBootstrapPath is the fallback bootstrap file, next to
+// global_config.yaml.
+func globalBootstrapPath() string {
+	return filepath.Join(paths.Paths.Config.Root, constants.App.Name, bootstrapFileName)
+}
+
+// BootstrapLogPath is where setup output for repoSlug/name is logged when it
+// isn't run in a tmux pane, alongside the worktree's metadata record.
+func BootstrapLogPath(repoSlug, name string) string {
+	return filepath.Join(
+		paths.Paths.Data.Root, "devgita", "worktree-logs", repoSlug, FlattenName(name)+".log",
+	)
+}
+
+// LoadBootstrapConfig returns the bootstrap config that applies to repoRoot:
+// its own .devgita/worktree.yaml when present, else the global one, else the
+// zero config (nothing to do). The repo's file replaces the global one
+// rather than merging with it. A file that exists but can't be read or
+// parsed is an error.
+func LoadBootstrapConfig(repoRoot string) (BootstrapConfig, error) {
+	cfg, _, _, err := loadBootstrapFile(repoRoot)
+	return cfg, err
+}
+
+// loadBootstrapFile is LoadBootstrapConfig, also returning which file the
+// config came from ("" for none) and its raw contents.
+func loadBootstrapFile(repoRoot string) (cfg BootstrapConfig, path string, data []byte, err error) {
+	for _, path := range []string{repoBootstrapPath(repoRoot), globalBootstrapPath()} {
+		data, err := os.ReadFile(path)
+		if errors.Is(err, os.ErrNotExist) {
+			continue
+		}
+		if err != nil {
+			return BootstrapConfig{}, "", nil, fmt.Errorf("failed to read %s: %w", path, err)
+		}
+		var cfg BootstrapConfig
+		if err := yaml.Unmarshal(data, &cfg); err != nil {
+			return BootstrapConfig{}, "", nil, fmt.Errorf("failed to parse %s: %w", path, err)
+		}
+		return cfg, path, data, nil
+	}
+	return BootstrapConfig{}, "", nil, nil
+}
+
+// bootstrapHash fingerprints a bootstrap file's contents for the trust
+// store, so any edit to a trusted file needs trusting again.
+func bootstrapHash(data []byte) string {
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
+
+// setupTrusted reports whether the repo bootstrap file at path, holding
+// data, has been trusted as-is with AllowRepoSetup. A global config that
+// can't be read trusts nothing.
+func setupTrusted(path string, data []byte) bool {
+	gc := &config.GlobalConfig{}
+	if err := gc.Load(); err != nil {
+		return false
+	}
+	return gc.Worktree.TrustedSetup[config.CanonicalRepoPath(path)] == bootstrapHash(data)
+}
+
+// AllowRepoSetup trusts repoRoot's .devgita/worktree.yaml as it is now,
+// recording its hash in global_config.yaml so its setup commands run on
+// later creates, and returns the config it trusted so the caller can show
+// what will run. It's an error for the repo to have no bootstrap file.
+func AllowRepoSetup(repoRoot string) (BootstrapConfig, error) {
+	path := repoBootstrapPath(repoRoot)
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return BootstrapConfig{}, fmt.Errorf("failed to read %s: %w", path, err)
+	}
+	var cfg BootstrapConfig
+	if err := yaml.Unmarshal(data, &cfg); err != nil {
+		return BootstrapConfig{}, fmt.Errorf("failed to parse %s: %w", path, err)
+	}
+
+	gc := &config.GlobalConfig{}
+	if err := gc.Create(); err != nil {
+		return BootstrapConfig{}, err
+	}
+	if err := gc.Load(); err != nil {
+		return BootstrapConfig{}, err
+	}
+	if gc.Worktree.TrustedSetup == nil {
+		gc.Worktree.TrustedSetup = map[string]string{}
+	}
+	gc.Worktree.TrustedSetup[config.CanonicalRepoPath(path)] = bootstrapHash(data)
+	if err := gc.Save(); err != nil {
+		return BootstrapConfig{}, err
+	}
+	return cfg, nil
+}
+
+// PrepareWorktree applies repoRoot's bootstrap copy and symlink entries to
+// the new worktree at wtPath and returns the setup commands still to run -
+// the caller decides where their output goes - along with every problem hit
+// on the way. None of the problems stop the rest: one missing .env doesn't
+// skip the symlinks after it. Setup commands from a repo file that hasn't
+// been trusted (see AllowRepoSetup) are dropped and reported as a problem.
+func PrepareWorktree(repoRoot, wtPath string) (setup []string, problems []error) {
+	cfg, path, data, err := loadBootstrapFile(repoRoot)
+	if err != nil {
+		return nil, []error{err}
+	}
+	for _, pattern := range cfg.Copy {
+		problems = append(problems, bootstrapEntry(repoRoot, wtPath, pattern, copyInto)...)
+	}
+	for _, pattern := range cfg.Symlink {
+		problems = append(problems, bootstrapEntry(repoRoot, wtPath, pattern, os.Symlink)...)
+	}
+	if len(cfg.Setup) > 0 && path == repoBootstrapPath(repoRoot) && !setupTrusted(path, data) {
+		problems = append(problems, fmt.Errorf(
+			"skipped %d setup command(s) from %s: the file isn't trusted (review it, then run `dg wt allow --repo %s`)",
+			len(cfg.Setup), path, repoRoot,
+		))
+		return nil, problems
+	}
+	return cfg.Setup, problems
+}
+
+// copyInto copies src (a file or a whole directory) to dst.
+func copyInto(src, dst string) error {
+	info, err := os.Stat(src)
+	if err != nil {
+		return err
+	}
+	if info.IsDir() {
+		if err := os.MkdirAll(dst, files.DirPermission); err != nil {
+			return err
+		}
+		return files.CopyDir(src, dst)
+	}
+	return files.CopyFile(src, dst)
+}
+
+// bootstrapEntry expands one copy/symlink pattern against repoRoot and
+// applies place(src, dst) to each match. A destination that already exists
+// is left alone: the checkout's tracked version wins over the main
+// worktree's.
+func bootstrapEntry(
+	repoRoot, wtPath, pattern string,
+	place func(src, dst string) error,
+) []error {
+	if !filepath.IsLocal(pattern) {
+		return []error{fmt.Errorf("bootstrap entry %q must be a path inside the repo", pattern)}
+	}
+	matches, err := filepath.Glob(filepath.Join(repoRoot, pattern))
+	if err != nil {
+		return []error{fmt.Errorf("bootstrap entry %q: %w", pattern, err)}
+	}
+	if len(matches) == 0 {
+		return []error{fmt.Errorf("bootstrap entry %q matches nothing in %s", pattern, repoRoot)}
+	}
+
+	var problems []error
+	for _, src := range matches {
+		rel, err := filepath.Rel(repoRoot, src)
+		if err != nil {
+			problems = append(problems, fmt.Errorf("bootstrap entry %q: %w", pattern, err))
+			continue
+		}
+		dst := filepath.Join(wtPath, rel)
+		if _, err := os.Lstat(dst); err == nil {
+			continue
+		}
+		if err := os.MkdirAll(filepath.Dir(dst), files.DirPermission); err != nil {
+			problems = append(problems, fmt.Errorf("bootstrap %s: %w", rel, err))
+			continue
+		}
+		if err := place(src, dst); err != nil {
+			problems = append(problems, fmt.Errorf("bootstrap %s: %w", rel, err))
+		}
+	}
+	return problems
+}
+
+// bootstrap applies repoRoot's bootstrap hooks to the worktree just created
+// at wtPath and returns layout with the setup commands handed to its first
+// pane, whose shell runs them (streaming their output there) before its own
+// launch line. Problems go through WarnFn like recordMetadata's - the
+// worktree is kept either way.
+func (w *WorktreeManager) bootstrap(repoRoot, wtPath, name string, layout Layout) Layout {
+	setup, problems := PrepareWorktree(repoRoot, wtPath)
+	if len(problems) > 0 {
+		logger.L().Debugw("worktree bootstrap problems", "worktree", name, "errors", problems)
+		warn := w.WarnFn
+		if warn == nil {
+			warn = utils.PrintWarning
+		}
+		messages := make([]string, len(problems))
+		for i, problem := range problems {
+			messages[i] = problem.Error()
+		}
+		warn(fmt.Sprintf(
+			"worktree %s created, but bootstrap hit problems: %s", name, strings.Join(messages, "; "),
+		))
+	}
+	layout.setup = setup
+	return layout
+}
+
+// setupLine chains setup commands into one line for a tmux pane's shell, so
+// their output streams in the pane ahead of whatever the pane launches next.
+// Each command that fails prints a note and the next one still runs.
+func setupLine(setup []string) string {
+	steps := make([]string, len(setup))
+	for i, command := range setup {
+		steps[i] = "{ " + command + "; } || echo " + shellQuote("devgita: setup failed: "+command)
+	}
+	return strings.Join(steps, "; ")
+}
+
+// RunSetup runs setup commands one at a time in wtPath through base,
+// streaming their output and appending it to logPath. A failing command is
+// reported and the rest still run.
+func RunSetup(base commands.BaseCommandExecutor, setup []string, wtPath, logPath string) []error {
+	if len(setup) == 0 {
+		return nil
+	}
+	var log *os.File
+	if err := os.MkdirAll(filepath.Dir(logPath), 0o755); err == nil {
+		log, _ = os.OpenFile(logPath, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o644)
+	}
+	if log != nil {
+		defer log.Close()
+	}
+
+	var problems []error
+	for _, command := range setup {
+		stdout, stderr, err := base.ExecCommand(commands.CommandParams{
+			Command: "sh",
+			Args:    []string{"-c", command},
+			Dir:     wtPath,
+			Stream:  true,
+		})
+		if log != nil {
+			fmt.Fprintf(log, "$ %s\n%s%s", command, stdout, stderr)
+		}
+		if err != nil {
+			problems = append(problems, fmt.Errorf(
+				"setup command %q failed: %w (output in %s)", command, err, logPath,
+			))
+		}
+	}
+	return problems
+}
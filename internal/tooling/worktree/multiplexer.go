@@ -0,0 +1,63 @@
+package worktree
+
+import (
+	"fmt"
+
+	"github.com/cjairm/devgita/internal/apps/tmux"
+	"github.com/cjairm/devgita/internal/apps/zellij"
+	"github.com/cjairm/devgita/pkg/constants"
+)
+
+// Multiplexer is the terminal multiplexer a WorktreeManager puts worktree
+// windows in: the subset of *tmux.Tmux's methods the manager (and the
+// workspace dashboard) use. *tmux.Tmux and *zellij.Zellij both implement it;
+// tests can substitute a fake. Window and session names follow tmux's
+// vocabulary - for Zellij a "window" is a tab.
+type Multiplexer interface {
+	CreateSession(name, workdir string) error
+	CreateSessionWithWindow(session, windowName, workdir string) error
+	CreateWindowInSession(session, name, workdir string) error
+	CreateWindow(name, workdir string) error
+	ListSessions() ([]tmux.SessionInfo, error)
+	HasSession(name string) bool
+	CurrentSession() (string, bool)
+	SwitchToSession(name string) error
+	KillSession(name string) error
+	WindowSession(name string) (string, bool)
+	FindWindowsBySuffix(suffix string) []string
+	SessionWindows() []tmux.SessionWindow
+	SwitchToWindow(session, name string) error
+	KillWindow(name string) error
+	SendKeysToWindow(window, keys string) error
+	SendKeysToWindowInSession(session, window, keys string) error
+	ActivePaneID(window string) (string, error)
+	SplitWindowSized(window, workdir, direction string, percent int) error
+	SelectPane(paneID string) error
+	SendLiteralKeys(target, text string) error
+	SendText(target, text string, enter bool) error
+	WindowPanes(target string) ([]tmux.PaneInfo, error)
+	CapturePane(target string, history int, escapes bool) (string, error)
+}
+
+// kdlWindowBuilder is implemented by multiplexers that build a whole window
+// in one step from a KDL layout (see Layout.kdlLayout) rather than split by
+// split - Zellij, whose CLI can't address the panes a split creates. session
+// "" means the current session; a missing session is created.
+type kdlWindowBuilder interface {
+	CreateWindowFromLayout(session, window, workdir, kdl string) error
+}
+
+// NewMultiplexer returns the multiplexer named by worktree.multiplexer in
+// global_config.yaml; "" means tmux.
+func NewMultiplexer(name string) (Multiplexer, error) {
+	switch name {
+	case "", constants.Tmux:
+		return tmux.New(), nil
+	case constants.Zellij:
+		return zellij.New(), nil
+	default:
+		return nil, fmt.Errorf(
+			"unknown multiplexer %q (want %q or %q)", name, constants.Tmux, constants.Zellij,
+		)
+	}
+}
@@ -0,0 +1,303 @@
+package worktree
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/cjairm/devgita/internal/apps/git"
+	"github.com/cjairm/devgita/internal/apps/tmux"
+	"github.com/cjairm/devgita/internal/apps/zellij"
+	"github.com/cjairm/devgita/internal/commands"
+	"github.com/cjairm/devgita/pkg/paths"
+)
+
+// fakeMux is an in-memory Multiplexer: it tracks windows per session and
+// records every call as "verb target", so a test can assert what the manager
+// asked for without pinning any one multiplexer's CLI.
+type fakeMux struct {
+	windows  map[string][]string // session → window names
+	calls    []string
+	captured string
+}
+
+func newFakeMux() *fakeMux {
+	return &fakeMux{windows: map[string][]string{}}
+}
+
+func (f *fakeMux) record(call string) { f.calls = append(f.calls, call) }
+
+func (f *fakeMux) CreateSession(name, _ string) error {
+	f.record("new-session " + name)
+	f.windows[name] = nil
+	return nil
+}
+
+func (f *fakeMux) CreateSessionWithWindow(session, window, _ string) error {
+	f.record("new-session " + session + ":" + window)
+	f.windows[session] = []string{window}
+	return nil
+}
+
+func (f *fakeMux) CreateWindowInSession(session, name, _ string) error {
+	f.record("new-window " + session + ":" + name)
+	f.windows[session] = append(f.windows[session], name)
+	return nil
+}
+
+func (f *fakeMux) CreateWindow(name, workdir string) error {
+	return f.CreateWindowInSession("current", name, workdir)
+}
+
+func (f *fakeMux) ListSessions() ([]tmux.SessionInfo, error) {
+	var sessions []tmux.SessionInfo
+	for name := range f.windows {
+		sessions = append(sessions, tmux.SessionInfo{Name: name})
+	}
+	return sessions, nil
+}
+
+func (f *fakeMux) HasSession(name string) bool {
+	_, ok := f.windows[name]
+	return ok
+}
+
+func (f *fakeMux) CurrentSession() (string, bool) { return "current", true }
+
+func (f *fakeMux) SwitchToSession(name string) error {
+	f.record("switch " + name)
+	return nil
+}
+
+func (f *fakeMux) KillSession(name string) error {
+	f.record("kill-session " + name)
+	delete(f.windows, name)
+	return nil
+}
+
+func (f *fakeMux) WindowSession(name string) (string, bool) {
+	for session, windows := range f.windows {
+		for _, w := range windows {
+			if w == name {
+				return session, true
+			}
+		}
+	}
+	return "", false
+}
+
+func (f *fakeMux) FindWindowsBySuffix(suffix string) []string {
+	var matches []string
+	for _, sw := range f.SessionWindows() {
+		if strings.HasSuffix(sw.Window, suffix) {
+			matches = append(matches, sw.Window)
+		}
+	}
+	return matches
+}
+
+func (f *fakeMux) SessionWindows() []tmux.SessionWindow {
+	var pairs []tmux.SessionWindow
+	for session, windows := range f.windows {
+		for _, w := range windows {
+			pairs = append(pairs, tmux.SessionWindow{Session: session, Window: w})
+		}
+	}
+	return pairs
+}
+
+func (f *fakeMux) SwitchToWindow(session, name string) error {
+	f.record("switch " + session + ":" + name)
+	return nil
+}
+
+func (f *fakeMux) KillWindow(name string) error {
+	f.record("kill-window " + name)
+	for session, windows := range f.windows {
+		var kept []string
+		for _, w := range windows {
+			if w != name {
+				kept = append(kept, w)
+			}
+		}
+		f.windows[session] = kept
+	}
+	return nil
+}
+
+func (f *fakeMux) SendKeysToWindow(window, keys string) error {
+	return f.SendText(window, keys, true)
+}
+
+func (f *fakeMux) SendKeysToWindowInSession(session, window, keys string) error {
+	return f.SendText(session+":"+window, keys, true)
+}
+
+func (f *fakeMux) ActivePaneID(window string) (string, error) { return window, nil }
+
+func (f *fakeMux) SplitWindowSized(window, _, direction string, _ int) error {
+	f.record("split " + window + " " + direction)
+	return nil
+}
+
+func (f *fakeMux) SelectPane(paneID string) error {
+	f.record("select " + paneID)
+	return nil
+}
+
+func (f *fakeMux) SendLiteralKeys(target, text string) error {
+	return f.SendText(target, text, true)
+}
+
+func (f *fakeMux) SendText(target, text string, _ bool) error {
+	f.record("send " + target + " " + text)
+	return nil
+}
+
+func (f *fakeMux) WindowPanes(target string) ([]tmux.PaneInfo, error) {
+	return []tmux.PaneInfo{{ID: target}}, nil
+}
+
+func (f *fakeMux) CapturePane(_ string, _ int, _ bool) (string, error) {
+	return f.captured, nil
+}
+
+// fakeKDLMux is a fakeMux that builds windows from KDL layouts, the way
+// Zellij does, recording each layout it was handed.
+type fakeKDLMux struct {
+	*fakeMux
+	layouts   []string
+	layoutErr error
+}
+
+func (f *fakeKDLMux) CreateWindowFromLayout(session, window, _, kdl string) error {
+	f.layouts = append(f.layouts, kdl)
+	if f.layoutErr != nil {
+		return f.layoutErr
+	}
+	if session == "" {
+		session = "current"
+	}
+	f.record("layout " + session + ":" + window)
+	f.windows[session] = append(f.windows[session], window)
+	return nil
+}
+
+func TestNewMultiplexer(t *testing.T) {
+	for _, name := range []string{"", "tmux"} {
+		mux, err := NewMultiplexer(name)
+		if err != nil {
+			t.Fatalf("%q: unexpected error: %v", name, err)
+		}
+		if _, ok := mux.(*tmux.Tmux); !ok {
+			t.Errorf("%q: expected tmux, got %T", name, mux)
+		}
+	}
+	mux, err := NewMultiplexer("zellij")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, ok := mux.(*zellij.Zellij); !ok {
+		t.Errorf("expected zellij, got %T", mux)
+	}
+	if _, ok := mux.(kdlWindowBuilder); !ok {
+		t.Error("expected zellij to build windows from KDL layouts")
+	}
+	if _, err := NewMultiplexer("screen"); err == nil {
+		t.Error("expected an unknown multiplexer to be rejected")
+	}
+}
+
+func newFakeMuxWM(t *testing.T, mux Multiplexer) (*WorktreeManager, string) {
+	t.Helper()
+	repoRoot := t.TempDir()
+	mockGitBase := commands.NewMockBaseCommand()
+	mockGitBase.SetExecCommandResults(
+		commands.ExecCommandResult(repoRoot+"\n", "", nil), // rev-parse --show-toplevel
+		commands.ExecCommandResult("", "", nil),            // everything else succeeds/empty
+	)
+	wm := &WorktreeManager{
+		Git:  &git.Git{Cmd: commands.NewMockCommand(), Base: mockGitBase},
+		Mux:  mux,
+		Base: commands.NewMockBaseCommand(),
+	}
+	repoSlug := filepath.Base(repoRoot)
+	t.Cleanup(func() {
+		dir := filepath.Join(paths.Paths.Data.Root, "devgita", "worktrees", repoSlug)
+		if err := os.RemoveAll(dir); err != nil {
+			t.Logf("cleanup: %v", err)
+		}
+	})
+	return wm, repoSlug
+}
+
+// TestCreateOnFakeTmuxStyleMultiplexer builds a layout split by split on a
+// multiplexer without KDL support.
+func TestCreateOnFakeTmuxStyleMultiplexer(t *testing.T) {
+	mux := newFakeMux()
+	wm, repoSlug := newFakeMuxWM(t, mux)
+
+	if err := wm.Create("feature-test", twoPaneLayout, true); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	window := GetWindowName(repoSlug, "feature-test")
+	want := []string{
+		"new-window current:" + window,
+		"send " + window + " pane0-cmd",
+		"split " + window + " vertical",
+		"send " + window + " pane1-cmd",
+		"select " + window,
+	}
+	if strings.Join(mux.calls, "\n") != strings.Join(want, "\n") {
+		t.Errorf(
+			"unexpected calls:\n%s\nwant:\n%s",
+			strings.Join(mux.calls, "\n"), strings.Join(want, "\n"),
+		)
+	}
+}
+
+// TestCreateOnKDLMultiplexerBuildsWindowInOneStep proves a KDL-capable
+// multiplexer gets the whole layout at once - no splits, no per-pane keys -
+// and that CreateAt hands it the repo-slug session.
+func TestCreateOnKDLMultiplexerBuildsWindowInOneStep(t *testing.T) {
+	mux := &fakeKDLMux{fakeMux: newFakeMux()}
+	wm, repoSlug := newFakeMuxWM(t, mux)
+
+	if err := wm.CreateAt(t.TempDir(), "feature-test", twoPaneLayout, true); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	window := GetWindowName(repoSlug, "feature-test")
+	for _, call := range mux.calls {
+		if strings.HasPrefix(call, "split") || strings.HasPrefix(call, "send") {
+			t.Errorf("expected no split-by-split build, got %q", call)
+		}
+	}
+	if len(mux.layouts) != 1 {
+		t.Fatalf("expected one layout build, got %d", len(mux.layouts))
+	}
+	if session, ok := mux.WindowSession(window); !ok || session != TmuxSessionName(repoSlug) {
+		t.Errorf("expected %s in the repo-slug session, got %q", window, session)
+	}
+	for _, cmd := range []string{"pane0-cmd", "pane1-cmd", `split_direction="vertical"`} {
+		if !strings.Contains(mux.layouts[0], cmd) {
+			t.Errorf("expected %q in the layout, got:\n%s", cmd, mux.layouts[0])
+		}
+	}
+}
+
+// TestCreateOnKDLMultiplexerFailureIsReported surfaces a layout the
+// multiplexer rejects, naming the layout, and leaves no window behind.
+func TestCreateOnKDLMultiplexerFailureIsReported(t *testing.T) {
+	mux := &fakeKDLMux{fakeMux: newFakeMux(), layoutErr: errors.New("bad layout")}
+	wm, repoSlug := newFakeMuxWM(t, mux)
+
+	err := wm.Create("feature-test", twoPaneLayout, true)
+	if err == nil || !strings.Contains(err.Error(), `layout "two-pane": bad layout`) {
+		t.Fatalf("expected the layout failure, got %v", err)
+	}
+	if _, ok := mux.WindowSession(GetWindowName(repoSlug, "feature-test")); ok {
+		t.Error("expected no window after a failed build")
+	}
+}
@@ -0,0 +1,202 @@
+// Auto-generated worktree names for a create that wasn't given one (`dg wt
+// new` with no <name>, or an empty name in the dashboard's name prompt): a
+// slug of the task prompt when there is one, otherwise worktree.name_template
+// expanded - and either way bumped with a -2, -3, ... suffix until it clashes
+// with no local branch, origin branch, or worktree directory.
+
+package worktree
+
+import (
+	"fmt"
+	"math/rand/v2"
+	"os"
+	"os/user"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/cjairm/devgita/internal/config"
+)
+
+// DefaultNameTemplate is used when worktree.name_template is unset.
+const DefaultNameTemplate = "{user}/{date}-{adjective}-{noun}"
+
+// maxPromptNameWords and maxPromptNameLen keep a prompt-derived name to the
+// gist of the task rather than the whole sentence.
+const (
+	maxPromptNameWords = 6
+	maxPromptNameLen   = 40
+)
+
+// maxNameAttempts bounds the collision suffixes tried before giving up.
+const maxNameAttempts = 100
+
+var (
+	nameAdjectives = []string{
+		"amber", "bold", "brave", "brisk", "calm", "clever", "cosmic", "crisp",
+		"eager", "fuzzy", "gentle", "golden", "happy", "lucky", "mellow", "nimble",
+		"quiet", "rapid", "shiny", "silent", "steady", "swift", "tidy", "witty",
+	}
+	nameNouns = []string{
+		"badger", "beacon", "canyon", "comet", "falcon", "fern", "harbor", "heron",
+		"lantern", "maple", "meadow", "otter", "panda", "pebble", "quartz", "raven",
+		"river", "rocket", "sparrow", "summit", "tiger", "walrus", "willow", "zephyr",
+	}
+	// promptStopWords are dropped from a prompt before it's slugged: they
+	// add length without telling one task from another.
+	promptStopWords = map[string]bool{
+		"a": true, "an": true, "and": true, "for": true, "in": true, "of": true,
+		"on": true, "please": true, "the": true, "to": true, "with": true,
+	}
+	placeholderPattern = regexp.MustCompile(`\{[^{}]*\}`)
+	nonSlugPattern     = regexp.MustCompile(`[^a-z0-9]+`)
+)
+
+// Swappable in tests so generated names are deterministic.
+var (
+	nameNowFn  = time.Now
+	nameRandFn = rand.IntN
+)
+
+// GenerateName returns a name for a new worktree of the repo at repoRoot.
+// With a non-empty prompt the name is a slug of its leading words ("Fix the
+// login redirect" -> "fix-login-redirect"); otherwise it's gc's
+// worktree.name_template (DefaultNameTemplate when unset) expanded. Either
+// way it's suffixed -2, -3, ... as needed so it names no existing local or
+// origin branch and no worktree directory of this repo.
+func (w *WorktreeManager) GenerateName(repoRoot, prompt string, gc *config.GlobalConfig) (string, error) {
+	base := nameFromPrompt(prompt)
+	if base == "" {
+		template := DefaultNameTemplate
+		if gc != nil && gc.Worktree.NameTemplate != "" {
+			template = gc.Worktree.NameTemplate
+		}
+		var err error
+		if base, err = expandNameTemplate(template); err != nil {
+			return "", err
+		}
+	}
+
+	repoSlug := filepath.Base(repoRoot)
+	for i := 1; i <= maxNameAttempts; i++ {
+		name := base
+		if i > 1 {
+			name = fmt.Sprintf("%s-%d", base, i)
+		}
+		taken, err := w.nameTaken(repoRoot, repoSlug, name)
+		if err != nil {
+			return "", err
+		}
+		if !taken {
+			return name, nil
+		}
+	}
+	return "", fmt.Errorf(
+		"couldn't find a free worktree name based on %q after %d tries; pass a name",
+		base, maxNameAttempts,
+	)
+}
+
+// nameTaken reports whether name is already a local branch, an origin
+// branch, or a worktree directory of repoSlug.
+func (w *WorktreeManager) nameTaken(repoRoot, repoSlug, name string) (bool, error) {
+	if exists, err := w.Git.BranchExistsIn(repoRoot, name); err != nil {
+		return false, fmt.Errorf("failed to check branch %s: %w", name, err)
+	} else if exists {
+		return true, nil
+	}
+	if exists, err := w.Git.RemoteBranchExistsIn(repoRoot, name); err != nil {
+		return false, fmt.Errorf("failed to check remote branch %s: %w", name, err)
+	} else if exists {
+		return true, nil
+	}
+	if _, err := os.Stat(w.worktreePath(repoSlug, name)); err == nil {
+		return true, nil
+	}
+	return false, nil
+}
+
+// nameFromPrompt slugs prompt's first few meaningful words, or returns ""
+// when it has none.
+func nameFromPrompt(prompt string) string {
+	var words []string
+	for _, word := range strings.Fields(slugify(prompt, " ")) {
+		if promptStopWords[word] {
+			continue
+		}
+		words = append(words, word)
+		if len(words) == maxPromptNameWords {
+			break
+		}
+	}
+	name := strings.Join(words, "-")
+	for len(name) > maxPromptNameLen {
+		cut := strings.LastIndex(name[:maxPromptNameLen], "-")
+		if cut <= 0 {
+			name = name[:maxPromptNameLen]
+			break
+		}
+		name = name[:cut]
+	}
+	return name
+}
+
+// expandNameTemplate fills template's {user}, {date} (YYYYMMDD), {adjective}
+// and {noun} placeholders and slugs each /-separated segment of the result,
+// so any template yields a valid branch name. An unknown placeholder is an
+// error rather than being left in the name.
+func expandNameTemplate(template string) (string, error) {
+	var unknown string
+	expanded := placeholderPattern.ReplaceAllStringFunc(template, func(p string) string {
+		switch p {
+		case "{user}":
+			return currentUserName()
+		case "{date}":
+			return nameNowFn().Format("20060102")
+		case "{adjective}":
+			return nameAdjectives[nameRandFn(len(nameAdjectives))]
+		case "{noun}":
+			return nameNouns[nameRandFn(len(nameNouns))]
+		}
+		if unknown == "" {
+			unknown = p
+		}
+		return ""
+	})
+	if unknown != "" {
+		return "", fmt.Errorf(
+			"worktree.name_template %q: unknown placeholder %s (valid: {user}, {date}, {adjective}, {noun})",
+			template, unknown,
+		)
+	}
+
+	var segments []string
+	for _, segment := range strings.Split(expanded, "/") {
+		if s := slugify(segment, "-"); s != "" {
+			segments = append(segments, s)
+		}
+	}
+	if len(segments) == 0 {
+		return "", fmt.Errorf("worktree.name_template %q expands to an empty name", template)
+	}
+	return strings.Join(segments, "/"), nil
+}
+
+// currentUserName is the login name for {user}: $USER, then the OS account,
+// then "dev" so a template never expands to an empty segment.
+func currentUserName() string {
+	if name := os.Getenv("USER"); name != "" {
+		return name
+	}
+	if u, err := user.Current(); err == nil && u.Username != "" {
+		return u.Username
+	}
+	return "dev"
+}
+
+// slugify lowercases s and replaces every run of characters outside [a-z0-9]
+// with sep, trimming sep from both ends.
+func slugify(s, sep string) string {
+	return strings.Trim(nonSlugPattern.ReplaceAllString(strings.ToLower(s), sep), sep)
+}
@@ -0,0 +1,112 @@
+package worktree
+
+import (
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/cjairm/devgita/internal/commands"
+	"github.com/cjairm/devgita/internal/config"
+)
+
+// stubNameSources pins the clock and word picks expandNameTemplate uses.
+func stubNameSources(t *testing.T) {
+	t.Helper()
+	origNow, origRand := nameNowFn, nameRandFn
+	nameNowFn = func() time.Time { return time.Date(2026, 10, 16, 9, 0, 0, 0, time.UTC) }
+	nameRandFn = func(int) int { return 0 }
+	t.Setenv("USER", "Jane.Doe")
+	t.Cleanup(func() { nameNowFn, nameRandFn = origNow, origRand })
+}
+
+func TestNameFromPrompt(t *testing.T) {
+	tests := []struct {
+		prompt string
+		want   string
+	}{
+		{"Fix the login redirect", "fix-login-redirect"},
+		{"  Add OAuth2 (Google) support!  ", "add-oauth2-google-support"},
+		{"please refactor the parser to handle nested generics and unions too", "refactor-parser-handle-nested-generics"},
+		{"!!!", ""},
+		{"", ""},
+	}
+	for _, tt := range tests {
+		if got := nameFromPrompt(tt.prompt); got != tt.want {
+			t.Errorf("nameFromPrompt(%q): expected %q, got %q", tt.prompt, tt.want, got)
+		}
+	}
+}
+
+func TestNameFromPromptCapsLength(t *testing.T) {
+	got := nameFromPrompt("internationalization localization accessibility")
+	if len(got) > maxPromptNameLen || strings.HasSuffix(got, "-") {
+		t.Errorf("expected a name of at most %d chars ending on a word, got %q", maxPromptNameLen, got)
+	}
+}
+
+func TestExpandNameTemplate(t *testing.T) {
+	stubNameSources(t)
+
+	got, err := expandNameTemplate(DefaultNameTemplate)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want := "jane-doe/20261016-" + nameAdjectives[0] + "-" + nameNouns[0]
+	if got != want {
+		t.Errorf("expected %q, got %q", want, got)
+	}
+
+	if _, err := expandNameTemplate("wip/{branch}"); err == nil ||
+		!strings.Contains(err.Error(), "{branch}") {
+		t.Errorf("expected an unknown-placeholder error naming {branch}, got %v", err)
+	}
+	if _, err := expandNameTemplate("//"); err == nil {
+		t.Error("expected an error for a template that expands to nothing")
+	}
+}
+
+func TestGenerateNameUsesConfiguredTemplate(t *testing.T) {
+	isolateDataRoot(t)
+	stubNameSources(t)
+
+	mockGitBase := commands.NewMockBaseCommand()
+	mockGitBase.SetExecCommandResult("", "", nil)
+	wm := newLayoutTestWM(mockGitBase, commands.NewMockBaseCommand())
+	gc := &config.GlobalConfig{}
+	gc.Worktree.NameTemplate = "wip/{noun}"
+
+	got, err := wm.GenerateName("/repos/app", "", gc)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got != "wip/"+nameNouns[0] {
+		t.Errorf("expected %q, got %q", "wip/"+nameNouns[0], got)
+	}
+}
+
+// TestGenerateNameSkipsTakenNames proves a local branch, an origin branch,
+// and a worktree directory each push the name to the next suffix.
+func TestGenerateNameSkipsTakenNames(t *testing.T) {
+	isolateDataRoot(t)
+
+	mockGitBase := commands.NewMockBaseCommand()
+	mockGitBase.SetExecCommandResults(
+		commands.ExecCommandResult("  fix-redirect\n", "", nil),          // local: fix-redirect
+		commands.ExecCommandResult("", "", nil),                          // local: fix-redirect-2
+		commands.ExecCommandResult("  origin/fix-redirect-2\n", "", nil), // origin: fix-redirect-2
+		commands.ExecCommandResult("", "", nil),                          // everything after
+	)
+	wm := newLayoutTestWM(mockGitBase, commands.NewMockBaseCommand())
+	if err := os.MkdirAll(wm.worktreePath("app", "fix-redirect-3"), 0o755); err != nil {
+		t.Fatalf("setup: %v", err)
+	}
+
+	got, err := wm.GenerateName("/repos/app", "Fix the redirect", nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got != "fix-redirect-4" {
+		t.Errorf("expected fix-redirect-4, got %q", got)
+	}
+}
@@ -0,0 +1,69 @@
+// Prompt-first creation: seeding the AI coder in a layout's first pane with
+// an initial task (`dg wt new <name> --prompt "..."`, or the prompt field of
+// the TUI's n/N flow) so it starts working as soon as the window is up.
+//
+// The prompt reaches the pane as typed shell input, so it's cleaned to one
+// line and shell-quoted before it's folded into the launch line: the text is
+// user-supplied and must never be able to end the quoted argument, run a
+// second command, or trigger shell completion while it's being typed.
+
+package worktree
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+)
+
+// WithPrompt returns a copy of l whose first pane seeds its AI coder with
+// prompt, passed the way that coder's PromptStyle says. An empty (or
+// all-whitespace) prompt returns l unchanged. It's an error for the first
+// pane not to launch an AI coder: there would be nothing to hand the task to.
+func (l Layout) WithPrompt(prompt string) (Layout, error) {
+	prompt = cleanPrompt(prompt)
+	if prompt == "" {
+		return l, nil
+	}
+	if len(l.Panes) == 0 || coderFor(l.Panes[0].Command) == nil {
+		return Layout{}, fmt.Errorf(
+			"layout %q can't take a prompt: its first pane doesn't launch an AI coder (cc or oc)",
+			l.Name,
+		)
+	}
+	l.prompt = prompt
+	return l, nil
+}
+
+// cleanPrompt flattens prompt to a single line: every run of whitespace or
+// control characters becomes one space. A newline typed into the pane's shell
+// would submit the line early and a tab would trigger completion, even inside
+// quotes.
+func cleanPrompt(prompt string) string {
+	return strings.Join(strings.FieldsFunc(prompt, func(r rune) bool {
+		return unicode.IsSpace(r) || unicode.IsControl(r)
+	}), " ")
+}
+
+// promptedLaunch builds pane's launch line carrying an already-cleaned
+// prompt in style. The prompt is always a single shell-quoted word, so
+// nothing in it is interpreted by the shell.
+func promptedLaunch(pane Pane, style PromptStyle, prompt string) (line, typed string) {
+	switch style.Mode {
+	case PromptStdin:
+		pane.Command = "printf '%s\\n' " + shellQuote(prompt) + " | " + pane.Command
+	case PromptSendKeys:
+		return pane.launchLine(), prompt
+	default:
+		switch {
+		case style.Flag != "":
+			// --flag='value', not --flag 'value': the latter would let a
+			// prompt starting with "-" be parsed as another option.
+			pane.Command += " " + style.Flag + "=" + shellQuote(prompt)
+		case strings.HasPrefix(prompt, "-"):
+			pane.Command += " -- " + shellQuote(prompt)
+		default:
+			pane.Command += " " + shellQuote(prompt)
+		}
+	}
+	return pane.launchLine(), ""
+}
@@ -0,0 +1,160 @@
+package worktree
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/cjairm/devgita/internal/commands"
+	"github.com/cjairm/devgita/internal/testutil"
+)
+
+func TestCleanPrompt(t *testing.T) {
+	got := cleanPrompt("  fix the\nlogin\t redirect\r\n")
+	if got != "fix the login redirect" {
+		t.Errorf("expected a single-line prompt, got %q", got)
+	}
+}
+
+func TestWithPrompt(t *testing.T) {
+	claude, err := ResolveLayout("claude", "", nil)
+	if err != nil {
+		t.Fatalf("resolve: %v", err)
+	}
+
+	t.Run("empty prompt leaves the layout alone", func(t *testing.T) {
+		got, err := claude.WithPrompt(" \n ")
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if line, _ := got.paneLaunch(0); line != "cc" {
+			t.Errorf("expected a plain launch, got %q", line)
+		}
+	})
+
+	t.Run("first pane without an AI coder is an error", func(t *testing.T) {
+		nvim, err := ResolveLayout("nvim", "", nil)
+		if err != nil {
+			t.Fatalf("resolve: %v", err)
+		}
+		if _, err := nvim.WithPrompt("fix it"); err == nil {
+			t.Fatal("expected an error for a layout with no AI coder up front")
+		}
+	})
+
+	t.Run("prompt only reaches the first pane", func(t *testing.T) {
+		claudeNvim, err := ResolveLayout("claude-nvim", "", nil)
+		if err != nil {
+			t.Fatalf("resolve: %v", err)
+		}
+		got, err := claudeNvim.WithPrompt("fix it")
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if line, _ := got.paneLaunch(0); line != "cc 'fix it'" {
+			t.Errorf("pane 1: got %q", line)
+		}
+		if line, _ := got.paneLaunch(1); line != "nvim" {
+			t.Errorf("pane 2: got %q", line)
+		}
+	})
+}
+
+func TestPromptedLaunch(t *testing.T) {
+	pane := Pane{Command: "cc", Dir: "web"}
+	tests := []struct {
+		name      string
+		style     PromptStyle
+		prompt    string
+		wantLine  string
+		wantTyped string
+	}{
+		{
+			name:     "positional argument",
+			style:    PromptStyle{Mode: PromptArg},
+			prompt:   "don't break it",
+			wantLine: `cd 'web' && cc 'don'\''t break it'`,
+		},
+		{
+			name:     "leading dash ends option parsing first",
+			style:    PromptStyle{Mode: PromptArg},
+			prompt:   "-rf everything",
+			wantLine: `cd 'web' && cc -- '-rf everything'`,
+		},
+		{
+			name:     "flag argument",
+			style:    PromptStyle{Mode: PromptArg, Flag: "--prompt"},
+			prompt:   "$(rm -rf ~); `id`",
+			wantLine: "cd 'web' && cc --prompt='$(rm -rf ~); `id`'",
+		},
+		{
+			name:     "stdin",
+			style:    PromptStyle{Mode: PromptStdin},
+			prompt:   "fix it",
+			wantLine: `cd 'web' && printf '%s\n' 'fix it' | cc`,
+		},
+		{
+			name:      "send-keys",
+			style:     PromptStyle{Mode: PromptSendKeys},
+			prompt:    "fix it",
+			wantLine:  "cd 'web' && cc",
+			wantTyped: "fix it",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			line, typed := promptedLaunch(pane, tt.style, tt.prompt)
+			if line != tt.wantLine {
+				t.Errorf("line: expected %q, got %q", tt.wantLine, line)
+			}
+			if typed != tt.wantTyped {
+				t.Errorf("typed: expected %q, got %q", tt.wantTyped, typed)
+			}
+		})
+	}
+}
+
+// TestCreateWithPromptLaunchesAndRecordsIt proves the prompt rides along on
+// the first pane's launch line and is recorded in the worktree's metadata.
+func TestCreateWithPromptLaunchesAndRecordsIt(t *testing.T) {
+	isolateDataRoot(t)
+	cleanupPaths := testutil.SetupIsolatedPaths(t)
+	defer cleanupPaths()
+	setShellCommandExistsFn(t, func(string) bool { return true })
+
+	repoRoot := t.TempDir()
+	wm, mockGitBase, mockTmuxBase, _ := newRecordingWM()
+	mockGitBase.SetExecCommandResults(
+		commands.ExecCommandResult(repoRoot+"\n", "", nil),
+		commands.ExecCommandResult("abc123\n", "", nil),
+	)
+	mockTmuxBase.SetExecCommandResult("", "", nil)
+
+	layout, err := ResolveLayout("opencode", "", nil)
+	if err != nil {
+		t.Fatalf("resolve: %v", err)
+	}
+	if layout, err = layout.WithPrompt("add\nsearch"); err != nil {
+		t.Fatalf("WithPrompt: %v", err)
+	}
+	if err := wm.Create("feat/x", layout, true); err != nil {
+		t.Fatalf("Create failed: %v", err)
+	}
+
+	window := GetWindowName(filepath.Base(repoRoot), "feat/x")
+	want := "send-keys -t " + window + " oc --prompt='add search' Enter"
+	var launched bool
+	for _, call := range mockTmuxBase.ExecCommandCalls {
+		if strings.Join(call.Args, " ") == want {
+			launched = true
+		}
+	}
+	if !launched {
+		t.Errorf("expected oc to launch with the prompt, got %v", mockTmuxBase.ExecCommandCalls)
+	}
+
+	meta, ok := LoadMetadata(filepath.Base(repoRoot), "feat/x")
+	if !ok || meta.Prompt != "add search" {
+		t.Errorf("expected the prompt to be recorded, got %+v (ok=%v)", meta, ok)
+	}
+}
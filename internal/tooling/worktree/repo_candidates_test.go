@@ -56,7 +56,7 @@ func newRecordingWM() (wm *WorktreeManager, mockGitBase, mockTmuxBase, mockBase
 	mockBase = commands.NewMockBaseCommand()
 	wm = &WorktreeManager{
 		Git:  &git.Git{Cmd: commands.NewMockCommand(), Base: mockGitBase},
-		Tmux: &tmux.Tmux{Cmd: commands.NewMockCommand(), Base: mockTmuxBase},
+		Mux:  &tmux.Tmux{Cmd: commands.NewMockCommand(), Base: mockTmuxBase},
 		Base: mockBase,
 	}
 	return
@@ -47,7 +47,7 @@ func tmuxCommandOrder(mockBase *commands.MockBaseCommand) []string {
 func newLayoutTestWM(mockGitBase, mockTmuxBase *commands.MockBaseCommand) *WorktreeManager {
 	return &WorktreeManager{
 		Git:  &git.Git{Cmd: commands.NewMockCommand(), Base: mockGitBase},
-		Tmux: &tmux.Tmux{Cmd: commands.NewMockCommand(), Base: mockTmuxBase},
+		Mux:  &tmux.Tmux{Cmd: commands.NewMockCommand(), Base: mockTmuxBase},
 		Base: commands.NewMockBaseCommand(),
 	}
 }
@@ -418,3 +418,79 @@ func TestRepairExistingWindowOnlyResendsPaneZero(t *testing.T) {
 		t.Errorf("expected pane 0's command sent, got %v", last.Args)
 	}
 }
+
+// TestCreateCustomLayoutFocusAndSize proves a user-defined layout's split
+// size reaches split-window as -l N%, and that a focus on a middle pane
+// captures that pane's id right after its split and reselects it at the end.
+func TestCreateCustomLayoutFocusAndSize(t *testing.T) {
+	repoRoot := t.TempDir()
+
+	mockGitBase := commands.NewMockBaseCommand()
+	mockGitBase.SetExecCommandResults(
+		commands.ExecCommandResult(repoRoot+"\n", "", nil),
+		commands.ExecCommandResult("", "", nil),
+	)
+	mockTmuxBase := commands.NewMockBaseCommand()
+	mockTmuxBase.SetExecCommandResults(
+		commands.ExecCommandResult("", "", nil),      // list-windows (state)
+		commands.ExecCommandResult("", "", nil),      // new-window
+		commands.ExecCommandResult("", "", nil),      // send-keys (pane 1)
+		commands.ExecCommandResult("", "", nil),      // split-window (pane 2)
+		commands.ExecCommandResult("%42\n", "", nil), // display-message (focus pane 2)
+		commands.ExecCommandResult("", "", nil),      // send-keys (pane 2)
+		commands.ExecCommandResult("", "", nil),      // split-window (pane 3)
+		commands.ExecCommandResult("", "", nil),      // select-pane
+	)
+
+	wm := newLayoutTestWM(mockGitBase, mockTmuxBase)
+
+	repoSlug := filepath.Base(repoRoot)
+	wtPath := filepath.Join(paths.Paths.Data.Root, "devgita", "worktrees", repoSlug, "feature-test")
+	t.Cleanup(func() {
+		if err := os.RemoveAll(filepath.Dir(wtPath)); err != nil {
+			t.Logf("cleanup: %v", err)
+		}
+	})
+
+	layout := Layout{
+		Name: "custom",
+		Panes: []Pane{
+			{Command: "pane0-cmd"},
+			{Command: "pane1-cmd", Split: "vertical", Size: 40},
+			{Split: "horizontal"}, // plain shell: nothing sent
+		},
+		Focus: 1,
+	}
+	if err := wm.Create("feature-test", layout, true); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	wantOrder := []string{
+		"list-windows",
+		"new-window",
+		"send-keys",
+		"split-window",
+		"display-message",
+		"send-keys",
+		"split-window",
+		"select-pane",
+	}
+	gotOrder := tmuxCommandOrder(mockTmuxBase)
+	if len(gotOrder) != len(wantOrder) {
+		t.Fatalf("expected %d tmux calls, got %d: %v", len(wantOrder), len(gotOrder), gotOrder)
+	}
+	for i, want := range wantOrder {
+		if gotOrder[i] != want {
+			t.Errorf("call %d: expected %q, got %q (full order: %v)", i, want, gotOrder[i], gotOrder)
+		}
+	}
+
+	split := mockTmuxBase.ExecCommandCalls[3].Args
+	if n := len(split); n < 2 || split[n-2] != "-l" || split[n-1] != "40%" {
+		t.Errorf("expected the sized split to end with -l 40%%, got %v", split)
+	}
+	last := mockTmuxBase.ExecCommandCalls[len(mockTmuxBase.ExecCommandCalls)-1]
+	if last.Args[len(last.Args)-1] != "%42" {
+		t.Errorf("expected select-pane to target the focus pane %%42, got %v", last.Args)
+	}
+}
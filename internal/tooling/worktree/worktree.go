@@ -12,6 +12,7 @@
 package worktree
 
 import (
+	"errors"
 	"fmt"
 	"os"
 	"path/filepath"
@@ -67,6 +68,7 @@ type WorktreeStatus struct {
 	TmuxWindow   string
 	WindowActive bool
 	Repo         string
+	Meta         *Metadata // what it was created with; nil when nothing was recorded
 }
 
 // SessionStatus describes a standalone tmux session for the workspace
@@ -88,7 +90,7 @@ type WorktreeState struct {
 // WorktreeManager coordinates git worktrees with tmux windows
 type WorktreeManager struct {
 	Git  *git.Git
-	Tmux *tmux.Tmux
+	Mux  Multiplexer // tmux (default) or Zellij; see NewMultiplexer
 	Fzf  *fzf.Fzf
 	Base cmd.BaseCommandExecutor
 	// WarnFn reports a non-fatal warning to the user (e.g. the recent-repos
@@ -100,15 +102,27 @@ type WorktreeManager struct {
 	WarnFn func(msg string)
 }
 
-// New creates a new WorktreeManager instance
+// New creates a new WorktreeManager instance, on the multiplexer configured
+// as worktree.multiplexer (tmux when unset or unknown, with a warning for the
+// latter).
 func New() *WorktreeManager {
-	return &WorktreeManager{
+	w := &WorktreeManager{
 		Git:    git.New(),
-		Tmux:   tmux.New(),
 		Fzf:    fzf.New(),
 		Base:   cmd.NewBaseCommand(),
 		WarnFn: utils.PrintWarning,
 	}
+	gc := &config.GlobalConfig{}
+	if err := gc.Load(); err != nil && !errors.Is(err, os.ErrNotExist) {
+		logger.L().Debugw("failed to load global config for multiplexer", "error", err)
+	}
+	mux, err := NewMultiplexer(gc.Worktree.Multiplexer)
+	if err != nil {
+		w.WarnFn(err.Error() + "; using tmux")
+		mux = tmux.New()
+	}
+	w.Mux = mux
+	return w
 }
 
 // worktreePath returns ~/.local/share/devgita/worktrees/<repo-slug>/<flat-name>
@@ -242,6 +256,7 @@ func (w *WorktreeManager) create(
 					return w.launchWindowAndRecord(
 						repoRoot,
 						repoSlug,
+						name,
 						windowName,
 						wtPath,
 						layout,
@@ -253,21 +268,32 @@ func (w *WorktreeManager) create(
 		return fmt.Errorf("failed to create worktree: %w", err)
 	}
 
-	return w.launchWindowAndRecord(repoRoot, repoSlug, windowName, wtPath, layout, useRepoSession)
+	return w.launchWindowAndRecord(
+		repoRoot,
+		repoSlug,
+		name,
+		windowName,
+		wtPath,
+		layout,
+		useRepoSession,
+	)
 }
 
 // launchWindowAndRecord wraps launchWindow so both create() call sites (the
-// happy path and the stale-entry retry path) record the repo as used on
-// success without duplicating that logic at each call site.
+// happy path and the stale-entry retry path) bootstrap the new worktree first
+// and record the repo as used, and the worktree's metadata, on success without
+// duplicating that logic at each call site.
 func (w *WorktreeManager) launchWindowAndRecord(
-	repoRoot, repoSlug, windowName, wtPath string,
+	repoRoot, repoSlug, name, windowName, wtPath string,
 	layout Layout,
 	useRepoSession bool,
 ) error {
+	layout = w.bootstrap(repoRoot, wtPath, name, layout)
 	if err := w.launchWindow(repoSlug, windowName, wtPath, layout, useRepoSession); err != nil {
 		return err
 	}
 	w.recordRepoUsed(repoRoot)
+	w.recordMetadata(repoRoot, repoSlug, wtPath, name, layout)
 	return nil
 }
 
@@ -289,10 +315,10 @@ func (w *WorktreeManager) launchWindow(
 		_ = w.Git.RemoveWorktree(wtPath, true, "")
 		return err
 	}
-	// Follow the new window when running inside tmux (best-effort).
-	if os.Getenv("TMUX") != "" {
-		if session, ok := w.Tmux.WindowSession(windowName); ok {
-			_ = w.Tmux.SwitchToWindow(session, windowName)
+	// Follow the new window when running inside the multiplexer (best-effort).
+	if os.Getenv("TMUX") != "" || os.Getenv("ZELLIJ") != "" {
+		if session, ok := w.Mux.WindowSession(windowName); ok {
+			_ = w.Mux.SwitchToWindow(session, windowName)
 		}
 	}
 	return nil
@@ -306,16 +332,23 @@ func (w *WorktreeManager) launchWindow(
 // single-pane path gave, never a window with some panes up alongside a
 // worktree that's still there.
 func (w *WorktreeManager) buildWindowFromLayout(windowName, wtPath string, layout Layout) error {
-	if err := w.Tmux.CreateWindow(windowName, wtPath); err != nil {
+	if builder, ok := w.Mux.(kdlWindowBuilder); ok {
+		if err := w.buildKDLWindow(builder, "", windowName, wtPath, layout); err != nil {
+			_ = w.Git.RemoveWorktree(wtPath, true, "")
+			return err
+		}
+		return nil
+	}
+	if err := w.Mux.CreateWindow(windowName, wtPath); err != nil {
 		_ = w.Git.RemoveWorktree(wtPath, true, "")
 		return fmt.Errorf("failed to create tmux window: %w", err)
 	}
 
 	sendKeys := func(command string) error {
-		return w.Tmux.SendKeysToWindow(windowName, command)
+		return w.Mux.SendKeysToWindow(windowName, command)
 	}
 	if err := w.buildWindowPanes(windowName, wtPath, layout, sendKeys); err != nil {
-		_ = w.Tmux.KillWindow(windowName)
+		_ = w.Mux.KillWindow(windowName)
 		_ = w.Git.RemoveWorktree(wtPath, true, "")
 		return err
 	}
@@ -325,7 +358,7 @@ func (w *WorktreeManager) buildWindowFromLayout(windowName, wtPath string, layou
 // buildWindowPanes builds every pane of layout into a window that already
 // exists with exactly one (pane 0) pane - i.e. right after CreateWindow,
 // CreateWindowInSession, or CreateSessionWithWindow. target is how the window
-// is addressed for SplitWindow/ActivePaneID: a bare window name (current
+// is addressed for SplitWindowSized/ActivePaneID: a bare window name (current
 // session) or "session:window" (qualified, for a window that may not be in
 // the attached client's session). sendKeys sends a command to target's
 // currently active pane, mirroring whichever of SendKeysToWindow /
@@ -335,16 +368,24 @@ func (w *WorktreeManager) buildWindowPanes(
 	layout Layout,
 	sendKeys func(command string) error,
 ) error {
-	// Pane 0's tmux pane_id must be captured now, before any split, while it
-	// is still the window's only (and therefore unambiguously "active") pane.
-	// It's needed only when there's more than one pane to reselect it later.
-	var pane0ID string
-	if len(layout.Panes) > 1 {
-		id, err := w.Tmux.ActivePaneID(target)
+	// The focus pane's tmux pane_id must be captured right after it is
+	// created, while it is still unambiguously the "active" pane - for pane 0
+	// that's now, before any split. It's needed only when a later pane will
+	// have been split off (and made active) by the time the build finishes.
+	var focusID string
+	captureFocus := func(i int) error {
+		if i != layout.Focus || i == len(layout.Panes)-1 {
+			return nil
+		}
+		id, err := w.Mux.ActivePaneID(target)
 		if err != nil {
-			return fmt.Errorf("layout %q: failed to identify pane 0: %w", layout.Name, err)
+			return fmt.Errorf("layout %q: failed to identify pane %d: %w", layout.Name, i+1, err)
 		}
-		pane0ID = id
+		focusID = id
+		return nil
+	}
+	if err := captureFocus(0); err != nil {
+		return err
 	}
 
 	for i, pane := range layout.Panes {
@@ -356,27 +397,43 @@ func (w *WorktreeManager) buildWindowPanes(
 			// split, the new pane is active, and sendKeys below (send-keys
 			// with no pane index) always targets whichever pane in the
 			// window is currently active.
-			if err := w.Tmux.SplitWindow(target, wtPath, pane.Split); err != nil {
+			if err := w.Mux.SplitWindowSized(target, wtPath, pane.Split, pane.Size); err != nil {
 				return fmt.Errorf(
 					"layout %q, pane %d: failed to split window: %w",
 					layout.Name, i+1, err,
 				)
 			}
+			if err := captureFocus(i); err != nil {
+				return err
+			}
+		}
+		// A pane with no command, dir, or env is a plain shell: nothing to send.
+		line, typed := layout.paneLaunch(i)
+		if line != "" {
+			if err := sendKeys(line); err != nil {
+				return fmt.Errorf("layout %q, pane %d: failed to launch: %w", layout.Name, i+1, err)
+			}
 		}
-		if err := sendKeys(pane.Command); err != nil {
-			return fmt.Errorf("layout %q, pane %d: failed to launch: %w", layout.Name, i+1, err)
+		if typed != "" {
+			if err := w.Mux.SendLiteralKeys(target, typed); err != nil {
+				return fmt.Errorf("layout %q, pane %d: failed to send prompt: %w", layout.Name, i+1, err)
+			}
 		}
 	}
 
-	if pane0ID != "" {
-		// Land the user on pane 0 (e.g. the AI coder), not whichever pane was
-		// split last (e.g. an editor pane), when they attach. Re-targeting by
-		// tmux pane index (e.g. target+".0") is NOT reliable: devgita's own
-		// shipped tmux.conf sets pane-base-index to 1 (configs/tmux/tmux.conf),
-		// so a window's first pane is index 1, not 0 - pane_id is tmux's own
-		// stable, globally-unique identifier and is unaffected by that option.
-		if err := w.Tmux.SelectPane(pane0ID); err != nil {
-			return fmt.Errorf("layout %q: failed to select pane 0: %w", layout.Name, err)
+	if focusID != "" {
+		// Land the user on the focus pane (pane 0, e.g. the AI coder, for
+		// every built-in), not whichever pane was split last (e.g. an editor
+		// pane), when they attach. Re-targeting by tmux pane index (e.g.
+		// target+".0") is NOT reliable: devgita's own shipped tmux.conf sets
+		// pane-base-index to 1 (configs/tmux/tmux.conf), so a window's first
+		// pane is index 1, not 0 - pane_id is tmux's own stable,
+		// globally-unique identifier and is unaffected by that option.
+		if err := w.Mux.SelectPane(focusID); err != nil {
+			return fmt.Errorf(
+				"layout %q: failed to select pane %d: %w",
+				layout.Name, layout.Focus+1, err,
+			)
 		}
 	}
 
@@ -404,7 +461,7 @@ func (w *WorktreeManager) worktreeState(repoSlug, name string) (WorktreeState, e
 		}
 	}
 
-	if _, ok := w.Tmux.WindowSession(state.WindowName); ok {
+	if _, ok := w.Mux.WindowSession(state.WindowName); ok {
 		state.WindowExists = true
 	}
 
@@ -457,15 +514,19 @@ func (w *WorktreeManager) List() ([]WorktreeStatus, error) {
 				}
 			}
 
-			_, windowActive := w.Tmux.WindowSession(windowName)
-			statuses = append(statuses, WorktreeStatus{
+			_, windowActive := w.Mux.WindowSession(windowName)
+			status := WorktreeStatus{
 				Name:         name,
 				Path:         wtPath,
 				Branch:       branch,
 				TmuxWindow:   windowName,
 				WindowActive: windowActive,
 				Repo:         repoSlug,
-			})
+			}
+			if meta, ok := LoadMetadata(repoSlug, name); ok {
+				status.Meta = &meta
+			}
+			statuses = append(statuses, status)
 		}
 	}
 
@@ -495,7 +556,7 @@ func (w *WorktreeManager) ListNames() ([]string, error) {
 // nil) no-server result, which flows through as an empty list here rather
 // than an error.
 func (w *WorktreeManager) ListSessions() ([]SessionStatus, error) {
-	sessions, err := w.Tmux.ListSessions()
+	sessions, err := w.Mux.ListSessions()
 	if err != nil {
 		return nil, err
 	}
@@ -504,7 +565,7 @@ func (w *WorktreeManager) ListSessions() ([]SessionStatus, error) {
 	}
 
 	worktreeSessions := make(map[string]bool, len(sessions))
-	for _, sw := range w.Tmux.SessionWindows() {
+	for _, sw := range w.Mux.SessionWindows() {
 		if isWorktreeWindow(sw.Window) {
 			worktreeSessions[sw.Session] = true
 		}
@@ -569,7 +630,7 @@ func (w *WorktreeManager) Remove(name string, force bool) error {
 	// window name (wt-<repo>-<flat-name>). Match orphan windows by their trailing
 	// "-<flat-name>" segment, keeping only those with the wt- prefix.
 	var orphans []string
-	for _, window := range w.Tmux.FindWindowsBySuffix("-" + FlattenName(name)) {
+	for _, window := range w.Mux.FindWindowsBySuffix("-" + FlattenName(name)) {
 		if isWorktreeWindow(window) {
 			orphans = append(orphans, window)
 		}
@@ -578,7 +639,7 @@ func (w *WorktreeManager) Remove(name string, force bool) error {
 	case 0:
 		return fmt.Errorf("nothing to remove for worktree '%s'", name)
 	case 1:
-		_ = w.Tmux.KillWindow(orphans[0])
+		_ = w.Mux.KillWindow(orphans[0])
 		return nil
 	default:
 		// Same worktree name across repos — killing an arbitrary match could
@@ -605,19 +666,24 @@ func (w *WorktreeManager) repoSlugForWorktree(name string) string {
 }
 
 // Repair recreates the missing window for an existing worktree and rebuilds
-// layout in it. The window is created in a tmux session named after the
-// worktree's parent folder (the repo slug), creating that session if it does
-// not exist. Works from any directory or session.
+// layout in it. Passing Layout{} rebuilds the layout the worktree was created
+// with (see repairLayout). The window is created in a tmux session named
+// after the worktree's parent folder (the repo slug), creating that session
+// if it does not exist. Works from any directory or session.
 func (w *WorktreeManager) Repair(name string, layout Layout) error {
-	if err := validateLayout(layout); err != nil {
-		return err
-	}
-
 	repoSlug := w.repoSlugForWorktree(name)
 	if repoSlug == "" {
 		return fmt.Errorf("no worktree '%s' to repair", name)
 	}
 
+	layout, err := repairLayout(repoSlug, name, layout)
+	if err != nil {
+		return err
+	}
+	if err := validateLayout(layout); err != nil {
+		return err
+	}
+
 	wtPath := w.worktreePath(repoSlug, name)
 	windowName := GetWindowName(repoSlug, name)
 
@@ -652,7 +718,7 @@ func (w *WorktreeManager) RemoveInRepo(repoSlug, name string, force bool) error
 // terminal survives the kill. The fallback session itself is never killed.
 func (w *WorktreeManager) RemoveWithSessionInRepo(repoSlug, name string) error {
 	windowName := GetWindowName(repoSlug, name)
-	session, hadWindow := w.Tmux.WindowSession(windowName)
+	session, hadWindow := w.Mux.WindowSession(windowName)
 
 	if err := w.removeByRepo(repoSlug, name, true); err != nil {
 		return err
@@ -663,17 +729,17 @@ func (w *WorktreeManager) RemoveWithSessionInRepo(repoSlug, name string) error {
 	}
 	// Killing the worktree window may have already destroyed the session
 	// (tmux removes a session when its last window closes).
-	if !w.Tmux.HasSession(session) {
+	if !w.Mux.HasSession(session) {
 		return nil
 	}
 
-	if current, ok := w.Tmux.CurrentSession(); ok && current == session {
-		if !w.Tmux.HasSession(fallbackSession) {
+	if current, ok := w.Mux.CurrentSession(); ok && current == session {
+		if !w.Mux.HasSession(fallbackSession) {
 			workdir, err := os.UserHomeDir()
 			if err != nil {
 				workdir = "/"
 			}
-			if err := w.Tmux.CreateSession(fallbackSession, workdir); err != nil {
+			if err := w.Mux.CreateSession(fallbackSession, workdir); err != nil {
 				return fmt.Errorf(
 					"worktree removed but session '%s' kept: failed to create fallback session '%s': %w",
 					session,
@@ -682,7 +748,7 @@ func (w *WorktreeManager) RemoveWithSessionInRepo(repoSlug, name string) error {
 				)
 			}
 		}
-		if err := w.Tmux.SwitchToSession(fallbackSession); err != nil {
+		if err := w.Mux.SwitchToSession(fallbackSession); err != nil {
 			return fmt.Errorf(
 				"worktree removed but session '%s' kept: failed to switch to '%s': %w",
 				session, fallbackSession, err,
@@ -690,14 +756,19 @@ func (w *WorktreeManager) RemoveWithSessionInRepo(repoSlug, name string) error {
 		}
 	}
 
-	if err := w.Tmux.KillSession(session); err != nil {
+	if err := w.Mux.KillSession(session); err != nil {
 		return fmt.Errorf("worktree removed but failed to kill session '%s': %w", session, err)
 	}
 	return nil
 }
 
 // RepairInRepo repairs a worktree in a specific repo, bypassing the slug-search ambiguity.
+// Like Repair, Layout{} rebuilds the layout the worktree was created with.
 func (w *WorktreeManager) RepairInRepo(repoSlug, name string, layout Layout) error {
+	layout, err := repairLayout(repoSlug, name, layout)
+	if err != nil {
+		return err
+	}
 	if err := validateLayout(layout); err != nil {
 		return err
 	}
@@ -730,36 +801,43 @@ func (w *WorktreeManager) RepairInRepo(repoSlug, name string, layout Layout) err
 // the window doesn't exist yet, in the worktree's repo-slug session (created
 // when absent).
 func (w *WorktreeManager) ensureWindow(repoSlug, windowName, wtPath string, layout Layout) error {
-	session, exists := w.Tmux.WindowSession(windowName)
+	session, exists := w.Mux.WindowSession(windowName)
 	if exists {
-		if err := w.Tmux.SendKeysToWindowInSession(
-			session,
-			windowName,
-			layout.Panes[0].Command,
-		); err != nil {
-			return fmt.Errorf("failed to launch %s: %w", layout.Name, err)
+		line, typed := layout.paneLaunch(0)
+		if line != "" {
+			if err := w.Mux.SendKeysToWindowInSession(session, windowName, line); err != nil {
+				return fmt.Errorf("failed to launch %s: %w", layout.Name, err)
+			}
+		}
+		if typed != "" {
+			if err := w.Mux.SendLiteralKeys(session+":"+windowName, typed); err != nil {
+				return fmt.Errorf("failed to send prompt to %s: %w", layout.Name, err)
+			}
 		}
 		return nil
 	}
 
 	session = TmuxSessionName(repoSlug)
-	if w.Tmux.HasSession(session) {
-		if err := w.Tmux.CreateWindowInSession(session, windowName, wtPath); err != nil {
+	if builder, ok := w.Mux.(kdlWindowBuilder); ok {
+		return w.buildKDLWindow(builder, session, windowName, wtPath, layout)
+	}
+	if w.Mux.HasSession(session) {
+		if err := w.Mux.CreateWindowInSession(session, windowName, wtPath); err != nil {
 			return fmt.Errorf("failed to create tmux window: %w", err)
 		}
 	} else {
-		if err := w.Tmux.CreateSessionWithWindow(session, windowName, wtPath); err != nil {
+		if err := w.Mux.CreateSessionWithWindow(session, windowName, wtPath); err != nil {
 			return fmt.Errorf("failed to create tmux session: %w", err)
 		}
 	}
 
 	sendKeys := func(command string) error {
-		return w.Tmux.SendKeysToWindowInSession(session, windowName, command)
+		return w.Mux.SendKeysToWindowInSession(session, windowName, command)
 	}
 	if err := w.buildWindowPanes(session+":"+windowName, wtPath, layout, sendKeys); err != nil {
 		// Kill only the window, never the session: other worktrees' windows
 		// may already live in this same repo-slug session.
-		_ = w.Tmux.KillWindow(windowName)
+		_ = w.Mux.KillWindow(windowName)
 		return err
 	}
 	return nil
@@ -828,7 +906,7 @@ func (w *WorktreeManager) removeByRepo(repoSlug, name string, force bool) error
 
 	// Always try to kill the window, even if state check didn't find it
 	// (state check may fail if not in tmux or window detection is unreliable)
-	_ = w.Tmux.KillWindow(windowName)
+	_ = w.Mux.KillWindow(windowName)
 
 	if state.WtExists {
 		if err := w.Git.RemoveWorktree(wtPath, true, name); err != nil {
@@ -841,6 +919,7 @@ func (w *WorktreeManager) removeByRepo(repoSlug, name string, force bool) error
 	} else {
 		_ = w.Git.PruneWorktreesAt(filepath.Dir(wtPath))
 	}
+	removeMetadata(repoSlug, name)
 
 	return nil
 }
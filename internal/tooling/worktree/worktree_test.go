@@ -26,8 +26,8 @@ func TestNew(t *testing.T) {
 	if wm.Git == nil {
 		t.Error("Git should not be nil")
 	}
-	if wm.Tmux == nil {
-		t.Error("Tmux should not be nil")
+	if wm.Mux == nil {
+		t.Error("Mux should not be nil")
 	}
 	if wm.Fzf == nil {
 		t.Error("Fzf should not be nil")
@@ -98,7 +98,7 @@ func TestCreate(t *testing.T) {
 
 		wm := &WorktreeManager{
 			Git:  gitApp,
-			Tmux: tmuxApp,
+			Mux:  tmuxApp,
 			Base: commands.NewMockBaseCommand(),
 		}
 
@@ -136,7 +136,7 @@ func TestCreate(t *testing.T) {
 
 		wm := &WorktreeManager{
 			Git:  gitApp,
-			Tmux: tmuxApp,
+			Mux:  tmuxApp,
 			Base: commands.NewMockBaseCommand(),
 		}
 
@@ -165,7 +165,7 @@ func TestList(t *testing.T) {
 
 		wm := &WorktreeManager{
 			Git:  gitApp,
-			Tmux: tmuxApp,
+			Mux:  tmuxApp,
 			Base: commands.NewMockBaseCommand(),
 		}
 
@@ -205,7 +205,7 @@ func TestRemove(t *testing.T) {
 
 		wm := &WorktreeManager{
 			Git:  gitApp,
-			Tmux: tmuxApp,
+			Mux:  tmuxApp,
 			Base: commands.NewMockBaseCommand(),
 		}
 
@@ -259,7 +259,7 @@ func TestRemove(t *testing.T) {
 
 		wm := &WorktreeManager{
 			Git:  gitApp,
-			Tmux: tmuxApp,
+			Mux:  tmuxApp,
 			Base: commands.NewMockBaseCommand(),
 		}
 
@@ -304,7 +304,7 @@ func TestRemove(t *testing.T) {
 
 		wm := &WorktreeManager{
 			Git:  gitApp,
-			Tmux: tmuxApp,
+			Mux:  tmuxApp,
 			Base: commands.NewMockBaseCommand(),
 		}
 
@@ -333,7 +333,7 @@ func TestRemoveByRepoUsesCorrectPath(t *testing.T) {
 		mockTmuxBase.SetExecCommandResult("", "window not found", os.ErrNotExist)
 		wm := &WorktreeManager{
 			Git:  &git.Git{Cmd: commands.NewMockCommand(), Base: mockGitBase},
-			Tmux: &tmux.Tmux{Cmd: commands.NewMockCommand(), Base: mockTmuxBase},
+			Mux:  &tmux.Tmux{Cmd: commands.NewMockCommand(), Base: mockTmuxBase},
 			Base: commands.NewMockBaseCommand(),
 		}
 		return wm, filepath.Base(tempDir)
@@ -424,7 +424,7 @@ func TestRepairStaleWorktree(t *testing.T) {
 
 	wm := &WorktreeManager{
 		Git:  gitApp,
-		Tmux: tmuxApp,
+		Mux:  tmuxApp,
 		Base: commands.NewMockBaseCommand(),
 	}
 
@@ -517,7 +517,7 @@ func TestRemoveWithSessionInRepo(t *testing.T) {
 		mockTmuxBase := commands.NewMockBaseCommand()
 		wm := &WorktreeManager{
 			Git:  &git.Git{Cmd: commands.NewMockCommand(), Base: mockGitBase},
-			Tmux: &tmux.Tmux{Cmd: commands.NewMockCommand(), Base: mockTmuxBase},
+			Mux:  &tmux.Tmux{Cmd: commands.NewMockCommand(), Base: mockTmuxBase},
 			Base: commands.NewMockBaseCommand(),
 		}
 		_ = session
@@ -699,7 +699,7 @@ func TestCreateAt(t *testing.T) {
 		mockGitBase.SetExecCommandResult("", "fatal: not a git repository", os.ErrNotExist)
 		wm := &WorktreeManager{
 			Git:  &git.Git{Cmd: commands.NewMockCommand(), Base: mockGitBase},
-			Tmux: &tmux.Tmux{Cmd: commands.NewMockCommand(), Base: commands.NewMockBaseCommand()},
+			Mux:  &tmux.Tmux{Cmd: commands.NewMockCommand(), Base: commands.NewMockBaseCommand()},
 			Base: commands.NewMockBaseCommand(),
 		}
 
@@ -740,7 +740,7 @@ func TestCreateAt(t *testing.T) {
 
 		wm := &WorktreeManager{
 			Git:  &git.Git{Cmd: commands.NewMockCommand(), Base: mockGitBase},
-			Tmux: &tmux.Tmux{Cmd: commands.NewMockCommand(), Base: mockTmuxBase},
+			Mux:  &tmux.Tmux{Cmd: commands.NewMockCommand(), Base: mockTmuxBase},
 			Base: commands.NewMockBaseCommand(),
 		}
 
@@ -804,7 +804,7 @@ func TestListSessions(t *testing.T) {
 				),
 			)
 			wm := &WorktreeManager{
-				Tmux: &tmux.Tmux{Cmd: commands.NewMockCommand(), Base: mockTmuxBase},
+				Mux: &tmux.Tmux{Cmd: commands.NewMockCommand(), Base: mockTmuxBase},
 			}
 
 			statuses, err := wm.ListSessions()
@@ -848,7 +848,7 @@ func TestListSessions(t *testing.T) {
 			errors.New("exit status 1"),
 		)
 		wm := &WorktreeManager{
-			Tmux: &tmux.Tmux{Cmd: commands.NewMockCommand(), Base: mockTmuxBase},
+			Mux: &tmux.Tmux{Cmd: commands.NewMockCommand(), Base: mockTmuxBase},
 		}
 
 		statuses, err := wm.ListSessions()
@@ -877,7 +877,7 @@ func TestListSessions(t *testing.T) {
 			errors.New("exit status 1"),
 		)
 		wm := &WorktreeManager{
-			Tmux: &tmux.Tmux{Cmd: commands.NewMockCommand(), Base: mockTmuxBase},
+			Mux: &tmux.Tmux{Cmd: commands.NewMockCommand(), Base: mockTmuxBase},
 		}
 
 		statuses, err := wm.ListSessions()
@@ -918,7 +918,7 @@ func TestListSessions(t *testing.T) {
 				commands.ExecCommandResult("", "error", errors.New("no server")),
 			)
 			wm := &WorktreeManager{
-				Tmux: &tmux.Tmux{Cmd: commands.NewMockCommand(), Base: mockTmuxBase},
+				Mux: &tmux.Tmux{Cmd: commands.NewMockCommand(), Base: mockTmuxBase},
 			}
 
 			statuses, err := wm.ListSessions()
@@ -2,10 +2,15 @@ package tuicomponents
 
 import (
 	"strings"
+	"time"
 
+	tea "charm.land/bubbletea/v2"
 	"github.com/charmbracelet/x/ansi"
 )
 
+// MaxToastWidth caps a toast so it never covers more than a corner.
+const MaxToastWidth = 48
+
 // ToastKind controls the border/title color of a notification toast.
 type ToastKind int
 
@@ -23,6 +28,52 @@ type Toast struct {
 	Action string // e.g. "⏎ to attach"; omitted if empty
 }
 
+// ToastExpiredMsg clears the toast it was scheduled for; a newer toast
+// carries a newer sequence number and survives it.
+type ToastExpiredMsg int
+
+// ToastState is a dashboard's current toast and the bookkeeping that lets
+// each one expire on its own timer: Show returns the tick for the toast it
+// shows, and Expire only clears the toast that tick was scheduled for.
+type ToastState struct {
+	Current *Toast // nil when no toast is up
+	seq     int
+}
+
+// Show displays t and returns the tick that clears it after d.
+func (s *ToastState) Show(t Toast, d time.Duration) tea.Cmd {
+	s.seq++
+	s.Current = &t
+	seq := s.seq
+	return tea.Tick(d, func(time.Time) tea.Msg { return ToastExpiredMsg(seq) })
+}
+
+// Expire clears the current toast if msg was scheduled for it, and reports
+// whether it did.
+func (s *ToastState) Expire(msg ToastExpiredMsg) bool {
+	if int(msg) != s.seq {
+		return false
+	}
+	s.Current = nil
+	return true
+}
+
+// ExpiredMsg is the message that would clear the current toast, as its
+// tick delivers it.
+func (s ToastState) ExpiredMsg() ToastExpiredMsg {
+	return ToastExpiredMsg(s.seq)
+}
+
+// Overlay draws the current toast, if any, over background's top-right
+// corner.
+func (s ToastState) Overlay(p *Palette, background string, width, height int) string {
+	if s.Current == nil {
+		return background
+	}
+	toast := p.Notification(*s.Current, min(MaxToastWidth, width-2))
+	return OverlayTopRight(background, toast, width, height)
+}
+
 // Notification renders a bordered toast box for top-right placement.
 // maxWidth < 6 → returns "".
 func (p *Palette) Notification(t Toast, maxWidth int) string {
@@ -0,0 +1,41 @@
+package tuicomponents_test
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/charmbracelet/x/ansi"
+
+	tuicomponents "github.com/cjairm/devgita/internal/tui/components"
+)
+
+func TestToastStateExpiresOnlyItsOwnToast(t *testing.T) {
+	var s tuicomponents.ToastState
+	if cmd := s.Show(tuicomponents.Toast{Title: "first"}, time.Second); cmd == nil {
+		t.Fatal("expected Show to return the expiry tick")
+	}
+	stale := s.ExpiredMsg()
+	s.Show(tuicomponents.Toast{Title: "second"}, time.Second)
+
+	if s.Expire(stale) || s.Current == nil || s.Current.Title != "second" {
+		t.Fatalf("expected a stale expiry to leave the newer toast, got %+v", s.Current)
+	}
+	if !s.Expire(s.ExpiredMsg()) || s.Current != nil {
+		t.Errorf("expected the toast's own expiry to clear it, got %+v", s.Current)
+	}
+}
+
+func TestToastStateOverlay(t *testing.T) {
+	p := tuicomponents.NewPalette()
+	background := strings.Repeat(strings.Repeat(".", 60)+"\n", 5)
+
+	var s tuicomponents.ToastState
+	if got := s.Overlay(p, background, 60, 5); got != background {
+		t.Error("expected no toast to leave the background untouched")
+	}
+	s.Show(tuicomponents.Toast{Title: "reinstalled tmux"}, time.Second)
+	if got := ansi.Strip(s.Overlay(p, background, 60, 5)); !strings.Contains(got, "reinstalled tmux") {
+		t.Errorf("expected the toast drawn over the background, got:\n%s", got)
+	}
+}
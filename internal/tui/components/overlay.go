@@ -21,16 +21,33 @@ func Overlay(background, popup string, width, height int) string {
 	if width <= 0 || height <= 0 {
 		return ""
 	}
-
 	popupLines, popupWidth, popupHeight := clipPopup(popup, width, height)
-	bgLines := canvasLines(background, width, height)
-
 	offsetX := max((width-popupWidth)/2, 0)
 	offsetY := max((height-popupHeight)/2, 0)
+	return stamp(background, popupLines, popupWidth, width, height, offsetX, offsetY)
+}
 
+// OverlayTopRight composites popup over background's top-right corner, one
+// cell in from the edges so a bordered pane's frame stays visible. It is how
+// notification toasts float over a live dashboard.
+func OverlayTopRight(background, popup string, width, height int) string {
+	if width <= 0 || height <= 0 {
+		return ""
+	}
+	popupLines, popupWidth, popupHeight := clipPopup(popup, width, height)
+	offsetX := max(width-popupWidth-1, 0)
+	offsetY := min(1, max(height-popupHeight, 0))
+	return stamp(background, popupLines, popupWidth, width, height, offsetX, offsetY)
+}
+
+// stamp writes the already-clipped popupLines into background at
+// (offsetX, offsetY). Background lines under the popup are cut at its left
+// edge and resumed after its right edge.
+func stamp(background string, popupLines []string, popupWidth, width, height, offsetX, offsetY int) string {
+	bgLines := canvasLines(background, width, height)
 	out := make([]string, height)
 	for i, bg := range bgLines {
-		if i < offsetY || i >= offsetY+popupHeight {
+		if i < offsetY || i >= offsetY+len(popupLines) {
 			out[i] = bg
 			continue
 		}
@@ -210,3 +210,22 @@ func TestOverlay_BackgroundShorterThanScreen(t *testing.T) {
 		t.Errorf("row 1 should still show popup content over blank padding: %q", lines[1])
 	}
 }
+
+func TestOverlayTopRight_KeepsFrameVisible(t *testing.T) {
+	background := strings.Join([]string{
+		"aaaaaaaaaa",
+		"aaaaaaaaaa",
+		"aaaaaaaaaa",
+		"aaaaaaaaaa",
+	}, "\n")
+
+	got := OverlayTopRight(background, "XXX\nXXX", 10, 4)
+	lines := strings.Split(got, "\n")
+
+	want := []string{"aaaaaaaaaa", "aaaaaaXXXa", "aaaaaaXXXa", "aaaaaaaaaa"}
+	for i, l := range lines {
+		if stripped := ansi.Strip(l); stripped != want[i] {
+			t.Errorf("row %d: got %q, want %q", i, stripped, want[i])
+		}
+	}
+}
@@ -6,13 +6,14 @@ type SessionState int
 
 const (
 	StateRunning     SessionState = iota
-	StateNeedsReview              // session finished, fired by Stop hook
+	StateNeedsReview              // agent idle or exited: waiting on the user
 	StateDirty                    // uncommitted changes, no active session
 	StateNoSession                // worktree exists, no tmux window
 )
 
-// SessionStateFromWorktree derives state from WorktreeStatus.
-// needsReview and dirtyCount are zero-valued until WorktreeStatus gains those fields.
+// SessionStateFromWorktree derives state from WorktreeStatus. needsReview is
+// whether the window's agent needs attention (worktree.AgentActivity);
+// dirtyCount is zero-valued until WorktreeStatus gains that field.
 func SessionStateFromWorktree(
 	s worktree.WorktreeStatus,
 	needsReview bool,
@@ -32,6 +32,18 @@ type model struct {
 	width, height int
 
 	palette *tuicomponents.Palette
+
+	// Repair actions (see repair_flow.go). repairer is nil in a read-only
+	// dashboard, which makes the repair keys no-ops.
+	repairer       repairer
+	selected       map[string]bool // itemKey -> in the bulk selection
+	pendingUntrack string          // targets armed by a first u press
+	repairing      bool
+	repairTotal    int
+	repairDone     int
+	repairFailed   int
+	lastRepairErr  error
+	toast          tuicomponents.ToastState
 }
 
 func newModel(items []inventory.Item, opts Options) model {
@@ -53,6 +65,7 @@ func newModel(items []inventory.Item, opts Options) model {
 		items:     items,
 		title:     title,
 		collapsed: map[string]bool{},
+		selected:  map[string]bool{},
 		groupMode: groupByCategory,
 		palette:   tuicomponents.NewPalette(),
 	}
@@ -81,6 +94,13 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		return m.handleKey(msg)
 	case tea.PasteMsg:
 		return m.handlePaste(msg.Content)
+	case repairDoneMsg:
+		return m.handleRepairDone(msg)
+	case tuicomponents.ToastExpiredMsg:
+		if m.toast.Expire(msg) {
+			m.pendingUntrack = ""
+		}
+		return m, nil
 	}
 	return m, nil
 }
@@ -118,6 +138,10 @@ func (m model) handleKey(msg tea.KeyPressMsg) (tea.Model, tea.Cmd) {
 		return m, nil
 	}
 
+	if key != "u" {
+		m.pendingUntrack = ""
+	}
+
 	switch key {
 	case "?":
 		m.showHelp = true
@@ -151,6 +175,21 @@ func (m model) handleKey(msg tea.KeyPressMsg) (tea.Model, tea.Cmd) {
 		}
 		m.collapsed = map[string]bool{}
 		m.rebuildRows()
+	case "r":
+		return m.startRepair(actionReinstall)
+	case "c":
+		return m.startRepair(actionReconfigure)
+	case "u":
+		return m.armUntrack()
+	case "m":
+		return m.startRepair(actionMarkPreExisting)
+	case "space":
+		m.toggleSelected()
+		m.moveCursor(1)
+	case "M":
+		m.selectMissing()
+	case "esc":
+		m.selected = map[string]bool{}
 	}
 	return m, nil
 }
@@ -219,7 +258,8 @@ func (m model) renderContent() string {
 		title += " · problems only"
 	}
 
-	return m.palette.BorderedPane(title, m.width, lines) + "\n" + summary + "\n" + hint
+	content := m.palette.BorderedPane(title, m.width, lines) + "\n" + summary + "\n" + hint
+	return m.toast.Overlay(m.palette, content, m.width, m.height)
 }
 
 func (m model) renderHelpOverlay() string {
@@ -229,6 +269,12 @@ func (m model) renderHelpOverlay() string {
 		{Key: "g", Desc: "group by category / by status"},
 		{Key: "p", Desc: "toggle problems only (MISSING/UNKNOWN)"},
 		{Key: "/", Desc: "filter by name  esc:clear  enter:keep"},
+		{Key: "r", Desc: "reinstall selected / current item"},
+		{Key: "c", Desc: "reconfigure (soft; edited files are kept)"},
+		{Key: "u u", Desc: "untrack (nothing is uninstalled)"},
+		{Key: "m", Desc: "mark as pre-existing"},
+		{Key: "space", Desc: "select / deselect item"},
+		{Key: "M", Desc: "select all MISSING items  esc:clear"},
 		{Key: "?", Desc: "toggle this help"},
 		{Key: "q / ctrl+c", Desc: "quit"},
 	}
@@ -285,21 +331,34 @@ func (m model) renderRow(i int) string {
 	}
 
 	glyph := statusGlyph(r.item.State)
-	name := "  " + glyph + " " + r.item.Name
+	marker := "  "
+	if m.selected[itemKey(r.item)] {
+		marker = "✓ "
+	}
+	name := marker + glyph + " " + r.item.Name
+	if r.item.Source == "pre-existing" {
+		name += " (pre-existing)"
+	}
+	// The install record sits flush right and is dropped entirely when it
+	// would collide with the name, rather than truncated mid-field.
+	details := itemDetails(r.item)
+	pad := innerWidth - ansi.StringWidth(name) - ansi.StringWidth(details)
+	if details == "" || pad < 2 {
+		details, pad = "", 0
+	}
 	if i == m.cursor {
-		plain := name
-		if r.item.Source == "pre-existing" {
-			plain += " (pre-existing)"
-		}
-		return m.palette.Selected.Render(plain)
+		return m.palette.Selected.Render(name + strings.Repeat(" ", pad) + details)
 	}
-	line := "  " + statusDot(
+	line := marker + statusDot(
 		m.palette,
 		r.item.State,
 	) + " " + r.item.Name + sourceTag(
 		m.palette,
 		r.item.Source,
 	)
+	if details != "" {
+		line += strings.Repeat(" ", pad) + m.palette.Inactive.Render(details)
+	}
 	return line
 }
 
@@ -318,6 +377,9 @@ func (m model) renderSummary(width int) string {
 		len(m.items),
 		missing,
 	)
+	if len(m.selected) > 0 {
+		text += fmt.Sprintf(" · %d SELECTED", len(m.selected))
+	}
 	return m.palette.SectionHead.Render(ansi.Truncate(text, width, ""))
 }
 
@@ -335,6 +397,8 @@ func (m model) renderHint(width int) string {
 		{Key: "/", Desc: "filter"},
 		{Key: "p", Desc: problemsDesc},
 		{Key: "g", Desc: "group"},
+		{Key: "r/c/u/m", Desc: "repair"},
+		{Key: "M", Desc: "select missing"},
 		{Key: "?", Desc: "help"},
 		{Key: "q", Desc: "quit"},
 	}
@@ -0,0 +1,155 @@
+package tuiinventory
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/cjairm/devgita/internal/apps"
+	"github.com/cjairm/devgita/internal/apps/registry"
+	"github.com/cjairm/devgita/internal/config"
+	"github.com/cjairm/devgita/internal/inventory"
+)
+
+// repairAction is one of the dashboard's repair keybindings.
+type repairAction int
+
+const (
+	actionReinstall repairAction = iota
+	actionReconfigure
+	actionUntrack
+	actionMarkPreExisting
+)
+
+// progressVerb is shown in the toast while the action runs.
+func (a repairAction) progressVerb() string {
+	switch a {
+	case actionReinstall:
+		return "reinstalling"
+	case actionReconfigure:
+		return "reconfiguring"
+	case actionUntrack:
+		return "untracking"
+	default:
+		return "marking pre-existing"
+	}
+}
+
+// needsTerminal reports whether the action runs an app's install or
+// configure step, which may shell out to package managers that prompt (sudo)
+// and so gets the terminal back from the dashboard while it runs.
+func (a repairAction) needsTerminal() bool {
+	return a == actionReinstall || a == actionReconfigure
+}
+
+// doneVerb is shown in the toast once the action succeeded.
+func (a repairAction) doneVerb() string {
+	switch a {
+	case actionReinstall:
+		return "reinstalled"
+	case actionReconfigure:
+		return "reconfigured"
+	case actionUntrack:
+		return "untracked"
+	default:
+		return "marked pre-existing"
+	}
+}
+
+// repairer runs a repair against one tracked item and re-collects its state
+// afterwards. The dashboard calls it from tea.Cmds (tea.Exec for the
+// terminal-bound actions), never from Update.
+type repairer interface {
+	Repair(action repairAction, item inventory.Item) error
+	// Recheck returns the item's fresh state, or false once it is no longer
+	// tracked (e.g. after untrack).
+	Recheck(item inventory.Item) (inventory.Item, bool)
+}
+
+// registryRepairer repairs through the registry app factories, the same
+// ones dg install / configure use, and edits global_config.yaml directly for
+// the tracking-only actions.
+type registryRepairer struct {
+	collector *inventory.Collector
+	getApp    func(name string) (apps.App, error)
+}
+
+func newRegistryRepairer(c *inventory.Collector) *registryRepairer {
+	return &registryRepairer{collector: c, getApp: registry.GetApp}
+}
+
+func (r *registryRepairer) Repair(action repairAction, item inventory.Item) error {
+	switch action {
+	case actionReinstall:
+		app, err := r.app(item)
+		if err != nil {
+			return err
+		}
+		// Install, not ForceInstall: uninstalling an item that is already
+		// MISSING fails before the install ever runs.
+		return app.Install()
+	case actionReconfigure:
+		app, err := r.app(item)
+		if err != nil {
+			return err
+		}
+		// Soft, so files the user edited are left alone; `dg configure
+		// --force` remains the way to reset them (with backups).
+		if err := app.SoftConfigure(); errors.Is(err, apps.ErrConfigureNotSupported) {
+			return fmt.Errorf("%s has nothing to configure", item.Name)
+		} else if err != nil {
+			return err
+		}
+		return nil
+	case actionUntrack:
+		return updateConfig(func(gc *config.GlobalConfig) error {
+			if !gc.Untrack(item.Name, inventory.ItemType(item.Category), inventory.ConfigType(item.Source)) {
+				return fmt.Errorf("%s is not tracked", item.Name)
+			}
+			return nil
+		})
+	case actionMarkPreExisting:
+		if item.Source == "pre-existing" {
+			return fmt.Errorf("%s is already pre-existing", item.Name)
+		}
+		return updateConfig(func(gc *config.GlobalConfig) error {
+			if !gc.MarkAlreadyInstalled(item.Name, inventory.ItemType(item.Category)) {
+				return fmt.Errorf("%s is not tracked as installed", item.Name)
+			}
+			return nil
+		})
+	}
+	return fmt.Errorf("unknown repair action %d", action)
+}
+
+// app resolves the registry app behind an item; fonts, languages, and
+// databases have none, so they can only be untracked or re-marked here.
+func (r *registryRepairer) app(item inventory.Item) (apps.App, error) {
+	if !registry.IsKnownApp(item.Name) {
+		return nil, fmt.Errorf("%s has no devgita app to run; use dg install", item.Name)
+	}
+	return r.getApp(item.Name)
+}
+
+func (r *registryRepairer) Recheck(item inventory.Item) (inventory.Item, bool) {
+	gc := &config.GlobalConfig{}
+	if err := gc.Load(); err != nil {
+		// Keep showing what we had rather than dropping the row.
+		item.State, item.Detail = inventory.StateUnknown, err.Error()
+		return item, true
+	}
+	return r.collector.Recheck(gc, item.Name, item.Category)
+}
+
+func updateConfig(edit func(gc *config.GlobalConfig) error) error {
+	gc := &config.GlobalConfig{}
+	if err := gc.Load(); err != nil {
+		return fmt.Errorf("failed to load global config: %w", err)
+	}
+	if err := edit(gc); err != nil {
+		return err
+	}
+	if err := gc.Save(); err != nil {
+		return fmt.Errorf("failed to save global config: %w", err)
+	}
+	return nil
+}
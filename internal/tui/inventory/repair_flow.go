@@ -0,0 +1,309 @@
+package tuiinventory
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+
+	tea "charm.land/bubbletea/v2"
+
+	"github.com/cjairm/devgita/internal/inventory"
+	tuicomponents "github.com/cjairm/devgita/internal/tui/components"
+	"github.com/cjairm/devgita/pkg/logger"
+)
+
+// toastDuration is how long a finished repair's toast stays up.
+const toastDuration = 4 * time.Second
+
+// repairDoneMsg reports one finished item of a (possibly bulk) repair run.
+// rest carries the items still queued, so the run proceeds one item at a
+// time and the toast can count through them.
+type repairDoneMsg struct {
+	action  repairAction
+	item    inventory.Item // the item as it was when the repair started
+	err     error
+	fresh   inventory.Item // re-collected state; ignored when !tracked
+	tracked bool
+	rest    []inventory.Item
+}
+
+// itemKey identifies an item row across re-collection.
+func itemKey(it inventory.Item) string {
+	return it.Category + "/" + it.Source + "/" + it.Name
+}
+
+// repairTargets returns the items a repair key acts on: the bulk selection
+// when there is one, otherwise the item under the cursor.
+func (m model) repairTargets() []inventory.Item {
+	if len(m.selected) > 0 {
+		var targets []inventory.Item
+		for _, it := range m.items {
+			if m.selected[itemKey(it)] {
+				targets = append(targets, it)
+			}
+		}
+		return targets
+	}
+	if m.cursor < 0 || m.cursor >= len(m.rows) || m.rows[m.cursor].kind != rowItem {
+		return nil
+	}
+	return []inventory.Item{m.rows[m.cursor].item}
+}
+
+// startRepair kicks off action over the current targets. Only one run is in
+// flight at a time; repair keys are ignored until it finishes.
+func (m model) startRepair(action repairAction) (tea.Model, tea.Cmd) {
+	if m.repairing || m.repairer == nil {
+		return m, nil
+	}
+	targets := m.repairTargets()
+	if len(targets) == 0 {
+		return m, nil
+	}
+	m.repairing = true
+	m.repairTotal = len(targets)
+	m.repairDone = 0
+	m.repairFailed = 0
+	m.lastRepairErr = nil
+	m.showProgress(action, targets[0])
+	return m, m.repairCmd(action, targets[0], targets[1:])
+}
+
+// execRepair hands a terminal-bound repair to the Bubble Tea runtime; tests
+// swap it for one that runs the repair inline.
+var execRepair = tea.Exec
+
+// repairCmd runs action on item. The tracking-only actions just edit
+// global_config.yaml, so they run as a plain tea.Cmd; reinstall and
+// reconfigure run through execRepair, which releases the alt screen for
+// their duration (see repairExec).
+func (m model) repairCmd(action repairAction, item inventory.Item, rest []inventory.Item) tea.Cmd {
+	r := m.repairer
+	if action.needsTerminal() {
+		run := &repairExec{repairer: r, action: action, item: item, rest: rest}
+		return execRepair(run, func(error) tea.Msg { return run.done })
+	}
+	return func() tea.Msg {
+		return runRepair(r, action, item, rest)
+	}
+}
+
+// runRepair runs one repair and re-collects the item's state afterwards.
+func runRepair(r repairer, action repairAction, item inventory.Item, rest []inventory.Item) repairDoneMsg {
+	err := r.Repair(action, item)
+	fresh, tracked := r.Recheck(item)
+	return repairDoneMsg{
+		action:  action,
+		item:    item,
+		err:     err,
+		fresh:   fresh,
+		tracked: tracked,
+		rest:    rest,
+	}
+}
+
+// repairExec adapts one repair to tea.ExecCommand. While it runs the
+// dashboard has handed the terminal back, so a sudo password prompt from a
+// package manager is usable, but the app's own progress output is captured
+// rather than printed over the screen the dashboard is about to redraw: it
+// goes to the debug log, and its last line into the error when the repair
+// fails.
+type repairExec struct {
+	repairer repairer
+	action   repairAction
+	item     inventory.Item
+	rest     []inventory.Item
+	done     repairDoneMsg
+}
+
+func (e *repairExec) Run() error {
+	output, err := captureOutput(func() error {
+		e.done = runRepair(e.repairer, e.action, e.item, e.rest)
+		return e.done.err
+	})
+	logger.L().Debugw("repair output", "item", e.item.Name, "output", output)
+	if err != nil {
+		if last := lastLine(output); last != "" {
+			e.done.err = fmt.Errorf("%w (%s)", err, last)
+		}
+	}
+	return nil
+}
+
+// The runtime's streams are ignored: the app writes to os.Stdout/os.Stderr
+// directly, which captureOutput redirects.
+func (e *repairExec) SetStdin(io.Reader)  {}
+func (e *repairExec) SetStdout(io.Writer) {}
+func (e *repairExec) SetStderr(io.Writer) {}
+
+// captureOutput runs fn with os.Stdout and os.Stderr pointed at a temp file
+// and returns what was written there. If the file can't be created, fn runs
+// uncaptured.
+func captureOutput(fn func() error) (string, error) {
+	f, err := os.CreateTemp("", "devgita-repair-*.log")
+	if err != nil {
+		return "", fn()
+	}
+	defer os.Remove(f.Name())
+	defer f.Close()
+
+	stdout, stderr := os.Stdout, os.Stderr
+	os.Stdout, os.Stderr = f, f
+	func() {
+		defer func() { os.Stdout, os.Stderr = stdout, stderr }()
+		err = fn()
+	}()
+
+	data, _ := os.ReadFile(f.Name())
+	return string(data), err
+}
+
+// lastLine is the last non-blank line of output, trimmed.
+func lastLine(output string) string {
+	lines := strings.Split(strings.TrimSpace(output), "\n")
+	return strings.TrimSpace(lines[len(lines)-1])
+}
+
+func (m *model) showProgress(action repairAction, item inventory.Item) {
+	title := fmt.Sprintf("%s %s…", action.progressVerb(), item.Name)
+	if m.repairTotal > 1 {
+		title = fmt.Sprintf(
+			"%s %d/%d: %s…",
+			action.progressVerb(), m.repairDone+1, m.repairTotal, item.Name,
+		)
+	}
+	// The progress toast's tick is dropped, so it stays up until the next
+	// toast replaces it.
+	m.toast.Show(tuicomponents.Toast{Kind: tuicomponents.ToastInfo, Title: title}, toastDuration)
+}
+
+func (m model) handleRepairDone(msg repairDoneMsg) (tea.Model, tea.Cmd) {
+	m.repairDone++
+	m.applyRecheck(msg.item, msg.fresh, msg.tracked)
+	delete(m.selected, itemKey(msg.item))
+	if msg.err != nil {
+		m.repairFailed++
+		m.lastRepairErr = fmt.Errorf("%s: %w", msg.item.Name, msg.err)
+	}
+	m.rebuildRows()
+
+	if len(msg.rest) > 0 {
+		m.showProgress(msg.action, msg.rest[0])
+		return m, m.repairCmd(msg.action, msg.rest[0], msg.rest[1:])
+	}
+
+	m.repairing = false
+	return m, m.toast.Show(m.repairSummary(msg.action, msg.item), toastDuration)
+}
+
+// repairSummary builds the final toast of a run.
+func (m model) repairSummary(action repairAction, last inventory.Item) tuicomponents.Toast {
+	if m.repairTotal == 1 {
+		if m.lastRepairErr != nil {
+			return tuicomponents.Toast{
+				Kind:  tuicomponents.ToastError,
+				Title: fmt.Sprintf("%s failed", action.progressVerb()),
+				Body:  m.lastRepairErr.Error(),
+			}
+		}
+		return tuicomponents.Toast{
+			Kind:  tuicomponents.ToastInfo,
+			Title: fmt.Sprintf("%s %s", action.doneVerb(), last.Name),
+		}
+	}
+	t := tuicomponents.Toast{
+		Kind: tuicomponents.ToastInfo,
+		Title: fmt.Sprintf(
+			"%s %d/%d items",
+			action.doneVerb(), m.repairTotal-m.repairFailed, m.repairTotal,
+		),
+	}
+	if m.lastRepairErr != nil {
+		t.Kind = tuicomponents.ToastError
+		t.Body = m.lastRepairErr.Error()
+	}
+	return t
+}
+
+// applyRecheck replaces old in m.items with its re-collected state, or drops
+// it once it is no longer tracked.
+func (m *model) applyRecheck(old, fresh inventory.Item, tracked bool) {
+	key := itemKey(old)
+	items := make([]inventory.Item, 0, len(m.items))
+	for _, it := range m.items {
+		if itemKey(it) != key {
+			items = append(items, it)
+			continue
+		}
+		if tracked {
+			items = append(items, fresh)
+		}
+	}
+	m.items = items
+}
+
+// toggleSelected flips the bulk selection of the item under the cursor.
+func (m *model) toggleSelected() {
+	if m.cursor < 0 || m.cursor >= len(m.rows) || m.rows[m.cursor].kind != rowItem {
+		return
+	}
+	key := itemKey(m.rows[m.cursor].item)
+	if m.selected[key] {
+		delete(m.selected, key)
+	} else {
+		m.selected[key] = true
+	}
+}
+
+// selectMissing selects every MISSING item, or clears the selection when
+// they are all selected already, so the key toggles.
+func (m *model) selectMissing() {
+	var missing []string
+	allSelected := true
+	for _, it := range m.items {
+		if it.State != inventory.StateMissing {
+			continue
+		}
+		missing = append(missing, itemKey(it))
+		if !m.selected[itemKey(it)] {
+			allSelected = false
+		}
+	}
+	if allSelected {
+		m.selected = map[string]bool{}
+		return
+	}
+	for _, key := range missing {
+		m.selected[key] = true
+	}
+}
+
+// armUntrack implements untrack's confirm-by-repeat: the first u arms the
+// targets, a second u on the same targets runs it.
+func (m model) armUntrack() (tea.Model, tea.Cmd) {
+	targets := m.repairTargets()
+	if m.repairing || len(targets) == 0 {
+		return m, nil
+	}
+	key := ""
+	for _, it := range targets {
+		key += itemKey(it) + "\n"
+	}
+	if m.pendingUntrack != key {
+		m.pendingUntrack = key
+		what := targets[0].Name
+		if len(targets) > 1 {
+			what = fmt.Sprintf("%d items", len(targets))
+		}
+		return m, m.toast.Show(tuicomponents.Toast{
+			Kind:   tuicomponents.ToastNeedsReview,
+			Title:  fmt.Sprintf("untrack %s?", what),
+			Body:   "devgita forgets it; nothing is uninstalled",
+			Action: "u again to confirm",
+		}, toastDuration)
+	}
+	m.pendingUntrack = ""
+	return m.startRepair(actionUntrack)
+}
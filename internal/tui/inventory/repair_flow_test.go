@@ -0,0 +1,241 @@
+package tuiinventory
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"testing"
+
+	tea "charm.land/bubbletea/v2"
+
+	"github.com/cjairm/devgita/internal/inventory"
+	tuicomponents "github.com/cjairm/devgita/internal/tui/components"
+)
+
+// fakeRepairer records repairs and reports every repaired item as OK
+// afterwards (or untracked, for untrack).
+type fakeRepairer struct {
+	calls []string
+	fail  map[string]error
+}
+
+func (f *fakeRepairer) Repair(action repairAction, item inventory.Item) error {
+	f.calls = append(f.calls, action.progressVerb()+" "+item.Name)
+	return f.fail[item.Name]
+}
+
+func (f *fakeRepairer) Recheck(item inventory.Item) (inventory.Item, bool) {
+	last := f.calls[len(f.calls)-1]
+	if strings.HasPrefix(last, "untracking") {
+		return inventory.Item{}, false
+	}
+	if f.fail[item.Name] == nil {
+		item.State = inventory.StateOK
+	}
+	return item, true
+}
+
+// execInline stands in for tea.Exec: it runs the repair on the spot, as the
+// runtime does once it has released the terminal.
+func execInline(c tea.ExecCommand, fn tea.ExecCallback) tea.Cmd {
+	return func() tea.Msg { return fn(c.Run()) }
+}
+
+func init() { execRepair = execInline }
+
+func repairModel(r repairer) model {
+	items := append(testItems(), inventory.Item{
+		Name: "lazygit", Category: "packages", Source: "installed", State: inventory.StateMissing,
+	})
+	m := newModel(items, Options{})
+	m.width, m.height = 80, 24
+	m.repairer = r
+	return m
+}
+
+// press sends key and runs the repair commands it starts until the run
+// finishes, like the Bubble Tea runtime would.
+func press(t *testing.T, m model, key tea.KeyPressMsg) model {
+	t.Helper()
+	next, cmd := m.Update(key)
+	m = next.(model)
+	for cmd != nil && m.repairing {
+		msg, ok := cmd().(repairDoneMsg)
+		if !ok {
+			break
+		}
+		next, cmd = m.Update(msg)
+		m = next.(model)
+	}
+	return m
+}
+
+func cursorTo(t *testing.T, m model, name string) model {
+	t.Helper()
+	for i, r := range m.rows {
+		if r.kind == rowItem && r.item.Name == name {
+			m.cursor = i
+			return m
+		}
+	}
+	t.Fatalf("no row for %s", name)
+	return m
+}
+
+func itemNamed(m model, name string) (inventory.Item, bool) {
+	for _, it := range m.items {
+		if it.Name == name {
+			return it, true
+		}
+	}
+	return inventory.Item{}, false
+}
+
+func TestRepair_ReinstallRechecksRow(t *testing.T) {
+	r := &fakeRepairer{}
+	m := cursorTo(t, repairModel(r), "tmux")
+
+	m = press(t, m, tea.KeyPressMsg{Code: 'r'})
+
+	if len(r.calls) != 1 || r.calls[0] != "reinstalling tmux" {
+		t.Fatalf("calls = %v, want a single tmux reinstall", r.calls)
+	}
+	if it, _ := itemNamed(m, "tmux"); it.State != inventory.StateOK {
+		t.Errorf("tmux state = %v, want OK after re-collect", it.State)
+	}
+	if m.repairing || m.toast.Current == nil || m.toast.Current.Title != "reinstalled tmux" {
+		t.Errorf("toast = %+v, want the finished reinstall", m.toast.Current)
+	}
+	if !strings.Contains(m.renderContent(), "reinstalled tmux") {
+		t.Error("the toast should be drawn over the dashboard")
+	}
+}
+
+func TestRepair_ProgressToastWhileRunning(t *testing.T) {
+	m := cursorTo(t, repairModel(&fakeRepairer{}), "tmux")
+
+	next, cmd := m.Update(tea.KeyPressMsg{Code: 'c'})
+	m = next.(model)
+
+	if cmd == nil || !m.repairing {
+		t.Fatal("c should start a reconfigure")
+	}
+	if m.toast.Current == nil || m.toast.Current.Title != "reconfiguring tmux…" {
+		t.Errorf("toast = %+v, want progress for tmux", m.toast.Current)
+	}
+	if _, cmd := m.Update(tea.KeyPressMsg{Code: 'r'}); cmd != nil {
+		t.Error("repair keys should be ignored while a repair is running")
+	}
+}
+
+func TestRepair_BulkMissingContinuesPastFailure(t *testing.T) {
+	r := &fakeRepairer{fail: map[string]error{"lazygit": errors.New("download failed")}}
+	m := repairModel(r)
+
+	m = press(t, m, tea.KeyPressMsg{Code: 'M'})
+	if len(m.selected) != 2 {
+		t.Fatalf("M should select both MISSING items, got %v", m.selected)
+	}
+	m = press(t, m, tea.KeyPressMsg{Code: 'r'})
+
+	if len(r.calls) != 2 {
+		t.Fatalf("calls = %v, want both MISSING items reinstalled", r.calls)
+	}
+	if it, _ := itemNamed(m, "tmux"); it.State != inventory.StateOK {
+		t.Errorf("tmux should be OK after the bulk repair, got %v", it.State)
+	}
+	if m.toast.Current == nil || m.toast.Current.Kind != tuicomponents.ToastError ||
+		m.toast.Current.Title != "reinstalled 1/2 items" ||
+		!strings.Contains(m.toast.Current.Body, "download failed") {
+		t.Errorf("toast = %+v, want a 1/2 summary naming the failure", m.toast.Current)
+	}
+	if len(m.selected) != 0 {
+		t.Errorf("repaired items should leave the selection, got %v", m.selected)
+	}
+}
+
+func TestRepair_UntrackNeedsConfirmation(t *testing.T) {
+	r := &fakeRepairer{}
+	m := cursorTo(t, repairModel(r), "git")
+
+	m = press(t, m, tea.KeyPressMsg{Code: 'u'})
+	if len(r.calls) != 0 {
+		t.Fatal("the first u should only arm the untrack")
+	}
+	if m.toast.Current == nil || m.toast.Current.Action != "u again to confirm" {
+		t.Errorf("toast = %+v, want a confirmation prompt", m.toast.Current)
+	}
+
+	m = press(t, m, tea.KeyPressMsg{Code: 'u'})
+
+	if len(r.calls) != 1 {
+		t.Fatalf("calls = %v, want the untrack to run", r.calls)
+	}
+	if _, ok := itemNamed(m, "git"); ok {
+		t.Error("an untracked item should drop out of the dashboard")
+	}
+}
+
+func TestRepair_OtherKeyDisarmsUntrack(t *testing.T) {
+	r := &fakeRepairer{}
+	m := cursorTo(t, repairModel(r), "git")
+
+	m = press(t, m, tea.KeyPressMsg{Code: 'u'})
+	m = press(t, m, tea.KeyPressMsg{Code: 'g'})
+	m = press(t, m, tea.KeyPressMsg{Code: 'u'})
+
+	if len(r.calls) != 0 {
+		t.Errorf("calls = %v, want the untrack re-armed, not run", r.calls)
+	}
+}
+
+func TestRepair_ToastExpiresOnlyForItsOwnSeq(t *testing.T) {
+	m := cursorTo(t, repairModel(&fakeRepairer{}), "tmux")
+	m = press(t, m, tea.KeyPressMsg{Code: 'r'})
+
+	next, _ := m.Update(m.toast.ExpiredMsg() - 1)
+	if next.(model).toast.Current == nil {
+		t.Error("a stale expiry must not clear the current toast")
+	}
+	next, _ = m.Update(m.toast.ExpiredMsg())
+	if next.(model).toast.Current != nil {
+		t.Error("the toast should clear once its own timer fires")
+	}
+}
+
+// printingRepairer writes to stdout like an app's install step does, then
+// fails.
+type printingRepairer struct{ fakeRepairer }
+
+func (p *printingRepairer) Repair(action repairAction, item inventory.Item) error {
+	fmt.Println("Installing " + item.Name + "...")
+	fmt.Fprintln(os.Stderr, "E: Unable to locate package "+item.Name)
+	return p.fakeRepairer.Repair(action, item)
+}
+
+func TestRepair_TerminalActionsRunThroughExecAndCaptureOutput(t *testing.T) {
+	var execs int
+	execRepair = func(c tea.ExecCommand, fn tea.ExecCallback) tea.Cmd {
+		execs++
+		return execInline(c, fn)
+	}
+	t.Cleanup(func() { execRepair = execInline })
+
+	r := &printingRepairer{fakeRepairer{fail: map[string]error{"tmux": errors.New("exit status 100")}}}
+	m := cursorTo(t, repairModel(r), "tmux")
+	m = press(t, m, tea.KeyPressMsg{Code: 'r'})
+
+	if execs != 1 {
+		t.Fatalf("reinstall should hand the terminal over through tea.Exec, got %d execs", execs)
+	}
+	want := "exit status 100 (E: Unable to locate package tmux)"
+	if m.toast.Current == nil || !strings.Contains(m.toast.Current.Body, want) {
+		t.Errorf("toast = %+v, want the error with the captured output's last line", m.toast.Current)
+	}
+
+	m = press(t, m, tea.KeyPressMsg{Code: 'm'})
+	if execs != 1 {
+		t.Errorf("marking pre-existing only edits the config and shouldn't take the terminal")
+	}
+}
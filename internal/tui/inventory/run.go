@@ -13,6 +13,7 @@ func Run(gc *config.GlobalConfig, opts Options) error {
 	c := &inventory.Collector{Cmd: commands.NewCommand(), Base: commands.NewBaseCommand()}
 	items := c.Collect(gc)
 	m := newModel(items, opts)
+	m.repairer = newRegistryRepairer(c)
 	p := tea.NewProgram(m)
 	_, err := p.Run()
 	return err
@@ -1,6 +1,8 @@
 package tuiinventory
 
 import (
+	"strings"
+
 	"github.com/cjairm/devgita/internal/inventory"
 	tuicomponents "github.com/cjairm/devgita/internal/tui/components"
 )
@@ -37,3 +39,23 @@ func sourceTag(p *tuicomponents.Palette, source string) string {
 	}
 	return p.Inactive.Render(" (pre-existing)")
 }
+
+// itemDetails renders the install record shown right-aligned on an item row:
+// version, method, install date, and the devgita version that tracked it,
+// skipping whatever was never recorded (items tracked before schema v2).
+func itemDetails(item inventory.Item) string {
+	var parts []string
+	if item.Version != "" {
+		parts = append(parts, item.Version)
+	}
+	if item.Method != "" {
+		parts = append(parts, item.Method)
+	}
+	if !item.InstalledAt.IsZero() {
+		parts = append(parts, item.InstalledAt.Local().Format("2006-01-02"))
+	}
+	if item.DevgitaVersion != "" {
+		parts = append(parts, "dg "+item.DevgitaVersion)
+	}
+	return strings.Join(parts, " · ")
+}
@@ -3,6 +3,7 @@ package tuiinventory
 import (
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/cjairm/devgita/internal/inventory"
 	tuicomponents "github.com/cjairm/devgita/internal/tui/components"
@@ -49,3 +50,21 @@ func TestSourceTag_PreExistingIsTagged(t *testing.T) {
 		t.Errorf("installed items should have no tag, got %q", got)
 	}
 }
+
+func TestItemDetails_SkipsUnrecordedFields(t *testing.T) {
+	full := inventory.Item{
+		Version:        "3.4",
+		Method:         "brew",
+		InstalledAt:    time.Date(2026, 3, 1, 12, 0, 0, 0, time.Local),
+		DevgitaVersion: "v1.2.0",
+	}
+	if got, want := itemDetails(full), "3.4 · brew · 2026-03-01 · dg v1.2.0"; got != want {
+		t.Errorf("got %q, want %q", got, want)
+	}
+	if got := itemDetails(inventory.Item{Method: "apt"}); got != "apt" {
+		t.Errorf("expected only the recorded method, got %q", got)
+	}
+	if got := itemDetails(inventory.Item{}); got != "" {
+		t.Errorf("expected no details for a v1 item, got %q", got)
+	}
+}
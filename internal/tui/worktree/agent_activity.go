@@ -0,0 +1,163 @@
+package tuiworktree
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	tea "charm.land/bubbletea/v2"
+
+	"github.com/cjairm/devgita/internal/tooling/worktree"
+	tuicomponents "github.com/cjairm/devgita/internal/tui/components"
+)
+
+// toastDuration is how long an agent-settled toast stays up.
+const toastDuration = 6 * time.Second
+
+// agentSamplesMsg carries one round of agent-pane samples, keyed by worktree
+// path. A worktree with no window, or whose pane couldn't be read, has no
+// entry; the latter are listed in unreadable instead (under Zellij that's
+// every windowed worktree). at is when the round was taken, and is also the
+// "now" the row badges measure time-since-activity against, so they only
+// move when new samples land.
+type agentSamplesMsg struct {
+	at         time.Time
+	samples    map[string]worktree.AgentSample
+	unreadable map[string]bool
+}
+
+// agentSampleCmd samples every windowed worktree's agent pane. It's
+// dispatched with each statuses refresh (so on the same 3s cadence as the
+// list) and runs off the Update goroutine, since it's a few tmux calls per
+// worktree; the samples are folded into m.agents back in Update.
+func (m Model) agentSampleCmd(statuses []worktree.WorktreeStatus) tea.Cmd {
+	sample := m.sampleAgentFn
+	return func() tea.Msg {
+		samples := map[string]worktree.AgentSample{}
+		unreadable := map[string]bool{}
+		for _, s := range statuses {
+			if !s.WindowActive {
+				continue
+			}
+			if got, err := sample(s); err == nil {
+				samples[s.Path] = got
+			} else {
+				unreadable[s.Path] = true
+			}
+		}
+		return agentSamplesMsg{at: time.Now(), samples: samples, unreadable: unreadable}
+	}
+}
+
+// handleAgentSamples feeds a sampling round into the tracker and toasts the
+// agents that just stopped working. A worktree missing from the round is
+// forgotten, so a rebuilt window starts over from a fresh first sample.
+func (m Model) handleAgentSamples(msg agentSamplesMsg) (tea.Model, tea.Cmd) {
+	m.agentsSampledAt = msg.at
+	m.agentsUnreadable = msg.unreadable
+	var settled []worktree.WorktreeStatus
+	for _, s := range m.statuses {
+		sample, ok := msg.samples[s.Path]
+		if !ok {
+			m.agents.Forget(s.Path)
+			continue
+		}
+		if _, stopped := m.agents.Observe(s.Path, sample, msg.at); stopped {
+			settled = append(settled, s)
+		}
+	}
+	if m.attentionOnly {
+		m.rebuildRows()
+	}
+	if len(settled) == 0 {
+		return m, nil
+	}
+	return m, m.toast.Show(m.settledToast(settled), toastDuration)
+}
+
+// settledToast describes the agents that just went idle or exited.
+func (m Model) settledToast(settled []worktree.WorktreeStatus) tuicomponents.Toast {
+	t := tuicomponents.Toast{
+		Kind:   tuicomponents.ToastNeedsReview,
+		Action: "a: show agents needing attention",
+	}
+	if len(settled) == 1 {
+		s := settled[0]
+		t.Title = s.Name + " is waiting for you"
+		if m.agents.Status(s.Path).Activity == worktree.AgentExited {
+			t.Title = s.Name + "'s agent exited"
+		}
+		t.Body = s.Repo
+		return t
+	}
+	names := make([]string, len(settled))
+	for i, s := range settled {
+		names[i] = s.Name
+	}
+	t.Title = fmt.Sprintf("%d agents need attention", len(settled))
+	t.Body = strings.Join(names, ", ")
+	return t
+}
+
+// agentStatus is s's latest agent status; AgentUnknown for a worktree with
+// no window, whatever the tracker last saw.
+func (m Model) agentStatus(s worktree.WorktreeStatus) worktree.AgentStatus {
+	if !s.WindowActive {
+		return worktree.AgentStatus{}
+	}
+	return m.agents.Status(s.Path)
+}
+
+// needsAttention is the "a" filter's predicate: the worktree's agent is idle
+// or has exited.
+func (m Model) needsAttention(s worktree.WorktreeStatus) bool {
+	return m.agentStatus(s).Activity.NeedsAttention()
+}
+
+// agentBadge is the right-aligned activity label of s's row: "working",
+// "idle 4m" or "exited 12s" (time since the pane last changed), "unknown"
+// when the pane couldn't be sampled, or "" while the activity isn't known
+// yet.
+func (m Model) agentBadge(s worktree.WorktreeStatus) string {
+	if s.WindowActive && m.agentsUnreadable[s.Path] {
+		return "unknown"
+	}
+	status := m.agentStatus(s)
+	switch status.Activity {
+	case worktree.AgentWorking:
+		return status.Activity.String()
+	case worktree.AgentIdle, worktree.AgentExited:
+		return status.Activity.String() + " " + formatAge(m.agentsSampledAt.Sub(status.Since))
+	}
+	return ""
+}
+
+// formatAge renders d in its largest whole unit: 45s, 4m, 2h, 3d.
+func formatAge(d time.Duration) string {
+	switch {
+	case d < time.Minute:
+		return fmt.Sprintf("%ds", int(max(d, 0).Seconds()))
+	case d < time.Hour:
+		return fmt.Sprintf("%dm", int(d.Minutes()))
+	case d < 24*time.Hour:
+		return fmt.Sprintf("%dh", int(d.Hours()))
+	default:
+		return fmt.Sprintf("%dd", int(d.Hours()/24))
+	}
+}
+
+// handleToggleAttention flips the "a" filter between every row and only the
+// worktrees whose agent needs attention.
+func (m Model) handleToggleAttention() (tea.Model, tea.Cmd) {
+	m.attentionOnly = !m.attentionOnly
+	m.rebuildRows()
+	if m.attentionOnly {
+		m.status = "showing agents that need attention (a shows all)"
+	} else {
+		m.status = "showing all worktrees"
+	}
+	if sel, ok := m.selectedStatus(); ok {
+		return m, m.selectionChangedCmd(sel)
+	}
+	return m, nil
+}
@@ -0,0 +1,172 @@
+package tuiworktree
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	tea "charm.land/bubbletea/v2"
+	"github.com/charmbracelet/x/ansi"
+
+	"github.com/cjairm/devgita/internal/tooling/worktree"
+)
+
+// sampleRound feeds one agentSamplesMsg taken at at, with a live sample of
+// fingerprint fp for each of paths.
+func sampleRound(m Model, at time.Time, fp uint64, paths ...string) (Model, tea.Cmd) {
+	samples := map[string]worktree.AgentSample{}
+	for _, p := range paths {
+		samples[p] = worktree.AgentSample{Command: "claude", Fingerprint: fp}
+	}
+	updated, cmd := m.Update(agentSamplesMsg{at: at, samples: samples})
+	return updated.(Model), cmd
+}
+
+func TestStatusesRefreshSamplesWindowedAgents(t *testing.T) {
+	var sampled []string
+	m := makeTestModel(testStatuses())
+	m.sampleAgentFn = func(s worktree.WorktreeStatus) (worktree.AgentSample, error) {
+		sampled = append(sampled, s.Path)
+		return worktree.AgentSample{Fingerprint: 1}, nil
+	}
+
+	_, cmd := m.Update(statusesMsg(testStatuses()))
+	var got *agentSamplesMsg
+	for _, msg := range flattenCmd(cmd) {
+		if v, ok := msg.(agentSamplesMsg); ok {
+			got = &v
+		}
+	}
+	if got == nil {
+		t.Fatal("expected a statuses refresh to dispatch an agent sampling round")
+	}
+	for _, s := range testStatuses() {
+		_, inRound := got.samples[s.Path]
+		if inRound != s.WindowActive {
+			t.Errorf("%s: expected sampled=%v (window active), got %v", s.Name, s.WindowActive, inRound)
+		}
+	}
+	if len(sampled) != len(got.samples) {
+		t.Errorf("expected one sample per windowed worktree, got %v", sampled)
+	}
+}
+
+func TestAgentGoingIdleShowsToastAndBadge(t *testing.T) {
+	m := makeTestModel(testStatuses())
+	start := time.Date(2026, 10, 16, 9, 0, 0, 0, time.UTC)
+
+	m, _ = sampleRound(m, start, 1, "/tmp/a")
+	m, _ = sampleRound(m, start.Add(3*time.Second), 2, "/tmp/a")
+	if !strings.Contains(ansi.Strip(m.renderLeft(40)), "working") {
+		t.Errorf("expected a working badge, got:\n%s", ansi.Strip(m.renderLeft(40)))
+	}
+	if m.toast.Current != nil {
+		t.Fatal("expected no toast while the agent is working")
+	}
+
+	m, cmd := sampleRound(m, start.Add(3*time.Second+worktree.AgentIdleAfter), 2, "/tmp/a")
+	if m.toast.Current == nil || !strings.Contains(m.toast.Current.Title, "feature-a") {
+		t.Fatalf("expected a toast naming feature-a, got %+v", m.toast.Current)
+	}
+	if cmd == nil {
+		t.Fatal("expected the toast to schedule its expiry")
+	}
+	if left := ansi.Strip(m.renderLeft(40)); !strings.Contains(left, "idle 5s") {
+		t.Errorf("expected an idle badge with the time since activity, got:\n%s", left)
+	}
+	if !strings.Contains(ansi.Strip(m.renderContent()), "feature-a is waiting") {
+		t.Error("expected the toast to be drawn over the dashboard")
+	}
+
+	updated, _ := m.Update(m.toast.ExpiredMsg())
+	if updated.(Model).toast.Current != nil {
+		t.Error("expected the toast to clear when its tick fires")
+	}
+}
+
+func TestAgentMissingFromRoundIsForgotten(t *testing.T) {
+	m := makeTestModel(testStatuses())
+	start := time.Now()
+	m, _ = sampleRound(m, start, 1, "/tmp/a")
+	m, _ = sampleRound(m, start.Add(3*time.Second), 2, "/tmp/a")
+
+	m, _ = sampleRound(m, start.Add(6*time.Second), 2)
+	if got := m.agents.Status("/tmp/a"); got.Activity != worktree.AgentUnknown {
+		t.Errorf("expected an unsampled worktree to be forgotten, got %v", got.Activity)
+	}
+	if m.toast.Current != nil {
+		t.Error("expected no toast when a window disappears")
+	}
+}
+
+func TestUnreadableAgentShowsUnknownBadge(t *testing.T) {
+	m := makeTestModel(testStatuses())
+	m.sampleAgentFn = func(worktree.WorktreeStatus) (worktree.AgentSample, error) {
+		return worktree.AgentSample{}, errors.New("unsupported")
+	}
+
+	msg := m.agentSampleCmd(testStatuses())().(agentSamplesMsg)
+	if len(msg.samples) != 0 || !msg.unreadable["/tmp/a"] {
+		t.Fatalf("expected /tmp/a reported unreadable, got %+v", msg)
+	}
+	updated, _ := m.Update(msg)
+	if left := ansi.Strip(updated.(Model).renderLeft(40)); !strings.Contains(left, "unknown") {
+		t.Errorf("expected an unknown badge, got:\n%s", left)
+	}
+}
+
+func TestAttentionFilterShowsOnlyWaitingAgents(t *testing.T) {
+	statuses := testStatuses()
+	m := makeTestModel(statuses)
+	m.sessions = testSessions()
+	m.rebuildRows()
+	start := time.Now()
+	m, _ = sampleRound(m, start, 1, "/tmp/a")
+	m, _ = sampleRound(m, start.Add(worktree.AgentIdleAfter), 1, "/tmp/a")
+
+	updated, _ := m.Update(tea.KeyPressMsg{Code: 'a', Text: "a"})
+	m = updated.(Model)
+	if !m.attentionOnly {
+		t.Fatal("expected a to turn the attention filter on")
+	}
+	var leaves []string
+	for _, r := range m.rows {
+		if r.kind == rowWorktree || r.kind == rowSession {
+			leaves = append(leaves, r.status.Name+r.session.Name)
+		}
+	}
+	if len(leaves) != 1 || leaves[0] != "feature-a" {
+		t.Errorf("expected only the idle feature-a row, got %v", leaves)
+	}
+
+	m, _ = sampleRound(m, start.Add(2*worktree.AgentIdleAfter), 2, "/tmp/a")
+	if left := ansi.Strip(m.renderLeft(40)); !strings.Contains(left, "No agents need attention") {
+		t.Errorf("expected guidance once nothing needs attention, got:\n%s", left)
+	}
+
+	updated, _ = m.Update(tea.KeyPressMsg{Code: 'a', Text: "a"})
+	if m = updated.(Model); m.attentionOnly || len(m.rows) != len(buildRows(
+		statuses, testSessions(), map[string]bool{}, "", nil,
+	)) {
+		t.Error("expected a second a to show every row again")
+	}
+}
+
+func TestFormatAge(t *testing.T) {
+	tests := []struct {
+		d    time.Duration
+		want string
+	}{
+		{-time.Second, "0s"},
+		{42 * time.Second, "42s"},
+		{4*time.Minute + 59*time.Second, "4m"},
+		{2 * time.Hour, "2h"},
+		{75 * time.Hour, "3d"},
+	}
+	for _, tt := range tests {
+		if got := formatAge(tt.d); got != tt.want {
+			t.Errorf("formatAge(%v): expected %q, got %q", tt.d, tt.want, got)
+		}
+	}
+}
@@ -167,12 +167,15 @@ func (m Model) handleRepoPickKey(key string) (tea.Model, tea.Cmd) {
 	return m, nil
 }
 
-// handleNameInputKey drives the floating single-line name prompt: esc cancels
-// the whole create flow, enter with a non-empty name kicks off createFn, and
-// every other key is a name edit delegated to the shared TextInput (typing,
-// backspace/delete, and left/right/home/end caret movement). Auto-naming on a
-// blank name is out of scope for this cycle, so enter with no text is a
-// no-op rather than falling back to a generated name.
+// handleNameInputKey drives the floating name prompt: esc cancels the whole
+// create flow, enter with a non-empty name kicks off createFn, tab switches
+// editing between the name and the optional task-prompt field, and every
+// other key is an edit of the focused field delegated to the shared TextInput
+// (typing, backspace/delete, and left/right/home/end caret movement).
+// Enter with no name first fills the field with a generated one
+// (generateNameFn: from the prompt field when it has text, else
+// worktree.name_template) and carries on as if it had been typed; if
+// generation fails, the error is shown and the prompt stays open.
 //
 // Enter also runs the hook-compatibility check (checkHookCompatibilityFn)
 // before create: if it finds warnings, the same two-press arm/confirm
@@ -188,9 +191,18 @@ func (m Model) handleNameInputKey(key string) (tea.Model, tea.Cmd) {
 		m.clearCreateState()
 		return m, nil
 
+	case "tab", "shift+tab":
+		m.promptFocused = !m.promptFocused
+		return m, nil
+
 	case "enter":
 		if m.createInput.Value == "" {
-			return m, nil
+			name, err := m.generateNameFn(m.createRepo, m.createPrompt.Value)
+			if err != nil {
+				m.status = "couldn't generate a name: " + err.Error()
+				return m, nil
+			}
+			m.createInput.SetValue(name)
 		}
 		if !m.pendingHookWarning {
 			if warnings := m.checkHookCompatibilityFn(m.createRepo); len(warnings) > 0 {
@@ -213,12 +225,17 @@ func (m Model) handleNameInputKey(key string) (tea.Model, tea.Cmd) {
 		return m.dispatchCreate("")
 
 	default:
+		if m.promptFocused {
+			m.createPrompt.HandleKey(key)
+			return m, nil
+		}
 		// Everything else is a name edit delegated to the shared TextInput:
 		// backspace/delete, caret movement (left/right/home/end), and printable
 		// insertion. A change to the text de-arms a pending hook-warning confirm
 		// (same as confirmThenRemove clearing pendingDelete on an edit); a bare
 		// caret move leaves the warning armed since the name it applies to
-		// hasn't changed.
+		// hasn't changed. The prompt doesn't bear on the hook check, so editing
+		// it leaves the warning armed.
 		if _, changed := m.createInput.HandleKey(key); changed {
 			m.pendingHookWarning = false
 		}
@@ -226,11 +243,16 @@ func (m Model) handleNameInputKey(key string) (tea.Model, tea.Cmd) {
 	return m, nil
 }
 
-// handleNameInputPaste inserts pasted text into the name field in one shot,
-// the paste counterpart to handleNameInputKey: a tea.PasteMsg carries the
-// whole clipboard content as one string, which handleNameInputKey's
-// per-rune default case would otherwise drop except for its first rune.
+// handleNameInputPaste inserts pasted text into the focused field (name or
+// prompt) in one shot, the paste counterpart to handleNameInputKey: a
+// tea.PasteMsg carries the whole clipboard content as one string, which
+// handleNameInputKey's per-rune default case would otherwise drop except for
+// its first rune.
 func (m Model) handleNameInputPaste(text string) (tea.Model, tea.Cmd) {
+	if m.promptFocused {
+		m.createPrompt.InsertText(text)
+		return m, nil
+	}
 	if m.createInput.InsertText(text) {
 		m.pendingHookWarning = false
 	}
@@ -245,7 +267,7 @@ func (m Model) handleNameInputPaste(text string) (tea.Model, tea.Cmd) {
 //
 // The item list is ordered with the resolved default layout first (skipping
 // it from the rest to avoid a duplicate), then every other built-in name in
-// their existing stable order: FuzzyPicker's cursor always starts at index 0
+// their existing stable order, then the user's worktree.layouts: FuzzyPicker's cursor always starts at index 0
 // of an unfiltered item list (see NewFuzzyPicker/SetItems/refilter), so this
 // ordering is what puts the cursor on the default without any extra
 // pre-selection logic — the same trick startNewWorktree's repo picker already
@@ -257,7 +279,7 @@ func (m Model) enterLayoutPick() (tea.Model, tea.Cmd) {
 		return m, nil
 	}
 
-	names := worktree.BuiltinLayoutNames()
+	names := worktree.LayoutNames(m.gc)
 	items := make([]tuicomponents.PaletteItem, 0, len(names))
 	items = append(items, tuicomponents.PaletteItem{Command: def.Name})
 	for _, name := range names {
@@ -302,8 +324,9 @@ func (m Model) handleLayoutPickKey(key string) (tea.Model, tea.Cmd) {
 	return m, nil
 }
 
-// dispatchCreate captures the flow's accumulated state (repo, name, and the
-// resolved layout name — "" for the n path, a picked/typed name for N) and
+// dispatchCreate captures the flow's accumulated state (repo, name, optional
+// task prompt, and the resolved layout name — "" for the n path, a
+// picked/typed name for N) and
 // kicks off the async createFn call. It's the single dispatch point both the
 // n path (handleNameInputKey) and the N path (handleLayoutPickKey) funnel
 // through, so the m.creating-arming safety reasoning only has to live in one
@@ -311,6 +334,7 @@ func (m Model) handleLayoutPickKey(key string) (tea.Model, tea.Cmd) {
 func (m Model) dispatchCreate(layoutName string) (tea.Model, tea.Cmd) {
 	repoPath := m.createRepo
 	name := m.createInput.Value
+	prompt := m.createPrompt.Value
 	createFn := m.createFn
 	m.clearCreateState()
 	// Armed here, before the tea.Cmd below ever runs: Bubble Tea runs every
@@ -330,7 +354,7 @@ func (m Model) dispatchCreate(layoutName string) (tea.Model, tea.Cmd) {
 	// where success attaches-and-quits) — so it never lingers.
 	m.status = layoutActionStatus("creating worktree", name, layoutName, m.gc)
 	return m, func() tea.Msg {
-		warning, err := createFn(repoPath, name, layoutName)
+		warning, err := createFn(repoPath, name, layoutName, prompt)
 		if err != nil {
 			return createFailedMsg{err: err}
 		}
@@ -361,6 +385,15 @@ func layoutActionStatus(verb, name, layoutName string, gc *config.GlobalConfig)
 	return actionStatus(verb, name)
 }
 
+// recordedLayoutName is the layout s was created with, or "" (the configured
+// default) when nothing was recorded - i.e. what a repair will rebuild.
+func recordedLayoutName(s worktree.WorktreeStatus) string {
+	if s.Meta == nil {
+		return ""
+	}
+	return s.Meta.Layout
+}
+
 // friendlyLayoutName maps a resolved layout's Name to a human label for the
 // status line: the "nvim" config token reads as "neovim", the two-pane layout
 // as "claude + neovim". opencode/claude already read fine and fall through, as
@@ -388,6 +421,8 @@ func (m *Model) clearCreateState() {
 	m.layoutPicker = nil
 	m.createRepo = ""
 	m.createInput.Reset()
+	m.createPrompt.Reset()
+	m.promptFocused = false
 	m.wantsLayoutPick = false
 	m.pendingHookWarning = false
 }
@@ -423,10 +458,20 @@ func (m Model) renderRepoPickPopup() string {
 }
 
 // renderNameInputPopup builds the raw (uncentered) name-prompt popup
-// content; composited over the dashboard background via Overlay.
+// content; composited over the dashboard background via Overlay. Only the
+// focused field draws a caret, so it's clear which one tab has selected.
 func (m Model) renderNameInputPopup() string {
 	maxW := min(m.width-2, 64)
-	lines := []string{"> " + m.createInput.RenderPlain()}
+	name, prompt := m.createInput.RenderPlain(), m.createPrompt.Value
+	if m.promptFocused {
+		name, prompt = m.createInput.Value, m.createPrompt.RenderPlain()
+	}
+	lines := []string{
+		"name   > " + name,
+		"prompt > " + prompt,
+		"",
+		"tab: switch field · enter: create · esc: cancel",
+	}
 	return m.palette.BorderedPane("New worktree — name", maxW, lines)
 }
 
@@ -136,7 +136,7 @@ func TestRepoPickSelectedInvalidStaysInPicker(t *testing.T) {
 		return "", fmt.Errorf("not a git repository: %s", path)
 	}
 	createCalled := false
-	m.createFn = func(_, _, _ string) (string, error) {
+	m.createFn = func(_, _, _, _ string) (string, error) {
 		createCalled = true
 		return "", nil
 	}
@@ -206,7 +206,7 @@ func TestRepoPickFreeTypedPathInvalidStaysInPicker(t *testing.T) {
 		return "", fmt.Errorf("not a git repository: %s", path)
 	}
 	createCalled := false
-	m.createFn = func(_, _, _ string) (string, error) {
+	m.createFn = func(_, _, _, _ string) (string, error) {
 		createCalled = true
 		return "", nil
 	}
@@ -295,6 +295,42 @@ func TestNameInputPasteStripsControlChars(t *testing.T) {
 	}
 }
 
+// TestNameInputTabEditsPromptAndDispatchesIt proves tab moves typing and
+// pastes to the prompt field, leaves the name alone, and that enter hands
+// the prompt to createFn.
+func TestNameInputTabEditsPromptAndDispatchesIt(t *testing.T) {
+	var gotName, gotPrompt string
+	m := makeTestModel(testStatuses())
+	m.mgr = &worktree.WorktreeManager{}
+	m.createMode = createNameInput
+	m.createRepo = "/repos/alpha"
+	m.createInput.SetValue("feat")
+	m.createFn = func(_, name, _, prompt string) (string, error) {
+		gotName, gotPrompt = name, prompt
+		return "", nil
+	}
+
+	m2, _ := m.Update(tea.KeyPressMsg{Code: tea.KeyTab})
+	m2, _ = m2.(Model).Update(tea.KeyPressMsg{Code: 'f', Text: "f"})
+	m2, _ = m2.(Model).Update(tea.PasteMsg{Content: "ix it\n"})
+	m3 := m2.(Model)
+	if m3.createInput.Value != "feat" {
+		t.Errorf("expected the name to stay %q, got %q", "feat", m3.createInput.Value)
+	}
+	if m3.createPrompt.Value != "fix it" {
+		t.Errorf("expected prompt %q, got %q", "fix it", m3.createPrompt.Value)
+	}
+
+	_, cmd := m3.Update(tea.KeyPressMsg{Code: tea.KeyEnter})
+	if cmd == nil {
+		t.Fatal("expected a create command after enter")
+	}
+	cmd()
+	if gotName != "feat" || gotPrompt != "fix it" {
+		t.Errorf("createFn called with name=%q prompt=%q", gotName, gotPrompt)
+	}
+}
+
 func TestNameInputBackspaceRemovesLastRuneNotLastByte(t *testing.T) {
 	m := makeTestModel(testStatuses())
 	m.createMode = createNameInput
@@ -327,12 +363,44 @@ func TestRepoPickPasteInsertsIntoQuery(t *testing.T) {
 	}
 }
 
-func TestNameInputEnterEmptyIsNoop(t *testing.T) {
+func TestNameInputEnterEmptyGeneratesName(t *testing.T) {
+	var gotPromptForName, gotName string
+	m := makeTestModel(testStatuses())
+	m.mgr = &worktree.WorktreeManager{}
+	m.createMode = createNameInput
+	m.createRepo = "/repos/alpha"
+	m.createPrompt.SetValue("fix the login redirect")
+	m.generateNameFn = func(repoPath, prompt string) (string, error) {
+		gotPromptForName = prompt
+		return "fix-login-redirect", nil
+	}
+	m.createFn = func(_, name, _, _ string) (string, error) {
+		gotName = name
+		return "", nil
+	}
+
+	_, cmd := m.Update(tea.KeyPressMsg{Code: tea.KeyEnter})
+	if cmd == nil {
+		t.Fatal("expected a create command after enter with a generated name")
+	}
+	cmd()
+	if gotPromptForName != "fix the login redirect" {
+		t.Errorf("expected the name to be generated from the prompt, got %q", gotPromptForName)
+	}
+	if gotName != "fix-login-redirect" {
+		t.Errorf("expected createFn to get the generated name, got %q", gotName)
+	}
+}
+
+func TestNameInputEnterEmptyGenerateFailureStaysOpen(t *testing.T) {
 	createCalled := false
 	m := makeTestModel(testStatuses())
 	m.createMode = createNameInput
 	m.createRepo = "/repos/alpha"
-	m.createFn = func(_, _, _ string) (string, error) {
+	m.generateNameFn = func(_, _ string) (string, error) {
+		return "", fmt.Errorf("git unavailable")
+	}
+	m.createFn = func(_, _, _, _ string) (string, error) {
 		createCalled = true
 		return "", nil
 	}
@@ -343,10 +411,13 @@ func TestNameInputEnterEmptyIsNoop(t *testing.T) {
 		cmd()
 	}
 	if createCalled {
-		t.Error("enter with an empty name must not call createFn")
+		t.Error("a failed name generation must not call createFn")
 	}
 	if m3.createMode != createNameInput {
-		t.Error("enter with an empty name should stay in name-input mode")
+		t.Error("a failed name generation should stay in name-input mode")
+	}
+	if !strings.Contains(m3.status, "git unavailable") {
+		t.Errorf("expected the generation error in status, got %q", m3.status)
 	}
 }
 
@@ -356,7 +427,7 @@ func TestNameInputEscCancels(t *testing.T) {
 	m.createMode = createNameInput
 	m.createRepo = "/repos/alpha"
 	m.createInput.SetValue("feat")
-	m.createFn = func(_, _, _ string) (string, error) {
+	m.createFn = func(_, _, _, _ string) (string, error) {
 		createCalled = true
 		return "", nil
 	}
@@ -383,7 +454,7 @@ func TestCreateSuccessAttachesAndQuits(t *testing.T) {
 	m.createMode = createNameInput
 	m.createRepo = "/repos/alpha"
 	m.createInput.SetValue("feat")
-	m.createFn = func(repoPath, name, _ string) (string, error) {
+	m.createFn = func(repoPath, name, _, _ string) (string, error) {
 		gotRepo = repoPath
 		gotName = name
 		return "", nil
@@ -444,7 +515,7 @@ func TestCreateFnFailureSetsStatusNoAttachNoQuit(t *testing.T) {
 	m.createMode = createNameInput
 	m.createRepo = "/repos/alpha"
 	m.createInput.SetValue("feat")
-	m.createFn = func(_, _, _ string) (string, error) {
+	m.createFn = func(_, _, _, _ string) (string, error) {
 		return "", fmt.Errorf("worktree already exists")
 	}
 	m.attachFn = func(_, _ string) error {
@@ -496,7 +567,7 @@ func TestCreateSuccessAttachFailureTriggersRefresh(t *testing.T) {
 
 	m := makeTestModel(testStatuses())
 	m.mgr = &worktree.WorktreeManager{}
-	m.createFn = func(_, _, _ string) (string, error) { return "", nil }
+	m.createFn = func(_, _, _, _ string) (string, error) { return "", nil }
 	m.windowSessionFn = func(_ string) (string, bool) { return "", false }
 	m.repairFn = func(_, _ string, _ worktree.Layout) error {
 		return fmt.Errorf("repair unavailable")
@@ -535,7 +606,7 @@ func TestNameInputEnterWithHookWarningRequiresSecondEnter(t *testing.T) {
 		}
 		return []string{"pre-commit (contains \"[ -d .git\")"}
 	}
-	m.createFn = func(_, _, _ string) (string, error) {
+	m.createFn = func(_, _, _, _ string) (string, error) {
 		createCalled = true
 		return "", nil
 	}
@@ -584,7 +655,7 @@ func TestNameInputEnterWithoutHookWarningCreatesImmediately(t *testing.T) {
 	m.createRepo = "/repos/alpha"
 	m.createInput.SetValue("feat")
 	m.checkHookCompatibilityFn = func(_ string) []string { return nil }
-	m.createFn = func(_, _, _ string) (string, error) {
+	m.createFn = func(_, _, _, _ string) (string, error) {
 		createCalled = true
 		return "", nil
 	}
@@ -685,7 +756,7 @@ func TestNameInputEnterWithoutWantsLayoutPickDispatchesImmediately(t *testing.T)
 	m.createMode = createNameInput
 	m.createRepo = "/repos/alpha"
 	m.createInput.SetValue("feat")
-	m.createFn = func(_, _, layoutName string) (string, error) {
+	m.createFn = func(_, _, layoutName, _ string) (string, error) {
 		gotLayout = layoutName
 		layoutArgSeen = true
 		return "", nil
@@ -715,7 +786,7 @@ func TestNameInputEnterWithWantsLayoutPickEntersLayoutPick(t *testing.T) {
 	m.createRepo = "/repos/alpha"
 	m.createInput.SetValue("feat")
 	m.wantsLayoutPick = true
-	m.createFn = func(_, _, _ string) (string, error) {
+	m.createFn = func(_, _, _, _ string) (string, error) {
 		createCalled = true
 		return "", nil
 	}
@@ -761,7 +832,7 @@ func TestLayoutPickSelectedDispatchesCreateWithChosenLayout(t *testing.T) {
 	m.createRepo = "/repos/alpha"
 	m.createInput.SetValue("feat")
 	m.wantsLayoutPick = true
-	m.createFn = func(repoPath, name, layoutName string) (string, error) {
+	m.createFn = func(repoPath, name, layoutName, _ string) (string, error) {
 		gotRepo = repoPath
 		gotName = name
 		gotLayout = layoutName
@@ -810,7 +881,7 @@ func TestLayoutPickEscCancelsWholeCreate(t *testing.T) {
 	m.createRepo = "/repos/alpha"
 	m.createInput.SetValue("feat")
 	m.wantsLayoutPick = true
-	m.createFn = func(_, _, _ string) (string, error) {
+	m.createFn = func(_, _, _, _ string) (string, error) {
 		createCalled = true
 		return "", nil
 	}
@@ -854,7 +925,7 @@ func TestLayoutPickFreeTypedQueryDispatchesUnlistedName(t *testing.T) {
 	m.createRepo = "/repos/alpha"
 	m.createInput.SetValue("feat")
 	m.wantsLayoutPick = true
-	m.createFn = func(_, _, layoutName string) (string, error) {
+	m.createFn = func(_, _, layoutName, _ string) (string, error) {
 		gotLayout = layoutName
 		return "", nil
 	}
@@ -896,7 +967,7 @@ func TestEnterLayoutPickResolveLayoutErrorStaysInNameInput(t *testing.T) {
 	m.createInput.SetValue("feat")
 	m.wantsLayoutPick = true
 	m.gc = &config.GlobalConfig{Worktree: config.WorktreeConfig{DefaultLayout: "not-a-real-layout"}}
-	m.createFn = func(_, _, _ string) (string, error) {
+	m.createFn = func(_, _, _, _ string) (string, error) {
 		createCalled = true
 		return "", nil
 	}
@@ -0,0 +1,176 @@
+package tuiworktree
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	tea "charm.land/bubbletea/v2"
+	"github.com/charmbracelet/x/ansi"
+
+	"github.com/cjairm/devgita/internal/tooling/task"
+	"github.com/cjairm/devgita/internal/tooling/worktree"
+)
+
+// mergeCheckedMsg carries a merge pre-check (git merge-tree) for s: a clean
+// one arms m/M for a second, confirming press; a conflicting one takes over
+// the diff pane.
+type mergeCheckedMsg struct {
+	s      worktree.WorktreeStatus
+	squash bool
+	check  task.MergeCheck
+	err    error
+}
+
+// mergedMsg reports a merge that landed and a worktree that was torn down;
+// like deletedMsg it carries the list with the row already dropped.
+type mergedMsg struct {
+	name     string
+	squash   bool
+	check    task.MergeCheck
+	statuses []worktree.WorktreeStatus
+}
+
+// mergeKey is the key that merges (m) or squash-merges (M).
+func mergeKey(squash bool) string {
+	if squash {
+		return "M"
+	}
+	return "m"
+}
+
+// mergeVerb names the action for status lines and the armed hint.
+func mergeVerb(squash bool) string {
+	if squash {
+		return "squash-merge"
+	}
+	return "merge"
+}
+
+// handleMerge implements m (merge) and M (squash-merge). The first press
+// only runs the conflict pre-check; the merge itself needs a second press
+// once that came back clean, the same two-press shape as d/D.
+func (m Model) handleMerge(squash bool) (tea.Model, tea.Cmd) {
+	sel, ok := m.selectedStatus()
+	if !ok {
+		return m, nil
+	}
+	key := sel.Repo + "/" + sel.Name
+	if m.pendingMerge == key && m.pendingSquash == squash {
+		m.pendingMerge = ""
+		verb := "merging"
+		if squash {
+			verb = "squash-merging"
+		}
+		m.status = actionStatus(verb, sel.Name)
+		return m, m.mergeCmd(sel, squash)
+	}
+	m.pendingMerge = ""
+	m.status = actionStatus("checking for conflicts", sel.Name)
+	check := m.mergeCheckFn
+	return m, func() tea.Msg {
+		result, err := check(sel.Path)
+		return mergeCheckedMsg{s: sel, squash: squash, check: result, err: err}
+	}
+}
+
+// handleMergeChecked applies a pre-check result. Arming is skipped when the
+// cursor has moved off the worktree while the check ran, so a late result
+// can never arm a merge for a row other than the one selected.
+func (m Model) handleMergeChecked(msg mergeCheckedMsg) (tea.Model, tea.Cmd) {
+	if msg.err != nil {
+		m.status = mergeVerb(msg.squash) + " check failed: " + msg.err.Error()
+		return m, nil
+	}
+	if len(msg.check.Conflicts) > 0 {
+		m.conflictPath = msg.s.Path
+		m.conflictCheck = msg.check
+		m.diffFocused = false
+		m.status = fmt.Sprintf(
+			"%s conflicts with %s in %d file(s); nothing was merged",
+			msg.s.Name, msg.check.Base, len(msg.check.Conflicts),
+		)
+		return m, nil
+	}
+	if m.conflictPath == msg.s.Path {
+		m.conflictPath = ""
+	}
+	m.status = msg.s.Name + " merges cleanly into " + msg.check.Base
+	if sel, ok := m.selectedStatus(); ok && sel.Path == msg.s.Path {
+		m.pendingMerge = sel.Repo + "/" + sel.Name
+		m.pendingSquash = msg.squash
+		m.pendingMergeBase = msg.check.Base
+	}
+	return m, nil
+}
+
+// mergeCmd merges s (re-running the pre-check inside task.MergeWorktreeAt,
+// since the branch may have moved since the first press) and then tears it
+// down the way D does: worktree, branch, window and session, hopping the
+// client to the fallback session first when it's sitting on that session.
+func (m Model) mergeCmd(s worktree.WorktreeStatus, squash bool) tea.Cmd {
+	merge := m.mergeFn
+	removeSession := m.removeSessionFn
+	statuses := m.statuses
+	return func() tea.Msg {
+		check, err := merge(s.Path, squash)
+		if errors.Is(err, task.ErrMergeConflicts) {
+			return mergeCheckedMsg{s: s, squash: squash, check: check}
+		}
+		if err != nil {
+			return statusMsg(mergeVerb(squash) + " failed: " + err.Error())
+		}
+		if err := removeSession(s.Repo, s.Name); err != nil {
+			return statusMsg(fmt.Sprintf(
+				"merged %s into %s, but removing the worktree failed: %v", s.Name, check.Base, err,
+			))
+		}
+		var updated []worktree.WorktreeStatus
+		for _, st := range statuses {
+			if st.Path != s.Path {
+				updated = append(updated, st)
+			}
+		}
+		return mergedMsg{name: s.Name, squash: squash, check: check, statuses: updated}
+	}
+}
+
+// handleMerged confirms a finished merge and applies the row-dropped list.
+func (m Model) handleMerged(msg mergedMsg) (tea.Model, tea.Cmd) {
+	verb := "merged "
+	if msg.squash {
+		verb = "squash-merged "
+	}
+	m.status = verb + msg.name + " into " + msg.check.Base
+	m.conflictPath = ""
+	return m.applyStatuses(msg.statuses)
+}
+
+// renderConflicts takes the diff pane's place for a worktree whose last
+// merge check conflicted, listing the files git merge-tree reported.
+func (m Model) renderConflicts(width int) string {
+	check := m.conflictCheck
+	lines := []string{
+		m.palette.RepoHeader.Render(check.Base) +
+			m.palette.Divider.Render(" ← ") +
+			m.palette.DiffFileHeader.Render(check.Branch) +
+			"  " + m.palette.StatusMsg.Render(
+			fmt.Sprintf("%d conflicting file(s)", len(check.Conflicts)),
+		),
+		"",
+		"Merging would conflict in:",
+	}
+	for _, file := range check.Conflicts {
+		lines = append(lines, "  ✗ "+file)
+	}
+	lines = append(lines,
+		"",
+		m.palette.Inactive.Render("Nothing was merged. Resolve it in the worktree"),
+		m.palette.Inactive.Render("(e.g. rebase onto "+check.Base+"), then press m again."),
+		m.palette.Inactive.Render("esc dismisses this list."),
+	)
+	for i, line := range lines {
+		lines[i] = ansi.Truncate(line, width, "")
+	}
+	return strings.Join(lines, "\n")
+}
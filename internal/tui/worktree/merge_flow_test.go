@@ -0,0 +1,122 @@
+package tuiworktree
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/charmbracelet/x/ansi"
+
+	"github.com/cjairm/devgita/internal/tooling/task"
+)
+
+func TestMergeNeedsCleanCheckThenSecondPress(t *testing.T) {
+	m := makeTestModel(testStatuses())
+	var merged []bool
+	var removed []string
+	m.mergeFn = func(path string, squash bool) (task.MergeCheck, error) {
+		merged = append(merged, squash)
+		return task.MergeCheck{Branch: "feature-a", Base: "main"}, nil
+	}
+	m.removeSessionFn = func(repo, name string) error {
+		removed = append(removed, name)
+		return nil
+	}
+
+	m, cmd := typeKeys(m, key('m'))
+	if !strings.Contains(m.status, "checking for conflicts") {
+		t.Errorf("expected the first press to run the check, got %q", m.status)
+	}
+	for _, msg := range flattenCmd(cmd) {
+		updated, _ := m.Update(msg)
+		m = updated.(Model)
+	}
+	if m.pendingMerge != "repo-a/feature-a" {
+		t.Fatalf("expected a clean check to arm the merge, got %q", m.pendingMerge)
+	}
+	if len(merged) != 0 {
+		t.Fatal("expected nothing merged before the confirming press")
+	}
+	hint := ansi.Strip(m.renderHint(200))
+	if !strings.Contains(hint, "press m again to merge feature-a into main") {
+		t.Errorf("expected the armed merge hint, got %q", hint)
+	}
+
+	m, cmd = typeKeys(m, key('m'))
+	for _, msg := range flattenCmd(cmd) {
+		updated, _ := m.Update(msg)
+		m = updated.(Model)
+	}
+	if len(merged) != 1 || merged[0] || len(removed) != 1 || removed[0] != "feature-a" {
+		t.Errorf("expected one merge followed by teardown, got merged=%v removed=%v", merged, removed)
+	}
+	if m.status != "merged feature-a into main" {
+		t.Errorf("unexpected status %q", m.status)
+	}
+	for _, s := range m.statuses {
+		if s.Name == "feature-a" {
+			t.Error("expected the merged worktree to be dropped from the list")
+		}
+	}
+}
+
+func TestMergeArmIsCancelledByOtherKeys(t *testing.T) {
+	m := makeTestModel(testStatuses())
+	var merged int
+	m.mergeFn = func(_ string, _ bool) (task.MergeCheck, error) {
+		merged++
+		return task.MergeCheck{Base: "main"}, nil
+	}
+
+	// M arms a squash; m afterwards must re-check rather than squash.
+	m, cmd := typeKeys(m, key('M'))
+	for _, msg := range flattenCmd(cmd) {
+		updated, _ := m.Update(msg)
+		m = updated.(Model)
+	}
+	if m.pendingMerge == "" || !m.pendingSquash {
+		t.Fatalf("expected M to arm a squash-merge, got %q", m.pendingMerge)
+	}
+	m, cmd = typeKeys(m, key('m'))
+	flattenCmd(cmd)
+	if merged != 0 || m.pendingMerge != "" {
+		t.Errorf("expected m not to confirm an armed M, merged=%d pending=%q", merged, m.pendingMerge)
+	}
+}
+
+func TestMergeConflictsShowInDiffPane(t *testing.T) {
+	m := makeTestModel(testStatuses())
+	m.mergeCheckFn = func(_ string) (task.MergeCheck, error) {
+		return task.MergeCheck{
+			Branch:    "feature-a",
+			Base:      "main",
+			Conflicts: []string{"src/app.go", "README.md"},
+		}, nil
+	}
+
+	m, cmd := typeKeys(m, key('m'))
+	for _, msg := range flattenCmd(cmd) {
+		updated, _ := m.Update(msg)
+		m = updated.(Model)
+	}
+	if m.pendingMerge != "" {
+		t.Error("expected a conflicting check not to arm the merge")
+	}
+	if !strings.Contains(m.status, "conflicts with main in 2 file(s)") {
+		t.Errorf("unexpected status %q", m.status)
+	}
+	right := ansi.Strip(m.renderRight(80))
+	if !strings.Contains(right, "✗ src/app.go") || !strings.Contains(right, "✗ README.md") {
+		t.Errorf("expected the conflicting files in the diff pane, got:\n%s", right)
+	}
+
+	// The conflict list belongs to feature-a only, and esc dismisses it.
+	m.moveCursor(1)
+	if right := ansi.Strip(m.renderRight(80)); strings.Contains(right, "src/app.go") {
+		t.Errorf("expected other worktrees to show their diff, got:\n%s", right)
+	}
+	m.moveCursor(-1)
+	m, _ = typeKeys(m, escKey)
+	if right := ansi.Strip(m.renderRight(80)); strings.Contains(right, "src/app.go") {
+		t.Errorf("expected esc to dismiss the conflict list, got:\n%s", right)
+	}
+}
@@ -11,7 +11,6 @@ import (
 	"github.com/charmbracelet/x/ansi"
 
 	"github.com/cjairm/devgita/internal/apps/git"
-	"github.com/cjairm/devgita/internal/apps/tmux"
 	"github.com/cjairm/devgita/internal/config"
 	"github.com/cjairm/devgita/internal/tooling/task"
 	"github.com/cjairm/devgita/internal/tooling/terminal/dev_tools/githubcli"
@@ -75,10 +74,10 @@ type deletedMsg struct {
 
 // Model is the Bubble Tea model for the worktree TUI dashboard.
 type Model struct {
-	mgr     *worktree.WorktreeManager
-	tmuxApp *tmux.Tmux
-	gitApp  *git.Git
-	gc      *config.GlobalConfig
+	mgr    *worktree.WorktreeManager
+	mux    worktree.Multiplexer
+	gitApp *git.Git
+	gc     *config.GlobalConfig
 
 	statuses []worktree.WorktreeStatus
 	// sessions holds standalone tmux sessions with no worktree-backed window;
@@ -101,11 +100,42 @@ type Model struct {
 	diffBranch    string // display branch for the "base ← branch" label
 	diffPath      string // worktree path the current diff belongs to; PR-title cache lookup key
 
+	// showPreview swaps the diff in the right pane for the selected
+	// worktree's live agent pane (see preview.go); previewPath is the
+	// worktree the latest capture belongs to.
+	showPreview    bool
+	previewContent string
+	previewPath    string
+	previewErr     error
+
 	prTitles       map[string]string // path -> PR title; "" cached means "looked up, no PR"
 	prTitlePending map[string]bool   // path -> lookup in flight, so we don't double-dispatch
 
 	filter tuicomponents.FilterField
 
+	// agents tracks each windowed worktree's agent pane across sampling
+	// rounds (see agent_activity.go); agentsSampledAt is when the latest
+	// round was taken and agentsUnreadable the worktrees whose pane it
+	// couldn't read. attentionOnly is the "a" filter: only worktrees whose
+	// agent is idle or exited are listed.
+	agents           *worktree.AgentTracker
+	agentsSampledAt  time.Time
+	agentsUnreadable map[string]bool
+	attentionOnly    bool
+
+	// marked holds the worktree paths x has marked for a send; sending and
+	// its companions back the i popup (see send_flow.go): the message being
+	// typed, the worktrees it goes to, and whether Enter follows it.
+	marked     map[string]bool
+	sending    bool
+	sendInput  tuicomponents.TextInput
+	sendTo     []worktree.WorktreeStatus
+	sendSubmit bool
+
+	// toast floats over the dashboard's top-right corner when an agent
+	// settles.
+	toast tuicomponents.ToastState
+
 	status string
 
 	width  int
@@ -123,6 +153,16 @@ type Model struct {
 	pendingKillSession   string // armed session name (sessions have no repo) or ""
 	showHelp             bool
 
+	// pendingMerge is the "repo/name" a clean merge pre-check armed m (or,
+	// with pendingSquash, M) for, merging into pendingMergeBase; conflictPath
+	// is the worktree whose last check conflicted, its conflictCheck shown in
+	// place of the diff (see merge_flow.go).
+	pendingMerge     string
+	pendingSquash    bool
+	pendingMergeBase string
+	conflictPath     string
+	conflictCheck    task.MergeCheck
+
 	// sessionMode and its companion fields back the s → folder-pick →
 	// name-prompt → CreateSession flow, kept deliberately separate from
 	// createMode/createInput: that machinery belongs to the n/N worktree flow
@@ -139,6 +179,8 @@ type Model struct {
 	repoPicker         *tuicomponents.FuzzyPicker
 	createRepo         string                  // resolved repo path chosen in repo-pick mode
 	createInput        tuicomponents.TextInput // in-progress name text + caret in name-input mode
+	createPrompt       tuicomponents.TextInput // optional initial task for the AI coder, edited alongside the name in name-input mode
+	promptFocused      bool                    // tab moved name-input's editing to createPrompt instead of createInput
 	wantsLayoutPick    bool                    // set when the flow was started via N (handleNewWorktreeWithLayoutPick) rather than n; read once, after a successful name-input enter, to decide whether to dispatch createFn immediately (n) or transition into createLayoutPick (N) first
 	layoutPicker       *tuicomponents.FuzzyPicker
 	pendingHookWarning bool // armed by a first enter when CheckHookCompatibility found warnings; a second enter confirms, any other key (or edited name) de-arms it
@@ -159,19 +201,25 @@ type Model struct {
 	validateRepoPathFn       func(path string) (string, error)
 	validateSessionDirFn     func(path string) (string, error)
 	checkHookCompatibilityFn func(repoPath string) []string
-	createFn                 func(repoPath, name, layoutName string) (warning string, err error)
+	createFn                 func(repoPath, name, layoutName, prompt string) (warning string, err error)
+	generateNameFn           func(repoPath, prompt string) (string, error)
 	prTitleFn                func(branch, path string) string
+	sampleAgentFn            func(s worktree.WorktreeStatus) (worktree.AgentSample, error)
+	captureAgentFn           func(s worktree.WorktreeStatus, history int) (string, error)
+	sendToAgentFn            func(s worktree.WorktreeStatus, message string, submit bool) error
+	mergeCheckFn             func(path string) (task.MergeCheck, error)
+	mergeFn                  func(path string, squash bool) (task.MergeCheck, error)
 }
 
 func newModel(
 	mgr *worktree.WorktreeManager,
-	tmuxApp *tmux.Tmux,
+	mux worktree.Multiplexer,
 	gitApp *git.Git,
 	gc *config.GlobalConfig,
 ) Model {
 	m := Model{
 		mgr:            mgr,
-		tmuxApp:        tmuxApp,
+		mux:            mux,
 		gitApp:         gitApp,
 		gc:             gc,
 		collapsed:      map[string]bool{},
@@ -179,12 +227,14 @@ func newModel(
 		leftPaneWidth:  defaultLeftPaneWidth,
 		prTitles:       map[string]string{},
 		prTitlePending: map[string]bool{},
+		agents:         worktree.NewAgentTracker(),
+		marked:         map[string]bool{},
 	}
 	m.diffFn = func(path string) (task.BranchDiffResult, error) {
 		return task.BranchDiffAt(gitApp, path)
 	}
 	m.attachFn = func(session, window string) error {
-		return tmuxApp.SwitchToWindow(session, window)
+		return mux.SwitchToWindow(session, window)
 	}
 	m.removeFn = func(repo, name string, force bool) error {
 		return mgr.RemoveInRepo(repo, name, force)
@@ -195,16 +245,16 @@ func newModel(
 	m.repairFn = func(repo, name string, layout worktree.Layout) error {
 		return mgr.RepairInRepo(repo, name, layout)
 	}
-	m.windowSessionFn = tmuxApp.WindowSession
-	m.createSessionFn = tmuxApp.CreateSession
-	m.switchToSessionFn = tmuxApp.SwitchToSession
-	m.killSessionFn = tmuxApp.KillSession
+	m.windowSessionFn = mux.WindowSession
+	m.createSessionFn = mux.CreateSession
+	m.switchToSessionFn = mux.SwitchToSession
+	m.killSessionFn = mux.KillSession
 	// listSessionNamesFn feeds the blank-name auto-namer's collision check: it
 	// needs every session on the tmux server (not just the standalone ones the
-	// dashboard shows), so it goes through tmuxApp.ListSessions directly rather
+	// dashboard shows), so it goes through mux.ListSessions directly rather
 	// than mgr.ListSessions, which filters to standalone sessions.
 	m.listSessionNamesFn = func() ([]string, error) {
-		sessions, err := tmuxApp.ListSessions()
+		sessions, err := mux.ListSessions()
 		if err != nil {
 			return nil, err
 		}
@@ -225,7 +275,14 @@ func newModel(
 	// gets the warning, just through a TUI-safe confirm (see
 	// handleNameInputKey), instead of losing it to a hardcoded force=true.
 	m.checkHookCompatibilityFn = gitApp.CheckHookCompatibility
-	m.createFn = func(repoPath, name, layoutName string) (string, error) {
+	// GenerateName runs a couple of read-only git branch lookups per
+	// candidate, cheap enough to call synchronously from the name prompt's
+	// enter (like checkHookCompatibilityFn) so the generated name is filled
+	// into the field before the hook confirm or layout picker shows.
+	m.generateNameFn = func(repoPath, prompt string) (string, error) {
+		return mgr.GenerateName(repoPath, prompt, gc)
+	}
+	m.createFn = func(repoPath, name, layoutName, prompt string) (string, error) {
 		// layoutName is "" for the n path (today's default behavior) and the
 		// N-picked name for the N path. aiAlias is always "" here — no flag or
 		// env var reaches this closure — which is exactly what lets
@@ -237,6 +294,9 @@ func newModel(
 		if err != nil {
 			return "", err
 		}
+		if layout, err = layout.WithPrompt(prompt); err != nil {
+			return "", err
+		}
 		// mgr.WarnFn fires synchronously from inside CreateAt below (e.g. the
 		// recent-repos store failed to record this create). Swapping it to a
 		// local closure and restoring it via defer right after CreateAt
@@ -272,6 +332,15 @@ func newModel(
 	m.prTitleFn = func(_, path string) string {
 		return gh.PRTitleAt(path, prTitleTimeout)
 	}
+	m.sampleAgentFn = mgr.SampleAgent
+	m.captureAgentFn = mgr.CaptureAgent
+	m.sendToAgentFn = mgr.SendToAgent
+	m.mergeCheckFn = func(path string) (task.MergeCheck, error) {
+		return task.MergeCheckAt(gitApp, path)
+	}
+	m.mergeFn = func(path string, squash bool) (task.MergeCheck, error) {
+		return task.MergeWorktreeAt(gitApp, path, squash)
+	}
 	return m
 }
 
@@ -401,13 +470,17 @@ func (m *Model) maybePRTitleCmd(s worktree.WorktreeStatus) tea.Cmd {
 
 // selectionChangedCmd builds the batch of commands to run whenever the
 // selected worktree changes (statusesMsg reload, or j/k moving the cursor):
-// always recompute the diff, and additionally kick off a PR-title lookup
-// when s's path has no cache entry and none is pending. Pointer receiver is
+// always recompute the diff, recapture the agent pane while the preview is
+// shown, and additionally kick off a PR-title lookup when s's path has no
+// cache entry and none is pending. Pointer receiver is
 // required so maybePRTitleCmd's pending-flag mutation lands on the
 // addressable local m in the three call sites (all of which operate on a
 // value-receiver Update/handleKey's local m before returning it).
 func (m *Model) selectionChangedCmd(sel worktree.WorktreeStatus) tea.Cmd {
 	cmds := []tea.Cmd{m.computeDiffCmd(sel)}
+	if m.showPreview && sel.WindowActive {
+		cmds = append(cmds, m.previewCmd(sel))
+	}
 	if c := m.maybePRTitleCmd(sel); c != nil {
 		cmds = append(cmds, c)
 	}
@@ -441,7 +514,11 @@ func (m Model) selectedSession() (worktree.SessionStatus, bool) {
 }
 
 func (m *Model) rebuildRows() {
-	m.rows = buildRows(m.statuses, m.sessions, m.collapsed, m.filter.Value())
+	var keep func(worktree.WorktreeStatus) bool
+	if m.attentionOnly {
+		keep = m.needsAttention
+	}
+	m.rows = buildRows(m.statuses, m.sessions, m.collapsed, m.filter.Value(), keep)
 	// Keep cursor on a valid leaf row (worktree or session)
 	m.cursor = tuicomponents.ClampCursor(leafIndices(m.rows), m.cursor)
 }
@@ -519,7 +596,27 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		return m, nil
 
 	case statusesMsg:
-		return m.applyStatuses([]worktree.WorktreeStatus(msg))
+		// Every refresh also samples the agent panes, so activity moves on
+		// the same cadence as the list.
+		statuses := []worktree.WorktreeStatus(msg)
+		updated, cmd := m.applyStatuses(statuses)
+		return updated, tea.Batch(cmd, m.agentSampleCmd(statuses))
+
+	case agentSamplesMsg:
+		return m.handleAgentSamples(msg)
+
+	case sentMsg:
+		return m.handleSent(msg)
+
+	case mergeCheckedMsg:
+		return m.handleMergeChecked(msg)
+
+	case mergedMsg:
+		return m.handleMerged(msg)
+
+	case tuicomponents.ToastExpiredMsg:
+		m.toast.Expire(msg)
+		return m, nil
 
 	case sessionsMsg:
 		// Only reached on a successful ListSessions() (see sessionsLoadCmd);
@@ -546,6 +643,12 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		m.diffPath = msg.path
 		return m, nil
 
+	case previewMsg:
+		m.previewPath = msg.path
+		m.previewContent = msg.content
+		m.previewErr = msg.err
+		return m, nil
+
 	case prTitleMsg:
 		m.prTitles[msg.path] = msg.title
 		delete(m.prTitlePending, msg.path)
@@ -631,6 +734,10 @@ func (m Model) handlePaste(text string) (tea.Model, tea.Cmd) {
 	if m.sessionMode == sessionNameInput {
 		return m.handleSessionNameInputPaste(text)
 	}
+	if m.sending {
+		m.sendInput.InsertText(text)
+		return m, nil
+	}
 
 	if m.filter.Active {
 		if m.filter.InsertText(text) {
@@ -668,6 +775,9 @@ func (m Model) handleKey(msg tea.KeyPressMsg) (tea.Model, tea.Cmd) {
 	if m.sessionMode == sessionNameInput {
 		return m.handleSessionNameInputKey(key)
 	}
+	if m.sending {
+		return m.handleSendInputKey(key)
+	}
 
 	if m.filter.Active {
 		if m.filter.HandleKey(key) {
@@ -690,6 +800,9 @@ func (m Model) handleKey(msg tea.KeyPressMsg) (tea.Model, tea.Cmd) {
 	if key != "d" && m.pendingKillSession != "" {
 		m.pendingKillSession = ""
 	}
+	if key != mergeKey(m.pendingSquash) && m.pendingMerge != "" {
+		m.pendingMerge = ""
+	}
 
 	switch key {
 	case "?":
@@ -775,8 +888,25 @@ func (m Model) handleKey(msg tea.KeyPressMsg) (tea.Model, tea.Cmd) {
 		m.filter.Active = true
 		return m, nil
 
+	case "a":
+		return m.handleToggleAttention()
+
+	case "p":
+		return m.handleTogglePreview()
+
+	case "x":
+		return m.handleToggleMark()
+
+	case "i":
+		return m.handleSendToAgent()
+
+	case "esc":
+		m.marked = map[string]bool{}
+		m.conflictPath = ""
+		return m, nil
+
 	case "space":
-		if m.diffContent != "" {
+		if m.diffContent != "" && !m.showPreview {
 			m.diffFocused = true
 		}
 		return m, nil
@@ -796,6 +926,12 @@ func (m Model) handleKey(msg tea.KeyPressMsg) (tea.Model, tea.Cmd) {
 	case "D":
 		return m.handleSessionDelete()
 
+	case "m":
+		return m.handleMerge(false)
+
+	case "M":
+		return m.handleMerge(true)
+
 	case "r":
 		return m.handleRepair()
 
@@ -897,7 +1033,7 @@ func (m Model) handleAttach() (tea.Model, tea.Cmd) {
 	// its goroutine): a cheap read-only tmux lookup, kept here rather than
 	// restructuring the shared cmd the create-success path also uses.
 	if _, ok := m.windowSessionFn(worktree.GetWindowName(sel.Repo, sel.Name)); !ok {
-		m.status = layoutActionStatus("repairing", sel.Name, "", m.gc)
+		m.status = layoutActionStatus("repairing", sel.Name, recordedLayoutName(sel), m.gc)
 	}
 	return m, m.attachToWindowCmd(sel.Repo, sel.Name)
 }
@@ -913,7 +1049,6 @@ func (m Model) attachToWindowCmd(repo, name string) tea.Cmd {
 	attachFn := m.attachFn
 	repairFn := m.repairFn
 	windowSessionFn := m.windowSessionFn
-	gc := m.gc
 
 	return func() tea.Msg {
 		session, ok := windowSessionFn(window)
@@ -924,16 +1059,11 @@ func (m Model) attachToWindowCmd(repo, name string) tea.Cmd {
 			return tea.QuitMsg{}
 		}
 		// Auto-repair: window missing. No --layout flag/picker reaches this
-		// path (that's a later step), so layoutName and aiAlias are both ""
-		// here, letting ResolveLayout rebuild gc.Worktree.DefaultLayout when
-		// set, else derive from gc.Worktree.DefaultAI, else opencode - see
-		// ResolveLayout's doc comment for why the folded ResolveAIAlias
-		// output must NOT be passed here instead.
-		layout, err := worktree.ResolveLayout("", "", gc)
-		if err != nil {
-			return statusMsg("repair failed: " + err.Error())
-		}
-		if err := repairFn(repo, name, layout); err != nil {
+		// path, so worktree.Layout{} asks RepairInRepo to rebuild the layout
+		// recorded when the worktree was created (falling back to
+		// gc.Worktree.DefaultLayout, then DefaultAI, then opencode for a
+		// worktree with no record).
+		if err := repairFn(repo, name, worktree.Layout{}); err != nil {
 			return statusMsg("repair failed: " + err.Error())
 		}
 		session, ok = windowSessionFn(window)
@@ -1027,19 +1157,14 @@ func (m Model) handleRepair() (tea.Model, tea.Cmd) {
 	repo := sel.Repo
 	name := sel.Name
 	// Repair rebuilds a tmux window (same cost as create), so show the same
-	// in-progress feedback naming the layout it will rebuild ("" resolves the
-	// configured default, matching the ResolveLayout call below). Superseded by
-	// "repaired:"/"repair failed:" when the async work resolves.
-	m.status = layoutActionStatus("repairing", name, "", gc)
+	// in-progress feedback naming the layout it will rebuild (the recorded one,
+	// or "" to resolve the configured default when nothing was recorded).
+	// Superseded by "repaired:"/"repair failed:" when the async work resolves.
+	m.status = layoutActionStatus("repairing", name, recordedLayoutName(sel), gc)
 	return m, func() tea.Msg {
-		// Same no-flags-given reasoning as attachToWindowCmd's auto-repair:
-		// "" for both layoutName and aiAlias lets ResolveLayout honor
-		// gc.Worktree.DefaultLayout, then gc.Worktree.DefaultAI, then opencode.
-		layout, err := worktree.ResolveLayout("", "", gc)
-		if err != nil {
-			return statusMsg("repair failed: " + err.Error())
-		}
-		if err := repairFn(repo, name, layout); err != nil {
+		// Same reasoning as attachToWindowCmd's auto-repair: worktree.Layout{}
+		// rebuilds the layout the worktree was created with.
+		if err := repairFn(repo, name, worktree.Layout{}); err != nil {
 			return statusMsg("repair failed: " + err.Error())
 		}
 		return statusMsg("repaired: " + name)
@@ -1060,7 +1185,7 @@ func (m Model) renderContent() string {
 		return ""
 	}
 
-	background := m.renderDashboard()
+	background := m.toast.Overlay(m.palette, m.renderDashboard(), m.width, m.height)
 	if m.showHelp {
 		return tuicomponents.Overlay(background, m.renderHelpPopup(), m.width, m.height)
 	}
@@ -1084,6 +1209,9 @@ func (m Model) renderContent() string {
 	if m.sessionMode == sessionNameInput {
 		return tuicomponents.Overlay(background, m.renderSessionNameInputPopup(), m.width, m.height)
 	}
+	if m.sending {
+		return tuicomponents.Overlay(background, m.renderSendPopup(), m.width, m.height)
+	}
 	return background
 }
 
@@ -1156,8 +1284,6 @@ func (m Model) renderLeft(width int) string {
 		return true
 	}
 
-	const branchChar = "∕" // U+2215 DIVISION SLASH — branch glyph (1 display col)
-
 	var sb strings.Builder
 	for i, r := range m.rows {
 		var line string
@@ -1216,7 +1342,7 @@ func (m Model) renderLeft(width int) string {
 				)
 			}
 		} else {
-			state := tuicomponents.SessionStateFromWorktree(r.status, false, 0)
+			state := tuicomponents.SessionStateFromWorktree(r.status, m.needsAttention(r.status), 0)
 			// Tree connector: "└ " for last child, "  " otherwise (both 2 display cols).
 			connectorRaw := "  "
 			connectorStyled := "  "
@@ -1224,28 +1350,47 @@ func (m Model) renderLeft(width int) string {
 				connectorRaw = "└ "
 				connectorStyled = m.palette.Divider.Render("└") + " "
 			}
-			// prefix = connector(2) + dot(1) + branchChar(1) + space(1) = 5 display cols
-			name := ansi.Truncate(r.status.Name, max(0, width-5), "")
+			// prefix = connector(2) + dot(1) + markGlyph(1) + space(1) = 5 display cols.
+			// The agent badge ("working", "idle 4m") is right-aligned like the
+			// repo header's tree count, with the name truncated to leave it room.
+			badge := m.agentBadge(r.status)
+			badgeW := ansi.StringWidth(badge)
+			if badgeW > 0 {
+				badgeW++ // separating space
+			}
+			name := ansi.Truncate(r.status.Name, max(0, width-5-badgeW), "")
 			pendingKey := r.status.Repo + "/" + r.status.Name
-			padding := strings.Repeat(" ", max(0, width-5-ansi.StringWidth(name)))
+			padding := strings.Repeat(
+				" ",
+				max(0, width-5-ansi.StringWidth(name)-ansi.StringWidth(badge)),
+			)
 
 			if i == m.cursor {
 				g := m.palette.StatusGlyph(state)
-				plainText := connectorRaw + g + branchChar + " " + name
+				plainText := connectorRaw + g + m.markGlyph(r.status, false) + " " + name
 				if m.pendingDelete == pendingKey || m.pendingSessionDelete == pendingKey {
-					line = m.palette.Armed.Render(plainText + padding)
+					line = m.palette.Armed.Render(plainText + padding + badge)
 				} else {
-					line = m.palette.Selected.Render(plainText + padding)
+					line = m.palette.Selected.Render(plainText + padding + badge)
 				}
 			} else {
 				line = connectorStyled + m.palette.StatusDot(
 					state,
-				) + m.palette.BranchLabel() + " " + name + padding
+				) + m.markGlyph(r.status, true) + " " + name + padding + m.palette.HintDesc.Render(
+					badge,
+				)
 			}
 		}
 		sb.WriteString(line)
 		sb.WriteString("\n")
 	}
+	// The attention filter with every agent busy would leave an empty pane;
+	// say so instead.
+	if len(m.rows) == 0 && m.attentionOnly {
+		return m.palette.Inactive.Render(
+			ansi.Truncate("No agents need attention · a shows all", width, ""),
+		)
+	}
 	return strings.TrimRight(sb.String(), "\n")
 }
 
@@ -1284,6 +1429,13 @@ func (m Model) renderRight(width int) string {
 		)
 	}
 
+	if m.showPreview {
+		return m.renderPreview(width, m.height-2)
+	}
+	if sel, ok := m.selectedStatus(); ok && sel.Path == m.conflictPath {
+		return m.renderConflicts(width)
+	}
+
 	header := m.palette.DiffStatLine(m.diffFiles, m.diffAdded, m.diffRemoved)
 	// GitHub-style "base ← compare" label, shown once for the whole diff.
 	if m.diffBase != "" && m.diffBranch != "" {
@@ -1362,6 +1514,15 @@ func (m Model) renderHint(width int) string {
 	if m.pendingDelete != "" {
 		return m.armedDeleteHint(m.pendingDelete, "d", "delete", "", width)
 	}
+	if m.pendingMerge != "" {
+		return m.armedDeleteHint(
+			m.pendingMerge,
+			mergeKey(m.pendingSquash),
+			mergeVerb(m.pendingSquash),
+			" into "+m.pendingMergeBase+" and remove it + its session",
+			width,
+		)
+	}
 	// createRepoPick and createLayoutPick are both plain FuzzyPicker
 	// interactions (list nav + select + cancel), so they share one hint set
 	// instead of two copy-pasted literals.
@@ -1380,6 +1541,14 @@ func (m Model) renderHint(width int) string {
 		}
 		return m.palette.HintBar(hints, width)
 	}
+	if m.sending {
+		hints := []tuicomponents.KeyHint{
+			{Key: "esc", Desc: "cancel"},
+			{Key: "enter", Desc: "send"},
+			{Key: "tab", Desc: "toggle Enter"},
+		}
+		return m.palette.HintBar(hints, width)
+	}
 	if m.filter.Active {
 		return m.palette.FilterHint(m.filter, width)
 	}
@@ -1402,19 +1571,32 @@ func (m Model) renderHint(width int) string {
 	// hints above already disambiguate the moment a press actually arms —
 	// splitting "d" into two entries here would add width for a distinction
 	// the help popup (which has room) already covers.
+	attentionDesc := "attention"
+	if m.attentionOnly {
+		attentionDesc = "show all"
+	}
+	previewDesc := "agent"
+	if m.showPreview {
+		previewDesc = "diff"
+	}
 	hints := []tuicomponents.KeyHint{
 		{Key: "↵", Desc: "attach"},
 		{Key: "n", Desc: "new"},
 		{Key: "N", Desc: "new w/ layout"},
 		{Key: "s", Desc: "new session"},
 		{Key: "spc", Desc: "diff"},
+		{Key: "p", Desc: previewDesc},
+		{Key: "i", Desc: "send"},
+		{Key: "x", Desc: "mark"},
 		{Key: "j/k", Desc: "move"},
 		{Key: "h/l", Desc: "fold"},
 		{Key: "z", Desc: "all"},
 		{Key: "d", Desc: "del"},
 		{Key: "D", Desc: "del+sess"},
+		{Key: "m/M", Desc: "merge/squash"},
 		{Key: "r", Desc: "repair"},
 		{Key: "/", Desc: "filter"},
+		{Key: "a", Desc: attentionDesc},
 		{Key: "?", Desc: "help"},
 		{Key: "q", Desc: "quit"},
 	}
@@ -1446,10 +1628,19 @@ func (m Model) renderHelpPopup() string {
 			Key:  "d d",
 			Desc: "delete worktree (confirm twice); on a session row: kill it (confirm twice)",
 		},
-		{Key: "D D", Desc: "delete worktree + kill its session"},
+		{Key: "D D", Desc: "discard: delete worktree + branch, kill its session"},
+		{
+			Key:  "m m",
+			Desc: "merge into the default branch (conflict check first), then remove like D",
+		},
+		{Key: "M M", Desc: "squash-merge into the default branch, then remove like D"},
 		{Key: "r", Desc: "repair (recreate window + relaunch AI)"},
 		{Key: "/", Desc: "filter  esc:clear  enter:keep"},
+		{Key: "a", Desc: "toggle showing only agents that need attention (idle or exited)"},
 		{Key: "space", Desc: "focus diff pane (esc returns to the list)"},
+		{Key: "p", Desc: "toggle the right pane between the diff and the live agent pane"},
+		{Key: "i", Desc: "send a message to the agent (every marked worktree's, if any)"},
+		{Key: "x", Desc: "mark / unmark a worktree for i  esc:clear marks"},
 		{Key: "ctrl+d / ctrl+u", Desc: "scroll diff down / up"},
 		{Key: "[ / ]", Desc: "previous / next file (diff focused)"},
 		{Key: "g / G", Desc: "diff top / bottom (diff focused)"},
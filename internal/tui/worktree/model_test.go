@@ -29,6 +29,8 @@ func makeTestModel(statuses []worktree.WorktreeStatus) Model {
 		height:         40,
 		prTitles:       map[string]string{},
 		prTitlePending: map[string]bool{},
+		agents:         worktree.NewAgentTracker(),
+		marked:         map[string]bool{},
 	}
 	m.diffFn = func(_ string) (task.BranchDiffResult, error) {
 		return task.BranchDiffResult{Content: "diff content", Files: 1, Added: 5, Removed: 2}, nil
@@ -46,8 +48,20 @@ func makeTestModel(statuses []worktree.WorktreeStatus) Model {
 	m.validateRepoPathFn = func(path string) (string, error) { return path, nil }
 	m.validateSessionDirFn = func(path string) (string, error) { return path, nil }
 	m.checkHookCompatibilityFn = func(_ string) []string { return nil }
-	m.createFn = func(_, _, _ string) (string, error) { return "", nil }
+	m.createFn = func(_, _, _, _ string) (string, error) { return "", nil }
+	m.generateNameFn = func(_, _ string) (string, error) { return "generated", nil }
 	m.prTitleFn = func(_, _ string) string { return "" }
+	m.sampleAgentFn = func(_ worktree.WorktreeStatus) (worktree.AgentSample, error) {
+		return worktree.AgentSample{}, nil
+	}
+	m.captureAgentFn = func(_ worktree.WorktreeStatus, _ int) (string, error) { return "", nil }
+	m.sendToAgentFn = func(_ worktree.WorktreeStatus, _ string, _ bool) error { return nil }
+	m.mergeCheckFn = func(_ string) (task.MergeCheck, error) {
+		return task.MergeCheck{Branch: "feature", Base: "main"}, nil
+	}
+	m.mergeFn = func(_ string, _ bool) (task.MergeCheck, error) {
+		return task.MergeCheck{Branch: "feature", Base: "main"}, nil
+	}
 	m.statuses = statuses
 	m.rebuildRows()
 	return m
@@ -99,7 +113,7 @@ func testStatuses() []worktree.WorktreeStatus {
 
 func TestBuildRowsGrouping(t *testing.T) {
 	statuses := testStatuses()
-	rows := buildRows(statuses, nil, map[string]bool{}, "")
+	rows := buildRows(statuses, nil, map[string]bool{}, "", nil)
 	// Should have: repo-a header, feature-a, feature-b, repo-b header, feature-x
 	if len(rows) != 5 {
 		t.Fatalf("expected 5 rows, got %d", len(rows))
@@ -123,7 +137,7 @@ func testSessions() []worktree.SessionStatus {
 }
 
 func TestBuildRowsRepoHeaderWorktreeCount(t *testing.T) {
-	rows := buildRows(testStatuses(), nil, map[string]bool{}, "")
+	rows := buildRows(testStatuses(), nil, map[string]bool{}, "", nil)
 	if rows[0].kind != rowRepo || rows[0].repo != "repo-a" {
 		t.Fatal("expected first row to be repo-a header")
 	}
@@ -139,7 +153,7 @@ func TestBuildRowsRepoHeaderWorktreeCount(t *testing.T) {
 	// Collapsing a repo must not change its header's worktree count — the
 	// count describes the repo's children, not what's currently rendered.
 	collapsed := map[string]bool{"repo-a": true}
-	rowsCollapsed := buildRows(testStatuses(), nil, collapsed, "")
+	rowsCollapsed := buildRows(testStatuses(), nil, collapsed, "", nil)
 	if rowsCollapsed[0].kind != rowRepo || rowsCollapsed[0].worktreeCount != 2 {
 		t.Errorf("collapsed repo-a header should still report worktreeCount=2, got %d",
 			rowsCollapsed[0].worktreeCount)
@@ -147,7 +161,7 @@ func TestBuildRowsRepoHeaderWorktreeCount(t *testing.T) {
 }
 
 func TestBuildRowsSessionsAppendedAsLeavesAfterRepos(t *testing.T) {
-	rows := buildRows(testStatuses(), testSessions(), map[string]bool{}, "")
+	rows := buildRows(testStatuses(), testSessions(), map[string]bool{}, "", nil)
 	// repo-a header, feature-a, feature-b, repo-b header, feature-x, then
 	// sessions alpha-sorted: notes, scratch.
 	if len(rows) != 7 {
@@ -174,7 +188,7 @@ func TestBuildRowsSessionsAppendedAsLeavesAfterRepos(t *testing.T) {
 
 func TestBuildRowsSessionsWithNoWorktrees(t *testing.T) {
 	// Sessions must appear even when there are zero repos/worktrees.
-	rows := buildRows(nil, testSessions(), map[string]bool{}, "")
+	rows := buildRows(nil, testSessions(), map[string]bool{}, "", nil)
 	if len(rows) != 2 {
 		t.Fatalf("expected 2 session rows, got %d", len(rows))
 	}
@@ -192,7 +206,7 @@ func TestBuildRowsSessionRowUnaffectedByCollapse(t *testing.T) {
 	// Collapsing every repo must not hide or alter session rows — sessions
 	// have no expand/collapse state of their own.
 	collapsed := map[string]bool{"repo-a": true, "repo-b": true}
-	rows := buildRows(testStatuses(), testSessions(), collapsed, "")
+	rows := buildRows(testStatuses(), testSessions(), collapsed, "", nil)
 	var sessionCount int
 	for _, r := range rows {
 		if r.kind == rowSession {
@@ -207,7 +221,7 @@ func TestBuildRowsSessionRowUnaffectedByCollapse(t *testing.T) {
 func TestBuildRowsFilterMatchesSessionNames(t *testing.T) {
 	// Judgment call: filter matches session names too, consistent with the
 	// dashboard reading as one unified/filterable list.
-	rows := buildRows(testStatuses(), testSessions(), map[string]bool{}, "notes")
+	rows := buildRows(testStatuses(), testSessions(), map[string]bool{}, "notes", nil)
 	if len(rows) != 1 {
 		t.Fatalf(
 			"expected filter 'notes' to leave only the matching session row, got %d rows",
@@ -224,7 +238,7 @@ func TestBuildRowsFilterMatchesSessionNames(t *testing.T) {
 }
 
 func TestLeafIndicesIncludesSessionRows(t *testing.T) {
-	rows := buildRows(testStatuses(), testSessions(), map[string]bool{}, "")
+	rows := buildRows(testStatuses(), testSessions(), map[string]bool{}, "", nil)
 	indices := leafIndices(rows)
 	// 3 worktree rows + 2 session rows = 5 leaf rows.
 	if len(indices) != 5 {
@@ -977,7 +991,7 @@ func TestAttachNoRepairingStatusWhenWindowPresent(t *testing.T) {
 // exercising the session-load side of loadCmd/sessionsLoadCmd.
 func mgrWithMockedTmux(mockTmuxBase *commands.MockBaseCommand) *worktree.WorktreeManager {
 	return &worktree.WorktreeManager{
-		Tmux: &tmux.Tmux{Cmd: commands.NewMockCommand(), Base: mockTmuxBase},
+		Mux: &tmux.Tmux{Cmd: commands.NewMockCommand(), Base: mockTmuxBase},
 	}
 }
 
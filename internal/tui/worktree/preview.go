@@ -0,0 +1,86 @@
+package tuiworktree
+
+import (
+	"strings"
+
+	tea "charm.land/bubbletea/v2"
+	"github.com/charmbracelet/x/ansi"
+
+	"github.com/cjairm/devgita/internal/tooling/worktree"
+)
+
+// previewHistory is how much scrollback above the agent pane's visible screen
+// a preview captures, so a tall dashboard can show more than a short pane
+// holds.
+const previewHistory = 200
+
+// previewMsg carries one capture of a worktree's agent pane for the p preview.
+type previewMsg struct {
+	path    string // worktree path the capture belongs to
+	content string
+	err     error
+}
+
+// previewCmd captures s's agent pane. Like computeDiffCmd it runs off the
+// Update goroutine, and is dispatched on selection change and on every
+// statuses refresh while the preview is shown.
+func (m Model) previewCmd(s worktree.WorktreeStatus) tea.Cmd {
+	capture := m.captureAgentFn
+	return func() tea.Msg {
+		content, err := capture(s, previewHistory)
+		return previewMsg{path: s.Path, content: content, err: err}
+	}
+}
+
+// handleTogglePreview flips the right pane between the branch diff and the
+// selected worktree's live agent pane.
+func (m Model) handleTogglePreview() (tea.Model, tea.Cmd) {
+	m.showPreview = !m.showPreview
+	if sel, ok := m.selectedStatus(); ok {
+		return m, m.selectionChangedCmd(sel)
+	}
+	return m, nil
+}
+
+// renderPreview renders the selected worktree's agent pane in a bordered box
+// filling the right pane, showing the tail of the capture that fits. The
+// capture keeps the pane's colors, so each line is closed with a style reset
+// before the border: a color left open at the end of a line (or cut open by
+// truncation) would otherwise bleed into the border and the lines below.
+func (m Model) renderPreview(width, height int) string {
+	sel, ok := m.selectedStatus()
+	if !ok {
+		return m.palette.Inactive.Render("(loading...)")
+	}
+	title := "agent · " + sel.Name
+	inner := max(height-2, 1)
+
+	var lines []string
+	switch {
+	case !sel.WindowActive:
+		lines = []string{m.palette.Inactive.Render("No tmux window — r rebuilds it.")}
+	case m.previewPath != sel.Path:
+		lines = []string{m.palette.Inactive.Render("(loading...)")}
+	case m.previewErr != nil:
+		lines = []string{m.palette.Inactive.Render("(preview unavailable: " + m.previewErr.Error() + ")")}
+	default:
+		lines = previewTail(m.previewContent, inner)
+		for i, line := range lines {
+			lines[i] = ansi.Truncate(line, max(width-2, 0), "") + ansi.ResetStyle
+		}
+	}
+	return m.palette.BorderedPane(title, width, lines)
+}
+
+// previewTail returns the last n lines of a capture, ignoring the blank rows
+// below the cursor that a pane's unused screen space captures as.
+func previewTail(content string, n int) []string {
+	lines := strings.Split(strings.TrimRight(content, "\n"), "\n")
+	for len(lines) > 0 && strings.TrimSpace(ansi.Strip(lines[len(lines)-1])) == "" {
+		lines = lines[:len(lines)-1]
+	}
+	if len(lines) > n {
+		lines = lines[len(lines)-n:]
+	}
+	return lines
+}
@@ -0,0 +1,106 @@
+package tuiworktree
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	tea "charm.land/bubbletea/v2"
+	"github.com/charmbracelet/x/ansi"
+
+	"github.com/cjairm/devgita/internal/tooling/worktree"
+)
+
+func TestTogglePreviewCapturesSelectedAgent(t *testing.T) {
+	var captured []string
+	m := makeTestModel(testStatuses())
+	m.captureAgentFn = func(s worktree.WorktreeStatus, history int) (string, error) {
+		captured = append(captured, s.Path)
+		return "> done\n", nil
+	}
+
+	updated, cmd := m.Update(tea.KeyPressMsg{Code: 'p', Text: "p"})
+	m = updated.(Model)
+	if !m.showPreview {
+		t.Fatal("expected p to turn the preview on")
+	}
+	for _, msg := range flattenCmd(cmd) {
+		if v, ok := msg.(previewMsg); ok {
+			updated, _ = m.Update(v)
+			m = updated.(Model)
+		}
+	}
+	if len(captured) != 1 || captured[0] != "/tmp/a" {
+		t.Fatalf("expected the selected worktree's pane to be captured, got %v", captured)
+	}
+	if right := ansi.Strip(m.renderRight(60)); !strings.Contains(right, "agent · feature-a") ||
+		!strings.Contains(right, "> done") {
+		t.Errorf("expected the bordered agent preview, got:\n%s", right)
+	}
+
+	// The refresh tick recaptures while the preview is up...
+	_, cmd = m.Update(statusesMsg(testStatuses()))
+	flattenCmd(cmd)
+	if len(captured) != 2 {
+		t.Errorf("expected a statuses refresh to recapture, got %d captures", len(captured))
+	}
+
+	// ...and stops once it's toggled back to the diff.
+	updated, _ = m.Update(tea.KeyPressMsg{Code: 'p', Text: "p"})
+	m = updated.(Model)
+	_, cmd = m.Update(statusesMsg(testStatuses()))
+	flattenCmd(cmd)
+	if len(captured) != 2 {
+		t.Errorf("expected no capture with the preview off, got %d captures", len(captured))
+	}
+	if right := ansi.Strip(m.renderRight(60)); strings.Contains(right, "agent ·") {
+		t.Errorf("expected the diff back in the right pane, got:\n%s", right)
+	}
+}
+
+func TestPreviewKeepsColorsInsideTheBorder(t *testing.T) {
+	m := makeTestModel(testStatuses())
+	m.showPreview = true
+	m.height = 8
+	long := "\x1b[31m" + strings.Repeat("x", 100) // red, never closed, wider than the pane
+	m.previewPath = "/tmp/a"
+	m.previewContent = "old line\n" + strings.Repeat("filler\n", 10) + long + "\n\n\n"
+
+	out := m.renderPreview(30, 6)
+	lines := strings.Split(out, "\n")
+	if len(lines) != 6 {
+		t.Fatalf("expected the box to fill the 6 rows, got %d:\n%s", len(lines), out)
+	}
+	for i, line := range lines {
+		if w := ansi.StringWidth(line); w != 30 {
+			t.Errorf("line %d: expected width 30, got %d", i, w)
+		}
+	}
+	last := lines[len(lines)-2]
+	if !strings.Contains(last, "\x1b[31m") || !strings.Contains(last, ansi.ResetStyle+"\x1b") {
+		t.Errorf("expected the red line to be reset before its border, got %q", last)
+	}
+	if strings.Contains(out, "old line") {
+		t.Error("expected only the tail of the capture to be shown")
+	}
+}
+
+func TestPreviewPlaceholders(t *testing.T) {
+	m := makeTestModel(testStatuses())
+	m.showPreview = true
+
+	if got := ansi.Strip(m.renderPreview(60, 10)); !strings.Contains(got, "(loading...)") {
+		t.Errorf("expected a loading placeholder before the first capture, got:\n%s", got)
+	}
+
+	m.previewPath = "/tmp/a"
+	m.previewErr = errors.New("can't find pane")
+	if got := ansi.Strip(m.renderPreview(60, 10)); !strings.Contains(got, "can't find pane") {
+		t.Errorf("expected the capture error, got:\n%s", got)
+	}
+
+	m.moveCursor(1) // feature-b: no window
+	if got := ansi.Strip(m.renderPreview(60, 10)); !strings.Contains(got, "No tmux window") {
+		t.Errorf("expected the no-window guidance, got:\n%s", got)
+	}
+}
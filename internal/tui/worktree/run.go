@@ -27,7 +27,7 @@ func Run() error {
 	mgr.WarnFn = func(msg string) {
 		logger.L().Debugw("worktree: non-fatal warning outside create flow", "msg", msg)
 	}
-	m := newModel(mgr, mgr.Tmux, mgr.Git, gc)
+	m := newModel(mgr, mgr.Mux, mgr.Git, gc)
 
 	p := tea.NewProgram(m)
 	_, err := p.Run()
@@ -0,0 +1,178 @@
+package tuiworktree
+
+import (
+	"fmt"
+	"strings"
+
+	tea "charm.land/bubbletea/v2"
+
+	"github.com/cjairm/devgita/internal/tooling/worktree"
+)
+
+// sentMsg reports a finished send: how many agents got the message, and a
+// "name: error" entry for each that didn't.
+type sentMsg struct {
+	sent     []string
+	failures []string
+}
+
+// sendTargets is who i sends to: every marked worktree (x) when any are
+// marked, otherwise the worktree under the cursor - in both cases only those
+// with a window, since there's no agent pane to type into without one.
+func (m Model) sendTargets() []worktree.WorktreeStatus {
+	var candidates []worktree.WorktreeStatus
+	if len(m.marked) > 0 {
+		for _, s := range m.statuses {
+			if m.marked[s.Path] {
+				candidates = append(candidates, s)
+			}
+		}
+	} else if sel, ok := m.selectedStatus(); ok {
+		candidates = append(candidates, sel)
+	}
+	var targets []worktree.WorktreeStatus
+	for _, s := range candidates {
+		if s.WindowActive {
+			targets = append(targets, s)
+		}
+	}
+	return targets
+}
+
+// handleToggleMark flips the x mark on the worktree under the cursor and
+// moves down, so a run of rows can be marked with repeated presses.
+func (m Model) handleToggleMark() (tea.Model, tea.Cmd) {
+	sel, ok := m.selectedStatus()
+	if !ok {
+		return m, nil
+	}
+	if m.marked[sel.Path] {
+		delete(m.marked, sel.Path)
+	} else {
+		m.marked[sel.Path] = true
+	}
+	m.moveCursor(1)
+	if next, ok := m.selectedStatus(); ok {
+		return m, m.selectionChangedCmd(next)
+	}
+	return m, nil
+}
+
+// handleSendToAgent opens the send popup for sendTargets.
+func (m Model) handleSendToAgent() (tea.Model, tea.Cmd) {
+	targets := m.sendTargets()
+	if len(targets) == 0 {
+		m.status = "no agent to send to: the worktree has no tmux window (r rebuilds it)"
+		return m, nil
+	}
+	m.sending = true
+	m.sendTo = targets
+	m.sendSubmit = true
+	m.sendInput.Reset()
+	return m, nil
+}
+
+// handleSendInputKey processes keys while the send popup is open: enter
+// sends, tab toggles pressing Enter after the message, esc cancels, and
+// everything else edits the message.
+func (m Model) handleSendInputKey(key string) (tea.Model, tea.Cmd) {
+	switch key {
+	case "esc":
+		m.clearSendState()
+	case "tab":
+		m.sendSubmit = !m.sendSubmit
+	case "enter":
+		message, submit, targets := m.sendInput.Value, m.sendSubmit, m.sendTo
+		if strings.TrimSpace(message) == "" && !submit {
+			return m, nil
+		}
+		m.clearSendState()
+		m.status = "sending to " + sendTargetLabel(targets) + "…"
+		return m, m.sendCmd(targets, message, submit)
+	default:
+		m.sendInput.HandleKey(key)
+	}
+	return m, nil
+}
+
+func (m *Model) clearSendState() {
+	m.sending = false
+	m.sendTo = nil
+	m.sendInput.Reset()
+}
+
+// sendCmd sends message to every target in turn, carrying on past failures
+// so one dead pane doesn't keep the rest from getting the message.
+func (m Model) sendCmd(targets []worktree.WorktreeStatus, message string, submit bool) tea.Cmd {
+	send := m.sendToAgentFn
+	return func() tea.Msg {
+		var result sentMsg
+		for _, s := range targets {
+			if err := send(s, message, submit); err != nil {
+				result.failures = append(result.failures, s.Name+": "+err.Error())
+				continue
+			}
+			result.sent = append(result.sent, s.Name)
+		}
+		return result
+	}
+}
+
+// handleSent reports a finished send on the status line. Marks are cleared
+// once everything got through, so the next i goes back to the cursor row.
+func (m Model) handleSent(msg sentMsg) (tea.Model, tea.Cmd) {
+	if len(msg.failures) > 0 {
+		m.status = "send failed: " + strings.Join(msg.failures, "; ")
+		return m, nil
+	}
+	m.marked = map[string]bool{}
+	if len(msg.sent) == 1 {
+		m.status = "sent to " + msg.sent[0]
+	} else {
+		m.status = fmt.Sprintf("sent to %d agents", len(msg.sent))
+	}
+	return m, nil
+}
+
+// sendTargetLabel names targets for the popup title and status line.
+func sendTargetLabel(targets []worktree.WorktreeStatus) string {
+	if len(targets) == 1 {
+		return targets[0].Name
+	}
+	return fmt.Sprintf("%d agents", len(targets))
+}
+
+// renderSendPopup builds the raw (uncentered) send popup content; the
+// caller composites it over the dashboard background via Overlay.
+func (m Model) renderSendPopup() string {
+	maxW := min(m.width-2, 64)
+	submit := "[ ]"
+	if m.sendSubmit {
+		submit = "[x]"
+	}
+	lines := []string{
+		"> " + m.sendInput.RenderPlain(),
+		"",
+		submit + " press Enter after the message",
+		"",
+		"enter: send · tab: toggle Enter · esc: cancel",
+	}
+	return m.palette.BorderedPane("Send to "+sendTargetLabel(m.sendTo), maxW, lines)
+}
+
+// markGlyph is the branch-glyph column of a worktree row: a check when the
+// row is marked for a send, the usual ∕ (U+2215 DIVISION SLASH, one display
+// column) otherwise. styled selects the
+// palette-colored form for rows not wrapped in a parent style.
+func (m Model) markGlyph(s worktree.WorktreeStatus, styled bool) string {
+	if !m.marked[s.Path] {
+		if styled {
+			return m.palette.BranchLabel()
+		}
+		return "∕"
+	}
+	if styled {
+		return m.palette.HintKey.Render("✓")
+	}
+	return "✓"
+}
@@ -0,0 +1,155 @@
+package tuiworktree
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	tea "charm.land/bubbletea/v2"
+	"github.com/charmbracelet/x/ansi"
+
+	"github.com/cjairm/devgita/internal/tooling/worktree"
+)
+
+type sendCall struct {
+	name    string
+	message string
+	submit  bool
+}
+
+// typeKeys feeds each key of keys through Update.
+func typeKeys(m Model, keys ...tea.KeyPressMsg) (Model, tea.Cmd) {
+	var cmd tea.Cmd
+	for _, k := range keys {
+		var updated tea.Model
+		updated, cmd = m.Update(k)
+		m = updated.(Model)
+	}
+	return m, cmd
+}
+
+func key(r rune) tea.KeyPressMsg { return tea.KeyPressMsg{Code: r, Text: string(r)} }
+
+var (
+	enterKey = tea.KeyPressMsg{Code: tea.KeyEnter}
+	tabKey   = tea.KeyPressMsg{Code: tea.KeyTab}
+	escKey   = tea.KeyPressMsg{Code: tea.KeyEscape}
+)
+
+func recordSends(m *Model, err error) *[]sendCall {
+	var calls []sendCall
+	m.sendToAgentFn = func(s worktree.WorktreeStatus, message string, submit bool) error {
+		calls = append(calls, sendCall{s.Name, message, submit})
+		return err
+	}
+	return &calls
+}
+
+func TestSendToSelectedAgent(t *testing.T) {
+	m := makeTestModel(testStatuses())
+	calls := recordSends(&m, nil)
+
+	m, _ = typeKeys(m, key('i'))
+	if !m.sending {
+		t.Fatal("expected i to open the send popup")
+	}
+	if popup := ansi.Strip(m.renderContent()); !strings.Contains(popup, "Send to feature-a") {
+		t.Errorf("expected the popup to name the target, got:\n%s", popup)
+	}
+	m, _ = typeKeys(m, key('g'), key('o'))
+	updated, _ := m.Update(tea.PasteMsg{Content: " on"})
+	m = updated.(Model)
+
+	m, cmd := typeKeys(m, enterKey)
+	if m.sending {
+		t.Error("expected enter to close the popup")
+	}
+	for _, msg := range flattenCmd(cmd) {
+		updated, _ := m.Update(msg)
+		m = updated.(Model)
+	}
+	if len(*calls) != 1 || (*calls)[0] != (sendCall{"feature-a", "go on", true}) {
+		t.Errorf("expected one submitted send to feature-a, got %+v", *calls)
+	}
+	if m.status != "sent to feature-a" {
+		t.Errorf("unexpected status %q", m.status)
+	}
+}
+
+func TestSendTabTogglesEnterAndEscCancels(t *testing.T) {
+	m := makeTestModel(testStatuses())
+	calls := recordSends(&m, nil)
+
+	m, _ = typeKeys(m, key('i'), key('y'), tabKey)
+	if m.sendSubmit {
+		t.Fatal("expected tab to turn Enter off")
+	}
+	m, cmd := typeKeys(m, enterKey)
+	flattenCmd(cmd)
+	if len(*calls) != 1 || (*calls)[0].submit {
+		t.Errorf("expected a send without Enter, got %+v", *calls)
+	}
+
+	m, _ = typeKeys(m, key('i'), key('n'), escKey)
+	if m.sending || m.sendInput.Value != "" {
+		t.Error("expected esc to close and clear the popup")
+	}
+	if len(*calls) != 1 {
+		t.Errorf("expected esc not to send, got %+v", *calls)
+	}
+}
+
+func TestBroadcastToMarkedAgents(t *testing.T) {
+	m := makeTestModel(testStatuses())
+	calls := recordSends(&m, nil)
+
+	// Mark feature-a (cursor moves to feature-b, which has no window), mark
+	// feature-b too, then feature-x.
+	m, _ = typeKeys(m, key('x'), key('x'), key('x'))
+	if len(m.marked) != 3 {
+		t.Fatalf("expected three marked worktrees, got %v", m.marked)
+	}
+	if left := ansi.Strip(m.renderLeft(40)); strings.Count(left, "✓") != 3 {
+		t.Errorf("expected marked rows to show a check, got:\n%s", left)
+	}
+
+	m, _ = typeKeys(m, key('i'))
+	if popup := ansi.Strip(m.renderContent()); !strings.Contains(popup, "Send to 2 agents") {
+		t.Errorf("expected only the windowed worktrees as targets, got:\n%s", popup)
+	}
+	m, cmd := typeKeys(m, key('k'), enterKey)
+	for _, msg := range flattenCmd(cmd) {
+		updated, _ := m.Update(msg)
+		m = updated.(Model)
+	}
+	if len(*calls) != 2 || (*calls)[0].name != "feature-a" || (*calls)[1].name != "feature-x" {
+		t.Errorf("expected sends to feature-a and feature-x, got %+v", *calls)
+	}
+	if m.status != "sent to 2 agents" || len(m.marked) != 0 {
+		t.Errorf("expected a broadcast confirmation and cleared marks, got %q, %v", m.status, m.marked)
+	}
+}
+
+func TestSendFailureKeepsMarks(t *testing.T) {
+	m := makeTestModel(testStatuses())
+	recordSends(&m, errors.New("can't find pane"))
+
+	m, _ = typeKeys(m, key('x'), key('i'), key('k'))
+	m, cmd := typeKeys(m, enterKey)
+	for _, msg := range flattenCmd(cmd) {
+		updated, _ := m.Update(msg)
+		m = updated.(Model)
+	}
+	if !strings.Contains(m.status, "feature-a: can't find pane") || len(m.marked) != 1 {
+		t.Errorf("expected the failure reported and the mark kept, got %q, %v", m.status, m.marked)
+	}
+}
+
+func TestSendWithoutWindowIsRefused(t *testing.T) {
+	m := makeTestModel(testStatuses())
+	m.moveCursor(1) // feature-b: no window
+	m, _ = typeKeys(m, key('i'))
+	if m.sending || !strings.Contains(m.status, "no tmux window") {
+		t.Errorf("expected i to be refused without a window, got sending=%v status=%q", m.sending, m.status)
+	}
+}
@@ -33,12 +33,15 @@ type row struct {
 // buildRows groups statuses by repo (alpha-sorted), applies filter, respects
 // collapsed map, then appends sessions (standalone tmux sessions with no
 // worktree-backed window) as leaf rows after every repo group — one flat
-// list: repo workspaces first, then plain sessions.
+// list: repo workspaces first, then plain sessions. A non-nil keep further
+// narrows the worktrees (the attention filter) and drops every session, which
+// has no agent to need attention.
 func buildRows(
 	statuses []worktree.WorktreeStatus,
 	sessions []worktree.SessionStatus,
 	collapsed map[string]bool,
 	filter string,
+	keep func(worktree.WorktreeStatus) bool,
 ) []row {
 	// Group by repo
 	groups := map[string][]worktree.WorktreeStatus{}
@@ -60,6 +63,9 @@ func buildRows(
 		// Filter: keep only children that match
 		var visible []worktree.WorktreeStatus
 		for _, s := range children {
+			if keep != nil && !keep(s) {
+				continue
+			}
 			if filter == "" || strings.Contains(strings.ToLower(repo+"/"+s.Name), filter) {
 				visible = append(visible, s)
 			}
@@ -79,6 +85,9 @@ func buildRows(
 	// appended after every repo group so they read as trailing leaves of one
 	// unified list. They have no children and are unaffected by any repo's
 	// collapsed state.
+	if keep != nil {
+		return rows
+	}
 	sorted := make([]worktree.SessionStatus, len(sessions))
 	copy(sorted, sessions)
 	sort.Slice(sorted, func(i, j int) bool { return sorted[i].Name < sorted[j].Name })
@@ -0,0 +1,266 @@
+package updates
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"os/exec"
+	"strings"
+	"sync"
+
+	"github.com/cjairm/devgita/pkg/constants"
+	gh "github.com/cjairm/devgita/pkg/github"
+)
+
+// RunFunc runs a command and returns its stdout. Sources default to
+// runCommand; tests replace it with canned output.
+type RunFunc func(ctx context.Context, name string, args ...string) ([]byte, error)
+
+// runCommand returns stdout even when the command exits non-zero, because
+// `brew outdated` and `mise outdated` use the exit status to signal "something
+// is outdated" while still printing the list. Only a failure with no output
+// is treated as an error.
+func runCommand(ctx context.Context, name string, args ...string) ([]byte, error) {
+	out, err := exec.CommandContext(ctx, name, args...).Output()
+	if err != nil && len(bytes.TrimSpace(out)) == 0 {
+		return nil, fmt.Errorf("%s %s: %w", name, strings.Join(args, " "), err)
+	}
+	return out, nil
+}
+
+func runOrDefault(run RunFunc) RunFunc {
+	if run == nil {
+		return runCommand
+	}
+	return run
+}
+
+// sourceKey maps a tracked item name to the name the source reports it under.
+func sourceKey(source, itemName string) string {
+	switch source {
+	case SourceApt:
+		return constants.GetDebianPackageName(itemName)
+	case SourceMise:
+		tool, _, _ := strings.Cut(itemName, "@")
+		return tool
+	}
+	return itemName
+}
+
+// filterNames keeps only entries for the requested names.
+func filterNames(all map[string]Versions, names []string) map[string]Versions {
+	out := make(map[string]Versions, len(names))
+	for _, n := range names {
+		if v, ok := all[n]; ok {
+			out[n] = v
+		}
+	}
+	return out
+}
+
+// BrewSource reads `brew outdated --json=v2`, which covers formulae and casks
+// in one call.
+type BrewSource struct {
+	Run RunFunc
+}
+
+func (s *BrewSource) Name() string            { return SourceBrew }
+func (s *BrewSource) ListsOnlyOutdated() bool { return true }
+
+func (s *BrewSource) Outdated(ctx context.Context, names []string) (map[string]Versions, error) {
+	out, err := runOrDefault(s.Run)(ctx, "brew", "outdated", "--json=v2")
+	if err != nil {
+		return nil, err
+	}
+	all, err := parseBrewOutdated(out)
+	if err != nil {
+		return nil, err
+	}
+	return filterNames(all, names), nil
+}
+
+type brewOutdatedEntry struct {
+	Name              string          `json:"name"`
+	InstalledVersions json.RawMessage `json:"installed_versions"`
+	CurrentVersion    string          `json:"current_version"`
+}
+
+func parseBrewOutdated(data []byte) (map[string]Versions, error) {
+	var doc struct {
+		Formulae []brewOutdatedEntry `json:"formulae"`
+		Casks    []brewOutdatedEntry `json:"casks"`
+	}
+	if err := json.Unmarshal(data, &doc); err != nil {
+		return nil, fmt.Errorf("failed to parse brew outdated output: %w", err)
+	}
+	out := map[string]Versions{}
+	for _, e := range append(doc.Formulae, doc.Casks...) {
+		out[e.Name] = Versions{
+			Installed: lastInstalledVersion(e.InstalledVersions),
+			Latest:    e.CurrentVersion,
+		}
+	}
+	return out, nil
+}
+
+// lastInstalledVersion handles installed_versions as either a list (formulae,
+// newer casks) or a bare string (older casks), returning the newest entry.
+func lastInstalledVersion(raw json.RawMessage) string {
+	var list []string
+	if err := json.Unmarshal(raw, &list); err == nil {
+		if len(list) == 0 {
+			return ""
+		}
+		return list[len(list)-1]
+	}
+	var single string
+	if err := json.Unmarshal(raw, &single); err == nil {
+		return single
+	}
+	return ""
+}
+
+// AptSource reads `apt list --upgradable`. PPA installs land here too: once
+// the repository is added, apt tracks them like any other package.
+type AptSource struct {
+	Run RunFunc
+}
+
+func (s *AptSource) Name() string            { return SourceApt }
+func (s *AptSource) ListsOnlyOutdated() bool { return true }
+
+func (s *AptSource) Outdated(ctx context.Context, names []string) (map[string]Versions, error) {
+	out, err := runOrDefault(s.Run)(ctx, "apt", "list", "--upgradable")
+	if err != nil {
+		return nil, err
+	}
+	return filterNames(parseAptUpgradable(out), names), nil
+}
+
+// parseAptUpgradable reads lines of the form
+//
+//	git/jammy-updates 1:2.34.1-1ubuntu1.11 amd64 [upgradable from: 1:2.34.1-1ubuntu1.10]
+//
+// skipping the "Listing..." banner and anything else that does not match.
+func parseAptUpgradable(data []byte) map[string]Versions {
+	out := map[string]Versions{}
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		name, rest, ok := strings.Cut(line, "/")
+		if !ok || name == "" {
+			continue
+		}
+		fields := strings.Fields(rest)
+		if len(fields) < 2 {
+			continue
+		}
+		v := Versions{Latest: fields[1]}
+		if _, from, ok := strings.Cut(line, "[upgradable from: "); ok {
+			v.Installed = strings.TrimSuffix(from, "]")
+		}
+		out[name] = v
+	}
+	return out
+}
+
+// MiseSource reads `mise outdated --json`, keyed by tool name (the part of a
+// language spec before "@").
+type MiseSource struct {
+	Run RunFunc
+}
+
+func (s *MiseSource) Name() string            { return SourceMise }
+func (s *MiseSource) ListsOnlyOutdated() bool { return true }
+
+func (s *MiseSource) Outdated(ctx context.Context, names []string) (map[string]Versions, error) {
+	out, err := runOrDefault(s.Run)(ctx, "mise", "outdated", "--json")
+	if err != nil {
+		return nil, err
+	}
+	all, err := parseMiseOutdated(out)
+	if err != nil {
+		return nil, err
+	}
+	return filterNames(all, names), nil
+}
+
+func parseMiseOutdated(data []byte) (map[string]Versions, error) {
+	var doc map[string]struct {
+		Current string `json:"current"`
+		Latest  string `json:"latest"`
+	}
+	if err := json.Unmarshal(data, &doc); err != nil {
+		return nil, fmt.Errorf("failed to parse mise outdated output: %w", err)
+	}
+	out := make(map[string]Versions, len(doc))
+	for tool, e := range doc {
+		out[tool] = Versions{Installed: e.Current, Latest: e.Latest}
+	}
+	return out, nil
+}
+
+// GitHubRepo is the release repository a GitHub-binary install is downloaded from.
+type GitHubRepo struct {
+	Owner, Repo string
+}
+
+// GitHubRepos lists the items devgita installs from GitHub releases.
+var GitHubRepos = map[string]GitHubRepo{
+	constants.LazyGit:    {"jesseduffield", "lazygit"},
+	constants.LazyDocker: {"jesseduffield", "lazydocker"},
+	constants.Rtk:        {"rtk-ai", "rtk"},
+	constants.Neovim:     {"neovim", "neovim"},
+}
+
+// GitHubSource looks up the latest release of each item, one request per
+// repository, all in flight at once.
+type GitHubSource struct {
+	Repos map[string]GitHubRepo
+	// FetchLatest defaults to github.FetchLatestRelease.
+	FetchLatest func(owner, repo string) (string, error)
+}
+
+func (s *GitHubSource) Name() string            { return SourceGitHub }
+func (s *GitHubSource) ListsOnlyOutdated() bool { return false }
+
+func (s *GitHubSource) Outdated(ctx context.Context, names []string) (map[string]Versions, error) {
+	fetch := s.FetchLatest
+	if fetch == nil {
+		fetch = gh.FetchLatestRelease
+	}
+	var (
+		mu       sync.Mutex
+		wg       sync.WaitGroup
+		out      = map[string]Versions{}
+		firstErr error
+	)
+	for _, name := range names {
+		repo, ok := s.Repos[name]
+		if !ok {
+			continue
+		}
+		wg.Add(1)
+		go func(name string, repo GitHubRepo) {
+			defer wg.Done()
+			latest, err := fetch(repo.Owner, repo.Repo)
+			mu.Lock()
+			defer mu.Unlock()
+			if err != nil {
+				if firstErr == nil {
+					firstErr = fmt.Errorf("%s/%s: %w", repo.Owner, repo.Repo, err)
+				}
+				return
+			}
+			out[name] = Versions{Latest: latest}
+		}(name, repo)
+	}
+	wg.Wait()
+	// A partial answer is more useful than none: only fail when nothing came back.
+	if len(out) == 0 && firstErr != nil {
+		return nil, firstErr
+	}
+	return out, nil
+}
@@ -0,0 +1,149 @@
+package updates
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+)
+
+func cannedRun(t *testing.T, wantCmd string, out string) RunFunc {
+	t.Helper()
+	return func(_ context.Context, name string, args ...string) ([]byte, error) {
+		if got := name + " " + strings.Join(args, " "); got != wantCmd {
+			t.Errorf("ran %q, want %q", got, wantCmd)
+		}
+		return []byte(out), nil
+	}
+}
+
+func TestBrewSource_ParsesFormulaeAndCasks(t *testing.T) {
+	out := `{
+  "formulae": [
+    {"name": "git", "installed_versions": ["2.43.0", "2.44.0"], "current_version": "2.45.0", "pinned": false}
+  ],
+  "casks": [
+    {"name": "alacritty", "installed_versions": "0.13.1", "current_version": "0.13.2"},
+    {"name": "raycast", "installed_versions": ["1.70.0"], "current_version": "1.71.0"}
+  ]
+}`
+	s := &BrewSource{Run: cannedRun(t, "brew outdated --json=v2", out)}
+
+	got, err := s.Outdated(context.Background(), []string{"git", "alacritty", "tmux"})
+	if err != nil {
+		t.Fatalf("Outdated: %v", err)
+	}
+
+	if got["git"] != (Versions{Installed: "2.44.0", Latest: "2.45.0"}) {
+		t.Errorf("git: %+v", got["git"])
+	}
+	if got["alacritty"] != (Versions{Installed: "0.13.1", Latest: "0.13.2"}) {
+		t.Errorf("alacritty: %+v", got["alacritty"])
+	}
+	if _, ok := got["raycast"]; ok {
+		t.Error("items that were not asked for should be dropped")
+	}
+	if _, ok := got["tmux"]; ok {
+		t.Error("tmux is not outdated")
+	}
+}
+
+func TestBrewSource_BadJSON(t *testing.T) {
+	s := &BrewSource{Run: cannedRun(t, "brew outdated --json=v2", "Error: not json")}
+
+	if _, err := s.Outdated(context.Background(), []string{"git"}); err == nil {
+		t.Fatal("expected a parse error")
+	}
+}
+
+func TestAptSource_ParsesUpgradableList(t *testing.T) {
+	out := `Listing... Done
+git/jammy-updates 1:2.34.1-1ubuntu1.11 amd64 [upgradable from: 1:2.34.1-1ubuntu1.10]
+fd-find/jammy 8.4.0-1 amd64 [upgradable from: 8.3.1-1]
+`
+	s := &AptSource{Run: cannedRun(t, "apt list --upgradable", out)}
+
+	got, err := s.Outdated(context.Background(), []string{"git", "fd-find", "tmux"})
+	if err != nil {
+		t.Fatalf("Outdated: %v", err)
+	}
+
+	if got["git"] != (Versions{Installed: "1:2.34.1-1ubuntu1.10", Latest: "1:2.34.1-1ubuntu1.11"}) {
+		t.Errorf("git: %+v", got["git"])
+	}
+	if got["fd-find"].Latest != "8.4.0-1" {
+		t.Errorf("fd-find: %+v", got["fd-find"])
+	}
+	if len(got) != 2 {
+		t.Errorf("expected 2 entries, got %v", got)
+	}
+}
+
+func TestMiseSource_ParsesOutdatedJSON(t *testing.T) {
+	out := `{"node": {"name": "node", "requested": "lts", "current": "20.11.0", "latest": "20.12.0"}}`
+	s := &MiseSource{Run: cannedRun(t, "mise outdated --json", out)}
+
+	got, err := s.Outdated(context.Background(), []string{"node", "python"})
+	if err != nil {
+		t.Fatalf("Outdated: %v", err)
+	}
+
+	if got["node"] != (Versions{Installed: "20.11.0", Latest: "20.12.0"}) {
+		t.Errorf("node: %+v", got["node"])
+	}
+	if _, ok := got["python"]; ok {
+		t.Error("python is not outdated")
+	}
+}
+
+func TestSourceRunError(t *testing.T) {
+	fail := func(context.Context, string, ...string) ([]byte, error) {
+		return nil, errors.New("command not found")
+	}
+	for _, s := range []Source{&BrewSource{Run: fail}, &AptSource{Run: fail}, &MiseSource{Run: fail}} {
+		if _, err := s.Outdated(context.Background(), []string{"x"}); err == nil {
+			t.Errorf("%s: expected the run error to surface", s.Name())
+		}
+	}
+}
+
+func TestGitHubSource_FetchesKnownRepos(t *testing.T) {
+	var asked []string
+	s := &GitHubSource{
+		Repos: map[string]GitHubRepo{"lazygit": {"jesseduffield", "lazygit"}, "rtk": {"rtk-ai", "rtk"}},
+		FetchLatest: func(owner, repo string) (string, error) {
+			if repo == "rtk" {
+				return "", errors.New("rate limited")
+			}
+			asked = append(asked, owner+"/"+repo)
+			return "0.45.0", nil
+		},
+	}
+
+	got, err := s.Outdated(context.Background(), []string{"lazygit", "rtk", "git"})
+	if err != nil {
+		t.Fatalf("a partial answer should not be an error: %v", err)
+	}
+
+	if got["lazygit"].Latest != "0.45.0" {
+		t.Errorf("lazygit: %+v", got["lazygit"])
+	}
+	if _, ok := got["rtk"]; ok {
+		t.Error("rtk failed and should be absent")
+	}
+	if len(asked) != 1 || asked[0] != "jesseduffield/lazygit" {
+		t.Errorf("unexpected requests: %v", asked)
+	}
+}
+
+func TestGitHubSource_AllFailed(t *testing.T) {
+	s := &GitHubSource{
+		Repos:       map[string]GitHubRepo{"rtk": {"rtk-ai", "rtk"}},
+		FetchLatest: func(string, string) (string, error) { return "", errors.New("offline") },
+	}
+
+	if _, err := s.Outdated(context.Background(), []string{"rtk"}); err == nil ||
+		!strings.Contains(err.Error(), "rtk-ai/rtk") {
+		t.Fatalf("expected an error naming the repo, got %v", err)
+	}
+}
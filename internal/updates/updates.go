@@ -0,0 +1,322 @@
+// Package updates finds newer versions of the items devgita tracks.
+//
+// Each tracked item is routed to the Source that manages it, chosen by the
+// install method recorded in global_config.yaml: Homebrew, apt (which also
+// covers PPAs), mise for languages, and GitHub releases for binaries devgita
+// downloads itself. Every source is queried once, concurrently, under a
+// shared timeout; a source that fails or times out marks only its own items
+// as unknown.
+package updates
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/cjairm/devgita/internal/config"
+)
+
+// Status is the outcome of checking one item.
+type Status string
+
+const (
+	StatusUpToDate Status = "up-to-date"
+	StatusOutdated Status = "outdated"
+	// StatusUnknown: the source failed, timed out, or the item has no source
+	// (script and git-clone installs, fonts). Error says which.
+	StatusUnknown Status = "unknown"
+)
+
+// Source names, as they appear in Result.Source.
+const (
+	SourceBrew   = "brew"
+	SourceApt    = "apt"
+	SourceMise   = "mise"
+	SourceGitHub = "github"
+)
+
+// DefaultTimeout bounds a whole check when the caller sets none.
+const DefaultTimeout = 30 * time.Second
+
+// Versions is what a source knows about one item. Installed may be empty
+// (GitHub only knows the latest release), in which case the version recorded
+// in global_config.yaml is used.
+type Versions struct {
+	Installed string
+	Latest    string
+}
+
+// Source reports available versions for the items it manages.
+type Source interface {
+	Name() string
+	// Outdated returns versions keyed by the names it was given. Sources that
+	// can list only what is outdated (brew, apt, mise) omit up-to-date items;
+	// sources that look items up one by one (GitHub) return every item they
+	// found.
+	Outdated(ctx context.Context, names []string) (map[string]Versions, error)
+	// ListsOnlyOutdated reports whether an item missing from Outdated's result
+	// means "up to date" rather than "not found".
+	ListsOnlyOutdated() bool
+}
+
+// Result is the check outcome for one tracked item.
+type Result struct {
+	Name     string `json:"name"`
+	Category string `json:"category"`
+	Source   string `json:"source,omitempty"`
+	Current  string `json:"current,omitempty"`
+	Latest   string `json:"latest,omitempty"`
+	Status   Status `json:"status"`
+	Error    string `json:"error,omitempty"`
+}
+
+// Checker routes tracked items to sources and collects the results.
+type Checker struct {
+	Sources map[string]Source // keyed by Source.Name()
+	Timeout time.Duration     // zero means DefaultTimeout
+	IsMac   bool              // routes items tracked before install methods were recorded
+}
+
+// New returns a Checker wired to the real package managers and GitHub.
+func New(isMac bool) *Checker {
+	return &Checker{
+		Sources: map[string]Source{
+			SourceBrew:   &BrewSource{},
+			SourceApt:    &AptSource{},
+			SourceMise:   &MiseSource{},
+			SourceGitHub: &GitHubSource{Repos: GitHubRepos},
+		},
+		Timeout: DefaultTimeout,
+		IsMac:   isMac,
+	}
+}
+
+// tracked is one item from global_config.yaml with the key the source knows
+// it by (the apt package name, the mise tool name).
+type tracked struct {
+	item     config.TrackedItem
+	category string
+	key      string
+}
+
+// Check queries every source concurrently and returns one Result per tracked
+// item, sorted by category order then name.
+func (c *Checker) Check(ctx context.Context, gc *config.GlobalConfig) []Result {
+	timeout := c.Timeout
+	if timeout <= 0 {
+		timeout = DefaultTimeout
+	}
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	bySource := map[string][]tracked{}
+	var results []Result
+	for _, t := range collectTracked(gc) {
+		name := c.route(t)
+		if _, ok := c.Sources[name]; name == "" || !ok {
+			results = append(results, Result{
+				Name:     t.item.Name,
+				Category: t.category,
+				Current:  t.item.Version,
+				Status:   StatusUnknown,
+				Error:    noSourceReason(t.item.Method),
+			})
+			continue
+		}
+		t.key = sourceKey(name, t.item.Name)
+		bySource[name] = append(bySource[name], t)
+	}
+
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+	for name, items := range bySource {
+		wg.Add(1)
+		go func(src Source, items []tracked) {
+			defer wg.Done()
+			found, err := queryWithContext(ctx, src, uniqueKeys(items))
+			if err != nil && errors.Is(ctx.Err(), context.DeadlineExceeded) {
+				err = fmt.Errorf("timed out after %s", timeout)
+			}
+			mu.Lock()
+			defer mu.Unlock()
+			for _, t := range items {
+				results = append(results, resolve(src, t, found, err))
+			}
+		}(c.Sources[name], items)
+	}
+	wg.Wait()
+
+	sortResults(results)
+	return results
+}
+
+// queryWithContext runs src.Outdated but returns as soon as ctx is done, so a
+// source that ignores cancellation cannot hold up the whole check.
+func queryWithContext(
+	ctx context.Context,
+	src Source,
+	names []string,
+) (map[string]Versions, error) {
+	type reply struct {
+		found map[string]Versions
+		err   error
+	}
+	ch := make(chan reply, 1)
+	go func() {
+		found, err := src.Outdated(ctx, names)
+		ch <- reply{found, err}
+	}()
+	select {
+	case r := <-ch:
+		return r.found, r.err
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
+func resolve(src Source, t tracked, found map[string]Versions, err error) Result {
+	r := Result{
+		Name:     t.item.Name,
+		Category: t.category,
+		Source:   src.Name(),
+		Current:  t.item.Version,
+	}
+	if err != nil {
+		r.Status = StatusUnknown
+		r.Error = err.Error()
+		return r
+	}
+	v, ok := found[t.key]
+	if !ok {
+		if src.ListsOnlyOutdated() {
+			r.Status = StatusUpToDate
+			r.Latest = r.Current
+		} else {
+			r.Status = StatusUnknown
+			r.Error = "no version found"
+		}
+		return r
+	}
+	if v.Installed != "" {
+		r.Current = v.Installed
+	}
+	r.Latest = v.Latest
+	switch {
+	case r.Latest == "":
+		r.Status = StatusUnknown
+		r.Error = "no version found"
+	case r.Current == "":
+		r.Status = StatusUnknown
+		r.Error = "installed version not recorded"
+	case normalizeVersion(r.Current) == normalizeVersion(r.Latest):
+		r.Status = StatusUpToDate
+	default:
+		r.Status = StatusOutdated
+	}
+	return r
+}
+
+// route picks the source for an item from its recorded install method,
+// falling back to the platform package manager (or mise, for versioned
+// language specs) for items tracked before methods were recorded.
+func (c *Checker) route(t tracked) string {
+	switch t.item.Method {
+	case config.MethodBrew:
+		return SourceBrew
+	case config.MethodApt, config.MethodPPA:
+		return SourceApt
+	case config.MethodMise:
+		return SourceMise
+	case config.MethodGitHubBinary:
+		return SourceGitHub
+	case "":
+		if t.category == "fonts" || t.category == "themes" {
+			return ""
+		}
+		if t.category == "dev_languages" && strings.Contains(t.item.Name, "@") {
+			return SourceMise
+		}
+		if c.IsMac {
+			return SourceBrew
+		}
+		if _, ok := GitHubRepos[t.item.Name]; ok {
+			return SourceGitHub
+		}
+		return SourceApt
+	}
+	return ""
+}
+
+func noSourceReason(method config.InstallMethod) string {
+	if method == "" {
+		return "no update source for this category"
+	}
+	return fmt.Sprintf("no update source for %s installs", method)
+}
+
+func uniqueKeys(items []tracked) []string {
+	seen := map[string]bool{}
+	var keys []string
+	for _, t := range items {
+		if !seen[t.key] {
+			seen[t.key] = true
+			keys = append(keys, t.key)
+		}
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// normalizeVersion drops a leading "v" so "v0.44.1" and "0.44.1" compare equal.
+func normalizeVersion(v string) string {
+	return strings.TrimPrefix(strings.TrimSpace(v), "v")
+}
+
+// categoryOrder matches the dg list display order.
+var categoryOrder = map[string]int{
+	"packages": 0, "desktop_apps": 1, "fonts": 2, "themes": 3,
+	"terminal_tools": 4, "dev_languages": 5, "databases": 6,
+}
+
+func sortResults(results []Result) {
+	sort.SliceStable(results, func(i, j int) bool {
+		a, b := results[i], results[j]
+		if a.Category != b.Category {
+			return categoryOrder[a.Category] < categoryOrder[b.Category]
+		}
+		return a.Name < b.Name
+	})
+}
+
+// collectTracked flattens both buckets of every category, dropping an item
+// tracked in both (its installed record wins).
+func collectTracked(gc *config.GlobalConfig) []tracked {
+	categories := []struct {
+		key               string
+		installed, exists config.TrackedItems
+	}{
+		{"packages", gc.Installed.Packages, gc.AlreadyInstalled.Packages},
+		{"desktop_apps", gc.Installed.DesktopApps, gc.AlreadyInstalled.DesktopApps},
+		{"fonts", gc.Installed.Fonts, gc.AlreadyInstalled.Fonts},
+		{"themes", gc.Installed.Themes, gc.AlreadyInstalled.Themes},
+		{"terminal_tools", gc.Installed.TerminalTools, gc.AlreadyInstalled.TerminalTools},
+		{"dev_languages", gc.Installed.DevLanguages, gc.AlreadyInstalled.DevLanguages},
+		{"databases", gc.Installed.Databases, gc.AlreadyInstalled.Databases},
+	}
+	var out []tracked
+	for _, c := range categories {
+		for _, item := range c.installed {
+			out = append(out, tracked{item: item, category: c.key})
+		}
+		for _, item := range c.exists {
+			if !c.installed.Contains(item.Name) {
+				out = append(out, tracked{item: item, category: c.key})
+			}
+		}
+	}
+	return out
+}
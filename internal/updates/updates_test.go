@@ -0,0 +1,195 @@
+package updates
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/cjairm/devgita/internal/config"
+)
+
+type fakeSource struct {
+	name         string
+	onlyOutdated bool
+	found        map[string]Versions
+	err          error
+	delay        time.Duration
+	gotNames     []string
+}
+
+func (f *fakeSource) Name() string            { return f.name }
+func (f *fakeSource) ListsOnlyOutdated() bool { return f.onlyOutdated }
+
+func (f *fakeSource) Outdated(ctx context.Context, names []string) (map[string]Versions, error) {
+	f.gotNames = names
+	if f.delay > 0 {
+		time.Sleep(f.delay)
+	}
+	return f.found, f.err
+}
+
+func byName(results []Result) map[string]Result {
+	out := map[string]Result{}
+	for _, r := range results {
+		out[r.Name] = r
+	}
+	return out
+}
+
+func TestCheck_RoutesByInstallMethod(t *testing.T) {
+	brew := &fakeSource{name: SourceBrew, onlyOutdated: true,
+		found: map[string]Versions{"git": {Installed: "2.44.0", Latest: "2.45.0"}}}
+	apt := &fakeSource{name: SourceApt, onlyOutdated: true,
+		found: map[string]Versions{"fd-find": {Installed: "8.3.1", Latest: "8.4.0"}}}
+	mise := &fakeSource{name: SourceMise, onlyOutdated: true}
+	github := &fakeSource{name: SourceGitHub,
+		found: map[string]Versions{"lazygit": {Latest: "0.45.0"}, "rtk": {Latest: "v1.0.0"}}}
+	c := &Checker{Sources: map[string]Source{
+		SourceBrew: brew, SourceApt: apt, SourceMise: mise, SourceGitHub: github,
+	}}
+
+	gc := &config.GlobalConfig{}
+	gc.Installed.Packages = config.TrackedItems{
+		{Name: "git", Version: "2.44.0", Method: config.MethodBrew},
+		{Name: "fd", Version: "8.3.1", Method: config.MethodApt},
+		{Name: "lazygit", Version: "0.44.1", Method: config.MethodGitHubBinary},
+		{Name: "rtk", Version: "1.0.0", Method: config.MethodGitHubBinary},
+		{Name: "mise", Method: config.MethodScript},
+	}
+	gc.Installed.DevLanguages = config.TrackedItems{
+		{Name: "node@lts", Version: "lts", Method: config.MethodMise},
+	}
+	gc.Installed.Fonts = config.NewTrackedItems("JetBrainsMono")
+
+	got := byName(c.Check(context.Background(), gc))
+
+	if r := got["git"]; r.Status != StatusOutdated || r.Source != SourceBrew || r.Latest != "2.45.0" {
+		t.Errorf("git: %+v", r)
+	}
+	if r := got["fd"]; r.Status != StatusOutdated || r.Current != "8.3.1" || r.Latest != "8.4.0" {
+		t.Errorf("fd should be looked up under its Debian name: %+v", r)
+	}
+	if r := got["lazygit"]; r.Status != StatusOutdated || r.Current != "0.44.1" {
+		t.Errorf("lazygit: %+v", r)
+	}
+	if r := got["rtk"]; r.Status != StatusUpToDate {
+		t.Errorf("rtk: a leading v should not count as a difference: %+v", r)
+	}
+	if r := got["node@lts"]; r.Status != StatusUpToDate || r.Source != SourceMise {
+		t.Errorf("node@lts: absent from mise outdated means up to date: %+v", r)
+	}
+	if len(mise.gotNames) != 1 || mise.gotNames[0] != "node" {
+		t.Errorf("mise should be asked for the tool name, got %v", mise.gotNames)
+	}
+	if r := got["mise"]; r.Status != StatusUnknown || !strings.Contains(r.Error, "script") {
+		t.Errorf("script installs have no source: %+v", r)
+	}
+	if r := got["JetBrainsMono"]; r.Status != StatusUnknown || r.Source != "" {
+		t.Errorf("fonts have no source: %+v", r)
+	}
+}
+
+func TestCheck_LegacyItemsRouteByPlatform(t *testing.T) {
+	gc := &config.GlobalConfig{}
+	gc.AlreadyInstalled.Packages = config.NewTrackedItems("curl", "lazydocker")
+
+	mac := &fakeSource{name: SourceBrew, onlyOutdated: true}
+	c := &Checker{IsMac: true, Sources: map[string]Source{SourceBrew: mac}}
+	for _, r := range c.Check(context.Background(), gc) {
+		if r.Source != SourceBrew {
+			t.Errorf("on macOS %s should go to brew, got %q", r.Name, r.Source)
+		}
+	}
+
+	apt := &fakeSource{name: SourceApt, onlyOutdated: true}
+	github := &fakeSource{name: SourceGitHub}
+	c = &Checker{Sources: map[string]Source{SourceApt: apt, SourceGitHub: github}}
+	got := byName(c.Check(context.Background(), gc))
+	if got["curl"].Source != SourceApt || got["lazydocker"].Source != SourceGitHub {
+		t.Errorf("unexpected linux routing: %+v", got)
+	}
+}
+
+func TestCheck_SourceErrorOnlyAffectsItsItems(t *testing.T) {
+	gc := &config.GlobalConfig{}
+	gc.Installed.Packages = config.TrackedItems{
+		{Name: "git", Version: "2.44.0", Method: config.MethodBrew},
+		{Name: "lazygit", Version: "0.44.1", Method: config.MethodGitHubBinary},
+	}
+	c := &Checker{Sources: map[string]Source{
+		SourceBrew:   &fakeSource{name: SourceBrew, err: errors.New("brew exploded")},
+		SourceGitHub: &fakeSource{name: SourceGitHub, found: map[string]Versions{"lazygit": {Latest: "0.44.1"}}},
+	}}
+
+	got := byName(c.Check(context.Background(), gc))
+
+	if r := got["git"]; r.Status != StatusUnknown || r.Error != "brew exploded" {
+		t.Errorf("git: %+v", r)
+	}
+	if r := got["lazygit"]; r.Status != StatusUpToDate {
+		t.Errorf("lazygit: %+v", r)
+	}
+}
+
+func TestCheck_TimesOutSlowSources(t *testing.T) {
+	gc := &config.GlobalConfig{}
+	gc.Installed.Packages = config.TrackedItems{
+		{Name: "git", Version: "2.44.0", Method: config.MethodBrew},
+		{Name: "lazygit", Version: "0.44.1", Method: config.MethodGitHubBinary},
+	}
+	c := &Checker{
+		Timeout: 50 * time.Millisecond,
+		Sources: map[string]Source{
+			SourceBrew: &fakeSource{name: SourceBrew, onlyOutdated: true, delay: 2 * time.Second},
+			SourceGitHub: &fakeSource{name: SourceGitHub,
+				found: map[string]Versions{"lazygit": {Latest: "0.45.0"}}},
+		},
+	}
+
+	start := time.Now()
+	got := byName(c.Check(context.Background(), gc))
+
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Fatalf("Check should return at the timeout, took %s", elapsed)
+	}
+	if r := got["git"]; r.Status != StatusUnknown || !strings.Contains(r.Error, "timed out") {
+		t.Errorf("git: %+v", r)
+	}
+	if r := got["lazygit"]; r.Status != StatusOutdated {
+		t.Errorf("a fast source should still report: %+v", r)
+	}
+}
+
+func TestCheck_MissingCurrentVersionIsUnknown(t *testing.T) {
+	gc := &config.GlobalConfig{}
+	gc.Installed.Packages = config.TrackedItems{{Name: "lazygit", Method: config.MethodGitHubBinary}}
+	c := &Checker{Sources: map[string]Source{
+		SourceGitHub: &fakeSource{name: SourceGitHub, found: map[string]Versions{"lazygit": {Latest: "0.45.0"}}},
+	}}
+
+	r := c.Check(context.Background(), gc)[0]
+
+	if r.Status != StatusUnknown || r.Latest != "0.45.0" {
+		t.Errorf("expected unknown with the latest version shown, got %+v", r)
+	}
+}
+
+func TestCheck_SortsByCategoryThenName(t *testing.T) {
+	gc := &config.GlobalConfig{}
+	gc.Installed.Databases = config.NewTrackedItems("redis")
+	gc.Installed.Packages = config.NewTrackedItems("tmux", "git")
+	c := &Checker{IsMac: true, Sources: map[string]Source{
+		SourceBrew: &fakeSource{name: SourceBrew, onlyOutdated: true},
+	}}
+
+	var names []string
+	for _, r := range c.Check(context.Background(), gc) {
+		names = append(names, r.Name)
+	}
+
+	if strings.Join(names, ",") != "git,tmux,redis" {
+		t.Errorf("got order %v", names)
+	}
+}
@@ -4,6 +4,8 @@ Copyright © 2024 NAME HERE <EMAIL ADDRESS>
 package main
 
 import (
+	"io/fs"
+
 	"github.com/cjairm/devgita/cmd"
 	"github.com/cjairm/devgita/internal/embedded"
 )
@@ -11,6 +13,9 @@ import (
 func main() {
 	// Set the default extractor function for devgita app
 	embedded.DefaultExtractor = ExtractEmbeddedConfigs
+	if configs, err := fs.Sub(ConfigsFS, "configs"); err == nil {
+		embedded.DefaultFS = configs
+	}
 
 	cmd.Execute()
 }
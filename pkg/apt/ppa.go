@@ -6,6 +6,7 @@ import (
 	"os/exec"
 	"path/filepath"
 
+	"github.com/cjairm/devgita/pkg/dryrun"
 	"github.com/cjairm/devgita/pkg/logger"
 )
 
@@ -29,12 +30,20 @@ func NewPPAManager() *PPAManager {
 	return &PPAManager{}
 }
 
-// AddPPA adds a PPA repository to the system
-// This function is idempotent - it checks if the PPA is already configured before making changes
-func (pm *PPAManager) AddPPA(config PPAConfig) error {
+// ppaSetup is what adding a PPA writes, derived from its config.
+type ppaSetup struct {
+	keyringPath string
+	sourcesFile string
+	repoEntry   string
+	configured  bool // the sources file already exists; nothing to do
+}
+
+// prepare validates config, fills in its defaults, and derives the keyring,
+// sources file and repository entry AddPPA would write.
+func (pm *PPAManager) prepare(config PPAConfig) (PPAConfig, ppaSetup, error) {
 	// Validate configuration
 	if config.Name == "" || config.KeyURL == "" || config.RepoURL == "" {
-		return fmt.Errorf("invalid PPA config: Name, KeyURL, and RepoURL are required")
+		return config, ppaSetup{}, fmt.Errorf("invalid PPA config: Name, KeyURL, and RepoURL are required")
 	}
 
 	// Set default values
@@ -49,18 +58,50 @@ func (pm *PPAManager) AddPPA(config PPAConfig) error {
 	if config.Architecture == "" {
 		arch, err := pm.detectArchitecture()
 		if err != nil {
-			return fmt.Errorf("failed to detect architecture: %w", err)
+			return config, ppaSetup{}, fmt.Errorf("failed to detect architecture: %w", err)
 		}
 		config.Architecture = arch
 	}
 
 	// Derive paths
-	keyringPath := fmt.Sprintf("/etc/apt/keyrings/%s-archive-keyring.gpg", config.Name)
-	sourcesFile := fmt.Sprintf("/etc/apt/sources.list.d/%s.list", config.Name)
+	setup := ppaSetup{
+		keyringPath: fmt.Sprintf("/etc/apt/keyrings/%s-archive-keyring.gpg", config.Name),
+		sourcesFile: fmt.Sprintf("/etc/apt/sources.list.d/%s.list", config.Name),
+	}
+	setup.configured = fileExists(setup.sourcesFile)
+	setup.repoEntry = fmt.Sprintf("deb [signed-by=%s arch=%s] %s %s %s",
+		setup.keyringPath, config.Architecture, config.RepoURL, config.Distribution, config.Component)
+	return config, setup, nil
+}
+
+// PlanPPA describes the steps AddPPA would take for config without taking
+// any of them, for a dry run. It returns no steps when the PPA is already
+// configured.
+func (pm *PPAManager) PlanPPA(config PPAConfig) ([]dryrun.Step, error) {
+	config, setup, err := pm.prepare(config)
+	if err != nil || setup.configured {
+		return nil, err
+	}
+	return []dryrun.Step{
+		{Kind: dryrun.KindExec, Target: "sudo apt install -y gpg wget curl"},
+		{Kind: dryrun.KindExec, Target: "sudo install -dm 755 /etc/apt/keyrings"},
+		{Kind: dryrun.KindDownload, Target: config.KeyURL, Detail: "dearmored to " + setup.keyringPath},
+		{Kind: dryrun.KindCreate, Target: setup.sourcesFile, Detail: setup.repoEntry},
+		{Kind: dryrun.KindExec, Target: "sudo apt update"},
+	}, nil
+}
+
+// AddPPA adds a PPA repository to the system
+// This function is idempotent - it checks if the PPA is already configured before making changes
+func (pm *PPAManager) AddPPA(config PPAConfig) error {
+	config, setup, err := pm.prepare(config)
+	if err != nil {
+		return err
+	}
 
 	// Check if already configured
-	if fileExists(sourcesFile) {
-		logger.L().Infow("PPA already configured", "name", config.Name, "sources_file", sourcesFile)
+	if setup.configured {
+		logger.L().Infow("PPA already configured", "name", config.Name, "sources_file", setup.sourcesFile)
 		return nil
 	}
 
@@ -77,15 +118,12 @@ func (pm *PPAManager) AddPPA(config PPAConfig) error {
 	}
 
 	// Step 3: Download and install GPG key
-	if err := pm.installGPGKey(config.KeyURL, keyringPath); err != nil {
+	if err := pm.installGPGKey(config.KeyURL, setup.keyringPath); err != nil {
 		return fmt.Errorf("failed to install GPG key: %w", err)
 	}
 
 	// Step 4: Create repository entry
-	repoEntry := fmt.Sprintf("deb [signed-by=%s arch=%s] %s %s %s",
-		keyringPath, config.Architecture, config.RepoURL, config.Distribution, config.Component)
-
-	if err := pm.createRepositoryEntry(repoEntry, sourcesFile); err != nil {
+	if err := pm.createRepositoryEntry(setup.repoEntry, setup.sourcesFile); err != nil {
 		return fmt.Errorf("failed to create repository entry: %w", err)
 	}
 
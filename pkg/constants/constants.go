@@ -114,6 +114,7 @@ const (
 	Gimp               = "gimp"
 	Git                = "git"
 	GithubCli          = "gh"
+	GitlabCli          = "glab"
 	I3                 = "i3"
 	Jemalloc           = "jemalloc"
 	LazyDocker         = "lazydocker"
@@ -139,6 +140,7 @@ const (
 	Syntaxhighlighting = "zsh-syntax-highlighting"
 	Tldr               = "tldr"
 	Tmux               = "tmux"
+	Zellij             = "zellij"
 	TreeSitterCli      = "tree-sitter-cli"  // Same name on both Homebrew and apt (trixie/sid)
 	Markdownlint       = "markdownlint-cli" // Brew/npm package; binary is `markdownlint`
 	Flake8             = "flake8"
@@ -11,6 +11,7 @@ import (
 	"strings"
 	"time"
 
+	"github.com/cjairm/devgita/pkg/dryrun"
 	"github.com/cjairm/devgita/pkg/logger"
 )
 
@@ -70,6 +71,10 @@ func IsRetryableError(err error) bool {
 // DownloadFileWithRetry downloads a file with retry logic and exponential backoff
 // Returns error if all retry attempts fail or a non-retryable error is encountered
 func DownloadFileWithRetry(ctx context.Context, url, destPath string, config RetryConfig) error {
+	if dryrun.Active() {
+		dryrun.Record(dryrun.KindDownload, url, "to "+destPath)
+		return nil
+	}
 	var lastErr error
 
 	for attempt := 0; attempt <= config.MaxRetries; attempt++ {
@@ -0,0 +1,210 @@
+// Package dryrun records the side effects devgita would perform — commands,
+// downloads, file writes and removals, global config mutations — into a Plan
+// instead of performing them.
+//
+// Commands are recorded by their executor: while a plan is set with
+// commands.RecordTo, the commands package hands out recording
+// implementations of Command and BaseCommandExecutor that add each mutating
+// invocation to the plan and still run read-only probes (brew list, dpkg -l,
+// version checks), so the plan reflects what is actually missing.
+//
+// File writes have no executor to swap — they go through plain functions in
+// pkg/files, pkg/downloader and config.GlobalConfig.Save — so they are the one
+// thing switched process-wide: Enable turns file-write recording on, and
+// those writers check Active() and record into the enabled plan instead of
+// touching disk.
+package dryrun
+
+import (
+	"fmt"
+	"io"
+	"path/filepath"
+	"strings"
+	"sync"
+)
+
+// Kind classifies a recorded step.
+type Kind string
+
+const (
+	KindExec      Kind = "run"
+	KindDownload  Kind = "download"
+	KindCreate    Kind = "create"
+	KindOverwrite Kind = "overwrite"
+	KindAppend    Kind = "append"
+	KindRemove    Kind = "remove"
+	KindConfig    Kind = "config"
+)
+
+// Step is one side effect that a real run would have performed.
+type Step struct {
+	Kind   Kind
+	Target string // command line, URL, or file path
+	Detail string // optional extra context (e.g. global config changes, one per line)
+}
+
+// Plan is the ordered list of side effects a dry run would have performed.
+// It is safe for concurrent use.
+type Plan struct {
+	mu    sync.Mutex
+	steps []Step
+}
+
+// NewPlan returns an empty plan.
+func NewPlan() *Plan {
+	return &Plan{}
+}
+
+// Record appends a step to the plan.
+func (p *Plan) Record(kind Kind, target, detail string) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.steps = append(p.steps, Step{Kind: kind, Target: target, Detail: detail})
+}
+
+// Steps returns a copy of the recorded steps in the order they happened.
+func (p *Plan) Steps() []Step {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	out := make([]Step, len(p.steps))
+	copy(out, p.steps)
+	return out
+}
+
+var (
+	mu       sync.Mutex
+	plan     *Plan
+	overlays map[string][]byte
+	removed  []string
+)
+
+// Enable turns file-write recording on and returns the fresh plan writes are
+// recorded into. Overlays and removals from any previous run are cleared.
+func Enable() *Plan {
+	mu.Lock()
+	defer mu.Unlock()
+	plan = NewPlan()
+	overlays = nil
+	removed = nil
+	return plan
+}
+
+// Disable turns file-write recording off and discards the overlays.
+func Disable() {
+	mu.Lock()
+	defer mu.Unlock()
+	plan = nil
+	overlays = nil
+	removed = nil
+}
+
+// Active reports whether file writes should be recorded instead of performed.
+func Active() bool {
+	mu.Lock()
+	defer mu.Unlock()
+	return plan != nil
+}
+
+// Record appends a step to the plan file writes are recorded into. It is a
+// no-op when file-write recording is off.
+func Record(kind Kind, target, detail string) {
+	mu.Lock()
+	p := plan
+	mu.Unlock()
+	if p != nil {
+		p.Record(kind, target, detail)
+	}
+}
+
+// RecordFileWrite records a write to path as a create or an overwrite,
+// depending on whether path already exists.
+func RecordFileWrite(path string, exists bool) {
+	if exists {
+		Record(KindOverwrite, path, "")
+		return
+	}
+	Record(KindCreate, path, "")
+}
+
+// SetOverlay stores the content a dry run would have written to path, so a
+// later read in the same run can observe it (e.g. global_config.yaml being
+// saved by one installer and loaded by the next). No-op when inactive.
+func SetOverlay(path string, data []byte) {
+	mu.Lock()
+	defer mu.Unlock()
+	if plan == nil {
+		return
+	}
+	if overlays == nil {
+		overlays = make(map[string][]byte)
+	}
+	overlays[path] = append([]byte(nil), data...)
+}
+
+// Overlay returns the content recorded for path by SetOverlay, if any.
+func Overlay(path string) ([]byte, bool) {
+	mu.Lock()
+	defer mu.Unlock()
+	data, ok := overlays[path]
+	return data, ok
+}
+
+// Overlays returns a copy of every path → content recorded by SetOverlay.
+func Overlays() map[string][]byte {
+	mu.Lock()
+	defer mu.Unlock()
+	out := make(map[string][]byte, len(overlays))
+	for p, data := range overlays {
+		out[p] = append([]byte(nil), data...)
+	}
+	return out
+}
+
+// SetRemoved records that path (a file or a whole tree) would be deleted and
+// drops any overlays beneath it, so a later SetOverlay re-creates only what
+// is written again. No-op when inactive.
+func SetRemoved(path string) {
+	mu.Lock()
+	defer mu.Unlock()
+	if plan == nil {
+		return
+	}
+	prefix := strings.TrimSuffix(path, string(filepath.Separator)) + string(filepath.Separator)
+	for p := range overlays {
+		if p == path || strings.HasPrefix(p, prefix) {
+			delete(overlays, p)
+		}
+	}
+	removed = append(removed, path)
+}
+
+// Removed returns the paths recorded by SetRemoved, in order.
+func Removed() []string {
+	mu.Lock()
+	defer mu.Unlock()
+	return append([]string(nil), removed...)
+}
+
+// Report writes the recorded plan to w, one step per line in execution
+// order. Detail lines are indented beneath their step.
+func (p *Plan) Report(w io.Writer) {
+	recorded := p.Steps()
+	if len(recorded) == 0 {
+		fmt.Fprintln(w, "Dry run: nothing to do.")
+		return
+	}
+	fmt.Fprintf(w, "Dry run: %d planned action(s), nothing was changed.\n\n", len(recorded))
+	width := 0
+	for _, s := range recorded {
+		width = max(width, len(s.Kind))
+	}
+	for _, s := range recorded {
+		fmt.Fprintf(w, "  %-*s  %s\n", width, s.Kind, s.Target)
+		if s.Detail == "" {
+			continue
+		}
+		for _, line := range strings.Split(strings.TrimRight(s.Detail, "\n"), "\n") {
+			fmt.Fprintf(w, "  %-*s    %s\n", width, "", line)
+		}
+	}
+}
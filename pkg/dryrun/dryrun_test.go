@@ -0,0 +1,126 @@
+package dryrun_test
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/cjairm/devgita/pkg/dryrun"
+)
+
+func TestRecord_NoOpWhenInactive(t *testing.T) {
+	dryrun.Disable()
+	dryrun.Record(dryrun.KindCreate, "/cfg.yaml", "")
+	if dryrun.Active() {
+		t.Fatal("expected file-write recording to stay off")
+	}
+}
+
+func TestEnable_StartsAFreshPlan(t *testing.T) {
+	first := dryrun.Enable()
+	t.Cleanup(dryrun.Disable)
+	dryrun.Record(dryrun.KindCreate, "/tmp/cfg.yaml", "")
+	dryrun.SetOverlay("/tmp/cfg.yaml", []byte("a: 1"))
+
+	second := dryrun.Enable()
+	if got := second.Steps(); len(got) != 0 {
+		t.Errorf("expected Enable to start an empty plan, got %v", got)
+	}
+	if got := first.Steps(); len(got) != 1 {
+		t.Errorf("expected the earlier plan to keep its step, got %v", got)
+	}
+	if _, ok := dryrun.Overlay("/tmp/cfg.yaml"); ok {
+		t.Error("expected Enable to clear overlays")
+	}
+}
+
+func TestRecordFileWrite(t *testing.T) {
+	plan := dryrun.Enable()
+	t.Cleanup(dryrun.Disable)
+
+	dryrun.RecordFileWrite("/new", false)
+	dryrun.RecordFileWrite("/existing", true)
+
+	want := []dryrun.Step{
+		{Kind: dryrun.KindCreate, Target: "/new"},
+		{Kind: dryrun.KindOverwrite, Target: "/existing"},
+	}
+	got := plan.Steps()
+	if len(got) != len(want) {
+		t.Fatalf("expected %d steps, got %d: %v", len(want), len(got), got)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("step %d: expected %+v, got %+v", i, want[i], got[i])
+		}
+	}
+}
+
+func TestOverlay_RoundTripsAndCopies(t *testing.T) {
+	dryrun.Enable()
+	t.Cleanup(dryrun.Disable)
+
+	data := []byte("shell:\n  tmux: true\n")
+	dryrun.SetOverlay("/cfg", data)
+	data[0] = 'X'
+
+	got, ok := dryrun.Overlay("/cfg")
+	if !ok {
+		t.Fatal("expected overlay to be set")
+	}
+	if string(got) != "shell:\n  tmux: true\n" {
+		t.Errorf("overlay should not alias the caller's slice, got %q", got)
+	}
+}
+
+func TestReport(t *testing.T) {
+	t.Run("empty plan", func(t *testing.T) {
+		var buf bytes.Buffer
+		dryrun.NewPlan().Report(&buf)
+		if !strings.Contains(buf.String(), "nothing to do") {
+			t.Errorf("unexpected report: %q", buf.String())
+		}
+	})
+
+	t.Run("steps in order with indented detail", func(t *testing.T) {
+		plan := dryrun.NewPlan()
+		plan.Record(dryrun.KindExec, "brew install tmux", "")
+		plan.Record(dryrun.KindConfig, "/cfg/global_config.yaml", "+ installed.packages: tmux\n- shell.tmux: false")
+
+		var buf bytes.Buffer
+		plan.Report(&buf)
+		out := buf.String()
+
+		if !strings.Contains(out, "2 planned action(s)") {
+			t.Errorf("expected step count in header, got %q", out)
+		}
+		runIdx := strings.Index(out, "run     brew install tmux")
+		cfgIdx := strings.Index(out, "config  /cfg/global_config.yaml")
+		if runIdx < 0 || cfgIdx < 0 || runIdx > cfgIdx {
+			t.Errorf("expected aligned steps in execution order, got:\n%s", out)
+		}
+		if !strings.Contains(out, "            + installed.packages: tmux\n") {
+			t.Errorf("expected detail lines indented under their step, got:\n%s", out)
+		}
+	})
+}
+
+func TestSetRemoved_DropsOverlaysUnderPath(t *testing.T) {
+	dryrun.Enable()
+	t.Cleanup(dryrun.Disable)
+
+	dryrun.SetOverlay("/cfg/skills/a.md", []byte("a"))
+	dryrun.SetOverlay("/cfg/settings.json", []byte("{}"))
+	dryrun.SetRemoved("/cfg/skills")
+
+	overlays := dryrun.Overlays()
+	if _, ok := overlays["/cfg/skills/a.md"]; ok {
+		t.Error("expected overlays under a removed path to be dropped")
+	}
+	if _, ok := overlays["/cfg/settings.json"]; !ok {
+		t.Error("expected unrelated overlays to survive")
+	}
+	if got := dryrun.Removed(); len(got) != 1 || got[0] != "/cfg/skills" {
+		t.Errorf("expected /cfg/skills recorded as removed, got %v", got)
+	}
+}
@@ -9,6 +9,7 @@ import (
 	"strings"
 	"text/template"
 
+	"github.com/cjairm/devgita/pkg/dryrun"
 	"github.com/cjairm/devgita/pkg/logger"
 )
 
@@ -56,10 +57,20 @@ func SoftCopyDir(src, dst string) error {
 // Returns an error if reading the source or writing the destination fails.
 func CopyFile(src, dst string) error {
 	logger.L().Debugw("Copying file", "src", src, "dst", dst)
+	if dryrun.Active() {
+		dryrun.RecordFileWrite(dst, FileAlreadyExist(dst))
+		if input, err := os.ReadFile(src); err == nil {
+			dryrun.SetOverlay(dst, input)
+		}
+		return nil
+	}
 	input, err := os.ReadFile(src)
 	if err != nil {
 		return fmt.Errorf("failed to read source file %s: %w", src, err)
 	}
+	if err := backupBeforeWrite(dst, input); err != nil {
+		return err
+	}
 	if err := os.WriteFile(dst, input, AllPermissions); err != nil {
 		return fmt.Errorf("failed to write destination file %s: %w", dst, err)
 	}
@@ -71,6 +82,11 @@ func CopyFile(src, dst string) error {
 // Returns an error if any directory or file operation fails.
 func CopyDir(src, dst string) error {
 	logger.L().Debugw("Copying directory", "src", src, "dst", dst)
+	if dryrun.Active() {
+		dryrun.RecordFileWrite(dst+string(filepath.Separator), DirAlreadyExist(dst))
+		overlayDir(src, dst)
+		return nil
+	}
 	entries, err := os.ReadDir(src)
 	if err != nil {
 		return fmt.Errorf("failed to read source directory %s: %w", src, err)
@@ -142,7 +158,18 @@ func UpdateFile(filePath, searchText, replacementText string) error {
 	if err != nil {
 		return fmt.Errorf("failed to read file %s: %w", filePath, err)
 	}
+	if overlay, ok := dryrun.Overlay(filePath); ok {
+		data = overlay
+	}
 	updatedContent := strings.ReplaceAll(string(data), searchText, replacementText)
+	if dryrun.Active() {
+		dryrun.Record(dryrun.KindOverwrite, filePath, "")
+		dryrun.SetOverlay(filePath, []byte(updatedContent))
+		return nil
+	}
+	if err := backupBeforeWrite(filePath, []byte(updatedContent)); err != nil {
+		return err
+	}
 	if err := os.WriteFile(filePath, []byte(updatedContent), FilePermission); err != nil {
 		return fmt.Errorf("failed to write updated content to file %s: %w", filePath, err)
 	}
@@ -182,6 +209,15 @@ func ContentExistsInFile(filePath, substringToFind string) (bool, error) {
 // Returns an error if opening the file or writing fails.
 func AddLineToFile(line, filePath string) error {
 	logger.L().Debugw("Adding line to file", "line", line, "filePath", filePath)
+	if dryrun.Active() {
+		dryrun.Record(dryrun.KindAppend, filePath, line)
+		current, ok := dryrun.Overlay(filePath)
+		if !ok {
+			current, _ = os.ReadFile(filePath)
+		}
+		dryrun.SetOverlay(filePath, append(append(current, '\n'), line...))
+		return nil
+	}
 	file, err := os.OpenFile(filePath, os.O_APPEND|os.O_CREATE|os.O_WRONLY, FilePermission)
 	if err != nil {
 		return fmt.Errorf("failed to open file %s for appending: %w", filePath, err)
@@ -210,6 +246,12 @@ func AddLineToFile(line, filePath string) error {
 //
 // Returns an error if template parsing, execution, or file writing fails.
 func GenerateFromTemplate(templatePath, outputPath string, data any) error {
+	// Under --dry-run the template may not be extracted yet (dg install on a
+	// fresh machine); the write is what matters to the plan.
+	if dryrun.Active() && !FileAlreadyExist(templatePath) {
+		dryrun.RecordFileWrite(outputPath, FileAlreadyExist(outputPath))
+		return nil
+	}
 	tmpl, err := template.ParseFiles(templatePath)
 	if err != nil {
 		return fmt.Errorf("failed to parse template %s: %w", templatePath, err)
@@ -233,6 +275,14 @@ func GenerateFromTemplate(templatePath, outputPath string, data any) error {
 // complete content or the new complete content is observed, never a partial mix.
 // The target directory is created if it does not already exist.
 func WriteFileAtomic(path string, data []byte, perm os.FileMode) error {
+	if dryrun.Active() {
+		dryrun.RecordFileWrite(path, FileAlreadyExist(path))
+		dryrun.SetOverlay(path, data)
+		return nil
+	}
+	if err := backupBeforeWrite(path, data); err != nil {
+		return err
+	}
 	dir := filepath.Dir(path)
 	if err := os.MkdirAll(dir, DirPermission); err != nil {
 		return fmt.Errorf("failed to create directory for %s: %w", path, err)
@@ -280,6 +330,79 @@ func WriteFileAtomic(path string, data []byte, perm os.FileMode) error {
 	return nil
 }
 
+// MkdirAll is os.MkdirAll that only records the directory under --dry-run.
+func MkdirAll(path string, perm os.FileMode) error {
+	if dryrun.Active() {
+		if !DirAlreadyExist(path) {
+			dryrun.Record(dryrun.KindCreate, path+string(filepath.Separator), "")
+		}
+		return nil
+	}
+	return os.MkdirAll(path, perm)
+}
+
+// Chmod is os.Chmod that is skipped under --dry-run (the file it targets was
+// typically only recorded, never written).
+func Chmod(path string, mode os.FileMode) error {
+	if dryrun.Active() {
+		return nil
+	}
+	return os.Chmod(path, mode)
+}
+
+// Remove is os.Remove that only records the removal under --dry-run. Paths
+// that do not exist are not recorded. Inside an overwrite-backup session the
+// file is saved first.
+func Remove(path string) error {
+	if dryrun.Active() {
+		if FileAlreadyExist(path) {
+			dryrun.Record(dryrun.KindRemove, path, "")
+		}
+		dryrun.SetRemoved(path)
+		return nil
+	}
+	if err := backupBeforeRemove(path); err != nil {
+		return err
+	}
+	return os.Remove(path)
+}
+
+// RemoveAll is os.RemoveAll that only records the removal under --dry-run.
+// Paths that do not exist are not recorded. Inside an overwrite-backup
+// session every file under path is saved first.
+func RemoveAll(path string) error {
+	if dryrun.Active() {
+		if FileAlreadyExist(path) {
+			dryrun.Record(dryrun.KindRemove, path, "")
+		}
+		dryrun.SetRemoved(path)
+		return nil
+	}
+	if err := backupBeforeRemove(path); err != nil {
+		return err
+	}
+	return os.RemoveAll(path)
+}
+
+// overlayDir records the content CopyDir would write for every file under
+// src, so a dry run can show exactly what would land in dst. Missing or
+// unreadable sources are skipped; the copy itself was already recorded.
+func overlayDir(src, dst string) {
+	_ = filepath.WalkDir(src, func(p string, d os.DirEntry, err error) error {
+		if err != nil || d.IsDir() {
+			return nil
+		}
+		rel, err := filepath.Rel(src, p)
+		if err != nil {
+			return nil
+		}
+		if data, err := os.ReadFile(p); err == nil {
+			dryrun.SetOverlay(filepath.Join(dst, rel), data)
+		}
+		return nil
+	})
+}
+
 // getEntryInfo retrieves file information for the given path.
 // Returns nil if the path doesn't exist or if an error occurs (e.g., permission denied).
 // This is a helper function used by FileAlreadyExist and DirAlreadyExist.
@@ -5,6 +5,7 @@ import (
 	"path/filepath"
 	"testing"
 
+	"github.com/cjairm/devgita/pkg/dryrun"
 	"github.com/cjairm/devgita/pkg/files"
 	"github.com/cjairm/devgita/pkg/logger"
 )
@@ -797,3 +798,61 @@ func TestWriteFileAtomic(t *testing.T) {
 		}
 	})
 }
+
+func TestDryRun_RecordsWritesWithoutTouchingDisk(t *testing.T) {
+	logger.Init(false)
+	tempDir := t.TempDir()
+	existing := filepath.Join(tempDir, "existing.txt")
+	if err := os.WriteFile(existing, []byte(fileContent), 0o644); err != nil {
+		t.Fatalf("Failed to seed file: %v", err)
+	}
+	missing := filepath.Join(tempDir, "missing.txt")
+	newDir := filepath.Join(tempDir, "newdir")
+
+	plan := dryrun.Enable()
+	t.Cleanup(dryrun.Disable)
+
+	if err := files.WriteFileAtomic(existing, []byte("changed"), 0o644); err != nil {
+		t.Fatalf("WriteFileAtomic: %v", err)
+	}
+	if err := files.CopyFile(existing, missing); err != nil {
+		t.Fatalf("CopyFile: %v", err)
+	}
+	if err := files.AddLineToFile("source ~/.devgita.zsh", existing); err != nil {
+		t.Fatalf("AddLineToFile: %v", err)
+	}
+	if err := files.MkdirAll(newDir, files.DirPermission); err != nil {
+		t.Fatalf("MkdirAll: %v", err)
+	}
+	if err := files.Remove(existing); err != nil {
+		t.Fatalf("Remove: %v", err)
+	}
+	if err := files.RemoveAll(filepath.Join(tempDir, "never-existed")); err != nil {
+		t.Fatalf("RemoveAll: %v", err)
+	}
+
+	got, err := os.ReadFile(existing)
+	if err != nil || string(got) != fileContent {
+		t.Errorf("Expected %s untouched, got %q (err %v)", existing, got, err)
+	}
+	if files.FileAlreadyExist(missing) || files.DirAlreadyExist(newDir) {
+		t.Error("Expected no files or directories to be created")
+	}
+
+	want := []dryrun.Step{
+		{Kind: dryrun.KindOverwrite, Target: existing},
+		{Kind: dryrun.KindCreate, Target: missing},
+		{Kind: dryrun.KindAppend, Target: existing, Detail: "source ~/.devgita.zsh"},
+		{Kind: dryrun.KindCreate, Target: newDir + string(filepath.Separator)},
+		{Kind: dryrun.KindRemove, Target: existing},
+	}
+	steps := plan.Steps()
+	if len(steps) != len(want) {
+		t.Fatalf("Expected %d recorded steps, got %d: %v", len(want), len(steps), steps)
+	}
+	for i := range want {
+		if steps[i] != want[i] {
+			t.Errorf("Step %d: expected %+v, got %+v", i, want[i], steps[i])
+		}
+	}
+}
@@ -0,0 +1,195 @@
+package files
+
+import (
+	"bytes"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/cjairm/devgita/pkg/logger"
+)
+
+// BackedUpFile pairs an overwritten or removed file with the copy of its
+// previous content.
+type BackedUpFile struct {
+	Path   string // the file that was overwritten or removed
+	Backup string // where its previous content was saved
+}
+
+// overwriteSession is the state behind BeginOverwriteBackups.
+type overwriteSession struct {
+	dir  string
+	base string
+	// saved maps an original path to its backup copy, so a file written twice
+	// in one session keeps its pre-session content.
+	saved map[string]string
+	// removed marks entries saved by Remove/RemoveAll; they are only kept if
+	// the path is not re-created with identical content by the end.
+	removed map[string]bool
+}
+
+var (
+	overwriteMu sync.Mutex
+	overwrite   *overwriteSession
+)
+
+// BeginOverwriteBackups starts saving a copy of every existing file that
+// CopyFile, UpdateFile, or WriteFileAtomic would replace with different
+// content, and of every file Remove or RemoveAll deletes, under dir. Copies
+// mirror the original path relative to base (or the absolute path when it
+// lies outside base). Call EndOverwriteBackups to finish the session.
+func BeginOverwriteBackups(dir, base string) {
+	overwriteMu.Lock()
+	defer overwriteMu.Unlock()
+	overwrite = &overwriteSession{
+		dir:     dir,
+		base:    base,
+		saved:   make(map[string]string),
+		removed: make(map[string]bool),
+	}
+}
+
+// EndOverwriteBackups stops the session and returns the files whose previous
+// content was saved, sorted by path. Files that were deleted and then
+// re-created with identical content (a sync that changed nothing) are dropped
+// from the backup, and dir is removed entirely when nothing needed saving.
+func EndOverwriteBackups() ([]BackedUpFile, error) {
+	overwriteMu.Lock()
+	s := overwrite
+	overwrite = nil
+	overwriteMu.Unlock()
+	if s == nil {
+		return nil, nil
+	}
+
+	var result []BackedUpFile
+	for original, backup := range s.saved {
+		if s.removed[original] && sameContent(original, backup) {
+			if err := os.Remove(backup); err != nil {
+				return nil, fmt.Errorf("failed to discard unchanged backup %s: %w", backup, err)
+			}
+			continue
+		}
+		result = append(result, BackedUpFile{Path: original, Backup: backup})
+	}
+	if err := removeEmptyDirs(s.dir); err != nil {
+		return nil, err
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i].Path < result[j].Path })
+	return result, nil
+}
+
+// backupBeforeWrite saves path's current content when it exists and differs
+// from next. A no-op outside a backup session.
+func backupBeforeWrite(path string, next []byte) error {
+	overwriteMu.Lock()
+	defer overwriteMu.Unlock()
+	if overwrite == nil {
+		return nil
+	}
+	current, err := os.ReadFile(path)
+	if err != nil || bytes.Equal(current, next) {
+		return nil
+	}
+	return overwrite.save(path)
+}
+
+// backupBeforeRemove saves every regular file under path before it is
+// deleted. A no-op outside a backup session.
+func backupBeforeRemove(path string) error {
+	overwriteMu.Lock()
+	defer overwriteMu.Unlock()
+	if overwrite == nil {
+		return nil
+	}
+	return filepath.WalkDir(path, func(p string, d fs.DirEntry, err error) error {
+		if err != nil {
+			if os.IsNotExist(err) {
+				return nil
+			}
+			return err
+		}
+		if !d.Type().IsRegular() {
+			return nil
+		}
+		if _, ok := overwrite.saved[p]; !ok {
+			overwrite.removed[p] = true
+		}
+		return overwrite.save(p)
+	})
+}
+
+// save copies path into the session dir unless it was already saved.
+func (s *overwriteSession) save(path string) error {
+	if _, ok := s.saved[path]; ok {
+		return nil
+	}
+	rel, err := filepath.Rel(s.base, path)
+	if err != nil || rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+		rel = strings.TrimPrefix(path, string(filepath.Separator))
+	}
+	dest := filepath.Join(s.dir, rel)
+	info, err := os.Stat(path)
+	if err != nil {
+		return fmt.Errorf("failed to back up %s: %w", path, err)
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("failed to back up %s: %w", path, err)
+	}
+	if err := os.MkdirAll(filepath.Dir(dest), DirPermission); err != nil {
+		return fmt.Errorf("failed to create backup directory for %s: %w", path, err)
+	}
+	if err := os.WriteFile(dest, data, info.Mode().Perm()); err != nil {
+		return fmt.Errorf("failed to back up %s: %w", path, err)
+	}
+	logger.L().Debugw("Backed up file before overwrite", "path", path, "backup", dest)
+	s.saved[path] = dest
+	return nil
+}
+
+func sameContent(a, b string) bool {
+	x, err := os.ReadFile(a)
+	if err != nil {
+		return false
+	}
+	y, err := os.ReadFile(b)
+	if err != nil {
+		return false
+	}
+	return bytes.Equal(x, y)
+}
+
+// removeEmptyDirs deletes dir and any directories under it left empty after
+// unchanged backups were discarded.
+func removeEmptyDirs(dir string) error {
+	var dirs []string
+	err := filepath.WalkDir(dir, func(p string, d fs.DirEntry, err error) error {
+		if err != nil {
+			if os.IsNotExist(err) {
+				return nil
+			}
+			return err
+		}
+		if d.IsDir() {
+			dirs = append(dirs, p)
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	// Deepest first, so parents are empty by the time they are checked.
+	for i := len(dirs) - 1; i >= 0; i-- {
+		if IsDirEmpty(dirs[i]) {
+			if err := os.Remove(dirs[i]); err != nil && !os.IsNotExist(err) {
+				return err
+			}
+		}
+	}
+	return nil
+}
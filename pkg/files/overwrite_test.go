@@ -0,0 +1,138 @@
+package files_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/cjairm/devgita/pkg/files"
+	"github.com/cjairm/devgita/pkg/logger"
+)
+
+func writeTestFile(t *testing.T, path, content string) {
+	t.Helper()
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func readTestFile(t *testing.T, path string) string {
+	t.Helper()
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("Failed to read %s: %v", path, err)
+	}
+	return string(data)
+}
+
+func TestOverwriteBackups(t *testing.T) {
+	logger.Init(false)
+	home := t.TempDir()
+	backupDir := filepath.Join(t.TempDir(), "overwritten", "1")
+	src := filepath.Join(t.TempDir(), "src")
+
+	edited := filepath.Join(home, ".config", "nvim", "init.lua")
+	unchanged := filepath.Join(home, ".config", "nvim", "lazy.lua")
+	fresh := filepath.Join(home, ".config", "nvim", "new.lua")
+	writeTestFile(t, edited, "-- my tweaks\n")
+	writeTestFile(t, unchanged, "return {}\n")
+	writeTestFile(t, filepath.Join(src, "init.lua"), "-- devgita default\n")
+	writeTestFile(t, filepath.Join(src, "lazy.lua"), "return {}\n")
+	writeTestFile(t, filepath.Join(src, "new.lua"), "-- new\n")
+
+	files.BeginOverwriteBackups(backupDir, home)
+	if err := files.CopyDir(src, filepath.Join(home, ".config", "nvim")); err != nil {
+		t.Fatalf("CopyDir: %v", err)
+	}
+	saved, err := files.EndOverwriteBackups()
+	if err != nil {
+		t.Fatalf("EndOverwriteBackups: %v", err)
+	}
+
+	if len(saved) != 1 || saved[0].Path != edited {
+		t.Fatalf("Expected only the differing file to be backed up, got %v", saved)
+	}
+	wantBackup := filepath.Join(backupDir, ".config", "nvim", "init.lua")
+	if saved[0].Backup != wantBackup {
+		t.Errorf("Expected backup at %s, got %s", wantBackup, saved[0].Backup)
+	}
+	if got := readTestFile(t, wantBackup); got != "-- my tweaks\n" {
+		t.Errorf("Expected backup to hold the previous content, got %q", got)
+	}
+	if got := readTestFile(t, edited); got != "-- devgita default\n" {
+		t.Errorf("Expected target to be overwritten, got %q", got)
+	}
+	if got := readTestFile(t, fresh); got != "-- new\n" {
+		t.Errorf("Expected new file to be written, got %q", got)
+	}
+}
+
+func TestOverwriteBackups_RemoveThenRecreate(t *testing.T) {
+	logger.Init(false)
+	home := t.TempDir()
+	backupDir := filepath.Join(t.TempDir(), "overwritten", "1")
+	skills := filepath.Join(home, ".claude", "skills")
+	same := filepath.Join(skills, "review.md")
+	changed := filepath.Join(skills, "mine.md")
+	writeTestFile(t, same, "upstream\n")
+	writeTestFile(t, changed, "local edit\n")
+
+	files.BeginOverwriteBackups(backupDir, home)
+	// A mirror sync: remove the subtree, then copy it back.
+	if err := files.RemoveAll(skills); err != nil {
+		t.Fatalf("RemoveAll: %v", err)
+	}
+	if err := files.WriteFileAtomic(same, []byte("upstream\n"), 0o644); err != nil {
+		t.Fatalf("WriteFileAtomic: %v", err)
+	}
+	saved, err := files.EndOverwriteBackups()
+	if err != nil {
+		t.Fatalf("EndOverwriteBackups: %v", err)
+	}
+
+	if len(saved) != 1 || saved[0].Path != changed {
+		t.Fatalf("Expected only the removed, locally-edited file to be kept, got %v", saved)
+	}
+	if _, err := os.Stat(filepath.Join(backupDir, ".claude", "skills", "review.md")); !os.IsNotExist(err) {
+		t.Error("Expected the unchanged re-created file's backup to be discarded")
+	}
+}
+
+func TestOverwriteBackups_NothingChangedLeavesNoDir(t *testing.T) {
+	logger.Init(false)
+	home := t.TempDir()
+	backupDir := filepath.Join(t.TempDir(), "overwritten", "1")
+	target := filepath.Join(home, ".gitconfig")
+	writeTestFile(t, target, "[user]\n")
+
+	files.BeginOverwriteBackups(backupDir, home)
+	if err := files.WriteFileAtomic(target, []byte("[user]\n"), 0o644); err != nil {
+		t.Fatalf("WriteFileAtomic: %v", err)
+	}
+	saved, err := files.EndOverwriteBackups()
+	if err != nil {
+		t.Fatalf("EndOverwriteBackups: %v", err)
+	}
+	if len(saved) != 0 {
+		t.Errorf("Expected nothing backed up, got %v", saved)
+	}
+	if _, err := os.Stat(backupDir); !os.IsNotExist(err) {
+		t.Errorf("Expected no backup directory, stat err = %v", err)
+	}
+}
+
+func TestOverwriteBackups_InactiveByDefault(t *testing.T) {
+	logger.Init(false)
+	target := filepath.Join(t.TempDir(), "f")
+	writeTestFile(t, target, "old")
+	if err := files.WriteFileAtomic(target, []byte("new"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	saved, err := files.EndOverwriteBackups()
+	if err != nil || saved != nil {
+		t.Errorf("Expected no session, got %v, %v", saved, err)
+	}
+}
@@ -1,13 +1,28 @@
 package utils
 
 import (
+	"errors"
 	"os"
 )
 
+// ExitError asks MaybeExitWithError for a specific exit status instead of
+// the default 1, for commands whose callers branch on the code.
+type ExitError struct {
+	Code    int
+	Message string
+}
+
+func (e *ExitError) Error() string { return e.Message }
+
 func MaybeExitWithError(err error) {
 	if err == nil {
 		return
 	}
+	code := 1
+	var exitErr *ExitError
+	if errors.As(err, &exitErr) {
+		code = exitErr.Code
+	}
 	PrintError(err.Error())
-	os.Exit(1)
+	os.Exit(code)
 }